@@ -296,7 +296,11 @@ func (c cBuild) getBuildInVarStr(ctx context.Context, in cBuildInput) string {
 		buildInVarMap = make(g.Map)
 	}
 	buildInVarMap["builtGit"] = c.getGitCommit(ctx)
+	buildInVarMap["builtGitSha"], buildInVarMap["builtGitDirty"] = c.getGitShaAndDirty(ctx)
 	buildInVarMap["builtTime"] = gtime.Now().String()
+	if in.Version != "" {
+		buildInVarMap["builtVersion"] = in.Version
+	}
 	b, err := json.Marshal(buildInVarMap)
 	if err != nil {
 		mlog.Fatal(err)
@@ -321,3 +325,21 @@ func (c cBuild) getGitCommit(ctx context.Context) string {
 	}
 	return ""
 }
+
+// getGitShaAndDirty retrieves and returns the latest git commit hash and whether the working tree
+// has uncommitted changes if present.
+func (c cBuild) getGitShaAndDirty(ctx context.Context) (sha string, dirty bool) {
+	if gproc.SearchBinary("git") == "" {
+		return "", false
+	}
+	s, err := gproc.ShellExec(ctx, `git rev-parse HEAD`)
+	if err != nil || gstr.Contains(s, "fatal") {
+		return "", false
+	}
+	sha = gstr.Trim(s)
+	s, err = gproc.ShellExec(ctx, `git status --porcelain`)
+	if err == nil {
+		dirty = gstr.Trim(s) != ""
+	}
+	return
+}
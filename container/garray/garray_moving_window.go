@@ -0,0 +1,170 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package garray
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gogf/gf/v2/internal/rwmutex"
+)
+
+// MovingWindow is a fixed size float64 array for streaming statistics, which keeps the latest appended values
+// and drops the oldest ones if it is full. It is useful for lightweight in-process metrics, like the average
+// latency of the latest 1000 requests.
+//
+// Appending values and calculating the sum, mean and standard deviation are O(1) amortized.
+// It contains a concurrent-safe/unsafe switch, which should be set
+// when its initialization and cannot be changed then.
+type MovingWindow struct {
+	mu     rwmutex.RWMutex
+	values []float64 // Ring buffer of values.
+	head   int       // Index of the oldest value in ring buffer.
+	length int       // Count of values in ring buffer.
+	total  int64     // Total count of values ever appended.
+	sum    float64   // Sum of values in ring buffer.
+	sumSq  float64   // Sum of squares of values in ring buffer.
+}
+
+// NewMovingWindow creates and returns an empty moving window keeping the latest `size` values,
+// in which `size` less than 1 is treated as 1.
+// The parameter `safe` is used to specify whether using window in concurrent-safety,
+// which is false in default.
+func NewMovingWindow(size int, safe ...bool) *MovingWindow {
+	if size < 1 {
+		size = 1
+	}
+	return &MovingWindow{
+		mu:     rwmutex.Create(safe...),
+		values: make([]float64, size),
+	}
+}
+
+// Append appends `values` to the window, the oldest values are dropped if the window is full.
+func (w *MovingWindow) Append(values ...float64) *MovingWindow {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	size := len(w.values)
+	for _, v := range values {
+		index := (w.head + w.length) % size
+		if w.length == size {
+			old := w.values[w.head]
+			w.sum -= old
+			w.sumSq -= old * old
+			w.head = (w.head + 1) % size
+		} else {
+			w.length++
+		}
+		w.values[index] = v
+		w.sum += v
+		w.sumSq += v * v
+		w.total++
+		// It recalculates the sums every cycle of ring buffer,
+		// to avoid the accumulation of float rounding errors.
+		if w.length == size && w.head == 0 {
+			w.sum, w.sumSq = 0, 0
+			for _, value := range w.values {
+				w.sum += value
+				w.sumSq += value * value
+			}
+		}
+	}
+	return w
+}
+
+// Size returns the max count of values that the window keeps.
+func (w *MovingWindow) Size() int {
+	return len(w.values)
+}
+
+// Len returns the count of values in the window.
+func (w *MovingWindow) Len() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.length
+}
+
+// Total returns the total count of values ever appended, including the dropped ones.
+func (w *MovingWindow) Total() int64 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.total
+}
+
+// Sum returns the sum of values in the window.
+func (w *MovingWindow) Sum() float64 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.sum
+}
+
+// Mean returns the arithmetic mean of values in the window, or 0 if the window is empty.
+func (w *MovingWindow) Mean() float64 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.length == 0 {
+		return 0
+	}
+	return w.sum / float64(w.length)
+}
+
+// StdDev returns the population standard deviation of values in the window, or 0 if the window is empty.
+func (w *MovingWindow) StdDev() float64 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.length == 0 {
+		return 0
+	}
+	mean := w.sum / float64(w.length)
+	variance := w.sumSq/float64(w.length) - mean*mean
+	if variance <= 0 {
+		return 0
+	}
+	return math.Sqrt(variance)
+}
+
+// Percentile returns the `p`th percentile of values in the window, in which `p` is in range [0, 100].
+// The result is linearly interpolated between the closest ranks, and it returns 0 if the window is empty.
+//
+// Note that it sorts a copy of values, which is O(n*log(n)).
+func (w *MovingWindow) Percentile(p float64) float64 {
+	values := w.Slice()
+	sort.Float64s(values)
+	return statsPercentile(values, p)
+}
+
+// Slice returns a copy of values in the window, from the oldest to the latest.
+func (w *MovingWindow) Slice() []float64 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	values := make([]float64, w.length)
+	for i := 0; i < w.length; i++ {
+		values[i] = w.values[(w.head+i)%len(w.values)]
+	}
+	return values
+}
+
+// Iterator iterates values in the window readonly from the oldest to the latest
+// with given callback function `f`. If `f` returns true, then it continues iterating; or false to stop.
+func (w *MovingWindow) Iterator(f func(k int, v float64) bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for i := 0; i < w.length; i++ {
+		if !f(i, w.values[(w.head+i)%len(w.values)]) {
+			break
+		}
+	}
+}
+
+// Clear deletes all values in the window, but keeps the total count.
+func (w *MovingWindow) Clear() *MovingWindow {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.head, w.length = 0, 0
+	w.sum, w.sumSq = 0, 0
+	return w
+}
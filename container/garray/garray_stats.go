@@ -0,0 +1,177 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package garray
+
+import (
+	"math"
+	"sort"
+)
+
+// Mean returns the arithmetic mean of values in an array, or 0 if the array is empty.
+func (a *IntArray) Mean() float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return statsMean(intsToFloats(a.array))
+}
+
+// StdDev returns the population standard deviation of values in an array, or 0 if the array is empty.
+func (a *IntArray) StdDev() float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return statsStdDev(intsToFloats(a.array))
+}
+
+// Percentile returns the `p`th percentile of values in an array, in which `p` is in range [0, 100],
+// eg: Percentile(50) returns the median, and Percentile(99) returns the P99 value.
+// The result is linearly interpolated between the closest ranks, and it returns 0 if the array is empty.
+//
+// Note that it sorts a copy of the array, use SortedIntArray for frequent percentile calculations.
+func (a *IntArray) Percentile(p float64) float64 {
+	a.mu.RLock()
+	values := intsToFloats(a.array)
+	a.mu.RUnlock()
+	sort.Float64s(values)
+	return statsPercentile(values, p)
+}
+
+// MovingAverage returns the averages of every `window` consecutive values in an array,
+// from the beginning to the end. It returns an empty slice if `window` is less than 1
+// or greater than the length of the array.
+func (a *IntArray) MovingAverage(window int) []float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return statsMovingAverage(intsToFloats(a.array), window)
+}
+
+// IteratorWindow iterates the sliding windows of `size` consecutive values in ascending order
+// readonly with given callback function `f`, in which `k` is the index of the first value of `window`.
+// If `f` returns true, then it continues iterating; or false to stop.
+//
+// Note that `window` is part of the underlying data of array, DO NOT modify or retain it.
+func (a *IntArray) IteratorWindow(size int, f func(k int, window []int) bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if size < 1 {
+		return
+	}
+	for i := 0; i+size <= len(a.array); i++ {
+		if !f(i, a.array[i:i+size]) {
+			break
+		}
+	}
+}
+
+// Mean returns the arithmetic mean of values in an array, or 0 if the array is empty.
+func (a *SortedIntArray) Mean() float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return statsMean(intsToFloats(a.array))
+}
+
+// StdDev returns the population standard deviation of values in an array, or 0 if the array is empty.
+func (a *SortedIntArray) StdDev() float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return statsStdDev(intsToFloats(a.array))
+}
+
+// Percentile returns the `p`th percentile of values in an array, in which `p` is in range [0, 100],
+// eg: Percentile(50) returns the median, and Percentile(99) returns the P99 value.
+// The result is linearly interpolated between the closest ranks, and it returns 0 if the array is empty.
+//
+// Note that the array should be sorted in increasing order by its comparator.
+func (a *SortedIntArray) Percentile(p float64) float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	length := len(a.array)
+	if length == 0 {
+		return 0
+	}
+	lower, upper, weight := percentileRank(length, p)
+	return float64(a.array[lower]) + (float64(a.array[upper])-float64(a.array[lower]))*weight
+}
+
+// intsToFloats converts and returns `values` as float64 slice.
+func intsToFloats(values []int) []float64 {
+	floats := make([]float64, len(values))
+	for i, v := range values {
+		floats[i] = float64(v)
+	}
+	return floats
+}
+
+// statsMean returns the arithmetic mean of `values`.
+func statsMean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// statsStdDev returns the population standard deviation of `values`.
+func statsStdDev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var (
+		mean = statsMean(values)
+		sum  float64
+	)
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
+
+// statsPercentile returns the `p`th percentile of `sorted`, which should be sorted in increasing order.
+func statsPercentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	lower, upper, weight := percentileRank(len(sorted), p)
+	return sorted[lower] + (sorted[upper]-sorted[lower])*weight
+}
+
+// percentileRank returns the closest ranks and the interpolation weight of the `p`th percentile
+// for sorted values of `length`.
+func percentileRank(length int, p float64) (lower, upper int, weight float64) {
+	if p <= 0 || math.IsNaN(p) {
+		return 0, 0, 0
+	}
+	if p >= 100 {
+		return length - 1, length - 1, 0
+	}
+	rank := p / 100 * float64(length-1)
+	lower = int(math.Floor(rank))
+	upper = int(math.Ceil(rank))
+	return lower, upper, rank - float64(lower)
+}
+
+// statsMovingAverage returns the averages of every `window` consecutive values of `values`.
+func statsMovingAverage(values []float64, window int) []float64 {
+	if window < 1 || window > len(values) {
+		return []float64{}
+	}
+	var (
+		sum      float64
+		averages = make([]float64, 0, len(values)-window+1)
+	)
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+		if i >= window-1 {
+			averages = append(averages, sum/float64(window))
+		}
+	}
+	return averages
+}
@@ -0,0 +1,119 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go
+
+package garray_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_IntArray_Stats(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		a := garray.NewIntArrayFrom([]int{9, 2, 4, 4, 4, 5, 5, 7})
+		t.Assert(a.Mean(), 5)
+		t.Assert(a.StdDev(), 2)
+		t.Assert(a.Percentile(0), 2)
+		t.Assert(a.Percentile(50), 4.5)
+		t.Assert(a.Percentile(100), 9)
+		t.Assert(a.Percentile(200), 9)
+		t.Assert(a.Percentile(-1), 2)
+		t.Assert(math.Abs(a.Percentile(90)-7.6) < 1e-9, true)
+		// The array is not changed.
+		t.Assert(a.Slice(), []int{9, 2, 4, 4, 4, 5, 5, 7})
+
+		t.Assert(a.MovingAverage(4), []float64{4.75, 3.5, 4.25, 4.5, 5.25})
+		t.Assert(a.MovingAverage(8), []float64{5})
+		t.Assert(a.MovingAverage(9), []float64{})
+		t.Assert(a.MovingAverage(0), []float64{})
+
+		windows := make([][]int, 0)
+		a.IteratorWindow(6, func(k int, window []int) bool {
+			windows = append(windows, append([]int{k}, window...))
+			return true
+		})
+		t.Assert(windows, [][]int{{0, 9, 2, 4, 4, 4, 5}, {1, 2, 4, 4, 4, 5, 5}, {2, 4, 4, 4, 5, 5, 7}})
+		count := 0
+		a.IteratorWindow(1, func(k int, window []int) bool {
+			count++
+			return k < 2
+		})
+		t.Assert(count, 3)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		a := garray.NewIntArray()
+		t.Assert(a.Mean(), 0)
+		t.Assert(a.StdDev(), 0)
+		t.Assert(a.Percentile(50), 0)
+	})
+}
+
+func Test_SortedIntArray_Stats(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		a := garray.NewSortedIntArrayFrom([]int{9, 2, 4, 4, 4, 5, 5, 7})
+		t.Assert(a.Mean(), 5)
+		t.Assert(a.StdDev(), 2)
+		t.Assert(a.Percentile(50), 4.5)
+		t.Assert(a.Percentile(100), 9)
+		t.Assert(math.Abs(a.Percentile(90)-7.6) < 1e-9, true)
+		t.Assert(garray.NewSortedIntArray().Percentile(50), 0)
+	})
+}
+
+func Test_MovingWindow(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		w := garray.NewMovingWindow(4, true)
+		t.Assert(w.Size(), 4)
+		t.Assert(w.Mean(), 0)
+		t.Assert(w.StdDev(), 0)
+		t.Assert(w.Percentile(50), 0)
+
+		w.Append(1, 2, 3)
+		t.Assert(w.Len(), 3)
+		t.Assert(w.Sum(), 6)
+		t.Assert(w.Mean(), 2)
+		t.Assert(w.Slice(), []float64{1, 2, 3})
+
+		w.Append(4, 5, 6)
+		t.Assert(w.Len(), 4)
+		t.Assert(w.Total(), 6)
+		t.Assert(w.Slice(), []float64{3, 4, 5, 6})
+		t.Assert(w.Sum(), 18)
+		t.Assert(w.Mean(), 4.5)
+		t.Assert(math.Abs(w.StdDev()-math.Sqrt(1.25)) < 1e-9, true)
+		t.Assert(w.Percentile(50), 4.5)
+		t.Assert(w.Percentile(100), 6)
+
+		values := make([]float64, 0)
+		w.Iterator(func(k int, v float64) bool {
+			values = append(values, v)
+			return k < 1
+		})
+		t.Assert(values, []float64{3, 4})
+
+		w.Clear()
+		t.Assert(w.Len(), 0)
+		t.Assert(w.Sum(), 0)
+		t.Assert(w.Total(), 6)
+		w.Append(7)
+		t.Assert(w.Slice(), []float64{7})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		w := garray.NewMovingWindow(0)
+		t.Assert(w.Size(), 1)
+		for i := 0; i < 1000; i++ {
+			w.Append(0.1 * float64(i))
+		}
+		t.Assert(w.Len(), 1)
+		t.Assert(math.Abs(w.Mean()-99.9) < 1e-9, true)
+		t.Assert(w.StdDev(), 0)
+	})
+}
@@ -0,0 +1,203 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gbloom provides concurrent-safe Bloom filter, which is a probabilistic container
+// testing whether a value is in a set, with false positives but no false negatives.
+package gbloom
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"sync/atomic"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Filter is the concurrent-safe Bloom filter, which is lock-free using atomic operations.
+type Filter struct {
+	words  []uint64 // Bit set of the filter.
+	bits   uint64   // Count of bits, which is multiple of 64.
+	hashes uint64   // Count of hash functions.
+}
+
+const (
+	defaultFalsePositiveRate = 0.01
+)
+
+// New creates and returns a Bloom filter, which is sized for `n` values with the false positive rate `fpr`.
+// The `fpr` should be in range (0, 1), which is 0.01 in default if it is out of range.
+//
+// Example:
+// filter := gbloom.New(1000000, 0.001)
+func New(n uint, fpr float64) *Filter {
+	if n == 0 {
+		n = 1
+	}
+	if fpr <= 0 || fpr >= 1 {
+		fpr = defaultFalsePositiveRate
+	}
+	var (
+		m = math.Ceil(-float64(n) * math.Log(fpr) / (math.Ln2 * math.Ln2))
+		k = math.Round(m / float64(n) * math.Ln2)
+	)
+	return NewWithSize(uint(m), uint(k))
+}
+
+// NewWithSize creates and returns a Bloom filter with `m` bits and `k` hash functions.
+// The `m` is rounded up to multiple of 64, and both of them are at least 1.
+func NewWithSize(m uint, k uint) *Filter {
+	if m == 0 {
+		m = 1
+	}
+	if k == 0 {
+		k = 1
+	}
+	words := (uint64(m) + 63) / 64
+	return &Filter{
+		words:  make([]uint64, words),
+		bits:   words * 64,
+		hashes: uint64(k),
+	}
+}
+
+// Add adds `value` to the filter, which is converted to bytes for hashing.
+// It returns true if the `value` is definitely not in the filter before adding,
+// or else false if it might be added before, which is usually used for deduplication.
+func (f *Filter) Add(value interface{}) bool {
+	var (
+		added  bool
+		h1, h2 = hashValue(value)
+	)
+	for i := uint64(0); i < f.hashes; i++ {
+		if f.setBit(f.location(h1, h2, i)) {
+			added = true
+		}
+	}
+	return added
+}
+
+// Contains checks whether `value` might be in the filter.
+// It returns false if the `value` is definitely not in the filter.
+func (f *Filter) Contains(value interface{}) bool {
+	h1, h2 := hashValue(value)
+	for i := uint64(0); i < f.hashes; i++ {
+		if !f.getBit(f.location(h1, h2, i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the estimated count of distinct values added to the filter.
+func (f *Filter) Count() uint {
+	var ones uint64
+	for i := range f.words {
+		ones += uint64(bits.OnesCount64(atomic.LoadUint64(&f.words[i])))
+	}
+	if ones >= f.bits {
+		ones = f.bits - 1
+	}
+	m, k := float64(f.bits), float64(f.hashes)
+	return uint(math.Round(-m / k * math.Log(1-float64(ones)/m)))
+}
+
+// Bits returns the count of bits of the filter.
+func (f *Filter) Bits() uint {
+	return uint(f.bits)
+}
+
+// Hashes returns the count of hash functions of the filter.
+func (f *Filter) Hashes() uint {
+	return uint(f.hashes)
+}
+
+// FalsePositiveRate returns the estimated false positive rate of the filter for its current count of values.
+func (f *Filter) FalsePositiveRate() float64 {
+	var (
+		n = float64(f.Count())
+		m = float64(f.bits)
+		k = float64(f.hashes)
+	)
+	return math.Pow(1-math.Exp(-k*n/m), k)
+}
+
+// Merge merges the values of `other` filter into current filter, which makes current filter the union
+// of them. The filters should be created with the same count of bits and hash functions.
+func (f *Filter) Merge(other *Filter) error {
+	if other.bits != f.bits || other.hashes != f.hashes {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`cannot merge filter of %d bits and %d hashes into filter of %d bits and %d hashes`,
+			other.bits, other.hashes, f.bits, f.hashes,
+		)
+	}
+	for i := range f.words {
+		if word := atomic.LoadUint64(&other.words[i]); word != 0 {
+			f.orWord(i, word)
+		}
+	}
+	return nil
+}
+
+// Clear removes all values of the filter.
+func (f *Filter) Clear() {
+	for i := range f.words {
+		atomic.StoreUint64(&f.words[i], 0)
+	}
+}
+
+// location returns the bit location of the `i`th hash function using double hashing.
+func (f *Filter) location(h1, h2, i uint64) uint64 {
+	return (h1 + i*h2) % f.bits
+}
+
+// setBit sets the bit at `location`, and returns true if the bit is not set before.
+func (f *Filter) setBit(location uint64) bool {
+	return f.orWord(int(location/64), 1<<(location%64))
+}
+
+// getBit checks whether the bit at `location` is set.
+func (f *Filter) getBit(location uint64) bool {
+	return atomic.LoadUint64(&f.words[location/64])&(1<<(location%64)) != 0
+}
+
+// orWord sets the bits `mask` of word at `index`, and returns true if any bit of `mask` is not set before.
+func (f *Filter) orWord(index int, mask uint64) bool {
+	for {
+		old := atomic.LoadUint64(&f.words[index])
+		if old&mask == mask {
+			return false
+		}
+		if atomic.CompareAndSwapUint64(&f.words[index], old, old|mask) {
+			return true
+		}
+	}
+}
+
+// hashValue returns the two hash values of `value` for double hashing.
+func hashValue(value interface{}) (h1, h2 uint64) {
+	hash := fnv.New128a()
+	_, _ = hash.Write(gconv.Bytes(value))
+	sum := hash.Sum(nil)
+	h1 = mix(binary.BigEndian.Uint64(sum[:8]))
+	// The second hash value is odd, so that it is never zero.
+	h2 = mix(binary.BigEndian.Uint64(sum[8:])) | 1
+	return
+}
+
+// mix finalizes hash value `h` with the mixer of MurmurHash3, so that all the bits are well distributed.
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb3fe1a85ec53
+	h ^= h >> 33
+	return h
+}
@@ -0,0 +1,96 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gbloom_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/gbloom"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Filter_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		filter := gbloom.New(1000, 0.01)
+		t.Assert(filter.Bits()%64, 0)
+		t.Assert(filter.Hashes(), 7)
+		t.Assert(filter.Add("a"), true)
+		t.Assert(filter.Add("a"), false)
+		t.Assert(filter.Add(1), true)
+		t.Assert(filter.Contains("a"), true)
+		t.Assert(filter.Contains(1), true)
+		t.Assert(filter.Contains("b"), false)
+		t.Assert(filter.Count(), 2)
+
+		filter.Clear()
+		t.Assert(filter.Contains("a"), false)
+		t.Assert(filter.Count(), 0)
+	})
+}
+
+func Test_Filter_FalsePositiveRate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			n      = 10000
+			filter = gbloom.New(uint(n), 0.01)
+		)
+		for i := 0; i < n; i++ {
+			filter.Add(i)
+		}
+		for i := 0; i < n; i++ {
+			t.Assert(filter.Contains(i), true)
+		}
+		falsePositives := 0
+		for i := n; i < n*2; i++ {
+			if filter.Contains(i) {
+				falsePositives++
+			}
+		}
+		t.AssertLT(float64(falsePositives)/float64(n), 0.02)
+		t.AssertLT(filter.FalsePositiveRate(), 0.02)
+		t.AssertGT(filter.Count(), uint(n*95/100))
+		t.AssertLT(filter.Count(), uint(n*105/100))
+	})
+}
+
+func Test_Filter_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg     sync.WaitGroup
+			filter = gbloom.NewWithSize(1<<16, 4)
+		)
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					filter.Add(g*1000 + i)
+				}
+			}(g)
+		}
+		wg.Wait()
+		for i := 0; i < 8000; i++ {
+			t.Assert(filter.Contains(i), true)
+		}
+	})
+}
+
+func Test_Filter_Merge(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			filter1 = gbloom.New(100, 0.01)
+			filter2 = gbloom.New(100, 0.01)
+		)
+		filter1.Add("a")
+		filter2.Add("b")
+		t.AssertNil(filter1.Merge(filter2))
+		t.Assert(filter1.Contains("a"), true)
+		t.Assert(filter1.Contains("b"), true)
+		t.AssertNE(filter1.Merge(gbloom.New(1000, 0.01)), nil)
+	})
+}
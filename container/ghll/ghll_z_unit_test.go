@@ -0,0 +1,86 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghll_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/ghll"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_HyperLogLog_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		h := ghll.New()
+		t.Assert(h.Precision(), ghll.DefaultPrecision)
+		t.Assert(h.Count(), 0)
+		t.Assert(h.Add("a"), true)
+		t.Assert(h.Add("a"), false)
+		h.Add("b")
+		h.Add(1)
+		t.Assert(h.Count(), 3)
+		h.Clear()
+		t.Assert(h.Count(), 0)
+
+		t.Assert(ghll.New(1).Precision(), ghll.MinPrecision)
+		t.Assert(ghll.New(100).Precision(), ghll.MaxPrecision)
+	})
+}
+
+func Test_HyperLogLog_Count(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		for _, n := range []int{100, 10000, 1000000} {
+			h := ghll.New()
+			for i := 0; i < n; i++ {
+				h.Add(i)
+				h.Add(i)
+			}
+			deviation := math.Abs(float64(h.Count())-float64(n)) / float64(n)
+			t.AssertLT(deviation, 0.03)
+		}
+	})
+}
+
+func Test_HyperLogLog_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg sync.WaitGroup
+			h  = ghll.New()
+		)
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 10000; i++ {
+					h.Add(g*10000 + i)
+				}
+			}(g)
+		}
+		wg.Wait()
+		deviation := math.Abs(float64(h.Count())-80000) / 80000
+		t.AssertLT(deviation, 0.03)
+	})
+}
+
+func Test_HyperLogLog_Merge(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			h1 = ghll.New()
+			h2 = ghll.New()
+		)
+		for i := 0; i < 1000; i++ {
+			h1.Add(i)
+			h2.Add(i + 500)
+		}
+		t.AssertNil(h1.Merge(h2))
+		deviation := math.Abs(float64(h1.Count())-1500) / 1500
+		t.AssertLT(deviation, 0.03)
+		t.AssertNE(h1.Merge(ghll.New(10)), nil)
+	})
+}
@@ -11,10 +11,12 @@ package glist
 import (
 	"bytes"
 	"container/list"
+	"encoding/gob"
 
 	"github.com/gogf/gf/v2/internal/deepcopy"
 	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/internal/rwmutex"
+	"github.com/gogf/gf/v2/internal/typehint"
 	"github.com/gogf/gf/v2/util/gconv"
 )
 
@@ -22,13 +24,23 @@ type (
 	// List is a doubly linked list containing a concurrent-safe/unsafe switch.
 	// The switch should be set when its initialization and cannot be changed then.
 	List struct {
-		mu   rwmutex.RWMutex
-		list *list.List
+		mu       rwmutex.RWMutex
+		list     *list.List
+		typeHint bool // Whether marshaling JSON with type hints, see SetTypeHint.
 	}
 	// Element the item type of the list.
 	Element = list.Element
 )
 
+func init() {
+	gob.Register(&List{})
+	typehint.Register(&List{}, func() interface{} {
+		l := New(true)
+		l.SetTypeHint(true)
+		return l
+	})
+}
+
 // New creates and returns a new empty doubly linked list.
 func New(safe ...bool) *List {
 	return &List{
@@ -510,26 +522,99 @@ func (l *List) String() string {
 	return "[" + l.Join(",") + "]"
 }
 
+// SetTypeHint enables or disables marshaling JSON with type hints, which keeps the Go types of values,
+// like int64 and nested containers, through JSON marshaling and unmarshaling.
+// The values with type hints are restored by UnmarshalJSON no matter it is enabled or not.
+func (l *List) SetTypeHint(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.typeHint = enabled
+}
+
 // MarshalJSON implements the interface MarshalJSON for json.Marshal.
 func (l List) MarshalJSON() ([]byte, error) {
+	if l.typeHint {
+		return l.MarshalJSONWithTypeHint()
+	}
 	return json.Marshal(l.FrontAll())
 }
 
+// MarshalJSONWithTypeHint marshals the list to JSON with type hints of values, see SetTypeHint.
+func (l *List) MarshalJSONWithTypeHint() ([]byte, error) {
+	array, err := typehint.Encode(l.FrontAll())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(array)
+}
+
 // UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
 func (l *List) UnmarshalJSON(b []byte) error {
+	var array []interface{}
+	if typehint.Contains(b) {
+		v, err := typehint.Decode(b)
+		if err != nil {
+			return err
+		}
+		array = gconv.Interfaces(v)
+	} else if err := json.UnmarshalUseNumber(b, &array); err != nil {
+		return err
+	}
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	if l.list == nil {
 		l.list = list.New()
 	}
-	var array []interface{}
-	if err := json.UnmarshalUseNumber(b, &array); err != nil {
+	for _, v := range array {
+		l.list.PushBack(v)
+	}
+	return nil
+}
+
+// GobEncode implements the interface GobEncoder for gob encoding.
+// Note that the custom types of values should be registered using gob.Register,
+// and the List value should be encoded by pointer, like the pointer of the struct holding it.
+func (l *List) GobEncode() ([]byte, error) {
+	var (
+		buffer = bytes.NewBuffer(nil)
+		data   = listGobData{
+			Safe:   l.mu.IsSafe(),
+			Values: l.FrontAll(),
+		}
+	)
+	if err := gob.NewEncoder(buffer).Encode(data); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// GobDecode implements the interface GobDecoder for gob decoding.
+// The decoded list is concurrent-safe if the encoded one is.
+func (l *List) GobDecode(b []byte) error {
+	var data listGobData
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&data); err != nil {
 		return err
 	}
-	l.PushBacks(array)
+	if data.Safe && !l.mu.IsSafe() {
+		l.mu = rwmutex.Create(true)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.list == nil {
+		l.list = list.New()
+	}
+	for _, v := range data.Values {
+		l.list.PushBack(v)
+	}
 	return nil
 }
 
+// listGobData is the gob encoding data of List.
+type listGobData struct {
+	Safe   bool
+	Values []interface{}
+}
+
 // UnmarshalValue is an interface implement which sets any type of value for list.
 func (l *List) UnmarshalValue(value interface{}) (err error) {
 	l.mu.Lock()
@@ -544,7 +629,9 @@ func (l *List) UnmarshalValue(value interface{}) (err error) {
 	default:
 		array = gconv.SliceAny(value)
 	}
-	l.PushBacks(array)
+	for _, v := range array {
+		l.list.PushBack(v)
+	}
 	return err
 }
 
@@ -7,7 +7,9 @@
 package glist
 
 import (
+	"bytes"
 	"container/list"
+	"encoding/gob"
 	"testing"
 
 	"github.com/gogf/gf/v2/internal/json"
@@ -765,3 +767,52 @@ func TestList_DeepCopy(t *testing.T) {
 		t.AssertNE(l.Size(), cl.Size())
 	})
 }
+
+func TestList_Gob(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			l       = NewFrom([]interface{}{1, "a", int64(2)}, true)
+			buffer  = bytes.NewBuffer(nil)
+			decoded List
+		)
+		t.AssertNil(gob.NewEncoder(buffer).Encode(l))
+		t.AssertNil(gob.NewDecoder(buffer).Decode(&decoded))
+		t.Assert(decoded.mu.IsSafe(), true)
+		t.AssertEQ(decoded.FrontAll(), []interface{}{1, "a", int64(2)})
+	})
+	// List as struct field value.
+	gtest.C(t, func(t *gtest.T) {
+		type Item struct {
+			L List
+		}
+		var (
+			item    = Item{L: *NewFrom([]interface{}{1, 2}, true)}
+			buffer  = bytes.NewBuffer(nil)
+			decoded Item
+		)
+		t.AssertNil(gob.NewEncoder(buffer).Encode(&item))
+		t.AssertNil(gob.NewDecoder(buffer).Decode(&decoded))
+		t.AssertEQ(decoded.L.FrontAll(), []interface{}{1, 2})
+	})
+}
+
+func TestList_Json_TypeHint(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l := NewFrom([]interface{}{int64(1), "a", []interface{}{uint(2)}})
+		l.SetTypeHint(true)
+		b, err := json.Marshal(l)
+		t.AssertNil(err)
+		t.Assert(b, `[{"@type":"int64","@value":1},"a",[{"@type":"uint","@value":2}]]`)
+
+		decoded := New(true)
+		t.AssertNil(json.Unmarshal(b, decoded))
+		t.AssertEQ(decoded.FrontAll(), []interface{}{int64(1), "a", []interface{}{uint(2)}})
+
+		// Unmarshaling into the concurrent-safe list.
+		decoded = New(true)
+		t.AssertNil(json.Unmarshal([]byte(`[1,2]`), decoded))
+		t.Assert(decoded.FrontAll(), []interface{}{1, 2})
+		t.AssertNil(decoded.UnmarshalValue([]interface{}{3}))
+		t.Assert(decoded.FrontAll(), []interface{}{1, 2, 3})
+	})
+}
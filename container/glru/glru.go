@@ -0,0 +1,223 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package glru provides a minimal concurrent-safe LRU cache with fixed capacity,
+// which is for the hot paths that the features and allocations of gcache are overkill.
+package glru
+
+import (
+	"sync"
+)
+
+// LRU is the concurrent-safe LRU cache, of which all operations are O(1).
+// The total cost of the entries is limited by the capacity, and the least recently used entries
+// are evicted if the capacity is exceeded.
+type LRU struct {
+	mu        sync.Mutex
+	capacity  int64                  // Maximum total cost of entries.
+	cost      int64                  // Current total cost of entries.
+	items     map[interface{}]*entry // Entries by key.
+	root      entry                  // Sentinel of the entry list, root.next is the most recently used one.
+	costFunc  CostFunc               // Cost function of entries.
+	evictFunc EvictFunc              // Callback of evicted entries.
+}
+
+// CostFunc returns the cost of the entry, like the memory size of value.
+// The cost less than 1 is treated as 1.
+type CostFunc func(key, value interface{}) int64
+
+// EvictFunc is the callback of the entry evicted for capacity.
+type EvictFunc func(key, value interface{})
+
+// entry is the element of entry list, which is intrusive for avoiding extra allocations.
+type entry struct {
+	key   interface{}
+	value interface{}
+	cost  int64
+	prev  *entry
+	next  *entry
+}
+
+// New creates and returns a LRU cache with `capacity` of the total cost of entries.
+// The optional `costFunc` returns the cost of each entry, and the cost of each entry is 1 if it
+// is not given, which makes `capacity` the maximum count of entries.
+func New(capacity int64, costFunc ...CostFunc) *LRU {
+	if capacity < 1 {
+		capacity = 1
+	}
+	c := &LRU{
+		capacity: capacity,
+		items:    make(map[interface{}]*entry),
+	}
+	if len(costFunc) > 0 {
+		c.costFunc = costFunc[0]
+	}
+	c.root.next = &c.root
+	c.root.prev = &c.root
+	return c
+}
+
+// SetEvictFunc sets the callback of the entries evicted for capacity, which is called without lock.
+func (c *LRU) SetEvictFunc(f EvictFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evictFunc = f
+}
+
+// Set sets `key` with `value` and marks it most recently used, which evicts the least recently used
+// entries if the capacity is exceeded. It returns false if the cost of the entry exceeds the capacity,
+// in which case the entry is not set and the old entry of `key` is removed.
+func (c *LRU) Set(key, value interface{}) bool {
+	cost := int64(1)
+	if c.costFunc != nil {
+		if cost = c.costFunc(key, value); cost < 1 {
+			cost = 1
+		}
+	}
+	c.mu.Lock()
+	if e, ok := c.items[key]; ok {
+		c.removeEntry(e)
+	}
+	if cost > c.capacity {
+		c.mu.Unlock()
+		return false
+	}
+	e := &entry{
+		key:   key,
+		value: value,
+		cost:  cost,
+	}
+	c.items[key] = e
+	c.cost += cost
+	c.pushFront(e)
+	var evicted []*entry
+	for c.cost > c.capacity {
+		oldest := c.root.prev
+		c.removeEntry(oldest)
+		evicted = append(evicted, oldest)
+	}
+	evictFunc := c.evictFunc
+	c.mu.Unlock()
+	if evictFunc != nil {
+		for _, e = range evicted {
+			evictFunc(e.key, e.value)
+		}
+	}
+	return true
+}
+
+// Get returns the value of `key` and marks it most recently used.
+// The returned `ok` is false if `key` does not exist.
+func (c *LRU) Get(key interface{}) (value interface{}, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.moveToFront(e)
+	return e.value, true
+}
+
+// Peek returns the value of `key` without marking it most recently used.
+// The returned `ok` is false if `key` does not exist.
+func (c *LRU) Peek(key interface{}) (value interface{}, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		return e.value, true
+	}
+	return nil, false
+}
+
+// Contains checks whether `key` exists, without marking it most recently used.
+func (c *LRU) Contains(key interface{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.items[key]
+	return ok
+}
+
+// Remove deletes `key` and returns its value.
+// The returned `ok` is false if `key` does not exist.
+func (c *LRU) Remove(key interface{}) (value interface{}, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.removeEntry(e)
+	return e.value, true
+}
+
+// Len returns the count of entries.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Cost returns the total cost of entries.
+func (c *LRU) Cost() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cost
+}
+
+// Cap returns the capacity of the total cost of entries.
+func (c *LRU) Cap() int64 {
+	return c.capacity
+}
+
+// Keys returns the keys in order from the most recently used to the least recently used.
+func (c *LRU) Keys() []interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]interface{}, 0, len(c.items))
+	for e := c.root.next; e != &c.root; e = e.next {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+// Clear deletes all entries, without calling the evict callback.
+func (c *LRU) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[interface{}]*entry)
+	c.root.next = &c.root
+	c.root.prev = &c.root
+	c.cost = 0
+}
+
+// pushFront inserts `e` to the front of entry list.
+func (c *LRU) pushFront(e *entry) {
+	e.prev = &c.root
+	e.next = c.root.next
+	c.root.next.prev = e
+	c.root.next = e
+}
+
+// moveToFront moves `e` to the front of entry list.
+func (c *LRU) moveToFront(e *entry) {
+	if c.root.next == e {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	c.pushFront(e)
+}
+
+// removeEntry removes `e` from the entry list and the items.
+func (c *LRU) removeEntry(e *entry) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = nil
+	e.next = nil
+	delete(c.items, e.key)
+	c.cost -= e.cost
+}
@@ -0,0 +1,27 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glru_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/container/glru"
+)
+
+var benchLru = glru.New(10000)
+
+func Benchmark_LRU_Set(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchLru.Set(i, i)
+	}
+}
+
+func Benchmark_LRU_Get(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchLru.Get(i)
+	}
+}
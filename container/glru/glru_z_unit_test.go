@@ -0,0 +1,113 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glru_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/glru"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_LRU_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			evicted []interface{}
+			c       = glru.New(3)
+		)
+		c.SetEvictFunc(func(key, value interface{}) {
+			evicted = append(evicted, key)
+		})
+		t.Assert(c.Cap(), 3)
+		t.Assert(c.Set(1, "a"), true)
+		t.Assert(c.Set(2, "b"), true)
+		t.Assert(c.Set(3, "c"), true)
+		t.Assert(c.Keys(), []interface{}{3, 2, 1})
+
+		v, ok := c.Get(1)
+		t.Assert(v, "a")
+		t.Assert(ok, true)
+		t.Assert(c.Keys(), []interface{}{1, 3, 2})
+
+		v, ok = c.Peek(2)
+		t.Assert(v, "b")
+		t.Assert(ok, true)
+		t.Assert(c.Keys(), []interface{}{1, 3, 2})
+
+		c.Set(4, "d")
+		t.Assert(c.Keys(), []interface{}{4, 1, 3})
+		t.Assert(c.Contains(2), false)
+		t.Assert(evicted, []interface{}{2})
+
+		c.Set(3, "cc")
+		t.Assert(c.Len(), 3)
+		t.Assert(c.Keys(), []interface{}{3, 4, 1})
+		v, _ = c.Get(3)
+		t.Assert(v, "cc")
+
+		v, ok = c.Remove(4)
+		t.Assert(v, "d")
+		t.Assert(ok, true)
+		_, ok = c.Remove(4)
+		t.Assert(ok, false)
+		_, ok = c.Get(4)
+		t.Assert(ok, false)
+		t.Assert(c.Len(), 2)
+
+		c.Clear()
+		t.Assert(c.Len(), 0)
+		t.Assert(c.Cost(), 0)
+		t.Assert(c.Keys(), []interface{}{})
+		t.Assert(evicted, []interface{}{2})
+	})
+}
+
+func Test_LRU_Cost(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := glru.New(10, func(key, value interface{}) int64 {
+			return int64(len(value.(string)))
+		})
+		t.Assert(c.Set("a", "12345"), true)
+		t.Assert(c.Set("b", "1234"), true)
+		t.Assert(c.Cost(), 9)
+		t.Assert(c.Set("c", "123"), true)
+		t.Assert(c.Keys(), []interface{}{"c", "b"})
+		t.Assert(c.Cost(), 7)
+
+		// The entry exceeding the capacity is not set, and its old entry is removed.
+		t.Assert(c.Set("b", "12345678901"), false)
+		t.Assert(c.Contains("b"), false)
+		t.Assert(c.Cost(), 3)
+
+		// The cost less than 1 is treated as 1.
+		t.Assert(c.Set("d", ""), true)
+		t.Assert(c.Cost(), 4)
+	})
+}
+
+func Test_LRU_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg sync.WaitGroup
+			c  = glru.New(100)
+		)
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					c.Set(g*1000+i, i)
+					c.Get(g*1000 + i/2)
+				}
+			}(g)
+		}
+		wg.Wait()
+		t.Assert(c.Len(), 100)
+		t.Assert(c.Cost(), 100)
+	})
+}
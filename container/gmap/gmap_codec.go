@@ -0,0 +1,92 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with gm file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmap
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/internal/typehint"
+)
+
+// mapGobData is the gob encoding data of maps, in which the keys and values are in the same order.
+type mapGobData struct {
+	Safe   bool
+	Keys   []interface{}
+	Values []interface{}
+}
+
+func init() {
+	// The maps are registered for gob encoding and type hints, so that they can be the nested values
+	// of other containers. The nested maps are decoded as concurrent-safe maps.
+	for _, v := range []struct {
+		value   interface{}
+		factory func() interface{}
+	}{
+		{&AnyAnyMap{}, func() interface{} { m := NewAnyAnyMap(true); m.SetTypeHint(true); return m }},
+		{&IntAnyMap{}, func() interface{} { m := NewIntAnyMap(true); m.SetTypeHint(true); return m }},
+		{&StrAnyMap{}, func() interface{} { m := NewStrAnyMap(true); m.SetTypeHint(true); return m }},
+		{&ListMap{}, func() interface{} { m := NewListMap(true); m.SetTypeHint(true); return m }},
+		{&IntIntMap{}, func() interface{} { return NewIntIntMap(true) }},
+		{&IntStrMap{}, func() interface{} { return NewIntStrMap(true) }},
+		{&StrIntMap{}, func() interface{} { return NewStrIntMap(true) }},
+		{&StrStrMap{}, func() interface{} { return NewStrStrMap(true) }},
+	} {
+		gob.Register(v.value)
+		typehint.Register(v.value, v.factory)
+	}
+}
+
+func newMapGobData(safe bool, size int) *mapGobData {
+	return &mapGobData{
+		Safe:   safe,
+		Keys:   make([]interface{}, 0, size),
+		Values: make([]interface{}, 0, size),
+	}
+}
+
+// add adds key-value pair to the data.
+func (d *mapGobData) add(key, value interface{}) {
+	d.Keys = append(d.Keys, key)
+	d.Values = append(d.Values, value)
+}
+
+// encode encodes the data using gob.
+func (d *mapGobData) encode() ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	if err := gob.NewEncoder(buffer).Encode(d); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// decodeMapGobData decodes and returns the gob encoding data of maps from `b`.
+func decodeMapGobData(b []byte) (*mapGobData, error) {
+	data := &mapGobData{}
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// unmarshalJSONMap unmarshals JSON `b` to map, which restores the values with type hints.
+func unmarshalJSONMap(b []byte) (map[string]interface{}, error) {
+	if typehint.Contains(b) {
+		v, err := typehint.Decode(b)
+		if err != nil {
+			return nil, err
+		}
+		data, _ := v.(map[string]interface{})
+		return data, nil
+	}
+	var data map[string]interface{}
+	if err := json.UnmarshalUseNumber(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
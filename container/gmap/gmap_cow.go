@@ -0,0 +1,41 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmap
+
+import "sync/atomic"
+
+// cowState is the snapshot state of copy-on-write mode, which is bound to the current underlying data map.
+// The underlying data map is shared with the snapshot iterators without lock if there are active snapshots,
+// and the writers should copy it before changing it.
+type cowState struct {
+	snapshots int32 // Count of active snapshot iterators.
+}
+
+// acquire marks the bound data map being iterated as a snapshot.
+func (s *cowState) acquire() {
+	atomic.AddInt32(&s.snapshots, 1)
+}
+
+// release marks a snapshot iteration of the bound data map done.
+func (s *cowState) release() {
+	atomic.AddInt32(&s.snapshots, -1)
+}
+
+// shared checks and returns whether the bound data map is being iterated as a snapshot,
+// in which case it should be copied before writing.
+func (s *cowState) shared() bool {
+	return s != nil && atomic.LoadInt32(&s.snapshots) > 0
+}
+
+// renew returns a new state for a newly created underlying data map,
+// or nil if copy-on-write mode is disabled.
+func (s *cowState) renew() *cowState {
+	if s == nil {
+		return nil
+	}
+	return new(cowState)
+}
@@ -12,13 +12,16 @@ import (
 	"github.com/gogf/gf/v2/internal/empty"
 	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/internal/rwmutex"
+	"github.com/gogf/gf/v2/internal/typehint"
 	"github.com/gogf/gf/v2/util/gconv"
 )
 
 // AnyAnyMap wraps map type `map[interface{}]interface{}` and provides more map features.
 type AnyAnyMap struct {
-	mu   rwmutex.RWMutex
-	data map[interface{}]interface{}
+	mu       rwmutex.RWMutex
+	data     map[interface{}]interface{}
+	cow      *cowState // Snapshot state of copy-on-write mode, which is nil if the mode is disabled.
+	typeHint bool      // Whether marshaling JSON with type hints, see SetTypeHint.
 }
 
 // NewAnyAnyMap creates and returns an empty hash map.
@@ -43,16 +46,57 @@ func NewAnyAnyMapFrom(data map[interface{}]interface{}, safe ...bool) *AnyAnyMap
 
 // Iterator iterates the hash map readonly with custom callback function `f`.
 // If `f` returns true, then it continues iterating; or false to stop.
+//
+// In copy-on-write mode, it iterates an immutable snapshot of the map without holding the lock,
+// so the writers are not blocked, and it is also safe to change the map in `f`.
 func (m *AnyAnyMap) Iterator(f func(k interface{}, v interface{}) bool) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-	for k, v := range m.data {
+	data := m.data
+	if cow := m.cow; cow != nil {
+		cow.acquire()
+		m.mu.RUnlock()
+		defer cow.release()
+	} else {
+		defer m.mu.RUnlock()
+	}
+	for k, v := range data {
 		if !f(k, v) {
 			break
 		}
 	}
 }
 
+// SetCopyOnWrite enables or disables the copy-on-write mode of the map.
+// In copy-on-write mode, Iterator works over an immutable snapshot of the map without holding the lock,
+// and the writers copy the underlying data map only if it is being iterated, which is useful for
+// long iterations over large maps, like dumping for diagnostics.
+func (m *AnyAnyMap) SetCopyOnWrite(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if enabled {
+		if m.cow == nil {
+			m.cow = new(cowState)
+		}
+		return
+	}
+	m.copyOnWrite()
+	m.cow = nil
+}
+
+// copyOnWrite copies the underlying data map if it is being iterated as a snapshot,
+// so that the writing does not change the snapshot. It should be called within write lock.
+func (m *AnyAnyMap) copyOnWrite() {
+	if !m.cow.shared() {
+		return
+	}
+	data := make(map[interface{}]interface{}, len(m.data))
+	for k, v := range m.data {
+		data[k] = v
+	}
+	m.data = data
+	m.cow = m.cow.renew()
+}
+
 // Clone returns a new hash map with copy of current map data.
 func (m *AnyAnyMap) Clone(safe ...bool) *AnyAnyMap {
 	return NewFrom(m.MapCopy(), safe...)
@@ -101,6 +145,7 @@ func (m *AnyAnyMap) MapStrAny() map[string]interface{} {
 func (m *AnyAnyMap) FilterEmpty() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	for k, v := range m.data {
 		if empty.IsEmpty(v) {
 			delete(m.data, k)
@@ -112,6 +157,7 @@ func (m *AnyAnyMap) FilterEmpty() {
 func (m *AnyAnyMap) FilterNil() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	for k, v := range m.data {
 		if empty.IsNil(v) {
 			delete(m.data, k)
@@ -122,6 +168,7 @@ func (m *AnyAnyMap) FilterNil() {
 // Set sets key-value to the hash map.
 func (m *AnyAnyMap) Set(key interface{}, value interface{}) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[interface{}]interface{})
 	}
@@ -132,6 +179,7 @@ func (m *AnyAnyMap) Set(key interface{}, value interface{}) {
 // Sets batch sets key-values to the hash map.
 func (m *AnyAnyMap) Sets(data map[interface{}]interface{}) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = data
 	} else {
@@ -167,6 +215,7 @@ func (m *AnyAnyMap) Get(key interface{}) (value interface{}) {
 func (m *AnyAnyMap) Pop() (key, value interface{}) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	for key, value = range m.data {
 		delete(m.data, key)
 		return
@@ -179,6 +228,7 @@ func (m *AnyAnyMap) Pop() (key, value interface{}) {
 func (m *AnyAnyMap) Pops(size int) map[interface{}]interface{} {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if size > len(m.data) || size == -1 {
 		size = len(m.data)
 	}
@@ -212,6 +262,7 @@ func (m *AnyAnyMap) Pops(size int) map[interface{}]interface{} {
 func (m *AnyAnyMap) doSetWithLockCheck(key interface{}, value interface{}) interface{} {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[interface{}]interface{})
 	}
@@ -322,6 +373,7 @@ func (m *AnyAnyMap) SetIfNotExistFuncLock(key interface{}, f func() interface{})
 // Remove deletes value from map by given `key`, and return this deleted value.
 func (m *AnyAnyMap) Remove(key interface{}) (value interface{}) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		var ok bool
 		if value, ok = m.data[key]; ok {
@@ -335,6 +387,7 @@ func (m *AnyAnyMap) Remove(key interface{}) (value interface{}) {
 // Removes batch deletes values of the map by keys.
 func (m *AnyAnyMap) Removes(keys []interface{}) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		for _, key := range keys {
 			delete(m.data, key)
@@ -402,6 +455,7 @@ func (m *AnyAnyMap) IsEmpty() bool {
 // Clear deletes all data of the map, it will remake a new underlying data map.
 func (m *AnyAnyMap) Clear() {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = make(map[interface{}]interface{})
 	m.mu.Unlock()
 }
@@ -409,6 +463,7 @@ func (m *AnyAnyMap) Clear() {
 // Replace the data of the map with given `data`.
 func (m *AnyAnyMap) Replace(data map[interface{}]interface{}) {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = data
 	m.mu.Unlock()
 }
@@ -417,6 +472,7 @@ func (m *AnyAnyMap) Replace(data map[interface{}]interface{}) {
 func (m *AnyAnyMap) LockFunc(f func(m map[interface{}]interface{})) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	f(m.data)
 }
 
@@ -431,6 +487,7 @@ func (m *AnyAnyMap) RLockFunc(f func(m map[interface{}]interface{})) {
 func (m *AnyAnyMap) Flip() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.cow = m.cow.renew()
 	n := make(map[interface{}]interface{}, len(m.data))
 	for k, v := range m.data {
 		n[v] = k
@@ -443,6 +500,7 @@ func (m *AnyAnyMap) Flip() {
 func (m *AnyAnyMap) Merge(other *AnyAnyMap) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = other.MapCopy()
 		return
@@ -467,18 +525,41 @@ func (m *AnyAnyMap) String() string {
 
 // MarshalJSON implements the interface MarshalJSON for json.Marshal.
 func (m AnyAnyMap) MarshalJSON() ([]byte, error) {
+	if m.typeHint {
+		return m.MarshalJSONWithTypeHint()
+	}
 	return json.Marshal(gconv.Map(m.Map()))
 }
 
+// SetTypeHint enables or disables marshaling JSON with type hints, which keeps the Go types of values,
+// like int64 and nested containers, through JSON marshaling and unmarshaling.
+// The values with type hints are restored by UnmarshalJSON no matter it is enabled or not.
+func (m *AnyAnyMap) SetTypeHint(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.typeHint = enabled
+}
+
+// MarshalJSONWithTypeHint marshals the map to JSON with type hints of values, see SetTypeHint.
+// Note that the keys are marshaled as strings.
+func (m *AnyAnyMap) MarshalJSONWithTypeHint() ([]byte, error) {
+	data, err := typehint.Encode(m.MapStrAny())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(data)
+}
+
 // UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
 func (m *AnyAnyMap) UnmarshalJSON(b []byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[interface{}]interface{})
 	}
-	var data map[string]interface{}
-	if err := json.UnmarshalUseNumber(b, &data); err != nil {
+	data, err := unmarshalJSONMap(b)
+	if err != nil {
 		return err
 	}
 	for k, v := range data {
@@ -487,10 +568,44 @@ func (m *AnyAnyMap) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// GobEncode implements the interface GobEncoder for gob encoding.
+// Note that the custom types of keys and values should be registered using gob.Register.
+func (m *AnyAnyMap) GobEncode() ([]byte, error) {
+	data := newMapGobData(m.mu.IsSafe(), m.Size())
+	m.Iterator(func(k interface{}, v interface{}) bool {
+		data.add(k, v)
+		return true
+	})
+	return data.encode()
+}
+
+// GobDecode implements the interface GobDecoder for gob decoding.
+// The decoded map is concurrent-safe if the encoded one is.
+func (m *AnyAnyMap) GobDecode(b []byte) error {
+	data, err := decodeMapGobData(b)
+	if err != nil {
+		return err
+	}
+	if data.Safe && !m.mu.IsSafe() {
+		m.mu = rwmutex.Create(true)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.copyOnWrite()
+	if m.data == nil {
+		m.data = make(map[interface{}]interface{}, len(data.Keys))
+	}
+	for i, k := range data.Keys {
+		m.data[k] = data.Values[i]
+	}
+	return nil
+}
+
 // UnmarshalValue is an interface implement which sets any type of value for map.
 func (m *AnyAnyMap) UnmarshalValue(value interface{}) (err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[interface{}]interface{})
 	}
@@ -13,12 +13,15 @@ import (
 	"github.com/gogf/gf/v2/internal/empty"
 	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/internal/rwmutex"
+	"github.com/gogf/gf/v2/internal/typehint"
 	"github.com/gogf/gf/v2/util/gconv"
 )
 
 type IntAnyMap struct {
-	mu   rwmutex.RWMutex
-	data map[int]interface{}
+	mu       rwmutex.RWMutex
+	data     map[int]interface{}
+	cow      *cowState // Snapshot state of copy-on-write mode, which is nil if the mode is disabled.
+	typeHint bool      // Whether marshaling JSON with type hints, see SetTypeHint.
 }
 
 // NewIntAnyMap returns an empty IntAnyMap object.
@@ -43,16 +46,57 @@ func NewIntAnyMapFrom(data map[int]interface{}, safe ...bool) *IntAnyMap {
 
 // Iterator iterates the hash map readonly with custom callback function `f`.
 // If `f` returns true, then it continues iterating; or false to stop.
+//
+// In copy-on-write mode, it iterates an immutable snapshot of the map without holding the lock,
+// so the writers are not blocked, and it is also safe to change the map in `f`.
 func (m *IntAnyMap) Iterator(f func(k int, v interface{}) bool) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-	for k, v := range m.data {
+	data := m.data
+	if cow := m.cow; cow != nil {
+		cow.acquire()
+		m.mu.RUnlock()
+		defer cow.release()
+	} else {
+		defer m.mu.RUnlock()
+	}
+	for k, v := range data {
 		if !f(k, v) {
 			break
 		}
 	}
 }
 
+// SetCopyOnWrite enables or disables the copy-on-write mode of the map.
+// In copy-on-write mode, Iterator works over an immutable snapshot of the map without holding the lock,
+// and the writers copy the underlying data map only if it is being iterated, which is useful for
+// long iterations over large maps, like dumping for diagnostics.
+func (m *IntAnyMap) SetCopyOnWrite(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if enabled {
+		if m.cow == nil {
+			m.cow = new(cowState)
+		}
+		return
+	}
+	m.copyOnWrite()
+	m.cow = nil
+}
+
+// copyOnWrite copies the underlying data map if it is being iterated as a snapshot,
+// so that the writing does not change the snapshot. It should be called within write lock.
+func (m *IntAnyMap) copyOnWrite() {
+	if !m.cow.shared() {
+		return
+	}
+	data := make(map[int]interface{}, len(m.data))
+	for k, v := range m.data {
+		data[k] = v
+	}
+	m.data = data
+	m.cow = m.cow.renew()
+}
+
 // Clone returns a new hash map with copy of current map data.
 func (m *IntAnyMap) Clone() *IntAnyMap {
 	return NewIntAnyMapFrom(m.MapCopy(), m.mu.IsSafe())
@@ -100,6 +144,7 @@ func (m *IntAnyMap) MapCopy() map[int]interface{} {
 // Values like: 0, nil, false, "", len(slice/map/chan) == 0 are considered empty.
 func (m *IntAnyMap) FilterEmpty() {
 	m.mu.Lock()
+	m.copyOnWrite()
 	for k, v := range m.data {
 		if empty.IsEmpty(v) {
 			delete(m.data, k)
@@ -112,6 +157,7 @@ func (m *IntAnyMap) FilterEmpty() {
 func (m *IntAnyMap) FilterNil() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	for k, v := range m.data {
 		if empty.IsNil(v) {
 			delete(m.data, k)
@@ -122,6 +168,7 @@ func (m *IntAnyMap) FilterNil() {
 // Set sets key-value to the hash map.
 func (m *IntAnyMap) Set(key int, val interface{}) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[int]interface{})
 	}
@@ -132,6 +179,7 @@ func (m *IntAnyMap) Set(key int, val interface{}) {
 // Sets batch sets key-values to the hash map.
 func (m *IntAnyMap) Sets(data map[int]interface{}) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = data
 	} else {
@@ -167,6 +215,7 @@ func (m *IntAnyMap) Get(key int) (value interface{}) {
 func (m *IntAnyMap) Pop() (key int, value interface{}) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	for key, value = range m.data {
 		delete(m.data, key)
 		return
@@ -179,6 +228,7 @@ func (m *IntAnyMap) Pop() (key int, value interface{}) {
 func (m *IntAnyMap) Pops(size int) map[int]interface{} {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if size > len(m.data) || size == -1 {
 		size = len(m.data)
 	}
@@ -212,6 +262,7 @@ func (m *IntAnyMap) Pops(size int) map[int]interface{} {
 func (m *IntAnyMap) doSetWithLockCheck(key int, value interface{}) interface{} {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[int]interface{})
 	}
@@ -320,6 +371,7 @@ func (m *IntAnyMap) SetIfNotExistFuncLock(key int, f func() interface{}) bool {
 // Removes batch deletes values of the map by keys.
 func (m *IntAnyMap) Removes(keys []int) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		for _, key := range keys {
 			delete(m.data, key)
@@ -331,6 +383,7 @@ func (m *IntAnyMap) Removes(keys []int) {
 // Remove deletes value from map by given `key`, and return this deleted value.
 func (m *IntAnyMap) Remove(key int) (value interface{}) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		var ok bool
 		if value, ok = m.data[key]; ok {
@@ -400,6 +453,7 @@ func (m *IntAnyMap) IsEmpty() bool {
 // Clear deletes all data of the map, it will remake a new underlying data map.
 func (m *IntAnyMap) Clear() {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = make(map[int]interface{})
 	m.mu.Unlock()
 }
@@ -407,6 +461,7 @@ func (m *IntAnyMap) Clear() {
 // Replace the data of the map with given `data`.
 func (m *IntAnyMap) Replace(data map[int]interface{}) {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = data
 	m.mu.Unlock()
 }
@@ -415,6 +470,7 @@ func (m *IntAnyMap) Replace(data map[int]interface{}) {
 func (m *IntAnyMap) LockFunc(f func(m map[int]interface{})) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	f(m.data)
 }
 
@@ -429,6 +485,7 @@ func (m *IntAnyMap) RLockFunc(f func(m map[int]interface{})) {
 func (m *IntAnyMap) Flip() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.cow = m.cow.renew()
 	n := make(map[int]interface{}, len(m.data))
 	for k, v := range m.data {
 		n[gconv.Int(v)] = k
@@ -441,6 +498,7 @@ func (m *IntAnyMap) Flip() {
 func (m *IntAnyMap) Merge(other *IntAnyMap) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = other.MapCopy()
 		return
@@ -465,28 +523,99 @@ func (m *IntAnyMap) String() string {
 
 // MarshalJSON implements the interface MarshalJSON for json.Marshal.
 func (m IntAnyMap) MarshalJSON() ([]byte, error) {
+	if m.typeHint {
+		return m.MarshalJSONWithTypeHint()
+	}
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	return json.Marshal(m.data)
 }
 
+// SetTypeHint enables or disables marshaling JSON with type hints, which keeps the Go types of values,
+// like int64 and nested containers, through JSON marshaling and unmarshaling.
+// The values with type hints are restored by UnmarshalJSON no matter it is enabled or not.
+func (m *IntAnyMap) SetTypeHint(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.typeHint = enabled
+}
+
+// MarshalJSONWithTypeHint marshals the map to JSON with type hints of values, see SetTypeHint.
+func (m *IntAnyMap) MarshalJSONWithTypeHint() ([]byte, error) {
+	var (
+		err  error
+		data = m.MapCopy()
+	)
+	for k, v := range data {
+		if data[k], err = typehint.Encode(v); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(data)
+}
+
 // UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
 func (m *IntAnyMap) UnmarshalJSON(b []byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[int]interface{})
 	}
+	if typehint.Contains(b) {
+		data, err := unmarshalJSONMap(b)
+		if err != nil {
+			return err
+		}
+		for k, v := range data {
+			m.data[gconv.Int(k)] = v
+		}
+		return nil
+	}
 	if err := json.UnmarshalUseNumber(b, &m.data); err != nil {
 		return err
 	}
 	return nil
 }
 
+// GobEncode implements the interface GobEncoder for gob encoding.
+// Note that the custom types of keys and values should be registered using gob.Register.
+func (m *IntAnyMap) GobEncode() ([]byte, error) {
+	data := newMapGobData(m.mu.IsSafe(), m.Size())
+	m.Iterator(func(k int, v interface{}) bool {
+		data.add(k, v)
+		return true
+	})
+	return data.encode()
+}
+
+// GobDecode implements the interface GobDecoder for gob decoding.
+// The decoded map is concurrent-safe if the encoded one is.
+func (m *IntAnyMap) GobDecode(b []byte) error {
+	data, err := decodeMapGobData(b)
+	if err != nil {
+		return err
+	}
+	if data.Safe && !m.mu.IsSafe() {
+		m.mu = rwmutex.Create(true)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.copyOnWrite()
+	if m.data == nil {
+		m.data = make(map[int]interface{}, len(data.Keys))
+	}
+	for i, k := range data.Keys {
+		m.data[gconv.Int(k)] = data.Values[i]
+	}
+	return nil
+}
+
 // UnmarshalValue is an interface implement which sets any type of value for map.
 func (m *IntAnyMap) UnmarshalValue(value interface{}) (err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[int]interface{})
 	}
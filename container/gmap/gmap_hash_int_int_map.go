@@ -16,6 +16,7 @@ import (
 type IntIntMap struct {
 	mu   rwmutex.RWMutex
 	data map[int]int
+	cow  *cowState // Snapshot state of copy-on-write mode, which is nil if the mode is disabled.
 }
 
 // NewIntIntMap returns an empty IntIntMap object.
@@ -40,16 +41,57 @@ func NewIntIntMapFrom(data map[int]int, safe ...bool) *IntIntMap {
 
 // Iterator iterates the hash map readonly with custom callback function `f`.
 // If `f` returns true, then it continues iterating; or false to stop.
+//
+// In copy-on-write mode, it iterates an immutable snapshot of the map without holding the lock,
+// so the writers are not blocked, and it is also safe to change the map in `f`.
 func (m *IntIntMap) Iterator(f func(k int, v int) bool) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-	for k, v := range m.data {
+	data := m.data
+	if cow := m.cow; cow != nil {
+		cow.acquire()
+		m.mu.RUnlock()
+		defer cow.release()
+	} else {
+		defer m.mu.RUnlock()
+	}
+	for k, v := range data {
 		if !f(k, v) {
 			break
 		}
 	}
 }
 
+// SetCopyOnWrite enables or disables the copy-on-write mode of the map.
+// In copy-on-write mode, Iterator works over an immutable snapshot of the map without holding the lock,
+// and the writers copy the underlying data map only if it is being iterated, which is useful for
+// long iterations over large maps, like dumping for diagnostics.
+func (m *IntIntMap) SetCopyOnWrite(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if enabled {
+		if m.cow == nil {
+			m.cow = new(cowState)
+		}
+		return
+	}
+	m.copyOnWrite()
+	m.cow = nil
+}
+
+// copyOnWrite copies the underlying data map if it is being iterated as a snapshot,
+// so that the writing does not change the snapshot. It should be called within write lock.
+func (m *IntIntMap) copyOnWrite() {
+	if !m.cow.shared() {
+		return
+	}
+	data := make(map[int]int, len(m.data))
+	for k, v := range m.data {
+		data[k] = v
+	}
+	m.data = data
+	m.cow = m.cow.renew()
+}
+
 // Clone returns a new hash map with copy of current map data.
 func (m *IntIntMap) Clone() *IntIntMap {
 	return NewIntIntMapFrom(m.MapCopy(), m.mu.IsSafe())
@@ -97,6 +139,7 @@ func (m *IntIntMap) MapCopy() map[int]int {
 // Values like: 0, nil, false, "", len(slice/map/chan) == 0 are considered empty.
 func (m *IntIntMap) FilterEmpty() {
 	m.mu.Lock()
+	m.copyOnWrite()
 	for k, v := range m.data {
 		if empty.IsEmpty(v) {
 			delete(m.data, k)
@@ -108,6 +151,7 @@ func (m *IntIntMap) FilterEmpty() {
 // Set sets key-value to the hash map.
 func (m *IntIntMap) Set(key int, val int) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[int]int)
 	}
@@ -118,6 +162,7 @@ func (m *IntIntMap) Set(key int, val int) {
 // Sets batch sets key-values to the hash map.
 func (m *IntIntMap) Sets(data map[int]int) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = data
 	} else {
@@ -153,6 +198,7 @@ func (m *IntIntMap) Get(key int) (value int) {
 func (m *IntIntMap) Pop() (key, value int) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	for key, value = range m.data {
 		delete(m.data, key)
 		return
@@ -165,6 +211,7 @@ func (m *IntIntMap) Pop() (key, value int) {
 func (m *IntIntMap) Pops(size int) map[int]int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if size > len(m.data) || size == -1 {
 		size = len(m.data)
 	}
@@ -194,6 +241,7 @@ func (m *IntIntMap) Pops(size int) map[int]int {
 func (m *IntIntMap) doSetWithLockCheck(key int, value int) int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[int]int)
 	}
@@ -233,6 +281,7 @@ func (m *IntIntMap) GetOrSetFuncLock(key int, f func() int) int {
 	if v, ok := m.Search(key); !ok {
 		m.mu.Lock()
 		defer m.mu.Unlock()
+		m.copyOnWrite()
 		if m.data == nil {
 			m.data = make(map[int]int)
 		}
@@ -276,6 +325,7 @@ func (m *IntIntMap) SetIfNotExistFuncLock(key int, f func() int) bool {
 	if !m.Contains(key) {
 		m.mu.Lock()
 		defer m.mu.Unlock()
+		m.copyOnWrite()
 		if m.data == nil {
 			m.data = make(map[int]int)
 		}
@@ -290,6 +340,7 @@ func (m *IntIntMap) SetIfNotExistFuncLock(key int, f func() int) bool {
 // Removes batch deletes values of the map by keys.
 func (m *IntIntMap) Removes(keys []int) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		for _, key := range keys {
 			delete(m.data, key)
@@ -301,6 +352,7 @@ func (m *IntIntMap) Removes(keys []int) {
 // Remove deletes value from map by given `key`, and return this deleted value.
 func (m *IntIntMap) Remove(key int) (value int) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		var ok bool
 		if value, ok = m.data[key]; ok {
@@ -370,6 +422,7 @@ func (m *IntIntMap) IsEmpty() bool {
 // Clear deletes all data of the map, it will remake a new underlying data map.
 func (m *IntIntMap) Clear() {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = make(map[int]int)
 	m.mu.Unlock()
 }
@@ -377,6 +430,7 @@ func (m *IntIntMap) Clear() {
 // Replace the data of the map with given `data`.
 func (m *IntIntMap) Replace(data map[int]int) {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = data
 	m.mu.Unlock()
 }
@@ -385,6 +439,7 @@ func (m *IntIntMap) Replace(data map[int]int) {
 func (m *IntIntMap) LockFunc(f func(m map[int]int)) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	f(m.data)
 }
 
@@ -399,6 +454,7 @@ func (m *IntIntMap) RLockFunc(f func(m map[int]int)) {
 func (m *IntIntMap) Flip() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.cow = m.cow.renew()
 	n := make(map[int]int, len(m.data))
 	for k, v := range m.data {
 		n[v] = k
@@ -411,6 +467,7 @@ func (m *IntIntMap) Flip() {
 func (m *IntIntMap) Merge(other *IntIntMap) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = other.MapCopy()
 		return
@@ -444,6 +501,7 @@ func (m IntIntMap) MarshalJSON() ([]byte, error) {
 func (m *IntIntMap) UnmarshalJSON(b []byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[int]int)
 	}
@@ -453,10 +511,44 @@ func (m *IntIntMap) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// GobEncode implements the interface GobEncoder for gob encoding.
+// Note that the custom types of keys and values should be registered using gob.Register.
+func (m *IntIntMap) GobEncode() ([]byte, error) {
+	data := newMapGobData(m.mu.IsSafe(), m.Size())
+	m.Iterator(func(k int, v int) bool {
+		data.add(k, v)
+		return true
+	})
+	return data.encode()
+}
+
+// GobDecode implements the interface GobDecoder for gob decoding.
+// The decoded map is concurrent-safe if the encoded one is.
+func (m *IntIntMap) GobDecode(b []byte) error {
+	data, err := decodeMapGobData(b)
+	if err != nil {
+		return err
+	}
+	if data.Safe && !m.mu.IsSafe() {
+		m.mu = rwmutex.Create(true)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.copyOnWrite()
+	if m.data == nil {
+		m.data = make(map[int]int, len(data.Keys))
+	}
+	for i, k := range data.Keys {
+		m.data[gconv.Int(k)] = gconv.Int(data.Values[i])
+	}
+	return nil
+}
+
 // UnmarshalValue is an interface implement which sets any type of value for map.
 func (m *IntIntMap) UnmarshalValue(value interface{}) (err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[int]int)
 	}
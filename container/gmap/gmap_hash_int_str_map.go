@@ -16,6 +16,7 @@ import (
 type IntStrMap struct {
 	mu   rwmutex.RWMutex
 	data map[int]string
+	cow  *cowState // Snapshot state of copy-on-write mode, which is nil if the mode is disabled.
 }
 
 // NewIntStrMap returns an empty IntStrMap object.
@@ -40,16 +41,57 @@ func NewIntStrMapFrom(data map[int]string, safe ...bool) *IntStrMap {
 
 // Iterator iterates the hash map readonly with custom callback function `f`.
 // If `f` returns true, then it continues iterating; or false to stop.
+//
+// In copy-on-write mode, it iterates an immutable snapshot of the map without holding the lock,
+// so the writers are not blocked, and it is also safe to change the map in `f`.
 func (m *IntStrMap) Iterator(f func(k int, v string) bool) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-	for k, v := range m.data {
+	data := m.data
+	if cow := m.cow; cow != nil {
+		cow.acquire()
+		m.mu.RUnlock()
+		defer cow.release()
+	} else {
+		defer m.mu.RUnlock()
+	}
+	for k, v := range data {
 		if !f(k, v) {
 			break
 		}
 	}
 }
 
+// SetCopyOnWrite enables or disables the copy-on-write mode of the map.
+// In copy-on-write mode, Iterator works over an immutable snapshot of the map without holding the lock,
+// and the writers copy the underlying data map only if it is being iterated, which is useful for
+// long iterations over large maps, like dumping for diagnostics.
+func (m *IntStrMap) SetCopyOnWrite(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if enabled {
+		if m.cow == nil {
+			m.cow = new(cowState)
+		}
+		return
+	}
+	m.copyOnWrite()
+	m.cow = nil
+}
+
+// copyOnWrite copies the underlying data map if it is being iterated as a snapshot,
+// so that the writing does not change the snapshot. It should be called within write lock.
+func (m *IntStrMap) copyOnWrite() {
+	if !m.cow.shared() {
+		return
+	}
+	data := make(map[int]string, len(m.data))
+	for k, v := range m.data {
+		data[k] = v
+	}
+	m.data = data
+	m.cow = m.cow.renew()
+}
+
 // Clone returns a new hash map with copy of current map data.
 func (m *IntStrMap) Clone() *IntStrMap {
 	return NewIntStrMapFrom(m.MapCopy(), m.mu.IsSafe())
@@ -97,6 +139,7 @@ func (m *IntStrMap) MapCopy() map[int]string {
 // Values like: 0, nil, false, "", len(slice/map/chan) == 0 are considered empty.
 func (m *IntStrMap) FilterEmpty() {
 	m.mu.Lock()
+	m.copyOnWrite()
 	for k, v := range m.data {
 		if empty.IsEmpty(v) {
 			delete(m.data, k)
@@ -108,6 +151,7 @@ func (m *IntStrMap) FilterEmpty() {
 // Set sets key-value to the hash map.
 func (m *IntStrMap) Set(key int, val string) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[int]string)
 	}
@@ -118,6 +162,7 @@ func (m *IntStrMap) Set(key int, val string) {
 // Sets batch sets key-values to the hash map.
 func (m *IntStrMap) Sets(data map[int]string) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = data
 	} else {
@@ -153,6 +198,7 @@ func (m *IntStrMap) Get(key int) (value string) {
 func (m *IntStrMap) Pop() (key int, value string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	for key, value = range m.data {
 		delete(m.data, key)
 		return
@@ -165,6 +211,7 @@ func (m *IntStrMap) Pop() (key int, value string) {
 func (m *IntStrMap) Pops(size int) map[int]string {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if size > len(m.data) || size == -1 {
 		size = len(m.data)
 	}
@@ -194,6 +241,7 @@ func (m *IntStrMap) Pops(size int) map[int]string {
 func (m *IntStrMap) doSetWithLockCheck(key int, value string) string {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[int]string)
 	}
@@ -233,6 +281,7 @@ func (m *IntStrMap) GetOrSetFuncLock(key int, f func() string) string {
 	if v, ok := m.Search(key); !ok {
 		m.mu.Lock()
 		defer m.mu.Unlock()
+		m.copyOnWrite()
 		if m.data == nil {
 			m.data = make(map[int]string)
 		}
@@ -276,6 +325,7 @@ func (m *IntStrMap) SetIfNotExistFuncLock(key int, f func() string) bool {
 	if !m.Contains(key) {
 		m.mu.Lock()
 		defer m.mu.Unlock()
+		m.copyOnWrite()
 		if m.data == nil {
 			m.data = make(map[int]string)
 		}
@@ -290,6 +340,7 @@ func (m *IntStrMap) SetIfNotExistFuncLock(key int, f func() string) bool {
 // Removes batch deletes values of the map by keys.
 func (m *IntStrMap) Removes(keys []int) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		for _, key := range keys {
 			delete(m.data, key)
@@ -301,6 +352,7 @@ func (m *IntStrMap) Removes(keys []int) {
 // Remove deletes value from map by given `key`, and return this deleted value.
 func (m *IntStrMap) Remove(key int) (value string) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		var ok bool
 		if value, ok = m.data[key]; ok {
@@ -370,6 +422,7 @@ func (m *IntStrMap) IsEmpty() bool {
 // Clear deletes all data of the map, it will remake a new underlying data map.
 func (m *IntStrMap) Clear() {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = make(map[int]string)
 	m.mu.Unlock()
 }
@@ -377,6 +430,7 @@ func (m *IntStrMap) Clear() {
 // Replace the data of the map with given `data`.
 func (m *IntStrMap) Replace(data map[int]string) {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = data
 	m.mu.Unlock()
 }
@@ -385,6 +439,7 @@ func (m *IntStrMap) Replace(data map[int]string) {
 func (m *IntStrMap) LockFunc(f func(m map[int]string)) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	f(m.data)
 }
 
@@ -399,6 +454,7 @@ func (m *IntStrMap) RLockFunc(f func(m map[int]string)) {
 func (m *IntStrMap) Flip() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.cow = m.cow.renew()
 	n := make(map[int]string, len(m.data))
 	for k, v := range m.data {
 		n[gconv.Int(v)] = gconv.String(k)
@@ -411,6 +467,7 @@ func (m *IntStrMap) Flip() {
 func (m *IntStrMap) Merge(other *IntStrMap) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = other.MapCopy()
 		return
@@ -444,6 +501,7 @@ func (m IntStrMap) MarshalJSON() ([]byte, error) {
 func (m *IntStrMap) UnmarshalJSON(b []byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[int]string)
 	}
@@ -453,10 +511,44 @@ func (m *IntStrMap) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// GobEncode implements the interface GobEncoder for gob encoding.
+// Note that the custom types of keys and values should be registered using gob.Register.
+func (m *IntStrMap) GobEncode() ([]byte, error) {
+	data := newMapGobData(m.mu.IsSafe(), m.Size())
+	m.Iterator(func(k int, v string) bool {
+		data.add(k, v)
+		return true
+	})
+	return data.encode()
+}
+
+// GobDecode implements the interface GobDecoder for gob decoding.
+// The decoded map is concurrent-safe if the encoded one is.
+func (m *IntStrMap) GobDecode(b []byte) error {
+	data, err := decodeMapGobData(b)
+	if err != nil {
+		return err
+	}
+	if data.Safe && !m.mu.IsSafe() {
+		m.mu = rwmutex.Create(true)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.copyOnWrite()
+	if m.data == nil {
+		m.data = make(map[int]string, len(data.Keys))
+	}
+	for i, k := range data.Keys {
+		m.data[gconv.Int(k)] = gconv.String(data.Values[i])
+	}
+	return nil
+}
+
 // UnmarshalValue is an interface implement which sets any type of value for map.
 func (m *IntStrMap) UnmarshalValue(value interface{}) (err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[int]string)
 	}
@@ -13,12 +13,15 @@ import (
 	"github.com/gogf/gf/v2/internal/empty"
 	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/internal/rwmutex"
+	"github.com/gogf/gf/v2/internal/typehint"
 	"github.com/gogf/gf/v2/util/gconv"
 )
 
 type StrAnyMap struct {
-	mu   rwmutex.RWMutex
-	data map[string]interface{}
+	mu       rwmutex.RWMutex
+	data     map[string]interface{}
+	cow      *cowState // Snapshot state of copy-on-write mode, which is nil if the mode is disabled.
+	typeHint bool      // Whether marshaling JSON with type hints, see SetTypeHint.
 }
 
 // NewStrAnyMap returns an empty StrAnyMap object.
@@ -43,16 +46,57 @@ func NewStrAnyMapFrom(data map[string]interface{}, safe ...bool) *StrAnyMap {
 
 // Iterator iterates the hash map readonly with custom callback function `f`.
 // If `f` returns true, then it continues iterating; or false to stop.
+//
+// In copy-on-write mode, it iterates an immutable snapshot of the map without holding the lock,
+// so the writers are not blocked, and it is also safe to change the map in `f`.
 func (m *StrAnyMap) Iterator(f func(k string, v interface{}) bool) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-	for k, v := range m.data {
+	data := m.data
+	if cow := m.cow; cow != nil {
+		cow.acquire()
+		m.mu.RUnlock()
+		defer cow.release()
+	} else {
+		defer m.mu.RUnlock()
+	}
+	for k, v := range data {
 		if !f(k, v) {
 			break
 		}
 	}
 }
 
+// SetCopyOnWrite enables or disables the copy-on-write mode of the map.
+// In copy-on-write mode, Iterator works over an immutable snapshot of the map without holding the lock,
+// and the writers copy the underlying data map only if it is being iterated, which is useful for
+// long iterations over large maps, like dumping for diagnostics.
+func (m *StrAnyMap) SetCopyOnWrite(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if enabled {
+		if m.cow == nil {
+			m.cow = new(cowState)
+		}
+		return
+	}
+	m.copyOnWrite()
+	m.cow = nil
+}
+
+// copyOnWrite copies the underlying data map if it is being iterated as a snapshot,
+// so that the writing does not change the snapshot. It should be called within write lock.
+func (m *StrAnyMap) copyOnWrite() {
+	if !m.cow.shared() {
+		return
+	}
+	data := make(map[string]interface{}, len(m.data))
+	for k, v := range m.data {
+		data[k] = v
+	}
+	m.data = data
+	m.cow = m.cow.renew()
+}
+
 // Clone returns a new hash map with copy of current map data.
 func (m *StrAnyMap) Clone() *StrAnyMap {
 	return NewStrAnyMapFrom(m.MapCopy(), m.mu.IsSafe())
@@ -94,6 +138,7 @@ func (m *StrAnyMap) MapCopy() map[string]interface{} {
 // Values like: 0, nil, false, "", len(slice/map/chan) == 0 are considered empty.
 func (m *StrAnyMap) FilterEmpty() {
 	m.mu.Lock()
+	m.copyOnWrite()
 	for k, v := range m.data {
 		if empty.IsEmpty(v) {
 			delete(m.data, k)
@@ -106,6 +151,7 @@ func (m *StrAnyMap) FilterEmpty() {
 func (m *StrAnyMap) FilterNil() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	for k, v := range m.data {
 		if empty.IsNil(v) {
 			delete(m.data, k)
@@ -116,6 +162,7 @@ func (m *StrAnyMap) FilterNil() {
 // Set sets key-value to the hash map.
 func (m *StrAnyMap) Set(key string, val interface{}) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[string]interface{})
 	}
@@ -126,6 +173,7 @@ func (m *StrAnyMap) Set(key string, val interface{}) {
 // Sets batch sets key-values to the hash map.
 func (m *StrAnyMap) Sets(data map[string]interface{}) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = data
 	} else {
@@ -161,6 +209,7 @@ func (m *StrAnyMap) Get(key string) (value interface{}) {
 func (m *StrAnyMap) Pop() (key string, value interface{}) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	for key, value = range m.data {
 		delete(m.data, key)
 		return
@@ -173,6 +222,7 @@ func (m *StrAnyMap) Pop() (key string, value interface{}) {
 func (m *StrAnyMap) Pops(size int) map[string]interface{} {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if size > len(m.data) || size == -1 {
 		size = len(m.data)
 	}
@@ -206,6 +256,7 @@ func (m *StrAnyMap) Pops(size int) map[string]interface{} {
 func (m *StrAnyMap) doSetWithLockCheck(key string, value interface{}) interface{} {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[string]interface{})
 	}
@@ -316,6 +367,7 @@ func (m *StrAnyMap) SetIfNotExistFuncLock(key string, f func() interface{}) bool
 // Removes batch deletes values of the map by keys.
 func (m *StrAnyMap) Removes(keys []string) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		for _, key := range keys {
 			delete(m.data, key)
@@ -327,6 +379,7 @@ func (m *StrAnyMap) Removes(keys []string) {
 // Remove deletes value from map by given `key`, and return this deleted value.
 func (m *StrAnyMap) Remove(key string) (value interface{}) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		var ok bool
 		if value, ok = m.data[key]; ok {
@@ -396,6 +449,7 @@ func (m *StrAnyMap) IsEmpty() bool {
 // Clear deletes all data of the map, it will remake a new underlying data map.
 func (m *StrAnyMap) Clear() {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = make(map[string]interface{})
 	m.mu.Unlock()
 }
@@ -403,6 +457,7 @@ func (m *StrAnyMap) Clear() {
 // Replace the data of the map with given `data`.
 func (m *StrAnyMap) Replace(data map[string]interface{}) {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = data
 	m.mu.Unlock()
 }
@@ -411,6 +466,7 @@ func (m *StrAnyMap) Replace(data map[string]interface{}) {
 func (m *StrAnyMap) LockFunc(f func(m map[string]interface{})) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	f(m.data)
 }
 
@@ -425,6 +481,7 @@ func (m *StrAnyMap) RLockFunc(f func(m map[string]interface{})) {
 func (m *StrAnyMap) Flip() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.cow = m.cow.renew()
 	n := make(map[string]interface{}, len(m.data))
 	for k, v := range m.data {
 		n[gconv.String(v)] = k
@@ -437,6 +494,7 @@ func (m *StrAnyMap) Flip() {
 func (m *StrAnyMap) Merge(other *StrAnyMap) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = other.MapCopy()
 		return
@@ -461,28 +519,94 @@ func (m *StrAnyMap) String() string {
 
 // MarshalJSON implements the interface MarshalJSON for json.Marshal.
 func (m StrAnyMap) MarshalJSON() ([]byte, error) {
+	if m.typeHint {
+		return m.MarshalJSONWithTypeHint()
+	}
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	return json.Marshal(m.data)
 }
 
+// SetTypeHint enables or disables marshaling JSON with type hints, which keeps the Go types of values,
+// like int64 and nested containers, through JSON marshaling and unmarshaling.
+// The values with type hints are restored by UnmarshalJSON no matter it is enabled or not.
+func (m *StrAnyMap) SetTypeHint(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.typeHint = enabled
+}
+
+// MarshalJSONWithTypeHint marshals the map to JSON with type hints of values, see SetTypeHint.
+func (m *StrAnyMap) MarshalJSONWithTypeHint() ([]byte, error) {
+	data, err := typehint.Encode(m.MapCopy())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(data)
+}
+
 // UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
 func (m *StrAnyMap) UnmarshalJSON(b []byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[string]interface{})
 	}
+	if typehint.Contains(b) {
+		data, err := unmarshalJSONMap(b)
+		if err != nil {
+			return err
+		}
+		for k, v := range data {
+			m.data[k] = v
+		}
+		return nil
+	}
 	if err := json.UnmarshalUseNumber(b, &m.data); err != nil {
 		return err
 	}
 	return nil
 }
 
+// GobEncode implements the interface GobEncoder for gob encoding.
+// Note that the custom types of keys and values should be registered using gob.Register.
+func (m *StrAnyMap) GobEncode() ([]byte, error) {
+	data := newMapGobData(m.mu.IsSafe(), m.Size())
+	m.Iterator(func(k string, v interface{}) bool {
+		data.add(k, v)
+		return true
+	})
+	return data.encode()
+}
+
+// GobDecode implements the interface GobDecoder for gob decoding.
+// The decoded map is concurrent-safe if the encoded one is.
+func (m *StrAnyMap) GobDecode(b []byte) error {
+	data, err := decodeMapGobData(b)
+	if err != nil {
+		return err
+	}
+	if data.Safe && !m.mu.IsSafe() {
+		m.mu = rwmutex.Create(true)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.copyOnWrite()
+	if m.data == nil {
+		m.data = make(map[string]interface{}, len(data.Keys))
+	}
+	for i, k := range data.Keys {
+		m.data[gconv.String(k)] = data.Values[i]
+	}
+	return nil
+}
+
 // UnmarshalValue is an interface implement which sets any type of value for map.
 func (m *StrAnyMap) UnmarshalValue(value interface{}) (err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	m.data = gconv.Map(value)
 	return
 }
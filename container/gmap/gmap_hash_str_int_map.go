@@ -17,6 +17,7 @@ import (
 type StrIntMap struct {
 	mu   rwmutex.RWMutex
 	data map[string]int
+	cow  *cowState // Snapshot state of copy-on-write mode, which is nil if the mode is disabled.
 }
 
 // NewStrIntMap returns an empty StrIntMap object.
@@ -41,16 +42,57 @@ func NewStrIntMapFrom(data map[string]int, safe ...bool) *StrIntMap {
 
 // Iterator iterates the hash map readonly with custom callback function `f`.
 // If `f` returns true, then it continues iterating; or false to stop.
+//
+// In copy-on-write mode, it iterates an immutable snapshot of the map without holding the lock,
+// so the writers are not blocked, and it is also safe to change the map in `f`.
 func (m *StrIntMap) Iterator(f func(k string, v int) bool) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-	for k, v := range m.data {
+	data := m.data
+	if cow := m.cow; cow != nil {
+		cow.acquire()
+		m.mu.RUnlock()
+		defer cow.release()
+	} else {
+		defer m.mu.RUnlock()
+	}
+	for k, v := range data {
 		if !f(k, v) {
 			break
 		}
 	}
 }
 
+// SetCopyOnWrite enables or disables the copy-on-write mode of the map.
+// In copy-on-write mode, Iterator works over an immutable snapshot of the map without holding the lock,
+// and the writers copy the underlying data map only if it is being iterated, which is useful for
+// long iterations over large maps, like dumping for diagnostics.
+func (m *StrIntMap) SetCopyOnWrite(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if enabled {
+		if m.cow == nil {
+			m.cow = new(cowState)
+		}
+		return
+	}
+	m.copyOnWrite()
+	m.cow = nil
+}
+
+// copyOnWrite copies the underlying data map if it is being iterated as a snapshot,
+// so that the writing does not change the snapshot. It should be called within write lock.
+func (m *StrIntMap) copyOnWrite() {
+	if !m.cow.shared() {
+		return
+	}
+	data := make(map[string]int, len(m.data))
+	for k, v := range m.data {
+		data[k] = v
+	}
+	m.data = data
+	m.cow = m.cow.renew()
+}
+
 // Clone returns a new hash map with copy of current map data.
 func (m *StrIntMap) Clone() *StrIntMap {
 	return NewStrIntMapFrom(m.MapCopy(), m.mu.IsSafe())
@@ -98,6 +140,7 @@ func (m *StrIntMap) MapCopy() map[string]int {
 // Values like: 0, nil, false, "", len(slice/map/chan) == 0 are considered empty.
 func (m *StrIntMap) FilterEmpty() {
 	m.mu.Lock()
+	m.copyOnWrite()
 	for k, v := range m.data {
 		if empty.IsEmpty(v) {
 			delete(m.data, k)
@@ -109,6 +152,7 @@ func (m *StrIntMap) FilterEmpty() {
 // Set sets key-value to the hash map.
 func (m *StrIntMap) Set(key string, val int) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[string]int)
 	}
@@ -119,6 +163,7 @@ func (m *StrIntMap) Set(key string, val int) {
 // Sets batch sets key-values to the hash map.
 func (m *StrIntMap) Sets(data map[string]int) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = data
 	} else {
@@ -154,6 +199,7 @@ func (m *StrIntMap) Get(key string) (value int) {
 func (m *StrIntMap) Pop() (key string, value int) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	for key, value = range m.data {
 		delete(m.data, key)
 		return
@@ -166,6 +212,7 @@ func (m *StrIntMap) Pop() (key string, value int) {
 func (m *StrIntMap) Pops(size int) map[string]int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if size > len(m.data) || size == -1 {
 		size = len(m.data)
 	}
@@ -194,6 +241,7 @@ func (m *StrIntMap) Pops(size int) map[string]int {
 // It returns value with given `key`.
 func (m *StrIntMap) doSetWithLockCheck(key string, value int) int {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[string]int)
 	}
@@ -237,6 +285,7 @@ func (m *StrIntMap) GetOrSetFuncLock(key string, f func() int) int {
 	if v, ok := m.Search(key); !ok {
 		m.mu.Lock()
 		defer m.mu.Unlock()
+		m.copyOnWrite()
 		if m.data == nil {
 			m.data = make(map[string]int)
 		}
@@ -280,6 +329,7 @@ func (m *StrIntMap) SetIfNotExistFuncLock(key string, f func() int) bool {
 	if !m.Contains(key) {
 		m.mu.Lock()
 		defer m.mu.Unlock()
+		m.copyOnWrite()
 		if m.data == nil {
 			m.data = make(map[string]int)
 		}
@@ -294,6 +344,7 @@ func (m *StrIntMap) SetIfNotExistFuncLock(key string, f func() int) bool {
 // Removes batch deletes values of the map by keys.
 func (m *StrIntMap) Removes(keys []string) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		for _, key := range keys {
 			delete(m.data, key)
@@ -305,6 +356,7 @@ func (m *StrIntMap) Removes(keys []string) {
 // Remove deletes value from map by given `key`, and return this deleted value.
 func (m *StrIntMap) Remove(key string) (value int) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		var ok bool
 		if value, ok = m.data[key]; ok {
@@ -374,6 +426,7 @@ func (m *StrIntMap) IsEmpty() bool {
 // Clear deletes all data of the map, it will remake a new underlying data map.
 func (m *StrIntMap) Clear() {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = make(map[string]int)
 	m.mu.Unlock()
 }
@@ -381,6 +434,7 @@ func (m *StrIntMap) Clear() {
 // Replace the data of the map with given `data`.
 func (m *StrIntMap) Replace(data map[string]int) {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = data
 	m.mu.Unlock()
 }
@@ -389,6 +443,7 @@ func (m *StrIntMap) Replace(data map[string]int) {
 func (m *StrIntMap) LockFunc(f func(m map[string]int)) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	f(m.data)
 }
 
@@ -403,6 +458,7 @@ func (m *StrIntMap) RLockFunc(f func(m map[string]int)) {
 func (m *StrIntMap) Flip() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.cow = m.cow.renew()
 	n := make(map[string]int, len(m.data))
 	for k, v := range m.data {
 		n[gconv.String(v)] = gconv.Int(k)
@@ -415,6 +471,7 @@ func (m *StrIntMap) Flip() {
 func (m *StrIntMap) Merge(other *StrIntMap) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = other.MapCopy()
 		return
@@ -448,6 +505,7 @@ func (m StrIntMap) MarshalJSON() ([]byte, error) {
 func (m *StrIntMap) UnmarshalJSON(b []byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[string]int)
 	}
@@ -457,10 +515,44 @@ func (m *StrIntMap) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// GobEncode implements the interface GobEncoder for gob encoding.
+// Note that the custom types of keys and values should be registered using gob.Register.
+func (m *StrIntMap) GobEncode() ([]byte, error) {
+	data := newMapGobData(m.mu.IsSafe(), m.Size())
+	m.Iterator(func(k string, v int) bool {
+		data.add(k, v)
+		return true
+	})
+	return data.encode()
+}
+
+// GobDecode implements the interface GobDecoder for gob decoding.
+// The decoded map is concurrent-safe if the encoded one is.
+func (m *StrIntMap) GobDecode(b []byte) error {
+	data, err := decodeMapGobData(b)
+	if err != nil {
+		return err
+	}
+	if data.Safe && !m.mu.IsSafe() {
+		m.mu = rwmutex.Create(true)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.copyOnWrite()
+	if m.data == nil {
+		m.data = make(map[string]int, len(data.Keys))
+	}
+	for i, k := range data.Keys {
+		m.data[gconv.String(k)] = gconv.Int(data.Values[i])
+	}
+	return nil
+}
+
 // UnmarshalValue is an interface implement which sets any type of value for map.
 func (m *StrIntMap) UnmarshalValue(value interface{}) (err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[string]int)
 	}
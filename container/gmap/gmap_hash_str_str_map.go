@@ -17,6 +17,7 @@ import (
 type StrStrMap struct {
 	mu   rwmutex.RWMutex
 	data map[string]string
+	cow  *cowState // Snapshot state of copy-on-write mode, which is nil if the mode is disabled.
 }
 
 // NewStrStrMap returns an empty StrStrMap object.
@@ -41,16 +42,57 @@ func NewStrStrMapFrom(data map[string]string, safe ...bool) *StrStrMap {
 
 // Iterator iterates the hash map readonly with custom callback function `f`.
 // If `f` returns true, then it continues iterating; or false to stop.
+//
+// In copy-on-write mode, it iterates an immutable snapshot of the map without holding the lock,
+// so the writers are not blocked, and it is also safe to change the map in `f`.
 func (m *StrStrMap) Iterator(f func(k string, v string) bool) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-	for k, v := range m.data {
+	data := m.data
+	if cow := m.cow; cow != nil {
+		cow.acquire()
+		m.mu.RUnlock()
+		defer cow.release()
+	} else {
+		defer m.mu.RUnlock()
+	}
+	for k, v := range data {
 		if !f(k, v) {
 			break
 		}
 	}
 }
 
+// SetCopyOnWrite enables or disables the copy-on-write mode of the map.
+// In copy-on-write mode, Iterator works over an immutable snapshot of the map without holding the lock,
+// and the writers copy the underlying data map only if it is being iterated, which is useful for
+// long iterations over large maps, like dumping for diagnostics.
+func (m *StrStrMap) SetCopyOnWrite(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if enabled {
+		if m.cow == nil {
+			m.cow = new(cowState)
+		}
+		return
+	}
+	m.copyOnWrite()
+	m.cow = nil
+}
+
+// copyOnWrite copies the underlying data map if it is being iterated as a snapshot,
+// so that the writing does not change the snapshot. It should be called within write lock.
+func (m *StrStrMap) copyOnWrite() {
+	if !m.cow.shared() {
+		return
+	}
+	data := make(map[string]string, len(m.data))
+	for k, v := range m.data {
+		data[k] = v
+	}
+	m.data = data
+	m.cow = m.cow.renew()
+}
+
 // Clone returns a new hash map with copy of current map data.
 func (m *StrStrMap) Clone() *StrStrMap {
 	return NewStrStrMapFrom(m.MapCopy(), m.mu.IsSafe())
@@ -98,6 +140,7 @@ func (m *StrStrMap) MapCopy() map[string]string {
 // Values like: 0, nil, false, "", len(slice/map/chan) == 0 are considered empty.
 func (m *StrStrMap) FilterEmpty() {
 	m.mu.Lock()
+	m.copyOnWrite()
 	for k, v := range m.data {
 		if empty.IsEmpty(v) {
 			delete(m.data, k)
@@ -109,6 +152,7 @@ func (m *StrStrMap) FilterEmpty() {
 // Set sets key-value to the hash map.
 func (m *StrStrMap) Set(key string, val string) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[string]string)
 	}
@@ -119,6 +163,7 @@ func (m *StrStrMap) Set(key string, val string) {
 // Sets batch sets key-values to the hash map.
 func (m *StrStrMap) Sets(data map[string]string) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = data
 	} else {
@@ -154,6 +199,7 @@ func (m *StrStrMap) Get(key string) (value string) {
 func (m *StrStrMap) Pop() (key, value string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	for key, value = range m.data {
 		delete(m.data, key)
 		return
@@ -166,6 +212,7 @@ func (m *StrStrMap) Pop() (key, value string) {
 func (m *StrStrMap) Pops(size int) map[string]string {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if size > len(m.data) || size == -1 {
 		size = len(m.data)
 	}
@@ -195,6 +242,7 @@ func (m *StrStrMap) Pops(size int) map[string]string {
 func (m *StrStrMap) doSetWithLockCheck(key string, value string) string {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[string]string)
 	}
@@ -236,6 +284,7 @@ func (m *StrStrMap) GetOrSetFuncLock(key string, f func() string) string {
 	if v, ok := m.Search(key); !ok {
 		m.mu.Lock()
 		defer m.mu.Unlock()
+		m.copyOnWrite()
 		if m.data == nil {
 			m.data = make(map[string]string)
 		}
@@ -279,6 +328,7 @@ func (m *StrStrMap) SetIfNotExistFuncLock(key string, f func() string) bool {
 	if !m.Contains(key) {
 		m.mu.Lock()
 		defer m.mu.Unlock()
+		m.copyOnWrite()
 		if m.data == nil {
 			m.data = make(map[string]string)
 		}
@@ -293,6 +343,7 @@ func (m *StrStrMap) SetIfNotExistFuncLock(key string, f func() string) bool {
 // Removes batch deletes values of the map by keys.
 func (m *StrStrMap) Removes(keys []string) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		for _, key := range keys {
 			delete(m.data, key)
@@ -304,6 +355,7 @@ func (m *StrStrMap) Removes(keys []string) {
 // Remove deletes value from map by given `key`, and return this deleted value.
 func (m *StrStrMap) Remove(key string) (value string) {
 	m.mu.Lock()
+	m.copyOnWrite()
 	if m.data != nil {
 		var ok bool
 		if value, ok = m.data[key]; ok {
@@ -373,6 +425,7 @@ func (m *StrStrMap) IsEmpty() bool {
 // Clear deletes all data of the map, it will remake a new underlying data map.
 func (m *StrStrMap) Clear() {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = make(map[string]string)
 	m.mu.Unlock()
 }
@@ -380,6 +433,7 @@ func (m *StrStrMap) Clear() {
 // Replace the data of the map with given `data`.
 func (m *StrStrMap) Replace(data map[string]string) {
 	m.mu.Lock()
+	m.cow = m.cow.renew()
 	m.data = data
 	m.mu.Unlock()
 }
@@ -388,6 +442,7 @@ func (m *StrStrMap) Replace(data map[string]string) {
 func (m *StrStrMap) LockFunc(f func(m map[string]string)) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	f(m.data)
 }
 
@@ -402,6 +457,7 @@ func (m *StrStrMap) RLockFunc(f func(m map[string]string)) {
 func (m *StrStrMap) Flip() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.cow = m.cow.renew()
 	n := make(map[string]string, len(m.data))
 	for k, v := range m.data {
 		n[v] = k
@@ -414,6 +470,7 @@ func (m *StrStrMap) Flip() {
 func (m *StrStrMap) Merge(other *StrStrMap) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = other.MapCopy()
 		return
@@ -447,6 +504,7 @@ func (m StrStrMap) MarshalJSON() ([]byte, error) {
 func (m *StrStrMap) UnmarshalJSON(b []byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	if m.data == nil {
 		m.data = make(map[string]string)
 	}
@@ -456,10 +514,44 @@ func (m *StrStrMap) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// GobEncode implements the interface GobEncoder for gob encoding.
+// Note that the custom types of keys and values should be registered using gob.Register.
+func (m *StrStrMap) GobEncode() ([]byte, error) {
+	data := newMapGobData(m.mu.IsSafe(), m.Size())
+	m.Iterator(func(k string, v string) bool {
+		data.add(k, v)
+		return true
+	})
+	return data.encode()
+}
+
+// GobDecode implements the interface GobDecoder for gob decoding.
+// The decoded map is concurrent-safe if the encoded one is.
+func (m *StrStrMap) GobDecode(b []byte) error {
+	data, err := decodeMapGobData(b)
+	if err != nil {
+		return err
+	}
+	if data.Safe && !m.mu.IsSafe() {
+		m.mu = rwmutex.Create(true)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.copyOnWrite()
+	if m.data == nil {
+		m.data = make(map[string]string, len(data.Keys))
+	}
+	for i, k := range data.Keys {
+		m.data[gconv.String(k)] = gconv.String(data.Values[i])
+	}
+	return nil
+}
+
 // UnmarshalValue is an interface implement which sets any type of value for map.
 func (m *StrStrMap) UnmarshalValue(value interface{}) (err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.copyOnWrite()
 	m.data = gconv.MapStrStr(value)
 	return
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/gogf/gf/v2/internal/empty"
 	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/internal/rwmutex"
+	"github.com/gogf/gf/v2/internal/typehint"
 	"github.com/gogf/gf/v2/util/gconv"
 )
 
@@ -27,9 +28,10 @@ import (
 //
 // Reference: http://en.wikipedia.org/wiki/Associative_array
 type ListMap struct {
-	mu   rwmutex.RWMutex
-	data map[interface{}]*glist.Element
-	list *glist.List
+	mu       rwmutex.RWMutex
+	data     map[interface{}]*glist.Element
+	list     *glist.List
+	typeHint bool // Whether marshaling JSON with type hints, see SetTypeHint.
 }
 
 type gListMapNode struct {
@@ -529,14 +531,39 @@ func (m *ListMap) String() string {
 	return string(b)
 }
 
+// SetTypeHint enables or disables marshaling JSON with type hints, which keeps the Go types of values,
+// like int64 and nested containers, through JSON marshaling and unmarshaling.
+// The values with type hints are restored by UnmarshalJSON no matter it is enabled or not.
+func (m *ListMap) SetTypeHint(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.typeHint = enabled
+}
+
 // MarshalJSON implements the interface MarshalJSON for json.Marshal.
 func (m ListMap) MarshalJSON() (jsonBytes []byte, err error) {
+	return m.marshalJSON(m.typeHint)
+}
+
+// MarshalJSONWithTypeHint marshals the map to JSON with type hints of values, see SetTypeHint.
+// Note that the keys are marshaled as strings.
+func (m *ListMap) MarshalJSONWithTypeHint() ([]byte, error) {
+	return m.marshalJSON(true)
+}
+
+// marshalJSON marshals the map to JSON in order, with type hints of values if `typeHint` is true.
+func (m *ListMap) marshalJSON(typeHint bool) (jsonBytes []byte, err error) {
 	if m.data == nil {
 		return []byte("null"), nil
 	}
 	buffer := bytes.NewBuffer(nil)
 	buffer.WriteByte('{')
 	m.Iterator(func(key, value interface{}) bool {
+		if typeHint {
+			if value, err = typehint.Encode(value); err != nil {
+				return false
+			}
+		}
 		valueBytes, valueJsonErr := json.Marshal(value)
 		if valueJsonErr != nil {
 			err = valueJsonErr
@@ -548,6 +575,9 @@ func (m ListMap) MarshalJSON() (jsonBytes []byte, err error) {
 		buffer.WriteString(fmt.Sprintf(`"%v":%s`, key, valueBytes))
 		return true
 	})
+	if err != nil {
+		return nil, err
+	}
 	buffer.WriteByte('}')
 	return buffer.Bytes(), nil
 }
@@ -560,8 +590,8 @@ func (m *ListMap) UnmarshalJSON(b []byte) error {
 		m.data = make(map[interface{}]*glist.Element)
 		m.list = glist.New()
 	}
-	var data map[string]interface{}
-	if err := json.UnmarshalUseNumber(b, &data); err != nil {
+	data, err := unmarshalJSONMap(b)
+	if err != nil {
 		return err
 	}
 	for key, value := range data {
@@ -574,6 +604,44 @@ func (m *ListMap) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// GobEncode implements the interface GobEncoder for gob encoding, which keeps the order of keys.
+// Note that the custom types of keys and values should be registered using gob.Register.
+func (m *ListMap) GobEncode() ([]byte, error) {
+	data := newMapGobData(m.mu.IsSafe(), m.Size())
+	m.Iterator(func(k, v interface{}) bool {
+		data.add(k, v)
+		return true
+	})
+	return data.encode()
+}
+
+// GobDecode implements the interface GobDecoder for gob decoding.
+// The decoded map is concurrent-safe if the encoded one is.
+func (m *ListMap) GobDecode(b []byte) error {
+	data, err := decodeMapGobData(b)
+	if err != nil {
+		return err
+	}
+	if data.Safe && !m.mu.IsSafe() {
+		m.mu = rwmutex.Create(true)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.data == nil {
+		m.data = make(map[interface{}]*glist.Element)
+		m.list = glist.New()
+	}
+	for i, k := range data.Keys {
+		v := data.Values[i]
+		if e, ok := m.data[k]; !ok {
+			m.data[k] = m.list.PushBack(&gListMapNode{k, v})
+		} else {
+			e.Value = &gListMapNode{k, v}
+		}
+	}
+	return nil
+}
+
 // UnmarshalValue is an interface implement which sets any type of value for map.
 func (m *ListMap) UnmarshalValue(value interface{}) (err error) {
 	m.mu.Lock()
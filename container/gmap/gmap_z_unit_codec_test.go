@@ -0,0 +1,148 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with gm file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmap_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/glist"
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func gobRoundTrip(t *gtest.T, value interface{}, pointer interface{}) {
+	buffer := bytes.NewBuffer(nil)
+	t.AssertNil(gob.NewEncoder(buffer).Encode(value))
+	t.AssertNil(gob.NewDecoder(buffer).Decode(pointer))
+}
+
+func Test_Map_Gob(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewStrAnyMap(true)
+		m.Set("int64", int64(1))
+		m.Set("list", glist.NewFrom([]interface{}{1, "a"}, true))
+		m.Set("map", gmap.NewIntStrMapFrom(map[int]string{1: "a"}))
+
+		var decoded gmap.StrAnyMap
+		gobRoundTrip(t, m, &decoded)
+		t.AssertEQ(decoded.Get("int64"), int64(1))
+		t.Assert(decoded.Get("list").(*glist.List).FrontAll(), []interface{}{1, "a"})
+		t.Assert(decoded.Get("map").(*gmap.IntStrMap).Get(1), "a")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewAnyAnyMap()
+		m.Set(1, "int key")
+		m.Set("a", uint8(1))
+		var decoded gmap.AnyAnyMap
+		gobRoundTrip(t, m, &decoded)
+		t.Assert(decoded.Get(1), "int key")
+		t.AssertEQ(decoded.Get("a"), uint8(1))
+	})
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewListMap()
+		m.Set("b", 1)
+		m.Set("a", 2)
+		m.Set(3, 3)
+		var decoded gmap.ListMap
+		gobRoundTrip(t, m, &decoded)
+		t.Assert(decoded.Keys(), []interface{}{"b", "a", 3})
+		t.Assert(decoded.Values(), []interface{}{1, 2, 3})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			intInt gmap.IntIntMap
+			intStr gmap.IntStrMap
+			strInt gmap.StrIntMap
+			strStr gmap.StrStrMap
+			intAny gmap.IntAnyMap
+		)
+		gobRoundTrip(t, gmap.NewIntIntMapFrom(map[int]int{1: 2}), &intInt)
+		gobRoundTrip(t, gmap.NewIntStrMapFrom(map[int]string{1: "a"}), &intStr)
+		gobRoundTrip(t, gmap.NewStrIntMapFrom(map[string]int{"a": 1}), &strInt)
+		gobRoundTrip(t, gmap.NewStrStrMapFrom(map[string]string{"a": "b"}), &strStr)
+		gobRoundTrip(t, gmap.NewIntAnyMapFrom(map[int]interface{}{1: 1.5}), &intAny)
+		t.Assert(intInt.Map(), map[int]int{1: 2})
+		t.Assert(intStr.Map(), map[int]string{1: "a"})
+		t.Assert(strInt.Map(), map[string]int{"a": 1})
+		t.Assert(strStr.Map(), map[string]string{"a": "b"})
+		t.AssertEQ(intAny.Get(1), 1.5)
+	})
+	// The map as struct field.
+	gtest.C(t, func(t *gtest.T) {
+		type Session struct {
+			Id   string
+			Data *gmap.StrStrMap
+		}
+		var decoded Session
+		gobRoundTrip(t, Session{Id: "1", Data: gmap.NewStrStrMapFrom(map[string]string{"a": "b"}, true)}, &decoded)
+		t.Assert(decoded.Id, "1")
+		t.Assert(decoded.Data.Map(), map[string]string{"a": "b"})
+	})
+}
+
+func Test_Map_Json_TypeHint(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		nested := gmap.NewStrIntMapFrom(map[string]int{"a": 1})
+		m := gmap.NewStrAnyMap(true)
+		m.SetTypeHint(true)
+		m.Set("int64", int64(1))
+		m.Set("string", "s")
+		m.Set("list", glist.NewFrom([]interface{}{int8(1), gmap.NewIntAnyMapFrom(map[int]interface{}{1: uint(2)})}))
+		m.Set("map", nested)
+		m.Set("slice", []interface{}{1, "a"})
+
+		b, err := json.Marshal(m)
+		t.AssertNil(err)
+		var decoded gmap.StrAnyMap
+		t.AssertNil(json.Unmarshal(b, &decoded))
+		t.AssertEQ(decoded.Get("int64"), int64(1))
+		t.AssertEQ(decoded.Get("string"), "s")
+		t.Assert(decoded.Get("map").(*gmap.StrIntMap).Map(), nested.Map())
+		t.AssertEQ(decoded.Get("slice"), []interface{}{1, "a"})
+
+		list := decoded.Get("list").(*glist.List)
+		t.Assert(list.Len(), 2)
+		t.AssertEQ(list.Front().Value, int8(1))
+		t.AssertEQ(list.Back().Value.(*gmap.IntAnyMap).Get(1), uint(2))
+
+		// The nested containers are decoded with type hint enabled, which marshals symmetrically.
+		b2, err := json.Marshal(list)
+		t.AssertNil(err)
+		b3, err := m.Get("list").(*glist.List).MarshalJSONWithTypeHint()
+		t.AssertNil(err)
+		t.Assert(b2, b3)
+	})
+	// Without type hints, the values are marshaled as they were.
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewStrAnyMapFrom(map[string]interface{}{"a": int64(1)})
+		b, err := json.Marshal(m)
+		t.AssertNil(err)
+		t.Assert(b, `{"a":1}`)
+		b, err = m.MarshalJSONWithTypeHint()
+		t.AssertNil(err)
+		t.Assert(b, `{"a":{"@type":"int64","@value":1}}`)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewListMap()
+		m.SetTypeHint(true)
+		m.Set("b", int16(1))
+		m.Set("a", "v")
+		b, err := json.Marshal(m)
+		t.AssertNil(err)
+		t.Assert(b, `{"b":{"@type":"int16","@value":1},"a":"v"}`)
+		var decoded gmap.ListMap
+		t.AssertNil(json.Unmarshal(b, &decoded))
+		t.AssertEQ(decoded.Get("b"), int16(1))
+
+		var anyAny gmap.AnyAnyMap
+		t.AssertNil(json.Unmarshal(b, &anyAny))
+		t.AssertEQ(anyAny.Get("b"), int16(1))
+	})
+}
@@ -391,3 +391,50 @@ func Test_AnyAnyMap_DeepCopy(t *testing.T) {
 		t.AssertNE(m.Get("k1"), n.Get("k1"))
 	})
 }
+
+func Test_AnyAnyMap_CopyOnWrite(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewAnyAnyMapFrom(g.MapAnyAny{1: 1, 2: 2, 3: 3}, true)
+		m.SetCopyOnWrite(true)
+		// Writing within iteration does not block or change the snapshot.
+		keys := garray.New()
+		m.Iterator(func(k interface{}, v interface{}) bool {
+			m.Set(gconv.Int(k)*10, v)
+			m.Remove(k)
+			keys.Append(k)
+			return true
+		})
+		t.Assert(keys.Len(), 3)
+		t.Assert(m.Size(), 3)
+		t.Assert(m.Get(10), 1)
+		t.Assert(m.Get(1), nil)
+
+		// Writers are not blocked by long iteration.
+		var (
+			done    = make(chan struct{})
+			written = make(chan struct{})
+		)
+		go m.Iterator(func(k interface{}, v interface{}) bool {
+			<-done
+			return true
+		})
+		time.Sleep(50 * time.Millisecond)
+		go func() {
+			m.Set(4, 4)
+			m.Clear()
+			m.Set(5, 5)
+			close(written)
+		}()
+		select {
+		case <-written:
+		case <-time.After(time.Second):
+			t.Error("writing is blocked by iteration")
+		}
+		close(done)
+		t.Assert(m.Map(), g.MapAnyAny{5: 5})
+
+		m.SetCopyOnWrite(false)
+		m.Set(6, 6)
+		t.Assert(m.Size(), 2)
+	})
+}
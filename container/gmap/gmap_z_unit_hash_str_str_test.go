@@ -385,3 +385,18 @@ func Test_StrStrMap_DeepCopy(t *testing.T) {
 		t.AssertNE(m.Get("key1"), n.Get("key1"))
 	})
 }
+
+func Test_StrStrMap_CopyOnWrite(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewStrStrMapFrom(g.MapStrStr{"a": "1", "b": "2"}, true)
+		m.SetCopyOnWrite(true)
+		snapshot := make(map[string]string)
+		m.Iterator(func(k string, v string) bool {
+			m.Flip()
+			snapshot[k] = v
+			return true
+		})
+		t.Assert(snapshot, g.MapStrStr{"a": "1", "b": "2"})
+		t.Assert(m.Map(), g.MapStrStr{"a": "1", "b": "2"})
+	})
+}
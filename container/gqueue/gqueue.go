@@ -16,13 +16,19 @@
 //
 // 4. Blocking when reading data from queue;
 //
+// 5. Optional disk-backed queue surviving process restarts;
+//
+// 6. Tracing propagation from producer to consumer;
+//
 package gqueue
 
 import (
+	"context"
 	"math"
 
 	"github.com/gogf/gf/v2/container/glist"
 	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/net/gtrace"
 )
 
 // Queue is a concurrent-safe queue built on doubly linked list and channel.
@@ -34,6 +40,12 @@ type Queue struct {
 	C      chan interface{} // Underlying channel for data reading.
 }
 
+// ctxItem is the item pushed by PushCtx, which carries the tracing content and baggage of the producer.
+type ctxItem struct {
+	carrier gtrace.Carrier
+	value   interface{}
+}
+
 const (
 	defaultQueueSize = 10000 // Size for queue buffer.
 	defaultBatchSize = 10    // Max batch size per-fetching from list.
@@ -105,10 +117,38 @@ func (q *Queue) Push(v interface{}) {
 	}
 }
 
+// PushCtx pushes the data `v` into the queue along with the tracing content and baggage of `ctx`,
+// which are restored by PopCtx, so that the consumer continues the tracing of the producer.
+// Note that the items pushed by PushCtx should be popped by Pop or PopCtx rather than reading `q.C` directly.
+func (q *Queue) PushCtx(ctx context.Context, v interface{}) {
+	q.Push(&ctxItem{
+		carrier: gtrace.InjectCarrier(ctx),
+		value:   v,
+	})
+}
+
 // Pop pops an item from the queue in FIFO way.
 // Note that it would return nil immediately if Pop is called after the queue is closed.
 func (q *Queue) Pop() interface{} {
-	return <-q.C
+	v := <-q.C
+	if item, ok := v.(*ctxItem); ok {
+		return item.value
+	}
+	return v
+}
+
+// PopCtx pops an item from the queue in FIFO way, and returns it along with the context derived from `ctx`,
+// which carries the tracing content and baggage of the producer if the item is pushed by PushCtx.
+// Note that it would return nil immediately if PopCtx is called after the queue is closed.
+func (q *Queue) PopCtx(ctx context.Context) (context.Context, interface{}) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	v := <-q.C
+	if item, ok := v.(*ctxItem); ok {
+		return gtrace.ExtractCarrier(ctx, item.carrier), item.value
+	}
+	return ctx, v
 }
 
 // Close closes the queue.
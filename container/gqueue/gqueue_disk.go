@@ -0,0 +1,461 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gqueue
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/net/gtrace"
+)
+
+// DiskSyncPolicy is the fsync policy of the WAL file of DiskQueue.
+type DiskSyncPolicy int
+
+const (
+	DiskSyncInterval DiskSyncPolicy = iota // Fsync the WAL file periodically, which is the default policy.
+	DiskSyncAlways                         // Fsync the WAL file after every writing, which is the safest but slowest.
+	DiskSyncNone                           // Never fsync the WAL file, but leave it to the operating system.
+)
+
+const (
+	defaultDiskSyncInterval = time.Second     // Default interval for DiskSyncInterval policy.
+	defaultDiskCompactSize  = 4 * 1024 * 1024 // Default WAL file size in bytes that triggers compaction.
+	diskRecordOpPush        = byte(1)         // Record operation for pushing.
+	diskRecordOpPop         = byte(2)         // Record operation for popping.
+	diskRecordOpPushCtx     = byte(3)         // Record operation for pushing along with the tracing carrier.
+	diskRecordHeaderSize    = 9               // Record header: 1 byte operation, 4 bytes length and 4 bytes checksum.
+	diskRecordMaxSize       = 1 << 30         // Max payload size of a record, which is used to detect corruption.
+)
+
+// DiskOption is the option for DiskQueue.
+type DiskOption struct {
+	Path         string         // Path of the WAL file, which is required.
+	SyncPolicy   DiskSyncPolicy // Fsync policy of the WAL file, which is DiskSyncInterval in default.
+	SyncInterval time.Duration  // Fsync interval for DiskSyncInterval policy, which is 1 second in default.
+	CompactSize  int64          // WAL file size in bytes that triggers compaction, which is 4MB in default.
+}
+
+// diskRecord is the pushing record in the WAL file.
+type diskRecord struct {
+	op      byte
+	payload []byte
+}
+
+// diskCtxPayload is the payload of the record pushed by PushCtx.
+type diskCtxPayload struct {
+	Carrier map[string]interface{} `json:"carrier"`
+	Value   interface{}            `json:"value"`
+}
+
+// DiskQueue is a concurrent-safe unlimited queue backed by a write-ahead log(WAL) file,
+// so that the items that are pushed but not popped survive process restarts.
+//
+// The items are stored as JSON in the WAL file, so the restored items after restarts are the
+// JSON decoded values, which can be converted to the original type using Var.Scan.
+// The item is removed from the WAL file once it is popped, so it is delivered at most once.
+type DiskQueue struct {
+	mu      sync.Mutex
+	option  DiskOption
+	file    *os.File      // WAL file in appending mode, which is nil if the queue is closed.
+	size    int64         // Size of the WAL file.
+	pending int           // Count of items in the WAL file that are pushed but not popped.
+	popped  int           // Count of popping records in the WAL file.
+	dirty   bool          // Whether there's writing not synced to the WAL file.
+	queue   *Queue        // Underlying memory queue for items delivering.
+	closed  *gtype.Bool   // Whether the queue is closed.
+	done    chan struct{} // Notifies the syncing loop to exit.
+}
+
+// NewDisk creates and returns a disk-backed queue with given `option`.
+// It replays the WAL file if it exists, and the items not popped before are restored to the queue.
+// If the WAL file is corrupted, for example, the process crashed when writing,
+// the records after the corrupted position are dropped and the WAL file is repaired.
+func NewDisk(option DiskOption) (*DiskQueue, error) {
+	if option.Path == "" {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `WAL file path should not be empty`)
+	}
+	if option.SyncInterval <= 0 {
+		option.SyncInterval = defaultDiskSyncInterval
+	}
+	if option.CompactSize <= 0 {
+		option.CompactSize = defaultDiskCompactSize
+	}
+	if err := os.MkdirAll(filepath.Dir(option.Path), 0755); err != nil {
+		return nil, gerror.Wrapf(err, `create directory for WAL file "%s" failed`, option.Path)
+	}
+	records, err := readDiskQueueFile(option.Path)
+	if err != nil {
+		return nil, err
+	}
+	q := &DiskQueue{
+		option: option,
+		queue:  New(),
+		closed: gtype.NewBool(),
+		done:   make(chan struct{}),
+	}
+	// It always rewrites the WAL file when opening, which removes the popped and corrupted records.
+	if err = q.rewriteWithoutLock(records); err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		item, err := decodeDiskRecord(record)
+		if err != nil {
+			intlog.Errorf(context.TODO(), `decode item from WAL file "%s" failed: %+v`, option.Path, err)
+		}
+		q.queue.Push(item)
+	}
+	if option.SyncPolicy == DiskSyncInterval {
+		go q.syncLoop()
+	}
+	return q, nil
+}
+
+// Push pushes the data `v` into the queue, which is written to the WAL file before it returns.
+// The data `v` should be able to be encoded as JSON.
+func (q *DiskQueue) Push(v interface{}) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return gerror.Wrap(err, `encode item for WAL file failed`)
+	}
+	return q.doPush(diskRecordOpPush, payload, v)
+}
+
+// PushCtx pushes the data `v` into the queue along with the tracing content and baggage of `ctx`,
+// which are written to the WAL file along with `v` and restored by PopCtx, even after restarts.
+// The data `v` should be able to be encoded as JSON.
+func (q *DiskQueue) PushCtx(ctx context.Context, v interface{}) error {
+	carrier := gtrace.InjectCarrier(ctx)
+	payload, err := json.Marshal(diskCtxPayload{
+		Carrier: carrier,
+		Value:   v,
+	})
+	if err != nil {
+		return gerror.Wrap(err, `encode item for WAL file failed`)
+	}
+	return q.doPush(diskRecordOpPushCtx, payload, &ctxItem{
+		carrier: carrier,
+		value:   v,
+	})
+}
+
+// doPush writes the pushing record of operation `op` and `payload` to the WAL file,
+// and pushes `item` into the memory queue.
+func (q *DiskQueue) doPush(op byte, payload []byte, item interface{}) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.file == nil {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `queue is closed`)
+	}
+	if err := q.writeWithoutLock(op, payload); err != nil {
+		return err
+	}
+	q.pending++
+	q.queue.Push(item)
+	return nil
+}
+
+// Pop pops an item from the queue in FIFO way, which blocks if the queue is empty.
+// Note that it returns nil immediately if Pop is called after the queue is closed.
+func (q *DiskQueue) Pop() *gvar.Var {
+	v, ok := q.doPop()
+	if !ok {
+		return nil
+	}
+	if item, ok := v.(*ctxItem); ok {
+		return gvar.New(item.value)
+	}
+	return gvar.New(v)
+}
+
+// PopCtx pops an item from the queue in FIFO way, which blocks if the queue is empty.
+// It returns the item along with the context derived from `ctx`, which carries the tracing content
+// and baggage of the producer if the item is pushed by PushCtx.
+// Note that it returns nil immediately if PopCtx is called after the queue is closed.
+func (q *DiskQueue) PopCtx(ctx context.Context) (context.Context, *gvar.Var) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	v, ok := q.doPop()
+	if !ok {
+		return ctx, nil
+	}
+	if item, ok := v.(*ctxItem); ok {
+		return gtrace.ExtractCarrier(ctx, item.carrier), gvar.New(item.value)
+	}
+	return ctx, gvar.New(v)
+}
+
+// doPop pops an item from the memory queue and writes the popping record to the WAL file.
+// It returns false if the queue is closed.
+func (q *DiskQueue) doPop() (interface{}, bool) {
+	v, ok := <-q.queue.C
+	if !ok {
+		return nil, false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.file == nil {
+		// The item is kept in the WAL file and restored next time.
+		return nil, false
+	}
+	if err := q.writeWithoutLock(diskRecordOpPop, nil); err != nil {
+		intlog.Errorf(context.TODO(), `%+v`, err)
+	}
+	q.pending--
+	q.popped++
+	if q.size >= q.option.CompactSize && q.popped >= q.pending {
+		if err := q.compactWithoutLock(); err != nil {
+			intlog.Errorf(context.TODO(), `%+v`, err)
+		}
+	}
+	return v, true
+}
+
+// Len returns the length of the queue.
+func (q *DiskQueue) Len() int {
+	return q.queue.Len()
+}
+
+// Size is alias of Len.
+func (q *DiskQueue) Size() int {
+	return q.Len()
+}
+
+// Sync commits the writing of the WAL file to stable storage.
+func (q *DiskQueue) Sync() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.syncWithoutLock()
+}
+
+// Close syncs and closes the WAL file, and closes the queue.
+// The items not popped are kept in the WAL file and restored when the queue is created next time.
+func (q *DiskQueue) Close() error {
+	if !q.closed.Cas(false, true) {
+		return nil
+	}
+	close(q.done)
+	q.mu.Lock()
+	err := q.syncWithoutLock()
+	if q.file != nil {
+		if closeErr := q.file.Close(); closeErr != nil && err == nil {
+			err = gerror.Wrapf(closeErr, `close WAL file "%s" failed`, q.option.Path)
+		}
+		q.file = nil
+	}
+	q.mu.Unlock()
+	q.queue.Close()
+	return err
+}
+
+// syncLoop syncs the WAL file periodically for DiskSyncInterval policy.
+func (q *DiskQueue) syncLoop() {
+	ticker := time.NewTicker(q.option.SyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-q.done:
+			return
+		case <-ticker.C:
+			if err := q.Sync(); err != nil {
+				intlog.Errorf(context.TODO(), `%+v`, err)
+			}
+		}
+	}
+}
+
+// syncWithoutLock syncs the WAL file if there's writing not synced.
+func (q *DiskQueue) syncWithoutLock() error {
+	if q.file == nil || !q.dirty {
+		return nil
+	}
+	if err := q.file.Sync(); err != nil {
+		return gerror.Wrapf(err, `sync WAL file "%s" failed`, q.option.Path)
+	}
+	q.dirty = false
+	return nil
+}
+
+// writeWithoutLock writes a record of operation `op` and `payload` to the WAL file.
+func (q *DiskQueue) writeWithoutLock(op byte, payload []byte) error {
+	n, err := q.file.Write(encodeDiskRecord(op, payload))
+	q.size += int64(n)
+	if err != nil {
+		return gerror.Wrapf(err, `write WAL file "%s" failed`, q.option.Path)
+	}
+	q.dirty = true
+	if q.option.SyncPolicy == DiskSyncAlways {
+		return q.syncWithoutLock()
+	}
+	return nil
+}
+
+// compactWithoutLock removes the popped records from the WAL file.
+func (q *DiskQueue) compactWithoutLock() error {
+	if err := q.syncWithoutLock(); err != nil {
+		return err
+	}
+	records := make([]diskRecord, 0)
+	if q.pending > 0 {
+		var err error
+		if records, err = readDiskQueueFile(q.option.Path); err != nil {
+			return err
+		}
+	}
+	if err := q.file.Close(); err != nil {
+		return gerror.Wrapf(err, `close WAL file "%s" failed`, q.option.Path)
+	}
+	return q.rewriteWithoutLock(records)
+}
+
+// rewriteWithoutLock rewrites the WAL file with pushing `records`, and reopens it for appending.
+// The WAL file is replaced atomically by renaming a temporary file.
+func (q *DiskQueue) rewriteWithoutLock(records []diskRecord) (err error) {
+	var (
+		path    = q.option.Path
+		tmpPath = path + ".tmp"
+		size    int64
+	)
+	tmpFile, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return gerror.Wrapf(err, `open temporary WAL file "%s" failed`, tmpPath)
+	}
+	writer := bufio.NewWriter(tmpFile)
+	for _, record := range records {
+		n, _ := writer.Write(encodeDiskRecord(record.op, record.payload))
+		size += int64(n)
+	}
+	if err = writer.Flush(); err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		return gerror.Wrapf(err, `rewrite WAL file "%s" failed`, path)
+	}
+	if q.file, err = os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644); err != nil {
+		q.file = nil
+		return gerror.Wrapf(err, `open WAL file "%s" failed`, path)
+	}
+	q.size = size
+	q.pending = len(records)
+	q.popped = 0
+	q.dirty = false
+	return nil
+}
+
+// encodeDiskRecord encodes and returns the WAL record of operation `op` and `payload`.
+func encodeDiskRecord(op byte, payload []byte) []byte {
+	record := make([]byte, diskRecordHeaderSize+len(payload))
+	record[0] = op
+	binary.BigEndian.PutUint32(record[1:5], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[5:9], diskRecordChecksum(op, payload))
+	copy(record[diskRecordHeaderSize:], payload)
+	return record
+}
+
+// decodeDiskRecord decodes and returns the item of pushing `record`,
+// which is *ctxItem if the record is pushed along with the tracing carrier.
+func decodeDiskRecord(record diskRecord) (interface{}, error) {
+	if record.op != diskRecordOpPushCtx {
+		var value interface{}
+		err := json.UnmarshalUseNumber(record.payload, &value)
+		return value, err
+	}
+	var payload diskCtxPayload
+	if err := json.UnmarshalUseNumber(record.payload, &payload); err != nil {
+		return nil, err
+	}
+	return &ctxItem{
+		carrier: gtrace.NewCarrier(payload.Carrier),
+		value:   payload.Value,
+	}, nil
+}
+
+// diskRecordChecksum calculates and returns the checksum of the WAL record.
+func diskRecordChecksum(op byte, payload []byte) uint32 {
+	return crc32.Update(crc32.ChecksumIEEE([]byte{op}), crc32.IEEETable, payload)
+}
+
+// readDiskQueueFile replays the WAL file of `path`, and returns the pushing records of items that are not popped.
+// It stops replaying at the first corrupted record, and the following records are dropped.
+func readDiskQueueFile(path string) ([]diskRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, gerror.Wrapf(err, `open WAL file "%s" failed`, path)
+	}
+	defer file.Close()
+	var (
+		reader  = bufio.NewReader(file)
+		header  = make([]byte, diskRecordHeaderSize)
+		records = make([]diskRecord, 0)
+		popped  = 0
+		offset  int64
+	)
+	for {
+		if _, err = io.ReadFull(reader, header); err != nil {
+			break
+		}
+		var (
+			op       = header[0]
+			length   = binary.BigEndian.Uint32(header[1:5])
+			checksum = binary.BigEndian.Uint32(header[5:9])
+		)
+		if (op != diskRecordOpPush && op != diskRecordOpPop && op != diskRecordOpPushCtx) || length > diskRecordMaxSize {
+			err = gerror.NewCodef(gcode.CodeInternalError, `invalid record header at offset %d`, offset)
+			break
+		}
+		payload := make([]byte, length)
+		if _, err = io.ReadFull(reader, payload); err != nil {
+			break
+		}
+		if diskRecordChecksum(op, payload) != checksum {
+			err = gerror.NewCodef(gcode.CodeInternalError, `checksum mismatch at offset %d`, offset)
+			break
+		}
+		if op == diskRecordOpPop {
+			if popped >= len(records) {
+				err = gerror.NewCodef(gcode.CodeInternalError, `unexpected popping record at offset %d`, offset)
+				break
+			}
+			records[popped] = diskRecord{}
+			popped++
+		} else {
+			records = append(records, diskRecord{op: op, payload: payload})
+		}
+		offset += int64(diskRecordHeaderSize) + int64(length)
+	}
+	if err != nil && err != io.EOF {
+		intlog.Errorf(
+			context.TODO(),
+			`WAL file "%s" is corrupted, records after offset %d are dropped: %v`,
+			path, offset, err,
+		)
+	}
+	return records[popped:], nil
+}
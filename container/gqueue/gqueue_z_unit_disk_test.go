@@ -0,0 +1,165 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gqueue_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gqueue"
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func TestDiskQueue_Restore(t *testing.T) {
+	type Item struct {
+		Id   int
+		Name string
+	}
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Temp("gqueue_disk_restore", "queue.wal")
+		defer gfile.Remove(gfile.Dir(path))
+
+		q, err := gqueue.NewDisk(gqueue.DiskOption{
+			Path:       path,
+			SyncPolicy: gqueue.DiskSyncAlways,
+		})
+		t.AssertNil(err)
+		for i := 1; i <= 5; i++ {
+			t.AssertNil(q.Push(Item{Id: i, Name: "john"}))
+		}
+		var item *Item
+		t.AssertNil(q.Pop().Scan(&item))
+		t.Assert(item.Id, 1)
+		t.AssertNil(q.Pop().Scan(&item))
+		t.Assert(item.Id, 2)
+		t.AssertNil(q.Close())
+		t.AssertNE(q.Push(1), nil)
+		t.AssertNil(q.Close())
+
+		// Restarting.
+		q, err = gqueue.NewDisk(gqueue.DiskOption{Path: path})
+		t.AssertNil(err)
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(q.Len(), 3)
+		t.AssertNil(q.Pop().Scan(&item))
+		t.Assert(item.Id, 3)
+		t.Assert(item.Name, "john")
+		t.AssertNil(q.Push(Item{Id: 6}))
+		t.AssertNil(q.Close())
+
+		q, err = gqueue.NewDisk(gqueue.DiskOption{Path: path, SyncPolicy: gqueue.DiskSyncNone})
+		t.AssertNil(err)
+		ids := make([]int, 0)
+		for i := 0; i < 3; i++ {
+			t.AssertNil(q.Pop().Scan(&item))
+			ids = append(ids, item.Id)
+		}
+		t.Assert(ids, []int{4, 5, 6})
+		t.AssertNil(q.Close())
+		t.Assert(q.Pop(), nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gqueue.NewDisk(gqueue.DiskOption{})
+		t.AssertNE(err, nil)
+	})
+}
+
+func TestDiskQueue_Corruption(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Temp("gqueue_disk_corruption", "queue.wal")
+		defer gfile.Remove(gfile.Dir(path))
+
+		q, err := gqueue.NewDisk(gqueue.DiskOption{Path: path})
+		t.AssertNil(err)
+		t.AssertNil(q.Push("a"))
+		t.AssertNil(q.Push("b"))
+		t.AssertNil(q.Push("c"))
+		t.AssertNil(q.Close())
+
+		// Truncates the last record like crashing when writing.
+		t.AssertNil(os.Truncate(path, gfile.Size(path)-1))
+
+		q, err = gqueue.NewDisk(gqueue.DiskOption{Path: path})
+		t.AssertNil(err)
+		t.Assert(q.Pop(), "a")
+		t.Assert(q.Pop(), "b")
+		t.AssertNil(q.Push("d"))
+		t.Assert(q.Pop(), "d")
+		t.AssertNil(q.Close())
+
+		// Corrupts the checksum.
+		content := gfile.GetBytes(path)
+		content[5] ^= 0xff
+		t.AssertNil(gfile.PutBytes(path, content))
+		q, err = gqueue.NewDisk(gqueue.DiskOption{Path: path})
+		t.AssertNil(err)
+		t.Assert(q.Len(), 0)
+		t.AssertNil(q.Close())
+	})
+}
+
+func TestDiskQueue_Compact(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Temp("gqueue_disk_compact", "queue.wal")
+		defer gfile.Remove(gfile.Dir(path))
+
+		q, err := gqueue.NewDisk(gqueue.DiskOption{Path: path, CompactSize: 100})
+		t.AssertNil(err)
+		for i := 0; i < 20; i++ {
+			t.AssertNil(q.Push(i))
+		}
+		for i := 0; i < 15; i++ {
+			t.Assert(q.Pop(), i)
+		}
+		t.Assert(gfile.Size(path) < 100, true)
+		t.AssertNil(q.Close())
+
+		q, err = gqueue.NewDisk(gqueue.DiskOption{Path: path})
+		t.AssertNil(err)
+		for i := 15; i < 20; i++ {
+			t.Assert(q.Pop(), i)
+		}
+		t.AssertNil(q.Close())
+	})
+}
+
+func TestDiskQueue_PushCtx(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Temp("gqueue_disk_ctx", "queue.wal")
+		defer gfile.Remove(gfile.Dir(path))
+
+		q, err := gqueue.NewDisk(gqueue.DiskOption{Path: path})
+		t.AssertNil(err)
+		ctx := gtrace.SetBaggageValue(context.Background(), "user", "john")
+		t.AssertNil(q.PushCtx(ctx, 1))
+		t.AssertNil(q.Push(2))
+		t.AssertNil(q.PushCtx(ctx, 3))
+
+		popCtx, v := q.PopCtx(context.Background())
+		t.Assert(v.Int(), 1)
+		t.Assert(gtrace.GetBaggageString(popCtx, "user"), "john")
+		t.AssertNil(q.Close())
+
+		// The tracing content is restored after restarting.
+		q, err = gqueue.NewDisk(gqueue.DiskOption{Path: path})
+		t.AssertNil(err)
+		popCtx, v = q.PopCtx(context.Background())
+		t.Assert(v.Int(), 2)
+		t.Assert(gtrace.GetBaggageString(popCtx, "user"), "")
+		popCtx, v = q.PopCtx(context.Background())
+		t.Assert(v.Int(), 3)
+		t.Assert(gtrace.GetBaggageString(popCtx, "user"), "john")
+		t.AssertNil(q.Close())
+
+		_, v = q.PopCtx(context.Background())
+		t.AssertNil(v)
+	})
+}
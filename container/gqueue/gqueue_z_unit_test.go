@@ -9,10 +9,12 @@
 package gqueue_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/gogf/gf/v2/container/gqueue"
+	"github.com/gogf/gf/v2/net/gtrace"
 	"github.com/gogf/gf/v2/test/gtest"
 )
 
@@ -71,3 +73,24 @@ func TestQueue_Close(t *testing.T) {
 		q1.Close()
 	})
 }
+
+func TestQueue_PushCtx(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		for _, q := range []*gqueue.Queue{gqueue.New(), gqueue.New(10)} {
+			ctx := gtrace.SetBaggageValue(context.Background(), "user", "john")
+			q.PushCtx(ctx, 1)
+			q.Push(2)
+			q.PushCtx(ctx, 3)
+
+			popCtx, v := q.PopCtx(context.Background())
+			t.Assert(v, 1)
+			t.Assert(gtrace.GetBaggageString(popCtx, "user"), "john")
+			popCtx, v = q.PopCtx(context.Background())
+			t.Assert(v, 2)
+			t.Assert(gtrace.GetBaggageString(popCtx, "user"), "")
+			// Pop returns the value without tracing content.
+			t.Assert(q.Pop(), 3)
+			q.Close()
+		}
+	})
+}
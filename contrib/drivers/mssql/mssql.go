@@ -6,8 +6,9 @@
 //
 // Note:
 // 1. It needs manually import: _ "github.com/denisenkom/go-mssqldb"
-// 2. It does not support Save/Replace features.
-// 3. It does not support LastInsertId.
+// 2. It does not support Replace/InsertIgnore features, and Save feature is implemented using MERGE statement.
+// 3. The LastInsertId is supported by OUTPUT clause for the table having identity column.
+// 4. The pagination uses TOP or OFFSET-FETCH clause, which requires SQL Server 2012 or later.
 
 // Package mssql implements gdb.Driver, which supports operations for MSSql.
 package mssql
@@ -16,6 +17,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -27,6 +29,7 @@ import (
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/text/gregex"
 	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
 )
 
 // Driver is the driver for SQL server database.
@@ -34,9 +37,31 @@ type Driver struct {
 	*gdb.Core
 }
 
+// insertResult is the sql.Result for the insert statement with OUTPUT clause.
+type insertResult struct {
+	lastInsertId int64
+	rowsAffected int64
+}
+
+const (
+	// maxInsertRows is the max rows of a single INSERT statement of SQL server.
+	maxInsertRows = 1000
+	// maxParameters is the max parameters of a single statement of SQL server.
+	maxParameters = 2100
+)
+
 var (
 	// tableFieldsMap caches the table information retrieved from database.
 	tableFieldsMap = gmap.New(true)
+
+	// limitReg matches the select statement with LIMIT clause at the end.
+	limitReg = regexp.MustCompile(`(?is)^(\s*(?:SELECT|WITH|\().+?)\s+LIMIT\s+(\d+)\s*(?:,\s*(\d+))?\s*$`)
+
+	// selectReg matches the beginning of the select statement, for TOP clause inserting.
+	selectReg = regexp.MustCompile(`(?is)^\s*SELECT\s+(?:DISTINCT\s+)?`)
+
+	// orderByReg matches the ORDER BY keyword.
+	orderByReg = regexp.MustCompile(`(?i)\bORDER\s+BY\b`)
 )
 
 func init() {
@@ -104,7 +129,7 @@ func (d *Driver) FilteredLink() string {
 
 // GetChars returns the security char for this type of database.
 func (d *Driver) GetChars() (charLeft string, charRight string) {
-	return `"`, `"`
+	return `[`, `]`
 }
 
 // DoFilter deals with the sql string before commits it to underlying sql driver.
@@ -118,104 +143,50 @@ func (d *Driver) DoFilter(ctx context.Context, link gdb.Link, sql string, args [
 		index++
 		return fmt.Sprintf("@p%d", index)
 	})
-	str, _ = gregex.ReplaceString("\"", "", str)
 	return d.parseSql(str), args, nil
 }
 
 // parseSql does some replacement of the sql before commits it to underlying driver,
 // for support of microsoft sql server.
+//
+// The LIMIT clause of select statement is converted to:
+// 1. TOP clause if there's no offset, like: SELECT TOP 10 * FROM user ORDER BY id;
+// 2. OFFSET-FETCH clause if there's offset, like: SELECT * FROM user ORDER BY id OFFSET 10 ROWS FETCH NEXT 10 ROWS ONLY.
 func (d *Driver) parseSql(sql string) string {
-	// SELECT * FROM USER WHERE ID=1 LIMIT 1
-	if m, _ := gregex.MatchString(`^SELECT(.+)LIMIT 1$`, sql); len(m) > 1 {
-		return fmt.Sprintf(`SELECT TOP 1 %s`, m[1])
-	}
-	// SELECT * FROM USER WHERE AGE>18 ORDER BY ID DESC LIMIT 100, 200
-	patten := `^\s*(?i)(SELECT)|(LIMIT\s*(\d+)\s*,\s*(\d+))`
-	if gregex.IsMatchString(patten, sql) == false {
+	match := limitReg.FindStringSubmatch(sql)
+	if len(match) == 0 {
 		return sql
 	}
-	res, err := gregex.MatchAllString(patten, sql)
-	if err != nil {
-		return ""
-	}
 	var (
-		index   = 0
-		keyword = strings.TrimSpace(res[index][0])
+		body          = match[1]
+		offset, limit int
 	)
-	index++
-	switch strings.ToUpper(keyword) {
-	case "SELECT":
-		// LIMIT statement checks.
-		if len(res) < 2 ||
-			(strings.HasPrefix(res[index][0], "LIMIT") == false &&
-				strings.HasPrefix(res[index][0], "limit") == false) {
-			break
-		}
-		if gregex.IsMatchString("((?i)SELECT)(.+)((?i)LIMIT)", sql) == false {
-			break
-		}
-		// ORDER BY statement checks.
-		var (
-			selectStr = ""
-			orderStr  = ""
-			haveOrder = gregex.IsMatchString("((?i)SELECT)(.+)((?i)ORDER BY)", sql)
-		)
-		if haveOrder {
-			queryExpr, _ := gregex.MatchString("((?i)SELECT)(.+)((?i)ORDER BY)", sql)
-			if len(queryExpr) != 4 ||
-				strings.EqualFold(queryExpr[1], "SELECT") == false ||
-				strings.EqualFold(queryExpr[3], "ORDER BY") == false {
-				break
-			}
-			selectStr = queryExpr[2]
-			orderExpr, _ := gregex.MatchString("((?i)ORDER BY)(.+)((?i)LIMIT)", sql)
-			if len(orderExpr) != 4 ||
-				strings.EqualFold(orderExpr[1], "ORDER BY") == false ||
-				strings.EqualFold(orderExpr[3], "LIMIT") == false {
-				break
-			}
-			orderStr = orderExpr[2]
-		} else {
-			queryExpr, _ := gregex.MatchString("((?i)SELECT)(.+)((?i)LIMIT)", sql)
-			if len(queryExpr) != 4 ||
-				strings.EqualFold(queryExpr[1], "SELECT") == false ||
-				strings.EqualFold(queryExpr[3], "LIMIT") == false {
-				break
-			}
-			selectStr = queryExpr[2]
+	if match[3] != "" {
+		offset, _ = strconv.Atoi(match[2])
+		limit, _ = strconv.Atoi(match[3])
+	} else {
+		limit, _ = strconv.Atoi(match[2])
+	}
+	if offset == 0 {
+		if loc := selectReg.FindStringIndex(body); loc != nil {
+			return fmt.Sprintf(`%sTOP %d %s`, body[:loc[1]], limit, body[loc[1]:])
 		}
-		first, limit := 0, 0
-		for i := 1; i < len(res[index]); i++ {
-			if len(strings.TrimSpace(res[index][i])) == 0 {
-				continue
-			}
+	}
+	// The OFFSET-FETCH clause requires ORDER BY clause.
+	if !hasOrderBy(body) {
+		body += " ORDER BY (SELECT NULL)"
+	}
+	return fmt.Sprintf(`%s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY`, body, offset, limit)
+}
 
-			if strings.HasPrefix(res[index][i], "LIMIT") ||
-				strings.HasPrefix(res[index][i], "limit") {
-				first, _ = strconv.Atoi(res[index][i+1])
-				limit, _ = strconv.Atoi(res[index][i+2])
-				break
-			}
-		}
-		if haveOrder {
-			sql = fmt.Sprintf(
-				"SELECT * FROM "+
-					"(SELECT ROW_NUMBER() OVER (ORDER BY %s) as ROWNUMBER_, %s ) as TMP_ "+
-					"WHERE TMP_.ROWNUMBER_ > %d AND TMP_.ROWNUMBER_ <= %d",
-				orderStr, selectStr, first, first+limit,
-			)
-		} else {
-			if first == 0 {
-				first = limit
-			}
-			sql = fmt.Sprintf(
-				"SELECT * FROM (SELECT TOP %d * FROM (SELECT TOP %d %s) as TMP1_ ) as TMP2_ ",
-				limit, first+limit, selectStr,
-			)
-		}
-	default:
+// hasOrderBy checks whether the statement `sql` has ORDER BY clause not in sub query.
+func hasOrderBy(sql string) bool {
+	locs := orderByReg.FindAllStringIndex(sql, -1)
+	if len(locs) == 0 {
+		return false
 	}
-	return sql
+	after := sql[locs[len(locs)-1][1]:]
+	return strings.Count(after, ")") <= strings.Count(after, "(")
 }
 
 // Tables retrieves and returns the tables of current schema.
@@ -320,16 +291,213 @@ ORDER BY a.id,a.colorder`,
 	return
 }
 
-// DoInsert is not supported in mssql.
+// DoInsert inserts or updates data for given table.
+// The Save operation is implemented using MERGE statement, and the LastInsertId of Insert operation is
+// retrieved using OUTPUT clause if the table has identity column.
+// The batch count is limited by the max rows and parameters of a single statement of SQL server.
 func (d *Driver) DoInsert(ctx context.Context, link gdb.Link, table string, list gdb.List, option gdb.DoInsertOption) (result sql.Result, err error) {
 	switch option.InsertOption {
-	case gdb.InsertOptionSave:
-		return nil, gerror.NewCode(gcode.CodeNotSupported, `Save operation is not supported by mssql driver`)
-
 	case gdb.InsertOptionReplace:
 		return nil, gerror.NewCode(gcode.CodeNotSupported, `Replace operation is not supported by mssql driver`)
 
+	case gdb.InsertOptionIgnore:
+		return nil, gerror.NewCode(gcode.CodeNotSupported, `Insert ignore operation is not supported by mssql driver`)
+	}
+	tableFields, err := d.TableFields(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		keys        []string // Field names.
+		primaryKeys []string // Primary key field names in the data.
+		identityKey string   // Identity field name of the table.
+	)
+	for k := range list[0] {
+		keys = append(keys, k)
+		if field, ok := tableFields[k]; ok && strings.EqualFold(field.Key, "PRI") {
+			primaryKeys = append(primaryKeys, k)
+		}
+	}
+	for _, field := range tableFields {
+		if field.Extra == "auto_increment" {
+			identityKey = field.Name
+		}
+	}
+	if option.InsertOption == gdb.InsertOptionSave && len(primaryKeys) == 0 {
+		return nil, gerror.NewCodef(
+			gcode.CodeMissingParameter,
+			`Save operation requires primary key in data for table "%s" by mssql driver`, table,
+		)
+	}
+	// Batch count limits.
+	var (
+		batchCount = option.BatchCount
+		batchLimit = maxInsertRows
+	)
+	if len(keys) > 0 && maxParameters/len(keys) < batchLimit {
+		batchLimit = maxParameters / len(keys)
+	}
+	if batchCount <= 0 || batchCount > batchLimit {
+		batchCount = batchLimit
+	}
+	var (
+		batchResult = new(gdb.SqlResult)
+		listLength  = len(list)
+		valueHolder = make([]string, 0)
+		params      = make([]interface{}, 0)
+		values      = make([]string, 0, len(keys))
+	)
+	for i := 0; i < listLength; i++ {
+		values = values[:0]
+		for _, k := range keys {
+			if s, ok := list[i][k].(gdb.Raw); ok {
+				values = append(values, gconv.String(s))
+			} else {
+				values = append(values, "?")
+				params = append(params, list[i][k])
+			}
+		}
+		valueHolder = append(valueHolder, "("+gstr.Join(values, ",")+")")
+		// Batch package checks: It meets the batch number, or it is the last element.
+		if len(valueHolder) == batchCount || i == listLength-1 {
+			var stdSqlResult sql.Result
+			if option.InsertOption == gdb.InsertOptionSave {
+				stdSqlResult, err = d.DoExec(
+					ctx, link, d.formatMergeSql(table, keys, primaryKeys, identityKey, valueHolder, option), params...,
+				)
+			} else {
+				stdSqlResult, err = d.doInsertWithOutput(ctx, link, table, keys, identityKey, valueHolder, params)
+			}
+			if err != nil {
+				return stdSqlResult, err
+			}
+			affectedRows, err := stdSqlResult.RowsAffected()
+			if err != nil {
+				return stdSqlResult, gerror.WrapCode(gcode.CodeDbOperationError, err, `sql.Result.RowsAffected failed`)
+			}
+			batchResult.Result = stdSqlResult
+			batchResult.Affected += affectedRows
+			params = params[:0]
+			valueHolder = valueHolder[:0]
+		}
+	}
+	return batchResult, nil
+}
+
+// doInsertWithOutput does the INSERT statement, which retrieves the identity value using OUTPUT clause
+// if `identityKey` is not empty.
+func (d *Driver) doInsertWithOutput(
+	ctx context.Context, link gdb.Link, table string, keys []string, identityKey string,
+	valueHolder []string, params []interface{},
+) (result sql.Result, err error) {
+	sqlStr := fmt.Sprintf(
+		"INSERT INTO %s(%s) VALUES%s",
+		d.QuotePrefixTableName(table), d.QuoteString(gstr.Join(keys, ",")), gstr.Join(valueHolder, ","),
+	)
+	if identityKey == "" {
+		return d.DoExec(ctx, link, sqlStr, params...)
+	}
+	sqlStr = fmt.Sprintf(
+		"INSERT INTO %s(%s) OUTPUT INSERTED.%s VALUES%s",
+		d.QuotePrefixTableName(table), d.QuoteString(gstr.Join(keys, ",")),
+		d.QuoteWord(identityKey), gstr.Join(valueHolder, ","),
+	)
+	// The statement with OUTPUT clause returns rows, which should be executed on master node.
+	if link == nil && gdb.TXFromCtx(ctx, d.GetGroup()) == nil {
+		if link, err = d.MasterLink(); err != nil {
+			return nil, err
+		}
+	}
+	records, err := d.DoQuery(ctx, link, sqlStr, params...)
+	if err != nil {
+		return nil, err
+	}
+	insertedResult := &insertResult{rowsAffected: int64(len(records))}
+	if len(records) > 0 {
+		insertedResult.lastInsertId = records[len(records)-1][identityKey].Int64()
+	}
+	return insertedResult, nil
+}
+
+// formatMergeSql formats and returns the MERGE statement for Save operation, which updates the
+// existing records by primary keys and inserts the others.
+func (d *Driver) formatMergeSql(
+	table string, keys, primaryKeys []string, identityKey string, valueHolder []string, option gdb.DoInsertOption,
+) string {
+	var (
+		onStr      []string
+		updateStr  []string
+		insertKeys []string
+		insertVals []string
+	)
+	for _, k := range primaryKeys {
+		onStr = append(onStr, fmt.Sprintf(`T.%s=S.%s`, d.QuoteWord(k), d.QuoteWord(k)))
+	}
+	for _, k := range keys {
+		// The identity value is generated by database.
+		if k == identityKey {
+			continue
+		}
+		insertKeys = append(insertKeys, d.QuoteWord(k))
+		insertVals = append(insertVals, "S."+d.QuoteWord(k))
+	}
+	switch {
+	case option.OnDuplicateStr != "":
+		updateStr = append(updateStr, option.OnDuplicateStr)
+
+	case len(option.OnDuplicateMap) > 0:
+		for k, v := range option.OnDuplicateMap {
+			switch v.(type) {
+			case gdb.Raw, *gdb.Raw:
+				updateStr = append(updateStr, fmt.Sprintf(`T.%s=%s`, d.QuoteWord(k), v))
+			default:
+				updateStr = append(updateStr, fmt.Sprintf(`T.%s=S.%s`, d.QuoteWord(k), d.QuoteWord(gconv.String(v))))
+			}
+		}
+
 	default:
-		return d.Core.DoInsert(ctx, link, table, list, option)
+		for _, k := range keys {
+			// The primary keys and the creating time are not updated.
+			if k == identityKey || gstr.InArray(primaryKeys, k) || d.isCreatedFieldName(k) {
+				continue
+			}
+			updateStr = append(updateStr, fmt.Sprintf(`T.%s=S.%s`, d.QuoteWord(k), d.QuoteWord(k)))
+		}
 	}
+	sqlStr := fmt.Sprintf(
+		"MERGE INTO %s AS T USING (VALUES%s) AS S(%s) ON (%s)",
+		d.QuotePrefixTableName(table), gstr.Join(valueHolder, ","),
+		d.QuoteString(gstr.Join(keys, ",")), gstr.Join(onStr, " AND "),
+	)
+	if len(updateStr) > 0 {
+		sqlStr += " WHEN MATCHED THEN UPDATE SET " + gstr.Join(updateStr, ",")
+	}
+	// The MERGE statement must be terminated by a semicolon.
+	return sqlStr + fmt.Sprintf(
+		" WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s);",
+		gstr.Join(insertKeys, ","), gstr.Join(insertVals, ","),
+	)
+}
+
+// isCreatedFieldName checks whether `name` is the field name for record creating time.
+func (d *Driver) isCreatedFieldName(name string) bool {
+	if createdAt := d.GetConfig().CreatedAt; createdAt != "" {
+		return strings.EqualFold(name, createdAt)
+	}
+	for _, v := range []string{"created_at", "create_at"} {
+		if strings.EqualFold(name, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// LastInsertId implements sql.Result.
+func (r *insertResult) LastInsertId() (int64, error) {
+	return r.lastInsertId, nil
+}
+
+// RowsAffected implements sql.Result.
+func (r *insertResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
 }
@@ -127,6 +127,28 @@ func TestFilteredLink(t *testing.T) {
 		gtest.AssertNE(err, nil)
 	})
 }
+
+func TestDoFilter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		sql, args, err := db.DoFilter(ctx, nil, "SELECT * FROM [t_user] WHERE [id]>? AND nickname='\"a\"' LIMIT 1", []interface{}{1})
+		t.AssertNil(err)
+		t.Assert(sql, "SELECT TOP 1 * FROM [t_user] WHERE [id]>@p1 AND nickname='\"a\"'")
+		t.Assert(args, []interface{}{1})
+
+		sql, _, _ = db.DoFilter(ctx, nil, "SELECT DISTINCT [id] FROM [t_user] ORDER BY [id] DESC LIMIT 0,10", nil)
+		t.Assert(sql, "SELECT DISTINCT TOP 10 [id] FROM [t_user] ORDER BY [id] DESC")
+
+		sql, _, _ = db.DoFilter(ctx, nil, "SELECT * FROM [t_user] ORDER BY [id] DESC LIMIT 20,10", nil)
+		t.Assert(sql, "SELECT * FROM [t_user] ORDER BY [id] DESC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY")
+
+		sql, _, _ = db.DoFilter(ctx, nil, "SELECT * FROM (SELECT * FROM [t_user] ORDER BY [id]) t LIMIT 20,10", nil)
+		t.Assert(sql, "SELECT * FROM (SELECT * FROM [t_user] ORDER BY [id]) t ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY")
+
+		sql, _, _ = db.DoFilter(ctx, nil, "UPDATE [t_user] SET [nickname]=? WHERE [id]=?", []interface{}{"a", 1})
+		t.Assert(sql, "UPDATE [t_user] SET [nickname]=@p1 WHERE [id]=@p2")
+	})
+}
+
 func TestDoInsert(t *testing.T) {
 	gtest.C(t, func(t *gtest.T) {
 		createTable("t_user")
@@ -158,7 +180,14 @@ func TestDoInsert(t *testing.T) {
 			"create_time": gtime.Now().String(),
 		}
 		_, err := db.Save(context.Background(), "t_user", data, 10)
-		gtest.AssertNE(err, nil)
+		gtest.AssertNil(err)
+
+		data["nickname"] = "T100"
+		_, err = db.Save(context.Background(), "t_user", data, 10)
+		gtest.AssertNil(err)
+		one, err := db.Model("t_user").Where("id", i).One()
+		gtest.AssertNil(err)
+		gtest.Assert(one["NICKNAME"], "T100")
 
 		_, err = db.Replace(context.Background(), "t_user", data, 10)
 		gtest.AssertNE(err, nil)
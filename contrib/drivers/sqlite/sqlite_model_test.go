@@ -25,6 +25,7 @@ import (
 	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gpage"
 	"github.com/gogf/gf/v2/util/guid"
 	"github.com/gogf/gf/v2/util/gutil"
 )
@@ -1823,6 +1824,44 @@ func Test_Model_Page(t *testing.T) {
 	})
 }
 
+func Test_Model_Cursor(t *testing.T) {
+	table := createInitTable()
+	defer dropTable(table)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ids         = make([]int, 0)
+			encoded     = ""
+			orderBy     = "nickname desc, id"
+			cursor, err = gpage.ParseCursor(encoded, orderBy)
+		)
+		t.AssertNil(err)
+		for {
+			cursor, err = gpage.ParseCursor(encoded, orderBy)
+			t.AssertNil(err)
+			result, err := db.Model(table).Cursor(cursor, 3).All()
+			t.AssertNil(err)
+			if result.IsEmpty() {
+				break
+			}
+			for _, record := range result {
+				ids = append(ids, record["id"].Int())
+			}
+			encoded = cursor.Next(result[len(result)-1].Map()).Encode()
+		}
+		// Nicknames "name_1" ... "name_10" in descending order.
+		t.Assert(ids, []int{9, 8, 7, 6, 5, 4, 3, 2, 10, 1})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		cursor, err := gpage.NewCursor("id")
+		t.AssertNil(err)
+		result, err := db.Model(table).Cursor(cursor.Next(g.Map{"id": 8}), 3).All()
+		t.AssertNil(err)
+		t.Assert(len(result), 2)
+		t.Assert(result[0]["id"], 9)
+		t.Assert(result[1]["id"], 10)
+	})
+}
+
 func Test_Model_Option_Map(t *testing.T) {
 	// Insert
 	gtest.C(t, func(t *gtest.T) {
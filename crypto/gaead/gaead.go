@@ -0,0 +1,130 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gaead provides useful API for authenticated encryption with associated data (AEAD),
+// using AES-GCM and ChaCha20-Poly1305 algorithms.
+//
+// The encrypted result is a versioned envelope, which contains the algorithm and random nonce,
+// so the decryption needs only the key:
+//
+//	| version(1 byte) | algorithm(1 byte) | nonce | cipher text with tag |
+package gaead
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Algorithm is the AEAD algorithm identifier stored in the envelope.
+type Algorithm byte
+
+const (
+	AlgorithmAESGCM           Algorithm = 1 // AES-GCM, the key must be 16/24/32 bytes length.
+	AlgorithmChaCha20Poly1305 Algorithm = 2 // ChaCha20-Poly1305, the key must be 32 bytes length.
+)
+
+const (
+	envelopeVersion    byte = 1 // Current version of the envelope.
+	envelopeHeaderSize      = 2 // Size of version and algorithm bytes.
+)
+
+// Encrypt encrypts `plainText` with `key` using AES-GCM algorithm.
+// The optional parameter `additionalData` is authenticated but not encrypted,
+// which must be the same for decryption.
+func Encrypt(plainText, key []byte, additionalData ...[]byte) ([]byte, error) {
+	return EncryptWith(AlgorithmAESGCM, plainText, key, additionalData...)
+}
+
+// EncryptWith encrypts `plainText` with `key` using specified `algorithm`.
+// The optional parameter `additionalData` is authenticated but not encrypted,
+// which must be the same for decryption.
+func EncryptWith(algorithm Algorithm, plainText, key []byte, additionalData ...[]byte) ([]byte, error) {
+	aead, err := newAEAD(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		nonceSize = aead.NonceSize()
+		envelope  = make([]byte, envelopeHeaderSize+nonceSize, envelopeHeaderSize+nonceSize+len(plainText)+aead.Overhead())
+		nonce     = envelope[envelopeHeaderSize:]
+	)
+	envelope[0] = envelopeVersion
+	envelope[1] = byte(algorithm)
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, gerror.Wrap(err, `generate random nonce failed`)
+	}
+	return aead.Seal(envelope, nonce, plainText, getAdditionalData(additionalData)), nil
+}
+
+// Decrypt decrypts `cipherText` produced by Encrypt or EncryptWith with `key`.
+// The algorithm is detected from the envelope of `cipherText`.
+// It returns error if `cipherText` or `additionalData` is tampered.
+func Decrypt(cipherText, key []byte, additionalData ...[]byte) ([]byte, error) {
+	if len(cipherText) < envelopeHeaderSize {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid cipher text: too short`)
+	}
+	if cipherText[0] != envelopeVersion {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `unsupported envelope version: %d`, cipherText[0])
+	}
+	aead, err := newAEAD(Algorithm(cipherText[1]), key)
+	if err != nil {
+		return nil, err
+	}
+	nonceSize := aead.NonceSize()
+	if len(cipherText) < envelopeHeaderSize+nonceSize+aead.Overhead() {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid cipher text: too short`)
+	}
+	plainText, err := aead.Open(
+		nil,
+		cipherText[envelopeHeaderSize:envelopeHeaderSize+nonceSize],
+		cipherText[envelopeHeaderSize+nonceSize:],
+		getAdditionalData(additionalData),
+	)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeSecurityReason, err, `message authentication failed`)
+	}
+	return plainText, nil
+}
+
+// newAEAD creates and returns the cipher.AEAD of `algorithm` with `key`.
+func newAEAD(algorithm Algorithm, key []byte) (cipher.AEAD, error) {
+	switch algorithm {
+	case AlgorithmAESGCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `aes.NewCipher failed for key length %d`, len(key))
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, gerror.Wrap(err, `cipher.NewGCM failed`)
+		}
+		return aead, nil
+
+	case AlgorithmChaCha20Poly1305:
+		aead, err := chacha20poly1305.New(key)
+		if err != nil {
+			return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `chacha20poly1305.New failed for key length %d`, len(key))
+		}
+		return aead, nil
+
+	default:
+		return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported algorithm: %d`, algorithm)
+	}
+}
+
+func getAdditionalData(additionalData [][]byte) []byte {
+	if len(additionalData) > 0 {
+		return additionalData[0]
+	}
+	return nil
+}
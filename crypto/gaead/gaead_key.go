@@ -0,0 +1,63 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gaead
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// KeySize is the recommended key size, which fits both AES-256-GCM and ChaCha20-Poly1305.
+	KeySize = 32
+
+	// Recommended scrypt parameters for interactive logins.
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+)
+
+// GenerateKey generates and returns a random key of KeySize.
+func GenerateKey() ([]byte, error) {
+	return GenerateSalt(KeySize)
+}
+
+// GenerateSalt generates and returns random bytes of `size`, which can be used as salt for key derivation.
+func GenerateSalt(size int) ([]byte, error) {
+	salt := make([]byte, size)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, gerror.Wrap(err, `generate random bytes failed`)
+	}
+	return salt, nil
+}
+
+// DeriveKeyHKDF derives a key of KeySize from high-entropy `secret` using HKDF with SHA-256.
+// The `salt` is optional but recommended, and the `info` binds the key to a specific usage,
+// so the different usages derive different keys from the same `secret`.
+func DeriveKeyHKDF(secret, salt, info []byte) ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), key); err != nil {
+		return nil, gerror.Wrap(err, `derive key using HKDF failed`)
+	}
+	return key, nil
+}
+
+// DeriveKeyScrypt derives a key of KeySize from low-entropy `password` using scrypt.
+// The `salt` should be random and stored with the encrypted data.
+func DeriveKeyScrypt(password, salt []byte) ([]byte, error) {
+	key, err := scrypt.Key(password, salt, scryptN, scryptR, scryptP, KeySize)
+	if err != nil {
+		return nil, gerror.Wrap(err, `derive key using scrypt failed`)
+	}
+	return key, nil
+}
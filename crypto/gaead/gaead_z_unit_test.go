@@ -0,0 +1,103 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gaead_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/crypto/gaead"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+var (
+	content = []byte("GoFrame")
+	key16   = []byte("1234567891234567")
+	key32   = []byte("12345678912345678912345678912345")
+)
+
+func Test_Encrypt_Decrypt(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		for _, key := range [][]byte{key16, key32} {
+			encrypted, err := gaead.Encrypt(content, key)
+			t.AssertNil(err)
+			decrypted, err := gaead.Decrypt(encrypted, key)
+			t.AssertNil(err)
+			t.Assert(decrypted, content)
+		}
+	})
+	// Random nonce.
+	gtest.C(t, func(t *gtest.T) {
+		encrypted1, err := gaead.Encrypt(content, key32)
+		t.AssertNil(err)
+		encrypted2, err := gaead.Encrypt(content, key32)
+		t.AssertNil(err)
+		t.AssertNE(encrypted1, encrypted2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		encrypted, err := gaead.EncryptWith(gaead.AlgorithmChaCha20Poly1305, content, key32, []byte("ad"))
+		t.AssertNil(err)
+		decrypted, err := gaead.Decrypt(encrypted, key32, []byte("ad"))
+		t.AssertNil(err)
+		t.Assert(decrypted, content)
+
+		_, err = gaead.Decrypt(encrypted, key32, []byte("tampered"))
+		t.AssertNE(err, nil)
+		_, err = gaead.EncryptWith(gaead.AlgorithmChaCha20Poly1305, content, key16)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Decrypt_Invalid(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		encrypted, err := gaead.Encrypt(content, key32)
+		t.AssertNil(err)
+
+		tampered := append([]byte{}, encrypted...)
+		tampered[len(tampered)-1] ^= 1
+		_, err = gaead.Decrypt(tampered, key32)
+		t.AssertNE(err, nil)
+
+		_, err = gaead.Decrypt(encrypted, key16)
+		t.AssertNE(err, nil)
+		_, err = gaead.Decrypt(encrypted[:10], key32)
+		t.AssertNE(err, nil)
+		_, err = gaead.Decrypt(append([]byte{9}, encrypted[1:]...), key32)
+		t.AssertNE(err, nil)
+		_, err = gaead.EncryptWith(gaead.Algorithm(9), content, key32)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_DeriveKey(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		key, err := gaead.GenerateKey()
+		t.AssertNil(err)
+		t.Assert(len(key), gaead.KeySize)
+
+		key1, err := gaead.DeriveKeyHKDF(key, []byte("salt"), []byte("usage1"))
+		t.AssertNil(err)
+		key2, err := gaead.DeriveKeyHKDF(key, []byte("salt"), []byte("usage2"))
+		t.AssertNil(err)
+		t.Assert(len(key1), gaead.KeySize)
+		t.AssertNE(key1, key2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		salt, err := gaead.GenerateSalt(16)
+		t.AssertNil(err)
+		key1, err := gaead.DeriveKeyScrypt([]byte("password"), salt)
+		t.AssertNil(err)
+		key2, err := gaead.DeriveKeyScrypt([]byte("password"), salt)
+		t.AssertNil(err)
+		t.Assert(key1, key2)
+
+		encrypted, err := gaead.Encrypt(content, key1)
+		t.AssertNil(err)
+		decrypted, err := gaead.Decrypt(encrypted, key2)
+		t.AssertNil(err)
+		t.Assert(decrypted, content)
+	})
+}
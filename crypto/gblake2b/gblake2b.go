@@ -0,0 +1,66 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gblake2b provides useful API for BLAKE2b-256 hash algorithms.
+package gblake2b
+
+import (
+	"encoding/hex"
+	"io"
+	"os"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Encrypt encrypts any type of variable using BLAKE2b-256 algorithms.
+// It uses package gconv to convert `v` to its bytes type.
+func Encrypt(v interface{}) string {
+	r := blake2b.Sum256(gconv.Bytes(v))
+	return hex.EncodeToString(r[:])
+}
+
+// EncryptFile encrypts file content of `path` using BLAKE2b-256 algorithms.
+func EncryptFile(path string) (encrypt string, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		err = gerror.Wrapf(err, `os.Open failed for name "%s"`, path)
+		return "", err
+	}
+	defer f.Close()
+	return EncryptReader(f)
+}
+
+// EncryptReader encrypts content read from `reader` until EOF using BLAKE2b-256 algorithms.
+// It reads and hashes the content chunk by chunk, so it does not load all content into memory,
+// which is suitable for very large content.
+// The optional parameter `progress` is called with the total read bytes count after each chunk is hashed.
+func EncryptReader(reader io.Reader, progress ...func(read int64)) (encrypt string, err error) {
+	var f func(read int64)
+	if len(progress) > 0 {
+		f = progress[0]
+	}
+	// It never fails with nil key.
+	h, _ := blake2b.New256(nil)
+	if _, err = utils.CopyWithProgress(h, reader, f); err != nil {
+		err = gerror.Wrap(err, `read content for hashing failed`)
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// MustEncryptFile encrypts file content of `path` using BLAKE2b-256 algorithms.
+// It panics if any error occurs.
+func MustEncryptFile(path string) string {
+	result, err := EncryptFile(path)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gblake2b_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/crypto/gblake2b"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+const (
+	content = "pibigstar"
+	result  = "ba2e787bc1d0ff04b4f819db9a441508533eb4589e0d0d7b99dc181203e8d5ed"
+)
+
+func TestEncrypt(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertEQ(gblake2b.Encrypt(content), result)
+		t.AssertEQ(gblake2b.Encrypt([]byte(content)), result)
+	})
+}
+
+func TestEncryptReader(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var progressed int64
+		encrypt, err := gblake2b.EncryptReader(strings.NewReader(content), func(read int64) {
+			progressed = read
+		})
+		t.AssertNil(err)
+		t.AssertEQ(encrypt, result)
+		t.AssertEQ(progressed, int64(len(content)))
+	})
+}
+
+func TestEncryptFile(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Temp(gtime.TimestampNanoStr())
+		t.AssertNil(gfile.PutContents(path, content))
+		defer gfile.Remove(path)
+		t.AssertEQ(gblake2b.MustEncryptFile(path), result)
+
+		_, err := gblake2b.EncryptFile(path + "_none")
+		t.AssertNE(err, nil)
+	})
+}
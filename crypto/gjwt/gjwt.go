@@ -0,0 +1,215 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gjwt provides JSON Web Token (JWT) creation and verification,
+// supporting HS256, RS256 and ES256 algorithms and JWKS key fetching.
+package gjwt
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Signing algorithms.
+const (
+	HS256 = "HS256" // HMAC using SHA-256, the key is []byte.
+	RS256 = "RS256" // RSASSA-PKCS1-v1_5 using SHA-256, the key is *rsa.PrivateKey or *rsa.PublicKey.
+	ES256 = "ES256" // ECDSA using P-256 and SHA-256, the key is *ecdsa.PrivateKey or *ecdsa.PublicKey.
+)
+
+// Registered claim names.
+const (
+	ClaimIssuer    = "iss"
+	ClaimSubject   = "sub"
+	ClaimAudience  = "aud"
+	ClaimExpiresAt = "exp"
+	ClaimNotBefore = "nbf"
+	ClaimIssuedAt  = "iat"
+	ClaimId        = "jti"
+)
+
+// Token is a parsed JWT.
+type Token struct {
+	Header    map[string]interface{} // Header of token, containing "alg", "typ" and optional "kid".
+	Claims    map[string]interface{} // Claims of token.
+	Signature []byte                 // Signature of token.
+}
+
+// KeyProvider provides the verification key for token by its header, eg: by "kid" in header.
+// The JWKS implements this interface.
+type KeyProvider interface {
+	GetKey(header map[string]interface{}) (key interface{}, err error)
+}
+
+// SignOption is the option for Sign.
+type SignOption struct {
+	KeyId     string        // Key id set into header as "kid", which is optional.
+	ExpiresIn time.Duration // If it's greater than 0, "exp" and "iat" claims are set automatically.
+}
+
+// ParseOption is the option for Parse.
+type ParseOption struct {
+	Algorithms []string      // Allowed algorithms, it allows the algorithm that matches the key type in default.
+	Leeway     time.Duration // Leeway for "exp" and "nbf" validation, for clock skew between servers.
+	Issuer     string        // Expected "iss" claim, which is not validated if empty.
+	Audience   string        // Expected "aud" claim, which is not validated if empty.
+}
+
+var (
+	// encoding is the base64 encoding for JWT segments.
+	encoding = base64.RawURLEncoding
+)
+
+// Sign creates and returns a signed JWT string with `claims` using `algorithm` and `key`.
+// The parameter `claims` can be a map or struct, which is converted to map using gconv.Map.
+func Sign(claims interface{}, algorithm string, key interface{}, option ...SignOption) (string, error) {
+	header := map[string]interface{}{
+		"alg": algorithm,
+		"typ": "JWT",
+	}
+	claimsMap := gconv.Map(claims)
+	if claimsMap == nil {
+		claimsMap = make(map[string]interface{})
+	}
+	if len(option) > 0 {
+		if option[0].KeyId != "" {
+			header["kid"] = option[0].KeyId
+		}
+		if option[0].ExpiresIn > 0 {
+			now := time.Now()
+			claimsMap[ClaimIssuedAt] = now.Unix()
+			claimsMap[ClaimExpiresAt] = now.Add(option[0].ExpiresIn).Unix()
+		}
+	}
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsBytes, err := json.Marshal(claimsMap)
+	if err != nil {
+		return "", err
+	}
+	signingInput := encoding.EncodeToString(headerBytes) + "." + encoding.EncodeToString(claimsBytes)
+	signature, err := sign(algorithm, signingInput, key)
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + encoding.EncodeToString(signature), nil
+}
+
+// Parse parses and verifies the JWT string `tokenString` with `key`, and validates its
+// "exp", "nbf", "iss" and "aud" claims.
+//
+// The parameter `key` can be []byte for HS256, *rsa.PublicKey for RS256, *ecdsa.PublicKey for ES256,
+// or a KeyProvider like JWKS, which provides the key by token header.
+func Parse(tokenString string, key interface{}, option ...ParseOption) (*Token, error) {
+	var opt ParseOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	parts := strings.Split(tokenString, ".")
+	if len(parts) != 3 {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid token: it should have 3 segments`)
+	}
+	token := &Token{}
+	if err := decodeSegment(parts[0], &token.Header); err != nil {
+		return nil, err
+	}
+	if err := decodeSegment(parts[1], &token.Claims); err != nil {
+		return nil, err
+	}
+	signature, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid token signature encoding`)
+	}
+	token.Signature = signature
+
+	algorithm := gconv.String(token.Header["alg"])
+	if len(opt.Algorithms) > 0 && !inArray(opt.Algorithms, algorithm) {
+		return nil, gerror.NewCodef(gcode.CodeNotAuthorized, `algorithm "%s" is not allowed`, algorithm)
+	}
+	if provider, ok := key.(KeyProvider); ok {
+		if key, err = provider.GetKey(token.Header); err != nil {
+			return nil, err
+		}
+	}
+	if err = verify(algorithm, parts[0]+"."+parts[1], signature, key); err != nil {
+		return nil, err
+	}
+	if err = token.validate(opt); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Scan converts the claims of token to struct `pointer` using gconv.
+func (t *Token) Scan(pointer interface{}) error {
+	return gconv.Scan(t.Claims, pointer)
+}
+
+// Get returns the value of claim `name`.
+func (t *Token) Get(name string) interface{} {
+	return t.Claims[name]
+}
+
+// ExtractBearer extracts and returns the token from HTTP Authorization header value like "Bearer xxx".
+// It returns empty string if `authorization` is not a Bearer token.
+func ExtractBearer(authorization string) string {
+	const prefix = "bearer "
+	if len(authorization) > len(prefix) && strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return strings.TrimSpace(authorization[len(prefix):])
+	}
+	return ""
+}
+
+// validate validates the time and registered claims of token.
+func (t *Token) validate(opt ParseOption) error {
+	now := time.Now()
+	if v, ok := t.Claims[ClaimExpiresAt]; ok {
+		if now.After(time.Unix(gconv.Int64(v), 0).Add(opt.Leeway)) {
+			return gerror.NewCode(gcode.CodeNotAuthorized, `token is expired`)
+		}
+	}
+	if v, ok := t.Claims[ClaimNotBefore]; ok {
+		if now.Add(opt.Leeway).Before(time.Unix(gconv.Int64(v), 0)) {
+			return gerror.NewCode(gcode.CodeNotAuthorized, `token is not valid yet`)
+		}
+	}
+	if opt.Issuer != "" && gconv.String(t.Claims[ClaimIssuer]) != opt.Issuer {
+		return gerror.NewCodef(gcode.CodeNotAuthorized, `invalid token issuer, expected "%s"`, opt.Issuer)
+	}
+	if opt.Audience != "" && !inArray(gconv.Strings(t.Claims[ClaimAudience]), opt.Audience) {
+		return gerror.NewCodef(gcode.CodeNotAuthorized, `invalid token audience, expected "%s"`, opt.Audience)
+	}
+	return nil
+}
+
+// decodeSegment decodes base64 encoded JSON segment into `pointer`.
+func decodeSegment(segment string, pointer interface{}) error {
+	b, err := encoding.DecodeString(segment)
+	if err != nil {
+		return gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid token segment encoding`)
+	}
+	if err = json.UnmarshalUseNumber(b, pointer); err != nil {
+		return gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid token segment content`)
+	}
+	return nil
+}
+
+func inArray(array []string, s string) bool {
+	for _, v := range array {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
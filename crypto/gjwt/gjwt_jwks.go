@@ -0,0 +1,179 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// JWKS fetches and caches the JSON Web Key Set from remote url, which implements KeyProvider.
+// It fetches the key set again if the cache expires or an unknown "kid" is requested.
+type JWKS struct {
+	mu          sync.RWMutex
+	url         string                 // Remote url of key set.
+	client      *http.Client           // HTTP client for fetching.
+	cacheTTL    time.Duration          // Cache duration of the key set.
+	minInterval time.Duration          // Min interval between fetching, to avoid flooding remote by unknown kid.
+	keys        map[string]interface{} // Kid to public key.
+	fetchedAt   time.Time              // Last fetching time.
+}
+
+const (
+	defaultJWKSCacheTTL    = time.Hour
+	defaultJWKSMinInterval = 10 * time.Second
+	defaultJWKSTimeout     = 10 * time.Second
+)
+
+// jwk is a JSON Web Key.
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// NewJWKS creates and returns a JWKS fetching key set from `url`.
+// The optional parameter `cacheTTL` specifies the cache duration of key set, which is 1 hour in default.
+func NewJWKS(url string, cacheTTL ...time.Duration) *JWKS {
+	j := &JWKS{
+		url:         url,
+		client:      &http.Client{Timeout: defaultJWKSTimeout},
+		cacheTTL:    defaultJWKSCacheTTL,
+		minInterval: defaultJWKSMinInterval,
+		keys:        make(map[string]interface{}),
+	}
+	if len(cacheTTL) > 0 && cacheTTL[0] > 0 {
+		j.cacheTTL = cacheTTL[0]
+	}
+	return j
+}
+
+// SetHTTPClient sets the HTTP client for fetching key set.
+func (j *JWKS) SetHTTPClient(client *http.Client) {
+	j.client = client
+}
+
+// GetKey returns the public key by "kid" in token `header`, which implements KeyProvider.
+func (j *JWKS) GetKey(header map[string]interface{}) (interface{}, error) {
+	kid := gconv.String(header["kid"])
+	j.mu.RLock()
+	key, ok := j.keys[kid]
+	expired := time.Since(j.fetchedAt) > j.cacheTTL
+	canFetch := time.Since(j.fetchedAt) > j.minInterval
+	j.mu.RUnlock()
+	if ok && !expired {
+		return key, nil
+	}
+	if expired || canFetch {
+		if err := j.Refresh(); err != nil {
+			// It uses the stale key if the refreshing fails.
+			if ok {
+				return key, nil
+			}
+			return nil, err
+		}
+		j.mu.RLock()
+		key, ok = j.keys[kid]
+		j.mu.RUnlock()
+	}
+	if !ok {
+		return nil, gerror.NewCodef(gcode.CodeNotAuthorized, `key not found for kid "%s"`, kid)
+	}
+	return key, nil
+}
+
+// Refresh fetches the key set from remote url and replaces the cached keys.
+func (j *JWKS) Refresh() error {
+	response, err := j.client.Get(j.url)
+	if err != nil {
+		return gerror.Wrapf(err, `fetch JWKS failed from "%s"`, j.url)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return gerror.NewCodef(
+			gcode.CodeOperationFailed, `fetch JWKS failed from "%s": status code %d`, j.url, response.StatusCode,
+		)
+	}
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return gerror.Wrapf(err, `read JWKS failed from "%s"`, j.url)
+	}
+	var keySet struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err = json.Unmarshal(body, &keySet); err != nil {
+		return gerror.Wrapf(err, `invalid JWKS content from "%s"`, j.url)
+	}
+	keys := make(map[string]interface{}, len(keySet.Keys))
+	for _, k := range keySet.Keys {
+		// The unsupported keys are ignored.
+		if key, err := k.publicKey(); err == nil {
+			keys[k.Kid] = key
+		}
+	}
+	j.mu.Lock()
+	j.keys = keys
+	j.fetchedAt = time.Now()
+	j.mu.Unlock()
+	return nil
+}
+
+// publicKey converts JSON Web Key to *rsa.PublicKey or *ecdsa.PublicKey.
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported curve "%s"`, k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+
+	default:
+		return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported key type "%s"`, k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := encoding.DecodeString(s)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid JWK number encoding`)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,132 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// es256KeySize is the byte size of r and s of ES256 signature.
+const es256KeySize = 32
+
+// sign signs `signingInput` using `algorithm` and `key`.
+func sign(algorithm, signingInput string, key interface{}) ([]byte, error) {
+	hashed := sha256.Sum256([]byte(signingInput))
+	switch algorithm {
+	case HS256:
+		secret, ok := key.([]byte)
+		if !ok {
+			return nil, errInvalidKeyType(algorithm, key)
+		}
+		h := hmac.New(sha256.New, secret)
+		h.Write([]byte(signingInput))
+		return h.Sum(nil), nil
+
+	case RS256:
+		privateKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errInvalidKeyType(algorithm, key)
+		}
+		signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashed[:])
+		if err != nil {
+			return nil, gerror.Wrap(err, `rsa.SignPKCS1v15 failed`)
+		}
+		return signature, nil
+
+	case ES256:
+		privateKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, errInvalidKeyType(algorithm, key)
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, privateKey, hashed[:])
+		if err != nil {
+			return nil, gerror.Wrap(err, `ecdsa.Sign failed`)
+		}
+		signature := make([]byte, 2*es256KeySize)
+		r.FillBytes(signature[:es256KeySize])
+		s.FillBytes(signature[es256KeySize:])
+		return signature, nil
+
+	default:
+		return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported algorithm "%s"`, algorithm)
+	}
+}
+
+// verify verifies `signature` of `signingInput` using `algorithm` and `key`.
+func verify(algorithm, signingInput string, signature []byte, key interface{}) error {
+	hashed := sha256.Sum256([]byte(signingInput))
+	switch algorithm {
+	case HS256:
+		secret, ok := key.([]byte)
+		if !ok {
+			return errInvalidKeyType(algorithm, key)
+		}
+		h := hmac.New(sha256.New, secret)
+		h.Write([]byte(signingInput))
+		if !hmac.Equal(signature, h.Sum(nil)) {
+			return errInvalidSignature()
+		}
+		return nil
+
+	case RS256:
+		publicKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			if privateKey, isPrivate := key.(*rsa.PrivateKey); isPrivate {
+				publicKey, ok = &privateKey.PublicKey, true
+			}
+		}
+		if !ok {
+			return errInvalidKeyType(algorithm, key)
+		}
+		if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], signature); err != nil {
+			return errInvalidSignature()
+		}
+		return nil
+
+	case ES256:
+		publicKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			if privateKey, isPrivate := key.(*ecdsa.PrivateKey); isPrivate {
+				publicKey, ok = &privateKey.PublicKey, true
+			}
+		}
+		if !ok {
+			return errInvalidKeyType(algorithm, key)
+		}
+		if len(signature) != 2*es256KeySize {
+			return errInvalidSignature()
+		}
+		var (
+			r = new(big.Int).SetBytes(signature[:es256KeySize])
+			s = new(big.Int).SetBytes(signature[es256KeySize:])
+		)
+		if !ecdsa.Verify(publicKey, hashed[:], r, s) {
+			return errInvalidSignature()
+		}
+		return nil
+
+	default:
+		return gerror.NewCodef(gcode.CodeNotSupported, `unsupported algorithm "%s"`, algorithm)
+	}
+}
+
+func errInvalidKeyType(algorithm string, key interface{}) error {
+	return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid key type "%T" for algorithm "%s"`, key, algorithm)
+}
+
+func errInvalidSignature() error {
+	return gerror.NewCode(gcode.CodeNotAuthorized, `invalid token signature`)
+}
@@ -0,0 +1,153 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/crypto/gjwt"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type User struct {
+	Id   int    `json:"uid"`
+	Name string `json:"name"`
+}
+
+var secret = []byte("my-secret")
+
+func Test_HS256(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		tokenString, err := gjwt.Sign(User{Id: 1, Name: "john"}, gjwt.HS256, secret, gjwt.SignOption{
+			ExpiresIn: time.Minute,
+		})
+		t.AssertNil(err)
+
+		token, err := gjwt.Parse(tokenString, secret)
+		t.AssertNil(err)
+		t.Assert(token.Header["alg"], gjwt.HS256)
+		t.AssertNE(token.Get(gjwt.ClaimExpiresAt), nil)
+
+		var user *User
+		t.AssertNil(token.Scan(&user))
+		t.Assert(user.Id, 1)
+		t.Assert(user.Name, "john")
+
+		_, err = gjwt.Parse(tokenString, []byte("wrong-secret"))
+		t.AssertNE(err, nil)
+		_, err = gjwt.Parse(tokenString+"x", secret)
+		t.AssertNE(err, nil)
+		_, err = gjwt.Parse("invalid", secret)
+		t.AssertNE(err, nil)
+		_, err = gjwt.Parse(tokenString, secret, gjwt.ParseOption{Algorithms: []string{gjwt.RS256}})
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Claims_Validation(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		now := time.Now()
+		tokenString, err := gjwt.Sign(map[string]interface{}{
+			gjwt.ClaimExpiresAt: now.Add(-time.Minute).Unix(),
+		}, gjwt.HS256, secret)
+		t.AssertNil(err)
+		_, err = gjwt.Parse(tokenString, secret)
+		t.AssertNE(err, nil)
+		_, err = gjwt.Parse(tokenString, secret, gjwt.ParseOption{Leeway: 2 * time.Minute})
+		t.AssertNil(err)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		tokenString, err := gjwt.Sign(map[string]interface{}{
+			gjwt.ClaimNotBefore: time.Now().Add(time.Minute).Unix(),
+		}, gjwt.HS256, secret)
+		t.AssertNil(err)
+		_, err = gjwt.Parse(tokenString, secret)
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		tokenString, err := gjwt.Sign(map[string]interface{}{
+			gjwt.ClaimIssuer:   "goframe",
+			gjwt.ClaimAudience: []string{"api", "web"},
+		}, gjwt.HS256, secret)
+		t.AssertNil(err)
+		_, err = gjwt.Parse(tokenString, secret, gjwt.ParseOption{Issuer: "goframe", Audience: "web"})
+		t.AssertNil(err)
+		_, err = gjwt.Parse(tokenString, secret, gjwt.ParseOption{Issuer: "other"})
+		t.AssertNE(err, nil)
+		_, err = gjwt.Parse(tokenString, secret, gjwt.ParseOption{Audience: "other"})
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_RS256_ES256(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		t.AssertNil(err)
+		tokenString, err := gjwt.Sign(User{Id: 1}, gjwt.RS256, rsaKey)
+		t.AssertNil(err)
+		_, err = gjwt.Parse(tokenString, &rsaKey.PublicKey)
+		t.AssertNil(err)
+		_, err = gjwt.Parse(tokenString, secret)
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		t.AssertNil(err)
+		tokenString, err := gjwt.Sign(User{Id: 1}, gjwt.ES256, ecKey)
+		t.AssertNil(err)
+		_, err = gjwt.Parse(tokenString, &ecKey.PublicKey)
+		t.AssertNil(err)
+
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		t.AssertNil(err)
+		_, err = gjwt.Parse(tokenString, &otherKey.PublicKey)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_JWKS(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encode := base64.RawURLEncoding.EncodeToString
+		fmt.Fprintf(
+			w, `{"keys":[{"kid":"k1","kty":"RSA","n":"%s","e":"%s"}]}`,
+			encode(rsaKey.N.Bytes()), encode(big.NewInt(int64(rsaKey.E)).Bytes()),
+		)
+	}))
+	defer server.Close()
+
+	gtest.C(t, func(t *gtest.T) {
+		jwks := gjwt.NewJWKS(server.URL)
+		tokenString, err := gjwt.Sign(User{Id: 1}, gjwt.RS256, rsaKey, gjwt.SignOption{KeyId: "k1"})
+		t.AssertNil(err)
+		_, err = gjwt.Parse(tokenString, jwks)
+		t.AssertNil(err)
+
+		tokenString, err = gjwt.Sign(User{Id: 1}, gjwt.RS256, rsaKey, gjwt.SignOption{KeyId: "k2"})
+		t.AssertNil(err)
+		_, err = gjwt.Parse(tokenString, jwks)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_ExtractBearer(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gjwt.ExtractBearer("Bearer abc"), "abc")
+		t.Assert(gjwt.ExtractBearer("bearer abc "), "abc")
+		t.Assert(gjwt.ExtractBearer("Basic abc"), "")
+	})
+}
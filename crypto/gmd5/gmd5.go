@@ -14,6 +14,7 @@ import (
 	"os"
 
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/utils"
 	"github.com/gogf/gf/v2/util/gconv"
 )
 
@@ -77,10 +78,23 @@ func EncryptFile(path string) (encrypt string, err error) {
 		return "", err
 	}
 	defer f.Close()
-	h := md5.New()
-	_, err = io.Copy(h, f)
-	if err != nil {
-		err = gerror.Wrap(err, `io.Copy failed`)
+	return EncryptReader(f)
+}
+
+// EncryptReader encrypts content read from `reader` until EOF using MD5 algorithms.
+// It reads and hashes the content chunk by chunk, so it does not load all content into memory,
+// which is suitable for very large content.
+// The optional parameter `progress` is called with the total read bytes count after each chunk is hashed.
+func EncryptReader(reader io.Reader, progress ...func(read int64)) (encrypt string, err error) {
+	var (
+		h = md5.New()
+		f func(read int64)
+	)
+	if len(progress) > 0 {
+		f = progress[0]
+	}
+	if _, err = utils.CopyWithProgress(h, reader, f); err != nil {
+		err = gerror.Wrap(err, `read content for hashing failed`)
 		return "", err
 	}
 	return fmt.Sprintf("%x", h.Sum(nil)), nil
@@ -10,6 +10,7 @@ package gmd5_test
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/gogf/gf/v2/crypto/gmd5"
@@ -75,3 +76,23 @@ func TestEncryptFile(t *testing.T) {
 	})
 
 }
+
+func TestEncryptReader(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			chunks  int
+			content = strings.Repeat("a", 100000)
+		)
+		encrypt, err := gmd5.EncryptReader(strings.NewReader(content), func(read int64) {
+			chunks++
+		})
+		t.AssertNil(err)
+		t.AssertEQ(encrypt, "1af6d6f2f682f76f80e606aeaaee1680")
+		t.Assert(chunks > 1, true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		encrypt, err := gmd5.EncryptReader(strings.NewReader(s))
+		t.AssertNil(err)
+		t.AssertEQ(encrypt, result)
+	})
+}
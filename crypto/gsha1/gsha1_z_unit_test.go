@@ -10,6 +10,7 @@ package gsha1_test
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/gogf/gf/v2/crypto/gsha1"
@@ -57,3 +58,18 @@ func TestEncryptFile(t *testing.T) {
 		t.AssertEQ(errEncrypt, "")
 	})
 }
+
+func TestEncryptReader(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			progressed int64
+			content    = strings.Repeat("a", 100000)
+		)
+		encrypt, err := gsha1.EncryptReader(strings.NewReader(content), func(read int64) {
+			progressed = read
+		})
+		t.AssertNil(err)
+		t.AssertEQ(encrypt, "c4d4b30851182fc4eb8675494d42fd7f17e29c93")
+		t.AssertEQ(progressed, int64(len(content)))
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/gogf/gf/v2/container/garray"
 	"github.com/gogf/gf/v2/container/gmap"
 	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/container/gvar"
@@ -135,31 +136,41 @@ type DB interface {
 	PingMaster() error // See Core.PingMaster.
 	PingSlave() error  // See Core.PingSlave.
 
+	// ===========================================================================
+	// Statistics.
+	// ===========================================================================
+
+	Stats(ctx context.Context) []PoolStats // See Core.Stats.
+
 	// ===========================================================================
 	// Transaction.
 	// ===========================================================================
 
-	Begin(ctx context.Context) (*TX, error)                                           // See Core.Begin.
-	Transaction(ctx context.Context, f func(ctx context.Context, tx *TX) error) error // See Core.Transaction.
+	Begin(ctx context.Context) (*TX, error)                                                                      // See Core.Begin.
+	Transaction(ctx context.Context, f func(ctx context.Context, tx *TX) error) error                            // See Core.Transaction.
+	TransactionWithOptions(ctx context.Context, opts TxOptions, f func(ctx context.Context, tx *TX) error) error // See Core.TransactionWithOptions.
 
 	// ===========================================================================
 	// Configuration methods.
 	// ===========================================================================
 
-	GetCache() *gcache.Cache            // See Core.GetCache.
-	SetDebug(debug bool)                // See Core.SetDebug.
-	GetDebug() bool                     // See Core.GetDebug.
-	GetSchema() string                  // See Core.GetSchema.
-	GetPrefix() string                  // See Core.GetPrefix.
-	GetGroup() string                   // See Core.GetGroup.
-	SetDryRun(enabled bool)             // See Core.SetDryRun.
-	GetDryRun() bool                    // See Core.GetDryRun.
-	SetLogger(logger glog.ILogger)      // See Core.SetLogger.
-	GetLogger() glog.ILogger            // See Core.GetLogger.
-	GetConfig() *ConfigNode             // See Core.GetConfig.
-	SetMaxIdleConnCount(n int)          // See Core.SetMaxIdleConnCount.
-	SetMaxOpenConnCount(n int)          // See Core.SetMaxOpenConnCount.
-	SetMaxConnLifeTime(d time.Duration) // See Core.SetMaxConnLifeTime.
+	GetCache() *gcache.Cache                   // See Core.GetCache.
+	SetDebug(debug bool)                       // See Core.SetDebug.
+	GetDebug() bool                            // See Core.GetDebug.
+	GetSchema() string                         // See Core.GetSchema.
+	GetPrefix() string                         // See Core.GetPrefix.
+	GetGroup() string                          // See Core.GetGroup.
+	SetDryRun(enabled bool)                    // See Core.SetDryRun.
+	GetDryRun() bool                           // See Core.GetDryRun.
+	SetLogger(logger glog.ILogger)             // See Core.SetLogger.
+	GetLogger() glog.ILogger                   // See Core.GetLogger.
+	SetAudit(config *AuditConfig)              // See Core.SetAudit.
+	GetAudit() *AuditConfig                    // See Core.GetAudit.
+	AddChangeListener(listener ChangeListener) // See Core.AddChangeListener.
+	GetConfig() *ConfigNode                    // See Core.GetConfig.
+	SetMaxIdleConnCount(n int)                 // See Core.SetMaxIdleConnCount.
+	SetMaxOpenConnCount(n int)                 // See Core.SetMaxOpenConnCount.
+	SetMaxConnLifeTime(d time.Duration)        // See Core.SetMaxConnLifeTime.
 
 	// ===========================================================================
 	// Utility methods.
@@ -178,15 +189,18 @@ type DB interface {
 
 // Core is the base struct for database management.
 type Core struct {
-	db     DB              // DB interface object.
-	ctx    context.Context // Context for chaining operation only. Do not set a default value in Core initialization.
-	group  string          // Configuration group name.
-	schema string          // Custom schema for this object.
-	debug  *gtype.Bool     // Enable debug mode for the database, which can be changed in runtime.
-	cache  *gcache.Cache   // Cache manager, SQL result cache only.
-	links  *gmap.StrAnyMap // links caches all created links by node.
-	logger glog.ILogger    // Logger for logging functionality.
-	config *ConfigNode     // Current config node.
+	db              DB              // DB interface object.
+	ctx             context.Context // Context for chaining operation only. Do not set a default value in Core initialization.
+	group           string          // Configuration group name.
+	schema          string          // Custom schema for this object.
+	debug           *gtype.Bool     // Enable debug mode for the database, which can be changed in runtime.
+	cache           *gcache.Cache   // Cache manager, SQL result cache only.
+	links           *gmap.StrAnyMap // links caches all created links by node.
+	health          *gmap.StrAnyMap // health caches the health states of links by node.
+	logger          glog.ILogger    // Logger for logging functionality.
+	audit           *AuditConfig    // Configuration for sql auditing.
+	changeListeners *garray.Array   // Listeners receiving the change events of tables.
+	config          *ConfigNode     // Current config node.
 }
 
 // DoCommitInput is the input parameters for function DoCommit.
@@ -285,6 +299,8 @@ const (
 	defaultMaxIdleConnCount = 10               // Max idle connection count in pool.
 	defaultMaxOpenConnCount = 0                // Max open connection count in pool. Default is no limit.
 	defaultMaxConnLifeTime  = 30 * time.Second // Max lifetime for per connection in pool in seconds.
+	defaultHealthFailures   = 3                // Consecutive health check failures before the pool is evicted.
+	maxHealthCheckBackoff   = 8                // Max multiple of health check interval for backoff on failures.
 	ctxTimeoutTypeExec      = iota
 	ctxTimeoutTypeQuery
 	ctxTimeoutTypePrepare
@@ -409,12 +425,14 @@ func NewByGroup(group ...string) (db DB, err error) {
 // doNewByNode creates and returns an ORM object with given configuration node and group name.
 func doNewByNode(node ConfigNode, group string) (db DB, err error) {
 	c := &Core{
-		group:  group,
-		debug:  gtype.NewBool(),
-		cache:  gcache.New(),
-		links:  gmap.NewStrAnyMap(true),
-		logger: glog.New(),
-		config: &node,
+		group:           group,
+		debug:           gtype.NewBool(),
+		cache:           gcache.New(),
+		links:           gmap.NewStrAnyMap(true),
+		health:          gmap.NewStrAnyMap(true),
+		changeListeners: garray.NewArray(true),
+		logger:          glog.New(),
+		config:          &node,
 	}
 	if v, ok := driverMap[node.Type]; ok {
 		if c.db, err = v.New(c, &node); err != nil {
@@ -593,6 +611,7 @@ func (c *Core) getSqlDb(master bool, schema ...string) (sqlDb *sql.DB, err error
 		} else {
 			sqlDb.SetConnMaxLifetime(defaultMaxConnLifeTime)
 		}
+		c.monitorLink(ctx, node, sqlDb)
 		return sqlDb
 	})
 	if v != nil && sqlDb == nil {
@@ -0,0 +1,262 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/debug/gdebug"
+	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// AuditRecord is the record of an executed sql statement for auditing.
+type AuditRecord struct {
+	Sql           string        // SQL string which contains reserved char '?'.
+	Fingerprint   string        // Normalized SQL of which the literals are replaced with '?', for grouping the similar statements.
+	Args          []interface{} // Arguments for the sql, of which the sensitive ones are redacted.
+	Type          string        // SQL operation type, like: DB.ExecContext.
+	Group         string        // Configuration group name that the sql is executed from.
+	Caller        string        // File path and line of the business code executing the sql.
+	Start         time.Time     // Start time of the execution.
+	Duration      time.Duration // Duration of the execution.
+	RowsAffected  int64         // Retrieved or affected number of rows.
+	Error         error         // Execution error.
+	IsTransaction bool          // Whether the sql is executed in transaction.
+}
+
+// AuditSink is the interface receiving the audit records, like writing them to logger, file or queue.
+// Note that it is called synchronously after each sql statement, it should be fast or asynchronous.
+type AuditSink interface {
+	Write(ctx context.Context, record *AuditRecord)
+}
+
+// AuditSinkFunc is the function adapter for AuditSink.
+type AuditSinkFunc func(ctx context.Context, record *AuditRecord)
+
+// AuditConfig is the configuration for sql auditing.
+type AuditConfig struct {
+	Sink             AuditSink     // Sink receiving the audit records.
+	RedactFields     []string      // Field names of which the argument values are redacted case-insensitively, like: password, id_card.
+	RedactMask       string        // Mask replacing the redacted argument values, default is "******".
+	SelectSampleRate float64       // Sampling rate in (0, 1) for SELECT statements, all SELECTs are audited if it is 0 or >= 1, and none if it is negative.
+	SlowThreshold    time.Duration // The statements slower than it are always audited regardless of sampling, it is disabled if it is 0.
+}
+
+// auditSinkLogger is the AuditSink writing records to logger.
+type auditSinkLogger struct {
+	logger glog.ILogger
+}
+
+const (
+	defaultAuditRedactMask = "******"
+)
+
+var (
+	// auditCallerFilters filters the framework callings for caller of audit record.
+	auditCallerFilters = []string{
+		utils.StackFilterKeyForGoFrame,
+		"/database/gdb/gdb.go",
+		"/database/gdb/gdb_core",
+		"/database/gdb/gdb_model",
+		"/database/gdb/gdb_statement",
+		"/contrib/drivers/",
+	}
+	fingerprintStringReg = regexp.MustCompile(`'(?:[^'\\]|\\.|'')*'|"(?:[^"\\]|\\.)*"`)
+	fingerprintNumberReg = regexp.MustCompile(`\b-?\d+(?:\.\d+)?\b`)
+	fingerprintInReg     = regexp.MustCompile(`(?i)\bIN\s*\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+	fingerprintValuesReg = regexp.MustCompile(`(\(\s*\?(?:\s*,\s*\?)*\s*\))(?:\s*,\s*\(\s*\?(?:\s*,\s*\?)*\s*\))+`)
+	fingerprintSpaceReg  = regexp.MustCompile(`\s+`)
+	auditInsertReg       = regexp.MustCompile(`(?is)^\s*(?:INSERT|REPLACE)\b.*?\(([^()]*)\)\s*VALUES\s*\(`)
+	auditFieldReg        = regexp.MustCompile("(?i)([\\w.`\"\\[\\]]+)\\s*(?:=|<>|!=|>=|<=|>|<|\\s(?:NOT\\s+)?(?:LIKE|IN|BETWEEN))$")
+)
+
+// Write implements AuditSink.
+func (f AuditSinkFunc) Write(ctx context.Context, record *AuditRecord) {
+	f(ctx, record)
+}
+
+// NewAuditSinkLogger creates and returns an AuditSink writing the records to `logger`.
+func NewAuditSinkLogger(logger glog.ILogger) AuditSink {
+	return &auditSinkLogger{
+		logger: logger,
+	}
+}
+
+// Write implements AuditSink.
+func (s *auditSinkLogger) Write(ctx context.Context, record *AuditRecord) {
+	content := fmt.Sprintf(
+		"[audit] [%3d ms] [%s] [rows:%-3d] [%s] %s %v",
+		record.Duration.Milliseconds(), record.Group, record.RowsAffected, record.Caller, record.Sql, record.Args,
+	)
+	if record.Error != nil {
+		s.logger.Error(ctx, content+"\nError: "+record.Error.Error())
+	} else {
+		s.logger.Info(ctx, content)
+	}
+}
+
+// SetAudit sets the sql auditing configuration, it disables auditing if `config` is nil or has no sink.
+func (c *Core) SetAudit(config *AuditConfig) {
+	c.audit = config
+}
+
+// GetAudit returns the sql auditing configuration.
+func (c *Core) GetAudit() *AuditConfig {
+	return c.audit
+}
+
+// FingerprintSql normalizes and returns the fingerprint of `sql`, of which the string and number
+// literals are replaced with '?', the multiple values in "IN" and "VALUES" are collapsed,
+// and the spaces are compacted, so the similar statements have the same fingerprint.
+//
+// Eg: "SELECT * FROM user WHERE id IN(1,2,3) AND name='john'" => "select * from user where id in(?+) and name=?".
+func FingerprintSql(sql string) string {
+	sql = fingerprintStringReg.ReplaceAllString(sql, "?")
+	sql = fingerprintNumberReg.ReplaceAllString(sql, "?")
+	sql = fingerprintInReg.ReplaceAllString(sql, "in(?+)")
+	sql = fingerprintValuesReg.ReplaceAllString(sql, "$1")
+	sql = fingerprintSpaceReg.ReplaceAllString(sql, " ")
+	return strings.ToLower(strings.TrimSpace(sql))
+}
+
+// writeSqlToAudit writes the Sql object to the audit sink if auditing is enabled and the sql is sampled.
+func (c *Core) writeSqlToAudit(ctx context.Context, sql *Sql) {
+	config := c.audit
+	if config == nil || config.Sink == nil || sql.Sql == "" || sql.Type == SqlTypePrepareContext {
+		return
+	}
+	var (
+		duration = time.Duration(sql.End-sql.Start) * time.Millisecond
+		sampled  = sql.Error != nil || (config.SlowThreshold > 0 && duration >= config.SlowThreshold)
+	)
+	if !sampled {
+		sampled = !isSelectSql(sql.Sql) || config.SelectSampleRate == 0 || config.SelectSampleRate >= 1 ||
+			(config.SelectSampleRate > 0 && float64(grand.Intn(1000000)) < config.SelectSampleRate*1000000)
+	}
+	if !sampled {
+		return
+	}
+	var caller string
+	if _, path, line := gdebug.CallerWithFilter(auditCallerFilters); path != "" {
+		caller = fmt.Sprintf(`%s:%d`, path, line)
+	}
+	config.Sink.Write(ctx, &AuditRecord{
+		Sql:           sql.Sql,
+		Fingerprint:   FingerprintSql(sql.Sql),
+		Args:          redactArgs(sql.Sql, sql.Args, config),
+		Type:          sql.Type,
+		Group:         sql.Group,
+		Caller:        caller,
+		Start:         time.Unix(0, sql.Start*int64(time.Millisecond)),
+		Duration:      duration,
+		RowsAffected:  sql.RowsAffected,
+		Error:         sql.Error,
+		IsTransaction: sql.IsTransaction,
+	})
+}
+
+// isSelectSql checks and returns whether `sql` is a SELECT statement.
+func isSelectSql(sql string) bool {
+	sql = strings.ToUpper(strings.TrimLeft(sql, " \t\r\n("))
+	return strings.HasPrefix(sql, "SELECT") || strings.HasPrefix(sql, "WITH")
+}
+
+// redactArgs returns a copy of `args` of which the values for the redacted fields are replaced with mask.
+func redactArgs(sql string, args []interface{}, config *AuditConfig) []interface{} {
+	if len(args) == 0 || len(config.RedactFields) == 0 {
+		return args
+	}
+	var (
+		mask     = config.RedactMask
+		fields   = getArgFieldNames(sql, len(args))
+		redacted = make([]interface{}, len(args))
+	)
+	if mask == "" {
+		mask = defaultAuditRedactMask
+	}
+	copy(redacted, args)
+	for i, field := range fields {
+		if field == "" {
+			continue
+		}
+		for _, redactField := range config.RedactFields {
+			if strings.EqualFold(field, redactField) {
+				redacted[i] = mask
+				break
+			}
+		}
+	}
+	return redacted
+}
+
+// getArgFieldNames guesses and returns the field names of the `count` placeholders in `sql` by order.
+// The field name is empty if it cannot be guessed, like the placeholder in function.
+func getArgFieldNames(sql string, count int) []string {
+	var (
+		fields  = make([]string, count)
+		columns []string
+		index   int
+		quote   byte
+	)
+	// The values of INSERT/REPLACE statement are matched with the columns by position.
+	if match := auditInsertReg.FindStringSubmatch(sql); len(match) > 1 {
+		for _, column := range strings.Split(match[1], ",") {
+			columns = append(columns, formatArgFieldName(column))
+		}
+	}
+	for i := 0; i < len(sql) && index < count; i++ {
+		switch {
+		case quote != 0:
+			if sql[i] == quote {
+				quote = 0
+			}
+		case sql[i] == '\'' || sql[i] == '"':
+			quote = sql[i]
+		case sql[i] == '?':
+			if len(columns) > 0 {
+				fields[index] = columns[index%len(columns)]
+			} else {
+				fields[index] = guessArgFieldName(sql[:i])
+			}
+			index++
+		}
+	}
+	return fields
+}
+
+// guessArgFieldName guesses and returns the field name of the placeholder after `prefix`,
+// like: "name=", "id IN(?,", "age BETWEEN ? AND".
+func guessArgFieldName(prefix string) string {
+	for {
+		prefix = strings.TrimRight(prefix, " \t\r\n,?(")
+		upper := strings.ToUpper(prefix)
+		if !strings.HasSuffix(upper, " AND") || !strings.HasSuffix(strings.TrimSpace(prefix[:len(prefix)-4]), "?") {
+			break
+		}
+		// The second placeholder of BETWEEN.
+		prefix = prefix[:len(prefix)-4]
+	}
+	if match := auditFieldReg.FindStringSubmatch(prefix); len(match) > 1 {
+		return formatArgFieldName(match[1])
+	}
+	return ""
+}
+
+// formatArgFieldName removes the quote chars and the table prefix of field name `name`.
+func formatArgFieldName(name string) string {
+	name = strings.Trim(strings.TrimSpace(name), "`\"[]")
+	if pos := strings.LastIndex(name, "."); pos != -1 {
+		name = name[pos+1:]
+	}
+	return strings.Trim(name, "`\"[]")
+}
@@ -0,0 +1,178 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// ChangeEvent is the event of table rows changed by Model, which is emitted to the change listeners
+// after the changing succeeds, or after the transaction commits if it is in transaction.
+type ChangeEvent struct {
+	Group        string        // Configuration group name of the database.
+	Schema       string        // Schema of the table.
+	Table        string        // Table name, without alias.
+	Operation    string        // Operation of the changing, like: insert, update, delete.
+	Keys         []interface{} // Primary key values of the changed rows, which is empty if they cannot be determined from the data or condition, that the whole table should be treated as changed.
+	RowsAffected int64         // Affected number of rows, which is -1 if the driver does not support it.
+}
+
+// ChangeListener is the interface receiving the change events, like invalidating caches or publishing messages.
+// Note that it is called synchronously after the changing, it should be fast or asynchronous.
+type ChangeListener interface {
+	OnChange(ctx context.Context, event *ChangeEvent)
+}
+
+// ChangeListenerFunc is the function adapter for ChangeListener.
+type ChangeListenerFunc func(ctx context.Context, event *ChangeEvent)
+
+const (
+	ChangeOperationInsert = "insert"
+	ChangeOperationUpdate = "update"
+	ChangeOperationDelete = "delete"
+)
+
+// OnChange implements ChangeListener.
+func (f ChangeListenerFunc) OnChange(ctx context.Context, event *ChangeEvent) {
+	f(ctx, event)
+}
+
+// AddChangeListener adds `listener` receiving the change events of the tables changed by Model,
+// which is usually used for keeping the derived caches consistent without touching every call site.
+//
+// Note that the changes by raw sql statements like Exec are not emitted.
+func (c *Core) AddChangeListener(listener ChangeListener) {
+	c.changeListeners.Append(listener)
+}
+
+// emitChangeEvents emits `events` to the change listeners.
+func (c *Core) emitChangeEvents(ctx context.Context, events ...*ChangeEvent) {
+	if c.changeListeners.Len() == 0 {
+		return
+	}
+	listeners := c.changeListeners.Slice()
+	for _, event := range events {
+		for _, listener := range listeners {
+			listener.(ChangeListener).OnChange(ctx, event)
+		}
+	}
+}
+
+// addChangeEvent emits change event of `operation` with `result` to the change listeners,
+// or retains it in transaction which is emitted after the transaction commits, including the
+// transaction carried by context `ctx`.
+func (m *Model) addChangeEvent(ctx context.Context, operation string, result sql.Result) {
+	core := m.db.GetCore()
+	if core.changeListeners.Len() == 0 {
+		return
+	}
+	event := &ChangeEvent{
+		Group:        m.db.GetGroup(),
+		Schema:       m.db.GetSchema(),
+		Table:        gstr.SplitAndTrim(m.tablesInit, " ")[0],
+		Operation:    operation,
+		RowsAffected: -1,
+	}
+	if result != nil && !core.GetIgnoreResultFromCtx(ctx) {
+		if n, err := result.RowsAffected(); err == nil {
+			if n == 0 {
+				return
+			}
+			event.RowsAffected = n
+		}
+	}
+	if primaryKey := m.getPrimaryKey(); primaryKey != "" {
+		if operation == ChangeOperationInsert {
+			event.Keys = m.getChangedKeysFromData(primaryKey, result)
+		} else {
+			event.Keys = m.getChangedKeysFromWhere(primaryKey)
+		}
+	}
+	// The transaction is either bound to the model or carried by the context.
+	tx := m.tx
+	if tx == nil {
+		tx = TXFromCtx(ctx, m.db.GetGroup())
+	}
+	if tx != nil && !tx.IsClosed() {
+		tx.changeEvents = append(tx.changeEvents, event)
+		return
+	}
+	core.emitChangeEvents(ctx, event)
+}
+
+// getChangedKeysFromData returns the primary key values of inserted data, or the last insert id
+// if only one row is inserted without primary key value.
+func (m *Model) getChangedKeysFromData(primaryKey string, result sql.Result) []interface{} {
+	var list List
+	switch value := m.data.(type) {
+	case List:
+		list = value
+	case Map:
+		list = List{value}
+	default:
+		return nil
+	}
+	keys := make([]interface{}, 0, len(list))
+	for _, item := range list {
+		if v, ok := item[primaryKey]; ok && !isZeroChangedKey(v) {
+			keys = append(keys, v)
+		}
+	}
+	if len(keys) == 0 && len(list) == 1 && result != nil {
+		if id, err := result.LastInsertId(); err == nil && id > 0 {
+			keys = append(keys, id)
+		}
+	}
+	if len(keys) != len(list) {
+		return nil
+	}
+	return keys
+}
+
+// getChangedKeysFromWhere returns the primary key values from the where conditions, which supports
+// the conditions like: WherePri(1), Where("id", 1), Where(g.Map{"id": g.Slice{1, 2}}).
+// It returns nil if there's no such condition of the primary key, or there's OR condition.
+func (m *Model) getChangedKeysFromWhere(primaryKey string) []interface{} {
+	if m.whereBuilder == nil {
+		return nil
+	}
+	var keys []interface{}
+	for _, holder := range m.whereBuilder.whereHolder {
+		if holder.Operator == whereHolderOperatorOr {
+			return nil
+		}
+		var value interface{}
+		switch where := holder.Where.(type) {
+		case string:
+			if len(holder.Args) == 1 && gstr.Equal(gstr.Trim(where, "`\""), primaryKey) {
+				value = holder.Args[0]
+			}
+		case Map:
+			value = where[primaryKey]
+		}
+		if value != nil {
+			keys = nil
+			if reflect.ValueOf(value).Kind() == reflect.Slice {
+				keys = append(keys, gconv.Interfaces(value)...)
+			} else {
+				keys = append(keys, value)
+			}
+		}
+	}
+	return keys
+}
+
+// isZeroChangedKey checks whether `v` is a zero primary key value, like nil, 0 and empty string,
+// which is generated by database.
+func isZeroChangedKey(v interface{}) bool {
+	return v == nil || gconv.String(v) == "" || gconv.String(v) == "0"
+}
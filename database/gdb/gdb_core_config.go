@@ -50,6 +50,9 @@ type ConfigNode struct {
 	UpdatedAt            string        `json:"updatedAt"`            // (Optional) The filed name of table for automatic-filled updated datetime.
 	DeletedAt            string        `json:"deletedAt"`            // (Optional) The filed name of table for automatic-filled updated datetime.
 	TimeMaintainDisabled bool          `json:"timeMaintainDisabled"` // (Optional) Disable the automatic time maintaining feature.
+	HealthCheckInterval  time.Duration `json:"healthCheckInterval"`  // (Optional) Interval for pinging the underlying connection pool, health check is disabled if it is 0.
+	HealthCheckFailures  int           `json:"healthCheckFailures"`  // (Optional, 3 in default) Consecutive health check failures before the connection pool is evicted.
+	TenantField          string        `json:"tenantField"`          // (Optional) The field name of table for multi-tenancy, the tables having this field are scoped by the tenant id from context.
 }
 
 const (
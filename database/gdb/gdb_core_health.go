@@ -0,0 +1,169 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gtimer"
+)
+
+// PoolStats is the statistics of an underlying connection pool.
+type PoolStats struct {
+	sql.DBStats                   // Statistics of the pool, like in-use, idle, wait count and wait duration.
+	Host                string    // Host of the node.
+	Port                string    // Port of the node.
+	Name                string    // Database name of the node.
+	Role                string    // Role of the node, master or slave.
+	Healthy             bool      // Whether the last health check succeeded, it is true if health check is disabled.
+	ConsecutiveFailures int       // Count of the consecutive health check failures.
+	LastCheckTime       time.Time // Time of the last health check, it is zero if health check is disabled.
+	LastError           error     // Error of the last failed health check, it is nil if the last check succeeded.
+	Evictions           int       // Count of the evictions of the pool of this node because of health check failures.
+}
+
+// linkHealth is the health state of the connection pool of a node.
+// It is kept after the pool is evicted, so the backoff and evictions continue for the reopened pool.
+type linkHealth struct {
+	mu        sync.RWMutex
+	node      *ConfigNode // Configuration node of the pool.
+	failures  int         // Consecutive health check failures.
+	lastCheck time.Time   // Time of the last health check.
+	lastError error       // Error of the last failed health check.
+	evictions int         // Count of evictions.
+}
+
+// Stats returns the statistics of all opened underlying connection pools of current DB,
+// which is usually used for monitoring.
+func (c *Core) Stats(ctx context.Context) []PoolStats {
+	var statsList = make([]PoolStats, 0)
+	c.links.RLockFunc(func(m map[string]interface{}) {
+		for key, v := range m {
+			sqlDb, ok := v.(*sql.DB)
+			if !ok || sqlDb == nil {
+				continue
+			}
+			stats := PoolStats{
+				DBStats: sqlDb.Stats(),
+				Healthy: true,
+			}
+			if health, ok := c.health.Get(key).(*linkHealth); ok {
+				health.mu.RLock()
+				stats.Host, stats.Port = health.node.Host, health.node.Port
+				stats.Name, stats.Role = health.node.Name, health.node.Role
+				stats.Healthy = health.failures == 0
+				stats.ConsecutiveFailures = health.failures
+				stats.LastCheckTime = health.lastCheck
+				stats.LastError = health.lastError
+				stats.Evictions = health.evictions
+				health.mu.RUnlock()
+			}
+			statsList = append(statsList, stats)
+		}
+	})
+	return statsList
+}
+
+// monitorLink records the health state of the connection pool `sqlDb` of `node`, and starts pinging
+// it periodically if health check is enabled. The pool is evicted after configured consecutive failures,
+// so that it is reopened by next operation, which discards the dead connections after the database fails over.
+func (c *Core) monitorLink(ctx context.Context, node *ConfigNode, sqlDb *sql.DB) {
+	var (
+		key    = node.String()
+		health = c.health.GetOrSetFuncLock(key, func() interface{} {
+			return &linkHealth{node: node}
+		}).(*linkHealth)
+	)
+	if c.config.HealthCheckInterval <= 0 {
+		return
+	}
+	// The checks run in background, which should not be canceled along with the operation context.
+	ctx = gctx.New()
+	gtimer.AddOnce(ctx, c.getHealthCheckDelay(health), func(ctx context.Context) {
+		c.doHealthCheck(ctx, key, sqlDb, health)
+	})
+}
+
+// doHealthCheck pings `sqlDb` and schedules the next check.
+// It stops checking if `sqlDb` is closed or evicted.
+func (c *Core) doHealthCheck(ctx context.Context, key string, sqlDb *sql.DB, health *linkHealth) {
+	if c.links.Get(key) != sqlDb {
+		return
+	}
+	var (
+		interval           = c.config.HealthCheckInterval
+		maxFailures        = c.config.HealthCheckFailures
+		timeoutCtx, cancel = context.WithTimeout(ctx, interval)
+	)
+	err := sqlDb.PingContext(timeoutCtx)
+	cancel()
+	if maxFailures <= 0 {
+		maxFailures = defaultHealthFailures
+	}
+	health.mu.Lock()
+	health.lastCheck = time.Now()
+	if err == nil {
+		health.failures = 0
+		health.lastError = nil
+		health.mu.Unlock()
+	} else {
+		health.failures++
+		health.lastError = gerror.WrapCode(gcode.CodeDbOperationError, err, `health check ping failed`)
+		failures := health.failures
+		health.mu.Unlock()
+		intlog.Errorf(ctx, `health check of link "%s" failed %d times: %+v`, key, failures, err)
+		if failures%maxFailures == 0 {
+			c.evictLink(ctx, key, sqlDb, health)
+			return
+		}
+	}
+	gtimer.AddOnce(ctx, c.getHealthCheckDelay(health), func(ctx context.Context) {
+		c.doHealthCheck(ctx, key, sqlDb, health)
+	})
+}
+
+// evictLink removes `sqlDb` from the cached links and closes it.
+// The in-use connections are closed after they are released.
+func (c *Core) evictLink(ctx context.Context, key string, sqlDb *sql.DB, health *linkHealth) {
+	var evicted bool
+	c.links.LockFunc(func(m map[string]interface{}) {
+		if m[key] == sqlDb {
+			delete(m, key)
+			evicted = true
+		}
+	})
+	if !evicted {
+		return
+	}
+	health.mu.Lock()
+	health.evictions++
+	health.mu.Unlock()
+	intlog.Printf(ctx, `evict link "%s" for health check failures`, key)
+	if err := sqlDb.Close(); err != nil {
+		intlog.Errorf(ctx, `close evicted link "%s" failed: %+v`, key, err)
+	}
+}
+
+// getHealthCheckDelay returns the delay of the next health check, which backs off exponentially
+// on consecutive failures to avoid hammering an unavailable database.
+func (c *Core) getHealthCheckDelay(health *linkHealth) time.Duration {
+	health.mu.RLock()
+	failures := health.failures
+	health.mu.RUnlock()
+	multiple := 1
+	for i := 0; i < failures && multiple < maxHealthCheckBackoff; i++ {
+		multiple *= 2
+	}
+	return c.config.HealthCheckInterval * time.Duration(multiple)
+}
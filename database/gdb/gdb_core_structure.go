@@ -33,6 +33,10 @@ func (c *Core) ConvertDataForRecord(ctx context.Context, value interface{}) (map
 		err  error
 		data = DataToMapDeep(value)
 	)
+	// Field codecs declared in orm tags of struct, like encryption.
+	if err = encodeDataByFieldCodec(ctx, value, data, true); err != nil {
+		return nil, err
+	}
 	for k, v := range data {
 		data[k], err = c.ConvertDataForRecordValue(ctx, v)
 		if err != nil {
@@ -20,20 +20,21 @@ import (
 )
 
 const (
-	traceInstrumentName       = "github.com/gogf/gf/v2/database/gdb"
-	traceAttrDbType           = "db.type"
-	traceAttrDbHost           = "db.host"
-	traceAttrDbPort           = "db.port"
-	traceAttrDbName           = "db.name"
-	traceAttrDbUser           = "db.user"
-	traceAttrDbLink           = "db.link"
-	traceAttrDbGroup          = "db.group"
-	traceEventDbExecution     = "db.execution"
-	traceEventDbExecutionSql  = "db.execution.sql"
-	traceEventDbExecutionCost = "db.execution.cost"
-	traceEventDbExecutionRows = "db.execution.rows"
-	traceEventDbExecutionTxID = "db.execution.txid"
-	traceEventDbExecutionType = "db.execution.type"
+	traceInstrumentName          = "github.com/gogf/gf/v2/database/gdb"
+	traceAttrDbType              = "db.type"
+	traceAttrDbHost              = "db.host"
+	traceAttrDbPort              = "db.port"
+	traceAttrDbName              = "db.name"
+	traceAttrDbUser              = "db.user"
+	traceAttrDbLink              = "db.link"
+	traceAttrDbGroup             = "db.group"
+	traceEventDbExecution        = "db.execution"
+	traceEventDbExecutionSql     = "db.execution.sql"
+	traceEventDbExecutionCost    = "db.execution.cost"
+	traceEventDbExecutionRows    = "db.execution.rows"
+	traceEventDbExecutionTxID    = "db.execution.txid"
+	traceEventDbExecutionType    = "db.execution.type"
+	traceEventDbExecutionBaggage = "db.execution.baggage"
 )
 
 // addSqlToTracing adds sql information to tracer if it's enabled.
@@ -82,5 +83,9 @@ func (c *Core) traceSpanEnd(ctx context.Context, span trace.Span, sql *Sql) {
 		}
 	}
 	events = append(events, attribute.String(traceEventDbExecutionType, sql.Type))
+	// The baggage cannot be carried to the database server, so it is recorded along with the statement.
+	if baggageMap := gtrace.GetBaggageMap(ctx); !baggageMap.IsEmpty() {
+		events = append(events, attribute.String(traceEventDbExecutionBaggage, baggageMap.String()))
+	}
 	span.AddEvent(traceEventDbExecution, trace.WithAttributes(events...))
 }
@@ -28,8 +28,33 @@ type TX struct {
 	transactionId    string          // transactionId is a unique id generated by this object for this transaction.
 	transactionCount int             // transactionCount marks the times that Begins.
 	isClosed         bool            // isClosed marks this transaction has already been committed or rolled back.
+	changeEvents     []*ChangeEvent  // changeEvents retains the change events emitted after committing.
+	changeEventMarks []int           // changeEventMarks marks the count of change events for each nested transaction.
 }
 
+// Propagation is the transaction propagation behavior when there's transaction in context,
+// which is used for the composition of service functions having their own transactions.
+type Propagation int
+
+// TxOptions is the options for transaction, see Core.TransactionWithOptions.
+type TxOptions struct {
+	Propagation Propagation // Propagation behavior of the transaction, default is PropagationNested.
+}
+
+const (
+	// PropagationNested executes in a nested transaction using save point if there's transaction in context,
+	// or else it creates a new transaction. It is the default propagation behavior.
+	PropagationNested Propagation = iota
+	// PropagationRequired joins the transaction in context if any, or else it creates a new transaction.
+	// The error of joined function is not rolled back by itself but returned to the caller transaction.
+	PropagationRequired
+	// PropagationRequiresNew always creates a new transaction, which is committed or rolled back independently
+	// of the transaction in context.
+	PropagationRequiresNew
+	// PropagationNotSupported always executes without transaction, even there's transaction in context.
+	PropagationNotSupported
+)
+
 const (
 	transactionPointerPrefix    = "transaction"
 	contextTransactionKeyPrefix = "TransactionObjectForGroup_"
@@ -69,6 +94,19 @@ func (c *Core) doBeginCtx(ctx context.Context) (*TX, error) {
 // Note that, you should not Commit or Rollback the transaction in function `f`
 // as it is automatically handled by this function.
 func (c *Core) Transaction(ctx context.Context, f func(ctx context.Context, tx *TX) error) (err error) {
+	return c.TransactionWithOptions(ctx, TxOptions{Propagation: PropagationNested}, f)
+}
+
+// TransactionWithOptions wraps the transaction logic using function `f` like Transaction,
+// with the propagation behavior `opts.Propagation` for the transaction in context `ctx`:
+// PropagationNested: it executes in a nested transaction of the transaction in context using save point;
+// PropagationRequired: it joins the transaction in context;
+// PropagationRequiresNew: it always executes in a new transaction, which is also injected into context for `f`;
+// PropagationNotSupported: it executes `f` without transaction, of which the parameter `tx` is nil.
+// It creates a new transaction if there's no transaction in context, except PropagationNotSupported.
+func (c *Core) TransactionWithOptions(
+	ctx context.Context, opts TxOptions, f func(ctx context.Context, tx *TX) error,
+) (err error) {
 	if ctx == nil {
 		ctx = c.db.GetCtx()
 	}
@@ -76,8 +114,25 @@ func (c *Core) Transaction(ctx context.Context, f func(ctx context.Context, tx *
 	// Check transaction object from context.
 	var tx *TX
 	tx = TXFromCtx(ctx, c.db.GetGroup())
-	if tx != nil {
-		return tx.Transaction(ctx, f)
+	switch opts.Propagation {
+	case PropagationNested:
+		if tx != nil {
+			return tx.Transaction(ctx, f)
+		}
+
+	case PropagationRequired:
+		if tx != nil {
+			return f(ctx, tx)
+		}
+
+	case PropagationRequiresNew:
+		ctx = withoutTX(ctx, c.db.GetGroup())
+
+	case PropagationNotSupported:
+		return f(withoutTX(ctx, c.db.GetGroup()), nil)
+
+	default:
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid transaction propagation "%d"`, opts.Propagation)
 	}
 	tx, err = c.doBeginCtx(ctx)
 	if err != nil {
@@ -128,6 +183,14 @@ func WithTX(ctx context.Context, tx *TX) context.Context {
 	return ctx
 }
 
+// withoutTX returns a context that hides the transaction object of `group` in `ctx`.
+func withoutTX(ctx context.Context, group string) context.Context {
+	if TXFromCtx(ctx, group) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, transactionKeyForContext(group), nil)
+}
+
 // TXFromCtx retrieves and returns transaction object from context.
 // It is usually used in nested transaction feature, and it returns nil if it is not set previously.
 func TXFromCtx(ctx context.Context, group string) *TX {
@@ -172,6 +235,9 @@ func (tx *TX) Commit() error {
 	if tx.transactionCount > 0 {
 		tx.transactionCount--
 		_, err := tx.Exec("RELEASE SAVEPOINT " + tx.transactionKeyForNestedPoint())
+		if err == nil {
+			tx.popChangeEventMark()
+		}
 		return err
 	}
 	_, err := tx.db.DoCommit(tx.ctx, DoCommitInput{
@@ -182,6 +248,9 @@ func (tx *TX) Commit() error {
 	})
 	if err == nil {
 		tx.isClosed = true
+		events := tx.changeEvents
+		tx.changeEvents = nil
+		tx.db.GetCore().emitChangeEvents(tx.ctx, events...)
 	}
 	return err
 }
@@ -193,6 +262,9 @@ func (tx *TX) Rollback() error {
 	if tx.transactionCount > 0 {
 		tx.transactionCount--
 		_, err := tx.Exec("ROLLBACK TO SAVEPOINT " + tx.transactionKeyForNestedPoint())
+		if err == nil {
+			tx.changeEvents = tx.changeEvents[:tx.popChangeEventMark()]
+		}
 		return err
 	}
 	_, err := tx.db.DoCommit(tx.ctx, DoCommitInput{
@@ -203,6 +275,7 @@ func (tx *TX) Rollback() error {
 	})
 	if err == nil {
 		tx.isClosed = true
+		tx.changeEvents = nil
 	}
 	return err
 }
@@ -219,6 +292,7 @@ func (tx *TX) Begin() error {
 		return err
 	}
 	tx.transactionCount++
+	tx.changeEventMarks = append(tx.changeEventMarks, len(tx.changeEvents))
 	return nil
 }
 
@@ -502,3 +576,13 @@ func (tx *TX) Update(table string, data interface{}, condition interface{}, args
 func (tx *TX) Delete(table string, condition interface{}, args ...interface{}) (sql.Result, error) {
 	return tx.Model(table).Ctx(tx.ctx).Where(condition, args...).Delete()
 }
+
+// popChangeEventMark pops and returns the count of change events marked when the nested transaction begins.
+func (tx *TX) popChangeEventMark() int {
+	if n := len(tx.changeEventMarks); n > 0 {
+		mark := tx.changeEventMarks[n-1]
+		tx.changeEventMarks = tx.changeEventMarks[:n-1]
+		return mark
+	}
+	return len(tx.changeEvents)
+}
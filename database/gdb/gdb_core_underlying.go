@@ -254,6 +254,9 @@ func (c *Core) DoCommit(ctx context.Context, in DoCommitInput) (out DoCommitOutp
 	if c.db.GetDebug() {
 		c.writeSqlToLogger(ctx, sqlObj)
 	}
+
+	// Auditing.
+	c.writeSqlToAudit(ctx, sqlObj)
 	if err != nil && err != sql.ErrNoRows {
 		err = gerror.NewCodef(
 			gcode.CodeDbOperationError,
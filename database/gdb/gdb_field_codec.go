@@ -0,0 +1,355 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"reflect"
+	"strings"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/crypto/gaead"
+	"github.com/gogf/gf/v2/crypto/gaes"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/empty"
+	"github.com/gogf/gf/v2/os/gstructs"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// FieldCodec is the interface for encoding field value before it is written to database,
+// and decoding field value after it is read from database, like encryption and masking.
+//
+// The codec of a field is declared in its orm tag after the field name, like:
+// Phone string `orm:"phone,codec:aes"`.
+type FieldCodec interface {
+	// Encode encodes `value` before it is written to database or used as condition.
+	Encode(ctx context.Context, value interface{}) (interface{}, error)
+
+	// Decode decodes `value` after it is read from database and before it is converted to struct.
+	Decode(ctx context.Context, value interface{}) (interface{}, error)
+}
+
+// FieldCodecMask is the FieldCodec masking the field value when reading, which is used for hiding PII
+// like phone number from the read APIs.
+//
+// Note that the masking is read-only: the masked field is ignored when the struct is written to database,
+// as the struct read from database holds the masked value, which would overwrite the original value
+// if it is written back. Use another struct without the mask codec for writing the field.
+type FieldCodecMask struct {
+	Left  int    // Count of chars kept on the left.
+	Right int    // Count of chars kept on the right.
+	Char  string // Char replacing the masked chars, default is "*".
+}
+
+// iFieldCodecReadOnly is the interface for FieldCodec that only applies to reading, of which the field
+// is ignored when the struct is written to database.
+type iFieldCodecReadOnly interface {
+	ReadOnly() bool
+}
+
+// fieldCodecAES is the FieldCodec encrypting field value using AES.
+type fieldCodecAES struct {
+	key           []byte // Encryption key.
+	ivKey         []byte // Key for synthetic IV of deterministic encryption.
+	deterministic bool   // Deterministic encryption produces the same cipher text for the same value.
+}
+
+const (
+	// ormTagForCodec is the key of codec in orm tag, like: `orm:"phone,codec:aes"`.
+	ormTagForCodec = "codec"
+	// FieldCodecNameMask is the name of built-in FieldCodecMask, which keeps 3 chars on the left
+	// and 4 chars on the right, like phone number 138****5678.
+	FieldCodecNameMask = "mask"
+)
+
+var (
+	// fieldCodecMap is the registered codec name to FieldCodec map.
+	fieldCodecMap = gmap.NewStrAnyMap(true)
+
+	// fieldCodecTagCache caches the field key to codec name map of struct types.
+	fieldCodecTagCache = gmap.NewAnyAnyMap(true)
+)
+
+func init() {
+	RegisterFieldCodec(FieldCodecNameMask, &FieldCodecMask{Left: 3, Right: 4})
+}
+
+// RegisterFieldCodec registers FieldCodec `codec` with `name`, which can be used in orm tag of struct fields.
+//
+// Eg:
+// gdb.RegisterFieldCodec("aes", gdb.NewFieldCodecAES(key, true))
+// and then declares the codec of field in struct: Phone string `orm:"phone,codec:aes"`.
+func RegisterFieldCodec(name string, codec FieldCodec) {
+	fieldCodecMap.Set(name, codec)
+}
+
+// GetFieldCodec returns the FieldCodec registered with `name`, it returns nil if not found.
+func GetFieldCodec(name string) FieldCodec {
+	if v := fieldCodecMap.Get(name); v != nil {
+		return v.(FieldCodec)
+	}
+	return nil
+}
+
+// NewFieldCodecAES creates and returns a FieldCodec encrypting the field value with `key` using AES,
+// of which the encrypted value is base64 encoded string.
+//
+// The deterministic encryption produces the same cipher text for the same value, so that the field
+// can be used in equality conditions like Where(User{Phone: "13800138000"}), which leaks whether
+// two values are equal. The randomized encryption is more secure, but cannot be used in conditions.
+func NewFieldCodecAES(key []byte, deterministic bool) FieldCodec {
+	// The keys for encryption and synthetic IV are derived from the same key with different usages,
+	// so the key of any length can be used.
+	encKey, err := gaead.DeriveKeyHKDF(key, nil, []byte("gdb field codec encryption"))
+	if err != nil {
+		panic(err)
+	}
+	ivKey, err := gaead.DeriveKeyHKDF(key, nil, []byte("gdb field codec iv"))
+	if err != nil {
+		panic(err)
+	}
+	return &fieldCodecAES{
+		key:           encKey,
+		ivKey:         ivKey,
+		deterministic: deterministic,
+	}
+}
+
+// Encode implements FieldCodec, which does nothing, as it is only used for conditions.
+func (c *FieldCodecMask) Encode(ctx context.Context, value interface{}) (interface{}, error) {
+	return value, nil
+}
+
+// ReadOnly marks the masking read-only, so that the masked value is never written to database.
+func (c *FieldCodecMask) ReadOnly() bool {
+	return true
+}
+
+// Decode implements FieldCodec, which masks the middle chars of `value`.
+// The chars are all masked except the first one if the value is not long enough.
+func (c *FieldCodecMask) Decode(ctx context.Context, value interface{}) (interface{}, error) {
+	if empty.IsNil(value) {
+		return value, nil
+	}
+	var (
+		runes       = []rune(gconv.String(value))
+		left, right = c.Left, c.Right
+		char        = c.Char
+	)
+	if char == "" {
+		char = "*"
+	}
+	if len(runes) == 0 {
+		return "", nil
+	}
+	if left+right >= len(runes) {
+		left, right = 1, 0
+	}
+	return string(runes[:left]) + strings.Repeat(char, len(runes)-left-right) + string(runes[len(runes)-right:]), nil
+}
+
+// Encode implements FieldCodec.
+func (c *fieldCodecAES) Encode(ctx context.Context, value interface{}) (interface{}, error) {
+	if empty.IsNil(value) {
+		return value, nil
+	}
+	var (
+		err        error
+		plainText  = gconv.Bytes(value)
+		cipherText []byte
+	)
+	if c.deterministic {
+		// Synthetic IV from the plain text, which makes the same value producing the same cipher text.
+		mac := hmac.New(sha256.New, c.ivKey)
+		mac.Write(plainText)
+		iv := mac.Sum(nil)[:16]
+		if cipherText, err = gaes.EncryptCBC(plainText, c.key, iv); err != nil {
+			return nil, err
+		}
+		cipherText = append(iv, cipherText...)
+	} else {
+		if cipherText, err = gaead.Encrypt(plainText, c.key); err != nil {
+			return nil, err
+		}
+	}
+	return base64.StdEncoding.EncodeToString(cipherText), nil
+}
+
+// Decode implements FieldCodec.
+func (c *fieldCodecAES) Decode(ctx context.Context, value interface{}) (interface{}, error) {
+	if empty.IsNil(value) {
+		return value, nil
+	}
+	encoded := gconv.String(value)
+	if encoded == "" {
+		return "", nil
+	}
+	cipherText, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid encrypted field value`)
+	}
+	var plainText []byte
+	if c.deterministic {
+		if len(cipherText) < 16 {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid encrypted field value`)
+		}
+		iv := cipherText[:16]
+		if plainText, err = gaes.DecryptCBC(cipherText[16:], c.key, iv); err != nil {
+			return nil, err
+		}
+		// The synthetic IV authenticates the plain text, which detects the tampered cipher text.
+		mac := hmac.New(sha256.New, c.ivKey)
+		mac.Write(plainText)
+		if !hmac.Equal(mac.Sum(nil)[:16], iv) {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, `encrypted field value authentication failed`)
+		}
+		return string(plainText), nil
+	}
+	if plainText, err = gaead.Decrypt(cipherText, c.key); err != nil {
+		return nil, err
+	}
+	return string(plainText), nil
+}
+
+// getFieldCodecTags retrieves and returns the field key to codec name map from the orm tags of
+// struct `pointer`, which can also be slice of struct. It returns nil if there's no codec declared.
+func getFieldCodecTags(pointer interface{}) (map[string]string, error) {
+	var reflectType reflect.Type
+	if v, ok := pointer.(reflect.Value); ok {
+		reflectType = v.Type()
+	} else {
+		reflectType = reflect.TypeOf(pointer)
+	}
+	if reflectType == nil {
+		return nil, nil
+	}
+	for {
+		switch reflectType.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			reflectType = reflectType.Elem()
+			continue
+		}
+		break
+	}
+	if reflectType.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	if v := fieldCodecTagCache.Get(reflectType); v != nil {
+		return v.(map[string]string), nil
+	}
+	fields, err := gstructs.Fields(gstructs.FieldsInput{
+		Pointer:         reflect.New(reflectType).Interface(),
+		RecursiveOption: gstructs.RecursiveOptionEmbeddedNoTag,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var codecTags map[string]string
+	for _, field := range fields {
+		var (
+			array     = strings.Split(field.Tag(OrmTagForStruct), ",")
+			fieldKey  = field.Name()
+			codecName string
+		)
+		for i, item := range array {
+			item = strings.TrimSpace(item)
+			if i == 0 && item != "" && !strings.Contains(item, ":") {
+				fieldKey = item
+			}
+			if kv := strings.SplitN(item, ":", 2); len(kv) == 2 && strings.TrimSpace(kv[0]) == ormTagForCodec {
+				codecName = strings.TrimSpace(kv[1])
+			}
+		}
+		if codecName == "" {
+			continue
+		}
+		if GetFieldCodec(codecName) == nil {
+			return nil, gerror.NewCodef(
+				gcode.CodeInvalidConfiguration,
+				`field codec "%s" of field "%s" is not registered`, codecName, field.Name(),
+			)
+		}
+		if codecTags == nil {
+			codecTags = make(map[string]string)
+		}
+		codecTags[fieldKey] = codecName
+	}
+	fieldCodecTagCache.Set(reflectType, codecTags)
+	return codecTags, nil
+}
+
+// encodeDataByFieldCodec encodes the values of `data` that is converted from struct `pointer`,
+// using the codecs declared in orm tags of `pointer`.
+//
+// The parameter `forRecord` specifies whether `data` is written to database as a record,
+// in which the fields of read-only codecs are removed from `data`.
+func encodeDataByFieldCodec(ctx context.Context, pointer interface{}, data map[string]interface{}, forRecord bool) (err error) {
+	codecTags, err := getFieldCodecTags(pointer)
+	if err != nil || len(codecTags) == 0 {
+		return err
+	}
+	for key, codecName := range codecTags {
+		value, ok := data[key]
+		if !ok {
+			continue
+		}
+		codec := GetFieldCodec(codecName)
+		if forRecord {
+			if v, ok := codec.(iFieldCodecReadOnly); ok && v.ReadOnly() {
+				delete(data, key)
+				continue
+			}
+		}
+		if empty.IsNil(value) {
+			continue
+		}
+		if data[key], err = codec.Encode(ctx, value); err != nil {
+			return gerror.Wrapf(err, `encode field "%s" with codec "%s" failed`, key, codecName)
+		}
+	}
+	return nil
+}
+
+// decodeResultByFieldCodec decodes the values of `result` using the codecs declared in orm tags of
+// struct `pointer`, before `result` is converted to `pointer`. It returns a new Result with decoded
+// records, as `result` might be shared from the query cache.
+func decodeResultByFieldCodec(ctx context.Context, pointer interface{}, result Result) (Result, error) {
+	if len(result) == 0 {
+		return result, nil
+	}
+	codecTags, err := getFieldCodecTags(pointer)
+	if err != nil || len(codecTags) == 0 {
+		return result, err
+	}
+	var (
+		decoded   interface{}
+		newResult = make(Result, len(result))
+	)
+	for i, record := range result {
+		newRecord := make(Record, len(record))
+		for key, value := range record {
+			newRecord[key] = value
+		}
+		for key, codecName := range codecTags {
+			value, ok := record[key]
+			if !ok || value.IsNil() {
+				continue
+			}
+			if decoded, err = GetFieldCodec(codecName).Decode(ctx, value.Val()); err != nil {
+				return nil, gerror.Wrapf(err, `decode field "%s" with codec "%s" failed`, key, codecName)
+			}
+			newRecord[key] = gvar.New(decoded)
+		}
+		newResult[i] = newRecord
+	}
+	return newResult, nil
+}
@@ -261,7 +261,9 @@ func getFieldsFromStructOrMap(structOrMap interface{}) (fields []string) {
 			RecursiveOption: gstructs.RecursiveOptionEmbeddedNoTag,
 		})
 		for _, structField := range structFields {
-			if tag := structField.Tag(OrmTagForStruct); tag != "" && gregex.IsMatchString(regularFieldNameRegPattern, tag) {
+			// The field name is the first part of orm tag, like: `orm:"phone,codec:aes"`.
+			tag := gstr.Trim(gstr.Split(structField.Tag(OrmTagForStruct), ",")[0])
+			if tag != "" && gregex.IsMatchString(regularFieldNameRegPattern, tag) {
 				fields = append(fields, tag)
 			} else {
 				fields = append(fields, structField.Name())
@@ -360,7 +362,7 @@ func isKeyValueCanBeOmitEmpty(omitEmpty bool, whereType string, key, value inter
 }
 
 // formatWhereHolder formats where statement and its arguments for `Where` and `Having` statements.
-func formatWhereHolder(ctx context.Context, db DB, in formatWhereHolderInput) (newWhere string, newArgs []interface{}) {
+func formatWhereHolder(ctx context.Context, db DB, in formatWhereHolderInput) (newWhere string, newArgs []interface{}, err error) {
 	var (
 		buffer      = bytes.NewBuffer(nil)
 		reflectInfo = reflection.OriginValueAndKind(in.Where)
@@ -427,6 +429,10 @@ func formatWhereHolder(ctx context.Context, db DB, in formatWhereHolderInput) (n
 			structField reflect.StructField
 			data        = DataToMapDeep(in.Where)
 		)
+		// Field codecs declared in orm tags of struct, like deterministic encryption for equality conditions.
+		if err = encodeDataByFieldCodec(ctx, in.Where, data, false); err != nil {
+			return "", nil, err
+		}
 		// If `Prefix` is given, it checks and retrieves the table name.
 		if in.Prefix != "" {
 			hasTable, _ := db.GetCore().HasTable(in.Prefix)
@@ -519,15 +525,21 @@ func formatWhereHolder(ctx context.Context, db DB, in formatWhereHolderInput) (n
 				whereStr, _ = gregex.ReplaceStringFunc(`(\?)`, whereStr, func(s string) string {
 					index++
 					if i+len(newArgs) == index {
-						sqlWithHolder, holderArgs := model.getFormattedSqlAndArgs(
+						sqlWithHolder, holderArgs, subErr := model.getFormattedSqlAndArgs(
 							ctx, queryTypeNormal, false,
 						)
+						if subErr != nil {
+							err = subErr
+						}
 						newArgs = append(newArgs, holderArgs...)
 						// Automatically adding the brackets.
 						return "(" + sqlWithHolder + ")"
 					}
 					return s
 				})
+				if err != nil {
+					return "", nil, err
+				}
 				in.Args = gutil.SliceDelete(in.Args, i)
 				continue
 			}
@@ -537,7 +549,7 @@ func formatWhereHolder(ctx context.Context, db DB, in formatWhereHolderInput) (n
 	}
 
 	if buffer.Len() == 0 {
-		return "", in.Args
+		return "", in.Args, nil
 	}
 	if len(in.Args) > 0 {
 		newArgs = append(newArgs, in.Args...)
@@ -572,7 +584,8 @@ func formatWhereHolder(ctx context.Context, db DB, in formatWhereHolderInput) (n
 			}
 		}
 	}
-	return handleArguments(newWhere, newArgs)
+	newWhere, newArgs = handleArguments(newWhere, newArgs)
+	return newWhere, newArgs, nil
 }
 
 // formatWhereInterfaces formats `where` as []interface{}.
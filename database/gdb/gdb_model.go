@@ -46,9 +46,11 @@ type Model struct {
 	cacheOption   CacheOption   // Cache option for query statement.
 	hookHandler   HookHandler   // Hook functions for model hook feature.
 	unscoped      bool          // Disables soft deleting features when select/delete operations.
+	withoutTenant bool          // Disables multi-tenancy features, see WithTenant.
 	safe          bool          // If true, it clones and returns a new model object whenever operation done; or else it changes the attribute of current model.
 	onDuplicate   interface{}   // onDuplicate is used for ON "DUPLICATE KEY UPDATE" statement.
 	onDuplicateEx interface{}   // onDuplicateEx is used for excluding some columns ON "DUPLICATE KEY UPDATE" statement.
+	ctes          []modelCTE    // Common table expressions for "WITH" statement.
 }
 
 // ModelHandler is a function that handles given Model and returns a new Model that is custom modified.
@@ -96,13 +98,17 @@ func (c *Core) Model(tableNameQueryOrStruct ...interface{}) *Model {
 				Where: conditionStr,
 				Args:  tableNameQueryOrStruct[1:],
 			}
-			tableStr, extraArgs = formatWhereHolder(ctx, c.db, formatWhereHolderInput{
+			var err error
+			tableStr, extraArgs, err = formatWhereHolder(ctx, c.db, formatWhereHolderInput{
 				WhereHolder: whereHolder,
 				OmitNil:     false,
 				OmitEmpty:   false,
 				Schema:      "",
 				Table:       "",
 			})
+			if err != nil {
+				panic(err)
+			}
 		}
 	}
 	// Normal model creation.
@@ -49,7 +49,17 @@ func (b *WhereBuilder) Clone() *WhereBuilder {
 }
 
 // Build builds current WhereBuilder and returns the condition string and parameters.
+// It panics if any condition fails building, like the field codec encoding failure of struct condition.
 func (b *WhereBuilder) Build() (conditionWhere string, conditionArgs []interface{}) {
+	conditionWhere, conditionArgs, err := b.build()
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// build builds current WhereBuilder and returns the condition string, parameters and the error.
+func (b *WhereBuilder) build() (conditionWhere string, conditionArgs []interface{}, err error) {
 	var (
 		ctx                         = b.model.GetCtx()
 		autoPrefix                  = b.model.getAutoPrefix()
@@ -62,13 +72,16 @@ func (b *WhereBuilder) Build() (conditionWhere string, conditionArgs []interface
 			}
 			switch holder.Operator {
 			case whereHolderOperatorWhere, whereHolderOperatorAnd:
-				newWhere, newArgs := formatWhereHolder(ctx, b.model.db, formatWhereHolderInput{
+				newWhere, newArgs, err := formatWhereHolder(ctx, b.model.db, formatWhereHolderInput{
 					WhereHolder: holder,
 					OmitNil:     b.model.option&optionOmitNilWhere > 0,
 					OmitEmpty:   b.model.option&optionOmitEmptyWhere > 0,
 					Schema:      b.model.schema,
 					Table:       tableForMappingAndFiltering,
 				})
+				if err != nil {
+					return "", nil, err
+				}
 				if len(newWhere) > 0 {
 					if len(conditionWhere) == 0 {
 						conditionWhere = newWhere
@@ -81,13 +94,16 @@ func (b *WhereBuilder) Build() (conditionWhere string, conditionArgs []interface
 				}
 
 			case whereHolderOperatorOr:
-				newWhere, newArgs := formatWhereHolder(ctx, b.model.db, formatWhereHolderInput{
+				newWhere, newArgs, err := formatWhereHolder(ctx, b.model.db, formatWhereHolderInput{
 					WhereHolder: holder,
 					OmitNil:     b.model.option&optionOmitNilWhere > 0,
 					OmitEmpty:   b.model.option&optionOmitEmptyWhere > 0,
 					Schema:      b.model.schema,
 					Table:       tableForMappingAndFiltering,
 				})
+				if err != nil {
+					return "", nil, err
+				}
 				if len(newWhere) > 0 {
 					if len(conditionWhere) == 0 {
 						conditionWhere = newWhere
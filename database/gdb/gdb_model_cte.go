@@ -0,0 +1,106 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// modelCTE is the common table expression definition for "WITH" statement.
+type modelCTE struct {
+	Name      string        // Name of the CTE, which can be along with column names, like: "cte(id, name)".
+	Query     interface{}   // Sub query, which can be type of *Model or raw sql string.
+	Args      []interface{} // Arguments for raw sql string query.
+	Recursive bool          // Whether it is a recursive CTE.
+}
+
+// WithCTE adds a common table expression for the "WITH" statement of the select query,
+// so that the CTE can be referenced as a table by name in the model.
+// The parameter `name` can be along with column names, like: "cte(id, name)".
+// The parameter `query` can be type of *Model or raw sql string with `args`.
+//
+// Eg:
+// db.Model("paid").WithCTE("paid", db.Model("order").Where("status", 1)).Where("amount>?", 100).All()
+// => WITH `paid` AS (SELECT * FROM `order` WHERE `status`=1) SELECT * FROM `paid` WHERE amount>100.
+//
+// Note that it is not named With, as Model.With is for model association feature.
+func (m *Model) WithCTE(name string, query interface{}, args ...interface{}) *Model {
+	return m.appendCTE(modelCTE{
+		Name:  name,
+		Query: query,
+		Args:  args,
+	})
+}
+
+// WithRecursiveCTE acts like WithCTE, but adds a recursive common table expression,
+// which can reference itself in `query`, like the tree traversing query.
+//
+// Eg:
+// db.Model("tree").WithRecursiveCTE("tree(id, parent_id)", "SELECT id, parent_id FROM category WHERE id=? UNION ALL SELECT c.id, c.parent_id FROM category c JOIN tree t ON c.parent_id=t.id", 1).All()
+func (m *Model) WithRecursiveCTE(name string, query interface{}, args ...interface{}) *Model {
+	return m.appendCTE(modelCTE{
+		Name:      name,
+		Query:     query,
+		Args:      args,
+		Recursive: true,
+	})
+}
+
+func (m *Model) appendCTE(cte modelCTE) *Model {
+	model := m.getModel()
+	ctes := make([]modelCTE, len(model.ctes), len(model.ctes)+1)
+	copy(ctes, model.ctes)
+	model.ctes = append(ctes, cte)
+	return model
+}
+
+// formatCTE formats and returns the "WITH" statement and its arguments of the model.
+// It returns empty string if there's no CTE defined.
+func (m *Model) formatCTE(ctx context.Context) (cteSql string, cteArgs []interface{}, err error) {
+	if len(m.ctes) == 0 {
+		return "", nil, nil
+	}
+	var (
+		core      = m.db.GetCore()
+		recursive bool
+		items     = make([]string, 0, len(m.ctes))
+	)
+	for _, cte := range m.ctes {
+		var (
+			name    = gstr.Trim(cte.Name)
+			columns string
+		)
+		if pos := strings.Index(name, "("); pos != -1 {
+			columns = "(" + core.QuoteString(gstr.Trim(name[pos:], "()")) + ")"
+			name = gstr.Trim(name[:pos])
+		}
+		var querySql string
+		switch query := cte.Query.(type) {
+		case *Model:
+			var holderArgs []interface{}
+			if querySql, holderArgs, err = query.getFormattedSqlAndArgs(ctx, queryTypeNormal, false); err != nil {
+				return "", nil, err
+			}
+			cteArgs = append(cteArgs, query.mergeArguments(holderArgs)...)
+		default:
+			querySql = fmt.Sprintf(`%v`, query)
+			cteArgs = append(cteArgs, cte.Args...)
+		}
+		if cte.Recursive {
+			recursive = true
+		}
+		items = append(items, fmt.Sprintf(`%s%s AS (%s)`, core.QuoteWord(name), columns, querySql))
+	}
+	if recursive {
+		return "WITH RECURSIVE " + strings.Join(items, ",") + " ", cteArgs, nil
+	}
+	return "WITH " + strings.Join(items, ",") + " ", cteArgs, nil
+}
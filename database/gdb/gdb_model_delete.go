@@ -27,12 +27,18 @@ func (m *Model) Delete(where ...interface{}) (result sql.Result, err error) {
 	defer func() {
 		if err == nil {
 			m.checkAndRemoveSelectCache(ctx)
+			m.removeIdentityMapTable(ctx)
+			m.addChangeEvent(ctx, ChangeOperationDelete, result)
 		}
 	}()
-	var (
-		fieldNameDelete                               = m.getSoftFieldNameDeleted()
-		conditionWhere, conditionExtra, conditionArgs = m.formatCondition(ctx, false, false)
-	)
+	if err = m.checkTenant(ctx); err != nil {
+		return nil, err
+	}
+	var fieldNameDelete = m.getSoftFieldNameDeleted()
+	conditionWhere, conditionExtra, conditionArgs, err := m.formatCondition(ctx, false, false)
+	if err != nil {
+		return nil, err
+	}
 	// Soft deleting.
 	if !m.unscoped && fieldNameDelete != "" {
 		in := &HookUpdateInput{
@@ -51,7 +57,13 @@ func (m *Model) Delete(where ...interface{}) (result sql.Result, err error) {
 		return in.Next(ctx)
 	}
 	conditionStr := conditionWhere + conditionExtra
-	if !gstr.ContainsI(conditionStr, " WHERE ") {
+	// The tenant condition does not count, which prevents deleting all records of the tenant by mistake.
+	userCondition, _, _ := m.whereBuilder.build()
+	tenantTables, err := m.getTenantTables()
+	if err != nil {
+		return nil, err
+	}
+	if !gstr.ContainsI(conditionStr, " WHERE ") || (userCondition == "" && len(tenantTables) > 0) {
 		return nil, gerror.NewCode(
 			gcode.CodeMissingParameter,
 			"there should be WHERE condition statement for DELETE operation",
@@ -0,0 +1,77 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"io"
+
+	"github.com/gogf/gf/v2/util/gpage"
+)
+
+// ExportCSV queries the records in batches and writes them to `w` as CSV content in streaming,
+// which does not load all records in memory, for the downloading of large reports.
+// The `w` can be a file, or the ghttp.Response for downloading.
+//
+// The records are queried using keyset pagination if `CursorOrder` of options is specified,
+// which is recommended for large tables, or else OFFSET pagination is used.
+//
+// Eg:
+// r.Response.Header().Set("Content-Type", "text/csv")
+// err := dao.User.Ctx(ctx).Fields("id,name").ExportCSV(r.Response, gdb.ExportOptions{CursorOrder: "id"})
+func (m *Model) ExportCSV(w io.Writer, options ...ExportOptions) error {
+	return m.doExport(NewCsvWriter(w, options...), options...)
+}
+
+// ExportXlsx queries the records in batches and writes them to `w` as Excel(xlsx) content in streaming.
+// See ExportCSV.
+func (m *Model) ExportXlsx(w io.Writer, options ...ExportOptions) error {
+	return m.doExport(NewXlsxWriter(w, options...), options...)
+}
+
+// doExport queries the records in batches and writes them using `writer`.
+func (m *Model) doExport(writer ResultWriter, options ...ExportOptions) (err error) {
+	var (
+		batchSize   = defaultExportBatchSize
+		cursorOrder string
+	)
+	if len(options) > 0 {
+		if options[0].BatchSize > 0 {
+			batchSize = options[0].BatchSize
+		}
+		cursorOrder = options[0].CursorOrder
+	}
+	if cursorOrder != "" {
+		cursor, err := gpage.NewCursor(cursorOrder)
+		if err != nil {
+			return err
+		}
+		for {
+			result, err := m.Clone().Cursor(cursor, batchSize).All()
+			if err != nil {
+				return err
+			}
+			if err = writer.WriteResult(result); err != nil {
+				return err
+			}
+			if len(result) < batchSize {
+				break
+			}
+			cursor = cursor.Next(result[len(result)-1].Map())
+		}
+	} else {
+		m.Clone().Chunk(batchSize, func(result Result, e error) bool {
+			if err = e; err == nil {
+				err = writer.WriteResult(result)
+			}
+			return err == nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
@@ -116,6 +116,59 @@ func (m *Model) FieldAvg(column string, as ...string) *Model {
 	return m.appendFieldsByStr(fmt.Sprintf(`AVG(%s)%s`, m.QuoteWord(column), asStr))
 }
 
+// Window is the window definition for window functions, which forms the "OVER" clause, like:
+// OVER(PARTITION BY `dept_id` ORDER BY `salary` DESC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW).
+type Window struct {
+	PartitionBy string // Fields of "PARTITION BY", multiple fields joined using char ','.
+	OrderBy     string // Fields of "ORDER BY", like: "salary DESC, id".
+	Frame       string // Frame clause, like: "ROWS BETWEEN 1 PRECEDING AND CURRENT ROW".
+}
+
+// FieldWindow formats and appends window function field `function OVER(window)` to the select fields of model.
+// The parameter `function` is not quoted, which can be ranking function or aggregate function.
+//
+// Eg:
+// FieldWindow("SUM(amount)", Window{PartitionBy: "user_id", OrderBy: "id"}, "total")
+// => SUM(amount) OVER(PARTITION BY `user_id` ORDER BY `id`) AS `total`.
+func (m *Model) FieldWindow(function string, window Window, as ...string) *Model {
+	var (
+		core    = m.db.GetCore()
+		clauses = make([]string, 0, 3)
+		asStr   string
+	)
+	if window.PartitionBy != "" {
+		clauses = append(clauses, "PARTITION BY "+core.QuoteString(window.PartitionBy))
+	}
+	if window.OrderBy != "" {
+		clauses = append(clauses, "ORDER BY "+core.QuoteString(window.OrderBy))
+	}
+	if window.Frame != "" {
+		clauses = append(clauses, window.Frame)
+	}
+	if len(as) > 0 && as[0] != "" {
+		asStr = fmt.Sprintf(` AS %s`, core.QuoteWord(as[0]))
+	}
+	return m.appendFieldsByStr(fmt.Sprintf(`%s OVER(%s)%s`, function, gstr.Join(clauses, " "), asStr))
+}
+
+// FieldRowNumber formats and appends commonly used window function field `ROW_NUMBER() OVER(window)`
+// to the select fields of model, which numbers the rows in each partition.
+func (m *Model) FieldRowNumber(window Window, as ...string) *Model {
+	return m.FieldWindow("ROW_NUMBER()", window, as...)
+}
+
+// FieldRank formats and appends commonly used window function field `RANK() OVER(window)`
+// to the select fields of model, which ranks the rows in each partition with gaps.
+func (m *Model) FieldRank(window Window, as ...string) *Model {
+	return m.FieldWindow("RANK()", window, as...)
+}
+
+// FieldDenseRank formats and appends commonly used window function field `DENSE_RANK() OVER(window)`
+// to the select fields of model, which ranks the rows in each partition without gaps.
+func (m *Model) FieldDenseRank(window Window, as ...string) *Model {
+	return m.FieldWindow("DENSE_RANK()", window, as...)
+}
+
 // GetFieldsStr retrieves and returns all fields from the table, joined with char ','.
 // The optional parameter `prefix` specifies the prefix for each field, eg: GetFieldsStr("u.").
 func (m *Model) GetFieldsStr(prefix ...string) string {
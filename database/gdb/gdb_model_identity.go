@@ -0,0 +1,195 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// identityMap holds the struct instances retrieved by primary key in context,
+// which is keyed by table and then by struct type and primary key value.
+type identityMap struct {
+	mu     sync.Mutex
+	tables map[string]map[string]reflect.Value
+}
+
+const (
+	contextKeyForIdentityMap gctx.StrKey = `IdentityMapInContext`
+)
+
+// WithIdentityMap creates and returns a context containing an identity map, which makes the repeated
+// primary key lookups by Model.ScanPri within the context return the same struct instance without
+// extra queries. It is usually called in the middleware for each request, so that the identity map
+// is scoped to the request and released after the request is done.
+//
+// The identity map of a table is cleared if there's any insert/update/delete operation on the table
+// with the context. It does nothing if `ctx` already contains an identity map.
+func WithIdentityMap(ctx context.Context) context.Context {
+	if ctx.Value(contextKeyForIdentityMap) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKeyForIdentityMap, &identityMap{
+		tables: make(map[string]map[string]reflect.Value),
+	})
+}
+
+// getIdentityMap retrieves and returns the identity map from context `ctx`.
+// It returns nil if there's no identity map in the context.
+func getIdentityMap(ctx context.Context) *identityMap {
+	if ctx == nil {
+		return nil
+	}
+	if v := ctx.Value(contextKeyForIdentityMap); v != nil {
+		return v.(*identityMap)
+	}
+	return nil
+}
+
+// ScanPri retrieves the record by primary key `key` and converts it into `pointer`,
+// which should be type of **struct or *struct.
+//
+// If the context of the model contains an identity map, see WithIdentityMap, the struct instance is
+// retained in the identity map, and the repeated lookups by the same primary key return the same
+// struct instance for **struct, or a copy of it for *struct, without querying the database.
+// The identity map is bypassed if the model carries the operations affecting the retrieved record,
+// like Fields, Where, Join, LockUpdate, Unscoped and WithoutTenant.
+//
+// Example:
+// ctx = gdb.WithIdentityMap(ctx)
+// var user1, user2 *User
+// err := db.Model("user").Ctx(ctx).ScanPri(&user1, 1)
+// err  = db.Model("user").Ctx(ctx).ScanPri(&user2, 1)
+// // user1 == user2
+func (m *Model) ScanPri(pointer interface{}, key interface{}) error {
+	var (
+		ctx          = m.GetCtx()
+		reflectValue = reflect.ValueOf(pointer)
+	)
+	if reflectValue.Kind() != reflect.Ptr || reflectValue.IsNil() {
+		return gerror.NewCode(
+			gcode.CodeInvalidParameter,
+			`the parameter "pointer" for function ScanPri should type of **struct/*struct`,
+		)
+	}
+	var (
+		elemValue    = reflectValue.Elem()
+		pointerToPtr = elemValue.Kind() == reflect.Ptr
+		structType   = elemValue.Type()
+	)
+	if pointerToPtr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return gerror.NewCode(
+			gcode.CodeInvalidParameter,
+			`the parameter "pointer" for function ScanPri should type of **struct/*struct`,
+		)
+	}
+	identities := getIdentityMap(ctx)
+	if identities == nil || !m.canUseIdentityMap() {
+		return m.WherePri(key).Scan(pointer)
+	}
+	var (
+		tableKey = m.getIdentityMapTableKey()
+		// The tenant id is in the key, as the context of the same identity map might have different tenants.
+		identityKey = fmt.Sprintf(`%s@%s@%s`, structType.String(), gconv.String(GetTenant(ctx)), gconv.String(key))
+	)
+	if cached, ok := identities.get(tableKey, identityKey); ok {
+		if pointerToPtr {
+			elemValue.Set(cached)
+		} else {
+			elemValue.Set(cached.Elem())
+		}
+		return nil
+	}
+	if !pointerToPtr {
+		if err := m.WherePri(key).Scan(pointer); err != nil {
+			return err
+		}
+		copied := reflect.New(structType)
+		copied.Elem().Set(elemValue)
+		identities.set(tableKey, identityKey, copied)
+		return nil
+	}
+	// It scans into a new struct instance, as the one `pointer` pointed to might be retained
+	// in the identity map for other primary key.
+	newPointer := reflect.New(elemValue.Type())
+	if err := m.WherePri(key).Scan(newPointer.Interface()); err != nil {
+		return err
+	}
+	elemValue.Set(newPointer.Elem())
+	// No record found.
+	if elemValue.IsNil() {
+		return nil
+	}
+	identities.set(tableKey, identityKey, newPointer.Elem())
+	return nil
+}
+
+// canUseIdentityMap checks whether the model retrieves the whole record by primary key only,
+// so that the retrieved record can be shared in identity map.
+func (m *Model) canUseIdentityMap() bool {
+	if m.rawSql != "" || m.unscoped || m.withoutTenant || m.lockInfo != "" {
+		return false
+	}
+	if (m.fields != "" && m.fields != defaultFields) || m.fieldsEx != "" {
+		return false
+	}
+	if m.whereBuilder != nil && len(m.whereBuilder.whereHolder) > 0 {
+		return false
+	}
+	if m.tables != m.tablesInit || m.groupBy != "" || len(m.having) > 0 {
+		return false
+	}
+	return true
+}
+
+// removeIdentityMapTable clears the identity map of the model table in context `ctx`.
+func (m *Model) removeIdentityMapTable(ctx context.Context) {
+	if identities := getIdentityMap(ctx); identities != nil {
+		identities.remove(m.getIdentityMapTableKey())
+	}
+}
+
+// getIdentityMapTableKey returns the table key of the model in identity map.
+func (m *Model) getIdentityMapTableKey() string {
+	return fmt.Sprintf(
+		`%s@%s.%s`,
+		m.db.GetGroup(), m.db.GetSchema(), gstr.SplitAndTrim(m.tablesInit, " ")[0],
+	)
+}
+
+func (i *identityMap) get(tableKey, identityKey string) (reflect.Value, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	v, ok := i.tables[tableKey][identityKey]
+	return v, ok
+}
+
+func (i *identityMap) set(tableKey, identityKey string, value reflect.Value) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.tables[tableKey] == nil {
+		i.tables[tableKey] = make(map[string]reflect.Value)
+	}
+	i.tables[tableKey][identityKey] = value
+}
+
+func (i *identityMap) remove(tableKey string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.tables, tableKey)
+}
@@ -242,6 +242,8 @@ func (m *Model) doInsertWithOption(ctx context.Context, insertOption int) (resul
 	defer func() {
 		if err == nil {
 			m.checkAndRemoveSelectCache(ctx)
+			m.removeIdentityMapTable(ctx)
+			m.addChangeEvent(ctx, ChangeOperationInsert, result)
 		}
 	}()
 	if m.data == nil {
@@ -329,6 +331,17 @@ func (m *Model) doInsertWithOption(ctx context.Context, insertOption int) (resul
 		return result, gerror.NewCode(gcode.CodeMissingParameter, "data list cannot be empty")
 	}
 
+	// Multi-tenancy.
+	if err = m.checkTenant(ctx); err != nil {
+		return nil, err
+	}
+	if err = m.checkTenantForInsertOption(insertOption); err != nil {
+		return nil, err
+	}
+	if err = m.fillTenantForInsert(ctx, list); err != nil {
+		return nil, err
+	}
+
 	// Automatic handling for creating/updating time.
 	if !m.unscoped && (fieldNameCreate != "" || fieldNameUpdate != "") {
 		for k, v := range list {
@@ -0,0 +1,180 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtimer"
+)
+
+// InsertBufferOption is the option for InsertBuffer, see Model.BufferedInsert.
+type InsertBufferOption struct {
+	// Size is the count of buffered rows that triggers flushing, which is 1000 in default.
+	Size int
+
+	// Interval is the interval of flushing the buffered rows, which is 1 second in default.
+	Interval time.Duration
+
+	// Capacity is the maximum count of buffered rows, which is 10 times of Size in default.
+	// Adding rows fails with error if the buffer is full, which usually happens if the database
+	// is slower than the adding or the flushing keeps failing.
+	Capacity int
+
+	// OnError is the callback if flushing fails, which receives the rows failed inserting.
+	// The failed rows are dropped after the callback, and it logs the error with the logger of
+	// the database if no callback.
+	OnError func(ctx context.Context, rows List, err error)
+}
+
+// InsertBuffer is the write-behind buffer for inserting, which batches the rows in memory and
+// inserts them in batch asynchronously by size or interval.
+// It should be closed on shutdown, which flushes the remaining buffered rows.
+type InsertBuffer struct {
+	mu       sync.Mutex         // Mutex for rows.
+	flushMu  sync.Mutex         // Mutex for flushing, which makes flushing in order.
+	ctx      context.Context    // Context for flushing.
+	model    *Model             // Model for inserting.
+	option   InsertBufferOption // Buffer option.
+	rows     List               // Buffered rows.
+	flushing *gtype.Bool        // Whether there's asynchronous flushing triggered by size.
+	closed   bool               // Whether the buffer is closed.
+	timer    *gtimer.Entry      // Timer for flushing by interval.
+}
+
+const (
+	defaultInsertBufferSize     = 1000
+	defaultInsertBufferInterval = time.Second
+)
+
+// BufferedInsert creates and returns an InsertBuffer for the model, which is usually used for the
+// telemetry or event tables written at high frequency that tolerate asynchronous inserting.
+// The rows are inserted with the options of the model, like Batch, Ignore and OmitEmpty.
+//
+// Example:
+// buffer := db.Model("event").BufferedInsert(gdb.InsertBufferOption{Size: 500})
+// defer buffer.Close()
+// err := buffer.Add(g.Map{"name": "click"})
+func (m *Model) BufferedInsert(option InsertBufferOption) *InsertBuffer {
+	if option.Size <= 0 {
+		option.Size = defaultInsertBufferSize
+	}
+	if option.Interval <= 0 {
+		option.Interval = defaultInsertBufferInterval
+	}
+	if option.Capacity < option.Size {
+		option.Capacity = option.Size * 10
+	}
+	b := &InsertBuffer{
+		ctx:      m.GetCtx(),
+		model:    m.Clone(),
+		option:   option,
+		rows:     make(List, 0, option.Size),
+		flushing: gtype.NewBool(),
+	}
+	b.timer = gtimer.AddSingleton(b.ctx, option.Interval, func(ctx context.Context) {
+		b.doFlush()
+	})
+	return b
+}
+
+// Add adds rows `data` to the buffer, which can be type of map/struct.
+// It triggers flushing asynchronously if the buffered rows reach the size of option.
+// It returns error if the buffer is closed or full.
+func (b *InsertBuffer) Add(data ...interface{}) error {
+	rows := make(List, 0, len(data))
+	for _, v := range data {
+		row, err := b.model.db.ConvertDataForRecord(b.ctx, v)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return gerror.NewCode(gcode.CodeInvalidOperation, `insert buffer is closed`)
+	}
+	if len(b.rows)+len(rows) > b.option.Capacity {
+		b.mu.Unlock()
+		return gerror.NewCodef(
+			gcode.CodeServerBusy,
+			`insert buffer is full with %d rows for table "%s"`,
+			len(b.rows), b.model.tablesInit,
+		)
+	}
+	b.rows = append(b.rows, rows...)
+	size := len(b.rows)
+	b.mu.Unlock()
+	if size >= b.option.Size && b.flushing.Cas(false, true) {
+		go func() {
+			defer b.flushing.Set(false)
+			b.doFlush()
+		}()
+	}
+	return nil
+}
+
+// Len returns the count of buffered rows.
+func (b *InsertBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.rows)
+}
+
+// Flush inserts all the buffered rows synchronously, and returns the error of the last failed batch.
+// The failed rows are also passed to the callback OnError of option.
+func (b *InsertBuffer) Flush() error {
+	return b.doFlush()
+}
+
+// Close stops flushing by interval and flushes the remaining buffered rows,
+// which should be called on shutdown. Adding rows to a closed buffer fails.
+func (b *InsertBuffer) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+	b.timer.Close()
+	return b.doFlush()
+}
+
+// doFlush takes all the buffered rows and inserts them in batches of option size.
+func (b *InsertBuffer) doFlush() (err error) {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+	b.mu.Lock()
+	rows := b.rows
+	if len(rows) > 0 {
+		b.rows = make(List, 0, b.option.Size)
+	}
+	b.mu.Unlock()
+	for len(rows) > 0 {
+		batch := rows
+		if len(batch) > b.option.Size {
+			batch = rows[:b.option.Size]
+		}
+		rows = rows[len(batch):]
+		if _, insertErr := b.model.Clone().Data(batch).Insert(); insertErr != nil {
+			err = gerror.Wrapf(insertErr, `flushing %d rows of insert buffer failed`, len(batch))
+			if b.option.OnError != nil {
+				b.option.OnError(b.ctx, batch, err)
+			} else {
+				b.model.db.GetLogger().Errorf(b.ctx, `%+v`, err)
+			}
+		}
+	}
+	return err
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/gogf/gf/v2/container/gset"
 	"github.com/gogf/gf/v2/container/gvar"
@@ -18,6 +19,7 @@ import (
 	"github.com/gogf/gf/v2/internal/reflection"
 	"github.com/gogf/gf/v2/text/gstr"
 	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gpage"
 )
 
 // All does "SELECT FROM ..." statement for the model.
@@ -42,7 +44,10 @@ func (m *Model) doGetAll(ctx context.Context, limit1 bool, where ...interface{})
 	if len(where) > 0 {
 		return m.Where(where[0], where[1:]...).All()
 	}
-	sqlWithHolder, holderArgs := m.getFormattedSqlAndArgs(ctx, queryTypeNormal, limit1)
+	sqlWithHolder, holderArgs, err := m.getFormattedSqlAndArgs(ctx, queryTypeNormal, limit1)
+	if err != nil {
+		return nil, err
+	}
 	return m.doGetAllBySql(ctx, queryTypeNormal, sqlWithHolder, holderArgs...)
 }
 
@@ -239,6 +244,13 @@ func (m *Model) doStruct(pointer interface{}, where ...interface{}) error {
 	if err != nil {
 		return err
 	}
+	if one != nil {
+		decoded, err := decodeResultByFieldCodec(m.GetCtx(), pointer, Result{one})
+		if err != nil {
+			return err
+		}
+		one = decoded[0]
+	}
 	if err = one.Struct(pointer); err != nil {
 		return err
 	}
@@ -283,6 +295,9 @@ func (m *Model) doStructs(pointer interface{}, where ...interface{}) error {
 	if err != nil {
 		return err
 	}
+	if all, err = decodeResultByFieldCodec(m.GetCtx(), pointer, all); err != nil {
+		return err
+	}
 	if err = all.Structs(pointer); err != nil {
 		return err
 	}
@@ -383,10 +398,11 @@ func (m *Model) Count(where ...interface{}) (int, error) {
 	if len(where) > 0 {
 		return m.Where(where[0], where[1:]...).Count()
 	}
-	var (
-		sqlWithHolder, holderArgs = m.getFormattedSqlAndArgs(ctx, queryTypeCount, false)
-		all, err                  = m.doGetAllBySql(ctx, queryTypeCount, sqlWithHolder, holderArgs...)
-	)
+	sqlWithHolder, holderArgs, err := m.getFormattedSqlAndArgs(ctx, queryTypeCount, false)
+	if err != nil {
+		return 0, err
+	}
+	all, err := m.doGetAllBySql(ctx, queryTypeCount, sqlWithHolder, holderArgs...)
 	if err != nil {
 		return 0, err
 	}
@@ -515,6 +531,82 @@ func (m *Model) Page(page, limit int) *Model {
 	return model
 }
 
+// Cursor sets the keyset pagination for the model with `cursor` and page size `limit`.
+// It sets the "ORDER BY" statement with the sorting fields of `cursor`, and the condition that
+// the records are after the `cursor` in the sorting order, which is more efficient than Page for
+// large tables as it does not scan the skipped records.
+//
+// The `cursor` of first page which has no value only sets "ORDER BY" and "LIMIT" statement.
+// Note that the sorting fields of `cursor` should not contain NULL values.
+// It panics if any sorting field name of `cursor` is not a plain field name like "id" or "u.id",
+// which might be injected by the client.
+//
+// Eg:
+// cursor, err := gpage.ParseCursor(req.Cursor, "score desc, id")
+// result, err := db.Model("user").Cursor(cursor, 20).All()
+// nextCursor := cursor.Next(result[len(result)-1].Map()).Encode()
+func (m *Model) Cursor(cursor *gpage.Cursor, limit int) *Model {
+	if cursor == nil || len(cursor.Fields) == 0 {
+		return m.Limit(limit)
+	}
+	var (
+		names   = make([]string, len(cursor.Fields))
+		orderBy = make([]string, len(cursor.Fields))
+	)
+	for i, field := range cursor.Fields {
+		name, ok := m.quoteCursorField(field.Name)
+		if !ok {
+			panic(gerror.NewCodef(gcode.CodeInvalidParameter, `invalid cursor field name "%s"`, field.Name))
+		}
+		names[i] = name
+		if field.Desc {
+			orderBy[i] = name + " DESC"
+		} else {
+			orderBy[i] = name + " ASC"
+		}
+	}
+	model := m.Order(Raw(strings.Join(orderBy, ","))).Limit(limit)
+	if cursor.IsFirst() {
+		return model
+	}
+	// Condition for fields (a, b) like: (a > ?) OR (a = ? AND b > ?).
+	var (
+		conditions = make([]string, 0, len(cursor.Fields))
+		args       = make([]interface{}, 0)
+	)
+	for i, field := range cursor.Fields {
+		parts := make([]string, 0, i+1)
+		for j, prev := range cursor.Fields[:i] {
+			parts = append(parts, fmt.Sprintf(`%s=?`, names[j]))
+			args = append(args, prev.Value)
+		}
+		operator := ">"
+		if field.Desc {
+			operator = "<"
+		}
+		parts = append(parts, fmt.Sprintf(`%s%s?`, names[i], operator))
+		args = append(args, field.Value)
+		conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+	}
+	return model.Where("("+strings.Join(conditions, " OR ")+")", args...)
+}
+
+// quoteCursorField quotes the cursor field name `name`, which might be qualified by table like "u.id".
+// It returns false if `name` is not a plain field name.
+func (m *Model) quoteCursorField(name string) (string, bool) {
+	parts := strings.Split(name, ".")
+	if len(parts) > 2 {
+		return "", false
+	}
+	for i, part := range parts {
+		if !quoteWordReg.MatchString(part) {
+			return "", false
+		}
+		parts[i] = m.QuoteWord(part)
+	}
+	return strings.Join(parts, "."), true
+}
+
 // Having sets the having statement for the model.
 // The parameters of this function usage are as the same as function Where.
 // See Where.
@@ -528,6 +620,9 @@ func (m *Model) Having(having interface{}, args ...interface{}) *Model {
 
 // doGetAllBySql does the select statement on the database.
 func (m *Model) doGetAllBySql(ctx context.Context, queryType int, sql string, args ...interface{}) (result Result, err error) {
+	if err = m.checkTenant(ctx); err != nil {
+		return nil, err
+	}
 	if result, err = m.getSelectResultFromCache(ctx, sql, args...); err != nil || result != nil {
 		return
 	}
@@ -552,7 +647,7 @@ func (m *Model) doGetAllBySql(ctx context.Context, queryType int, sql string, ar
 	return
 }
 
-func (m *Model) getFormattedSqlAndArgs(ctx context.Context, queryType int, limit1 bool) (sqlWithHolder string, holderArgs []interface{}) {
+func (m *Model) getFormattedSqlAndArgs(ctx context.Context, queryType int, limit1 bool) (sqlWithHolder string, holderArgs []interface{}, err error) {
 	switch queryType {
 	case queryTypeCount:
 		queryFields := "COUNT(1)"
@@ -564,17 +659,31 @@ func (m *Model) getFormattedSqlAndArgs(ctx context.Context, queryType int, limit
 		// Raw SQL Model.
 		if m.rawSql != "" {
 			sqlWithHolder = fmt.Sprintf("SELECT %s FROM (%s) AS T", queryFields, m.rawSql)
-			return sqlWithHolder, nil
+			return sqlWithHolder, nil, nil
+		}
+		conditionWhere, conditionExtra, conditionArgs, err := m.formatCondition(ctx, false, true)
+		if err != nil {
+			return "", nil, err
 		}
-		conditionWhere, conditionExtra, conditionArgs := m.formatCondition(ctx, false, true)
 		sqlWithHolder = fmt.Sprintf("SELECT %s FROM %s%s", queryFields, m.tables, conditionWhere+conditionExtra)
 		if len(m.groupBy) > 0 {
 			sqlWithHolder = fmt.Sprintf("SELECT COUNT(1) FROM (%s) count_alias", sqlWithHolder)
 		}
-		return sqlWithHolder, conditionArgs
+		// Common table expressions.
+		cteSql, cteArgs, err := m.formatCTE(ctx)
+		if err != nil {
+			return "", nil, err
+		}
+		if cteSql != "" {
+			return cteSql + sqlWithHolder, append(cteArgs, conditionArgs...), nil
+		}
+		return sqlWithHolder, conditionArgs, nil
 
 	default:
-		conditionWhere, conditionExtra, conditionArgs := m.formatCondition(ctx, limit1, false)
+		conditionWhere, conditionExtra, conditionArgs, err := m.formatCondition(ctx, limit1, false)
+		if err != nil {
+			return "", nil, err
+		}
 		// Raw SQL Model, especially for UNION/UNION ALL featured SQL.
 		if m.rawSql != "" {
 			sqlWithHolder = fmt.Sprintf(
@@ -582,7 +691,7 @@ func (m *Model) getFormattedSqlAndArgs(ctx context.Context, queryType int, limit
 				m.rawSql,
 				conditionWhere+conditionExtra,
 			)
-			return sqlWithHolder, conditionArgs
+			return sqlWithHolder, conditionArgs, nil
 		}
 		// DO NOT quote the m.fields where, in case of fields like:
 		// DISTINCT t.user_id uid
@@ -590,7 +699,15 @@ func (m *Model) getFormattedSqlAndArgs(ctx context.Context, queryType int, limit
 			"SELECT %s%s FROM %s%s",
 			m.distinct, m.getFieldsFiltered(), m.tables, conditionWhere+conditionExtra,
 		)
-		return sqlWithHolder, conditionArgs
+		// Common table expressions.
+		cteSql, cteArgs, err := m.formatCTE(ctx)
+		if err != nil {
+			return "", nil, err
+		}
+		if cteSql != "" {
+			return cteSql + sqlWithHolder, append(cteArgs, conditionArgs...), nil
+		}
+		return sqlWithHolder, conditionArgs, nil
 	}
 }
 
@@ -608,14 +725,16 @@ func (m *Model) getAutoPrefix() string {
 // Note that this function does not change any attribute value of the `m`.
 //
 // The parameter `limit1` specifies whether limits querying only one record if m.limit is not set.
-func (m *Model) formatCondition(ctx context.Context, limit1 bool, isCountStatement bool) (conditionWhere string, conditionExtra string, conditionArgs []interface{}) {
+func (m *Model) formatCondition(ctx context.Context, limit1 bool, isCountStatement bool) (conditionWhere string, conditionExtra string, conditionArgs []interface{}, err error) {
 	var autoPrefix = m.getAutoPrefix()
 	// GROUP BY.
 	if m.groupBy != "" {
 		conditionExtra += " GROUP BY " + m.groupBy
 	}
 	// WHERE
-	conditionWhere, conditionArgs = m.whereBuilder.Build()
+	if conditionWhere, conditionArgs, err = m.whereBuilder.build(); err != nil {
+		return "", "", nil, err
+	}
 	softDeletingCondition := m.getConditionForSoftDeleting()
 	if m.rawSql != "" && conditionWhere != "" {
 		if gstr.ContainsI(m.rawSql, " WHERE ") {
@@ -634,6 +753,15 @@ func (m *Model) formatCondition(ctx context.Context, limit1 bool, isCountStateme
 			conditionWhere = " WHERE " + conditionWhere
 		}
 	}
+	// Multi-tenancy.
+	if tenantCondition, tenantArgs := m.getConditionForTenant(ctx); tenantCondition != "" {
+		if conditionWhere == "" {
+			conditionWhere = " WHERE " + tenantCondition
+		} else {
+			conditionWhere = fmt.Sprintf(` WHERE (%s) AND %s`, gstr.TrimLeftStr(conditionWhere, " WHERE "), tenantCondition)
+		}
+		conditionArgs = append(conditionArgs, tenantArgs...)
+	}
 	// HAVING.
 	if len(m.having) > 0 {
 		havingHolder := WhereHolder{
@@ -641,13 +769,16 @@ func (m *Model) formatCondition(ctx context.Context, limit1 bool, isCountStateme
 			Args:   gconv.Interfaces(m.having[1]),
 			Prefix: autoPrefix,
 		}
-		havingStr, havingArgs := formatWhereHolder(ctx, m.db, formatWhereHolderInput{
+		havingStr, havingArgs, err := formatWhereHolder(ctx, m.db, formatWhereHolderInput{
 			WhereHolder: havingHolder,
 			OmitNil:     m.option&optionOmitNilWhere > 0,
 			OmitEmpty:   m.option&optionOmitEmptyWhere > 0,
 			Schema:      m.schema,
 			Table:       m.tables,
 		})
+		if err != nil {
+			return "", "", nil, err
+		}
 		if len(havingStr) > 0 {
 			conditionExtra += " HAVING " + havingStr
 			conditionArgs = append(conditionArgs, havingArgs...)
@@ -0,0 +1,291 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/empty"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/text/gregex"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+// tenantTable is a tenant scoped table in the model.
+type tenantTable struct {
+	Qualifier string // Alias or name of the table for the condition, which is empty for single table model.
+	Field     string // Tenant id field name of the table.
+}
+
+const (
+	contextKeyForTenant gctx.StrKey = `TenantInContext`
+)
+
+// WithTenant creates and returns a context containing tenant id `tenantId`, which is used for
+// the multi-tenancy feature. It is usually called in the middleware after the tenant is authenticated.
+//
+// The multi-tenancy feature is enabled by configuration `TenantField`, and the tables having the
+// tenant id field are scoped by the tenant id from context automatically, that:
+// 1. The select, update and delete statements have the condition of the tenant id;
+// 2. The insert statements fill the tenant id field;
+// 3. The update statements cannot change the tenant id field;
+// 4. The operations fail if there's no tenant id in context;
+// 5. The replace/save statements and the raw sql models fail, as they cannot be scoped by the tenant id.
+// It can be disabled for specified operation by Model.WithoutTenant.
+func WithTenant(ctx context.Context, tenantId interface{}) context.Context {
+	return context.WithValue(ctx, contextKeyForTenant, tenantId)
+}
+
+// GetTenant retrieves and returns the tenant id from context `ctx`.
+// It returns nil if there's no tenant id in the context.
+func GetTenant(ctx context.Context) interface{} {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Value(contextKeyForTenant)
+}
+
+// WithoutTenant disables the multi-tenancy feature for the model, which is usually used for
+// cross tenant operations like administration and statistics.
+func (m *Model) WithoutTenant() *Model {
+	model := m.getModel()
+	model.withoutTenant = true
+	return model
+}
+
+// checkTenant checks and returns error if the model operates on tenant scoped tables,
+// but there's no tenant id in context `ctx`.
+// It also returns error for the raw sql model, which cannot be scoped by the tenant id.
+func (m *Model) checkTenant(ctx context.Context) error {
+	if m.rawSql != "" && !m.withoutTenant && m.db.GetConfig().TenantField != "" {
+		return gerror.NewCode(
+			gcode.CodeInvalidOperation,
+			`raw sql model cannot be scoped by tenant id, use Model.WithoutTenant to disable the multi-tenancy explicitly`,
+		)
+	}
+	tables, err := m.getTenantTables()
+	if err != nil {
+		return err
+	}
+	if len(tables) > 0 && empty.IsNil(GetTenant(ctx)) {
+		return gerror.NewCodef(
+			gcode.CodeMissingParameter,
+			`tenant id is missing in context for tenant scoped table "%s", use gdb.WithTenant to set it or Model.WithoutTenant to disable it`,
+			m.tablesInit,
+		)
+	}
+	return nil
+}
+
+// getConditionForTenant retrieves and returns the condition string and its arguments for
+// the tenant scoped tables of the model. It returns a condition matching nothing if there's no
+// tenant id in context or the tenant scoped tables cannot be determined, which never leaks the
+// records of other tenants.
+func (m *Model) getConditionForTenant(ctx context.Context) (condition string, args []interface{}) {
+	var (
+		tables, err = m.getTenantTables()
+		tenantId    = GetTenant(ctx)
+		core        = m.db.GetCore()
+	)
+	if err != nil {
+		return "1=0", nil
+	}
+	if len(tables) == 0 {
+		return "", nil
+	}
+	if empty.IsNil(tenantId) {
+		return "1=0", nil
+	}
+	for _, table := range tables {
+		if condition != "" {
+			condition += " AND "
+		}
+		if table.Qualifier != "" {
+			condition += fmt.Sprintf(`%s.%s=?`, core.QuoteWord(table.Qualifier), core.QuoteWord(table.Field))
+		} else {
+			condition += fmt.Sprintf(`%s=?`, core.QuoteWord(table.Field))
+		}
+		args = append(args, tenantId)
+	}
+	return
+}
+
+// checkTenantForUpdate checks and returns error if the updating `data`, which is type of map or string,
+// changes the tenant id field of the tenant scoped tables, which moves the records to other tenant.
+func (m *Model) checkTenantForUpdate(data interface{}) error {
+	tables, err := m.getTenantTables()
+	if err != nil || len(tables) == 0 {
+		return err
+	}
+	var (
+		fields       = make(map[string]struct{}, len(tables))
+		charL, charR = m.db.GetChars()
+	)
+	for _, table := range tables {
+		fields[gstr.ToLower(table.Field)] = struct{}{}
+	}
+	var keys []string
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for k := range value {
+			keys = append(keys, k)
+		}
+	default:
+		// The string updating data like: "name=?,tenant_id=?", or with qualifier and quotes.
+		matches, _ := gregex.MatchAllString(`([\w\.`+"`"+`"\[\]]+)\s*=`, gconv.String(value))
+		for _, match := range matches {
+			keys = append(keys, match[1])
+		}
+	}
+	for _, key := range keys {
+		array := gstr.SplitAndTrim(key, ".")
+		if len(array) == 0 {
+			continue
+		}
+		field := gstr.ToLower(gstr.Trim(array[len(array)-1], charL+charR+"`\"[]"))
+		if _, ok := fields[field]; ok {
+			return gerror.NewCodef(
+				gcode.CodeInvalidOperation,
+				`tenant id field "%s" cannot be updated, use Model.WithoutTenant to disable the multi-tenancy explicitly`,
+				key,
+			)
+		}
+	}
+	return nil
+}
+
+// checkTenantForInsertOption checks and returns error if the inserting with option `insertOption`
+// might overwrite or delete the records of other tenants by the conflicting keys, like the replace and save.
+func (m *Model) checkTenantForInsertOption(insertOption int) error {
+	if insertOption != InsertOptionReplace && insertOption != InsertOptionSave {
+		return nil
+	}
+	field, err := m.getTenantFieldName(m.tablesInit)
+	if err != nil || field == "" {
+		return err
+	}
+	return gerror.NewCodef(
+		gcode.CodeNotSupported,
+		`replace/save is not supported for tenant scoped table "%s", as the conflicting record might belong to other tenant, use Model.WithoutTenant to disable the multi-tenancy explicitly`,
+		m.tablesInit,
+	)
+}
+
+// fillTenantForInsert fills the tenant id field of `list` for inserting.
+// It returns error if the tenant id of any item is not the one in context.
+func (m *Model) fillTenantForInsert(ctx context.Context, list List) error {
+	field, err := m.getTenantFieldName(m.tablesInit)
+	if err != nil || field == "" {
+		return err
+	}
+	tenantId := GetTenant(ctx)
+	for _, item := range list {
+		if v, ok := item[field]; ok && !empty.IsEmpty(v) && gconv.String(v) != gconv.String(tenantId) {
+			return gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`tenant id "%v" of inserting data mismatches the tenant id "%v" in context`,
+				v, tenantId,
+			)
+		}
+		item[field] = tenantId
+	}
+	return nil
+}
+
+// getTenantTables retrieves and returns the tenant scoped tables of the model.
+// It supports multiple tables string like soft deleting feature, see getConditionForSoftDeleting.
+// It returns error if the fields of any table cannot be retrieved, as it cannot be determined
+// whether the table is tenant scoped.
+func (m *Model) getTenantTables() ([]tenantTable, error) {
+	if m.withoutTenant || m.rawSql != "" || m.db.GetConfig().TenantField == "" {
+		return nil, nil
+	}
+	var (
+		tables       []tenantTable
+		tableStrings []string
+	)
+	if gstr.Contains(m.tables, " JOIN ") {
+		// Base table.
+		match, _ := gregex.MatchString(`(.+?) [A-Z]+ JOIN`, m.tables)
+		tableStrings = append(tableStrings, match[1])
+		// Multiple joined tables, exclude the sub query sql which contains char '(' and ')'.
+		matches, _ := gregex.MatchAllString(`JOIN ([^()]+?) ON`, m.tables)
+		for _, match := range matches {
+			tableStrings = append(tableStrings, match[1])
+		}
+	} else if gstr.Contains(m.tables, ",") {
+		tableStrings = gstr.SplitAndTrim(m.tables, ",")
+	}
+	if len(tableStrings) == 0 {
+		// Only one table.
+		field, err := m.getTenantFieldName(m.tablesInit)
+		if err != nil {
+			return nil, err
+		}
+		if field != "" {
+			tables = append(tables, tenantTable{Field: field})
+		}
+		return tables, nil
+	}
+	for _, s := range tableStrings {
+		var (
+			array1       = gstr.SplitAndTrim(s, " ")
+			array2       = gstr.SplitAndTrim(array1[0], ".")
+			charL, charR = m.db.GetChars()
+			table        = gstr.Trim(array2[len(array2)-1], charL+charR)
+			qualifier    = table
+		)
+		if len(array1) >= 3 {
+			qualifier = array1[2]
+		} else if len(array1) >= 2 {
+			qualifier = array1[1]
+		}
+		field, err := m.getTenantFieldName(table)
+		if err != nil {
+			return nil, err
+		}
+		if field != "" {
+			tables = append(tables, tenantTable{Qualifier: qualifier, Field: field})
+		}
+	}
+	return tables, nil
+}
+
+// getTenantFieldName checks and returns the tenant id field name of `table`.
+// It returns an empty string if the table is not tenant scoped.
+//
+// Unlike the soft time fields, it does not ignore the error from TableFields, as treating the table
+// as not tenant scoped by mistake leaks the records of all tenants.
+func (m *Model) getTenantFieldName(table string) (string, error) {
+	if m.withoutTenant || m.rawSql != "" {
+		return "", nil
+	}
+	tenantField := m.db.GetConfig().TenantField
+	if tenantField == "" {
+		return "", nil
+	}
+	fieldsMap, err := m.TableFields(table)
+	if err != nil {
+		return "", gerror.WrapCodef(
+			gcode.CodeInternalError, err,
+			`retrieve fields of table "%s" failed for checking tenant id field`, table,
+		)
+	}
+	if len(fieldsMap) == 0 {
+		return "", gerror.NewCodef(
+			gcode.CodeInternalError,
+			`no fields retrieved of table "%s" for checking tenant id field`, table,
+		)
+	}
+	field, _ := gutil.MapPossibleItemByKey(gconv.Map(fieldsMap), tenantField)
+	return field, nil
+}
@@ -38,17 +38,25 @@ func (m *Model) Update(dataAndWhere ...interface{}) (result sql.Result, err erro
 	defer func() {
 		if err == nil {
 			m.checkAndRemoveSelectCache(ctx)
+			m.removeIdentityMapTable(ctx)
+			m.addChangeEvent(ctx, ChangeOperationUpdate, result)
 		}
 	}()
 	if m.data == nil {
 		return nil, gerror.NewCode(gcode.CodeMissingParameter, "updating table with empty data")
 	}
+	if err = m.checkTenant(ctx); err != nil {
+		return nil, err
+	}
 	var (
-		updateData                                    = m.data
-		reflectInfo                                   = reflection.OriginTypeAndKind(updateData)
-		fieldNameUpdate                               = m.getSoftFieldNameUpdated()
-		conditionWhere, conditionExtra, conditionArgs = m.formatCondition(ctx, false, false)
+		updateData      = m.data
+		reflectInfo     = reflection.OriginTypeAndKind(updateData)
+		fieldNameUpdate = m.getSoftFieldNameUpdated()
 	)
+	conditionWhere, conditionExtra, conditionArgs, err := m.formatCondition(ctx, false, false)
+	if err != nil {
+		return nil, err
+	}
 	switch reflectInfo.OriginKind {
 	case reflect.Map, reflect.Struct:
 		var dataMap map[string]interface{}
@@ -72,6 +80,9 @@ func (m *Model) Update(dataAndWhere ...interface{}) (result sql.Result, err erro
 		}
 		updateData = updates
 	}
+	if err = m.checkTenantForUpdate(updateData); err != nil {
+		return nil, err
+	}
 	newData, err := m.filterDataForInsertOrUpdate(updateData)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,359 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// ExportOptions is the options for exporting Result to CSV or Excel(xlsx) content.
+type ExportOptions struct {
+	Fields      []string                               // Fields and their order of the exported columns, it uses the sorted fields of the first record if it is empty.
+	Headers     []string                               // Header names of the columns in the order of Fields, it uses the field names if it is empty.
+	NoHeader    bool                                   // Do not write the header row.
+	Formatter   func(field string, value Value) string // Custom formatting hook for the values, like formatting time or amount.
+	Comma       rune                                   // (CSV only) Field delimiter, default is ','.
+	BOM         bool                                   // (CSV only) Write UTF-8 BOM at the beginning, which makes Excel recognize the encoding.
+	SheetName   string                                 // (Xlsx only) Name of the sheet, default is "Sheet1".
+	BatchSize   int                                    // (Model only) Records count of each query, default is 1000.
+	CursorOrder string                                 // (Model only) Sorting fields for keyset pagination like "id", it uses OFFSET pagination if it is empty.
+}
+
+// ResultWriter is the streaming writer of Result, which writes the records one by one to underlying writer.
+type ResultWriter interface {
+	// WriteRecord writes one record, the header is written before the first record.
+	WriteRecord(record Record) error
+
+	// WriteResult writes all records of `result`.
+	WriteResult(result Result) error
+
+	// Close writes the remaining content and flushes to underlying writer.
+	// Note that it does not close the underlying writer.
+	Close() error
+}
+
+// CsvWriter is the ResultWriter writing CSV content.
+type CsvWriter struct {
+	writer   *csv.Writer
+	buffer   *bufio.Writer
+	exporter *resultExporter
+}
+
+// XlsxWriter is the ResultWriter writing Excel(xlsx) content, which writes the rows of the sheet in
+// streaming without buffering all records in memory.
+type XlsxWriter struct {
+	zip      *zip.Writer
+	sheet    *bufio.Writer
+	exporter *resultExporter
+	err      error
+}
+
+// resultExporter handles the columns and values formatting of the writers.
+type resultExporter struct {
+	options       ExportOptions
+	headerWritten bool
+}
+
+const (
+	defaultExportBatchSize = 1000
+	defaultXlsxSheetName   = "Sheet1"
+	xlsxContentTypes       = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+// StreamCSV writes the records of `r` to `w` as CSV content.
+// The `w` can be a file, or the ghttp.Response for downloading.
+func (r Result) StreamCSV(w io.Writer, options ...ExportOptions) error {
+	writer := NewCsvWriter(w, options...)
+	if err := writer.WriteResult(r); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// StreamXlsx writes the records of `r` to `w` as Excel(xlsx) content.
+// The `w` can be a file, or the ghttp.Response for downloading.
+func (r Result) StreamXlsx(w io.Writer, options ...ExportOptions) error {
+	writer := NewXlsxWriter(w, options...)
+	if err := writer.WriteResult(r); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// NewCsvWriter creates and returns a CsvWriter writing to `w`.
+func NewCsvWriter(w io.Writer, options ...ExportOptions) *CsvWriter {
+	var (
+		buffer = bufio.NewWriter(w)
+		writer = &CsvWriter{
+			writer:   csv.NewWriter(buffer),
+			buffer:   buffer,
+			exporter: newResultExporter(options...),
+		}
+	)
+	if writer.exporter.options.Comma != 0 {
+		writer.writer.Comma = writer.exporter.options.Comma
+	}
+	if writer.exporter.options.BOM {
+		_, _ = buffer.WriteString("\xEF\xBB\xBF")
+	}
+	return writer
+}
+
+// WriteRecord implements ResultWriter.
+func (w *CsvWriter) WriteRecord(record Record) error {
+	if header := w.exporter.header(record); header != nil {
+		if err := w.writer.Write(header); err != nil {
+			return gerror.Wrap(err, `write csv header failed`)
+		}
+	}
+	row := make([]string, len(w.exporter.options.Fields))
+	for i, field := range w.exporter.options.Fields {
+		row[i] = w.exporter.format(field, record[field])
+	}
+	if err := w.writer.Write(row); err != nil {
+		return gerror.Wrap(err, `write csv record failed`)
+	}
+	return nil
+}
+
+// WriteResult implements ResultWriter.
+func (w *CsvWriter) WriteResult(result Result) error {
+	for _, record := range result {
+		if err := w.WriteRecord(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close implements ResultWriter.
+func (w *CsvWriter) Close() error {
+	if header := w.exporter.header(nil); header != nil {
+		if err := w.writer.Write(header); err != nil {
+			return gerror.Wrap(err, `write csv header failed`)
+		}
+	}
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return gerror.Wrap(err, `flush csv content failed`)
+	}
+	if err := w.buffer.Flush(); err != nil {
+		return gerror.Wrap(err, `flush csv content failed`)
+	}
+	return nil
+}
+
+// NewXlsxWriter creates and returns a XlsxWriter writing to `w`.
+func NewXlsxWriter(w io.Writer, options ...ExportOptions) *XlsxWriter {
+	writer := &XlsxWriter{
+		zip:      zip.NewWriter(w),
+		exporter: newResultExporter(options...),
+	}
+	sheetName := writer.exporter.options.SheetName
+	if sheetName == "" {
+		sheetName = defaultXlsxSheetName
+	}
+	// The sheet is the last entry of the zip, so its rows can be written in streaming.
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, escapeXml(sheetName))},
+	}
+	for _, file := range files {
+		var f io.Writer
+		if f, writer.err = writer.zip.Create(file.name); writer.err != nil {
+			return writer
+		}
+		if _, writer.err = io.WriteString(f, file.content); writer.err != nil {
+			return writer
+		}
+	}
+	var f io.Writer
+	if f, writer.err = writer.zip.Create("xl/worksheets/sheet1.xml"); writer.err != nil {
+		return writer
+	}
+	writer.sheet = bufio.NewWriter(f)
+	_, writer.err = writer.sheet.WriteString(xlsxSheetHeader)
+	return writer
+}
+
+// WriteRecord implements ResultWriter.
+func (w *XlsxWriter) WriteRecord(record Record) error {
+	if w.err != nil {
+		return w.err
+	}
+	if header := w.exporter.header(record); header != nil {
+		w.writeRow(header, nil)
+	}
+	var (
+		fields = w.exporter.options.Fields
+		row    = make([]string, len(fields))
+		number = make([]bool, len(fields))
+	)
+	for i, field := range fields {
+		row[i] = w.exporter.format(field, record[field])
+		// The numeric values are written as number cells, unless they are formatted by custom hook.
+		number[i] = w.exporter.options.Formatter == nil && isNumericValue(record[field])
+	}
+	w.writeRow(row, number)
+	return w.err
+}
+
+// WriteResult implements ResultWriter.
+func (w *XlsxWriter) WriteResult(result Result) error {
+	for _, record := range result {
+		if err := w.WriteRecord(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close implements ResultWriter.
+func (w *XlsxWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	if header := w.exporter.header(nil); header != nil {
+		w.writeRow(header, nil)
+	}
+	if w.err == nil {
+		_, w.err = w.sheet.WriteString(xlsxSheetFooter)
+	}
+	if w.err == nil {
+		w.err = w.sheet.Flush()
+	}
+	if w.err == nil {
+		w.err = w.zip.Close()
+	}
+	if w.err != nil {
+		return gerror.Wrap(w.err, `write xlsx content failed`)
+	}
+	return nil
+}
+
+// writeRow writes a row of cells to the sheet, the cells of which `number` is true are number cells,
+// and the others are inline string cells.
+func (w *XlsxWriter) writeRow(cells []string, number []bool) {
+	if w.err != nil {
+		return
+	}
+	_, _ = w.sheet.WriteString("<row>")
+	for i, cell := range cells {
+		if number != nil && number[i] {
+			_, _ = w.sheet.WriteString(`<c t="n"><v>` + cell + `</v></c>`)
+		} else {
+			_, _ = w.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">` + escapeXml(cell) + `</t></is></c>`)
+		}
+	}
+	_, w.err = w.sheet.WriteString("</row>")
+}
+
+func newResultExporter(options ...ExportOptions) *resultExporter {
+	exporter := &resultExporter{}
+	if len(options) > 0 {
+		exporter.options = options[0]
+	}
+	return exporter
+}
+
+// header returns the header row if it is not written yet, which also determines the fields by `record`
+// if they are not specified. It returns nil if the header should not be written.
+func (e *resultExporter) header(record Record) []string {
+	if e.headerWritten {
+		return nil
+	}
+	if len(e.options.Fields) == 0 {
+		if record == nil {
+			return nil
+		}
+		for field := range record {
+			e.options.Fields = append(e.options.Fields, field)
+		}
+		sort.Strings(e.options.Fields)
+	}
+	e.headerWritten = true
+	if e.options.NoHeader {
+		return nil
+	}
+	header := make([]string, len(e.options.Fields))
+	for i, field := range e.options.Fields {
+		if i < len(e.options.Headers) {
+			header[i] = e.options.Headers[i]
+		} else {
+			header[i] = field
+		}
+	}
+	return header
+}
+
+// format formats and returns the string of `value` of `field`.
+func (e *resultExporter) format(field string, value Value) string {
+	if e.options.Formatter != nil {
+		return e.options.Formatter(field, value)
+	}
+	if value == nil || value.IsNil() {
+		return ""
+	}
+	return value.String()
+}
+
+// isNumericValue checks whether `value` is a number type value.
+func isNumericValue(value Value) bool {
+	if value == nil || value.IsNil() {
+		return false
+	}
+	switch reflect.TypeOf(value.Val()).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// escapeXml escapes `s` as XML text.
+func escapeXml(s string) string {
+	var buffer = bytes.NewBuffer(nil)
+	_ = xml.EscapeText(buffer, []byte(s))
+	return buffer.String()
+}
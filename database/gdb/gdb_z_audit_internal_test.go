@@ -0,0 +1,94 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+func Test_FingerprintSql(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(
+			FingerprintSql("SELECT * FROM `user`  WHERE id IN(1, 2,3) AND name='jo\\'hn' AND t1.age>-18"),
+			"select * from `user` where id in(?+) and name=? and t1.age>-?",
+		)
+		t.Assert(
+			FingerprintSql("INSERT INTO `user`(`id`,`name`) VALUES(?,?),(?,?),(?,?)"),
+			"insert into `user`(`id`,`name`) values(?,?)",
+		)
+		t.Assert(FingerprintSql("SELECT * FROM user WHERE id IN(?,?)"), FingerprintSql("select * from user where id in (?)"))
+	})
+}
+
+func Test_Audit_RedactArgs(t *testing.T) {
+	config := &AuditConfig{RedactFields: []string{"password", "ID_CARD"}}
+	gtest.C(t, func(t *gtest.T) {
+		args := []interface{}{"john", "123456", 1}
+		t.Assert(
+			redactArgs("SELECT * FROM `user` WHERE `name`=? AND u.`password`=? AND id>?", args, config),
+			[]interface{}{"john", "******", 1},
+		)
+		t.Assert(args, []interface{}{"john", "123456", 1})
+		t.Assert(
+			redactArgs("INSERT INTO `user`(`name`,`password`) VALUES(?,?),(?,?)", []interface{}{"a", "1", "b", "2"}, config),
+			[]interface{}{"a", "******", "b", "******"},
+		)
+		t.Assert(
+			redactArgs("UPDATE user SET password=?,name=? WHERE id_card IN(?,?) AND age BETWEEN ? AND ? AND note='?'", []interface{}{1, 2, 3, 4, 5, 6}, config),
+			[]interface{}{"******", 2, "******", "******", 5, 6},
+		)
+	})
+}
+
+func Test_Core_Audit(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		testDb, err := New(ConfigNode{Type: "test"})
+		t.AssertNil(err)
+		var records []*AuditRecord
+		testDb.SetAudit(&AuditConfig{
+			Sink: AuditSinkFunc(func(ctx context.Context, record *AuditRecord) {
+				records = append(records, record)
+			}),
+			RedactFields:     []string{"password"},
+			SelectSampleRate: -1,
+			SlowThreshold:    time.Second,
+		})
+		core := testDb.GetCore()
+		core.writeSqlToAudit(ctx, &Sql{
+			Sql:          "UPDATE user SET password=? WHERE id=?",
+			Type:         SqlTypeExecContext,
+			Args:         []interface{}{"123456", 1},
+			Start:        1000,
+			End:          1005,
+			RowsAffected: 1,
+		})
+		// SELECTs are not sampled except the slow and failed ones.
+		core.writeSqlToAudit(ctx, &Sql{Sql: "SELECT * FROM user", Type: SqlTypeQueryContext, Start: 1000, End: 1005})
+		core.writeSqlToAudit(ctx, &Sql{Sql: "SELECT * FROM user", Type: SqlTypeQueryContext, Start: 1000, End: 3000})
+		core.writeSqlToAudit(ctx, &Sql{Sql: "SELECT * FROM t", Type: SqlTypeQueryContext, Error: errors.New("no table")})
+		core.writeSqlToAudit(ctx, &Sql{Sql: "SELECT 1", Type: SqlTypePrepareContext})
+
+		t.Assert(len(records), 3)
+		t.Assert(records[0].Args, []interface{}{"******", 1})
+		t.Assert(records[0].Fingerprint, "update user set password=? where id=?")
+		t.Assert(records[0].Duration, 5*time.Millisecond)
+		t.Assert(records[0].RowsAffected, 1)
+		t.Assert(gstr.Contains(records[0].Caller, "gdb_z_audit_internal_test.go"), true)
+		t.Assert(records[1].Duration, 2*time.Second)
+		t.AssertNE(records[2].Error, nil)
+
+		testDb.SetAudit(nil)
+		core.writeSqlToAudit(ctx, &Sql{Sql: "DELETE FROM user", Type: SqlTypeExecContext})
+		t.Assert(len(records), 3)
+	})
+}
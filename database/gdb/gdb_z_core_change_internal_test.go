@@ -0,0 +1,119 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Core_ChangeListener(t *testing.T) {
+	testDb, err := New(ConfigNode{Type: "identity_test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		events []*ChangeEvent
+		hook   = HookHandler{
+			Insert: func(ctx context.Context, in *HookInsertInput) (result sql.Result, err error) {
+				return driver.RowsAffected(len(in.Data)), nil
+			},
+			Update: func(ctx context.Context, in *HookUpdateInput) (result sql.Result, err error) {
+				return driver.RowsAffected(1), nil
+			},
+			Delete: func(ctx context.Context, in *HookDeleteInput) (result sql.Result, err error) {
+				return driver.RowsAffected(0), nil
+			},
+		}
+	)
+	testDb.AddChangeListener(ChangeListenerFunc(func(ctx context.Context, event *ChangeEvent) {
+		events = append(events, event)
+	}))
+	gtest.C(t, func(t *gtest.T) {
+		_, err := testDb.Model("user u").Hook(hook).Data(List{{"id": 1, "name": "john"}, {"id": 2}}).Insert()
+		t.AssertNil(err)
+		t.Assert(len(events), 1)
+		t.Assert(events[0].Table, "user")
+		t.Assert(events[0].Operation, ChangeOperationInsert)
+		t.Assert(events[0].Keys, []interface{}{1, 2})
+		t.Assert(events[0].RowsAffected, 2)
+
+		// Without primary key values.
+		_, err = testDb.Model("user").Hook(hook).Data(Map{"name": "john"}).Insert()
+		t.AssertNil(err)
+		t.Assert(len(events), 2)
+		t.Assert(len(events[1].Keys), 0)
+
+		_, err = testDb.Model("user").Hook(hook).Data(Map{"name": "john"}).WherePri(3).Update()
+		t.AssertNil(err)
+		t.Assert(len(events), 3)
+		t.Assert(events[2].Operation, ChangeOperationUpdate)
+		t.Assert(events[2].Keys, []interface{}{3})
+
+		_, err = testDb.Model("user").Hook(hook).Data(Map{"name": "john"}).Where("id", []int{4, 5}).Update()
+		t.AssertNil(err)
+		t.Assert(events[3].Keys, []interface{}{4, 5})
+
+		_, err = testDb.Model("user").Hook(hook).Data(Map{"name": "john"}).Where("id", 6).WhereOr("id", 7).Update()
+		t.AssertNil(err)
+		t.Assert(len(events[4].Keys), 0)
+
+		// No rows affected.
+		_, err = testDb.Model("user").Hook(hook).Delete("id", 1)
+		t.AssertNil(err)
+		t.Assert(len(events), 5)
+	})
+}
+
+func Test_Core_ChangeListener_CtxTransaction(t *testing.T) {
+	testDb, err := New(ConfigNode{Type: "tx_test", Name: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		events []*ChangeEvent
+		hook   = HookHandler{
+			Update: func(ctx context.Context, in *HookUpdateInput) (result sql.Result, err error) {
+				return driver.RowsAffected(1), nil
+			},
+		}
+		errRollback = errors.New("rollback")
+	)
+	testDb.AddChangeListener(ChangeListenerFunc(func(ctx context.Context, event *ChangeEvent) {
+		events = append(events, event)
+	}))
+	// The events are discarded if the transaction in context rolls back.
+	gtest.C(t, func(t *gtest.T) {
+		events = nil
+		err := testDb.Transaction(ctx, func(ctx context.Context, tx *TX) error {
+			_, err := testDb.Model("user").Ctx(ctx).Hook(hook).Data(Map{"name": "john"}).Where("id", 1).Update()
+			t.AssertNil(err)
+			t.Assert(len(events), 0)
+			return errRollback
+		})
+		t.Assert(err, errRollback)
+		t.Assert(len(events), 0)
+	})
+	// The events are emitted after the transaction in context commits.
+	gtest.C(t, func(t *gtest.T) {
+		events = nil
+		err := testDb.Transaction(ctx, func(ctx context.Context, tx *TX) error {
+			_, err := testDb.Model("user").Ctx(ctx).Hook(hook).Data(Map{"name": "john"}).Where("id", 1).Update()
+			t.AssertNil(err)
+			t.Assert(len(events), 0)
+			return nil
+		})
+		t.AssertNil(err)
+		t.Assert(len(events), 1)
+		t.Assert(events[0].Operation, ChangeOperationUpdate)
+	})
+}
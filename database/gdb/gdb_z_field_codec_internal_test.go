@@ -0,0 +1,134 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+type fieldCodecTestUser struct {
+	Id     int
+	Phone  string `orm:"phone,codec:aes_deterministic"`
+	IdCard string `orm:"id_card,codec:aes"`
+	Email  string `orm:"email,codec:mask"`
+}
+
+func init() {
+	key := []byte("0123456789abcdef")
+	RegisterFieldCodec("aes_deterministic", NewFieldCodecAES(key, true))
+	RegisterFieldCodec("aes", NewFieldCodecAES(key, false))
+}
+
+func Test_FieldCodec_Encode_Decode(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		testDb, err := New(ConfigNode{Type: "test"})
+		t.AssertNil(err)
+		user := &fieldCodecTestUser{
+			Id:     1,
+			Phone:  "13800138000",
+			IdCard: "110101199001011234",
+			Email:  "john@example.com",
+		}
+		data1, err := testDb.ConvertDataForRecord(ctx, user)
+		t.AssertNil(err)
+		data2, err := testDb.ConvertDataForRecord(ctx, user)
+		t.AssertNil(err)
+		t.Assert(data1["Id"], 1)
+		// Masking is read-only.
+		_, ok := data1["email"]
+		t.Assert(ok, false)
+		t.AssertNE(data1["phone"], "13800138000")
+		t.AssertNE(data1["id_card"], "110101199001011234")
+		// Deterministic encryption for equality conditions.
+		t.Assert(data1["phone"], data2["phone"])
+		t.AssertNE(data1["id_card"], data2["id_card"])
+
+		// Decoding.
+		record := Record{}
+		for k, v := range data1 {
+			record[k] = gvar.New(v)
+		}
+		record["email"] = gvar.New("john@example.com")
+		result, err := decodeResultByFieldCodec(ctx, &[]*fieldCodecTestUser{}, Result{record})
+		t.AssertNil(err)
+		var users []*fieldCodecTestUser
+		t.AssertNil(result.Structs(&users))
+		t.Assert(users[0].Phone, "13800138000")
+		t.Assert(users[0].IdCard, "110101199001011234")
+		t.Assert(users[0].Email, "joh*********.com")
+		// The original result is not changed.
+		t.Assert(record["phone"], data1["phone"])
+
+		// Condition.
+		where, args, _ := formatWhereHolder(ctx, testDb, formatWhereHolderInput{
+			WhereHolder: WhereHolder{
+				Where: map[string]interface{}{"phone": "13800138000"},
+			},
+		})
+		t.Assert(where, "phone=?")
+		t.Assert(args, []interface{}{"13800138000"})
+		_, args, _ = formatWhereHolder(ctx, testDb, formatWhereHolderInput{
+			WhereHolder: WhereHolder{
+				Where: fieldCodecTestUser{Phone: "13800138000"},
+			},
+		})
+		t.AssertIN(data1["phone"], args)
+
+		// Encoding error is returned.
+		type invalidUser struct {
+			Name string `orm:"name,codec:none"`
+		}
+		_, _, err = formatWhereHolder(ctx, testDb, formatWhereHolderInput{
+			WhereHolder: WhereHolder{
+				Where: invalidUser{Name: "john"},
+			},
+		})
+		t.AssertNE(err, nil)
+		_, err = testDb.Model("user").Where(invalidUser{Name: "john"}).All()
+		t.AssertNE(err, nil)
+		_, err = testDb.Model("user").Where("id IN(?)", testDb.Model("user").Fields("id").Where(invalidUser{Name: "john"})).Count()
+		t.AssertNE(err, nil)
+	})
+	// Tampered cipher text of deterministic encryption.
+	gtest.C(t, func(t *gtest.T) {
+		codec := NewFieldCodecAES([]byte("0123456789abcdef"), true)
+		v1, err := codec.Encode(ctx, "13800138000")
+		t.AssertNil(err)
+		v2, err := codec.Encode(ctx, "13900139000")
+		t.AssertNil(err)
+		decoded, err := codec.Decode(ctx, v1)
+		t.AssertNil(err)
+		t.Assert(decoded, "13800138000")
+		// Cipher text of other value with the IV of `v1`.
+		c1, _ := base64.StdEncoding.DecodeString(gconv.String(v1))
+		c2, _ := base64.StdEncoding.DecodeString(gconv.String(v2))
+		tampered := base64.StdEncoding.EncodeToString(append(c1[:16:16], c2[16:]...))
+		_, err = codec.Decode(ctx, tampered)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_FieldCodec_Invalid(t *testing.T) {
+	type invalidUser struct {
+		Name string `orm:"name,codec:none"`
+	}
+	gtest.C(t, func(t *gtest.T) {
+		_, err := getFieldCodecTags(&invalidUser{})
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		mask := &FieldCodecMask{Left: 3, Right: 4}
+		v, err := mask.Decode(ctx, "abc")
+		t.AssertNil(err)
+		t.Assert(v, "a**")
+	})
+}
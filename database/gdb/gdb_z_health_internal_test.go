@@ -0,0 +1,123 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// healthTestServerDown simulates the database server being down.
+var healthTestServerDown = gtype.NewBool()
+
+// healthTestSqlDriver is the sql driver of which the connections fail when server is down.
+type healthTestSqlDriver struct{}
+
+type healthTestSqlConn struct{}
+
+func (healthTestSqlDriver) Open(name string) (driver.Conn, error) {
+	if healthTestServerDown.Val() {
+		return nil, errors.New("connection refused")
+	}
+	return &healthTestSqlConn{}, nil
+}
+
+func (*healthTestSqlConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (*healthTestSqlConn) Close() error {
+	return nil
+}
+
+func (*healthTestSqlConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (*healthTestSqlConn) Ping(ctx context.Context) error {
+	if healthTestServerDown.Val() {
+		return errors.New("server has gone away")
+	}
+	return nil
+}
+
+// healthTestDriver is the gdb driver using healthTestSqlDriver.
+type healthTestDriver struct {
+	*Core
+}
+
+func (d *healthTestDriver) New(core *Core, node *ConfigNode) (DB, error) {
+	return &healthTestDriver{Core: core}, nil
+}
+
+func (d *healthTestDriver) Open(config *ConfigNode) (*sql.DB, error) {
+	return sql.Open("gdb_health_test", config.Name)
+}
+
+func init() {
+	sql.Register("gdb_health_test", healthTestSqlDriver{})
+	if err := Register("health_test", &healthTestDriver{}); err != nil {
+		panic(err)
+	}
+}
+
+func Test_Core_Stats_HealthCheck(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		db, err := New(ConfigNode{
+			Type:                "health_test",
+			Host:                "127.0.0.1",
+			Name:                "test",
+			HealthCheckInterval: 100 * time.Millisecond,
+			HealthCheckFailures: 2,
+		})
+		t.AssertNil(err)
+		defer db.Close(ctx)
+
+		_, err = db.Master()
+		t.AssertNil(err)
+		stats := db.Stats(ctx)
+		t.Assert(len(stats), 1)
+		t.Assert(stats[0].Host, "127.0.0.1")
+		t.Assert(stats[0].Name, "test")
+		t.Assert(stats[0].Healthy, true)
+
+		time.Sleep(250 * time.Millisecond)
+		stats = db.Stats(ctx)
+		t.Assert(stats[0].Healthy, true)
+		t.Assert(stats[0].LastCheckTime.IsZero(), false)
+		t.Assert(stats[0].OpenConnections, 1)
+
+		// The pool is evicted after 2 consecutive failures, of which the second check backs off to 200ms.
+		healthTestServerDown.Set(true)
+		time.Sleep(700 * time.Millisecond)
+		t.Assert(len(db.Stats(ctx)), 0)
+
+		// The pool is reopened by next operation, and recovers in next check after backoff.
+		healthTestServerDown.Set(false)
+		_, err = db.Master()
+		t.AssertNil(err)
+		stats = db.Stats(ctx)
+		t.Assert(len(stats), 1)
+		t.Assert(stats[0].Healthy, false)
+		t.Assert(stats[0].ConsecutiveFailures, 2)
+		t.AssertNE(stats[0].LastError, nil)
+		t.Assert(stats[0].Evictions, 1)
+
+		time.Sleep(700 * time.Millisecond)
+		stats = db.Stats(ctx)
+		t.Assert(stats[0].Healthy, true)
+		t.Assert(stats[0].ConsecutiveFailures, 0)
+		t.AssertNil(stats[0].LastError)
+	})
+}
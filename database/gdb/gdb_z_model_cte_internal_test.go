@@ -0,0 +1,63 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Model_WithCTE(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		testDb, err := New(ConfigNode{Type: "test"})
+		t.AssertNil(err)
+		sub := testDb.Model("order").Unscoped().Where("status=?", 1)
+		sqlStr, args, _ := testDb.Model("paid").Unscoped().
+			WithCTE("paid", sub).
+			WithCTE("big(id, amount)", "SELECT id, amount FROM paid WHERE amount>?", 100).
+			Where("id>?", 10).
+			getFormattedSqlAndArgs(ctx, queryTypeNormal, false)
+		t.Assert(
+			sqlStr,
+			"WITH paid AS (SELECT * FROM order WHERE status=?),big(id,amount) AS (SELECT id, amount FROM paid WHERE amount>?) SELECT * FROM paid WHERE id>?",
+		)
+		t.Assert(args, []interface{}{1, 100, 10})
+
+		sqlStr, args, _ = testDb.Model("tree").Unscoped().
+			WithRecursiveCTE("tree(id, parent_id)", "SELECT id, parent_id FROM category WHERE id=? UNION ALL SELECT c.id, c.parent_id FROM category c JOIN tree t ON c.parent_id=t.id", 1).
+			getFormattedSqlAndArgs(ctx, queryTypeCount, false)
+		t.Assert(
+			sqlStr,
+			"WITH RECURSIVE tree(id,parent_id) AS (SELECT id, parent_id FROM category WHERE id=? UNION ALL SELECT c.id, c.parent_id FROM category c JOIN tree t ON c.parent_id=t.id) SELECT COUNT(1) FROM tree",
+		)
+		t.Assert(args, []interface{}{1})
+	})
+}
+
+func Test_Model_FieldWindow(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		testDb, err := New(ConfigNode{Type: "test"})
+		t.AssertNil(err)
+		sqlStr, _, _ := testDb.Model("employee").Unscoped().
+			Fields("id").
+			FieldRowNumber(Window{PartitionBy: "dept_id", OrderBy: "salary DESC"}, "rn").
+			FieldWindow("SUM(salary)", Window{
+				PartitionBy: "dept_id",
+				OrderBy:     "id",
+				Frame:       "ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW",
+			}, "total").
+			FieldDenseRank(Window{OrderBy: "salary"}).
+			getFormattedSqlAndArgs(ctx, queryTypeNormal, false)
+		t.Assert(
+			sqlStr,
+			"SELECT id,ROW_NUMBER() OVER(PARTITION BY dept_id ORDER BY salary DESC) AS rn,"+
+				"SUM(salary) OVER(PARTITION BY dept_id ORDER BY id ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS total,"+
+				"DENSE_RANK() OVER(ORDER BY salary) FROM employee",
+		)
+	})
+}
@@ -0,0 +1,50 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gpage"
+)
+
+func Test_Model_Cursor(t *testing.T) {
+	testDb, err := New(ConfigNode{Type: "identity_test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gtest.C(t, func(t *gtest.T) {
+		cursor, err := gpage.NewCursor("u.score desc, id")
+		t.AssertNil(err)
+		sqlStr, args, _ := testDb.Model("user u").Unscoped().Cursor(cursor, 10).
+			getFormattedSqlAndArgs(ctx, queryTypeNormal, false)
+		t.Assert(sqlStr, "SELECT * FROM user u ORDER BY u.score DESC,id ASC LIMIT 10")
+		t.Assert(len(args), 0)
+
+		sqlStr, args, _ = testDb.Model("user u").Unscoped().
+			Cursor(cursor.Next(map[string]interface{}{"u.score": 90, "id": 5}), 10).
+			getFormattedSqlAndArgs(ctx, queryTypeNormal, false)
+		t.Assert(sqlStr, "SELECT * FROM user u WHERE ((u.score<?) OR (u.score=? AND id>?)) ORDER BY u.score DESC,id ASC LIMIT 10")
+		t.Assert(args, []interface{}{90, 90, 5})
+	})
+	// The field names injected by client are refused.
+	gtest.C(t, func(t *gtest.T) {
+		for _, name := range []string{"id=id OR 1", "(SELECT 1)", "a.b.c", "`id`"} {
+			var recovered interface{}
+			func() {
+				defer func() {
+					recovered = recover()
+				}()
+				testDb.Model("user").Cursor(&gpage.Cursor{
+					Fields: []gpage.CursorField{{Name: name, Value: 1}},
+				}, 10)
+			}()
+			t.AssertNE(recovered, nil)
+		}
+	})
+}
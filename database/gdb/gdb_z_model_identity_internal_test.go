@@ -0,0 +1,129 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// identityTestDriver is the test driver of which the table "user" has primary key "id".
+type identityTestDriver struct {
+	*DriverTest
+}
+
+func (d *identityTestDriver) New(core *Core, node *ConfigNode) (DB, error) {
+	return &identityTestDriver{DriverTest: &DriverTest{Core: core}}, nil
+}
+
+func (d *identityTestDriver) TableFields(ctx context.Context, table string, schema ...string) (map[string]*TableField, error) {
+	return map[string]*TableField{
+		"id":   {Index: 0, Name: "id", Key: "PRI"},
+		"name": {Index: 1, Name: "name"},
+	}, nil
+}
+
+func init() {
+	if err := Register("identity_test", &identityTestDriver{}); err != nil {
+		panic(err)
+	}
+}
+
+func Test_Model_IdentityMap(t *testing.T) {
+	testDb, err := New(ConfigNode{Type: "identity_test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	type User struct {
+		Id   int
+		Name string
+	}
+	var (
+		count = 0
+		hook  = HookHandler{
+			Select: func(ctx context.Context, in *HookSelectInput) (result Result, err error) {
+				count++
+				return Result{Record{"id": gvar.New(1), "name": gvar.New("john")}}, nil
+			},
+		}
+	)
+	gtest.C(t, func(t *gtest.T) {
+		count = 0
+		// Without identity map.
+		var user1, user2 *User
+		t.AssertNil(testDb.Model("user").Ctx(ctx).Hook(hook).ScanPri(&user1, 1))
+		t.AssertNil(testDb.Model("user").Ctx(ctx).Hook(hook).ScanPri(&user2, 1))
+		t.Assert(user1.Name, "john")
+		t.Assert(user1 == user2, false)
+		t.Assert(count, 2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		count = 0
+		identityCtx := WithIdentityMap(ctx)
+		t.Assert(WithIdentityMap(identityCtx), identityCtx)
+
+		var (
+			user1, user2, user4 *User
+			user3               User
+		)
+		t.AssertNil(testDb.Model("user").Ctx(identityCtx).Hook(hook).ScanPri(&user1, 1))
+		t.AssertNil(testDb.Model("user").Ctx(identityCtx).Hook(hook).ScanPri(&user2, 1))
+		t.AssertNil(testDb.Model("user").Ctx(identityCtx).Hook(hook).ScanPri(&user3, 1))
+		t.Assert(user1 == user2, true)
+		t.Assert(user3.Name, "john")
+		t.Assert(count, 1)
+
+		// Different primary key.
+		t.AssertNil(testDb.Model("user").Ctx(identityCtx).Hook(hook).ScanPri(&user4, 2))
+		t.Assert(user1 == user4, false)
+		t.Assert(count, 2)
+
+		// Cleared by writing.
+		testDb.Model("user").Ctx(identityCtx).removeIdentityMapTable(identityCtx)
+		t.AssertNil(testDb.Model("user").Ctx(identityCtx).Hook(hook).ScanPri(&user2, 1))
+		t.Assert(user1 == user2, false)
+		t.Assert(count, 3)
+
+		// Invalid pointer.
+		t.AssertNE(testDb.Model("user").Ctx(identityCtx).ScanPri(user3, 1), nil)
+	})
+	// The identity map is bypassed if the model carries fields, conditions or soft deleting overrides.
+	gtest.C(t, func(t *gtest.T) {
+		count = 0
+		identityCtx := WithIdentityMap(ctx)
+		var user1, user2 *User
+		t.AssertNil(testDb.Model("user").Ctx(identityCtx).Hook(hook).ScanPri(&user1, 1))
+		t.Assert(count, 1)
+
+		models := []*Model{
+			testDb.Model("user").Fields("id"),
+			testDb.Model("user").FieldsEx("name"),
+			testDb.Model("user").Where("name", "smith"),
+			testDb.Model("user").Unscoped(),
+			testDb.Model("user").WithoutTenant(),
+			testDb.Model("user").LockUpdate(),
+			testDb.Model("user u").LeftJoin("user_detail ud", "ud.uid=u.id"),
+		}
+		for i, model := range models {
+			t.AssertNil(model.Ctx(identityCtx).Hook(hook).ScanPri(&user2, 1))
+			t.Assert(user1 == user2, false)
+			t.Assert(count, i+2)
+		}
+		// The plain model still uses the identity map.
+		t.AssertNil(testDb.Model("user").Ctx(identityCtx).Hook(hook).ScanPri(&user2, 1))
+		t.Assert(user1 == user2, true)
+		t.Assert(count, len(models)+1)
+
+		// Different tenant in the context of the same identity map.
+		t.AssertNil(testDb.Model("user").Ctx(WithTenant(identityCtx, 100)).Hook(hook).ScanPri(&user2, 1))
+		t.Assert(user1 == user2, false)
+		t.Assert(count, len(models)+2)
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Model_BufferedInsert(t *testing.T) {
+	testDb, err := New(ConfigNode{Type: "identity_test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		mu      sync.Mutex
+		batches []List
+		failing bool
+		hook    = HookHandler{
+			Insert: func(ctx context.Context, in *HookInsertInput) (result sql.Result, err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if failing {
+					return nil, gerror.New("insert failed")
+				}
+				batches = append(batches, in.Data)
+				return nil, nil
+			},
+		}
+		getBatches = func() []List {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]List{}, batches...)
+		}
+	)
+	type User struct {
+		Id   int
+		Name string
+	}
+	// Flushing by size.
+	gtest.C(t, func(t *gtest.T) {
+		batches = nil
+		buffer := testDb.Model("user").Hook(hook).BufferedInsert(InsertBufferOption{
+			Size:     2,
+			Interval: time.Hour,
+		})
+		t.AssertNil(buffer.Add(Map{"id": 1, "name": "john"}))
+		t.Assert(buffer.Len(), 1)
+		t.AssertNil(buffer.Add(User{Id: 2, Name: "smith"}))
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(buffer.Len(), 0)
+		t.Assert(len(getBatches()), 1)
+		t.Assert(getBatches()[0], List{{"id": 1, "name": "john"}, {"id": 2, "name": "smith"}})
+
+		// Flushing on close.
+		t.AssertNil(buffer.Add(Map{"id": 3}))
+		t.AssertNil(buffer.Close())
+		t.Assert(len(getBatches()), 2)
+		t.AssertNE(buffer.Add(Map{"id": 4}), nil)
+	})
+	// Flushing by interval.
+	gtest.C(t, func(t *gtest.T) {
+		batches = nil
+		buffer := testDb.Model("user").Hook(hook).BufferedInsert(InsertBufferOption{
+			Interval: 100 * time.Millisecond,
+		})
+		defer buffer.Close()
+		t.AssertNil(buffer.Add(Map{"id": 1}, Map{"id": 2}))
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(buffer.Len(), 0)
+		t.Assert(len(getBatches()), 1)
+		t.Assert(len(getBatches()[0]), 2)
+	})
+	// Capacity and error callback.
+	gtest.C(t, func(t *gtest.T) {
+		failing = true
+		defer func() { failing = false }()
+		var failedRows List
+		buffer := testDb.Model("user").Hook(hook).BufferedInsert(InsertBufferOption{
+			Size:     10,
+			Interval: time.Hour,
+			Capacity: 20,
+			OnError: func(ctx context.Context, rows List, err error) {
+				failedRows = append(failedRows, rows...)
+			},
+		})
+		defer buffer.Close()
+		t.AssertNil(buffer.Add(Map{"id": 1}, Map{"id": 2}, Map{"id": 3}, Map{"id": 4}, Map{"id": 5}))
+		var rows []interface{}
+		for i := 0; i < 16; i++ {
+			rows = append(rows, Map{"id": i})
+		}
+		t.AssertNE(buffer.Add(rows...), nil)
+		t.Assert(buffer.Len(), 5)
+		t.AssertNE(buffer.Flush(), nil)
+		t.Assert(len(failedRows), 5)
+		t.Assert(buffer.Len(), 0)
+	})
+}
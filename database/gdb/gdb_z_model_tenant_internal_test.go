@@ -0,0 +1,183 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// tenantTestDriver is the test driver of which the table "user" and "order" have tenant id field,
+// and the fields of table "broken" cannot be retrieved.
+type tenantTestDriver struct {
+	*DriverTest
+}
+
+func (d *tenantTestDriver) New(core *Core, node *ConfigNode) (DB, error) {
+	return &tenantTestDriver{DriverTest: &DriverTest{Core: core}}, nil
+}
+
+func (d *tenantTestDriver) TableFields(ctx context.Context, table string, schema ...string) (map[string]*TableField, error) {
+	switch table {
+	case "user", "order":
+		return map[string]*TableField{
+			"id":        {Index: 0, Name: "id"},
+			"tenant_id": {Index: 1, Name: "tenant_id"},
+		}, nil
+	case "broken":
+		return nil, gerror.New("connection refused")
+	}
+	return map[string]*TableField{
+		"id": {Index: 0, Name: "id"},
+	}, nil
+}
+
+func init() {
+	if err := Register("tenant_test", &tenantTestDriver{}); err != nil {
+		panic(err)
+	}
+}
+
+func Test_Model_Tenant(t *testing.T) {
+	testDb, err := New(ConfigNode{Type: "tenant_test", TenantField: "tenant_id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenantCtx := WithTenant(ctx, 100)
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(GetTenant(tenantCtx), 100)
+		t.AssertNil(GetTenant(ctx))
+	})
+	gtest.C(t, func(t *gtest.T) {
+		model := testDb.Model("user").Ctx(tenantCtx).Unscoped().Where("id>?", 1)
+		sqlStr, args, _ := model.getFormattedSqlAndArgs(tenantCtx, queryTypeNormal, false)
+		t.Assert(sqlStr, "SELECT * FROM user WHERE (id>?) AND tenant_id=?")
+		t.Assert(args, []interface{}{1, 100})
+
+		sqlStr, args, _ = testDb.Model("user").Ctx(tenantCtx).Unscoped().
+			getFormattedSqlAndArgs(tenantCtx, queryTypeCount, false)
+		t.Assert(sqlStr, "SELECT COUNT(1) FROM user WHERE tenant_id=?")
+		t.Assert(args, []interface{}{100})
+	})
+	// Joined tables.
+	gtest.C(t, func(t *gtest.T) {
+		sqlStr, args, _ := testDb.Model("user u").Ctx(tenantCtx).Unscoped().
+			LeftJoin("order o", "o.uid=u.id").
+			LeftJoin("product p", "p.id=o.pid").
+			Where("u.id", 1).
+			getFormattedSqlAndArgs(tenantCtx, queryTypeNormal, false)
+		t.Assert(sqlStr, "SELECT * FROM user u LEFT JOIN order o ON (o.uid=u.id) LEFT JOIN product p ON (p.id=o.pid) WHERE (u.id=?) AND u.tenant_id=? AND o.tenant_id=?")
+		t.Assert(args, []interface{}{1, 100, 100})
+	})
+	// Not tenant scoped table and opt-out.
+	gtest.C(t, func(t *gtest.T) {
+		sqlStr, _, _ := testDb.Model("product").Unscoped().getFormattedSqlAndArgs(ctx, queryTypeNormal, false)
+		t.Assert(sqlStr, "SELECT * FROM product")
+		t.AssertNil(testDb.Model("product").checkTenant(ctx))
+
+		model := testDb.Model("user").Unscoped().WithoutTenant()
+		sqlStr, _, _ = model.getFormattedSqlAndArgs(ctx, queryTypeNormal, false)
+		t.Assert(sqlStr, "SELECT * FROM user")
+		t.AssertNil(model.checkTenant(ctx))
+	})
+	// Missing tenant.
+	gtest.C(t, func(t *gtest.T) {
+		model := testDb.Model("user").Unscoped()
+		t.AssertNE(model.checkTenant(ctx), nil)
+		sqlStr, _, _ := model.getFormattedSqlAndArgs(ctx, queryTypeNormal, false)
+		t.Assert(sqlStr, "SELECT * FROM user WHERE 1=0")
+
+		_, err := model.Ctx(ctx).All()
+		t.AssertNE(err, nil)
+		_, err = model.Ctx(ctx).Data("id", 1).Where("id", 1).Update()
+		t.AssertNE(err, nil)
+		_, err = model.Ctx(ctx).Where("id", 1).Delete()
+		t.AssertNE(err, nil)
+	})
+	// Tenant condition only is not enough for deleting.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := testDb.Model("user").Ctx(tenantCtx).Delete()
+		t.AssertNE(err, nil)
+	})
+	// Insert.
+	gtest.C(t, func(t *gtest.T) {
+		model := testDb.Model("user")
+		list := List{{"id": 1}, {"id": 2, "tenant_id": 100}}
+		t.AssertNil(model.fillTenantForInsert(tenantCtx, list))
+		t.Assert(list, List{{"id": 1, "tenant_id": 100}, {"id": 2, "tenant_id": 100}})
+
+		list = List{{"id": 1, "tenant_id": 200}}
+		t.AssertNE(model.fillTenantForInsert(tenantCtx, list), nil)
+
+		list = List{{"id": 1, "tenant_id": 200}}
+		t.AssertNil(testDb.Model("user").WithoutTenant().fillTenantForInsert(tenantCtx, list))
+		t.Assert(list, List{{"id": 1, "tenant_id": 200}})
+
+		_, err := model.Ctx(ctx).Data(Map{"id": 1}).Insert()
+		t.AssertNE(err, nil)
+	})
+	// Update cannot move records to other tenant.
+	gtest.C(t, func(t *gtest.T) {
+		model := testDb.Model("user")
+		t.AssertNil(model.checkTenantForUpdate(map[string]interface{}{"id": 1}))
+		t.AssertNE(model.checkTenantForUpdate(map[string]interface{}{"tenant_id": 200}), nil)
+		t.AssertNE(model.checkTenantForUpdate(map[string]interface{}{"TENANT_ID": 200}), nil)
+		t.AssertNil(model.checkTenantForUpdate("id=1,name='tenant_id'"))
+		t.AssertNE(model.checkTenantForUpdate("id=1,tenant_id=200"), nil)
+		t.AssertNE(model.checkTenantForUpdate("id=1, `tenant_id` = 200"), nil)
+		t.AssertNil(testDb.Model("user").WithoutTenant().checkTenantForUpdate("tenant_id=200"))
+		t.AssertNil(testDb.Model("product").checkTenantForUpdate("tenant_id=200"))
+
+		joined := testDb.Model("product p").LeftJoin("user u", "u.id=p.uid")
+		t.AssertNE(joined.checkTenantForUpdate(map[string]interface{}{"u.tenant_id": 200}), nil)
+
+		_, err := model.Ctx(tenantCtx).Data(Map{"tenant_id": 200}).Where("id", 1).Update()
+		t.AssertNE(err, nil)
+	})
+	// Replace and save might overwrite records of other tenant.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := testDb.Model("user").Ctx(tenantCtx).Data(Map{"id": 1}).Save()
+		t.AssertNE(err, nil)
+		_, err = testDb.Model("user").Ctx(tenantCtx).Data(Map{"id": 1}).Replace()
+		t.AssertNE(err, nil)
+		t.AssertNil(testDb.Model("user").checkTenantForInsertOption(InsertOptionIgnore))
+		t.AssertNil(testDb.Model("user").WithoutTenant().checkTenantForInsertOption(InsertOptionSave))
+		t.AssertNil(testDb.Model("product").checkTenantForInsertOption(InsertOptionReplace))
+	})
+	// Raw sql model cannot be scoped.
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNE(testDb.Raw("SELECT * FROM user").checkTenant(tenantCtx), nil)
+		t.AssertNil(testDb.Raw("SELECT * FROM user").WithoutTenant().checkTenant(tenantCtx))
+		_, err := testDb.Raw("SELECT * FROM user").Ctx(tenantCtx).All()
+		t.AssertNE(err, nil)
+	})
+	// Fail closed if the tenant id field cannot be determined.
+	gtest.C(t, func(t *gtest.T) {
+		model := testDb.Model("broken").Unscoped()
+		t.AssertNE(model.checkTenant(tenantCtx), nil)
+		sqlStr, _, _ := model.getFormattedSqlAndArgs(tenantCtx, queryTypeNormal, false)
+		t.Assert(sqlStr, "SELECT * FROM broken WHERE 1=0")
+
+		_, err := model.Ctx(tenantCtx).All()
+		t.AssertNE(err, nil)
+		_, err = model.Ctx(tenantCtx).Data("id", 1).Where("id", 1).Update()
+		t.AssertNE(err, nil)
+		_, err = model.Ctx(tenantCtx).Where("id", 1).Delete()
+		t.AssertNE(err, nil)
+		_, err = model.Ctx(tenantCtx).Data(Map{"id": 1}).Insert()
+		t.AssertNE(err, nil)
+		t.AssertNE(model.checkTenantForUpdate(map[string]interface{}{"id": 1}), nil)
+
+		joined := testDb.Model("user u").LeftJoin("broken b", "b.uid=u.id")
+		t.AssertNE(joined.checkTenant(tenantCtx), nil)
+
+		t.AssertNil(testDb.Model("broken").WithoutTenant().checkTenant(tenantCtx))
+	})
+}
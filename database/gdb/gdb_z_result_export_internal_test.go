@@ -0,0 +1,90 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Result_StreamCSV(t *testing.T) {
+	result := Result{
+		{"id": gvar.New(1), "name": gvar.New("john"), "note": gvar.New(`say "hi", bye`)},
+		{"id": gvar.New(2), "name": gvar.New("smith"), "note": gvar.New(nil)},
+	}
+	gtest.C(t, func(t *gtest.T) {
+		buffer := bytes.NewBuffer(nil)
+		t.AssertNil(result.StreamCSV(buffer))
+		t.Assert(buffer.String(), "id,name,note\n1,john,\"say \"\"hi\"\", bye\"\n2,smith,\n")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		buffer := bytes.NewBuffer(nil)
+		t.AssertNil(result.StreamCSV(buffer, ExportOptions{
+			Fields:  []string{"name", "id"},
+			Headers: []string{"Name", "ID"},
+			Comma:   ';',
+			BOM:     true,
+			Formatter: func(field string, value Value) string {
+				if field == "id" {
+					return "#" + value.String()
+				}
+				return value.String()
+			},
+		}))
+		t.Assert(buffer.String(), "\xEF\xBB\xBFName;ID\njohn;#1\nsmith;#2\n")
+	})
+	// Header only for empty result with fields specified.
+	gtest.C(t, func(t *gtest.T) {
+		buffer := bytes.NewBuffer(nil)
+		t.AssertNil(Result{}.StreamCSV(buffer, ExportOptions{Fields: []string{"id"}}))
+		t.Assert(buffer.String(), "id\n")
+
+		buffer.Reset()
+		t.AssertNil(Result{}.StreamCSV(buffer))
+		t.Assert(buffer.String(), "")
+	})
+}
+
+func Test_Result_StreamXlsx(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			buffer = bytes.NewBuffer(nil)
+			result = Result{
+				{"id": gvar.New(1), "name": gvar.New("<john> & co")},
+				{"id": gvar.New(2.5), "name": gvar.New("smith")},
+			}
+		)
+		t.AssertNil(result.StreamXlsx(buffer, ExportOptions{SheetName: "Users"}))
+
+		reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		t.AssertNil(err)
+		files := make(map[string]string)
+		for _, file := range reader.File {
+			f, err := file.Open()
+			t.AssertNil(err)
+			content, err := ioutil.ReadAll(f)
+			t.AssertNil(err)
+			files[file.Name] = string(content)
+			f.Close()
+		}
+		t.Assert(len(files), 5)
+		t.Assert(bytes.Contains([]byte(files["xl/workbook.xml"]), []byte(`<sheet name="Users"`)), true)
+		t.Assert(
+			files["xl/worksheets/sheet1.xml"],
+			xlsxSheetHeader+
+				`<row><c t="inlineStr"><is><t xml:space="preserve">id</t></is></c><c t="inlineStr"><is><t xml:space="preserve">name</t></is></c></row>`+
+				`<row><c t="n"><v>1</v></c><c t="inlineStr"><is><t xml:space="preserve">&lt;john&gt; &amp; co</t></is></c></row>`+
+				`<row><c t="n"><v>2.5</v></c><c t="inlineStr"><is><t xml:space="preserve">smith</t></is></c></row>`+
+				xlsxSheetFooter,
+		)
+	})
+}
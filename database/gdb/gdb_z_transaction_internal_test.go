@@ -0,0 +1,142 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// txTestEvents records the transaction events of txTestSqlDriver.
+var txTestEvents = garray.NewStrArray(true)
+
+// txTestSqlDriver is the sql driver only supporting transaction beginning, committing and rolling back.
+type txTestSqlDriver struct{}
+
+type txTestSqlConn struct{}
+
+type txTestSqlTx struct{}
+
+func (txTestSqlDriver) Open(name string) (driver.Conn, error) {
+	return &txTestSqlConn{}, nil
+}
+
+func (*txTestSqlConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (*txTestSqlConn) Close() error {
+	return nil
+}
+
+func (*txTestSqlConn) Begin() (driver.Tx, error) {
+	txTestEvents.Append("begin")
+	return &txTestSqlTx{}, nil
+}
+
+func (*txTestSqlTx) Commit() error {
+	txTestEvents.Append("commit")
+	return nil
+}
+
+func (*txTestSqlTx) Rollback() error {
+	txTestEvents.Append("rollback")
+	return nil
+}
+
+// txTestDriver is the gdb driver using txTestSqlDriver.
+type txTestDriver struct {
+	*Core
+}
+
+func (d *txTestDriver) New(core *Core, node *ConfigNode) (DB, error) {
+	return &txTestDriver{Core: core}, nil
+}
+
+func (d *txTestDriver) Open(config *ConfigNode) (*sql.DB, error) {
+	return sql.Open("gdb_tx_test", config.Name)
+}
+
+func init() {
+	sql.Register("gdb_tx_test", txTestSqlDriver{})
+	if err := Register("tx_test", &txTestDriver{}); err != nil {
+		panic(err)
+	}
+}
+
+func Test_Core_TransactionWithOptions(t *testing.T) {
+	testDb, err := New(ConfigNode{Type: "tx_test", Name: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var errInner = errors.New("inner error")
+	// Required: joins the transaction in context.
+	gtest.C(t, func(t *gtest.T) {
+		txTestEvents.Clear()
+		err := testDb.Transaction(ctx, func(ctx context.Context, tx *TX) error {
+			return testDb.TransactionWithOptions(ctx, TxOptions{Propagation: PropagationRequired}, func(ctx context.Context, innerTx *TX) error {
+				t.Assert(innerTx == tx, true)
+				return nil
+			})
+		})
+		t.AssertNil(err)
+		t.Assert(txTestEvents.Slice(), []string{"begin", "commit"})
+
+		// It creates a new transaction if there's no one in context.
+		txTestEvents.Clear()
+		err = testDb.TransactionWithOptions(ctx, TxOptions{Propagation: PropagationRequired}, func(ctx context.Context, tx *TX) error {
+			t.AssertNE(tx, nil)
+			t.Assert(TXFromCtx(ctx, testDb.GetGroup()) == tx, true)
+			return errInner
+		})
+		t.Assert(err, errInner)
+		t.Assert(txTestEvents.Slice(), []string{"begin", "rollback"})
+	})
+	// RequiresNew: the new transaction is independent of the one in context.
+	gtest.C(t, func(t *gtest.T) {
+		txTestEvents.Clear()
+		err := testDb.Transaction(ctx, func(ctx context.Context, tx *TX) error {
+			innerErr := testDb.TransactionWithOptions(ctx, TxOptions{Propagation: PropagationRequiresNew}, func(ctx context.Context, innerTx *TX) error {
+				t.Assert(innerTx != tx, true)
+				t.Assert(TXFromCtx(ctx, testDb.GetGroup()) == innerTx, true)
+				return errInner
+			})
+			t.Assert(innerErr, errInner)
+			// The outer transaction is back in context.
+			t.Assert(TXFromCtx(ctx, testDb.GetGroup()) == tx, true)
+			return nil
+		})
+		t.AssertNil(err)
+		t.Assert(txTestEvents.Slice(), []string{"begin", "begin", "rollback", "commit"})
+	})
+	// NotSupported: executes without transaction.
+	gtest.C(t, func(t *gtest.T) {
+		txTestEvents.Clear()
+		err := testDb.Transaction(ctx, func(ctx context.Context, tx *TX) error {
+			return testDb.TransactionWithOptions(ctx, TxOptions{Propagation: PropagationNotSupported}, func(ctx context.Context, innerTx *TX) error {
+				t.AssertNil(innerTx)
+				t.AssertNil(TXFromCtx(ctx, testDb.GetGroup()))
+				return nil
+			})
+		})
+		t.AssertNil(err)
+		t.Assert(txTestEvents.Slice(), []string{"begin", "commit"})
+	})
+	// Invalid propagation.
+	gtest.C(t, func(t *gtest.T) {
+		err := testDb.TransactionWithOptions(ctx, TxOptions{Propagation: 100}, func(ctx context.Context, tx *TX) error {
+			return nil
+		})
+		t.AssertNE(err, nil)
+	})
+}
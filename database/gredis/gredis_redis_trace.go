@@ -34,6 +34,7 @@ const (
 	traceEventRedisExecutionCommand   = "redis.execution.command"
 	traceEventRedisExecutionCost      = "redis.execution.cost"
 	traceEventRedisExecutionArguments = "redis.execution.arguments"
+	traceEventRedisExecutionBaggage   = "redis.execution.baggage"
 )
 
 // traceSpanEnd checks and adds redis trace information to OpenTelemetry.
@@ -58,9 +59,14 @@ func (c *RedisConn) traceSpanEnd(ctx context.Context, span trace.Span, item *tra
 	}
 
 	jsonBytes, _ := json.Marshal(item.args)
-	span.AddEvent(traceEventRedisExecution, trace.WithAttributes(
+	events := []attribute.KeyValue{
 		attribute.String(traceEventRedisExecutionCommand, item.command),
 		attribute.String(traceEventRedisExecutionCost, fmt.Sprintf(`%d ms`, item.costMilli)),
 		attribute.String(traceEventRedisExecutionArguments, string(jsonBytes)),
-	))
+	}
+	// Redis commands have no metadata for propagating, so the baggage is recorded in the span instead.
+	if baggageMap := gtrace.GetBaggageMap(ctx); !baggageMap.IsEmpty() {
+		events = append(events, attribute.String(traceEventRedisExecutionBaggage, baggageMap.String()))
+	}
+	span.AddEvent(traceEventRedisExecution, trace.WithAttributes(events...))
 }
@@ -0,0 +1,221 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdebug
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// ProfilingAuthFunc checks whether the request is allowed to access the profiling endpoints.
+type ProfilingAuthFunc func(r *http.Request) bool
+
+const (
+	// DefaultProfilingPath is the default path prefix of the profiling endpoints.
+	DefaultProfilingPath = "/debug/profiling"
+
+	defaultProfilingSeconds = 30  // Default seconds for CPU profiling.
+	defaultTraceSeconds     = 1   // Default seconds for execution tracing.
+	maxProfilingSeconds     = 600 // Max seconds for CPU profiling and execution tracing.
+)
+
+var (
+	// blockProfileRate records the rate of block profiling, as runtime does not expose it.
+	blockProfileRate int64
+)
+
+// ProfilingHandler returns the http.Handler serving the profiling endpoints under `path`,
+// which can be used with the standard net/http server, or ghttp.Server.EnableProfiling. The endpoints are:
+//
+// {path}/                 : Index page of the endpoints and profiles.
+// {path}/{profile}        : Profile of given name like "heap", "allocs", "goroutine", "mutex", "block", "threadcreate".
+// {path}/cmdline          : Command line of the running program.
+// {path}/profile          : CPU profile lasting for "seconds" parameter, default 30 seconds.
+// {path}/trace            : Execution trace lasting for "seconds" parameter, default 1 second.
+// {path}/dump/goroutine   : Full goroutine stack dump in text.
+// {path}/dump/heap        : Heap profile after garbage collection as attachment.
+// {path}/toggle/mutex     : Sets mutex profile fraction with "rate" parameter, 0 to turn off.
+// {path}/toggle/block     : Sets block profile rate with "rate" parameter, 0 to turn off.
+//
+// The parameter `path` is DefaultProfilingPath if it is empty.
+// The parameter `auth` guards all the endpoints, and all the requests are denied if it is nil,
+// as the profiles expose the internals of the program. See ProfilingAuthLoopback.
+func ProfilingHandler(path string, auth ProfilingAuthFunc) http.Handler {
+	if path == "" {
+		path = DefaultProfilingPath
+	}
+	path = "/" + strings.Trim(path, "/")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth == nil || !auth(r) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		action := strings.Trim(strings.TrimPrefix(r.URL.Path, path), "/")
+		switch action {
+		case "":
+			serveProfilingIndex(w, path)
+		case "cmdline":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = io.WriteString(w, strings.Join(os.Args, "\x00"))
+		case "profile":
+			serveProfilingDuration(w, r, defaultProfilingSeconds, "profile", func(w io.Writer) error {
+				return pprof.StartCPUProfile(w)
+			}, pprof.StopCPUProfile)
+		case "trace":
+			serveProfilingDuration(w, r, defaultTraceSeconds, "trace", trace.Start, trace.Stop)
+		case "dump/goroutine":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_ = DumpGoroutines(w)
+		case "dump/heap":
+			setAttachmentHeader(w, "heap")
+			_ = DumpHeap(w)
+		case "toggle/mutex", "toggle/block":
+			rate, err := strconv.Atoi(r.URL.Query().Get("rate"))
+			if err != nil || rate < 0 {
+				http.Error(w, `invalid parameter "rate"`, http.StatusBadRequest)
+				return
+			}
+			var previous int
+			if action == "toggle/mutex" {
+				previous = SetMutexProfileFraction(rate)
+			} else {
+				previous = SetBlockProfileRate(rate)
+			}
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = fmt.Fprintf(w, "rate: %d -> %d\n", previous, rate)
+		default:
+			profile := pprof.Lookup(action)
+			if profile == nil {
+				http.Error(w, fmt.Sprintf(`unknown profile "%s"`, action), http.StatusNotFound)
+				return
+			}
+			debug, _ := strconv.Atoi(r.URL.Query().Get("debug"))
+			if debug == 0 {
+				setAttachmentHeader(w, action)
+			} else {
+				w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			}
+			_ = profile.WriteTo(w, debug)
+		}
+	})
+}
+
+// DumpGoroutines writes the stack traces of all current goroutines to `w` in text format.
+func DumpGoroutines(w io.Writer) error {
+	if err := pprof.Lookup("goroutine").WriteTo(w, 2); err != nil {
+		return gerror.WrapCode(gcode.CodeInternalError, err, `dump goroutines failed`)
+	}
+	return nil
+}
+
+// DumpHeap runs a garbage collection and writes the heap profile to `w` in pprof format.
+func DumpHeap(w io.Writer) error {
+	runtime.GC()
+	if err := pprof.Lookup("heap").WriteTo(w, 0); err != nil {
+		return gerror.WrapCode(gcode.CodeInternalError, err, `dump heap failed`)
+	}
+	return nil
+}
+
+// SetMutexProfileFraction controls the fraction of mutex contention events that are reported
+// in the mutex profile, 0 turns off the profiling. It returns the previous rate.
+func SetMutexProfileFraction(rate int) int {
+	return runtime.SetMutexProfileFraction(rate)
+}
+
+// SetBlockProfileRate controls the fraction of goroutine blocking events that are reported
+// in the block profile, 0 turns off the profiling. It returns the previous rate.
+func SetBlockProfileRate(rate int) int {
+	runtime.SetBlockProfileRate(rate)
+	return int(atomic.SwapInt64(&blockProfileRate, int64(rate)))
+}
+
+// serveProfilingIndex writes the index page of the profiling endpoints.
+func serveProfilingIndex(w http.ResponseWriter, path string) {
+	var (
+		profiles = pprof.Profiles()
+		builder  = strings.Builder{}
+	)
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name() < profiles[j].Name()
+	})
+	builder.WriteString("<html><head><title>GoFrame Profiling</title></head><body>profiles:<br><table>")
+	for _, p := range profiles {
+		builder.WriteString(fmt.Sprintf(
+			`<tr><td align=right>%d</td><td><a href="%s/%s?debug=1">%s</a></td></tr>`,
+			p.Count(), path, html.EscapeString(p.Name()), html.EscapeString(p.Name()),
+		))
+	}
+	builder.WriteString("</table><br>")
+	for _, item := range []string{
+		"cmdline", "profile", "trace", "dump/goroutine", "dump/heap",
+		"toggle/mutex?rate=0", "toggle/block?rate=0",
+	} {
+		builder.WriteString(fmt.Sprintf(`<a href="%s/%s">%s</a><br>`, path, item, item))
+	}
+	builder.WriteString("</body></html>")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = io.WriteString(w, builder.String())
+}
+
+// serveProfilingDuration runs the profiling with `start` and `stop` for "seconds" parameter of request.
+func serveProfilingDuration(
+	w http.ResponseWriter, r *http.Request, defaultSeconds int, name string,
+	start func(w io.Writer) error, stop func(),
+) {
+	seconds, err := strconv.Atoi(r.URL.Query().Get("seconds"))
+	if err != nil || seconds <= 0 {
+		seconds = defaultSeconds
+	}
+	if seconds > maxProfilingSeconds {
+		seconds = maxProfilingSeconds
+	}
+	setAttachmentHeader(w, name)
+	if err = start(w); err != nil {
+		w.Header().Del("Content-Disposition")
+		http.Error(w, fmt.Sprintf(`could not start %s: %v`, name, err), http.StatusInternalServerError)
+		return
+	}
+	select {
+	case <-time.After(time.Duration(seconds) * time.Second):
+	case <-r.Context().Done():
+	}
+	stop()
+}
+
+// setAttachmentHeader sets the response headers for binary profile attachment.
+func setAttachmentHeader(w http.ResponseWriter, name string) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+}
+
+// ProfilingAuthLoopback is the ProfilingAuthFunc allowing only the requests from loopback address.
+// Note that it should not be used if the server is behind a reverse proxy on the same host,
+// as all the proxied requests are from loopback address.
+func ProfilingAuthLoopback(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
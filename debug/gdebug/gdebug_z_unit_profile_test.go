@@ -0,0 +1,82 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdebug_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/debug/gdebug"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_DumpGoroutines(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		buffer := bytes.NewBuffer(nil)
+		t.AssertNil(gdebug.DumpGoroutines(buffer))
+		t.Assert(strings.Contains(buffer.String(), "Test_DumpGoroutines"), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		buffer := bytes.NewBuffer(nil)
+		t.AssertNil(gdebug.DumpHeap(buffer))
+		t.Assert(buffer.Len() > 0, true)
+	})
+}
+
+func Test_ProfilingHandler(t *testing.T) {
+	serve := func(handler http.Handler, url, remoteAddr string) *httptest.ResponseRecorder {
+		var (
+			w = httptest.NewRecorder()
+			r = httptest.NewRequest(http.MethodGet, url, nil)
+		)
+		r.RemoteAddr = remoteAddr
+		handler.ServeHTTP(w, r)
+		return w
+	}
+	gtest.C(t, func(t *gtest.T) {
+		// All requests are denied without auth function.
+		handler := gdebug.ProfilingHandler("", nil)
+		t.Assert(serve(handler, "/debug/profiling/", "127.0.0.1:1234").Code, http.StatusForbidden)
+
+		// Only loopback requests are allowed.
+		handler = gdebug.ProfilingHandler("", gdebug.ProfilingAuthLoopback)
+		t.Assert(serve(handler, "/debug/profiling/", "10.0.0.1:1234").Code, http.StatusForbidden)
+
+		w := serve(handler, "/debug/profiling/", "127.0.0.1:1234")
+		t.Assert(w.Code, http.StatusOK)
+		t.Assert(strings.Contains(w.Body.String(), "/debug/profiling/goroutine?debug=1"), true)
+
+		w = serve(handler, "/debug/profiling/goroutine?debug=1", "127.0.0.1:1234")
+		t.Assert(w.Code, http.StatusOK)
+		t.Assert(strings.Contains(w.Body.String(), "goroutine profile"), true)
+
+		w = serve(handler, "/debug/profiling/heap", "127.0.0.1:1234")
+		t.Assert(w.Code, http.StatusOK)
+		t.Assert(w.Header().Get("Content-Disposition"), `attachment; filename="heap"`)
+
+		w = serve(handler, "/debug/profiling/toggle/mutex?rate=x", "127.0.0.1:1234")
+		t.Assert(w.Code, http.StatusBadRequest)
+		w = serve(handler, "/debug/profiling/unknown", "127.0.0.1:1234")
+		t.Assert(w.Code, http.StatusNotFound)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		handler := gdebug.ProfilingHandler("/pprof/", func(r *http.Request) bool {
+			return r.URL.Query().Get("token") == "secret"
+		})
+		t.Assert(serve(handler, "/pprof/cmdline", "127.0.0.1:1234").Code, http.StatusForbidden)
+		w := serve(handler, "/pprof/cmdline?token=secret", "10.0.0.1:1234")
+		t.Assert(w.Code, http.StatusOK)
+		t.AssertNE(w.Body.Len(), 0)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gdebug.SetMutexProfileFraction(5), 0)
+		t.Assert(gdebug.SetMutexProfileFraction(0), 5)
+	})
+}
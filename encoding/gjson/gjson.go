@@ -278,6 +278,57 @@ done:
 	return nil
 }
 
+// parsePattern converts the array index syntax in `pattern` to hierarchical pattern,
+// eg: "a.b[2]" to "a.b.2", "a[0][1].c" to "a.0.1.c".
+// The returned `appending` is true if `pattern` ends with "[]", eg: "a.b[]",
+// which means appending to the array by the returned pattern "a.b".
+// The brackets whose content is not a non-negative integer are treated as part of the key.
+func (j *Json) parsePattern(pattern string) (parsed string, appending bool, err error) {
+	if strings.IndexByte(pattern, '[') == -1 {
+		return pattern, false, nil
+	}
+	var builder strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '[' {
+			builder.WriteByte(pattern[i])
+			continue
+		}
+		end := strings.IndexByte(pattern[i+1:], ']')
+		if end == -1 {
+			builder.WriteString(pattern[i:])
+			break
+		}
+		index := pattern[i+1 : i+1+end]
+		switch {
+		case index == "":
+			if i+2 != len(pattern) {
+				return "", false, gerror.NewCodef(
+					gcode.CodeInvalidParameter,
+					`invalid pattern "%s": appending "[]" is only supported at the end of pattern`,
+					pattern,
+				)
+			}
+			appending = true
+			i++
+
+		case strings.Trim(index, "0123456789") == "":
+			if builder.Len() > 0 {
+				builder.WriteByte(j.c)
+			}
+			builder.WriteString(index)
+			i += end + 1
+
+		default:
+			builder.WriteByte(pattern[i])
+		}
+	}
+	parsed = builder.String()
+	if parsed == "" && appending {
+		parsed = "."
+	}
+	return parsed, appending, nil
+}
+
 // convertValue converts `value` to map[string]interface{} or []interface{},
 // which can be supported for hierarchical data access.
 func (j *Json) convertValue(value interface{}) (convertedValue interface{}, err error) {
@@ -356,6 +407,10 @@ func (j *Json) setPointerWithValue(pointer *interface{}, key string, value inter
 
 // getPointerByPattern returns a pointer to the value by specified `pattern`.
 func (j *Json) getPointerByPattern(pattern string) *interface{} {
+	pattern, appending, err := j.parsePattern(pattern)
+	if err != nil || appending {
+		return nil
+	}
 	if j.vc {
 		return j.getPointerByPatternWithViolenceCheck(pattern)
 	} else {
@@ -45,7 +45,7 @@ func (j *Json) IsNil() bool {
 // It returns nil if no value found by `pattern`.
 //
 // We can also access slice item by its index number in `pattern` like:
-// "list.10", "array.0.name", "array.0.1.id".
+// "list.10", "array.0.name", "array.0.1.id", or by index syntax like: "list[10]", "array[0][1].id".
 //
 // It returns a default value specified by `def` if value for `pattern` is not found.
 func (j *Json) Get(pattern string, def ...interface{}) *gvar.Var {
@@ -106,7 +106,17 @@ func (j *Json) GetJsonMap(pattern string, def ...interface{}) map[string]*Json {
 
 // Set sets value with specified `pattern`.
 // It supports hierarchical data access by char separator, which is '.' in default.
+//
+// The slice item can also be accessed by index syntax in `pattern` like: "array[0].name",
+// and the value is appended to the slice if `pattern` ends with "[]" like: "array[]".
 func (j *Json) Set(pattern string, value interface{}) error {
+	pattern, appending, err := j.parsePattern(pattern)
+	if err != nil {
+		return err
+	}
+	if appending {
+		return j.Append(pattern, value)
+	}
 	return j.setValue(pattern, value, false)
 }
 
@@ -117,10 +127,32 @@ func (j *Json) MustSet(pattern string, value interface{}) {
 	}
 }
 
+// SetIfNotExist sets `value` with specified `pattern` if there's no value by `pattern`,
+// and then returns true. It returns false if the value by `pattern` already exists.
+func (j *Json) SetIfNotExist(pattern string, value interface{}) (bool, error) {
+	if j.Contains(pattern) {
+		return false, nil
+	}
+	if err := j.Set(pattern, value); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Remove deletes value with specified `pattern`.
 // It supports hierarchical data access by char separator, which is '.' in default.
+//
+// The slice item can also be accessed by index syntax in `pattern` like: "array[2]",
+// and the slice is compacted after its item deleted.
 func (j *Json) Remove(pattern string) error {
-	return j.setValue(pattern, nil, true)
+	parsed, appending, err := j.parsePattern(pattern)
+	if err != nil {
+		return err
+	}
+	if appending {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern "%s" for removing`, pattern)
+	}
+	return j.setValue(parsed, nil, true)
 }
 
 // MustRemove performs as Remove, but it panics if any error occurs.
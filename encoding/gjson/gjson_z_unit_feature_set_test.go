@@ -347,3 +347,55 @@ func Test_Set_WithEmptyStruct(t *testing.T) {
 		t.Assert(j.MustToJsonString(), `{"aa":"123"}`)
 	})
 }
+
+func Test_Set_IndexSyntax(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		j := gjson.New(nil)
+		t.AssertNil(j.Set("a.b[]", 1))
+		t.AssertNil(j.Set("a.b[]", 2))
+		t.AssertNil(j.Set("a.b[]", 3))
+		t.Assert(j.Get("a.b").Slice(), g.Slice{1, 2, 3})
+		t.Assert(j.Get("a.b[1]"), 2)
+
+		t.AssertNil(j.Set("a.b[1]", 20))
+		t.Assert(j.Get("a.b").Slice(), g.Slice{1, 20, 3})
+
+		t.AssertNil(j.Remove("a.b[0]"))
+		t.Assert(j.Get("a.b").Slice(), g.Slice{20, 3})
+		t.Assert(j.Len("a.b"), 2)
+
+		t.AssertNil(j.Set("a.c[0][1].name", "john"))
+		t.Assert(j.Get("a.c.0.1.name"), "john")
+		t.Assert(j.Get("a.c[0][1].name"), "john")
+
+		t.AssertNE(j.Set("a.b[].c", 1), nil)
+		t.AssertNE(j.Remove("a.b[]"), nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		j := gjson.New(nil)
+		t.AssertNil(j.Set("[]", "a"))
+		t.AssertNil(j.Set("[]", "b"))
+		t.Assert(j.Array(), g.Slice{"a", "b"})
+		t.AssertNil(j.Remove("[0]"))
+		t.Assert(j.Array(), g.Slice{"b"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		j := gjson.New(g.Map{"a[x]": 1})
+		t.Assert(j.Get("a[x]"), 1)
+	})
+}
+
+func Test_SetIfNotExist(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		j := gjson.New(g.Map{"a": g.Map{"b": 1}})
+		ok, err := j.SetIfNotExist("a.b", 2)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+		t.Assert(j.Get("a.b"), 1)
+
+		ok, err = j.SetIfNotExist("a.c", 3)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+		t.Assert(j.Get("a.c"), 3)
+	})
+}
@@ -0,0 +1,595 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gmsgpack provides encoding and decoding for MessagePack content.
+//
+// It supports the common types: nil, bool, integers, floats, string, []byte, time.Time,
+// slices, arrays, maps and structs. The structs are encoded as maps, of which the keys
+// follow the same rules as gconv.Map. The integers keep their types as int64 or uint64
+// when decoding, which are not mangled as float64 like JSON.
+package gmsgpack
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Format codes of MessagePack specification.
+const (
+	codeNil      = 0xc0
+	codeFalse    = 0xc2
+	codeTrue     = 0xc3
+	codeBin8     = 0xc4
+	codeBin16    = 0xc5
+	codeBin32    = 0xc6
+	codeExt8     = 0xc7
+	codeExt16    = 0xc8
+	codeExt32    = 0xc9
+	codeFloat32  = 0xca
+	codeFloat64  = 0xcb
+	codeUint8    = 0xcc
+	codeUint16   = 0xcd
+	codeUint32   = 0xce
+	codeUint64   = 0xcf
+	codeInt8     = 0xd0
+	codeInt16    = 0xd1
+	codeInt32    = 0xd2
+	codeInt64    = 0xd3
+	codeFixExt4  = 0xd6
+	codeFixExt8  = 0xd7
+	codeStr8     = 0xd9
+	codeStr16    = 0xda
+	codeStr32    = 0xdb
+	codeArray16  = 0xdc
+	codeArray32  = 0xdd
+	codeMap16    = 0xde
+	codeMap32    = 0xdf
+	extTimestamp = -1
+)
+
+// iInterface is used for type assert api for Interface, eg: gvar.Var.
+type iInterface interface {
+	Interface() interface{}
+}
+
+// iMapStrAny is the interface support for converting struct parameter to map, eg: gmap.StrAnyMap.
+type iMapStrAny interface {
+	MapStrAny() map[string]interface{}
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// Encode encodes `value` to MessagePack content.
+func Encode(value interface{}) ([]byte, error) {
+	e := &encoder{buffer: make([]byte, 0, 64)}
+	if err := e.encode(value); err != nil {
+		return nil, err
+	}
+	return e.buffer, nil
+}
+
+// Decode decodes MessagePack `content` and returns the value, the maps are decoded as map[string]interface{}
+// and arrays as []interface{}.
+func Decode(content []byte) (interface{}, error) {
+	d := &decoder{content: content}
+	value, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+	if d.offset != len(d.content) {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter, `invalid msgpack content: %d extra bytes`, len(d.content)-d.offset,
+		)
+	}
+	return value, nil
+}
+
+// DecodeTo decodes MessagePack `content` to `pointer`, which can be pointer to any type that gconv.Scan supports.
+func DecodeTo(content []byte, pointer interface{}) error {
+	value, err := Decode(content)
+	if err != nil {
+		return err
+	}
+	if p, ok := pointer.(*interface{}); ok {
+		*p = value
+		return nil
+	}
+	return gconv.Scan(value, pointer)
+}
+
+type encoder struct {
+	buffer []byte
+}
+
+func (e *encoder) encode(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		e.buffer = append(e.buffer, codeNil)
+	case bool:
+		if v {
+			e.buffer = append(e.buffer, codeTrue)
+		} else {
+			e.buffer = append(e.buffer, codeFalse)
+		}
+	case int:
+		e.encodeInt(int64(v))
+	case int8:
+		e.encodeInt(int64(v))
+	case int16:
+		e.encodeInt(int64(v))
+	case int32:
+		e.encodeInt(int64(v))
+	case int64:
+		e.encodeInt(v)
+	case uint:
+		e.encodeUint(uint64(v))
+	case uint8:
+		e.encodeUint(uint64(v))
+	case uint16:
+		e.encodeUint(uint64(v))
+	case uint32:
+		e.encodeUint(uint64(v))
+	case uint64:
+		e.encodeUint(v)
+	case float32:
+		e.buffer = append(e.buffer, codeFloat32)
+		e.buffer = appendUint32(e.buffer, math.Float32bits(v))
+	case float64:
+		e.buffer = append(e.buffer, codeFloat64)
+		e.buffer = appendUint64(e.buffer, math.Float64bits(v))
+	case string:
+		e.encodeString(v)
+	case []byte:
+		e.encodeBytes(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			e.encodeInt(i)
+		} else if f, err := v.Float64(); err == nil {
+			return e.encode(f)
+		} else {
+			e.encodeString(v.String())
+		}
+	case time.Time:
+		e.encodeTime(v)
+	case *time.Time:
+		if v == nil {
+			e.buffer = append(e.buffer, codeNil)
+		} else {
+			e.encodeTime(*v)
+		}
+	case map[string]interface{}:
+		e.encodeMapHeader(len(v))
+		for k, item := range v {
+			e.encodeString(k)
+			if err := e.encode(item); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		e.encodeArrayHeader(len(v))
+		for _, item := range v {
+			if err := e.encode(item); err != nil {
+				return err
+			}
+		}
+	case iMapStrAny:
+		return e.encode(v.MapStrAny())
+	case iInterface:
+		return e.encode(v.Interface())
+	default:
+		return e.encodeReflect(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+func (e *encoder) encodeReflect(reflectValue reflect.Value) error {
+	switch reflectValue.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if reflectValue.IsNil() {
+			e.buffer = append(e.buffer, codeNil)
+			return nil
+		}
+		return e.encode(reflectValue.Elem().Interface())
+
+	case reflect.Bool:
+		return e.encode(reflectValue.Bool())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.encodeInt(reflectValue.Int())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.encodeUint(reflectValue.Uint())
+
+	case reflect.Float32:
+		return e.encode(float32(reflectValue.Float()))
+
+	case reflect.Float64:
+		return e.encode(reflectValue.Float())
+
+	case reflect.String:
+		e.encodeString(reflectValue.String())
+
+	case reflect.Slice, reflect.Array:
+		if reflectValue.Kind() == reflect.Slice && reflectValue.IsNil() {
+			e.buffer = append(e.buffer, codeNil)
+			return nil
+		}
+		if reflectValue.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, reflectValue.Len())
+			reflect.Copy(reflect.ValueOf(bytes), reflectValue)
+			e.encodeBytes(bytes)
+			return nil
+		}
+		e.encodeArrayHeader(reflectValue.Len())
+		for i := 0; i < reflectValue.Len(); i++ {
+			if err := e.encode(reflectValue.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		if reflectValue.IsNil() {
+			e.buffer = append(e.buffer, codeNil)
+			return nil
+		}
+		e.encodeMapHeader(reflectValue.Len())
+		iterator := reflectValue.MapRange()
+		for iterator.Next() {
+			if err := e.encode(iterator.Key().Interface()); err != nil {
+				return err
+			}
+			if err := e.encode(iterator.Value().Interface()); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		if reflectValue.Type() == timeType {
+			e.encodeTime(reflectValue.Interface().(time.Time))
+			return nil
+		}
+		return e.encode(gconv.Map(reflectValue.Interface()))
+
+	default:
+		return gerror.NewCodef(
+			gcode.CodeNotSupported, `unsupported type "%s" for msgpack encoding`, reflectValue.Type(),
+		)
+	}
+	return nil
+}
+
+func (e *encoder) encodeInt(v int64) {
+	switch {
+	case v >= 0:
+		e.encodeUint(uint64(v))
+	case v >= -32:
+		e.buffer = append(e.buffer, byte(v))
+	case v >= math.MinInt8:
+		e.buffer = append(e.buffer, codeInt8, byte(v))
+	case v >= math.MinInt16:
+		e.buffer = append(e.buffer, codeInt16)
+		e.buffer = appendUint16(e.buffer, uint16(v))
+	case v >= math.MinInt32:
+		e.buffer = append(e.buffer, codeInt32)
+		e.buffer = appendUint32(e.buffer, uint32(v))
+	default:
+		e.buffer = append(e.buffer, codeInt64)
+		e.buffer = appendUint64(e.buffer, uint64(v))
+	}
+}
+
+func (e *encoder) encodeUint(v uint64) {
+	switch {
+	case v <= 0x7f:
+		e.buffer = append(e.buffer, byte(v))
+	case v <= math.MaxUint8:
+		e.buffer = append(e.buffer, codeUint8, byte(v))
+	case v <= math.MaxUint16:
+		e.buffer = append(e.buffer, codeUint16)
+		e.buffer = appendUint16(e.buffer, uint16(v))
+	case v <= math.MaxUint32:
+		e.buffer = append(e.buffer, codeUint32)
+		e.buffer = appendUint32(e.buffer, uint32(v))
+	default:
+		e.buffer = append(e.buffer, codeUint64)
+		e.buffer = appendUint64(e.buffer, v)
+	}
+}
+
+func (e *encoder) encodeString(v string) {
+	n := len(v)
+	switch {
+	case n <= 31:
+		e.buffer = append(e.buffer, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		e.buffer = append(e.buffer, codeStr8, byte(n))
+	case n <= math.MaxUint16:
+		e.buffer = append(e.buffer, codeStr16)
+		e.buffer = appendUint16(e.buffer, uint16(n))
+	default:
+		e.buffer = append(e.buffer, codeStr32)
+		e.buffer = appendUint32(e.buffer, uint32(n))
+	}
+	e.buffer = append(e.buffer, v...)
+}
+
+func (e *encoder) encodeBytes(v []byte) {
+	n := len(v)
+	switch {
+	case n <= math.MaxUint8:
+		e.buffer = append(e.buffer, codeBin8, byte(n))
+	case n <= math.MaxUint16:
+		e.buffer = append(e.buffer, codeBin16)
+		e.buffer = appendUint16(e.buffer, uint16(n))
+	default:
+		e.buffer = append(e.buffer, codeBin32)
+		e.buffer = appendUint32(e.buffer, uint32(n))
+	}
+	e.buffer = append(e.buffer, v...)
+}
+
+func (e *encoder) encodeArrayHeader(n int) {
+	switch {
+	case n <= 15:
+		e.buffer = append(e.buffer, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		e.buffer = append(e.buffer, codeArray16)
+		e.buffer = appendUint16(e.buffer, uint16(n))
+	default:
+		e.buffer = append(e.buffer, codeArray32)
+		e.buffer = appendUint32(e.buffer, uint32(n))
+	}
+}
+
+func (e *encoder) encodeMapHeader(n int) {
+	switch {
+	case n <= 15:
+		e.buffer = append(e.buffer, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		e.buffer = append(e.buffer, codeMap16)
+		e.buffer = appendUint16(e.buffer, uint16(n))
+	default:
+		e.buffer = append(e.buffer, codeMap32)
+		e.buffer = appendUint32(e.buffer, uint32(n))
+	}
+}
+
+// encodeTime encodes time as the timestamp extension type in 96 bits format.
+func (e *encoder) encodeTime(t time.Time) {
+	e.buffer = append(e.buffer, codeExt8, 12, byte(0xff))
+	e.buffer = appendUint32(e.buffer, uint32(t.Nanosecond()))
+	e.buffer = appendUint64(e.buffer, uint64(t.Unix()))
+}
+
+type decoder struct {
+	content []byte
+	offset  int
+}
+
+func (d *decoder) decode() (interface{}, error) {
+	code, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case code <= 0x7f:
+		return int64(code), nil
+	case code >= 0xe0:
+		return int64(int8(code)), nil
+	case code&0xf0 == 0x80:
+		return d.decodeMap(int(code & 0x0f))
+	case code&0xf0 == 0x90:
+		return d.decodeArray(int(code & 0x0f))
+	case code&0xe0 == 0xa0:
+		return d.readString(int(code & 0x1f))
+	}
+	switch code {
+	case codeNil:
+		return nil, nil
+	case codeFalse:
+		return false, nil
+	case codeTrue:
+		return true, nil
+	case codeBin8, codeBin16, codeBin32:
+		n, err := d.readLength(code, codeBin8)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.readBytes(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case codeFloat32:
+		b, err := d.readBytes(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(b)), nil
+	case codeFloat64:
+		b, err := d.readBytes(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	case codeUint8, codeUint16, codeUint32, codeUint64:
+		b, err := d.readBytes(1 << (code - codeUint8))
+		if err != nil {
+			return nil, err
+		}
+		v := readUint(b)
+		if v <= math.MaxInt64 {
+			return int64(v), nil
+		}
+		return v, nil
+	case codeInt8, codeInt16, codeInt32, codeInt64:
+		size := 1 << (code - codeInt8)
+		b, err := d.readBytes(size)
+		if err != nil {
+			return nil, err
+		}
+		// Sign extension.
+		shift := uint(64 - size*8)
+		return int64(readUint(b)<<shift) >> shift, nil
+	case codeStr8, codeStr16, codeStr32:
+		n, err := d.readLength(code, codeStr8)
+		if err != nil {
+			return nil, err
+		}
+		return d.readString(n)
+	case codeArray16, codeArray32:
+		n, err := d.readLength(code, codeArray16)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(n)
+	case codeMap16, codeMap32:
+		n, err := d.readLength(code, codeMap16)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(n)
+	case codeFixExt4, codeFixExt8, codeExt8, codeExt16, codeExt32:
+		return d.decodeExt(code)
+	}
+	return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid msgpack format code 0x%x`, code)
+}
+
+func (d *decoder) decodeArray(n int) (interface{}, error) {
+	if n > len(d.content)-d.offset {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid msgpack array length %d`, n)
+	}
+	array := make([]interface{}, n)
+	for i := 0; i < n; i++ {
+		v, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		array[i] = v
+	}
+	return array, nil
+}
+
+func (d *decoder) decodeMap(n int) (interface{}, error) {
+	if n > len(d.content)-d.offset {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid msgpack map length %d`, n)
+	}
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		k, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		m[gconv.String(k)] = v
+	}
+	return m, nil
+}
+
+func (d *decoder) decodeExt(code byte) (interface{}, error) {
+	var (
+		n   int
+		err error
+	)
+	switch code {
+	case codeFixExt4:
+		n = 4
+	case codeFixExt8:
+		n = 8
+	default:
+		if n, err = d.readLength(code, codeExt8); err != nil {
+			return nil, err
+		}
+	}
+	extType, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	data, err := d.readBytes(n)
+	if err != nil {
+		return nil, err
+	}
+	if int8(extType) != extTimestamp {
+		return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported msgpack extension type %d`, int8(extType))
+	}
+	switch n {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(data)), 0), nil
+	case 8:
+		v := binary.BigEndian.Uint64(data)
+		return time.Unix(int64(v&0x00000003ffffffff), int64(v>>34)), nil
+	case 12:
+		return time.Unix(int64(binary.BigEndian.Uint64(data[4:])), int64(binary.BigEndian.Uint32(data[:4]))), nil
+	}
+	return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid msgpack timestamp length %d`, n)
+}
+
+// readLength reads the length of 8/16/32 bits for format `code`, of which `base` is the 8 bits code.
+func (d *decoder) readLength(code, base byte) (int, error) {
+	b, err := d.readBytes(1 << (code - base))
+	if err != nil {
+		return 0, err
+	}
+	return int(readUint(b)), nil
+}
+
+func (d *decoder) readByte() (byte, error) {
+	if d.offset >= len(d.content) {
+		return 0, gerror.NewCode(gcode.CodeInvalidParameter, `unexpected end of msgpack content`)
+	}
+	b := d.content[d.offset]
+	d.offset++
+	return b, nil
+}
+
+func (d *decoder) readBytes(n int) ([]byte, error) {
+	if n < 0 || n > len(d.content)-d.offset {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `unexpected end of msgpack content`)
+	}
+	b := d.content[d.offset : d.offset+n]
+	d.offset += n
+	return b, nil
+}
+
+func (d *decoder) readString(n int) (string, error) {
+	b, err := d.readBytes(n)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func readUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	return append(b, byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32), byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
@@ -0,0 +1,111 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmsgpack_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gmsgpack"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Encode_Decode(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		data := map[string]interface{}{
+			"nil":    nil,
+			"bool":   true,
+			"int":    -1,
+			"int8":   int8(-100),
+			"int16":  int16(-1000),
+			"int32":  int32(-100000),
+			"int64":  int64(math.MinInt64),
+			"uint8":  uint8(200),
+			"uint64": uint64(math.MaxUint64),
+			"big":    int64(1 << 60),
+			"float":  1.5,
+			"string": "john",
+			"bytes":  []byte{1, 2, 3},
+			"array":  []interface{}{1, "a", false},
+			"map":    map[string]interface{}{"k": "v"},
+		}
+		content, err := gmsgpack.Encode(data)
+		t.AssertNil(err)
+
+		result, err := gmsgpack.Decode(content)
+		t.AssertNil(err)
+		m := result.(map[string]interface{})
+		t.Assert(m["nil"], nil)
+		t.Assert(m["bool"], true)
+		t.Assert(m["int"], int64(-1))
+		t.Assert(m["int8"], int64(-100))
+		t.Assert(m["int16"], int64(-1000))
+		t.Assert(m["int32"], int64(-100000))
+		t.Assert(m["int64"], int64(math.MinInt64))
+		t.Assert(m["uint8"], int64(200))
+		t.Assert(m["uint64"], uint64(math.MaxUint64))
+		t.Assert(m["big"], int64(1<<60))
+		t.Assert(m["float"], 1.5)
+		t.Assert(m["string"], "john")
+		t.Assert(m["bytes"], []byte{1, 2, 3})
+		t.Assert(m["array"], []interface{}{int64(1), "a", false})
+		t.Assert(m["map"], map[string]interface{}{"k": "v"})
+	})
+}
+
+func Test_Encode_Decode_Time(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		now := time.Unix(1700000000, 123456789)
+		content, err := gmsgpack.Encode(now)
+		t.AssertNil(err)
+
+		result, err := gmsgpack.Decode(content)
+		t.AssertNil(err)
+		t.Assert(result.(time.Time).UnixNano(), now.UnixNano())
+	})
+}
+
+func Test_DecodeTo(t *testing.T) {
+	type User struct {
+		Id    int64
+		Name  string
+		Roles []string
+	}
+	gtest.C(t, func(t *gtest.T) {
+		content, err := gmsgpack.Encode(&User{
+			Id:    1 << 40,
+			Name:  "john",
+			Roles: []string{"admin", "user"},
+		})
+		t.AssertNil(err)
+
+		var user *User
+		t.AssertNil(gmsgpack.DecodeTo(content, &user))
+		t.Assert(user.Id, int64(1<<40))
+		t.Assert(user.Name, "john")
+		t.Assert(user.Roles, []string{"admin", "user"})
+	})
+}
+
+func Test_Decode_Invalid(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gmsgpack.Decode([]byte{0xc1})
+		t.AssertNE(err, nil)
+
+		// Truncated string.
+		_, err = gmsgpack.Decode([]byte{0xa5, 'a'})
+		t.AssertNE(err, nil)
+
+		// Extra bytes.
+		_, err = gmsgpack.Decode([]byte{0x01, 0x02})
+		t.AssertNE(err, nil)
+
+		_, err = gmsgpack.Encode(make(chan int))
+		t.AssertNE(err, nil)
+	})
+}
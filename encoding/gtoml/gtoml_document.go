@@ -0,0 +1,931 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtoml
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Document is a TOML document for round-trip editing, which preserves comments, key order and
+// formatting of the loaded content when it is modified and encoded, as it edits the lines of
+// the modified values only. It is suitable for tools editing configuration files of users.
+//
+// It supports hierarchical data access by pattern like gjson, eg: "server.address", in which
+// the items of array or inline table value can also be accessed, eg: "server.hosts.0" or
+// "server.hosts[0]", and so can the elements of array of tables, eg: "items.1.name" for key "name"
+// of the second "[[items]]". It also supports appending to array by pattern like "server.hosts[]".
+type Document struct {
+	lines []string // Content lines.
+}
+
+// documentTable is a table header in document.
+type documentTable struct {
+	name   string // Table name joined by '.', in which the element indexes of arrays of tables are inserted.
+	header string // Header name joined by '.', like name but without the element indexes.
+	line   int    // Line index of the table header.
+	end    int    // Last line index of the table entries, or its header if no entries.
+	array  bool   // Whether it is an element of array of tables, like "[[name]]".
+}
+
+// documentEntry is a key/value pair in document.
+type documentEntry struct {
+	key        string // Full key joined by '.'.
+	table      int    // Index of the table in tables, -1 for root table.
+	start      int    // Line index where the entry starts.
+	end        int    // Line index where the entry ends.
+	valueStart int    // Byte offset of the value in its start line.
+	valueEnd   int    // Byte offset after the value in its end line.
+}
+
+// documentScan is the scanning result of document.
+type documentScan struct {
+	tables  []documentTable
+	entries []documentEntry
+}
+
+// LoadDocument loads `content` as Document for round-trip editing.
+func LoadDocument(content []byte) (*Document, error) {
+	if _, err := Decode(content); err != nil {
+		return nil, err
+	}
+	return &Document{
+		lines: strings.Split(string(content), "\n"),
+	}, nil
+}
+
+// Get retrieves and returns the value by specified `pattern`.
+// It returns all values of the document if `pattern` is ".".
+// It returns nil if no value found by `pattern`.
+func (d *Document) Get(pattern string) interface{} {
+	keys, appending := splitDocumentPattern(pattern)
+	if appending {
+		return nil
+	}
+	data, err := Decode([]byte(strings.Join(d.lines, "\n")))
+	if err != nil {
+		return nil
+	}
+	value, _ := getDocumentValue(data, keys)
+	return value
+}
+
+// Contains checks whether the value by specified `pattern` exists.
+func (d *Document) Contains(pattern string) bool {
+	return d.Get(pattern) != nil
+}
+
+// Set sets `value` by specified `pattern`. The line of existing value is updated with its comment
+// retained, and the new key is added to the end of its table, or to a new table appended to the end
+// of document if there's no table for it.
+//
+// The pattern ending with "[]" appends `value` to the array, or appends a new table of map `value`
+// to the array of tables. The array is created if it does not exist.
+func (d *Document) Set(pattern string, value interface{}) error {
+	keys, appending := splitDocumentPattern(pattern)
+	if len(keys) == 0 {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern "%s" for setting`, pattern)
+	}
+	if value == nil {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `nil value is not supported by TOML for pattern "%s"`, pattern)
+	}
+	if appending {
+		return d.appendValue(keys, value)
+	}
+	return d.update(func(scan documentScan) error {
+		var (
+			key       = strings.Join(keys, ".")
+			valueText string
+			err       error
+		)
+		if valueText, err = formatDocumentValue(value); err != nil {
+			return err
+		}
+		// Existing entry, or the value is in an inline table or array of existing entry.
+		for _, entry := range scan.entries {
+			if entry.key == key {
+				// Literal string style is retained if possible.
+				oldText := d.valueText(entry)
+				if v, ok := value.(string); ok && strings.HasPrefix(oldText, "'") &&
+					!strings.HasPrefix(oldText, "'''") && !strings.ContainsAny(v, "'\r\n") {
+					valueText = "'" + v + "'"
+				}
+				d.replaceValue(entry, valueText)
+				return nil
+			}
+			if strings.HasPrefix(key, entry.key+".") {
+				return d.setInlineValue(entry, keys[len(strings.Split(entry.key, ".")):], value)
+			}
+		}
+		// Existing table is updated by the keys of map value, or else replaced.
+		if scan.hasChildren(key) {
+			if m, ok := value.(map[string]interface{}); ok {
+				return d.setTable(keys, m)
+			}
+			d.removeByPrefix(scan, key)
+			return d.Set(pattern, value)
+		}
+		// New key added to its table.
+		for i := len(keys) - 1; i > 0; i-- {
+			tableName := strings.Join(keys[:i], ".")
+			for index, table := range scan.tables {
+				if table.name == tableName {
+					d.insertLines(
+						scan.tableEnd(index)+1,
+						d.entryIndent(scan, table.name)+formatDocumentKey(keys[i:])+" = "+valueText,
+					)
+					return nil
+				}
+			}
+		}
+		// The element of array of tables is not created by table header like "[items.5]",
+		// which defines a sub table of the last element instead.
+		for i := len(keys) - 1; i > 0; i-- {
+			if scan.isArrayOfTables(strings.Join(keys[:i], ".")) {
+				return gerror.NewCodef(
+					gcode.CodeInvalidParameter, `element of array of tables not found for pattern "%s"`, pattern,
+				)
+			}
+		}
+		// New key added to root table, if it is a root key or root table has keys of the same prefix.
+		rootEnd := scan.tableEnd(-1)
+		if len(keys) == 1 || scan.hasRootPrefix(keys[0]) {
+			switch {
+			case rootEnd != -1:
+				d.insertLines(rootEnd+1, formatDocumentKey(keys)+" = "+valueText)
+			case len(scan.tables) > 0:
+				d.insertLines(d.rootInsertion(scan), formatDocumentKey(keys)+" = "+valueText, "")
+			default:
+				d.insertLines(d.rootInsertion(scan), formatDocumentKey(keys)+" = "+valueText)
+			}
+			return nil
+		}
+		// New table appended to the end of document.
+		var lines []string
+		if len(d.lines) > 0 && strings.TrimSpace(d.lines[len(d.lines)-1]) == "" {
+			d.lines = d.lines[:len(d.lines)-1]
+		}
+		if len(d.lines) > 0 && strings.TrimSpace(d.lines[len(d.lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		lines = append(
+			lines,
+			"["+formatDocumentKey(keys[:len(keys)-1])+"]",
+			formatDocumentKey(keys[len(keys)-1:])+" = "+valueText,
+			"",
+		)
+		d.lines = append(d.lines, lines...)
+		return nil
+	})
+}
+
+// Remove deletes the value by specified `pattern`, including the tables and their sub tables.
+// It does nothing if no value found by `pattern`.
+func (d *Document) Remove(pattern string) error {
+	keys, appending := splitDocumentPattern(pattern)
+	if len(keys) == 0 || appending {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern "%s" for removing`, pattern)
+	}
+	return d.update(func(scan documentScan) error {
+		key := strings.Join(keys, ".")
+		for _, entry := range scan.entries {
+			if strings.HasPrefix(key, entry.key+".") {
+				return d.setInlineValue(entry, keys[len(strings.Split(entry.key, ".")):], nil)
+			}
+		}
+		d.removeByPrefix(scan, key)
+		return nil
+	})
+}
+
+// Encode encodes the document to TOML content.
+func (d *Document) Encode() ([]byte, error) {
+	return []byte(strings.Join(d.lines, "\n")), nil
+}
+
+// update calls `f` with the scanning result to modify the document lines,
+// which are restored if `f` fails or the modified content is invalid.
+func (d *Document) update(f func(scan documentScan) error) error {
+	backup := make([]string, len(d.lines))
+	copy(backup, d.lines)
+	err := f(d.scan())
+	if err == nil {
+		_, err = Decode([]byte(strings.Join(d.lines, "\n")))
+	}
+	if err != nil {
+		d.lines = backup
+	}
+	return err
+}
+
+// appendValue appends `value` to the array value by `keys`, or appends a new table of map `value`
+// to the array of tables by `keys`. It creates the array value if it does not exist.
+func (d *Document) appendValue(keys []string, value interface{}) error {
+	key := strings.Join(keys, ".")
+	switch v := d.Get(key).(type) {
+	case nil:
+		return d.Set(key, []interface{}{value})
+	case []interface{}:
+		return d.Set(key+"."+strconv.Itoa(len(v)), value)
+	case []map[string]interface{}:
+		return d.appendTable(keys, value)
+	}
+	return gerror.NewCodef(gcode.CodeInvalidParameter, `cannot append value to non-array key "%s"`, key)
+}
+
+// appendTable appends a new table of map `value` to the array of tables by `keys`,
+// after the last element of the array and its sub tables.
+func (d *Document) appendTable(keys []string, value interface{}) error {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`cannot append value of type %T to array of tables "%s", which should be map`,
+			value, strings.Join(keys, "."),
+		)
+	}
+	return d.update(func(scan documentScan) error {
+		var (
+			key   = strings.Join(keys, ".")
+			end   = -1
+			found bool
+		)
+		for index, table := range scan.tables {
+			if table.array && table.header == key && strings.HasPrefix(table.name, key+".") {
+				found = true
+			}
+			if strings.HasPrefix(table.name, key+".") && scan.tableEnd(index) > end {
+				end = scan.tableEnd(index)
+			}
+		}
+		// The array of tables nested in element of other array of tables, like "a.0.b",
+		// cannot be addressed by table header.
+		if !found {
+			return gerror.NewCodef(gcode.CodeNotSupported, `appending to array of tables "%s" is not supported`, key)
+		}
+		names := make([]string, 0, len(m))
+		for k := range m {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		lines := []string{"", "[[" + formatDocumentKey(keys) + "]]"}
+		for _, k := range names {
+			valueText, err := formatDocumentValue(m[k])
+			if err != nil {
+				return err
+			}
+			lines = append(lines, formatDocumentKey([]string{k})+" = "+valueText)
+		}
+		d.insertLines(end+1, lines...)
+		return nil
+	})
+}
+
+// replaceValue replaces the value of `entry` with `valueText`, retaining the content after the value.
+func (d *Document) replaceValue(entry documentEntry, valueText string) {
+	line := d.lines[entry.start][:entry.valueStart] + valueText + d.lines[entry.end][entry.valueEnd:]
+	d.lines = append(d.lines[:entry.start], append([]string{line}, d.lines[entry.end+1:]...)...)
+}
+
+// setInlineValue sets `value` by `keys` in the inline table or array value of `entry`.
+// It deletes the value by `keys` if `value` is nil.
+func (d *Document) setInlineValue(entry documentEntry, keys []string, value interface{}) error {
+	var (
+		valueText = d.valueText(entry)
+		data      map[string]interface{}
+	)
+	if err := DecodeTo([]byte("v = "+valueText), &data); err != nil {
+		return err
+	}
+	newValue, err := setDocumentValue(data["v"], keys, value)
+	if err != nil {
+		return err
+	}
+	if valueText, err = formatDocumentValue(newValue); err != nil {
+		return err
+	}
+	d.replaceValue(entry, valueText)
+	return nil
+}
+
+// setTable updates the table by `keys` with the keys of `m`, in which the keys not in `m` are removed.
+func (d *Document) setTable(keys []string, m map[string]interface{}) error {
+	key := strings.Join(keys, ".")
+	if existing, ok := d.Get(key).(map[string]interface{}); ok {
+		for k := range existing {
+			if _, ok = m[k]; !ok {
+				if err := d.Remove(key + "." + k); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	names := make([]string, 0, len(m))
+	for k := range m {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		if err := d.Set(key+"."+k, m[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeByPrefix removes the entries and tables of name `key` or of prefix `key`.
+// It returns whether any line removed.
+func (d *Document) removeByPrefix(scan documentScan, key string) bool {
+	var (
+		prefix  = key + "."
+		removed = make(map[int]struct{})
+	)
+	for _, entry := range scan.entries {
+		if entry.key == key || strings.HasPrefix(entry.key, prefix) {
+			for i := entry.start; i <= entry.end; i++ {
+				removed[i] = struct{}{}
+			}
+		}
+	}
+	for index, table := range scan.tables {
+		if table.name == key || strings.HasPrefix(table.name, prefix) {
+			for i := table.line; i <= scan.tableEnd(index); i++ {
+				removed[i] = struct{}{}
+			}
+		}
+	}
+	if len(removed) == 0 {
+		return false
+	}
+	lines := make([]string, 0, len(d.lines))
+	for i, line := range d.lines {
+		if _, ok := removed[i]; !ok {
+			lines = append(lines, line)
+		}
+	}
+	d.lines = lines
+	return true
+}
+
+// insertLines inserts `lines` before line index `index`.
+func (d *Document) insertLines(index int, lines ...string) {
+	d.lines = append(d.lines[:index], append(lines, d.lines[index:]...)...)
+}
+
+// rootInsertion returns the line index for inserting the first key of root table,
+// which is before the first table header and its leading comments.
+func (d *Document) rootInsertion(scan documentScan) int {
+	if len(scan.tables) == 0 {
+		if len(d.lines) > 0 && d.lines[len(d.lines)-1] == "" {
+			return len(d.lines) - 1
+		}
+		return len(d.lines)
+	}
+	index := scan.tables[0].line
+	for index > 0 && strings.HasPrefix(strings.TrimSpace(d.lines[index-1]), "#") {
+		index--
+	}
+	return index
+}
+
+// entryIndent returns the indentation of the last entry in table `table`.
+func (d *Document) entryIndent(scan documentScan, table string) string {
+	var indent string
+	for _, entry := range scan.entries {
+		if entry.table >= 0 && scan.tables[entry.table].name == table {
+			line := d.lines[entry.start]
+			indent = line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		}
+	}
+	return indent
+}
+
+// valueText returns the value text of `entry`.
+func (d *Document) valueText(entry documentEntry) string {
+	if entry.start == entry.end {
+		return d.lines[entry.start][entry.valueStart:entry.valueEnd]
+	}
+	lines := []string{d.lines[entry.start][entry.valueStart:]}
+	lines = append(lines, d.lines[entry.start+1:entry.end]...)
+	lines = append(lines, d.lines[entry.end][:entry.valueEnd])
+	return strings.Join(lines, "\n")
+}
+
+// scan scans and returns the tables and entries of the document.
+func (d *Document) scan() documentScan {
+	var (
+		scan   documentScan
+		table  = -1
+		arrays = make(map[string]int) // Element counts of arrays of tables by their table names.
+	)
+	for i := 0; i < len(d.lines); i++ {
+		var (
+			line    = d.lines[i]
+			trimmed = strings.TrimSpace(line)
+			pos     = len(line) - len(strings.TrimLeft(line, " \t"))
+		)
+		if trimmed == "" || trimmed[0] == '#' {
+			continue
+		}
+		if trimmed[0] == '[' {
+			array := strings.HasPrefix(trimmed, "[[")
+			if array {
+				pos += 2
+			} else {
+				pos++
+			}
+			keys, _ := parseDocumentKey(line, pos)
+			scan.tables = append(scan.tables, documentTable{
+				name:   resolveDocumentTableName(keys, arrays, array),
+				header: strings.Join(keys, "."),
+				line:   i,
+				end:    i,
+				array:  array,
+			})
+			table = len(scan.tables) - 1
+			continue
+		}
+		keys, next := parseDocumentKey(line, pos)
+		if next >= len(line) || line[next] != '=' {
+			continue
+		}
+		valueStart := next + 1
+		for valueStart < len(line) && (line[valueStart] == ' ' || line[valueStart] == '\t') {
+			valueStart++
+		}
+		end, valueEnd := scanDocumentValue(d.lines, i, valueStart)
+		entry := documentEntry{
+			key:        strings.Join(keys, "."),
+			table:      table,
+			start:      i,
+			end:        end,
+			valueStart: valueStart,
+			valueEnd:   valueEnd,
+		}
+		if table >= 0 {
+			entry.key = scan.tables[table].name + "." + entry.key
+			scan.tables[table].end = end
+		}
+		scan.entries = append(scan.entries, entry)
+		i = end
+	}
+	return scan
+}
+
+// resolveDocumentTableName resolves table header `keys` to the table name, in which the element
+// indexes of arrays of tables are inserted, eg: "items.1" for the second "[[items]]", and "items.1.sub"
+// for "[items.sub]" after it. The element counts in `arrays` are increased for the array of tables.
+func resolveDocumentTableName(keys []string, arrays map[string]int, array bool) string {
+	resolved := make([]string, 0, len(keys))
+	for i, key := range keys {
+		resolved = append(resolved, key)
+		name := strings.Join(resolved, ".")
+		if i == len(keys)-1 && array {
+			arrays[name]++
+			resolved = append(resolved, strconv.Itoa(arrays[name]-1))
+		} else if count, ok := arrays[name]; ok && i < len(keys)-1 {
+			// It belongs to the last element of the array of tables.
+			resolved = append(resolved, strconv.Itoa(count-1))
+		}
+	}
+	return strings.Join(resolved, ".")
+}
+
+// tableEnd returns the last line index of table at `index` in tables, or of root table if `index` is -1.
+// It returns -1 if root table has no entries.
+func (s documentScan) tableEnd(index int) int {
+	if index >= 0 {
+		return s.tables[index].end
+	}
+	end := -1
+	for _, entry := range s.entries {
+		if entry.table == -1 {
+			end = entry.end
+		}
+	}
+	return end
+}
+
+// hasChildren checks whether there's table of name `key`, or entries and tables of prefix `key`.
+func (s documentScan) hasChildren(key string) bool {
+	prefix := key + "."
+	for _, table := range s.tables {
+		if table.name == key || strings.HasPrefix(table.name, prefix) {
+			return true
+		}
+	}
+	for _, entry := range s.entries {
+		if strings.HasPrefix(entry.key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isArrayOfTables checks whether `name` is the name of array of tables.
+func (s documentScan) isArrayOfTables(name string) bool {
+	for _, table := range s.tables {
+		if table.array && table.name[:strings.LastIndex(table.name, ".")] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRootPrefix checks whether root table has keys of prefix `key`.
+func (s documentScan) hasRootPrefix(key string) bool {
+	for _, entry := range s.entries {
+		if entry.table == -1 && strings.HasPrefix(entry.key, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDocumentKey parses the dotted key starting at `pos` of `line`, which might be bare or quoted,
+// and returns its parts and the position after the key and its trailing spaces.
+func parseDocumentKey(line string, pos int) (keys []string, next int) {
+	for pos < len(line) {
+		for pos < len(line) && (line[pos] == ' ' || line[pos] == '\t') {
+			pos++
+		}
+		if pos >= len(line) {
+			break
+		}
+		var key string
+		switch line[pos] {
+		case '"':
+			end := pos + 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return keys, len(line)
+			}
+			if unquoted, err := strconv.Unquote(line[pos : end+1]); err == nil {
+				key = unquoted
+			} else {
+				key = line[pos+1 : end]
+			}
+			pos = end + 1
+
+		case '\'':
+			end := strings.IndexByte(line[pos+1:], '\'')
+			if end == -1 {
+				return keys, len(line)
+			}
+			key = line[pos+1 : pos+1+end]
+			pos += end + 2
+
+		default:
+			end := pos
+			for end < len(line) && isBareKeyChar(line[end]) {
+				end++
+			}
+			key = line[pos:end]
+			pos = end
+		}
+		keys = append(keys, key)
+		for pos < len(line) && (line[pos] == ' ' || line[pos] == '\t') {
+			pos++
+		}
+		if pos >= len(line) || line[pos] != '.' {
+			break
+		}
+		pos++
+	}
+	return keys, pos
+}
+
+// scanDocumentValue scans the value starting at `pos` of line `start` in `lines`, which might
+// span multiple lines, and returns the line index and byte offset where the value ends.
+func scanDocumentValue(lines []string, start, pos int) (endLine, endPos int) {
+	var depth int
+	endLine, endPos = start, pos
+	for l := start; l < len(lines); l++ {
+		line := lines[l]
+		i := 0
+		if l == start {
+			i = pos
+		}
+		for i < len(line) {
+			c := line[i]
+			switch {
+			case strings.HasPrefix(line[i:], `"""`) || strings.HasPrefix(line[i:], `'''`):
+				l, i = findDocumentDelimiter(lines, l, i+3, line[i:i+3])
+				line = lines[l]
+				endLine, endPos = l, i
+				continue
+
+			case c == '"' || c == '\'':
+				j := i + 1
+				for j < len(line) && line[j] != c {
+					if c == '"' && line[j] == '\\' {
+						j++
+					}
+					j++
+				}
+				if i = j + 1; i > len(line) {
+					i = len(line)
+				}
+				endLine, endPos = l, i
+				continue
+
+			case c == '#':
+				i = len(line)
+				continue
+
+			case c == '[' || c == '{':
+				depth++
+
+			case c == ']' || c == '}':
+				depth--
+			}
+			if c != ' ' && c != '\t' && c != '\r' {
+				endLine, endPos = l, i+1
+			}
+			i++
+		}
+		if depth <= 0 {
+			return
+		}
+	}
+	return
+}
+
+// findDocumentDelimiter finds the closing multi-line string `delimiter` starting at `pos` of
+// line `start` in `lines`, and returns the position after it.
+func findDocumentDelimiter(lines []string, start, pos int, delimiter string) (int, int) {
+	for l := start; l < len(lines); l++ {
+		line := lines[l]
+		i := 0
+		if l == start {
+			i = pos
+		}
+		for ; i < len(line); i++ {
+			if delimiter == `"""` && line[i] == '\\' {
+				i++
+				continue
+			}
+			if strings.HasPrefix(line[i:], delimiter) {
+				end := i + 3
+				// Up to two quotes are allowed right before the closing delimiter.
+				for n := 0; n < 2 && end < len(line) && line[end] == delimiter[0]; n++ {
+					end++
+				}
+				return l, end
+			}
+		}
+	}
+	return len(lines) - 1, len(lines[len(lines)-1])
+}
+
+// isBareKeyChar checks whether `c` is allowed in bare key.
+func isBareKeyChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '-'
+}
+
+// splitDocumentPattern splits `pattern` into keys, in which the index syntax like "a[0]" is
+// converted to "a.0". The returned `appending` is true if `pattern` ends with "[]".
+func splitDocumentPattern(pattern string) (keys []string, appending bool) {
+	if strings.HasSuffix(pattern, "[]") {
+		pattern = pattern[:len(pattern)-2]
+		appending = true
+	}
+	pattern = strings.NewReplacer("[", ".", "]", "").Replace(pattern)
+	for _, key := range strings.Split(pattern, ".") {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return
+}
+
+// formatDocumentKey formats `keys` as dotted key, in which the keys are quoted if necessary.
+func formatDocumentKey(keys []string) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key
+		if key == "" {
+			parts[i] = `""`
+			continue
+		}
+		for j := 0; j < len(key); j++ {
+			if !isBareKeyChar(key[j]) {
+				parts[i] = formatDocumentString(key)
+				break
+			}
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// formatDocumentString formats `s` as TOML basic string.
+func formatDocumentString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// formatDocumentValue formats `value` as TOML inline value.
+func formatDocumentValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", gerror.NewCode(gcode.CodeInvalidParameter, `nil value is not supported by TOML`)
+	case string:
+		return formatDocumentString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case json.Number:
+		return v.String(), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case *time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	}
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(reflectValue.Int(), 10), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(reflectValue.Uint(), 10), nil
+
+	case reflect.Float32, reflect.Float64:
+		f := reflectValue.Float()
+		switch {
+		case math.IsNaN(f):
+			return "nan", nil
+		case math.IsInf(f, 1):
+			return "inf", nil
+		case math.IsInf(f, -1):
+			return "-inf", nil
+		}
+		s := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eE") {
+			s += ".0"
+		}
+		return s, nil
+
+	case reflect.Slice, reflect.Array:
+		items := make([]string, reflectValue.Len())
+		for i := range items {
+			item, err := formatDocumentValue(reflectValue.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			items[i] = item
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+
+	case reflect.Map:
+		var (
+			keys  = reflectValue.MapKeys()
+			items = make([]string, 0, len(keys))
+			names = make([]string, len(keys))
+		)
+		for i, key := range keys {
+			names[i] = fmt.Sprint(key.Interface())
+		}
+		sort.Sort(documentKeys{names: names, keys: keys})
+		for i, key := range keys {
+			item, err := formatDocumentValue(reflectValue.MapIndex(key).Interface())
+			if err != nil {
+				return "", err
+			}
+			items = append(items, formatDocumentKey([]string{names[i]})+" = "+item)
+		}
+		if len(items) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(items, ", ") + " }", nil
+
+	case reflect.Ptr, reflect.Interface:
+		if reflectValue.IsNil() {
+			return formatDocumentValue(nil)
+		}
+		return formatDocumentValue(reflectValue.Elem().Interface())
+	}
+	// Other types like struct are converted using json.
+	var (
+		converted interface{}
+		b, err    = json.Marshal(value)
+	)
+	if err != nil {
+		return "", err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err = decoder.Decode(&converted); err != nil {
+		return "", err
+	}
+	return formatDocumentValue(converted)
+}
+
+// documentKeys sorts the map keys by their names.
+type documentKeys struct {
+	names []string
+	keys  []reflect.Value
+}
+
+func (k documentKeys) Len() int           { return len(k.names) }
+func (k documentKeys) Less(i, j int) bool { return k.names[i] < k.names[j] }
+func (k documentKeys) Swap(i, j int) {
+	k.names[i], k.names[j] = k.names[j], k.names[i]
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+}
+
+// getDocumentValue retrieves the value by `keys` from `data`.
+func getDocumentValue(data interface{}, keys []string) (interface{}, bool) {
+	for _, key := range keys {
+		switch v := data.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if data, ok = v[key]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, false
+			}
+			data = v[index]
+		case []map[string]interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, false
+			}
+			data = v[index]
+		default:
+			return nil, false
+		}
+	}
+	return data, true
+}
+
+// setDocumentValue sets `value` by `keys` in `data` and returns the modified `data`.
+// It deletes the value by `keys` if `value` is nil.
+func setDocumentValue(data interface{}, keys []string, value interface{}) (interface{}, error) {
+	if len(keys) == 0 {
+		return value, nil
+	}
+	key := keys[0]
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if value == nil && len(keys) == 1 {
+			delete(v, key)
+			return v, nil
+		}
+		item, err := setDocumentValue(v[key], keys[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		v[key] = item
+		return v, nil
+
+	case []interface{}:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index > len(v) || (index == len(v) && value == nil) {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid array index "%s"`, key)
+		}
+		if value == nil && len(keys) == 1 {
+			return append(v[:index], v[index+1:]...), nil
+		}
+		if index == len(v) {
+			v = append(v, nil)
+		}
+		item, err := setDocumentValue(v[index], keys[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		v[index] = item
+		return v, nil
+
+	case nil:
+		if value == nil {
+			return nil, nil
+		}
+		item, err := setDocumentValue(nil, keys[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{key: item}, nil
+	}
+	return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `cannot set key "%s" to value of type %T`, key, data)
+}
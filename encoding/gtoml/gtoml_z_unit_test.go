@@ -131,3 +131,157 @@ func TestToJson(t *testing.T) {
 		}
 	})
 }
+
+func Test_Document(t *testing.T) {
+	content := `# Global config.
+title = "demo" # the title
+
+# Server config.
+[server]
+    address = ":8000" # listening address
+    hosts = [
+        "a.com",
+        "b.com",
+    ]
+    limits = { max = 10, min = 1 }
+
+# Database config.
+[database]
+link = 'mysql://root@tcp(127.0.0.1)/test'
+`
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gtoml.LoadDocument([]byte(content))
+		t.AssertNil(err)
+		t.Assert(doc.Get("server.address"), ":8000")
+		t.Assert(doc.Get("server.limits.max"), 10)
+		t.Assert(doc.Contains("server.none"), false)
+
+		t.AssertNil(doc.Set("server.address", ":9000"))
+		t.AssertNil(doc.Set("server.hosts.2", "c.com"))
+		t.AssertNil(doc.Remove("server.hosts.0"))
+		t.AssertNil(doc.Set("server.limits.max", 20))
+		t.AssertNil(doc.Set("server.timeout", 30))
+		t.AssertNil(doc.Set("database.link", "mysql://root@tcp(127.0.0.1)/prod"))
+		t.AssertNil(doc.Set("version", "v1"))
+		t.AssertNil(doc.Set("redis.default.address", "127.0.0.1:6379"))
+		t.AssertNE(doc.Set("server.address", nil), nil)
+
+		out, err := doc.Encode()
+		t.AssertNil(err)
+		t.Assert(string(out), `# Global config.
+title = "demo" # the title
+version = "v1"
+
+# Server config.
+[server]
+    address = ":9000" # listening address
+    hosts = ["b.com", "c.com"]
+    limits = { max = 20, min = 1 }
+    timeout = 30
+
+# Database config.
+[database]
+link = 'mysql://root@tcp(127.0.0.1)/prod'
+
+[redis.default]
+address = "127.0.0.1:6379"
+`)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gtoml.LoadDocument([]byte(content))
+		t.AssertNil(err)
+		t.AssertNil(doc.Remove("server"))
+		t.AssertNil(doc.Remove("title"))
+		t.Assert(doc.Contains("server"), false)
+		t.Assert(doc.Get("database.link"), "mysql://root@tcp(127.0.0.1)/test")
+
+		out, err := doc.Encode()
+		t.AssertNil(err)
+		t.Assert(string(out), `# Global config.
+
+# Server config.
+
+# Database config.
+[database]
+link = 'mysql://root@tcp(127.0.0.1)/test'
+`)
+	})
+}
+
+func Test_Document_ArrayOfTables(t *testing.T) {
+	content := `[server]
+hosts = ["a", "b"]
+
+# Items.
+[[items]]
+name = "I1" # first
+
+[[items]]
+name = "I2"
+
+[items.meta]
+tag = "t2"
+
+[database]
+link = "mysql"
+`
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gtoml.LoadDocument([]byte(content))
+		t.AssertNil(err)
+		t.Assert(doc.Get("items.1.name"), "I2")
+		t.Assert(doc.Get("items[1].meta.tag"), "t2")
+
+		t.AssertNil(doc.Set("items.1.name", "I2-new"))
+		t.AssertNil(doc.Set("items.0.price", 10))
+		t.AssertNil(doc.Set("items[1].meta.tag", "t2-new"))
+		t.AssertNil(doc.Set("server.hosts[]", "c"))
+		t.AssertNil(doc.Set("server.ports[]", 80))
+		t.AssertNil(doc.Set("items[]", map[string]interface{}{"name": "I3"}))
+		t.Assert(doc.Get("items.1.name"), "I2-new")
+		t.Assert(doc.Get("server.hosts"), []interface{}{"a", "b", "c"})
+
+		out, err := doc.Encode()
+		t.AssertNil(err)
+		t.Assert(string(out), `[server]
+hosts = ["a", "b", "c"]
+ports = [80]
+
+# Items.
+[[items]]
+name = "I1" # first
+price = 10
+
+[[items]]
+name = "I2-new"
+
+[items.meta]
+tag = "t2-new"
+
+[[items]]
+name = "I3"
+
+[database]
+link = "mysql"
+`)
+	})
+	// Errors.
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gtoml.LoadDocument([]byte(content))
+		t.AssertNil(err)
+		t.AssertNE(doc.Set("items.5.name", "I6"), nil)
+		t.AssertNE(doc.Set("database.link[]", "c"), nil)
+		t.AssertNE(doc.Set("items[]", "I3"), nil)
+		t.AssertNE(doc.Remove("server.hosts[]"), nil)
+
+		out, err := doc.Encode()
+		t.AssertNil(err)
+		t.Assert(string(out), content)
+	})
+	// Removing element of array of tables.
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gtoml.LoadDocument([]byte(content))
+		t.AssertNil(err)
+		t.AssertNil(doc.Remove("items.1"))
+		t.Assert(doc.Get("items"), []map[string]interface{}{{"name": "I1"}})
+	})
+}
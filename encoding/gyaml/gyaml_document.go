@@ -0,0 +1,311 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gyaml
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	defaultDocumentIndent = 4 // Default indent spaces for encoding document.
+)
+
+// Document is a YAML document for round-trip editing, which preserves comments, key order
+// and scalar styles of the loaded content when it is modified and encoded, so that it is
+// suitable for tools editing configuration files of users.
+//
+// It supports hierarchical data access by pattern like gjson, eg: "server.address",
+// "server.hosts.0", "server.hosts[0]", and appending to sequence by pattern like "server.hosts[]".
+//
+// Note that the indentation is normalized to the one detected from the loaded content,
+// and the blank lines are not retained.
+type Document struct {
+	root   *yaml.Node // Document node.
+	indent int        // Indent spaces for encoding.
+}
+
+// LoadDocument loads `content` as Document for round-trip editing.
+func LoadDocument(content []byte) (*Document, error) {
+	var (
+		root yaml.Node
+		doc  = &Document{
+			root:   &root,
+			indent: detectIndent(content),
+		}
+	)
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		err = gerror.Wrap(err, `yaml.Unmarshal failed`)
+		return nil, err
+	}
+	if root.Kind == 0 {
+		root.Kind = yaml.DocumentNode
+	}
+	if len(root.Content) == 0 {
+		root.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	return doc, nil
+}
+
+// Get retrieves and returns the value by specified `pattern`.
+// It returns all values of the document if `pattern` is ".".
+// It returns nil if no value found by `pattern`.
+func (d *Document) Get(pattern string) interface{} {
+	keys, appending := splitDocumentPattern(pattern)
+	if appending {
+		return nil
+	}
+	node := d.root.Content[0]
+	for _, key := range keys {
+		if node = childNode(node, key); node == nil {
+			return nil
+		}
+	}
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil
+	}
+	return value
+}
+
+// Contains checks whether the value by specified `pattern` exists.
+func (d *Document) Contains(pattern string) bool {
+	keys, appending := splitDocumentPattern(pattern)
+	if appending {
+		return false
+	}
+	node := d.root.Content[0]
+	for _, key := range keys {
+		if node = childNode(node, key); node == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Set sets `value` by specified `pattern`, creating the missing nodes.
+// The comments and scalar style of the replaced node are retained.
+func (d *Document) Set(pattern string, value interface{}) error {
+	keys, appending := splitDocumentPattern(pattern)
+	if len(keys) == 0 && !appending {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern "%s" for setting`, pattern)
+	}
+	var newNode yaml.Node
+	if err := newNode.Encode(value); err != nil {
+		err = gerror.Wrap(err, `yaml.Node.Encode failed`)
+		return err
+	}
+	var (
+		parent = d.root.Content[0]
+		last   = len(keys)
+	)
+	if !appending {
+		last--
+	}
+	for i := 0; i < last; i++ {
+		node := childNode(parent, keys[i])
+		if node == nil {
+			var err error
+			nextIsIndex := appending && i == last-1
+			if i+1 < len(keys) {
+				nextIsIndex = isIndexKey(keys[i+1])
+			}
+			if node, err = addChildNode(parent, keys[i], newContainerNode(nextIsIndex)); err != nil {
+				return err
+			}
+		}
+		parent = node
+	}
+	if appending {
+		if parent.Kind != yaml.SequenceNode {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern "%s": value is not a sequence`, pattern)
+		}
+		parent.Content = append(parent.Content, &newNode)
+		return nil
+	}
+	key := keys[len(keys)-1]
+	if old := childNode(parent, key); old != nil {
+		retainNodeFormat(old, &newNode)
+		*old = newNode
+		return nil
+	}
+	_, err := addChildNode(parent, key, &newNode)
+	return err
+}
+
+// Remove deletes the value by specified `pattern`.
+// It does nothing if no value found by `pattern`.
+func (d *Document) Remove(pattern string) error {
+	keys, appending := splitDocumentPattern(pattern)
+	if len(keys) == 0 || appending {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid pattern "%s" for removing`, pattern)
+	}
+	parent := d.root.Content[0]
+	for _, key := range keys[:len(keys)-1] {
+		if parent = childNode(parent, key); parent == nil {
+			return nil
+		}
+	}
+	key := keys[len(keys)-1]
+	switch parent.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(parent.Content); i += 2 {
+			if parent.Content[i].Value == key {
+				parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+				return nil
+			}
+		}
+
+	case yaml.SequenceNode:
+		if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(parent.Content) {
+			parent.Content = append(parent.Content[:index], parent.Content[index+1:]...)
+		}
+	}
+	return nil
+}
+
+// Encode encodes the document to YAML content.
+func (d *Document) Encode() ([]byte, error) {
+	var (
+		buffer  = bytes.NewBuffer(nil)
+		encoder = yaml.NewEncoder(buffer)
+	)
+	encoder.SetIndent(d.indent)
+	if err := encoder.Encode(d.root); err != nil {
+		err = gerror.Wrap(err, `yaml.Encoder.Encode failed`)
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		err = gerror.Wrap(err, `yaml.Encoder.Close failed`)
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// splitDocumentPattern splits `pattern` into keys, in which the index syntax like "a[0]" is
+// converted to "a.0". The returned `appending` is true if `pattern` ends with "[]".
+func splitDocumentPattern(pattern string) (keys []string, appending bool) {
+	if strings.HasSuffix(pattern, "[]") {
+		pattern = pattern[:len(pattern)-2]
+		appending = true
+	}
+	pattern = strings.NewReplacer("[", ".", "]", "").Replace(pattern)
+	for _, key := range strings.Split(pattern, ".") {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return
+}
+
+// isIndexKey checks whether `key` is an index of sequence.
+func isIndexKey(key string) bool {
+	return key != "" && strings.Trim(key, "0123456789") == ""
+}
+
+// childNode returns the child node of `node` by `key`, which is map key or sequence index.
+func childNode(node *yaml.Node, key string) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1]
+			}
+		}
+
+	case yaml.SequenceNode:
+		if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(node.Content) {
+			return node.Content[index]
+		}
+
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			return childNode(node.Alias, key)
+		}
+	}
+	return nil
+}
+
+// addChildNode adds `child` to `node` by `key` and returns `child`.
+// The null node `node` is converted to mapping or sequence node according to `key`.
+// The sequence is padded with null nodes if `key` exceeds its length.
+func addChildNode(node *yaml.Node, key string, child *yaml.Node) (*yaml.Node, error) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null" {
+		*node = *newContainerNode(isIndexKey(key))
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		node.Content = append(node.Content, &yaml.Node{
+			Kind:  yaml.ScalarNode,
+			Tag:   "!!str",
+			Value: key,
+		}, child)
+		return child, nil
+
+	case yaml.SequenceNode:
+		if index, err := strconv.Atoi(key); err == nil && index >= 0 {
+			for len(node.Content) < index {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"})
+			}
+			node.Content = append(node.Content, child)
+			return child, nil
+		}
+	}
+	return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `cannot set key "%s" to value of tag "%s"`, key, node.ShortTag())
+}
+
+// newContainerNode creates and returns an empty sequence node if `sequence` is true, or else a mapping node.
+func newContainerNode(sequence bool) *yaml.Node {
+	if sequence {
+		return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	}
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+// retainNodeFormat retains the comments and scalar style of `old` node in `new` node that replaces it.
+func retainNodeFormat(old, new *yaml.Node) {
+	if new.HeadComment == "" {
+		new.HeadComment = old.HeadComment
+	}
+	if new.LineComment == "" {
+		new.LineComment = old.LineComment
+	}
+	if new.FootComment == "" {
+		new.FootComment = old.FootComment
+	}
+	if old.Kind == yaml.ScalarNode && new.Kind == yaml.ScalarNode && old.ShortTag() == new.ShortTag() {
+		new.Style = old.Style
+	}
+	if old.Kind == new.Kind && (old.Kind == yaml.MappingNode || old.Kind == yaml.SequenceNode) {
+		new.Style = old.Style
+	}
+}
+
+// detectIndent detects and returns the indent spaces of `content`, which is the smallest indentation
+// of its lines. It returns the default indent if no indented line found.
+func detectIndent(content []byte) int {
+	indent := 0
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || trimmed[0] == '#' || trimmed[0] == '-' {
+			continue
+		}
+		if n := len(line) - len(trimmed); n > 0 && (indent == 0 || n < indent) {
+			indent = n
+		}
+	}
+	if indent < 2 {
+		return defaultDocumentIndent
+	}
+	return indent
+}
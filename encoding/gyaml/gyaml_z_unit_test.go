@@ -165,3 +165,61 @@ func Test_ToJson(t *testing.T) {
 		}
 	})
 }
+
+func Test_Document(t *testing.T) {
+	content := `# Server config.
+server:
+  address: ":8000" # listening address
+  hosts:
+    - a.com
+    - b.com
+  name: 'demo'
+# Database config.
+database:
+  link: mysql://root@tcp(127.0.0.1)/test
+`
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gyaml.LoadDocument([]byte(content))
+		t.AssertNil(err)
+		t.Assert(doc.Get("server.address"), ":8000")
+		t.Assert(doc.Get("server.hosts[1]"), "b.com")
+		t.Assert(doc.Contains("server.none"), false)
+
+		t.AssertNil(doc.Set("server.address", ":9000"))
+		t.AssertNil(doc.Set("server.hosts[]", "c.com"))
+		t.AssertNil(doc.Remove("server.hosts[0]"))
+		t.AssertNil(doc.Set("server.name", "new"))
+		t.AssertNil(doc.Set("redis.default.address", "127.0.0.1:6379"))
+		t.AssertNil(doc.Set("list[0].id", 1))
+		t.AssertNE(doc.Set("server.address[]", 1), nil)
+
+		out, err := doc.Encode()
+		t.AssertNil(err)
+		t.Assert(string(out), `# Server config.
+server:
+  address: ":9000" # listening address
+  hosts:
+    - b.com
+    - c.com
+  name: 'new'
+# Database config.
+database:
+  link: mysql://root@tcp(127.0.0.1)/test
+redis:
+  default:
+    address: 127.0.0.1:6379
+list:
+  - id: 1
+`)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gyaml.LoadDocument(nil)
+		t.AssertNil(err)
+		t.AssertNil(doc.Set("a.b", 1))
+		t.AssertNil(doc.Remove("a.b"))
+		t.AssertNil(doc.Remove("none.key"))
+		out, err := doc.Encode()
+		t.AssertNil(err)
+		t.Assert(string(out), "a: {}\n")
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcode
+
+import "sync"
+
+// Status is the transport status mapping of an error code.
+type Status struct {
+	HttpStatus int // HTTP status code, eg: 404.
+	GrpcCode   int // gRPC status code, which is the value of google.golang.org/grpc/codes.Code, eg: 5 for NotFound.
+}
+
+const (
+	// defaultHttpStatus is the HTTP status for error code that has no mapping, which is 200 OK,
+	// as the custom business error codes are usually carried in the response body.
+	defaultHttpStatus = 200
+	// defaultGrpcCode is the gRPC code for error code that has no mapping, which is 2 Unknown.
+	defaultGrpcCode = 2
+)
+
+var (
+	// statusMu protects the status mapping registries.
+	statusMu sync.RWMutex
+
+	// statusMapping maps error code number to its transport status.
+	// Note that the gRPC codes are defined in numbers, as this package should not depend on gRPC.
+	statusMapping = map[int]Status{
+		CodeOK.Code():                       {HttpStatus: 200, GrpcCode: 0},  // OK.
+		CodeInternalError.Code():            {HttpStatus: 500, GrpcCode: 13}, // Internal.
+		CodeValidationFailed.Code():         {HttpStatus: 400, GrpcCode: 3},  // InvalidArgument.
+		CodeDbOperationError.Code():         {HttpStatus: 500, GrpcCode: 13}, // Internal.
+		CodeInvalidParameter.Code():         {HttpStatus: 400, GrpcCode: 3},  // InvalidArgument.
+		CodeMissingParameter.Code():         {HttpStatus: 400, GrpcCode: 3},  // InvalidArgument.
+		CodeInvalidOperation.Code():         {HttpStatus: 400, GrpcCode: 9},  // FailedPrecondition.
+		CodeInvalidConfiguration.Code():     {HttpStatus: 500, GrpcCode: 13}, // Internal.
+		CodeMissingConfiguration.Code():     {HttpStatus: 500, GrpcCode: 13}, // Internal.
+		CodeNotImplemented.Code():           {HttpStatus: 501, GrpcCode: 12}, // Unimplemented.
+		CodeNotSupported.Code():             {HttpStatus: 501, GrpcCode: 12}, // Unimplemented.
+		CodeOperationFailed.Code():          {HttpStatus: 500, GrpcCode: 13}, // Internal.
+		CodeNotAuthorized.Code():            {HttpStatus: 401, GrpcCode: 16}, // Unauthenticated.
+		CodeSecurityReason.Code():           {HttpStatus: 403, GrpcCode: 7},  // PermissionDenied.
+		CodeServerBusy.Code():               {HttpStatus: 503, GrpcCode: 14}, // Unavailable.
+		CodeUnknown.Code():                  {HttpStatus: 500, GrpcCode: 2},  // Unknown.
+		CodeNotFound.Code():                 {HttpStatus: 404, GrpcCode: 5},  // NotFound.
+		CodeInvalidRequest.Code():           {HttpStatus: 400, GrpcCode: 3},  // InvalidArgument.
+		CodeBusinessValidationFailed.Code(): {HttpStatus: 400, GrpcCode: 9},  // FailedPrecondition.
+	}
+
+	// httpStatusMapping maps HTTP status to error code, which is used for translating
+	// the HTTP status of response to error code.
+	httpStatusMapping = map[int]Code{
+		200: CodeOK,
+		401: CodeNotAuthorized,
+		403: CodeSecurityReason,
+		404: CodeNotFound,
+	}
+)
+
+// SetStatus sets or overrides the transport status mapping of error code `code`.
+// It is usually called in boot procedure for custom business error codes.
+func SetStatus(code Code, status Status) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	statusMapping[code.Code()] = status
+}
+
+// GetStatus returns the transport status mapping of error code `code`.
+// The second returned value reports whether `code` has mapping registered.
+func GetStatus(code Code) (status Status, ok bool) {
+	if code == nil {
+		return Status{}, false
+	}
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+	status, ok = statusMapping[code.Code()]
+	return
+}
+
+// HttpStatus returns the HTTP status of error code `code`.
+// It returns 200 if `code` has no mapping registered, use SetStatus to register the HTTP status
+// for custom business error codes.
+func HttpStatus(code Code) int {
+	if status, ok := GetStatus(code); ok && status.HttpStatus > 0 {
+		return status.HttpStatus
+	}
+	return defaultHttpStatus
+}
+
+// GrpcCode returns the gRPC status code of error code `code`.
+// It returns 2(Unknown) if `code` has no mapping registered.
+func GrpcCode(code Code) int {
+	if status, ok := GetStatus(code); ok {
+		return status.GrpcCode
+	}
+	return defaultGrpcCode
+}
+
+// SetHttpStatusCode sets or overrides the error code that HTTP status `httpStatus` is translated to.
+func SetHttpStatusCode(httpStatus int, code Code) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	httpStatusMapping[httpStatus] = code
+}
+
+// FromHttpStatus returns the error code that HTTP status `httpStatus` is translated to.
+// It returns CodeUnknown if `httpStatus` has no mapping registered.
+func FromHttpStatus(httpStatus int) Code {
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+	if code, ok := httpStatusMapping[httpStatus]; ok {
+		return code
+	}
+	return CodeUnknown
+}
@@ -21,3 +21,40 @@ func Test_Nil(t *testing.T) {
 		t.Assert(c.Detail(), "detailed description")
 	})
 }
+
+func Test_Status(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gcode.HttpStatus(gcode.CodeOK), 200)
+		t.Assert(gcode.HttpStatus(gcode.CodeNotFound), 404)
+		t.Assert(gcode.HttpStatus(gcode.CodeInvalidParameter), 400)
+		t.Assert(gcode.HttpStatus(gcode.CodeNotAuthorized), 401)
+		t.Assert(gcode.HttpStatus(gcode.CodeSecurityReason), 403)
+		t.Assert(gcode.HttpStatus(gcode.CodeInternalError), 500)
+		t.Assert(gcode.HttpStatus(gcode.CodeNil), 200)
+		t.Assert(gcode.HttpStatus(nil), 200)
+		t.Assert(gcode.HttpStatus(gcode.New(10000, "", nil)), 200)
+		t.Assert(gcode.GrpcCode(gcode.CodeOK), 0)
+		t.Assert(gcode.GrpcCode(gcode.CodeNotFound), 5)
+		t.Assert(gcode.GrpcCode(gcode.CodeServerBusy), 14)
+		t.Assert(gcode.GrpcCode(gcode.New(10000, "", nil)), 2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		code := gcode.New(10001, "Insufficient Balance", nil)
+		_, ok := gcode.GetStatus(code)
+		t.Assert(ok, false)
+		gcode.SetStatus(code, gcode.Status{HttpStatus: 402, GrpcCode: 9})
+		status, ok := gcode.GetStatus(gcode.WithCode(code, "detail"))
+		t.Assert(ok, true)
+		t.Assert(status.HttpStatus, 402)
+		t.Assert(gcode.HttpStatus(code), 402)
+		t.Assert(gcode.GrpcCode(code), 9)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gcode.FromHttpStatus(404), gcode.CodeNotFound)
+		t.Assert(gcode.FromHttpStatus(401), gcode.CodeNotAuthorized)
+		t.Assert(gcode.FromHttpStatus(403), gcode.CodeSecurityReason)
+		t.Assert(gcode.FromHttpStatus(418), gcode.CodeUnknown)
+		gcode.SetHttpStatusCode(418, gcode.CodeNotSupported)
+		t.Assert(gcode.FromHttpStatus(418), gcode.CodeNotSupported)
+	})
+}
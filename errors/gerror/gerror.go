@@ -56,3 +56,9 @@ type IUnwrap interface {
 	Error() string
 	Unwrap() error
 }
+
+// IFields is the interface for Fields feature.
+type IFields interface {
+	Error() string
+	Fields() map[string]interface{}
+}
@@ -0,0 +1,82 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror
+
+import (
+	"github.com/gogf/gf/v2/errors/gcode"
+)
+
+// WithFields wraps error with structured key-value context `fields`, which is retrievable by
+// function Fields from any wrapping layer of the returned error. It returns nil if given `err` is nil.
+// Note that it does not change the error text and error code of `err`.
+//
+// Eg: gerror.WithFields(err, g.Map{"order_id": orderId})
+func WithFields(err error, fields map[string]interface{}) error {
+	if err == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		copied[k] = v
+	}
+	return &Error{
+		error:  err,
+		stack:  callers(),
+		code:   gcode.CodeNil,
+		fields: copied,
+	}
+}
+
+// WithField wraps error with a single structured key-value context.
+// It returns nil if given `err` is nil.
+func WithField(err error, key string, value interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{
+		error:  err,
+		stack:  callers(),
+		code:   gcode.CodeNil,
+		fields: map[string]interface{}{key: value},
+	}
+}
+
+// Fields returns the structured key-value context attached to all levels of `err`,
+// in which the fields of outer level override the ones of inner level with the same key.
+// It returns nil if no field attached.
+func Fields(err error) map[string]interface{} {
+	var levels []map[string]interface{}
+	for loop := err; loop != nil; loop = Unwrap(loop) {
+		if e, ok := loop.(IFields); ok {
+			if fields := e.Fields(); len(fields) > 0 {
+				levels = append(levels, fields)
+			}
+		}
+	}
+	if len(levels) == 0 {
+		return nil
+	}
+	merged := make(map[string]interface{})
+	for i := len(levels) - 1; i >= 0; i-- {
+		for k, v := range levels[i] {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// Fields returns a copy of the structured key-value context attached to current level error.
+func (err *Error) Fields() map[string]interface{} {
+	if err == nil || len(err.fields) == 0 {
+		return nil
+	}
+	fields := make(map[string]interface{}, len(err.fields))
+	for k, v := range err.fields {
+		fields[k] = v
+	}
+	return fields
+}
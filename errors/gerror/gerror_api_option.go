@@ -10,19 +10,21 @@ import "github.com/gogf/gf/v2/errors/gcode"
 
 // Option is option for creating error.
 type Option struct {
-	Error error      // Wrapped error if any.
-	Stack bool       // Whether recording stack information into error.
-	Text  string     // Error text, which is created by New* functions.
-	Code  gcode.Code // Error code if necessary.
+	Error  error                  // Wrapped error if any.
+	Stack  bool                   // Whether recording stack information into error.
+	Text   string                 // Error text, which is created by New* functions.
+	Code   gcode.Code             // Error code if necessary.
+	Fields map[string]interface{} // Structured key-value context if necessary.
 }
 
 // NewOption creates and returns a custom error with Option.
 // It is the senior usage for creating error, which is often used internally in framework.
 func NewOption(option Option) error {
 	err := &Error{
-		error: option.Error,
-		text:  option.Text,
-		code:  option.Code,
+		error:  option.Error,
+		text:   option.Text,
+		code:   option.Code,
+		fields: option.Fields,
 	}
 	if option.Stack {
 		err.stack = callers()
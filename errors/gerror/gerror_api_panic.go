@@ -0,0 +1,71 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror
+
+import (
+	"fmt"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+)
+
+// Recover recovers from panic and stores the panic converted as error into `err`,
+// which records the stack of panic and retains the original panic value retrievable by PanicValue.
+// It does nothing if there's no panic. It must be called directly by defer statement, like:
+//
+//	func Handle() (err error) {
+//	    defer gerror.Recover(&err)
+//	    ...
+//	}
+//
+// Note that the panic is also recovered if `err` is nil, but the error is discarded.
+func Recover(err *error) {
+	if exception := recover(); exception != nil && err != nil {
+		*err = newPanicError(exception)
+	}
+}
+
+// Try calls function `fn` and returns the panic converted as error if any panic occurs in `fn`,
+// or else it returns nil. See Recover.
+func Try(fn func()) (err error) {
+	defer Recover(&err)
+	fn()
+	return
+}
+
+// IsPanic checks and reports whether `err` is converted from panic by Recover or Try.
+func IsPanic(err error) bool {
+	_, ok := PanicValue(err)
+	return ok
+}
+
+// PanicValue returns the original panic value of `err` converted from panic by Recover or Try.
+// The returned `ok` is false if `err` is not converted from panic.
+func PanicValue(err error) (value interface{}, ok bool) {
+	for loop := err; loop != nil; loop = Unwrap(loop) {
+		if e, isError := loop.(*Error); isError && e.panic != nil {
+			return e.panic, true
+		}
+	}
+	return nil, false
+}
+
+// newPanicError creates and returns an error from panic value `exception`, which records the stack
+// of its caller. The error wraps `exception` if it is an error, so that its text, code and stack
+// are retained, or else the error text is the formatted `exception`.
+func newPanicError(exception interface{}) error {
+	err := &Error{
+		stack: callers(1),
+		code:  gcode.CodeNil,
+		panic: exception,
+	}
+	if v, ok := exception.(error); ok {
+		err.error = v
+	} else {
+		err.text = fmt.Sprintf(`%+v`, exception)
+	}
+	return err
+}
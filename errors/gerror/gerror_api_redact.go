@@ -0,0 +1,73 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RedactFunc is the hook function that redacts the error message `message` of `err`
+// before it is serialized to outside, like the response of HTTP server.
+// It returns the redacted message.
+type RedactFunc func(err error, message string) string
+
+var (
+	// redactMu protects redactFuncs.
+	redactMu sync.RWMutex
+
+	// redactFuncs are the registered redaction hooks, which are called in registering order.
+	redactFuncs []RedactFunc
+
+	// filePathRegex matches the absolute file paths in text, like "/home/john/app/main.go" or "C:\app\main.go".
+	filePathRegex = regexp.MustCompile(`(^|[\s"'(=\[])((?:[A-Za-z]:)?(?:[\\/][\w.\-@+]+){2,})`)
+)
+
+// AddRedactFunc registers redaction hook `f`, which is called by Redact.
+func AddRedactFunc(f RedactFunc) {
+	redactMu.Lock()
+	defer redactMu.Unlock()
+	redactFuncs = append(redactFuncs, f)
+}
+
+// ClearRedactFuncs removes all the registered redaction hooks.
+func ClearRedactFuncs() {
+	redactMu.Lock()
+	defer redactMu.Unlock()
+	redactFuncs = nil
+}
+
+// Redact returns the error message of `err` that is redacted by all the registered redaction hooks.
+// It returns the error message directly if no hook registered, and an empty string if `err` is nil.
+//
+// It should be used when the error message is serialized to outside, like the response of HTTP server.
+func Redact(err error) string {
+	if err == nil {
+		return ""
+	}
+	redactMu.RLock()
+	funcs := redactFuncs
+	redactMu.RUnlock()
+	message := err.Error()
+	for _, f := range funcs {
+		message = f(err, message)
+	}
+	return message
+}
+
+// RedactFilePath is a RedactFunc that replaces the absolute file paths in `message` with their base names,
+// which avoids leaking the internal directory structure of server.
+//
+// Eg: "open /home/john/app/config.yaml: no such file" is redacted to "open config.yaml: no such file".
+func RedactFilePath(err error, message string) string {
+	return filePathRegex.ReplaceAllStringFunc(message, func(s string) string {
+		match := filePathRegex.FindStringSubmatch(s)
+		return match[1] + path.Base(strings.Replace(match[2], "\\", "/", -1))
+	})
+}
@@ -106,7 +106,11 @@ func HasError(err, target error) bool {
 
 // callers returns the stack callers.
 // Note that it here just retrieves the caller memory address array not the caller information.
+// It returns nil if the stack mode is StackModeDisabled.
 func callers(skip ...int) stack {
+	if GetStackMode() == StackModeDisabled {
+		return nil
+	}
 	var (
 		pcs [maxStackDepth]uintptr
 		n   = 3
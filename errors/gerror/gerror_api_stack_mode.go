@@ -0,0 +1,80 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/gogf/gf/v2/internal/command"
+)
+
+// StackMode is the mode for capturing and printing stack of errors.
+type StackMode string
+
+const (
+	// StackModeFull captures and prints all stack frames except the ones of GOROOT, which is the default mode.
+	StackModeFull StackMode = "full"
+
+	// StackModeBrief prints only the stack frames of project packages, that is,
+	// the frames of framework packages and third-party module packages are trimmed.
+	StackModeBrief StackMode = "brief"
+
+	// StackModeDisabled does not capture stack for errors, which is also the most performant mode.
+	StackModeDisabled StackMode = "disabled"
+)
+
+const (
+	// commandEnvKeyForStackMode is the command option or environment key for stack mode.
+	commandEnvKeyForStackMode = "gf.gerror.stack.mode"
+
+	// Filtering key for framework package names.
+	stackFilterKeyFramework = "github.com/gogf/gf/"
+
+	// Filtering key for third-party module paths in module cache.
+	stackFilterKeyModCache = "/pkg/mod/"
+)
+
+var (
+	// stackMode is the current stack mode, which is configurable by command option or environment.
+	stackMode atomic.Value
+)
+
+func init() {
+	mode := StackMode(strings.ToLower(command.GetOptWithEnv(commandEnvKeyForStackMode)))
+	switch mode {
+	case StackModeBrief, StackModeDisabled:
+		stackMode.Store(mode)
+	default:
+		stackMode.Store(StackModeFull)
+	}
+}
+
+// SetStackMode sets the stack mode for all errors.
+// Note that it affects only the errors created after the setting if `mode` is StackModeDisabled,
+// as the stack of errors is captured when they are created.
+func SetStackMode(mode StackMode) {
+	switch mode {
+	case StackModeBrief, StackModeDisabled:
+	default:
+		mode = StackModeFull
+	}
+	stackMode.Store(mode)
+}
+
+// GetStackMode returns the current stack mode.
+func GetStackMode() StackMode {
+	return stackMode.Load().(StackMode)
+}
+
+// isBriefStackFiltered checks and returns whether the stack frame should be trimmed in brief stack mode.
+func isBriefStackFiltered(funcName, file string) bool {
+	if strings.HasPrefix(funcName, stackFilterKeyFramework) {
+		return true
+	}
+	return strings.Contains(strings.Replace(file, "\\", "/", -1), stackFilterKeyModCache)
+}
@@ -17,10 +17,12 @@ import (
 
 // Error is custom error for additional features.
 type Error struct {
-	error error      // Wrapped error.
-	stack stack      // Stack array, which records the stack information when this error is created or wrapped.
-	text  string     // Custom Error text when Error is created, might be empty when its code is not nil.
-	code  gcode.Code // Error code if necessary.
+	error  error                  // Wrapped error.
+	stack  stack                  // Stack array, which records the stack information when this error is created or wrapped.
+	text   string                 // Custom Error text when Error is created, might be empty when its code is not nil.
+	code   gcode.Code             // Error code if necessary.
+	fields map[string]interface{} // Structured key-value context attached to this level, retrievable by Fields.
+	panic  interface{}            // Original panic value if this error is converted from panic, retrievable by PanicValue.
 }
 
 const (
@@ -91,10 +93,11 @@ func (err *Error) Current() error {
 		return nil
 	}
 	return &Error{
-		error: nil,
-		stack: err.stack,
-		text:  err.text,
-		code:  err.code,
+		error:  nil,
+		stack:  err.stack,
+		text:   err.text,
+		code:   err.code,
+		fields: err.fields,
 	}
 }
 
@@ -47,8 +47,11 @@ func formatSubStack(st stack, buffer *bytes.Buffer) {
 	if st == nil {
 		return
 	}
-	index := 1
-	space := "  "
+	var (
+		index = 1
+		space = "  "
+		brief = GetStackMode() == StackModeBrief
+	)
 	for _, p := range st {
 		if fn := runtime.FuncForPC(p - 1); fn != nil {
 			file, line := fn.FileLine(p - 1)
@@ -66,6 +69,10 @@ func formatSubStack(st stack, buffer *bytes.Buffer) {
 				file[0:len(goRootForFilter)] == goRootForFilter {
 				continue
 			}
+			// Trim the frames of framework and third-party packages in brief mode.
+			if brief && isBriefStackFiltered(fn.Name(), file) {
+				continue
+			}
 			// Graceful indent.
 			if index > 9 {
 				space = " "
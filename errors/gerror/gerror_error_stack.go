@@ -9,6 +9,7 @@ package gerror
 import (
 	"bytes"
 	"fmt"
+	"sort"
 )
 
 // Stack returns the stack callers as string.
@@ -23,7 +24,15 @@ func (err *Error) Stack() string {
 		buffer = bytes.NewBuffer(nil)
 	)
 	for loop != nil {
-		buffer.WriteString(fmt.Sprintf("%d. %-v\n", index, loop))
+		switch {
+		case len(loop.fields) > 0 && loop.text == "":
+			// Level only for attaching fields.
+			buffer.WriteString(fmt.Sprintf("%d. %s\n", index, formatFields(loop.fields)))
+		case len(loop.fields) > 0:
+			buffer.WriteString(fmt.Sprintf("%d. %-v %s\n", index, loop, formatFields(loop.fields)))
+		default:
+			buffer.WriteString(fmt.Sprintf("%d. %-v\n", index, loop))
+		}
 		index++
 		formatSubStack(loop.stack, buffer)
 		if loop.error != nil {
@@ -40,3 +49,21 @@ func (err *Error) Stack() string {
 	}
 	return buffer.String()
 }
+
+// formatFields formats `fields` as string like "{k1: v1, k2: v2}" with keys in ascending order.
+func formatFields(fields map[string]interface{}) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	buffer := bytes.NewBufferString("{")
+	for i, k := range keys {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(fmt.Sprintf("%s: %v", k, fields[k]))
+	}
+	buffer.WriteString("}")
+	return buffer.String()
+}
@@ -0,0 +1,186 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+)
+
+// MultiError is an error that aggregates multiple errors, which is usually used for collecting
+// errors from parallel operations. It is concurrent-safe, so the errors can be appended from
+// different goroutines.
+//
+// It supports stdlib errors.Is and errors.As, which check every aggregated error.
+type MultiError struct {
+	mu     sync.RWMutex
+	errors []error // Aggregated errors, which contain no nil error.
+}
+
+// Join aggregates `errs` into a MultiError, in which the nil errors are ignored.
+// The errors of any MultiError in `errs` are aggregated directly instead of the MultiError itself.
+// It returns nil if there's no error in `errs`.
+func Join(errs ...error) error {
+	multi := NewMultiError()
+	multi.Append(errs...)
+	return multi.ErrorOrNil()
+}
+
+// NewMultiError creates and returns an empty MultiError.
+func NewMultiError() *MultiError {
+	return &MultiError{}
+}
+
+// Append appends `errs` to the MultiError, in which the nil errors are ignored.
+func (err *MultiError) Append(errs ...error) {
+	err.mu.Lock()
+	defer err.mu.Unlock()
+	for _, e := range errs {
+		if e == nil {
+			continue
+		}
+		if multi, ok := e.(*MultiError); ok {
+			if multi != err {
+				err.errors = append(err.errors, multi.Errors()...)
+			}
+			continue
+		}
+		err.errors = append(err.errors, e)
+	}
+}
+
+// Errors returns a copy of the aggregated errors.
+func (err *MultiError) Errors() []error {
+	if err == nil {
+		return nil
+	}
+	err.mu.RLock()
+	defer err.mu.RUnlock()
+	errs := make([]error, len(err.errors))
+	copy(errs, err.errors)
+	return errs
+}
+
+// Len returns the count of the aggregated errors.
+func (err *MultiError) Len() int {
+	if err == nil {
+		return 0
+	}
+	err.mu.RLock()
+	defer err.mu.RUnlock()
+	return len(err.errors)
+}
+
+// ErrorOrNil returns the MultiError itself as error, or nil if it has no error aggregated.
+// It is useful for returning the MultiError as error, as a nil *MultiError is not a nil error.
+func (err *MultiError) ErrorOrNil() error {
+	if err.Len() == 0 {
+		return nil
+	}
+	return err
+}
+
+// Error implements the interface of Error, it returns all the aggregated error strings.
+func (err *MultiError) Error() string {
+	errs := err.Errors()
+	switch len(errs) {
+	case 0:
+		return ""
+	case 1:
+		return errs[0].Error()
+	}
+	texts := make([]string, len(errs))
+	for i, e := range errs {
+		texts[i] = e.Error()
+	}
+	return fmt.Sprintf(`%d errors occurred: %s`, len(errs), strings.Join(texts, "; "))
+}
+
+// Code returns the first error code of the aggregated errors that is not CodeNil.
+func (err *MultiError) Code() gcode.Code {
+	for _, e := range err.Errors() {
+		if code := Code(e); code != gcode.CodeNil {
+			return code
+		}
+	}
+	return gcode.CodeNil
+}
+
+// Unwrap returns the aggregated errors.
+// It is just for implements for stdlib errors.Is/As from Go version 1.20.
+func (err *MultiError) Unwrap() []error {
+	return err.Errors()
+}
+
+// Is reports whether any of the aggregated errors has error `target` in its chaining errors.
+// It is just for implements for stdlib errors.Is.
+func (err *MultiError) Is(target error) bool {
+	for _, e := range err.Errors() {
+		if e == target || Equal(e, target) || errors.Is(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first aggregated error that matches `target`, and if so, sets `target` to that error value
+// and returns true. It is just for implements for stdlib errors.As.
+func (err *MultiError) As(target interface{}) bool {
+	for _, e := range err.Errors() {
+		if errors.As(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Stack returns the consolidated stack of all the aggregated errors as string.
+func (err *MultiError) Stack() string {
+	var (
+		errs   = err.Errors()
+		buffer = bytes.NewBuffer(nil)
+	)
+	for i, e := range errs {
+		buffer.WriteString(fmt.Sprintf("Error %d/%d: %s\n", i+1, len(errs), e.Error()))
+		if HasStack(e) {
+			buffer.WriteString(Stack(e))
+		}
+	}
+	return buffer.String()
+}
+
+// Format formats the frame according to the fmt.Formatter interface.
+//
+// %v, %s   : Print all the error string;
+// %+s      : Print consolidated stack of all errors;
+// %+v      : Print the error string and consolidated stack of all errors;
+func (err *MultiError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v':
+		switch {
+		case s.Flag('+'):
+			if verb == 's' {
+				_, _ = io.WriteString(s, err.Stack())
+			} else {
+				_, _ = io.WriteString(s, err.Error()+"\n"+err.Stack())
+			}
+		default:
+			_, _ = io.WriteString(s, err.Error())
+		}
+	}
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+func (err *MultiError) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`%q`, err.Error())), nil
+}
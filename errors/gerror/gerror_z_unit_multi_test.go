@@ -0,0 +1,123 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Join(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(gerror.Join())
+		t.AssertNil(gerror.Join(nil, nil))
+		t.AssertNil(gerror.NewMultiError().ErrorOrNil())
+	})
+	gtest.C(t, func(t *gtest.T) {
+		err := gerror.Join(nil, errors.New("1"))
+		t.Assert(err.Error(), "1")
+		err = gerror.Join(errors.New("1"), nil, gerror.New("2"))
+		t.Assert(err.Error(), "2 errors occurred: 1; 2")
+		t.Assert(fmt.Sprintf("%v", err), "2 errors occurred: 1; 2")
+		// Nested MultiError is flattened.
+		err = gerror.Join(err, gerror.Join(errors.New("3"), errors.New("4")))
+		t.Assert(err.(*gerror.MultiError).Len(), 4)
+		t.Assert(err.Error(), "4 errors occurred: 1; 2; 3; 4")
+	})
+}
+
+func Test_MultiError_Append(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg    sync.WaitGroup
+			multi = gerror.NewMultiError()
+		)
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if i%2 == 0 {
+					multi.Append(gerror.Newf("%d", i))
+				} else {
+					multi.Append(nil)
+				}
+			}(i)
+		}
+		wg.Wait()
+		t.Assert(multi.Len(), 50)
+		t.Assert(len(multi.Errors()), 50)
+		t.AssertNE(multi.ErrorOrNil(), nil)
+	})
+}
+
+func Test_MultiError_IsAs(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			errNotFound = errors.New("not found")
+			pathErr     = &os.PathError{Op: "open", Path: "/tmp", Err: os.ErrNotExist}
+			err         = gerror.Join(
+				gerror.New("1"),
+				gerror.Wrap(errNotFound, "query failed"),
+				gerror.Wrap(pathErr, "read failed"),
+			)
+		)
+		t.Assert(errors.Is(err, errNotFound), true)
+		t.Assert(errors.Is(err, os.ErrNotExist), true)
+		t.Assert(errors.Is(err, os.ErrExist), false)
+		t.Assert(gerror.Is(err, errNotFound), true)
+
+		var target *os.PathError
+		t.Assert(errors.As(err, &target), true)
+		t.Assert(target.Path, "/tmp")
+
+		// Wrapped MultiError.
+		wrapped := gerror.Wrap(err, "batch failed")
+		t.Assert(errors.Is(wrapped, errNotFound), true)
+		t.Assert(gerror.Is(wrapped, errNotFound), true)
+	})
+}
+
+func Test_MultiError_Code(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		err := gerror.Join(
+			errors.New("1"),
+			gerror.NewCode(gcode.CodeNotFound, "2"),
+			gerror.NewCode(gcode.CodeInvalidParameter, "3"),
+		)
+		t.Assert(gerror.Code(err), gcode.CodeNotFound)
+		t.Assert(gerror.Code(gerror.Wrap(err, "4")), gcode.CodeNotFound)
+		t.Assert(gerror.Code(gerror.Join(errors.New("1"))), gcode.CodeNil)
+	})
+}
+
+func Test_MultiError_Stack(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		err := gerror.Join(errors.New("1"), gerror.New("2"))
+		t.Assert(gerror.HasStack(err), true)
+		stack := fmt.Sprintf("%+s", err)
+		t.Assert(strings.Contains(stack, "Error 1/2: 1\n"), true)
+		t.Assert(strings.Contains(stack, "Error 2/2: 2\n1. 2\n"), true)
+		t.Assert(fmt.Sprintf("%+v", err), err.Error()+"\n"+stack)
+	})
+}
+
+func Test_MultiError_Json(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		b, err := json.Marshal(gerror.Join(errors.New(`"1"`), errors.New("2")))
+		t.AssertNil(err)
+		t.Assert(string(b), `"2 errors occurred: \"1\"; 2"`)
+	})
+}
@@ -9,6 +9,7 @@ package gerror_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/gogf/gf/v2/errors/gcode"
@@ -385,3 +386,142 @@ func Test_HashCode(t *testing.T) {
 		t.Assert(gerror.HasCode(err4, gcode.CodeNotAuthorized), true)
 	})
 }
+
+func Test_Fields(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(gerror.WithFields(nil, map[string]interface{}{"k": "v"}))
+		t.AssertNil(gerror.WithField(nil, "k", "v"))
+		t.AssertNil(gerror.Fields(nil))
+		t.AssertNil(gerror.Fields(errors.New("1")))
+		t.AssertNil(gerror.Fields(gerror.New("1")))
+	})
+	gtest.C(t, func(t *gtest.T) {
+		err := gerror.NewCode(gcode.CodeNotFound, "1")
+		err = gerror.WithFields(err, map[string]interface{}{"order_id": 1, "user_id": 2})
+		err = gerror.Wrap(err, "2")
+		err = gerror.WithField(err, "order_id", 3)
+		err = gerror.Wrap(err, "3")
+		t.Assert(err.Error(), "3: 2: 1")
+		t.Assert(gerror.Code(err), gcode.CodeNotFound)
+		t.Assert(gerror.Fields(err), map[string]interface{}{"order_id": 3, "user_id": 2})
+		t.Assert(gerror.Fields(gerror.Cause(err)), nil)
+
+		stack := gerror.Stack(err)
+		t.Assert(strings.Contains(stack, "2. {order_id: 3}\n"), true)
+		t.Assert(strings.Contains(stack, "4. {order_id: 1, user_id: 2}\n"), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		fields := map[string]interface{}{"k": "v"}
+		err := gerror.WithFields(errors.New("1"), fields)
+		fields["k"] = "changed"
+		t.Assert(gerror.Fields(err), map[string]interface{}{"k": "v"})
+		t.Assert(gerror.Fields(gerror.Current(err)), map[string]interface{}{"k": "v"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		err := gerror.NewOption(gerror.Option{
+			Text:   "1",
+			Fields: map[string]interface{}{"k": "v"},
+		})
+		t.Assert(gerror.Fields(err), map[string]interface{}{"k": "v"})
+		t.Assert(gerror.Stack(err), "1. 1 {k: v}\n")
+	})
+}
+
+func Test_StackMode(t *testing.T) {
+	defer gerror.SetStackMode(gerror.StackModeFull)
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gerror.GetStackMode(), gerror.StackModeFull)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		gerror.SetStackMode(gerror.StackModeDisabled)
+		t.Assert(gerror.GetStackMode(), gerror.StackModeDisabled)
+		err := gerror.Wrap(gerror.New("1"), "2")
+		t.Assert(gerror.Stack(err), "1. 2\n2. 1\n")
+		t.Assert(fmt.Sprintf("%+v", err), "2: 1\n1. 2\n2. 1\n")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		gerror.SetStackMode(gerror.StackModeBrief)
+		t.Assert(gerror.GetStackMode(), gerror.StackModeBrief)
+		// All frames are of framework packages.
+		err := gerror.Wrap(gerror.New("1"), "2")
+		t.Assert(gerror.Stack(err), "1. 2\n2. 1\n")
+		gerror.SetStackMode(gerror.StackModeFull)
+		t.AssertNE(gerror.Stack(err), "1. 2\n2. 1\n")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		gerror.SetStackMode("unknown")
+		t.Assert(gerror.GetStackMode(), gerror.StackModeFull)
+	})
+}
+
+func Test_Redact(t *testing.T) {
+	defer gerror.ClearRedactFuncs()
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gerror.Redact(nil), "")
+		t.Assert(gerror.Redact(gerror.New("open /app/config.yaml failed")), "open /app/config.yaml failed")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		gerror.AddRedactFunc(gerror.RedactFilePath)
+		gerror.AddRedactFunc(func(err error, message string) string {
+			if gerror.Code(err) == gcode.CodeDbOperationError {
+				return gcode.CodeDbOperationError.Message()
+			}
+			return message
+		})
+		t.Assert(
+			gerror.Redact(gerror.New(`open /home/john/app/config.yaml: no such file`)),
+			`open config.yaml: no such file`,
+		)
+		t.Assert(
+			gerror.Redact(gerror.New(`read "C:\app\data\a.txt" failed`)),
+			`read "a.txt" failed`,
+		)
+		t.Assert(
+			gerror.Redact(gerror.New(`request http://goframe.org/a/b failed`)),
+			`request http://goframe.org/a/b failed`,
+		)
+		t.Assert(
+			gerror.Redact(gerror.NewCode(gcode.CodeDbOperationError, "table user not found")),
+			`Database Operation Error`,
+		)
+		gerror.ClearRedactFuncs()
+		t.Assert(gerror.Redact(gerror.New("/a/b")), "/a/b")
+	})
+}
+
+func Test_Panic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		err := gerror.Try(func() {})
+		t.AssertNil(err)
+		t.Assert(gerror.IsPanic(err), false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		err := gerror.Try(func() {
+			panic("boom")
+		})
+		t.AssertNE(err, nil)
+		t.Assert(err.Error(), "boom")
+		t.Assert(gerror.IsPanic(err), true)
+		t.Assert(gerror.HasStack(err), true)
+		value, ok := gerror.PanicValue(err)
+		t.Assert(ok, true)
+		t.Assert(value, "boom")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			origin = gerror.NewCode(gcode.CodeNotFound, "not found")
+			fn     = func() (err error) {
+				defer gerror.Recover(&err)
+				panic(origin)
+			}
+			err = fn()
+		)
+		t.Assert(err.Error(), "not found")
+		t.Assert(gerror.Code(err), gcode.CodeNotFound)
+		t.Assert(gerror.Is(err, origin), true)
+		value, ok := gerror.PanicValue(gerror.Wrap(err, "wrapped"))
+		t.Assert(ok, true)
+		t.Assert(value, origin)
+		t.Assert(gerror.IsPanic(origin), false)
+	})
+}
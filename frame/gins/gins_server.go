@@ -13,6 +13,7 @@ import (
 	"github.com/gogf/gf/v2/internal/consts"
 	"github.com/gogf/gf/v2/internal/intlog"
 	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gcfg"
 	"github.com/gogf/gf/v2/util/gconv"
 	"github.com/gogf/gf/v2/util/gutil"
 )
@@ -38,34 +39,7 @@ func Server(name ...interface{}) *ghttp.Server {
 		server := ghttp.GetServer(instanceName)
 		if Config().Available(ctx) {
 			// Server initialization from configuration.
-			var (
-				configMap             map[string]interface{}
-				serverConfigMap       map[string]interface{}
-				serverLoggerConfigMap map[string]interface{}
-				configNodeName        string
-			)
-			if configMap, err = Config().Data(ctx); err != nil {
-				intlog.Errorf(ctx, `retrieve config data map failed: %+v`, err)
-			}
-			// Find possible server configuration item by possible names.
-			if len(configMap) > 0 {
-				if v, _ := gutil.MapPossibleItemByKey(configMap, consts.ConfigNodeNameServer); v != "" {
-					configNodeName = v
-				}
-				if configNodeName == "" {
-					if v, _ := gutil.MapPossibleItemByKey(configMap, consts.ConfigNodeNameServerSecondary); v != "" {
-						configNodeName = v
-					}
-				}
-			}
-			// Automatically retrieve configuration by instance name.
-			serverConfigMap = Config().MustGet(
-				ctx,
-				fmt.Sprintf(`%s.%s`, configNodeName, instanceName),
-			).Map()
-			if len(serverConfigMap) == 0 {
-				serverConfigMap = Config().MustGet(ctx, configNodeName).Map()
-			}
+			serverConfigMap, serverLoggerConfigMap := getServerConfigMaps(ctx, instanceName)
 			if len(serverConfigMap) > 0 {
 				if err = server.SetConfigWithMap(serverConfigMap); err != nil {
 					panic(err)
@@ -78,19 +52,17 @@ func Server(name ...interface{}) *ghttp.Server {
 					instanceName,
 				)
 			}
-			// Server logger configuration checks.
-			serverLoggerConfigMap = Config().MustGet(
-				ctx,
-				fmt.Sprintf(`%s.%s.%s`, configNodeName, instanceName, consts.ConfigNodeNameLogger),
-			).Map()
-			if len(serverLoggerConfigMap) == 0 && len(serverConfigMap) > 0 {
-				serverLoggerConfigMap = gconv.Map(serverConfigMap[consts.ConfigNodeNameLogger])
-			}
 			if len(serverLoggerConfigMap) > 0 {
 				if err = server.Logger().SetConfigWithMap(serverLoggerConfigMap); err != nil {
 					panic(err)
 				}
 			}
+			// Server configuration reloading when configuration file changes.
+			if adapter, ok := Config().GetAdapter().(*gcfg.AdapterFile); ok {
+				adapter.AddWatcher(instanceKey, func(ctx context.Context, file string) {
+					reloadServerConfig(ctx, server, instanceName)
+				})
+			}
 		}
 		// The server name is necessary. It sets a default server name is it is not configured.
 		if server.GetName() == "" || server.GetName() == ghttp.DefaultServerName {
@@ -102,3 +74,61 @@ func Server(name ...interface{}) *ghttp.Server {
 		return server
 	}).(*ghttp.Server)
 }
+
+// getServerConfigMaps retrieves and returns the server configuration map and server logger configuration map
+// of server `instanceName` from configuration component.
+func getServerConfigMaps(ctx context.Context, instanceName string) (serverConfigMap, serverLoggerConfigMap map[string]interface{}) {
+	var (
+		err            error
+		configMap      map[string]interface{}
+		configNodeName string
+	)
+	if configMap, err = Config().Data(ctx); err != nil {
+		intlog.Errorf(ctx, `retrieve config data map failed: %+v`, err)
+	}
+	// Find possible server configuration item by possible names.
+	if len(configMap) > 0 {
+		if v, _ := gutil.MapPossibleItemByKey(configMap, consts.ConfigNodeNameServer); v != "" {
+			configNodeName = v
+		}
+		if configNodeName == "" {
+			if v, _ := gutil.MapPossibleItemByKey(configMap, consts.ConfigNodeNameServerSecondary); v != "" {
+				configNodeName = v
+			}
+		}
+	}
+	// Automatically retrieve configuration by instance name.
+	serverConfigMap = Config().MustGet(
+		ctx,
+		fmt.Sprintf(`%s.%s`, configNodeName, instanceName),
+	).Map()
+	if len(serverConfigMap) == 0 {
+		serverConfigMap = Config().MustGet(ctx, configNodeName).Map()
+	}
+	// Server logger configuration checks.
+	serverLoggerConfigMap = Config().MustGet(
+		ctx,
+		fmt.Sprintf(`%s.%s.%s`, configNodeName, instanceName, consts.ConfigNodeNameLogger),
+	).Map()
+	if len(serverLoggerConfigMap) == 0 && len(serverConfigMap) > 0 {
+		serverLoggerConfigMap = gconv.Map(serverConfigMap[consts.ConfigNodeNameLogger])
+	}
+	return
+}
+
+// reloadServerConfig reloads the runtime reloadable configurations of `server` from configuration component,
+// which is called when configuration file changes.
+func reloadServerConfig(ctx context.Context, server *ghttp.Server, instanceName string) {
+	serverConfigMap, serverLoggerConfigMap := getServerConfigMaps(ctx, instanceName)
+	if len(serverConfigMap) > 0 {
+		if err := server.ReloadConfigWithMap(serverConfigMap); err != nil {
+			intlog.Errorf(ctx, `reload configuration for HTTP server "%s" failed: %+v`, instanceName, err)
+			return
+		}
+	}
+	if len(serverLoggerConfigMap) > 0 {
+		if err := server.Logger().SetConfigWithMap(serverLoggerConfigMap); err != nil {
+			intlog.Errorf(ctx, `reload logger configuration for HTTP server "%s" failed: %+v`, instanceName, err)
+		}
+	}
+}
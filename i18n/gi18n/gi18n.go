@@ -50,3 +50,40 @@ func Translate(ctx context.Context, content string) string {
 func GetContent(ctx context.Context, key string) string {
 	return Instance().GetContent(ctx, key)
 }
+
+// Tp is alias of TranslateParams for convenience.
+func Tp(ctx context.Context, key string, params map[string]interface{}) string {
+	return Instance().TranslateParams(ctx, key, params)
+}
+
+// Tn is alias of TranslatePlural for convenience.
+func Tn(ctx context.Context, key string, count interface{}, params ...map[string]interface{}) string {
+	return Instance().TranslatePlural(ctx, key, count, params...)
+}
+
+// TranslateParams translates `key` with configured language, and replaces the named parameters
+// of the translated message with `params`.
+func TranslateParams(ctx context.Context, key string, params map[string]interface{}) string {
+	return Instance().TranslateParams(ctx, key, params)
+}
+
+// TranslatePlural translates `key` with the plural form for number `count` in configured language,
+// and replaces the named parameters of the translated message with `params` and `count`.
+func TranslatePlural(ctx context.Context, key string, count interface{}, params ...map[string]interface{}) string {
+	return Instance().TranslatePlural(ctx, key, count, params...)
+}
+
+// SetMissingHandler sets the hook function `handler` called when translation is missing.
+func SetMissingHandler(handler MissingHandler) {
+	Instance().SetMissingHandler(handler)
+}
+
+// GetMissing returns a copy of the recorded missing keys and their counts of each language.
+func GetMissing() map[string]map[string]int {
+	return Instance().GetMissing()
+}
+
+// ExtractKeys scans the files under `path` recursively, and returns the sorted unique translation keys.
+func ExtractKeys(path string, pattern ...string) ([]string, error) {
+	return Instance().ExtractKeys(path, pattern...)
+}
@@ -0,0 +1,302 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/text/gregex"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Locale is the formatting rules of a language, for pluralization and parameter formatting.
+type Locale struct {
+	Plural           PluralRule // Plural rule selecting the plural category for a number.
+	DecimalSeparator string     // Decimal separator of numbers, like "." of "1.5".
+	GroupSeparator   string     // Grouping separator of numbers, like "," of "1,000".
+	DateLayout       string     // Layout of dates using gtime format pattern, like "Y-m-d".
+	DatetimeLayout   string     // Layout of date times using gtime format pattern, like "Y-m-d H:i:s".
+}
+
+// PluralRule selects and returns the plural category for number `n`, following the CLDR plural rules.
+// The parameter `i` is the integer digits of `n`, and `v` is the count of visible fraction digits of `n`.
+type PluralRule func(n float64, i int64, v int) string
+
+// Plural categories defined by CLDR.
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+const (
+	// pluralKeySeparator separates the message key and the plural category, like: "apple.one".
+	pluralKeySeparator = "."
+	// pluralCountParam is the parameter name of the count for plural message.
+	pluralCountParam = "count"
+)
+
+var (
+	localeMu  sync.RWMutex
+	localeMap = map[string]Locale{
+		"en": {Plural: pluralRuleOneOther, DecimalSeparator: ".", GroupSeparator: ",", DateLayout: "m/d/Y", DatetimeLayout: "m/d/Y H:i:s"},
+		"de": {Plural: pluralRuleOneOther, DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "d.m.Y", DatetimeLayout: "d.m.Y H:i:s"},
+		"nl": {Plural: pluralRuleOneOther, DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "d-m-Y", DatetimeLayout: "d-m-Y H:i:s"},
+		"it": {Plural: pluralRuleOneOther, DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "d/m/Y", DatetimeLayout: "d/m/Y H:i:s"},
+		"es": {Plural: pluralRuleOneOther, DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "d/m/Y", DatetimeLayout: "d/m/Y H:i:s"},
+		"pt": {Plural: pluralRuleZeroOneOther, DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "d/m/Y", DatetimeLayout: "d/m/Y H:i:s"},
+		"fr": {Plural: pluralRuleZeroOneOther, DecimalSeparator: ",", GroupSeparator: " ", DateLayout: "d/m/Y", DatetimeLayout: "d/m/Y H:i:s"},
+		"tr": {Plural: pluralRuleOneOther, DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "d.m.Y", DatetimeLayout: "d.m.Y H:i:s"},
+		"ru": {Plural: pluralRuleSlavic, DecimalSeparator: ",", GroupSeparator: " ", DateLayout: "d.m.Y", DatetimeLayout: "d.m.Y H:i:s"},
+		"uk": {Plural: pluralRuleSlavic, DecimalSeparator: ",", GroupSeparator: " ", DateLayout: "d.m.Y", DatetimeLayout: "d.m.Y H:i:s"},
+		"pl": {Plural: pluralRulePolish, DecimalSeparator: ",", GroupSeparator: " ", DateLayout: "d.m.Y", DatetimeLayout: "d.m.Y H:i:s"},
+		"cs": {Plural: pluralRuleCzech, DecimalSeparator: ",", GroupSeparator: " ", DateLayout: "d.m.Y", DatetimeLayout: "d.m.Y H:i:s"},
+		"ar": {Plural: pluralRuleArabic, DecimalSeparator: ".", GroupSeparator: ",", DateLayout: "d/m/Y", DatetimeLayout: "d/m/Y H:i:s"},
+		"zh": {Plural: pluralRuleOther, DecimalSeparator: ".", GroupSeparator: ",", DateLayout: "Y-m-d", DatetimeLayout: "Y-m-d H:i:s"},
+		"ja": {Plural: pluralRuleOther, DecimalSeparator: ".", GroupSeparator: ",", DateLayout: "Y/m/d", DatetimeLayout: "Y/m/d H:i:s"},
+		"ko": {Plural: pluralRuleOther, DecimalSeparator: ".", GroupSeparator: ",", DateLayout: "Y.m.d", DatetimeLayout: "Y.m.d H:i:s"},
+		"vi": {Plural: pluralRuleOther, DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "d/m/Y", DatetimeLayout: "d/m/Y H:i:s"},
+		"th": {Plural: pluralRuleOther, DecimalSeparator: ".", GroupSeparator: ",", DateLayout: "d/m/Y", DatetimeLayout: "d/m/Y H:i:s"},
+		"id": {Plural: pluralRuleOther, DecimalSeparator: ",", GroupSeparator: ".", DateLayout: "d/m/Y", DatetimeLayout: "d/m/Y H:i:s"},
+	}
+	// paramPattern matches the named parameters of message like: {name}, {amount,number,2}, {birthday,date}.
+	paramPattern = `\{(\w+)(?:\s*,\s*(\w+))?(?:\s*,\s*([^{}]+?))?\s*\}`
+)
+
+// RegisterLocale registers or overwrites the Locale of `language`, like "en" or "zh-TW".
+// The Locale of a language falls back to its base language if it is not registered,
+// eg: "zh-TW" uses the Locale of "zh", and the unknown languages use the Locale of "en".
+func RegisterLocale(language string, locale Locale) {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	localeMap[language] = locale
+}
+
+// GetLocale returns the Locale of `language`, see RegisterLocale.
+func GetLocale(language string) Locale {
+	localeMu.RLock()
+	defer localeMu.RUnlock()
+	for _, lang := range getBaseLanguages(language) {
+		if locale, ok := localeMap[lang]; ok {
+			return locale
+		}
+	}
+	return localeMap["en"]
+}
+
+// PluralCategory returns the plural category of number `count` for `language`.
+func PluralCategory(language string, count interface{}) string {
+	var (
+		s    = strings.TrimLeft(gconv.String(count), "-+")
+		n, _ = strconv.ParseFloat(s, 64)
+		i    = int64(math.Abs(n))
+		v    int
+	)
+	if pos := strings.Index(s, "."); pos != -1 {
+		v = len(s) - pos - 1
+	}
+	plural := GetLocale(language).Plural
+	if plural == nil {
+		return PluralOther
+	}
+	return plural(math.Abs(n), i, v)
+}
+
+// FormatNumber formats number `value` with the separators of `language`, and the optional
+// parameter `precision` specifies the fraction digits, which is not changed in default.
+func FormatNumber(language string, value interface{}, precision ...int) string {
+	var (
+		locale = GetLocale(language)
+		s      string
+	)
+	if len(precision) > 0 && precision[0] >= 0 {
+		s = strconv.FormatFloat(gconv.Float64(value), 'f', precision[0], 64)
+	} else {
+		s = strconv.FormatFloat(gconv.Float64(value), 'f', -1, 64)
+	}
+	var (
+		sign           string
+		integer, frac  = s, ""
+		groupSeparator = locale.GroupSeparator
+	)
+	if strings.HasPrefix(integer, "-") {
+		sign, integer = "-", integer[1:]
+	}
+	if pos := strings.Index(integer, "."); pos != -1 {
+		integer, frac = integer[:pos], integer[pos+1:]
+	}
+	if groupSeparator != "" && len(integer) > 3 {
+		var builder strings.Builder
+		for i, c := range integer {
+			if i > 0 && (len(integer)-i)%3 == 0 {
+				builder.WriteString(groupSeparator)
+			}
+			builder.WriteRune(c)
+		}
+		integer = builder.String()
+	}
+	if frac != "" {
+		decimalSeparator := locale.DecimalSeparator
+		if decimalSeparator == "" {
+			decimalSeparator = "."
+		}
+		return sign + integer + decimalSeparator + frac
+	}
+	return sign + integer
+}
+
+// FormatParams replaces the named parameters in `message` with `params` formatted for `language`.
+// The parameter syntax is {name[,type[,style]]}, of which the type can be:
+// number: formats the number with separators, the style is the fraction digits, like {amount,number,2};
+// date: formats the time with date layout of the language, or the style as gtime format pattern, like {day,date,Y-m};
+// datetime: formats the time with datetime layout of the language, or the style as gtime format pattern.
+// The parameter without type is converted to string, and the parameter not in `params` is kept unchanged.
+func FormatParams(language string, message string, params map[string]interface{}) string {
+	if len(params) == 0 || !strings.Contains(message, "{") {
+		return message
+	}
+	result, _ := gregex.ReplaceStringFuncMatch(paramPattern, message, func(match []string) string {
+		value, ok := params[match[1]]
+		if !ok {
+			return match[0]
+		}
+		var (
+			style  = strings.TrimSpace(match[3])
+			locale = GetLocale(language)
+		)
+		switch strings.ToLower(match[2]) {
+		case "number":
+			if style != "" {
+				return FormatNumber(language, value, gconv.Int(style))
+			}
+			return FormatNumber(language, value)
+
+		case "date":
+			if style == "" {
+				style = locale.DateLayout
+			}
+			return gtime.New(value).Format(style)
+
+		case "datetime":
+			if style == "" {
+				style = locale.DatetimeLayout
+			}
+			return gtime.New(value).Format(style)
+
+		default:
+			return gconv.String(value)
+		}
+	})
+	return result
+}
+
+// getBaseLanguages returns `language` and its base languages, like: zh-Hant-TW => [zh-Hant-TW, zh-Hant, zh].
+func getBaseLanguages(language string) []string {
+	languages := []string{language}
+	for {
+		pos := strings.LastIndexAny(language, "-_")
+		if pos <= 0 {
+			break
+		}
+		language = language[:pos]
+		languages = append(languages, language)
+	}
+	return languages
+}
+
+// pluralRuleOther is the plural rule for languages without plural forms, like Chinese and Japanese.
+func pluralRuleOther(n float64, i int64, v int) string {
+	return PluralOther
+}
+
+// pluralRuleOneOther is the plural rule for languages like English and German.
+func pluralRuleOneOther(n float64, i int64, v int) string {
+	if i == 1 && v == 0 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralRuleZeroOneOther is the plural rule for languages like French, of which 0 and 1 are singular.
+func pluralRuleZeroOneOther(n float64, i int64, v int) string {
+	if i == 0 || i == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// pluralRuleSlavic is the plural rule for Russian and Ukrainian.
+func pluralRuleSlavic(n float64, i int64, v int) string {
+	if v != 0 {
+		return PluralOther
+	}
+	switch {
+	case i%10 == 1 && i%100 != 11:
+		return PluralOne
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// pluralRulePolish is the plural rule for Polish.
+func pluralRulePolish(n float64, i int64, v int) string {
+	if v != 0 {
+		return PluralOther
+	}
+	switch {
+	case i == 1:
+		return PluralOne
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// pluralRuleCzech is the plural rule for Czech and Slovak.
+func pluralRuleCzech(n float64, i int64, v int) string {
+	switch {
+	case v != 0:
+		return PluralMany
+	case i == 1:
+		return PluralOne
+	case i >= 2 && i <= 4:
+		return PluralFew
+	default:
+		return PluralOther
+	}
+}
+
+// pluralRuleArabic is the plural rule for Arabic.
+func pluralRuleArabic(n float64, i int64, v int) string {
+	if v != 0 {
+		return PluralOther
+	}
+	switch {
+	case i == 0:
+		return PluralZero
+	case i == 1:
+		return PluralOne
+	case i == 2:
+		return PluralTwo
+	case i%100 >= 3 && i%100 <= 10:
+		return PluralFew
+	case i%100 >= 11 && i%100 <= 99:
+		return PluralMany
+	default:
+		return PluralOther
+	}
+}
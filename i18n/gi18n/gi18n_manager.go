@@ -19,23 +19,33 @@ import (
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/gfsnotify"
 	"github.com/gogf/gf/v2/os/gres"
+	"github.com/gogf/gf/v2/os/gtimer"
 	"github.com/gogf/gf/v2/text/gregex"
 	"github.com/gogf/gf/v2/util/gconv"
 )
 
 // Manager for i18n contents, it is concurrent safe, supporting hot reload.
 type Manager struct {
-	mu      sync.RWMutex
-	data    map[string]map[string]string // Translating map.
-	pattern string                       // Pattern for regex parsing.
-	options Options                      // configuration options.
+	mu             sync.RWMutex
+	data           map[string]map[string]string   // Translating map.
+	pattern        string                         // Pattern for regex parsing.
+	options        Options                        // configuration options.
+	sources        []Source                       // Remote translation sources.
+	sourceData     []map[string]map[string]string // Translations loaded from sources, the index is the same as sources.
+	refreshEntry   *gtimer.Entry                  // Timer entry refreshing the sources periodically.
+	watcher        *gfsnotify.Callback            // Callback watching the i18n files for hot reload.
+	missingMu      sync.Mutex                     // Mutex for missing translations.
+	missing        map[string]map[string]int      // Recorded missing keys and their counts of each language.
+	missingHandler MissingHandler                 // Hook function called when translation is missing.
 }
 
 // Options is used for i18n object configuration.
 type Options struct {
-	Path       string   // I18n files storage path.
-	Language   string   // Default local language.
-	Delimiters []string // Delimiters for variable parsing.
+	Path          string              // I18n files storage path.
+	Language      string              // Default local language.
+	Delimiters    []string            // Delimiters for variable parsing.
+	Fallbacks     map[string][]string // Custom fallback languages of language, like: "zh-HK": ["zh-TW"].
+	RecordMissing bool                // Records the missing keys with counts, see GetMissing.
 }
 
 var (
@@ -118,6 +128,18 @@ func (m *Manager) SetDelimiters(left, right string) {
 	intlog.Printf(context.TODO(), `SetDelimiters: %v`, m.pattern)
 }
 
+// SetFallback sets the custom fallback languages of `language` in order, which are looked up before
+// the base languages and the default language, see Translate.
+func (m *Manager) SetFallback(language string, fallbacks ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.options.Fallbacks == nil {
+		m.options.Fallbacks = make(map[string][]string)
+	}
+	m.options.Fallbacks[language] = fallbacks
+	intlog.Printf(context.TODO(), `SetFallback: %s => %v`, language, fallbacks)
+}
+
 // T is alias of Translate for convenience.
 func (m *Manager) T(ctx context.Context, content string) string {
 	return m.Translate(ctx, content)
@@ -128,38 +150,113 @@ func (m *Manager) Tf(ctx context.Context, format string, values ...interface{})
 	return m.TranslateFormat(ctx, format, values...)
 }
 
+// Tp is alias of TranslateParams for convenience.
+func (m *Manager) Tp(ctx context.Context, key string, params map[string]interface{}) string {
+	return m.TranslateParams(ctx, key, params)
+}
+
+// Tn is alias of TranslatePlural for convenience.
+func (m *Manager) Tn(ctx context.Context, key string, count interface{}, params ...map[string]interface{}) string {
+	return m.TranslatePlural(ctx, key, count, params...)
+}
+
 // TranslateFormat translates, formats and returns the `format` with configured language
 // and given `values`.
 func (m *Manager) TranslateFormat(ctx context.Context, format string, values ...interface{}) string {
 	return fmt.Sprintf(m.Translate(ctx, format), values...)
 }
 
-// Translate translates `content` with configured language.
-func (m *Manager) Translate(ctx context.Context, content string) string {
+// TranslateParams translates `key` with configured language, and replaces the named parameters
+// of the translated message with `params`, like: "Hello {name}, you paid {amount,number,2}".
+// See FormatParams for the parameter syntax.
+func (m *Manager) TranslateParams(ctx context.Context, key string, params map[string]interface{}) string {
+	return FormatParams(m.getLanguage(ctx), m.Translate(ctx, key), params)
+}
+
+// TranslatePlural translates `key` with the plural form for number `count` in configured language,
+// and replaces the named parameters of the translated message with `params` and `count`.
+//
+// The plural forms are configured as the sub keys of `key` named by the CLDR plural categories:
+// zero, one, two, few, many and other, of which "other" is required, like in toml:
+// [apple]
+// one = "{count} apple"
+// other = "{count} apples"
+// The count is formatted as number in the message, which can be customized like "{count,number,2}".
+func (m *Manager) TranslatePlural(ctx context.Context, key string, count interface{}, params ...map[string]interface{}) string {
 	m.init(ctx)
+	var (
+		language  = m.getLanguage(ctx)
+		message   = key
+		allParams = map[string]interface{}{pluralCountParam: count}
+	)
+	if len(params) > 0 {
+		for k, v := range params[0] {
+			allParams[k] = v
+		}
+	}
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-	transLang := m.options.Language
-	if lang := LanguageFromCtx(ctx); lang != "" {
-		transLang = lang
+	for _, lang := range m.getLanguageChain(language) {
+		data := m.data[lang]
+		if data == nil {
+			continue
+		}
+		category := PluralCategory(lang, count)
+		if v, ok := data[key+pluralKeySeparator+category]; ok {
+			message, language = v, lang
+			break
+		}
+		if v, ok := data[key+pluralKeySeparator+PluralOther]; ok {
+			message, language = v, lang
+			break
+		}
 	}
-	data := m.data[transLang]
-	if data == nil {
-		return content
+	m.mu.RUnlock()
+	if message == key {
+		m.handleMissing(ctx, language, key)
 	}
+	if strings.Contains(message, "{"+pluralCountParam+"}") {
+		message = strings.Replace(message, "{"+pluralCountParam+"}", "{"+pluralCountParam+",number}", -1)
+	}
+	return FormatParams(language, message, allParams)
+}
+
+// Translate translates `content` with configured language.
+// The content is looked up in the languages in order: the configured language, the custom fallback
+// languages, the base languages, and the default language, eg: zh-TW => zh => en.
+func (m *Manager) Translate(ctx context.Context, content string) string {
+	m.init(ctx)
+	m.mu.RLock()
+	var (
+		transLang = m.getLanguage(ctx)
+		languages = m.getLanguageChain(transLang)
+	)
 	// Parse content as name.
-	if v, ok := data[content]; ok {
+	if v, ok := m.lookup(languages, content); ok {
+		m.mu.RUnlock()
 		return v
 	}
 	// Parse content as variables container.
+	var (
+		matched bool
+		missing []string
+	)
 	result, _ := gregex.ReplaceStringFuncMatch(
 		m.pattern, content,
 		func(match []string) string {
-			if v, ok := data[match[1]]; ok {
+			matched = true
+			if v, ok := m.lookup(languages, match[1]); ok {
 				return v
 			}
+			missing = append(missing, match[1])
 			return match[0]
 		})
+	m.mu.RUnlock()
+	if !matched {
+		missing = append(missing, content)
+	}
+	for _, key := range missing {
+		m.handleMissing(ctx, transLang, key)
+	}
 	intlog.Printf(ctx, `Translate for language: %s`, transLang)
 	return result
 }
@@ -169,15 +266,62 @@ func (m *Manager) Translate(ctx context.Context, content string) string {
 func (m *Manager) GetContent(ctx context.Context, key string) string {
 	m.init(ctx)
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-	transLang := m.options.Language
+	language := m.getLanguage(ctx)
+	v, ok := m.lookup(m.getLanguageChain(language), key)
+	m.mu.RUnlock()
+	if !ok {
+		m.handleMissing(ctx, language, key)
+	}
+	return v
+}
+
+// GetLanguage returns the language of translation for context `ctx`, which is the language
+// in `ctx` set by WithLanguage, or the configured default language.
+func (m *Manager) GetLanguage(ctx context.Context) string {
+	return m.getLanguage(ctx)
+}
+
+// getLanguage returns the language from context `ctx`, or the configured default language.
+func (m *Manager) getLanguage(ctx context.Context) string {
 	if lang := LanguageFromCtx(ctx); lang != "" {
-		transLang = lang
+		return lang
+	}
+	return m.options.Language
+}
+
+// getLanguageChain returns the languages for looking up translations of `language` in order,
+// which are the language itself, its custom fallbacks, its base languages and the default language.
+func (m *Manager) getLanguageChain(language string) []string {
+	var (
+		languages []string
+		appended  = make(map[string]struct{})
+		appendFn  = func(lang string) {
+			if _, ok := appended[lang]; !ok && lang != "" {
+				appended[lang] = struct{}{}
+				languages = append(languages, lang)
+			}
+		}
+	)
+	appendFn(language)
+	for _, lang := range m.options.Fallbacks[language] {
+		appendFn(lang)
+	}
+	for _, lang := range getBaseLanguages(language) {
+		appendFn(lang)
 	}
-	if data, ok := m.data[transLang]; ok {
-		return data[key]
+	appendFn(m.options.Language)
+	return languages
+}
+
+// lookup retrieves the content of `key` in `languages` in order.
+// Note that it should be called with the read lock.
+func (m *Manager) lookup(languages []string, key string) (string, bool) {
+	for _, lang := range languages {
+		if v, ok := m.data[lang][key]; ok {
+			return v, true
+		}
 	}
-	return ""
+	return "", false
 }
 
 // init initializes the manager for lazy initialization design.
@@ -193,6 +337,7 @@ func (m *Manager) init(ctx context.Context) {
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	defer m.mergeSourceData()
 	if gres.Contains(m.options.Path) {
 		files := gres.ScanDirFile(m.options.Path, "*.*", true)
 		if len(files) > 0 {
@@ -216,9 +361,7 @@ func (m *Manager) init(ctx context.Context) {
 					m.data[lang] = make(map[string]string)
 				}
 				if j, err := gjson.LoadContent(file.Content()); err == nil {
-					for k, v := range j.Var().Map() {
-						m.data[lang][k] = gconv.String(v)
-					}
+					setI18nData(m.data[lang], "", j.Var().Map())
 				} else {
 					intlog.Errorf(ctx, "load i18n file '%s' failed: %+v", name, err)
 				}
@@ -247,20 +390,49 @@ func (m *Manager) init(ctx context.Context) {
 				m.data[lang] = make(map[string]string)
 			}
 			if j, err := gjson.LoadContent(gfile.GetBytes(file)); err == nil {
-				for k, v := range j.Var().Map() {
-					m.data[lang][k] = gconv.String(v)
-				}
+				setI18nData(m.data[lang], "", j.Var().Map())
 			} else {
 				intlog.Errorf(ctx, "load i18n file '%s' failed: %+v", file, err)
 			}
 		}
-		// Monitor changes of i18n files for hot reload feature.
-		_, _ = gfsnotify.Add(path, func(event *gfsnotify.Event) {
-			// Any changes of i18n files, clear the data.
-			m.mu.Lock()
-			m.data = nil
-			m.mu.Unlock()
-			gfsnotify.Exit()
-		})
+		m.watch(ctx)
+	}
+}
+
+// watch monitors changes of i18n files under the path for hot reload feature.
+// Note that it should be called with the write lock.
+func (m *Manager) watch(ctx context.Context) {
+	if m.watcher != nil {
+		if m.watcher.Path == m.options.Path {
+			return
+		}
+		_ = gfsnotify.RemoveCallback(m.watcher.Id)
+		m.watcher = nil
+	}
+	watcher, err := gfsnotify.Add(m.options.Path, func(event *gfsnotify.Event) {
+		// Any changes of i18n files, clear the data for reloading.
+		intlog.Printf(ctx, `i18n file changed: %s`, event.String())
+		m.mu.Lock()
+		m.data = nil
+		m.mu.Unlock()
+	}, true)
+	if err != nil {
+		intlog.Errorf(ctx, `watch i18n path "%s" failed: %+v`, m.options.Path, err)
+		return
+	}
+	m.watcher = watcher
+}
+
+// setI18nData sets the key-value pairs of `values` to `data`, of which the keys are prefixed with `prefix`.
+// The nested maps are also flattened as keys joined with char '.', like the plural forms "apple.one".
+func setI18nData(data map[string]string, prefix string, values map[string]interface{}) {
+	for k, v := range values {
+		if prefix != "" {
+			k = prefix + pluralKeySeparator + k
+		}
+		data[k] = gconv.String(v)
+		if nested, ok := v.(map[string]interface{}); ok {
+			setI18nData(data, k, nested)
+		}
 	}
 }
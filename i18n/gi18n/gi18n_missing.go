@@ -0,0 +1,177 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/text/gregex"
+)
+
+// MissingHandler is the hook function called when the translation of `key` is missing for `language`.
+type MissingHandler func(ctx context.Context, language, key string)
+
+const (
+	// defaultExtractPattern is the default file pattern for extracting translation keys.
+	defaultExtractPattern = "*.go,*.html,*.htm,*.tpl,*.tmpl,*.js,*.vue"
+	// keyPattern matches the content that is used as a translation key, but not a whole template content.
+	keyPattern = `^[\w\-.:]+$`
+	// callPattern matches the translating function calls in source, like: T(ctx, "hello") or gi18n.Tf(ctx, `hello`, 1).
+	callPattern = `\b(?:T|Tf|Tp|Tn|Translate|TranslateFormat|TranslateParams|TranslatePlural|GetContent)\(\s*[\w.()]+\s*,\s*("(?:[^"\\]|\\.)*"|` + "`[^`]*`" + `)`
+)
+
+// SetMissingHandler sets the hook function `handler` called when translation is missing,
+// which is usually used for reporting the missing keys to logger or monitor.
+// Note that the handler is called synchronously in translating, it should be fast.
+func (m *Manager) SetMissingHandler(handler MissingHandler) {
+	m.missingMu.Lock()
+	defer m.missingMu.Unlock()
+	m.missingHandler = handler
+}
+
+// SetRecordMissing enables or disables recording the missing keys with counts, see GetMissing.
+func (m *Manager) SetRecordMissing(enabled bool) {
+	m.missingMu.Lock()
+	defer m.missingMu.Unlock()
+	m.options.RecordMissing = enabled
+	intlog.Printf(context.TODO(), `SetRecordMissing: %v`, enabled)
+}
+
+// GetMissing returns a copy of the recorded missing keys and their counts of each language,
+// like: {"zh-CN": {"hello": 2}}.
+func (m *Manager) GetMissing() map[string]map[string]int {
+	m.missingMu.Lock()
+	defer m.missingMu.Unlock()
+	missing := make(map[string]map[string]int, len(m.missing))
+	for lang, keys := range m.missing {
+		missing[lang] = make(map[string]int, len(keys))
+		for k, v := range keys {
+			missing[lang][k] = v
+		}
+	}
+	return missing
+}
+
+// ClearMissing clears the recorded missing keys.
+func (m *Manager) ClearMissing() {
+	m.missingMu.Lock()
+	defer m.missingMu.Unlock()
+	m.missing = nil
+}
+
+// ExtractKeys scans the files under `path` recursively, and returns the sorted unique translation keys,
+// which are the variables with delimiters like "{#hello}", and the keys of translating function
+// calls like `T(ctx, "hello")`. The optional parameter `pattern` specifies the file pattern,
+// which is "*.go,*.html,*.htm,*.tpl,*.tmpl,*.js,*.vue" in default.
+func (m *Manager) ExtractKeys(path string, pattern ...string) ([]string, error) {
+	filePattern := defaultExtractPattern
+	if len(pattern) > 0 && pattern[0] != "" {
+		filePattern = pattern[0]
+	}
+	var files []string
+	if gfile.IsDir(path) {
+		var err error
+		if files, err = gfile.ScanDirFile(path, filePattern, true); err != nil {
+			return nil, err
+		}
+	} else {
+		files = []string{path}
+	}
+	keySet := make(map[string]struct{})
+	for _, file := range files {
+		for _, key := range m.extractKeysFromContent(gfile.GetContents(file)) {
+			keySet[key] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// CheckKeys returns the sorted keys of `keys` that are missing for each loaded language,
+// which can be used with ExtractKeys for keeping the translation files complete.
+// The languages without missing keys are not in the result.
+func (m *Manager) CheckKeys(ctx context.Context, keys []string) map[string][]string {
+	m.init(ctx)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	result := make(map[string][]string)
+	for lang, data := range m.data {
+		for _, key := range keys {
+			if _, ok := data[key]; ok {
+				continue
+			}
+			// The plural forms, see TranslatePlural.
+			if _, ok := data[key+pluralKeySeparator+PluralOther]; ok {
+				continue
+			}
+			result[lang] = append(result[lang], key)
+		}
+		sort.Strings(result[lang])
+	}
+	return result
+}
+
+// extractKeysFromContent extracts and returns the translation keys in `content`.
+func (m *Manager) extractKeysFromContent(content string) []string {
+	var keys []string
+	if matches, _ := gregex.MatchAllString(m.pattern, content); len(matches) > 0 {
+		for _, match := range matches {
+			keys = append(keys, match[1])
+		}
+	}
+	if matches, _ := gregex.MatchAllString(callPattern, content); len(matches) > 0 {
+		for _, match := range matches {
+			key := match[1]
+			if key[0] == '`' {
+				key = key[1 : len(key)-1]
+			} else if s, err := strconv.Unquote(key); err == nil {
+				key = s
+			} else {
+				continue
+			}
+			// The variables in content have been extracted above.
+			if gregex.IsMatchString(keyPattern, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// handleMissing records the missing `key` for `language` and calls the missing handler.
+// The `key` is ignored if it is not like a translation key, like the whole template content.
+func (m *Manager) handleMissing(ctx context.Context, language, key string) {
+	if !gregex.IsMatchString(keyPattern, key) {
+		return
+	}
+	m.missingMu.Lock()
+	var (
+		handler = m.missingHandler
+		record  = m.options.RecordMissing
+	)
+	if record {
+		if m.missing == nil {
+			m.missing = make(map[string]map[string]int)
+		}
+		if m.missing[language] == nil {
+			m.missing[language] = make(map[string]int)
+		}
+		m.missing[language][key]++
+	}
+	m.missingMu.Unlock()
+	if handler != nil {
+		handler(ctx, language, key)
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gi18n
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gtimer"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Source is the interface for loading translations from remote, like HTTP endpoint or database,
+// so that the translations can be updated without redeploying.
+type Source interface {
+	// Load loads and returns the translations of all languages, the map key is the language.
+	Load(ctx context.Context) (map[string]map[string]string, error)
+}
+
+// SourceFunc is the function adapter for Source.
+type SourceFunc func(ctx context.Context) (map[string]map[string]string, error)
+
+// HttpSourceOptions is the options for HTTP translation source.
+type HttpSourceOptions struct {
+	Url     string            // URL of the endpoint, which responds the translations in JSON like: {"en": {"hello": "Hello"}}.
+	Header  map[string]string // Custom request headers, like the authorization token.
+	Timeout time.Duration     // Request timeout, default is 10 seconds.
+}
+
+// DbSourceOptions is the options for database translation source.
+type DbSourceOptions struct {
+	Query         func(ctx context.Context) ([]map[string]interface{}, error) // Query returns the translation records, which is usually the List of gdb query result.
+	LanguageField string                                                      // Field name of the language, default is "language".
+	KeyField      string                                                      // Field name of the translation key, default is "key".
+	ValueField    string                                                      // Field name of the translated content, default is "value".
+}
+
+// httpSource is the Source loading translations from HTTP endpoint.
+type httpSource struct {
+	options HttpSourceOptions
+	client  *http.Client
+}
+
+// dbSource is the Source loading translations from database records.
+type dbSource struct {
+	options DbSourceOptions
+}
+
+const (
+	defaultHttpSourceTimeout = 10 * time.Second
+	defaultDbLanguageField   = "language"
+	defaultDbKeyField        = "key"
+	defaultDbValueField      = "value"
+)
+
+// Load implements Source.
+func (f SourceFunc) Load(ctx context.Context) (map[string]map[string]string, error) {
+	return f(ctx)
+}
+
+// NewHttpSource creates and returns a Source loading translations from HTTP endpoint with GET method.
+// The response content should be translations of languages in JSON, like: {"en": {"hello": "Hello"}}.
+func NewHttpSource(options HttpSourceOptions) Source {
+	if options.Timeout <= 0 {
+		options.Timeout = defaultHttpSourceTimeout
+	}
+	return &httpSource{
+		options: options,
+		client:  &http.Client{Timeout: options.Timeout},
+	}
+}
+
+// Load implements Source.
+func (s *httpSource) Load(ctx context.Context) (map[string]map[string]string, error) {
+	req, err := http.NewRequest(http.MethodGet, s.options.Url, nil)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `create i18n request for "%s" failed`, s.options.Url)
+	}
+	for k, v := range s.options.Header {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, gerror.Wrapf(err, `request i18n source "%s" failed`, s.options.Url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, gerror.NewCodef(
+			gcode.CodeOperationFailed, `request i18n source "%s" failed with status: %s`, s.options.Url, resp.Status,
+		)
+	}
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `read i18n source "%s" failed`, s.options.Url)
+	}
+	j, err := gjson.LoadContent(content)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `parse i18n source "%s" failed`, s.options.Url)
+	}
+	data := make(map[string]map[string]string)
+	for lang, values := range j.Var().Map() {
+		data[lang] = make(map[string]string)
+		setI18nData(data[lang], "", gconv.Map(values))
+	}
+	return data, nil
+}
+
+// NewDbSource creates and returns a Source loading translations from database records, each of which
+// contains the language, key and translated content of a translation.
+func NewDbSource(options DbSourceOptions) Source {
+	if options.LanguageField == "" {
+		options.LanguageField = defaultDbLanguageField
+	}
+	if options.KeyField == "" {
+		options.KeyField = defaultDbKeyField
+	}
+	if options.ValueField == "" {
+		options.ValueField = defaultDbValueField
+	}
+	return &dbSource{
+		options: options,
+	}
+}
+
+// Load implements Source.
+func (s *dbSource) Load(ctx context.Context) (map[string]map[string]string, error) {
+	if s.options.Query == nil {
+		return nil, gerror.NewCode(gcode.CodeMissingConfiguration, `query function of i18n database source is not configured`)
+	}
+	records, err := s.options.Query(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]map[string]string)
+	for _, record := range records {
+		var (
+			lang = gconv.String(record[s.options.LanguageField])
+			key  = gconv.String(record[s.options.KeyField])
+		)
+		if lang == "" || key == "" {
+			continue
+		}
+		if data[lang] == nil {
+			data[lang] = make(map[string]string)
+		}
+		data[lang][key] = gconv.String(record[s.options.ValueField])
+	}
+	return data, nil
+}
+
+// AddSource adds a remote translation `source` to the manager and loads it immediately.
+// The translations from sources override the ones from i18n files, and the later added source
+// overrides the former ones. The source is still added if it fails loading, which can be retried
+// by Refresh.
+func (m *Manager) AddSource(ctx context.Context, source Source) error {
+	m.mu.Lock()
+	m.sources = append(m.sources, source)
+	m.sourceData = append(m.sourceData, nil)
+	m.mu.Unlock()
+	return m.Refresh(ctx)
+}
+
+// Refresh reloads the translations from all added sources.
+// The previously loaded translations of a source are kept if it fails loading this time,
+// and the first error is returned.
+func (m *Manager) Refresh(ctx context.Context) error {
+	m.mu.RLock()
+	sources := make([]Source, len(m.sources))
+	copy(sources, m.sources)
+	m.mu.RUnlock()
+
+	var (
+		firstErr error
+		loaded   = make([]map[string]map[string]string, len(sources))
+	)
+	for i, source := range sources {
+		data, err := source.Load(ctx)
+		if err != nil {
+			intlog.Errorf(ctx, `load i18n source failed: %+v`, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		loaded[i] = data
+	}
+	m.mu.Lock()
+	for i, data := range loaded {
+		if data != nil {
+			m.sourceData[i] = data
+		}
+	}
+	// Clear the data for reloading with the refreshed translations.
+	m.data = nil
+	m.mu.Unlock()
+	return firstErr
+}
+
+// SetRefreshInterval sets the interval refreshing the translations from sources periodically,
+// it stops refreshing if `interval` <= 0.
+func (m *Manager) SetRefreshInterval(interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.refreshEntry != nil {
+		m.refreshEntry.Close()
+		m.refreshEntry = nil
+	}
+	if interval > 0 {
+		m.refreshEntry = gtimer.AddSingleton(context.Background(), interval, func(ctx context.Context) {
+			_ = m.Refresh(ctx)
+		})
+	}
+	intlog.Printf(context.TODO(), `SetRefreshInterval: %s`, interval)
+}
+
+// mergeSourceData merges the translations loaded from sources into data.
+// Note that it should be called with the write lock.
+func (m *Manager) mergeSourceData() {
+	for _, sourceData := range m.sourceData {
+		for lang, values := range sourceData {
+			if m.data == nil {
+				m.data = make(map[string]map[string]string)
+			}
+			if m.data[lang] == nil {
+				m.data[lang] = make(map[string]string)
+			}
+			for k, v := range values {
+				m.data[lang][k] = v
+			}
+		}
+	}
+}
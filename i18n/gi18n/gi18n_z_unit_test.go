@@ -8,10 +8,16 @@ package gi18n_test
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	_ "github.com/gogf/gf/v2/os/gres/testdata/data"
 
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/debug/gdebug"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/i18n/gi18n"
@@ -155,3 +161,265 @@ func Test_Resource(t *testing.T) {
 		t.Assert(m.T(context.Background(), "{#hello}{#world}"), "你好世界")
 	})
 }
+
+func Test_Plural(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gi18n.PluralCategory("en", 1), gi18n.PluralOne)
+		t.Assert(gi18n.PluralCategory("en", 1.5), gi18n.PluralOther)
+		t.Assert(gi18n.PluralCategory("en-US", 2), gi18n.PluralOther)
+		t.Assert(gi18n.PluralCategory("fr", 0), gi18n.PluralOne)
+		t.Assert(gi18n.PluralCategory("ru", 21), gi18n.PluralOne)
+		t.Assert(gi18n.PluralCategory("ru", 3), gi18n.PluralFew)
+		t.Assert(gi18n.PluralCategory("ru", 11), gi18n.PluralMany)
+		t.Assert(gi18n.PluralCategory("pl", 22), gi18n.PluralFew)
+		t.Assert(gi18n.PluralCategory("pl", 25), gi18n.PluralMany)
+		t.Assert(gi18n.PluralCategory("ar", 0), gi18n.PluralZero)
+		t.Assert(gi18n.PluralCategory("ar", 2), gi18n.PluralTwo)
+		t.Assert(gi18n.PluralCategory("zh-CN", 1), gi18n.PluralOther)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		i18n := gi18n.New(gi18n.Options{
+			Path:     gtest.DataPath("i18n-plural"),
+			Language: "en",
+		})
+		ctx := context.Background()
+		t.Assert(i18n.Tn(ctx, "apple", 1), "1 apple")
+		t.Assert(i18n.Tn(ctx, "apple", 1234), "1,234 apples")
+
+		ruCtx := gi18n.WithLanguage(ctx, "ru")
+		t.Assert(i18n.Tn(ruCtx, "apple", 1), "1 яблоко")
+		t.Assert(i18n.Tn(ruCtx, "apple", 3), "3 яблока")
+		t.Assert(i18n.Tn(ruCtx, "apple", 5), "5 яблок")
+		t.Assert(i18n.Tn(ruCtx, "apple", 1.5), "1,5 яблока")
+
+		zhCtx := gi18n.WithLanguage(ctx, "zh-TW")
+		t.Assert(i18n.Tn(zhCtx, "apple", 1), "1个苹果")
+		t.Assert(i18n.Tn(ctx, "pear", 1), "pear")
+	})
+}
+
+func Test_Fallback(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		i18n := gi18n.New(gi18n.Options{
+			Path:     gtest.DataPath("i18n-plural"),
+			Language: "en",
+		})
+		ctx := gi18n.WithLanguage(context.Background(), "zh-TW")
+		t.Assert(i18n.T(ctx, "{#hello}{#world}"), "妳好世界")
+		t.Assert(i18n.T(ctx, "only-en"), "Only English")
+		t.Assert(i18n.GetContent(ctx, "world"), "世界")
+		t.Assert(i18n.GetContent(ctx, "not-exist"), "")
+
+		ctx = gi18n.WithLanguage(context.Background(), "zh-HK")
+		t.Assert(i18n.T(ctx, "hello"), "你好")
+		i18n.SetFallback("zh-HK", "zh-TW")
+		t.Assert(i18n.T(ctx, "hello"), "妳好")
+	})
+}
+
+func Test_TranslateParams(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gi18n.FormatNumber("en", 1234567.891, 2), "1,234,567.89")
+		t.Assert(gi18n.FormatNumber("de", -1234567.891, 1), "-1.234.567,9")
+		t.Assert(gi18n.FormatNumber("fr", 1234), "1 234")
+		t.Assert(gi18n.FormatParams("en", "{name} {unknown}", g.Map{"name": "john"}), "john {unknown}")
+		t.Assert(gi18n.FormatParams("de", "{day,date}", g.Map{"day": "2022-03-04"}), "04.03.2022")
+		t.Assert(gi18n.FormatParams("en", "{day,date,Y/m}", g.Map{"day": "2022-03-04"}), "2022/03")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		i18n := gi18n.New(gi18n.Options{
+			Path:     gtest.DataPath("i18n-plural"),
+			Language: "en",
+		})
+		t.Assert(
+			i18n.Tp(context.Background(), "greeting", g.Map{"name": "john", "amount": 1234.5, "date": "2022-03-04 12:00:00"}),
+			"Hello john, you paid 1,234.50 on 03/04/2022",
+		)
+	})
+}
+
+func Test_Source(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.Background()
+			content = "Hello"
+			i18n    = gi18n.New(gi18n.Options{
+				Path:     gtest.DataPath("i18n"),
+				Language: "en",
+			})
+		)
+		t.Assert(i18n.T(ctx, "{#hello}{#world}"), "HelloWorld")
+		err := i18n.AddSource(ctx, gi18n.SourceFunc(func(ctx context.Context) (map[string]map[string]string, error) {
+			return map[string]map[string]string{"en": {"hello": content, "remote": "Remote"}}, nil
+		}))
+		t.AssertNil(err)
+		t.Assert(i18n.T(ctx, "{#hello}{#world}{#remote}"), "HelloWorldRemote")
+
+		content = "Hi"
+		t.AssertNil(i18n.Refresh(ctx))
+		t.Assert(i18n.T(ctx, "{#hello}{#world}"), "HiWorld")
+	})
+	// Failed source keeps the previous translations.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx    = context.Background()
+			failed = false
+			i18n   = gi18n.New(gi18n.Options{Language: "en"})
+		)
+		err := i18n.AddSource(ctx, gi18n.SourceFunc(func(ctx context.Context) (map[string]map[string]string, error) {
+			if failed {
+				return nil, errors.New("failed")
+			}
+			return map[string]map[string]string{"en": {"hello": "Hello"}}, nil
+		}))
+		t.AssertNil(err)
+		failed = true
+		t.AssertNE(i18n.Refresh(ctx), nil)
+		t.Assert(i18n.T(ctx, "hello"), "Hello")
+	})
+	// Periodic refresh.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx   = context.Background()
+			count = gtype.NewInt()
+			i18n  = gi18n.New(gi18n.Options{Language: "en"})
+		)
+		t.AssertNil(i18n.AddSource(ctx, gi18n.SourceFunc(func(ctx context.Context) (map[string]map[string]string, error) {
+			return map[string]map[string]string{"en": {"count": gconv.String(count.Add(1))}}, nil
+		})))
+		t.Assert(i18n.T(ctx, "count"), "1")
+		i18n.SetRefreshInterval(100 * time.Millisecond)
+		time.Sleep(350 * time.Millisecond)
+		i18n.SetRefreshInterval(0)
+		t.AssertGT(gconv.Int(i18n.T(ctx, "count")), 1)
+	})
+}
+
+func Test_HttpSource(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"en": {"hello": "Hello"}, "zh": {"hello": "你好", "apple": {"other": "{count}个苹果"}}}`))
+		}))
+		defer server.Close()
+
+		ctx := context.Background()
+		data, err := gi18n.NewHttpSource(gi18n.HttpSourceOptions{Url: server.URL}).Load(ctx)
+		t.AssertNE(err, nil)
+		t.AssertNil(data)
+
+		i18n := gi18n.New(gi18n.Options{Language: "en"})
+		err = i18n.AddSource(ctx, gi18n.NewHttpSource(gi18n.HttpSourceOptions{
+			Url:    server.URL,
+			Header: map[string]string{"Authorization": "token"},
+		}))
+		t.AssertNil(err)
+		t.Assert(i18n.T(ctx, "hello"), "Hello")
+		zhCtx := gi18n.WithLanguage(ctx, "zh")
+		t.Assert(i18n.T(zhCtx, "hello"), "你好")
+		t.Assert(i18n.Tn(zhCtx, "apple", 2), "2个苹果")
+	})
+}
+
+func Test_DbSource(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx    = context.Background()
+			i18n   = gi18n.New(gi18n.Options{Language: "en"})
+			source = gi18n.NewDbSource(gi18n.DbSourceOptions{
+				Query: func(ctx context.Context) ([]map[string]interface{}, error) {
+					return g.List{
+						{"lang": "en", "name": "hello", "content": "Hello"},
+						{"lang": "ja", "name": "hello", "content": "こんにちは"},
+					}, nil
+				},
+				LanguageField: "lang",
+				KeyField:      "name",
+				ValueField:    "content",
+			})
+		)
+		t.AssertNil(i18n.AddSource(ctx, source))
+		t.Assert(i18n.T(ctx, "hello"), "Hello")
+		t.Assert(i18n.T(gi18n.WithLanguage(ctx, "ja"), "hello"), "こんにちは")
+
+		_, err := gi18n.NewDbSource(gi18n.DbSourceOptions{}).Load(ctx)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_HotReload(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx  = context.Background()
+			path = gfile.Temp(gconv.String(gtime.TimestampNano()))
+			file = gfile.Join(path, "en.toml")
+		)
+		t.AssertNil(gfile.PutContents(file, `hello = "Hello"`))
+		defer gfile.Remove(path)
+
+		i18n := gi18n.New(gi18n.Options{Path: path, Language: "en"})
+		t.Assert(i18n.T(ctx, "hello"), "Hello")
+
+		t.AssertNil(gfile.PutContents(file, `hello = "Hi"`))
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(i18n.T(ctx, "hello"), "Hi")
+	})
+}
+
+func Test_Missing(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx      = context.Background()
+			zhCtx    = gi18n.WithLanguage(ctx, "zh-CN")
+			reported = garray.NewStrArray(true)
+			i18n     = gi18n.New(gi18n.Options{
+				Path:          gtest.DataPath("i18n"),
+				Language:      "en",
+				RecordMissing: true,
+			})
+		)
+		i18n.SetMissingHandler(func(ctx context.Context, language, key string) {
+			reported.Append(language + ":" + key)
+		})
+		t.Assert(i18n.T(zhCtx, "{#hello}{#title}"), "你好{#title}")
+		t.Assert(i18n.T(zhCtx, "title"), "title")
+		t.Assert(i18n.T(ctx, "not a key"), "not a key")
+		t.Assert(i18n.GetContent(ctx, "content"), "")
+		t.Assert(i18n.Tn(ctx, "apple", 1), "apple")
+		t.Assert(i18n.T(ctx, "hello"), "Hello")
+		t.Assert(i18n.GetMissing(), map[string]map[string]int{
+			"zh-CN": {"title": 2},
+			"en":    {"content": 1, "apple": 1},
+		})
+		t.Assert(reported.Slice(), []string{"zh-CN:title", "zh-CN:title", "en:content", "en:apple"})
+
+		i18n.ClearMissing()
+		i18n.SetRecordMissing(false)
+		i18n.T(ctx, "title")
+		t.Assert(i18n.GetMissing(), map[string]map[string]int{})
+		t.Assert(reported.Len(), 5)
+	})
+}
+
+func Test_ExtractKeys(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		i18n := gi18n.New(gi18n.Options{
+			Path:     gtest.DataPath("i18n"),
+			Language: "en",
+		})
+		keys, err := i18n.ExtractKeys(gtest.DataPath("i18n-extract"))
+		t.AssertNil(err)
+		t.Assert(keys, []string{"apple", "greeting", "hello", "title", "world"})
+
+		keys, err = i18n.ExtractKeys(gtest.DataPath("i18n-extract"), "*.html")
+		t.AssertNil(err)
+		t.Assert(keys, []string{"hello", "title", "world"})
+
+		missing := i18n.CheckKeys(context.Background(), keys)
+		t.Assert(missing["en"], []string{"title"})
+		t.Assert(missing["zh-CN"], []string{"title"})
+	})
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/i18n/gi18n"
+)
+
+func main() {
+	ctx := context.Background()
+	g.I18n().T(ctx, "hello")
+	gi18n.Tf(ctx, `greeting`, "john")
+	gi18n.Tn(ctx, "apple", 2)
+	gi18n.T(ctx, "{#world} text")
+	gi18n.T(ctx, "not a key")
+}
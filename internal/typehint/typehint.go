@@ -0,0 +1,224 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package typehint provides JSON encoding with type hints, which keeps the Go types of values,
+// like int64 and nested containers, through JSON encoding and decoding.
+//
+// A value of registered type is encoded as object: {"@type": "int64", "@value": 1},
+// and the values of unregistered types are encoded as they are.
+package typehint
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// Marshaler is the interface for containers marshaling their values with type hints.
+type Marshaler interface {
+	MarshalJSONWithTypeHint() ([]byte, error)
+}
+
+// Value is the type hint object of value.
+type Value struct {
+	Type  string      `json:"@type"`
+	Value interface{} `json:"@value"`
+}
+
+// Keys of the type hint object.
+const (
+	KeyType  = "@type"
+	KeyValue = "@value"
+)
+
+type entry struct {
+	name    string
+	typ     reflect.Type
+	factory func() interface{} // Factory creating pointer for decoding, it uses reflect.New if it is nil.
+}
+
+var (
+	mu          sync.RWMutex
+	nameEntries = make(map[string]*entry)
+	typeEntries = make(map[reflect.Type]*entry)
+	keyTypeHint = []byte(`"` + KeyType + `"`)
+)
+
+func init() {
+	for _, v := range []interface{}{
+		int(0), int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0),
+		float32(0), float64(0), []byte(nil), time.Time{},
+	} {
+		Register(v, nil)
+	}
+}
+
+// Register registers the type of `value` with its type name, like: int64, *gmap.StrAnyMap.
+// The optional `factory` creates the pointer for decoding the value, which is usually used for
+// containers that should be created with constructor. If `value` is pointer, the `factory`
+// should return the pointer value itself, or else it should return pointer to the value.
+func Register(value interface{}, factory func() interface{}) {
+	typ := reflect.TypeOf(value)
+	e := &entry{
+		name:    typ.String(),
+		typ:     typ,
+		factory: factory,
+	}
+	mu.Lock()
+	nameEntries[e.name] = e
+	typeEntries[typ] = e
+	mu.Unlock()
+}
+
+// Contains checks whether JSON `b` might contain type hints, which is used for fast checking.
+func Contains(b []byte) bool {
+	return bytes.Contains(b, keyTypeHint)
+}
+
+// Encode converts `value` to the value with type hints for JSON encoding.
+// The values of slice and map with string keys are converted recursively.
+func Encode(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, string, bool:
+		return v, nil
+
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, item := range v {
+			encoded, err := Encode(item)
+			if err != nil {
+				return nil, err
+			}
+			array[i] = encoded
+		}
+		return array, nil
+
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			encoded, err := Encode(item)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = encoded
+		}
+		return m, nil
+	}
+	e := getEntryByType(reflect.TypeOf(value))
+	if e == nil {
+		return value, nil
+	}
+	if marshaler, ok := value.(Marshaler); ok {
+		b, err := marshaler.MarshalJSONWithTypeHint()
+		if err != nil {
+			return nil, err
+		}
+		return Value{Type: e.name, Value: json.RawMessage(b)}, nil
+	}
+	return Value{Type: e.name, Value: value}, nil
+}
+
+// Decode decodes JSON `b` with type hints, which restores the values of registered types.
+// The objects and arrays are decoded as map[string]interface{} and []interface{} recursively,
+// and the numbers without type hints are decoded as json.Number.
+func Decode(b []byte) (interface{}, error) {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return nil, nil
+	}
+	switch b[0] {
+	case '{':
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(b, &fields); err != nil {
+			return nil, err
+		}
+		if len(fields) == 2 {
+			if v, ok, err := decodeValue(fields); ok || err != nil {
+				return v, err
+			}
+		}
+		m := make(map[string]interface{}, len(fields))
+		for key, field := range fields {
+			v, err := Decode(field)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = v
+		}
+		return m, nil
+
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(b, &items); err != nil {
+			return nil, err
+		}
+		array := make([]interface{}, len(items))
+		for i, item := range items {
+			v, err := Decode(item)
+			if err != nil {
+				return nil, err
+			}
+			array[i] = v
+		}
+		return array, nil
+
+	default:
+		var v interface{}
+		if err := json.UnmarshalUseNumber(b, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+}
+
+// decodeValue decodes the type hint object `fields`.
+// It returns false if `fields` is not a type hint object of registered type.
+func decodeValue(fields map[string]json.RawMessage) (value interface{}, ok bool, err error) {
+	typeBytes, ok1 := fields[KeyType]
+	valueBytes, ok2 := fields[KeyValue]
+	if !ok1 || !ok2 {
+		return nil, false, nil
+	}
+	var name string
+	if json.Unmarshal(typeBytes, &name) != nil {
+		return nil, false, nil
+	}
+	e := getEntryByName(name)
+	if e == nil {
+		return nil, false, nil
+	}
+	if e.factory != nil {
+		pointer := e.factory()
+		if err = json.Unmarshal(valueBytes, pointer); err != nil {
+			return nil, true, err
+		}
+		if e.typ.Kind() == reflect.Ptr {
+			return pointer, true, nil
+		}
+		return reflect.ValueOf(pointer).Elem().Interface(), true, nil
+	}
+	pointer := reflect.New(e.typ)
+	if err = json.Unmarshal(valueBytes, pointer.Interface()); err != nil {
+		return nil, true, err
+	}
+	return pointer.Elem().Interface(), true, nil
+}
+
+func getEntryByType(typ reflect.Type) *entry {
+	mu.RLock()
+	defer mu.RUnlock()
+	return typeEntries[typ]
+}
+
+func getEntryByName(name string) *entry {
+	mu.RLock()
+	defer mu.RUnlock()
+	return nameEntries[name]
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/gogf/gf/v2/errors/gerror"
 )
 
+// copyBufferSize is the chunk size for CopyWithProgress.
+const copyBufferSize = 32 * 1024
+
 // ReadCloser implements the io.ReadCloser interface
 // which is used for reading request body content multiple times.
 //
@@ -64,3 +67,28 @@ func (b *ReadCloser) Read(p []byte) (n int, err error) {
 func (b *ReadCloser) Close() error {
 	return nil
 }
+
+// CopyWithProgress copies from `src` to `dst` chunk by chunk until EOF and returns the written bytes count.
+// The optional `progress` is called with the total written bytes count after each chunk is written.
+func CopyWithProgress(dst io.Writer, src io.Reader, progress func(written int64)) (written int64, err error) {
+	if progress == nil {
+		return io.Copy(dst, src)
+	}
+	buffer := make([]byte, copyBufferSize)
+	for {
+		n, readErr := src.Read(buffer)
+		if n > 0 {
+			if _, err = dst.Write(buffer[:n]); err != nil {
+				return written, err
+			}
+			written += int64(n)
+			progress(written)
+		}
+		if readErr == io.EOF {
+			return written, nil
+		}
+		if readErr != nil {
+			return written, readErr
+		}
+	}
+}
@@ -33,6 +33,7 @@ type Client struct {
 	retryInterval     time.Duration     // Retry interval when request fails.
 	middlewareHandler []HandlerFunc     // Interceptor handlers
 	selectorBuilder   gsel.Builder      // Builder for request balance.
+	dnsResolver       *dnsResolver      // Resolver with DNS cache for connections.
 }
 
 const (
@@ -0,0 +1,178 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Page is the page extracted from the response of paginated API.
+type Page struct {
+	Items    []interface{} // Items of the page.
+	NextUrl  string        // URL of the next page, which stops paginating if it is empty.
+	NextData interface{}   // Request data of the next page, eg: cursor parameters.
+}
+
+// PageExtractFunc extracts and returns the page from response `resp` of paginated API,
+// in which the items are decoded and the next page is determined by cursor or url.
+// The response is closed after extracting.
+type PageExtractFunc func(ctx context.Context, resp *Response) (*Page, error)
+
+// PaginateOption is the option for iterating through paginated API, see Client.Paginate.
+type PaginateOption struct {
+	Method   string          // Request method, which is GET in default.
+	Url      string          // Request URL of the first page.
+	Data     interface{}     // Request data of the first page.
+	Extract  PageExtractFunc // Page extraction callback, which is required.
+	Interval time.Duration   // Minimum interval between page requests for rate limiting.
+	MaxPages int             // Maximum pages to request, no limit if it is not greater than 0.
+}
+
+// PageIterator iterates through the items of paginated API, which requests pages on demand.
+//
+// Example:
+//
+//	iterator := client.Paginate(ctx, option)
+//	for iterator.Next() {
+//	    item := iterator.Item()
+//	}
+//	if err := iterator.Err(); err != nil {
+//	    // ...
+//	}
+type PageIterator struct {
+	ctx       context.Context
+	client    *Client
+	option    PaginateOption
+	url       string        // URL of the next page to request.
+	data      interface{}   // Request data of the next page to request.
+	items     []interface{} // Items of current page not iterated.
+	item      interface{}   // Current item.
+	pages     int           // Count of requested pages.
+	done      bool          // Whether there's no more page.
+	err       error         // Error that stops iterating.
+	requestAt time.Time     // Time of last page request.
+}
+
+// Paginate creates and returns an iterator through the items of paginated API, which requests
+// the first page by `option.Url` and `option.Data`, and requests the next pages determined by
+// `option.Extract` until no next page, the maximum pages reached or any error occurs.
+func (c *Client) Paginate(ctx context.Context, option PaginateOption) *PageIterator {
+	if option.Method == "" {
+		option.Method = http.MethodGet
+	}
+	iterator := &PageIterator{
+		ctx:    ctx,
+		client: c,
+		option: option,
+		url:    option.Url,
+		data:   option.Data,
+	}
+	if option.Extract == nil {
+		iterator.err = gerror.NewCode(gcode.CodeMissingParameter, `page extraction callback is required for paginating`)
+	}
+	return iterator
+}
+
+// Next advances the iterator to the next item, which requests the next page if the items of
+// current page are all iterated. It returns false if there's no more item or any error occurs.
+func (it *PageIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.err != nil || it.done {
+			it.item = nil
+			return false
+		}
+		if it.err = it.fetch(); it.err != nil {
+			it.item = nil
+			return false
+		}
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item returns the current item of the iterator.
+func (it *PageIterator) Item() interface{} {
+	return it.item
+}
+
+// Err returns the error that stops iterating, or nil if there's no more item.
+func (it *PageIterator) Err() error {
+	return it.err
+}
+
+// Pages returns the count of requested pages.
+func (it *PageIterator) Pages() int {
+	return it.pages
+}
+
+// Chan returns a channel of the items, which is closed if there's no more item or any error
+// occurs, and Err should be checked after the channel is closed.
+// Note that the iterator should not be used elsewhere after calling Chan.
+func (it *PageIterator) Chan() <-chan interface{} {
+	ch := make(chan interface{})
+	go func() {
+		defer close(ch)
+		for it.Next() {
+			select {
+			case ch <- it.Item():
+			case <-it.ctx.Done():
+				it.err = it.ctx.Err()
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// fetch requests and extracts the next page.
+func (it *PageIterator) fetch() error {
+	if it.option.MaxPages > 0 && it.pages >= it.option.MaxPages {
+		it.done = true
+		return nil
+	}
+	// Rate limiting.
+	if it.option.Interval > 0 && !it.requestAt.IsZero() {
+		if wait := it.option.Interval - time.Since(it.requestAt); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-it.ctx.Done():
+				timer.Stop()
+				return it.ctx.Err()
+			}
+		}
+	}
+	it.requestAt = time.Now()
+	var data []interface{}
+	if it.data != nil {
+		data = append(data, it.data)
+	}
+	resp, err := it.client.DoRequest(it.ctx, it.option.Method, it.url, data...)
+	if err != nil {
+		return gerror.Wrapf(err, `request page %d "%s" failed`, it.pages+1, it.url)
+	}
+	defer resp.Close()
+	it.pages++
+	page, err := it.option.Extract(it.ctx, resp)
+	if err != nil {
+		return gerror.Wrapf(err, `extract page %d "%s" failed`, it.pages, it.url)
+	}
+	if page == nil || page.NextUrl == "" {
+		it.done = true
+	} else {
+		it.url, it.data = page.NextUrl, page.NextData
+	}
+	if page != nil {
+		it.items = page.Items
+	}
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/gogf/gf/v2/internal/httputil"
 	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/internal/utils"
+	"github.com/gogf/gf/v2/os/gctx"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/text/gregex"
 	"github.com/gogf/gf/v2/text/gstr"
@@ -294,6 +295,10 @@ func (c *Client) prepareRequest(ctx context.Context, method, url string, data ..
 			req.Header.Set(k, v)
 		}
 	}
+	// Request id propagation.
+	if requestId := gctx.RequestId(ctx); requestId != "" && req.Header.Get(gctx.RequestIdHeader) == "" {
+		req.Header.Set(gctx.RequestIdHeader, requestId)
+	}
 	// It's necessary set the req.Host if you want to custom the host value of the request.
 	// It uses the "Host" value from header if it's not empty.
 	if reqHeaderHost := req.Header.Get(httpHeaderHost); reqHeaderHost != "" {
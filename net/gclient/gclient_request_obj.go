@@ -9,11 +9,13 @@ package gclient
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"reflect"
 
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/net/goai"
+	"github.com/gogf/gf/v2/os/gstructs"
 	"github.com/gogf/gf/v2/text/gregex"
 	"github.com/gogf/gf/v2/text/gstr"
 	"github.com/gogf/gf/v2/util/gconv"
@@ -54,7 +56,22 @@ func (c *Client) DoRequestObj(ctx context.Context, req, res interface{}) error {
 			goai.TagNamePath, reflect.TypeOf(req).String(),
 		)
 	}
-	path = c.handlePathForObjRequest(path, req)
+	requestInfo, err := gstructs.RequestInfoOf(req)
+	if err != nil {
+		return err
+	}
+	var (
+		client = c
+		data   = c.buildDataForObjRequest(req, requestInfo, method)
+	)
+	path = c.handlePathForObjRequest(path, data[gstructs.ParamInPath])
+	path = c.handleQueryForObjRequest(path, method, data[gstructs.ParamInQuery])
+	if headers := gconv.MapStrStr(data[gstructs.ParamInHeader]); len(headers) > 0 {
+		client = client.Header(headers)
+	}
+	if cookies := gconv.MapStrStr(data[gstructs.ParamInCookie]); len(cookies) > 0 {
+		client = client.Cookie(cookies)
+	}
 	switch gstr.ToUpper(method) {
 	case
 		http.MethodGet,
@@ -66,7 +83,7 @@ func (c *Client) DoRequestObj(ctx context.Context, req, res interface{}) error {
 		http.MethodConnect,
 		http.MethodOptions,
 		http.MethodTrace:
-		if result := c.RequestVar(ctx, method, path, req); res != nil && !result.IsEmpty() {
+		if result := client.RequestVar(ctx, method, path, data[gstructs.ParamInBody]); res != nil && !result.IsEmpty() {
 			return result.Scan(res)
 		}
 		return nil
@@ -76,22 +93,81 @@ func (c *Client) DoRequestObj(ctx context.Context, req, res interface{}) error {
 	}
 }
 
-// handlePathForObjRequest replaces parameters in `path` with parameters from request object.
+// buildDataForObjRequest retrieves the parameters from request object `req` and returns them grouped by
+// their locations, which are the same as ghttp binding, see gstructs.RequestInfoOf.
+// The query parameters are put in body data for the methods without body, like GET.
+func (c *Client) buildDataForObjRequest(
+	req interface{}, requestInfo *gstructs.RequestInfo, method string,
+) map[string]map[string]interface{} {
+	var (
+		data = map[string]map[string]interface{}{
+			gstructs.ParamInPath:   {},
+			gstructs.ParamInQuery:  {},
+			gstructs.ParamInHeader: {},
+			gstructs.ParamInCookie: {},
+			gstructs.ParamInBody:   {},
+		}
+		reflectValue = reflect.ValueOf(req)
+		hasBody      = methodHasBody(method)
+	)
+	for _, param := range requestInfo.Params {
+		value := param.Field.Value(reflectValue)
+		if !value.IsValid() {
+			continue
+		}
+		in := param.In
+		if in == gstructs.ParamInQuery && !hasBody {
+			in = gstructs.ParamInBody
+		}
+		data[in][param.Name] = value.Interface()
+	}
+	return data
+}
+
+// handlePathForObjRequest replaces parameters in `path` with path parameters `params` from request object.
 // Eg:
 // /order/{id}  -> /order/1
 // /user/{name} -> /order/john
-func (c *Client) handlePathForObjRequest(path string, req interface{}) string {
-	if gstr.Contains(path, "{") {
-		requestParamsMap := gconv.Map(req)
-		if len(requestParamsMap) > 0 {
-			path, _ = gregex.ReplaceStringFuncMatch(`\{(\w+)\}`, path, func(match []string) string {
-				foundKey, foundValue := gutil.MapPossibleItemByKey(requestParamsMap, match[1])
-				if foundKey != "" {
-					return gconv.String(foundValue)
-				}
-				return match[0]
-			})
-		}
+func (c *Client) handlePathForObjRequest(path string, params map[string]interface{}) string {
+	if gstr.Contains(path, "{") && len(params) > 0 {
+		path, _ = gregex.ReplaceStringFuncMatch(`\{(\w+)\}`, path, func(match []string) string {
+			foundKey, foundValue := gutil.MapPossibleItemByKey(params, match[1])
+			if foundKey != "" {
+				return gconv.String(foundValue)
+			}
+			return match[0]
+		})
 	}
 	return path
 }
+
+// handleQueryForObjRequest appends query parameters `params` from request object to `path`
+// for the methods with body, like POST.
+func (c *Client) handleQueryForObjRequest(path, method string, params map[string]interface{}) string {
+	if len(params) == 0 || !methodHasBody(method) {
+		return path
+	}
+	values := url.Values{}
+	for k, v := range params {
+		if reflectValue := reflect.ValueOf(v); reflectValue.Kind() == reflect.Slice {
+			if _, ok := v.([]byte); !ok {
+				values[k] = gconv.Strings(v)
+				continue
+			}
+		}
+		values.Set(k, gconv.String(v))
+	}
+	if gstr.Contains(path, "?") {
+		return path + "&" + values.Encode()
+	}
+	return path + "?" + values.Encode()
+}
+
+// methodHasBody checks whether the request of `method` carries parameters in body.
+func methodHasBody(method string) bool {
+	switch gstr.ToUpper(method) {
+	case http.MethodGet, http.MethodDelete, http.MethodHead:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,181 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// Resolver is the interface for resolving host to addresses for client connections,
+// which is implemented by *net.Resolver.
+type Resolver interface {
+	// LookupHost looks up the given host and returns a slice of its addresses.
+	LookupHost(ctx context.Context, host string) (addrs []string, err error)
+}
+
+// ResolverTTL is the optional interface of Resolver, which returns the TTL of the addresses.
+// The DNS cache of client respects the returned TTL if it is greater than 0, or else it uses
+// the TTL configured by Client.SetDnsCache.
+type ResolverTTL interface {
+	// LookupHostTTL looks up the given host and returns a slice of its addresses and their TTL.
+	LookupHostTTL(ctx context.Context, host string) (addrs []string, ttl time.Duration, err error)
+}
+
+// ResolverFunc is the function implementing Resolver, eg: static host mapping.
+type ResolverFunc func(ctx context.Context, host string) (addrs []string, err error)
+
+// dnsResolver resolves host for client connections with custom Resolver and DNS cache.
+type dnsResolver struct {
+	mu       sync.RWMutex
+	resolver Resolver                 // Custom resolver, it uses net.DefaultResolver if nil.
+	cacheTTL time.Duration            // TTL of DNS cache, it disables caching if it is not greater than 0.
+	cache    map[string]*dnsCacheItem // Cached addresses of hosts.
+	dialer   *net.Dialer              // Dialer for addresses.
+}
+
+// dnsCacheItem is the cached addresses of host.
+type dnsCacheItem struct {
+	addrs    []string
+	expireAt time.Time
+}
+
+// LookupHost implements interface Resolver.
+func (f ResolverFunc) LookupHost(ctx context.Context, host string) (addrs []string, err error) {
+	return f(ctx, host)
+}
+
+// SetResolver sets custom Resolver for resolving host of the client connections,
+// eg: static host mapping or DNS of service registry like consul.
+// It uses the default resolver of system if `resolver` is nil.
+//
+// Note that it replaces the dialing of the underlying Transport, and it does nothing
+// if the Transport of the client is customized.
+func (c *Client) SetResolver(resolver Resolver) *Client {
+	if r := c.getDnsResolver(); r != nil {
+		r.mu.Lock()
+		r.resolver = resolver
+		r.cache = make(map[string]*dnsCacheItem)
+		r.mu.Unlock()
+	}
+	return c
+}
+
+// SetDnsCache enables the DNS cache of the client, which caches the resolved addresses of hosts
+// for `ttl` to avoid the resolving latency of each connection. The TTL returned by Resolver that
+// implements ResolverTTL takes precedence over `ttl`. It disables the DNS cache if `ttl` is not
+// greater than 0.
+//
+// Note that it replaces the dialing of the underlying Transport, and it does nothing
+// if the Transport of the client is customized.
+func (c *Client) SetDnsCache(ttl time.Duration) *Client {
+	if r := c.getDnsResolver(); r != nil {
+		r.mu.Lock()
+		r.cacheTTL = ttl
+		r.cache = make(map[string]*dnsCacheItem)
+		r.mu.Unlock()
+	}
+	return c
+}
+
+// getDnsResolver returns the dnsResolver of the client, which is created and
+// installed to the underlying Transport if it is not created yet.
+// It returns nil if the Transport of the client is customized.
+func (c *Client) getDnsResolver() *dnsResolver {
+	if c.dnsResolver != nil {
+		return c.dnsResolver
+	}
+	transport, ok := c.Transport.(*http.Transport)
+	if !ok {
+		intlog.Print(context.TODO(), `cannot set resolver for custom Transport of the client`)
+		return nil
+	}
+	c.dnsResolver = &dnsResolver{
+		cache: make(map[string]*dnsCacheItem),
+		dialer: &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		},
+	}
+	transport.DialContext = c.dnsResolver.DialContext
+	return c.dnsResolver
+}
+
+// DialContext dials `addr` with the addresses resolved by the resolver, which tries each
+// address in order until one succeeds.
+func (r *dnsResolver) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `invalid address "%s"`, addr)
+	}
+	if net.ParseIP(host) != nil {
+		return r.dialer.DialContext(ctx, network, addr)
+	}
+	addrs, err := r.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var conn net.Conn
+	for _, ip := range addrs {
+		if conn, err = r.dialer.DialContext(ctx, network, net.JoinHostPort(ip, port)); err == nil {
+			return conn, nil
+		}
+	}
+	return nil, err
+}
+
+// LookupHost returns the addresses of `host` from cache or resolver.
+func (r *dnsResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.mu.RLock()
+	var (
+		resolver = r.resolver
+		cacheTTL = r.cacheTTL
+		item     = r.cache[host]
+	)
+	r.mu.RUnlock()
+	if item != nil && time.Now().Before(item.expireAt) {
+		return item.addrs, nil
+	}
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	var (
+		addrs []string
+		ttl   time.Duration
+		err   error
+	)
+	if v, ok := resolver.(ResolverTTL); ok {
+		addrs, ttl, err = v.LookupHostTTL(ctx, host)
+	} else {
+		addrs, err = resolver.LookupHost(ctx, host)
+	}
+	if err != nil {
+		return nil, gerror.Wrapf(err, `lookup host "%s" failed`, host)
+	}
+	if len(addrs) == 0 {
+		return nil, gerror.NewCodef(gcode.CodeNotFound, `no address found for host "%s"`, host)
+	}
+	if ttl <= 0 {
+		ttl = cacheTTL
+	}
+	if cacheTTL > 0 && ttl > 0 {
+		r.mu.Lock()
+		r.cache[host] = &dnsCacheItem{
+			addrs:    addrs,
+			expireAt: time.Now().Add(ttl),
+		}
+		r.mu.Unlock()
+	}
+	return addrs, nil
+}
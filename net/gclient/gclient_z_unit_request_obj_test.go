@@ -15,6 +15,7 @@ import (
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/net/gtcp"
 	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
 )
 
 func Test_Client_DoRequestObj(t *testing.T) {
@@ -79,3 +80,70 @@ func Test_Client_DoRequestObj(t *testing.T) {
 		t.Assert(queryRes.Name, "john")
 	})
 }
+
+func Test_Client_DoRequestObj_ParamsIn(t *testing.T) {
+	type UserUpdateReq struct {
+		g.Meta  `path:"/user/{id}" method:"put"`
+		Id      int    `json:"id"`
+		Token   string `p:"X-Token" in:"header" v:"required"`
+		Session string `json:"session" in:"cookie"`
+		Fields  string `json:"fields" in:"query"`
+		Name    string `json:"name"`
+	}
+	type UserUpdateRes struct {
+		Id      int
+		Token   string
+		Session string
+		Fields  string
+		Name    string
+		Query   string
+	}
+	s := g.Server(guid.S())
+	s.BindHandler("PUT:/user/{id}", func(r *ghttp.Request) {
+		var req *UserUpdateReq
+		if err := r.Parse(&req); err != nil {
+			r.Response.WriteExit(err.Error())
+		}
+		r.Response.WriteJson(UserUpdateRes{
+			Id:      req.Id,
+			Token:   req.Token,
+			Session: req.Session,
+			Fields:  req.Fields,
+			Name:    req.Name,
+			Query:   r.URL.RawQuery,
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())).ContentJson()
+		var (
+			res *UserUpdateRes
+			req = UserUpdateReq{
+				Id:      1,
+				Token:   "token",
+				Session: "session",
+				Fields:  "id,name",
+				Name:    "john",
+			}
+		)
+		err := client.DoRequestObj(ctx, req, &res)
+		t.AssertNil(err)
+		t.Assert(res, &UserUpdateRes{
+			Id:      1,
+			Token:   "token",
+			Session: "session",
+			Fields:  "id,name",
+			Name:    "john",
+			Query:   "fields=id%2Cname",
+		})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())).ContentJson()
+		content := client.PutContent(ctx, "/user/1", g.Map{"name": "john"})
+		t.Assert(content, "The Token field is required")
+	})
+}
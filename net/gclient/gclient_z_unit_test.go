@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/gogf/gf/v2/debug/gdebug"
+	"github.com/gogf/gf/v2/encoding/gjson"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
@@ -664,3 +665,112 @@ func TestClient_SetBodyContent(t *testing.T) {
 		t.Assert(res.ReadAllString(), "world")
 	})
 }
+
+func Test_Client_Resolver(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/hello", func(r *ghttp.Request) {
+		r.Response.Write("hello")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			count    = 0
+			resolver = gclient.ResolverFunc(func(ctx context.Context, host string) ([]string, error) {
+				count++
+				if host == "gf.local" {
+					return []string{"127.0.0.1"}, nil
+				}
+				return nil, fmt.Errorf(`unknown host "%s"`, host)
+			})
+			url    = fmt.Sprintf("http://gf.local:%d", s.GetListenedPort())
+			client = gclient.New().SetResolver(resolver)
+		)
+		// Without cache.
+		t.Assert(client.GetContent(ctx, url+"/hello"), "hello")
+		t.Assert(client.GetContent(ctx, url+"/hello"), "hello")
+		t.Assert(count, 2)
+
+		// With cache.
+		client.SetDnsCache(time.Minute)
+		t.Assert(client.GetContent(ctx, url+"/hello"), "hello")
+		t.Assert(client.GetContent(ctx, url+"/hello"), "hello")
+		t.Assert(count, 3)
+
+		// Unknown host.
+		_, err := client.Get(ctx, fmt.Sprintf("http://unknown.local:%d/hello", s.GetListenedPort()))
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Client_Paginate(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/items", func(r *ghttp.Request) {
+		var (
+			cursor = r.Get("cursor").Int()
+			items  = g.Slice{cursor*2 + 1, cursor*2 + 2}
+			next   = cursor + 1
+		)
+		if next == 3 {
+			next = 0
+		}
+		r.Response.WriteJson(g.Map{"items": items, "next": next})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client = g.Client().SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+			option = gclient.PaginateOption{
+				Url:      "/items",
+				Interval: 50 * time.Millisecond,
+				Extract: func(ctx context.Context, resp *gclient.Response) (*gclient.Page, error) {
+					j, err := gjson.LoadContent(resp.ReadAll())
+					if err != nil {
+						return nil, err
+					}
+					page := &gclient.Page{Items: j.Get("items").Array()}
+					if next := j.Get("next").Int(); next > 0 {
+						page.NextUrl = "/items"
+						page.NextData = g.Map{"cursor": next}
+					}
+					return page, nil
+				},
+			}
+			items []interface{}
+			start = time.Now()
+		)
+		iterator := client.Paginate(ctx, option)
+		for iterator.Next() {
+			items = append(items, iterator.Item())
+		}
+		t.AssertNil(iterator.Err())
+		t.Assert(items, g.Slice{1, 2, 3, 4, 5, 6})
+		t.Assert(iterator.Pages(), 3)
+		t.AssertGE(time.Since(start), 100*time.Millisecond)
+
+		// Maximum pages with channel.
+		option.MaxPages = 2
+		items = items[:0]
+		iterator = client.Paginate(ctx, option)
+		for item := range iterator.Chan() {
+			items = append(items, item)
+		}
+		t.AssertNil(iterator.Err())
+		t.Assert(items, g.Slice{1, 2, 3, 4})
+
+		// Extraction error.
+		option.Extract = func(ctx context.Context, resp *gclient.Response) (*gclient.Page, error) {
+			return nil, gerror.New("invalid page")
+		}
+		iterator = client.Paginate(ctx, option)
+		t.Assert(iterator.Next(), false)
+		t.AssertNE(iterator.Err(), nil)
+	})
+}
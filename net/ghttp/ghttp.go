@@ -10,6 +10,7 @@ package ghttp
 import (
 	"net/http"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/gogf/gf/v2/errors/gcode"
@@ -29,6 +30,7 @@ type (
 	Server struct {
 		instance         string                    // Instance name of current HTTP server.
 		config           ServerConfig              // Server configuration.
+		configMu         sync.RWMutex              // Concurrent safety mutex for the runtime reloadable configurations, see ReloadConfig.
 		plugins          []Plugin                  // Plugin array to extend server functionality.
 		servers          []*gracefulServer         // Underlying http.Server array.
 		serverCount      *gtype.Int                // Underlying http.Server number for internal usage.
@@ -40,6 +42,12 @@ type (
 		sessionManager   *gsession.Manager         // Session manager.
 		openapi          *goai.OpenApiV3           // The OpenApi specification management object.
 		service          gsvc.Service              // The service for Registry.
+		responseEnvelope ResponseEnvelope          // Global ResponseEnvelope overriding DefaultResponseEnvelope.
+		tlsCertificate   *gtype.Interface          // The *tls.Certificate loaded from certification files, which is reloadable.
+		apiVersion       *apiVersionManager        // API versioning management, see SetAPIVersion.
+		listenerHandlers map[string][]HandlerFunc  // Middleware of listeners, see BindListenerMiddleware.
+		maintenance      *maintenanceManager       // Maintenance mode management, see SetMaintenance.
+		diagnosticsLogs  *logRingBuffer            // Recent logs for panic diagnostics, see SetPanicDiagnostics.
 	}
 
 	// Router object.
@@ -71,9 +79,10 @@ type (
 
 	// handlerFuncInfo contains the HandlerFunc address and its reflection type.
 	handlerFuncInfo struct {
-		Func  HandlerFunc   // Handler function address.
-		Type  reflect.Type  // Reflect type information for current handler, which is used for extensions of the handler feature.
-		Value reflect.Value // Reflect value information for current handler, which is used for extensions of the handler feature.
+		Func     HandlerFunc   // Handler function address.
+		Type     reflect.Type  // Reflect type information for current handler, which is used for extensions of the handler feature.
+		Value    reflect.Value // Reflect value information for current handler, which is used for extensions of the handler feature.
+		Envelope string        // Registered ResponseEnvelope name from the meta tag of request structure.
 	}
 
 	// HandlerItem is the registered handler for route handling,
@@ -129,6 +138,7 @@ const (
 	contentTypeXml          = "text/xml"
 	contentTypeHtml         = "text/html"
 	contentTypeJson         = "application/json"
+	contentTypeMsgpack      = "application/msgpack"
 	swaggerUIPackedPath     = "/goframe/swaggerui"
 	responseTraceIDHeader   = "Trace-ID"
 	specialMethodNameInit   = "Init"
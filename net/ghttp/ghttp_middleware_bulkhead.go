@@ -0,0 +1,168 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// BulkheadOption is the option for MiddlewareBulkhead.
+type BulkheadOption struct {
+	// MaxConcurrent is the max count of in-flight requests of a partition, it is required.
+	MaxConcurrent int
+
+	// MaxQueue is the max count of requests waiting for a slot of a partition.
+	// The requests are rejected immediately when all slots are taken if it is 0.
+	MaxQueue int
+
+	// QueueTimeout is the max duration a request waits in queue, default is 1 second.
+	QueueTimeout time.Duration
+
+	// RetryAfter is the duration in "Retry-After" response header of rejected requests, default is 1 second.
+	RetryAfter time.Duration
+
+	// KeyFunc returns the partition key of request, default is BulkheadKeyByRoute.
+	// The requests of the same key share the same limit.
+	KeyFunc func(r *Request) string
+}
+
+// bulkheadManager manages the bulkheads of all partitions.
+type bulkheadManager struct {
+	mu        sync.Mutex
+	option    BulkheadOption
+	bulkheads map[string]*bulkhead
+}
+
+// bulkhead limits the in-flight requests of a partition.
+type bulkhead struct {
+	slots   chan struct{} // Semaphore for in-flight requests.
+	waiting int           // Count of waiting requests, which is protected by bulkheadManager.mu.
+	refs    int           // Count of requests using it, it is removed when no reference.
+}
+
+const (
+	defaultBulkheadQueueTimeout = time.Second
+	defaultBulkheadRetryAfter   = time.Second
+)
+
+// BulkheadKeyByRoute returns the key of the matched route and method of request,
+// which limits the in-flight requests per route.
+func BulkheadKeyByRoute(r *Request) string {
+	if r.Router != nil {
+		return r.Method + ":" + r.Router.Uri
+	}
+	return r.Method + ":" + r.URL.Path
+}
+
+// BulkheadKeyByHeader returns a KeyFunc that uses the value of request header `name` as the key,
+// like API key, which limits the in-flight requests per client. The requests without the header
+// share the same limit.
+func BulkheadKeyByHeader(name string) func(r *Request) string {
+	return func(r *Request) string {
+		return r.Header.Get(name)
+	}
+}
+
+// MiddlewareBulkhead returns a middleware handler limiting the in-flight requests per partition,
+// which is per route in default, with a waiting queue and timeout. The requests are rejected with
+// 503 and "Retry-After" header when saturated, which protects slow downstream from thundering herds.
+//
+// It panics if option.MaxConcurrent is not positive.
+func MiddlewareBulkhead(option BulkheadOption) HandlerFunc {
+	if option.MaxConcurrent <= 0 {
+		panic(`bulkhead MaxConcurrent should be greater than 0`)
+	}
+	if option.QueueTimeout <= 0 {
+		option.QueueTimeout = defaultBulkheadQueueTimeout
+	}
+	if option.RetryAfter <= 0 {
+		option.RetryAfter = defaultBulkheadRetryAfter
+	}
+	if option.KeyFunc == nil {
+		option.KeyFunc = BulkheadKeyByRoute
+	}
+	m := &bulkheadManager{
+		option:    option,
+		bulkheads: make(map[string]*bulkhead),
+	}
+	return func(r *Request) {
+		key := m.option.KeyFunc(r)
+		if !m.acquire(r, key) {
+			retryAfter := int64((m.option.RetryAfter + time.Second - 1) / time.Second)
+			r.Response.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+			r.Response.WriteStatus(http.StatusServiceUnavailable)
+			return
+		}
+		defer m.release(key)
+		r.Middleware.Next()
+	}
+}
+
+// acquire acquires a slot of partition `key`, it returns false if the partition is saturated
+// or the waiting is timeout.
+func (m *bulkheadManager) acquire(r *Request, key string) bool {
+	m.mu.Lock()
+	b := m.bulkheads[key]
+	if b == nil {
+		b = &bulkhead{
+			slots: make(chan struct{}, m.option.MaxConcurrent),
+		}
+		m.bulkheads[key] = b
+	}
+	b.refs++
+	select {
+	case b.slots <- struct{}{}:
+		m.mu.Unlock()
+		return true
+	default:
+	}
+	if b.waiting >= m.option.MaxQueue {
+		m.unref(key, b)
+		m.mu.Unlock()
+		return false
+	}
+	b.waiting++
+	m.mu.Unlock()
+
+	timer := time.NewTimer(m.option.QueueTimeout)
+	defer timer.Stop()
+	var acquired bool
+	select {
+	case b.slots <- struct{}{}:
+		acquired = true
+	case <-timer.C:
+	case <-r.Context().Done():
+	}
+	m.mu.Lock()
+	b.waiting--
+	if !acquired {
+		m.unref(key, b)
+	}
+	m.mu.Unlock()
+	return acquired
+}
+
+// release releases the slot of partition `key`.
+func (m *bulkheadManager) release(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if b := m.bulkheads[key]; b != nil {
+		<-b.slots
+		m.unref(key, b)
+	}
+}
+
+// unref decreases the reference of `b` and removes it if no reference, it should be called with lock.
+func (m *bulkheadManager) unref(key string, b *bulkhead) {
+	b.refs--
+	if b.refs == 0 {
+		delete(m.bulkheads, key)
+	}
+}
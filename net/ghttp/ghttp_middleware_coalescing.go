@@ -0,0 +1,164 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CoalescingOption is the option for MiddlewareCoalescing.
+type CoalescingOption struct {
+	// KeyFunc returns the key of request, the concurrent requests of the same key are coalesced,
+	// default is CoalescingKeyDefault.
+	KeyFunc func(r *Request) string
+
+	// WaitTimeout is the max duration a coalesced request waits for the response of the executing one,
+	// the request executes the handler by itself after timeout. It waits until the executing one finishes if it is 0.
+	WaitTimeout time.Duration
+}
+
+// coalescingGroup manages the executing calls of keys.
+type coalescingGroup struct {
+	mu    sync.Mutex
+	calls map[string]*coalescingCall
+}
+
+// coalescingCall is an executing handler call shared by the requests of the same key.
+type coalescingCall struct {
+	done   chan struct{} // Closed when the call finishes.
+	ok     bool          // Whether the call finishes normally and the response can be shared.
+	status int           // Response status.
+	header http.Header   // Response header.
+	body   []byte        // Response body.
+}
+
+const (
+	// coalescedHeader marks the response is shared from another executing request.
+	coalescedHeader = "X-Coalesced"
+)
+
+var (
+	// coalescingIgnoredHeaders are the response headers that are not shared.
+	coalescingIgnoredHeaders = map[string]struct{}{
+		"Set-Cookie":          {},
+		responseTraceIDHeader: {},
+	}
+)
+
+// CoalescingKeyDefault returns the key of request consisting of the normalized URL of which the query
+// parameters are sorted, and the hash of the auth principal, which is the "Authorization" and "Cookie"
+// headers, so that the requests of different users are never coalesced.
+func CoalescingKeyDefault(r *Request) string {
+	key := r.GetHost() + r.URL.Path
+	if r.URL.RawQuery != "" {
+		key += "?" + r.URL.Query().Encode()
+	}
+	return key + "#" + getRequestPrincipalHash(r)
+}
+
+// getRequestPrincipalHash returns the hash of the auth principal of the request,
+// which is the "Authorization" and "Cookie" headers.
+func getRequestPrincipalHash(r *Request) string {
+	principal := sha256.Sum256([]byte(r.Header.Get("Authorization") + "\n" + r.Header.Get("Cookie")))
+	return hex.EncodeToString(principal[:])
+}
+
+// MiddlewareCoalescing returns a middleware handler that collapses the concurrent identical GET
+// requests into one handler execution, and shares its response to all of them with header
+// "X-Coalesced: true", which protects the expensive read endpoints from cache-miss storms.
+//
+// The identical requests are the ones of the same key, see CoalescingKeyDefault. Note that the
+// handler should not write the response directly to the underlying writer, like streaming and
+// websocket, as only the buffered response can be shared.
+func MiddlewareCoalescing(option ...CoalescingOption) HandlerFunc {
+	var opt CoalescingOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.KeyFunc == nil {
+		opt.KeyFunc = CoalescingKeyDefault
+	}
+	group := &coalescingGroup{
+		calls: make(map[string]*coalescingCall),
+	}
+	return func(r *Request) {
+		if r.Method != http.MethodGet {
+			r.Middleware.Next()
+			return
+		}
+		key := opt.KeyFunc(r)
+		group.mu.Lock()
+		if call, ok := group.calls[key]; ok {
+			group.mu.Unlock()
+			if group.wait(r, call, opt.WaitTimeout) {
+				return
+			}
+			// Executes the handler by itself if the call fails or times out.
+			r.Middleware.Next()
+			return
+		}
+		call := &coalescingCall{done: make(chan struct{})}
+		group.calls[key] = call
+		group.mu.Unlock()
+
+		defer func() {
+			group.mu.Lock()
+			delete(group.calls, key)
+			group.mu.Unlock()
+			close(call.done)
+		}()
+
+		r.Middleware.Next()
+
+		call.status = r.Response.Status
+		if call.status == 0 {
+			call.status = http.StatusOK
+		}
+		call.header = make(http.Header)
+		for k, v := range r.Response.Header() {
+			if _, ok := coalescingIgnoredHeaders[k]; !ok {
+				call.header[k] = v
+			}
+		}
+		call.body = append([]byte(nil), r.Response.Buffer()...)
+		call.ok = true
+	}
+}
+
+// wait waits for the response of `call` and writes it to request `r`.
+// It returns false if the call fails or times out.
+func (g *coalescingGroup) wait(r *Request, call *coalescingCall, timeout time.Duration) bool {
+	var timeoutChan <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutChan = timer.C
+	}
+	select {
+	case <-call.done:
+	case <-timeoutChan:
+		return false
+	case <-r.Context().Done():
+		// The client is gone.
+		return true
+	}
+	if !call.ok {
+		return false
+	}
+	header := r.Response.Header()
+	for k, v := range call.header {
+		header[k] = v
+	}
+	header.Set(coalescedHeader, "true")
+	r.Response.WriteHeader(call.status)
+	r.Response.SetBuffer(call.body)
+	return true
+}
@@ -6,7 +6,8 @@
 
 package ghttp
 
-// MiddlewareCORS is a middleware handler for CORS with default options.
+// MiddlewareCORS is a middleware handler for CORS with default options, which allows any cross-domain request.
+// See NewCORSPolicy for the fine-grained CORS policies.
 func MiddlewareCORS(r *Request) {
 	r.Response.CORSDefault()
 	r.Middleware.Next()
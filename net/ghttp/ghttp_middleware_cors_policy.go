@@ -0,0 +1,275 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSPolicyOption is the option of a CORS policy.
+// See https://fetch.spec.whatwg.org/#http-cors-protocol .
+type CORSPolicyOption struct {
+	// AllowOrigins are the allowed origin patterns, which can be exact origin like "https://example.com",
+	// wildcard subdomain pattern like "https://*.example.com", or "*" allowing any origin.
+	AllowOrigins []string
+
+	// AllowOriginFunc is the custom function checking the origin, which is checked if AllowOrigins does not match.
+	AllowOriginFunc func(r *Request, origin string) bool
+
+	// AllowMethods are the allowed methods, default is GET, HEAD, POST, PUT, PATCH and DELETE.
+	AllowMethods []string
+
+	// AllowHeaders are the allowed request headers. The requested headers are all allowed if it is empty.
+	AllowHeaders []string
+
+	// ExposeHeaders are the response headers exposed to the client scripts.
+	ExposeHeaders []string
+
+	// AllowCredentials specifies whether the client can send credentials like cookies.
+	// The wildcard origin "*" is not allowed along with it, as it would allow any site to read
+	// responses with the user's credentials.
+	AllowCredentials bool
+
+	// AllowPrivateNetwork specifies whether the public sites can request this server in private network.
+	// See https://wicg.github.io/private-network-access/ .
+	AllowPrivateNetwork bool
+
+	// MaxAge is the duration the client caches the preflight result, which is not cached if it is 0.
+	MaxAge time.Duration
+
+	// PreflightContinue passes the preflight request to the next handlers instead of responding 204.
+	PreflightContinue bool
+}
+
+// CORSPolicy is the CORS policy engine, which handles the preflight and actual requests by
+// a default policy and the per-route policies overriding it.
+type CORSPolicy struct {
+	defaultPolicy *corsPolicyItem
+	routes        []*corsPolicyItem // Route policies, which are sorted by pattern length from long to short.
+}
+
+// corsPolicyItem is the compiled CORS policy.
+type corsPolicyItem struct {
+	pattern        string              // Route pattern, which is empty for default policy.
+	option         CORSPolicyOption    // Original option.
+	anyOrigin      bool                // Any origin is allowed.
+	origins        map[string]struct{} // Exact origins in lower case.
+	originPatterns []*regexp.Regexp    // Wildcard origin patterns.
+	methods        map[string]struct{} // Allowed methods.
+	headers        map[string]struct{} // Allowed headers in canonical format.
+	allowMethods   string              // Value of "Access-Control-Allow-Methods".
+	exposeHeaders  string              // Value of "Access-Control-Expose-Headers".
+	maxAge         string              // Value of "Access-Control-Max-Age".
+}
+
+var (
+	defaultCORSPolicyMethods = []string{
+		http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+	}
+)
+
+// NewCORSPolicy creates and returns a CORS policy engine with default policy `option`.
+//
+// It panics if the wildcard origin "*" is used along with AllowCredentials.
+func NewCORSPolicy(option CORSPolicyOption) *CORSPolicy {
+	return &CORSPolicy{
+		defaultPolicy: newCORSPolicyItem("", option),
+	}
+}
+
+// Route sets `option` for the routes matching `pattern`, which overrides the default policy.
+// The `pattern` is the registered route URI like "/user/{id}", or path prefix ending with "/*"
+// like "/api/*". The longest prefix matches if there are multiple prefix patterns matched.
+//
+// It panics if the wildcard origin "*" is used along with AllowCredentials.
+func (p *CORSPolicy) Route(pattern string, option CORSPolicyOption) *CORSPolicy {
+	p.routes = append(p.routes, newCORSPolicyItem(pattern, option))
+	sort.SliceStable(p.routes, func(i, j int) bool {
+		return len(p.routes[i].pattern) > len(p.routes[j].pattern)
+	})
+	return p
+}
+
+// Middleware is the middleware handler applying the policy, which responds the preflight requests
+// directly with 204, or 403 if the preflight is not allowed.
+func (p *CORSPolicy) Middleware(r *Request) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		r.Middleware.Next()
+		return
+	}
+	var (
+		policy      = p.getPolicy(r)
+		header      = r.Response.Header()
+		isPreflight = r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+	)
+	header.Add("Vary", "Origin")
+	if isPreflight {
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+	}
+	if !policy.isOriginAllowed(r, origin) {
+		if isPreflight {
+			r.Response.WriteStatus(http.StatusForbidden)
+			r.ExitAll()
+			return
+		}
+		r.Middleware.Next()
+		return
+	}
+	if policy.anyOrigin && !policy.option.AllowCredentials {
+		header.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		header.Set("Access-Control-Allow-Origin", origin)
+	}
+	if policy.option.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+	if !isPreflight {
+		if policy.exposeHeaders != "" {
+			header.Set("Access-Control-Expose-Headers", policy.exposeHeaders)
+		}
+		r.Middleware.Next()
+		return
+	}
+
+	// Preflight request.
+	requestMethod := strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))
+	if _, ok := policy.methods[requestMethod]; !ok {
+		r.Response.WriteStatus(http.StatusForbidden)
+		r.ExitAll()
+		return
+	}
+	requestHeaders := r.Header.Get("Access-Control-Request-Headers")
+	if requestHeaders != "" {
+		if !policy.isHeadersAllowed(requestHeaders) {
+			r.Response.WriteStatus(http.StatusForbidden)
+			r.ExitAll()
+			return
+		}
+		header.Set("Access-Control-Allow-Headers", requestHeaders)
+	}
+	if r.Header.Get("Access-Control-Request-Private-Network") == "true" {
+		if !policy.option.AllowPrivateNetwork {
+			r.Response.WriteStatus(http.StatusForbidden)
+			r.ExitAll()
+			return
+		}
+		header.Set("Access-Control-Allow-Private-Network", "true")
+	}
+	header.Set("Access-Control-Allow-Methods", policy.allowMethods)
+	if policy.maxAge != "" {
+		header.Set("Access-Control-Max-Age", policy.maxAge)
+	}
+	if policy.option.PreflightContinue {
+		r.Middleware.Next()
+		return
+	}
+	r.Response.WriteHeader(http.StatusNoContent)
+	r.ExitAll()
+}
+
+// getPolicy returns the policy for request `r`.
+func (p *CORSPolicy) getPolicy(r *Request) *corsPolicyItem {
+	for _, item := range p.routes {
+		if strings.HasSuffix(item.pattern, "/*") {
+			prefix := item.pattern[:len(item.pattern)-1]
+			if strings.HasPrefix(r.URL.Path, prefix) || r.URL.Path == prefix[:len(prefix)-1] {
+				return item
+			}
+		} else if r.Router != nil && r.Router.Uri == item.pattern {
+			return item
+		}
+	}
+	return p.defaultPolicy
+}
+
+// newCORSPolicyItem compiles and returns the policy of `option`.
+func newCORSPolicyItem(pattern string, option CORSPolicyOption) *corsPolicyItem {
+	item := &corsPolicyItem{
+		pattern: pattern,
+		option:  option,
+		origins: make(map[string]struct{}),
+		methods: make(map[string]struct{}),
+		headers: make(map[string]struct{}),
+	}
+	for _, origin := range option.AllowOrigins {
+		origin = strings.ToLower(strings.TrimSpace(origin))
+		switch {
+		case origin == "*":
+			if option.AllowCredentials {
+				panic(`CORS wildcard origin "*" cannot be used along with AllowCredentials`)
+			}
+			item.anyOrigin = true
+		case strings.Contains(origin, "*"):
+			expr := "^" + strings.Replace(regexp.QuoteMeta(origin), `\*`, `[a-z0-9\-\.]+`, -1) + "$"
+			item.originPatterns = append(item.originPatterns, regexp.MustCompile(expr))
+		default:
+			item.origins[origin] = struct{}{}
+		}
+	}
+	methods := option.AllowMethods
+	if len(methods) == 0 {
+		methods = defaultCORSPolicyMethods
+	}
+	upperMethods := make([]string, len(methods))
+	for i, method := range methods {
+		upperMethods[i] = strings.ToUpper(method)
+		item.methods[upperMethods[i]] = struct{}{}
+	}
+	item.allowMethods = strings.Join(upperMethods, ",")
+	for _, header := range option.AllowHeaders {
+		item.headers[http.CanonicalHeaderKey(strings.TrimSpace(header))] = struct{}{}
+	}
+	item.exposeHeaders = strings.Join(option.ExposeHeaders, ",")
+	if option.MaxAge > 0 {
+		item.maxAge = strconv.FormatInt(int64(option.MaxAge/time.Second), 10)
+	}
+	return item
+}
+
+// isOriginAllowed checks whether `origin` is allowed.
+func (item *corsPolicyItem) isOriginAllowed(r *Request, origin string) bool {
+	if item.anyOrigin {
+		return true
+	}
+	lowerOrigin := strings.ToLower(origin)
+	if _, ok := item.origins[lowerOrigin]; ok {
+		return true
+	}
+	for _, pattern := range item.originPatterns {
+		if pattern.MatchString(lowerOrigin) {
+			return true
+		}
+	}
+	if item.option.AllowOriginFunc != nil {
+		return item.option.AllowOriginFunc(r, origin)
+	}
+	return false
+}
+
+// isHeadersAllowed checks whether the comma separated `headers` are all allowed.
+func (item *corsPolicyItem) isHeadersAllowed(headers string) bool {
+	if len(item.headers) == 0 {
+		return true
+	}
+	for _, header := range strings.Split(headers, ",") {
+		header = http.CanonicalHeaderKey(strings.TrimSpace(header))
+		if header == "" {
+			continue
+		}
+		if _, ok := item.headers[header]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -10,7 +10,6 @@ import (
 	"net/http"
 
 	"github.com/gogf/gf/v2/errors/gcode"
-	"github.com/gogf/gf/v2/errors/gerror"
 )
 
 // DefaultHandlerResponse is the default implementation of HandlerResponse.
@@ -18,9 +17,18 @@ type DefaultHandlerResponse struct {
 	Code    int         `json:"code"    dc:"Error code"`
 	Message string      `json:"message" dc:"Error message"`
 	Data    interface{} `json:"data"    dc:"Result data for certain request according API definition"`
+
+	// RequestId is the request id for error response, which is only available if request id feature is enabled.
+	RequestId string `json:"requestId,omitempty" dc:"Request id for error response"`
 }
 
 // MiddlewareHandlerResponse is the default middleware handling handler response object and its error.
+// The response body is produced by the ResponseEnvelope of the request, which is DefaultHandlerResponse
+// in default, and is written in the format negotiated by the "Accept" header of the request.
+//
+// The HTTP status of error response is the one mapped from the error code, see gcode.HttpStatus,
+// if the handler does not set other status than 200. The error codes having no mapping registered,
+// like the custom business codes, are responded with status 200.
 func MiddlewareHandlerResponse(r *Request) {
 	r.Middleware.Next()
 
@@ -28,34 +36,12 @@ func MiddlewareHandlerResponse(r *Request) {
 	if r.Response.BufferLength() > 0 {
 		return
 	}
-
-	var (
-		msg  string
-		err  = r.GetError()
-		res  = r.GetHandlerResponse()
-		code = gerror.Code(err)
-	)
-	if err != nil {
-		if code == gcode.CodeNil {
-			code = gcode.CodeInternalError
-		}
-		msg = err.Error()
-	} else if r.Response.Status > 0 && r.Response.Status != http.StatusOK {
-		msg = http.StatusText(r.Response.Status)
-		switch r.Response.Status {
-		case http.StatusNotFound:
-			code = gcode.CodeNotFound
-		case http.StatusForbidden:
-			code = gcode.CodeNotAuthorized
-		default:
-			code = gcode.CodeUnknown
-		}
-	} else {
-		code = gcode.CodeOK
+	err := r.GetError()
+	// The status is set to 200 in default after the handler is served.
+	if err != nil && (r.Response.Status == 0 || r.Response.Status == http.StatusOK) {
+		r.Response.WriteHeader(gcode.HttpStatus(getResponseErrorCode(err)))
 	}
-	r.Response.WriteJson(DefaultHandlerResponse{
-		Code:    code.Code(),
-		Message: msg,
-		Data:    res,
-	})
+	r.Response.WriteByAccept(
+		r.GetResponseEnvelope().Envelope(r, r.GetHandlerResponse(), err),
+	)
 }
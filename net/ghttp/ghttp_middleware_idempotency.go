@@ -0,0 +1,201 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gcache"
+)
+
+// IdempotencyOption is the option for MiddlewareIdempotency.
+type IdempotencyOption struct {
+	// Header is the request header name of the idempotency key, default is "Idempotency-Key".
+	Header string
+
+	// TTL is the window in which the first response is replayed for the same key, default is 24 hours.
+	TTL time.Duration
+
+	// LockTTL is the max duration that a request of a key is considered in processing,
+	// in which the concurrent requests of the same key are rejected with 409, default is 1 minute.
+	LockTTL time.Duration
+
+	// Cache stores the responses, default is an in-memory cache.
+	// It should be a shared cache like redis for distributed services, eg: gcache.NewWithAdapter(gcache.NewAdapterRedis(redis)).
+	Cache *gcache.Cache
+
+	// Methods are the HTTP methods the middleware applies to, default is POST, PUT, PATCH and DELETE.
+	Methods []string
+
+	// PrincipalFunc returns the identity of the caller, like the user id, that the idempotency keys
+	// are scoped in, so that the response of a caller is never replayed to others.
+	// Default is the hash of the "Authorization" and "Cookie" headers of the request.
+	PrincipalFunc func(r *Request) string
+}
+
+// idempotencyRecord is the stored response of an idempotency key.
+type idempotencyRecord struct {
+	Processing bool                `json:"processing"` // The first request is still in processing.
+	BodyHash   string              `json:"bodyHash"`   // SHA256 hash of the request body of the first request.
+	Status     int                 `json:"status"`     // Response status.
+	Header     map[string][]string `json:"header"`     // Response header.
+	Body       string              `json:"body"`       // Response body.
+}
+
+const (
+	defaultIdempotencyHeader  = "Idempotency-Key"
+	defaultIdempotencyTTL     = 24 * time.Hour
+	defaultIdempotencyLockTTL = time.Minute
+	idempotencyCachePrefix    = "gf_idempotency:"
+
+	// idempotencyReplayedHeader marks the response is replayed from the stored one.
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+)
+
+var (
+	defaultIdempotencyMethods = []string{
+		http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+	}
+	// idempotencyIgnoredHeaders are the response headers that are not stored for replaying.
+	idempotencyIgnoredHeaders = map[string]struct{}{
+		"Set-Cookie":          {},
+		"Date":                {},
+		responseTraceIDHeader: {},
+	}
+)
+
+// MiddlewareIdempotency returns a middleware handler that makes the requests idempotent with the
+// idempotency key in request header. The response of the first request of a key is stored and replayed
+// for the retries of the same key, method, path and caller, with header "Idempotent-Replayed: true".
+// The caller is identified by IdempotencyOption.PrincipalFunc.
+//
+// The request reusing a key with different body is rejected with 422, and the concurrent request of a key
+// in processing is rejected with 409. The server error responses (5xx) are not stored, so they can be retried.
+// The requests without the idempotency key header are not affected.
+func MiddlewareIdempotency(option ...IdempotencyOption) HandlerFunc {
+	var opt IdempotencyOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Header == "" {
+		opt.Header = defaultIdempotencyHeader
+	}
+	if opt.TTL <= 0 {
+		opt.TTL = defaultIdempotencyTTL
+	}
+	if opt.LockTTL <= 0 {
+		opt.LockTTL = defaultIdempotencyLockTTL
+	}
+	if opt.Cache == nil {
+		opt.Cache = gcache.New()
+	}
+	if len(opt.Methods) == 0 {
+		opt.Methods = defaultIdempotencyMethods
+	}
+	if opt.PrincipalFunc == nil {
+		opt.PrincipalFunc = getRequestPrincipalHash
+	}
+	methods := make(map[string]struct{}, len(opt.Methods))
+	for _, method := range opt.Methods {
+		methods[strings.ToUpper(method)] = struct{}{}
+	}
+	return func(r *Request) {
+		idempotencyKey := r.Header.Get(opt.Header)
+		if _, ok := methods[r.Method]; !ok || idempotencyKey == "" {
+			r.Middleware.Next()
+			return
+		}
+		var (
+			ctx      = r.Context()
+			cacheKey = idempotencyCachePrefix + r.Method + ":" + r.URL.Path + ":" +
+				opt.PrincipalFunc(r) + ":" + idempotencyKey
+			bodySum  = sha256.Sum256(r.GetBody())
+			bodyHash = hex.EncodeToString(bodySum[:])
+		)
+		ok, err := opt.Cache.SetIfNotExist(ctx, cacheKey, &idempotencyRecord{
+			Processing: true,
+			BodyHash:   bodyHash,
+		}, opt.LockTTL)
+		if err != nil {
+			r.SetError(err)
+			r.Response.WriteStatus(http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			record, err := getIdempotencyRecord(r, opt.Cache, cacheKey)
+			switch {
+			case err != nil:
+				r.SetError(err)
+				r.Response.WriteStatus(http.StatusInternalServerError)
+			case record == nil:
+				// Expired just now, it is considered in processing by another request.
+				r.Response.WriteStatus(http.StatusConflict)
+			case record.BodyHash != bodyHash:
+				r.Response.WriteStatus(http.StatusUnprocessableEntity)
+			case record.Processing:
+				r.Response.WriteStatus(http.StatusConflict)
+			default:
+				header := r.Response.Header()
+				for k, v := range record.Header {
+					header[k] = v
+				}
+				header.Set(idempotencyReplayedHeader, "true")
+				r.Response.WriteHeader(record.Status)
+				r.Response.SetBuffer([]byte(record.Body))
+			}
+			return
+		}
+
+		r.Middleware.Next()
+
+		status := r.Response.Status
+		if status == 0 && r.GetError() == nil {
+			status = http.StatusOK
+		}
+		if status == 0 || status >= http.StatusInternalServerError {
+			// Releases the key for retrying.
+			if _, err = opt.Cache.Remove(ctx, cacheKey); err != nil {
+				r.Server.handleErrorLog(err, r)
+			}
+			return
+		}
+		record := &idempotencyRecord{
+			BodyHash: bodyHash,
+			Status:   status,
+			Header:   make(map[string][]string),
+			Body:     r.Response.BufferString(),
+		}
+		for k, v := range r.Response.Header() {
+			if _, ok = idempotencyIgnoredHeaders[k]; !ok {
+				record.Header[k] = v
+			}
+		}
+		if err = opt.Cache.Set(ctx, cacheKey, record, opt.TTL); err != nil {
+			r.Server.handleErrorLog(err, r)
+		}
+	}
+}
+
+// getIdempotencyRecord retrieves the stored record of `cacheKey`.
+func getIdempotencyRecord(r *Request, cache *gcache.Cache, cacheKey string) (*idempotencyRecord, error) {
+	v, err := cache.Get(r.Context(), cacheKey)
+	if err != nil || v.IsNil() {
+		return nil, err
+	}
+	if record, ok := v.Val().(*idempotencyRecord); ok {
+		return record, nil
+	}
+	var record *idempotencyRecord
+	if err = v.Scan(&record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
@@ -0,0 +1,89 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"github.com/gogf/gf/v2/os/gsession"
+)
+
+// MiddlewareRememberMe returns a middleware handler that transparently restores the session from the
+// remember-me token in cookie if the session has no user bound, and rotates the token in cookie.
+// The invalid token is removed from cookie.
+//
+// The token is issued to cookie by Request.IssueRememberMe when user logs in.
+func MiddlewareRememberMe(rememberMe *gsession.RememberMe) HandlerFunc {
+	return func(r *Request) {
+		if err := r.restoreRememberMe(rememberMe); err != nil {
+			r.Server.handleErrorLog(err, r)
+		}
+		r.Middleware.Next()
+	}
+}
+
+// IssueRememberMe issues a remember-me token for `userId` and sets it to cookie,
+// which is usually called when user logs in with "remember me" checked.
+// The optional parameter `data` is restored to the session along with the user id.
+func (r *Request) IssueRememberMe(rememberMe *gsession.RememberMe, userId string, data ...map[string]interface{}) error {
+	token, err := rememberMe.Issue(r.Context(), userId, data...)
+	if err != nil {
+		return err
+	}
+	r.setRememberMeCookie(rememberMe, token)
+	return nil
+}
+
+// RevokeRememberMe revokes the remember-me token in cookie and removes it from cookie,
+// which is usually called when user logs out.
+func (r *Request) RevokeRememberMe(rememberMe *gsession.RememberMe) error {
+	token := r.Cookie.Get(rememberMe.CookieName()).String()
+	if token == "" {
+		return nil
+	}
+	r.Cookie.Remove(rememberMe.CookieName())
+	return rememberMe.Revoke(r.Context(), token)
+}
+
+// restoreRememberMe restores the session from the remember-me token in cookie if the session has no user bound.
+func (r *Request) restoreRememberMe(rememberMe *gsession.RememberMe) error {
+	token := r.Cookie.Get(rememberMe.CookieName()).String()
+	if token == "" {
+		return nil
+	}
+	v, err := r.Session.Get(rememberMe.SessionKey())
+	if err != nil {
+		return err
+	}
+	if v != nil && !v.IsNil() {
+		return nil
+	}
+	record, newToken, err := rememberMe.Consume(r.Context(), token)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		r.Cookie.Remove(rememberMe.CookieName())
+		return nil
+	}
+	r.setRememberMeCookie(rememberMe, newToken)
+	return rememberMe.Restore(r.Session, record)
+}
+
+// setRememberMeCookie sets the remember-me `token` to cookie, which is always http only.
+func (r *Request) setRememberMeCookie(rememberMe *gsession.RememberMe, token string) {
+	r.Cookie.SetCookie(
+		rememberMe.CookieName(),
+		token,
+		r.Server.GetCookieDomain(),
+		r.Server.GetCookiePath(),
+		rememberMe.TTL(),
+		CookieOptions{
+			SameSite: r.Server.GetCookieSameSite(),
+			Secure:   r.Server.GetCookieSecure(),
+			HttpOnly: true,
+		},
+	)
+}
@@ -0,0 +1,175 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gview"
+)
+
+// SecurityHeadersOption is the option for MiddlewareSecurityHeaders.
+// The header is not responded if its option is empty.
+type SecurityHeadersOption struct {
+	// ContentSecurityPolicy is the "Content-Security-Policy" header, the placeholder "{nonce}" in which is
+	// replaced with a random nonce generated for each request, like: "script-src 'self' 'nonce-{nonce}'".
+	// The nonce is available as template variable "CspNonce" in gview, like: <script nonce="{{.CspNonce}}">,
+	// and can also be retrieved by gview.CspNonceFromCtx(r.Context()).
+	ContentSecurityPolicy string
+
+	// CSPReportOnly responds the policy in "Content-Security-Policy-Report-Only" header instead,
+	// which reports the violations without enforcing the policy.
+	CSPReportOnly bool
+
+	// HSTSMaxAge is the max-age of "Strict-Transport-Security" header, which is only responded for HTTPS requests.
+	HSTSMaxAge time.Duration
+
+	// HSTSIncludeSubDomains adds "includeSubDomains" directive to "Strict-Transport-Security" header.
+	HSTSIncludeSubDomains bool
+
+	// HSTSPreload adds "preload" directive to "Strict-Transport-Security" header.
+	HSTSPreload bool
+
+	// ContentTypeNosniff responds "X-Content-Type-Options: nosniff" header.
+	ContentTypeNosniff bool
+
+	// FrameOptions is the "X-Frame-Options" header, like "DENY" or "SAMEORIGIN".
+	FrameOptions string
+
+	// ReferrerPolicy is the "Referrer-Policy" header, like "strict-origin-when-cross-origin".
+	ReferrerPolicy string
+
+	// PermissionsPolicy is the "Permissions-Policy" header, like "camera=(), microphone=()".
+	PermissionsPolicy string
+
+	// CrossOriginOpenerPolicy is the "Cross-Origin-Opener-Policy" header, like "same-origin".
+	CrossOriginOpenerPolicy string
+}
+
+const (
+	cspNoncePlaceholder = "{nonce}"
+	cspNonceLength      = 16
+)
+
+// SecurityHeadersStrict returns the strict preset of SecurityHeadersOption, which only allows the
+// resources from the same origin and the scripts and styles with nonce, and disables framing and
+// the powerful browser features. It fits the new applications and the APIs.
+func SecurityHeadersStrict() SecurityHeadersOption {
+	return SecurityHeadersOption{
+		ContentSecurityPolicy: "default-src 'self'; " +
+			"script-src 'self' 'nonce-{nonce}'; style-src 'self' 'nonce-{nonce}'; " +
+			"img-src 'self' data:; font-src 'self'; object-src 'none'; " +
+			"base-uri 'self'; form-action 'self'; frame-ancestors 'none'",
+		HSTSMaxAge:              2 * 365 * 24 * time.Hour,
+		HSTSIncludeSubDomains:   true,
+		ContentTypeNosniff:      true,
+		FrameOptions:            "DENY",
+		ReferrerPolicy:          "no-referrer",
+		PermissionsPolicy:       "camera=(), microphone=(), geolocation=(), payment=(), usb=()",
+		CrossOriginOpenerPolicy: "same-origin",
+	}
+}
+
+// SecurityHeadersRelaxed returns the relaxed preset of SecurityHeadersOption, which does not restrict
+// the resources of pages, but prevents the clickjacking, plugins and MIME sniffing.
+// It fits the existing applications that load resources from third parties.
+func SecurityHeadersRelaxed() SecurityHeadersOption {
+	return SecurityHeadersOption{
+		ContentSecurityPolicy: "object-src 'none'; base-uri 'self'; frame-ancestors 'self'",
+		HSTSMaxAge:            180 * 24 * time.Hour,
+		ContentTypeNosniff:    true,
+		FrameOptions:          "SAMEORIGIN",
+		ReferrerPolicy:        "strict-origin-when-cross-origin",
+		PermissionsPolicy:     "camera=(), microphone=(), geolocation=()",
+	}
+}
+
+// MiddlewareSecurityHeaders returns a middleware handler responding the security headers,
+// which uses SecurityHeadersRelaxed if `option` is not given. The headers are set before
+// the following handlers, so they can be overwritten by the handlers.
+func MiddlewareSecurityHeaders(option ...SecurityHeadersOption) HandlerFunc {
+	opt := SecurityHeadersRelaxed()
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	var (
+		cspHeader   = "Content-Security-Policy"
+		cspNonce    = strings.Contains(opt.ContentSecurityPolicy, cspNoncePlaceholder)
+		hstsHeader  string
+		fixedHeader = make(map[string]string)
+	)
+	if opt.CSPReportOnly {
+		cspHeader = "Content-Security-Policy-Report-Only"
+	}
+	if opt.ContentSecurityPolicy != "" && !cspNonce {
+		fixedHeader[cspHeader] = opt.ContentSecurityPolicy
+	}
+	if opt.HSTSMaxAge > 0 {
+		hstsHeader = "max-age=" + strconv.FormatInt(int64(opt.HSTSMaxAge/time.Second), 10)
+		if opt.HSTSIncludeSubDomains {
+			hstsHeader += "; includeSubDomains"
+		}
+		if opt.HSTSPreload {
+			hstsHeader += "; preload"
+		}
+	}
+	if opt.ContentTypeNosniff {
+		fixedHeader["X-Content-Type-Options"] = "nosniff"
+	}
+	if opt.FrameOptions != "" {
+		fixedHeader["X-Frame-Options"] = opt.FrameOptions
+	}
+	if opt.ReferrerPolicy != "" {
+		fixedHeader["Referrer-Policy"] = opt.ReferrerPolicy
+	}
+	if opt.PermissionsPolicy != "" {
+		fixedHeader["Permissions-Policy"] = opt.PermissionsPolicy
+	}
+	if opt.CrossOriginOpenerPolicy != "" {
+		fixedHeader["Cross-Origin-Opener-Policy"] = opt.CrossOriginOpenerPolicy
+	}
+	return func(r *Request) {
+		header := r.Response.Header()
+		for k, v := range fixedHeader {
+			header.Set(k, v)
+		}
+		if hstsHeader != "" && isHttpsRequest(r) {
+			header.Set("Strict-Transport-Security", hstsHeader)
+		}
+		if cspNonce {
+			nonce, err := generateCspNonce()
+			if err != nil {
+				r.SetError(err)
+				r.Response.WriteStatus(http.StatusInternalServerError)
+				return
+			}
+			header.Set(cspHeader, strings.Replace(opt.ContentSecurityPolicy, cspNoncePlaceholder, nonce, -1))
+			r.SetCtx(gview.WithCspNonce(r.Context(), nonce))
+		}
+		r.Middleware.Next()
+	}
+}
+
+// isHttpsRequest checks and returns whether the request is an HTTPS request,
+// including the one forwarded by the TLS terminating proxy.
+func isHttpsRequest(r *Request) bool {
+	return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
+
+// generateCspNonce generates and returns a random nonce for CSP.
+func generateCspNonce() (string, error) {
+	b := make([]byte, cspNonceLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,15 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+// MiddlewareSessionReadOnly marks the session of request read-only, which is usually bound to the
+// routes of handlers only reading session data, so that the session is neither saved nor renewed
+// after the request, which cuts the storage writing of hot read endpoints.
+func MiddlewareSessionReadOnly(r *Request) {
+	r.Session.ReadOnly()
+	r.Middleware.Next()
+}
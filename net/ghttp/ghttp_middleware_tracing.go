@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -52,19 +53,22 @@ func internalMiddlewareServerTracing(r *Request) {
 
 	ctx = context.WithValue(ctx, tracingMiddlewareHandled, 1)
 	var (
-		span trace.Span
-		tr   = otel.GetTracerProvider().Tracer(
+		span      trace.Span
+		startTime = time.Now()
+		tr        = otel.GetTracerProvider().Tracer(
 			tracingInstrumentName,
 			trace.WithInstrumentationVersion(gf.VERSION),
 		)
+		parentCtx = otel.GetTextMapPropagator().Extract(
+			r.Server.withTracingSampling(ctx, r),
+			propagation.HeaderCarrier(r.Header),
+		)
 	)
 	ctx, span = tr.Start(
-		otel.GetTextMapPropagator().Extract(
-			ctx,
-			propagation.HeaderCarrier(r.Header),
-		),
+		parentCtx,
 		r.URL.String(),
 		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithTimestamp(startTime),
 	)
 	defer span.End()
 
@@ -100,6 +104,22 @@ func internalMiddlewareServerTracing(r *Request) {
 	if err := r.GetError(); err != nil {
 		span.SetStatus(codes.Error, fmt.Sprintf(`%+v`, err))
 	}
+	// Sampled span for the failed request which is not sampled.
+	if !span.SpanContext().IsSampled() && r.Server.isTracingErrorSampled(r) {
+		_, errorSpan := tr.Start(
+			gtrace.WithSampling(parentCtx, gtrace.SamplingAlways),
+			r.URL.String(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithTimestamp(startTime),
+		)
+		errorSpan.SetAttributes(gtrace.CommonLabels()...)
+		if err := r.GetError(); err != nil {
+			errorSpan.SetStatus(codes.Error, fmt.Sprintf(`%+v`, err))
+		} else {
+			errorSpan.SetStatus(codes.Error, fmt.Sprintf(`status %d`, r.Response.Status))
+		}
+		errorSpan.End()
+	}
 	// Response content logging.
 	var resBodyContent = gstr.StrLimit(r.Response.BufferString(), gtrace.MaxContentLogSize(), "...")
 
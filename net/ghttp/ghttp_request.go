@@ -40,28 +40,31 @@ type Request struct {
 	// Private attributes for internal usage purpose.
 	// =================================================================================================================
 
-	context         context.Context        // Custom context for internal usage purpose.
-	handlers        []*handlerParsedItem   // All matched handlers containing handler, hook and middleware for this request.
-	handlerResponse interface{}            // Handler response object for Request/Response handler.
-	hasHookHandler  bool                   // A bool marking whether there's hook handler in the handlers for performance purpose.
-	hasServeHandler bool                   // A bool marking whether there's serving handler in the handlers for performance purpose.
-	parsedQuery     bool                   // A bool marking whether the GET parameters parsed.
-	parsedBody      bool                   // A bool marking whether the request body parsed.
-	parsedForm      bool                   // A bool marking whether request Form parsed for HTTP method PUT, POST, PATCH.
-	paramsMap       map[string]interface{} // Custom parameters map.
-	routerMap       map[string]string      // Router parameters map, which might be nil if there are no router parameters.
-	queryMap        map[string]interface{} // Query parameters map, which is nil if there's no query string.
-	formMap         map[string]interface{} // Form parameters map, which is nil if there's no form of data from the client.
-	bodyMap         map[string]interface{} // Body parameters map, which might be nil if their nobody content.
-	error           error                  // Current executing error of the request.
-	exitAll         bool                   // A bool marking whether current request is exited.
-	parsedHost      string                 // The parsed host name for current host used by GetHost function.
-	clientIp        string                 // The parsed client ip for current host used by GetClientIp function.
-	bodyContent     []byte                 // Request body content.
-	isFileRequest   bool                   // A bool marking whether current request is file serving.
-	viewObject      *gview.View            // Custom template view engine object for this response.
-	viewParams      gview.Params           // Custom template view variables for this response.
-	originUrlPath   string                 // Original URL path that passed from client.
+	context          context.Context        // Custom context for internal usage purpose.
+	handlers         []*handlerParsedItem   // All matched handlers containing handler, hook and middleware for this request.
+	handlerResponse  interface{}            // Handler response object for Request/Response handler.
+	envelopeName     string                 // Registered ResponseEnvelope name of the serving handler.
+	responseEnvelope ResponseEnvelope       // Custom ResponseEnvelope for this request.
+	hasHookHandler   bool                   // A bool marking whether there's hook handler in the handlers for performance purpose.
+	hasServeHandler  bool                   // A bool marking whether there's serving handler in the handlers for performance purpose.
+	parsedQuery      bool                   // A bool marking whether the GET parameters parsed.
+	parsedBody       bool                   // A bool marking whether the request body parsed.
+	parsedForm       bool                   // A bool marking whether request Form parsed for HTTP method PUT, POST, PATCH.
+	paramsMap        map[string]interface{} // Custom parameters map.
+	routerMap        map[string]string      // Router parameters map, which might be nil if there are no router parameters.
+	queryMap         map[string]interface{} // Query parameters map, which is nil if there's no query string.
+	formMap          map[string]interface{} // Form parameters map, which is nil if there's no form of data from the client.
+	bodyMap          map[string]interface{} // Body parameters map, which might be nil if their nobody content.
+	error            error                  // Current executing error of the request.
+	exitAll          bool                   // A bool marking whether current request is exited.
+	parsedHost       string                 // The parsed host name for current host used by GetHost function.
+	clientIp         string                 // The parsed client ip for current host used by GetClientIp function.
+	bodyContent      []byte                 // Request body content.
+	isFileRequest    bool                   // A bool marking whether current request is file serving.
+	viewObject       *gview.View            // Custom template view engine object for this response.
+	viewParams       gview.Params           // Custom template view variables for this response.
+	originUrlPath    string                 // Original URL path that passed from client.
+	apiVersion       string                 // API version of the request, see Server.SetAPIVersion.
 }
 
 type handlerResponse struct {
@@ -106,6 +109,12 @@ func newRequest(s *Server, r *http.Request, w http.ResponseWriter) *Request {
 	if err != nil {
 		panic(err)
 	}
+	// Client information for session binding.
+	if s.sessionManager.GetBinding() != nil {
+		if err = request.Session.SetClient(request.GetClientIp(), request.UserAgent()); err != nil {
+			panic(err)
+		}
+	}
 	// Remove char '/' in the tail of URI.
 	if request.URL.Path != "/" {
 		for len(request.URL.Path) > 0 && request.URL.Path[len(request.URL.Path)-1] == '/' {
@@ -183,11 +192,17 @@ func (r *Request) IsAjaxRequest() bool {
 }
 
 // GetClientIp returns the client ip of this request without port.
-// Note that this ip address might be modified by client header.
+// Note that this ip address might be modified by client header, unless the trusted proxies are
+// configured, in which the client ip is resolved only from the headers set by the trusted proxies.
+// See ServerConfig.TrustedProxies and ServerConfig.ClientIpHeaders.
 func (r *Request) GetClientIp() string {
 	if r.clientIp != "" {
 		return r.clientIp
 	}
+	if len(r.Server.config.TrustedProxies) > 0 {
+		r.clientIp = r.Server.resolveClientIp(r)
+		return r.clientIp
+	}
 	realIps := r.Header.Get("X-Forwarded-For")
 	if realIps != "" && len(realIps) != 0 && !strings.EqualFold("unknown", realIps) {
 		ipArray := strings.Split(realIps, ",")
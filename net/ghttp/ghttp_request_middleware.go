@@ -109,6 +109,7 @@ func (m *middleware) Next() {
 				// of the real error point.
 				m.request.error = gerror.WrapCodeSkip(gcode.CodeInternalError, 1, exception, "")
 			}
+			m.request.Server.handlePanicDiagnostics(m.request, m.request.error)
 			m.request.Response.WriteStatus(http.StatusInternalServerError, exception)
 			loop = false
 		})
@@ -130,6 +131,7 @@ func (m *middleware) callHandlerFunc(funcInfo handlerFuncInfo) {
 		if funcInfo.Func != nil {
 			funcInfo.Func(m.request)
 		} else {
+			m.request.envelopeName = funcInfo.Envelope
 			var inputValues = []reflect.Value{
 				reflect.ValueOf(m.request.Context()),
 			}
@@ -21,6 +21,7 @@ import (
 	"github.com/gogf/gf/v2/encoding/gxml"
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
 	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/internal/utils"
 	"github.com/gogf/gf/v2/text/gregex"
@@ -225,6 +226,14 @@ func (r *Request) parseBody() {
 		return
 	}
 	if body := r.GetBody(); len(body) > 0 {
+		// Registered body codec of the content type, like MessagePack.
+		if codec := GetBodyCodec(r.Header.Get("Content-Type")); codec != nil {
+			var err error
+			if r.bodyMap, err = codec.Decode(body); err != nil {
+				intlog.Errorf(r.Context(), `decoding request body failed: %+v`, err)
+			}
+			return
+		}
 		// Trim space/new line characters.
 		body = bytes.TrimSpace(body)
 		// JSON format checks.
@@ -105,5 +105,9 @@ func (r *Request) doGetFormStruct(pointer interface{}, mapping ...map[string]str
 	if err = r.mergeDefaultStructValue(data, pointer); err != nil {
 		return data, nil
 	}
+	// Transformation with rules from struct tag definition.
+	if err = r.transformStructValue(data, pointer); err != nil {
+		return data, err
+	}
 	return data, gconv.Struct(data, pointer, mapping...)
 }
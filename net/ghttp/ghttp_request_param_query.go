@@ -144,5 +144,9 @@ func (r *Request) doGetQueryStruct(pointer interface{}, mapping ...map[string]st
 	if err = r.mergeDefaultStructValue(data, pointer); err != nil {
 		return data, nil
 	}
+	// Transformation with rules from struct tag definition.
+	if err = r.transformStructValue(data, pointer); err != nil {
+		return data, err
+	}
 	return data, gconv.Struct(data, pointer, mapping...)
 }
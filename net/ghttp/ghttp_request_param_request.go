@@ -177,14 +177,45 @@ func (r *Request) doGetRequestStruct(pointer interface{}, mapping ...map[string]
 	if data == nil {
 		data = map[string]interface{}{}
 	}
+	// Header and cookie values.
+	if err = r.mergeInTagStructValue(data, pointer); err != nil {
+		return data, err
+	}
 	// Default struct values.
 	if err = r.mergeDefaultStructValue(data, pointer); err != nil {
 		return data, nil
 	}
+	// Transformation with rules from struct tag definition.
+	if err = r.transformStructValue(data, pointer); err != nil {
+		return data, err
+	}
 
 	return data, gconv.Struct(data, pointer, mapping...)
 }
 
+// mergeInTagStructValue merges the request parameters with the header and cookie values of the struct
+// fields located by tag "in", like `in:"header"`, see gstructs.RequestInfoOf.
+func (r *Request) mergeInTagStructValue(data map[string]interface{}, pointer interface{}) error {
+	requestInfo, err := gstructs.RequestInfoOf(pointer)
+	if err != nil {
+		return err
+	}
+	for _, param := range requestInfo.Params {
+		switch param.In {
+		case gstructs.ParamInHeader:
+			if value := r.Header.Get(param.Name); value != "" {
+				data[param.Name] = value
+			}
+
+		case gstructs.ParamInCookie:
+			if r.Cookie.Contains(param.Name) {
+				data[param.Name] = r.Cookie.Get(param.Name).String()
+			}
+		}
+	}
+	return nil
+}
+
 // mergeDefaultStructValue merges the request parameters with default values from struct tag definition.
 func (r *Request) mergeDefaultStructValue(data map[string]interface{}, pointer interface{}) error {
 	tagFields, err := gstructs.TagFields(pointer, defaultValueTags)
@@ -0,0 +1,137 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"strings"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/encoding/ghtml"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gstructs"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+// TransformFunc is the function transforming a string parameter value.
+type TransformFunc func(value string) string
+
+const (
+	// transformRuleSeparator separates the rules in transform tag, like: `tf:"trim|lower"`.
+	transformRuleSeparator = "|"
+	// transformRuleDefault is the rule setting default value if the value is empty, like: `tf:"trim|default:guest"`.
+	transformRuleDefault = "default"
+)
+
+var (
+	// transformTags are the struct tag names for parameter transformation rules.
+	transformTags = []string{"tf", "transform"}
+
+	// transformFuncMap is the transformation rule name to TransformFunc map.
+	transformFuncMap = gmap.NewStrAnyMap(true)
+)
+
+func init() {
+	RegisterTransform("trim", strings.TrimSpace)
+	RegisterTransform("lower", strings.ToLower)
+	RegisterTransform("upper", strings.ToUpper)
+	RegisterTransform("ucwords", gstr.UcWords)
+	RegisterTransform("collapse", func(value string) string {
+		return strings.Join(strings.Fields(value), " ")
+	})
+	RegisterTransform("html", ghtml.SpecialChars)
+	RegisterTransform("striptags", ghtml.StripTags)
+}
+
+// RegisterTransform registers transformation rule `name` with function `f`, which can be used in
+// struct tag "tf" or "transform" of request parameters, like: `tf:"trim|lower"`.
+//
+// The built-in rules are:
+// trim:      removes the leading and trailing white spaces.
+// lower:     converts to lower case.
+// upper:     converts to upper case.
+// ucwords:   converts the first letter of each word to upper case.
+// collapse:  removes the leading and trailing white spaces and collapses the inner ones into single space.
+// html:      escapes the HTML special chars, which prevents HTML injection.
+// striptags: removes the HTML tags.
+// default:   sets the default value if the value is empty, like: `tf:"trim|default:guest"`.
+func RegisterTransform(name string, f TransformFunc) {
+	transformFuncMap.Set(name, f)
+}
+
+// transformStructValue transforms the request parameters in `data` with the transformation rules
+// from struct tag definition of `pointer`. The transformations are applied in order of the rules,
+// and only the string values or the string values in slices are transformed.
+func (r *Request) transformStructValue(data map[string]interface{}, pointer interface{}) error {
+	tagFields, err := gstructs.TagFields(pointer, transformTags)
+	if err != nil {
+		return err
+	}
+	for _, field := range tagFields {
+		key, value := gutil.MapPossibleItemByKey(data, field.Name())
+		if key == "" {
+			key = field.Name()
+		}
+		for _, rule := range strings.Split(field.TagValue, transformRuleSeparator) {
+			if rule = strings.TrimSpace(rule); rule == "" {
+				continue
+			}
+			if value, err = transformValue(value, rule); err != nil {
+				return err
+			}
+		}
+		if value != nil {
+			data[key] = value
+		}
+	}
+	return nil
+}
+
+// transformValue transforms `value` with `rule`.
+func transformValue(value interface{}, rule string) (interface{}, error) {
+	var (
+		ruleName  = rule
+		ruleParam string
+	)
+	if pos := strings.Index(rule, ":"); pos != -1 {
+		ruleName, ruleParam = rule[:pos], rule[pos+1:]
+	}
+	if ruleName == transformRuleDefault {
+		if value == nil || value == "" {
+			return ruleParam, nil
+		}
+		return value, nil
+	}
+	v := transformFuncMap.Get(ruleName)
+	if v == nil {
+		return nil, gerror.NewCodef(gcode.CodeInvalidConfiguration, `invalid transformation rule "%s"`, rule)
+	}
+	f := v.(TransformFunc)
+	switch typedValue := value.(type) {
+	case string:
+		return f(typedValue), nil
+	case []string:
+		result := make([]string, len(typedValue))
+		for i, item := range typedValue {
+			result[i] = f(item)
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(typedValue))
+		for i, item := range typedValue {
+			if s, ok := item.(string); ok {
+				result[i] = f(s)
+			} else {
+				result[i] = item
+			}
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// ResponseEnvelope wraps the handler response object and its error into the response body,
+// which is used by MiddlewareHandlerResponse.
+type ResponseEnvelope interface {
+	// Envelope returns the response body for handler response `res` and error `err`.
+	Envelope(r *Request, res interface{}, err error) interface{}
+}
+
+// ResponseEnvelopeFunc is a function implementing ResponseEnvelope.
+type ResponseEnvelopeFunc func(r *Request, res interface{}, err error) interface{}
+
+// defaultResponseEnvelope is the default ResponseEnvelope producing DefaultHandlerResponse.
+type defaultResponseEnvelope struct{}
+
+const (
+	// responseEnvelopeTagName is the meta tag name of request structure specifying
+	// the registered ResponseEnvelope name for the route, eg: g.Meta `envelope:"raw"`.
+	responseEnvelopeTagName = "envelope"
+)
+
+var (
+	// DefaultResponseEnvelope is the default ResponseEnvelope producing DefaultHandlerResponse.
+	DefaultResponseEnvelope ResponseEnvelope = defaultResponseEnvelope{}
+
+	// responseEnvelopeMap is the registered ResponseEnvelope map, which is name to ResponseEnvelope.
+	responseEnvelopeMap = gmap.NewStrAnyMap(true)
+)
+
+// Envelope implements ResponseEnvelope.
+func (f ResponseEnvelopeFunc) Envelope(r *Request, res interface{}, err error) interface{} {
+	return f(r, res, err)
+}
+
+// Envelope implements ResponseEnvelope.
+func (defaultResponseEnvelope) Envelope(r *Request, res interface{}, err error) interface{} {
+	var (
+		msg  string
+		code gcode.Code
+	)
+	if err != nil {
+		code = getResponseErrorCode(err)
+		msg = gerror.Redact(err)
+	} else if r.Response.Status > 0 && r.Response.Status != http.StatusOK {
+		msg = http.StatusText(r.Response.Status)
+		code = gcode.FromHttpStatus(r.Response.Status)
+	} else {
+		code = gcode.CodeOK
+	}
+	response := DefaultHandlerResponse{
+		Code:    code.Code(),
+		Message: msg,
+		Data:    res,
+	}
+	if err != nil {
+		response.RequestId = r.GetRequestId()
+	}
+	return response
+}
+
+// getResponseErrorCode returns the error code of `err` for response,
+// which is CodeInternalError if `err` has no error code.
+func getResponseErrorCode(err error) gcode.Code {
+	if code := gerror.Code(err); code != gcode.CodeNil {
+		return code
+	}
+	return gcode.CodeInternalError
+}
+
+// RegisterResponseEnvelope registers `envelope` with `name`, which can be used for certain routes
+// by meta tag of the request structure, eg: g.Meta `envelope:"name"`.
+func RegisterResponseEnvelope(name string, envelope ResponseEnvelope) {
+	responseEnvelopeMap.Set(name, envelope)
+}
+
+// GetResponseEnvelope returns the registered ResponseEnvelope of `name`, it returns nil if not found.
+func GetResponseEnvelope(name string) ResponseEnvelope {
+	if v := responseEnvelopeMap.Get(name); v != nil {
+		return v.(ResponseEnvelope)
+	}
+	return nil
+}
+
+// SetResponseEnvelope sets the global ResponseEnvelope of the server overriding DefaultResponseEnvelope.
+func (s *Server) SetResponseEnvelope(envelope ResponseEnvelope) {
+	s.responseEnvelope = envelope
+}
+
+// GetResponseEnvelope returns the global ResponseEnvelope of the server.
+func (s *Server) GetResponseEnvelope() ResponseEnvelope {
+	if s.responseEnvelope != nil {
+		return s.responseEnvelope
+	}
+	return DefaultResponseEnvelope
+}
+
+// SetResponseEnvelope sets the ResponseEnvelope for current request, which has the most priority.
+func (r *Request) SetResponseEnvelope(envelope ResponseEnvelope) {
+	r.responseEnvelope = envelope
+}
+
+// GetResponseEnvelope returns the ResponseEnvelope for current request, which is in priority of the one
+// set by Request.SetResponseEnvelope, the one of the meta tag of the route, and the global one of the server.
+func (r *Request) GetResponseEnvelope() ResponseEnvelope {
+	if r.responseEnvelope != nil {
+		return r.responseEnvelope
+	}
+	if r.envelopeName != "" {
+		if envelope := GetResponseEnvelope(r.envelopeName); envelope != nil {
+			return envelope
+		}
+	}
+	return r.Server.GetResponseEnvelope()
+}
+
+// MiddlewareResponseEnvelope returns a middleware handler setting `envelope` for the requests,
+// which is usually bound to certain routes or groups overriding the global ResponseEnvelope.
+func MiddlewareResponseEnvelope(envelope ResponseEnvelope) HandlerFunc {
+	return func(r *Request) {
+		r.SetResponseEnvelope(envelope)
+		r.Middleware.Next()
+	}
+}
+
+// negotiateContentType returns the response content type of the "Accept" header value `accept`.
+// It is JSON if JSON is acceptable, like "application/json" or "*/*" is accepted, so the responses
+// for the clients like browsers keep JSON format. Or else it is the supported one with the highest
+// quality, and is JSON in default.
+func negotiateContentType(accept string) string {
+	var (
+		contentType    = contentTypeJson
+		quality        = -1.0
+		jsonAcceptable = false
+	)
+	for _, part := range strings.Split(accept, ",") {
+		var (
+			params    = strings.Split(part, ";")
+			mediaType = strings.ToLower(strings.TrimSpace(params[0]))
+			q         = 1.0
+			matched   string
+		)
+		switch mediaType {
+		case "application/xml", "text/xml":
+			matched = contentTypeXml
+		case "application/msgpack", "application/x-msgpack", "application/vnd.msgpack":
+			matched = contentTypeMsgpack
+		case "application/json", "application/*", "*/*":
+			matched = contentTypeJson
+		default:
+			if GetBodyCodec(mediaType) == nil {
+				continue
+			}
+			matched = mediaType
+		}
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		if matched == contentTypeJson {
+			jsonAcceptable = true
+		}
+		if q > quality {
+			contentType, quality = matched, q
+		}
+	}
+	if jsonAcceptable {
+		return contentTypeJson
+	}
+	return contentType
+}
@@ -12,6 +12,7 @@ import (
 	"net/http"
 
 	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/encoding/gmsgpack"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/util/gconv"
@@ -189,6 +190,73 @@ func (r *Response) WriteXmlExit(content interface{}, rootTag ...string) {
 	r.Request.Exit()
 }
 
+// WriteMsgpack writes `content` to the response with MessagePack format.
+func (r *Response) WriteMsgpack(content interface{}) {
+	r.Header().Set("Content-Type", contentTypeMsgpack)
+	// If given []byte, response it directly to clients.
+	if b, ok := content.([]byte); ok {
+		r.Write(b)
+		return
+	}
+	if b, err := gmsgpack.Encode(content); err != nil {
+		panic(gerror.Wrap(err, `WriteMsgpack failed`))
+	} else {
+		r.Write(b)
+	}
+}
+
+// WriteByAccept writes `content` to the response with the format negotiated by the "Accept" header
+// of the request. It is JSON if JSON is acceptable, like "application/json" or "*/*" is accepted.
+// Or else it is XML for "application/xml" or "text/xml", MessagePack for "application/msgpack"
+// or "application/x-msgpack", the format of BodyCodec registered for the accepted content type,
+// and JSON for others.
+func (r *Response) WriteByAccept(content interface{}) {
+	accept := r.Request.Header.Get("Accept")
+	if accept != "" {
+		r.Header().Add("Vary", "Accept")
+	}
+	contentType := negotiateContentType(accept)
+	switch contentType {
+	case contentTypeXml:
+		// It encodes the content with JSON first, so that the nested structs are converted
+		// in the same way of the JSON format.
+		switch content.(type) {
+		case string, []byte:
+			r.WriteXml(content)
+		default:
+			b, err := json.Marshal(content)
+			if err != nil {
+				panic(gerror.Wrap(err, `WriteByAccept failed`))
+			}
+			j, err := gjson.LoadContent(b)
+			if err != nil {
+				panic(gerror.Wrap(err, `WriteByAccept failed`))
+			}
+			r.WriteXml(j.Map())
+		}
+	case contentTypeMsgpack:
+		r.WriteMsgpack(content)
+	case contentTypeJson:
+		r.WriteJson(content)
+	default:
+		codec := GetBodyCodec(contentType)
+		if codec == nil {
+			r.WriteJson(content)
+			return
+		}
+		r.Header().Set("Content-Type", contentType)
+		if b, ok := content.([]byte); ok {
+			r.Write(b)
+			return
+		}
+		if b, err := codec.Encode(content); err != nil {
+			panic(gerror.Wrap(err, `WriteByAccept failed`))
+		} else {
+			r.Write(b)
+		}
+	}
+}
+
 // WriteStatus writes HTTP `status` and `content` to the response.
 // Note that it does not set a Content-Type header here.
 func (r *Response) WriteStatus(status int, content ...interface{}) {
@@ -101,6 +101,8 @@ func GetServer(name ...interface{}) *Server {
 		servers:          make([]*gracefulServer, 0),
 		closeChan:        make(chan struct{}, 10000),
 		serverCount:      gtype.NewInt(),
+		tlsCertificate:   gtype.NewInterface(),
+		maintenance:      &maintenanceManager{},
 		statusHandlerMap: make(map[string][]HandlerFunc),
 		serveTree:        make(map[string]interface{}),
 		serveCache:       gcache.New(),
@@ -123,6 +125,9 @@ func GetServer(name ...interface{}) *Server {
 func (s *Server) Start() error {
 	var ctx = context.TODO()
 
+	// Recent logs capturing for panic diagnostics.
+	s.initPanicDiagnostics()
+
 	// Swagger UI.
 	if s.config.SwaggerPath != "" {
 		swaggerui.Init()
@@ -612,9 +617,13 @@ func (s *Server) GetListenedPort() int {
 }
 
 // GetListenedPorts retrieves and returns the ports which are listened by current server.
+// The unix domain socket listeners are ignored as they have no port.
 func (s *Server) GetListenedPorts() []int {
 	ports := make([]int, 0)
 	for _, server := range s.servers {
+		if server.isUnix() {
+			continue
+		}
 		ports = append(ports, server.GetListenedPort())
 	}
 	return ports
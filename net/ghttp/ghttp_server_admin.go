@@ -23,9 +23,10 @@ type utilAdmin struct{}
 // Index shows the administration page.
 func (p *utilAdmin) Index(r *Request) {
 	data := map[string]interface{}{
-		"pid":  gproc.Pid(),
-		"path": gfile.SelfPath(),
-		"uri":  strings.TrimRight(r.URL.Path, "/"),
+		"pid":         gproc.Pid(),
+		"path":        gfile.SelfPath(),
+		"uri":         strings.TrimRight(r.URL.Path, "/"),
+		"maintenance": r.Server.IsMaintenance(),
 	}
 	buffer, _ := gview.ParseContent(r.Context(), `
             <html>
@@ -37,6 +38,10 @@ func (p *utilAdmin) Index(r *Request) {
                 <p>File Path: {{.path}}</p>
                 <p><a href="{{$.uri}}/restart">Restart</a></p>
                 <p><a href="{{$.uri}}/shutdown">Shutdown</a></p>
+                <p>Maintenance: {{.maintenance}}
+                    <a href="{{$.uri}}/maintenance?enabled=true">Enable</a>
+                    <a href="{{$.uri}}/maintenance?enabled=false">Disable</a>
+                </p>
             </body>
             </html>
     `, data)
@@ -76,13 +81,28 @@ func (p *utilAdmin) Shutdown(r *Request) {
 	r.Response.WriteExit("server shutdown")
 }
 
+// Maintenance enables or disables the maintenance mode of current server with query parameter "enabled",
+// and shows the maintenance status.
+func (p *utilAdmin) Maintenance(r *Request) {
+	if enabled := r.GetQuery("enabled"); !enabled.IsNil() {
+		if err := r.Server.SetMaintenance(enabled.Bool()); err != nil {
+			r.Response.WriteExit(err.Error())
+		}
+	}
+	r.Response.WriteExit("maintenance: ", r.Server.IsMaintenance())
+}
+
 // EnableAdmin enables the administration feature for the process.
-// The optional parameter `pattern` specifies the URI for the administration page.
+// The optional parameter `pattern` specifies the URI for the administration page,
+// which is always served in maintenance mode.
 func (s *Server) EnableAdmin(pattern ...string) {
 	p := "/debug/admin"
 	if len(pattern) > 0 {
 		p = pattern[0]
 	}
+	s.maintenance.mu.Lock()
+	s.maintenance.adminPath = strings.TrimRight(p, "/")
+	s.maintenance.mu.Unlock()
 	s.BindObject(p, &utilAdmin{})
 }
 
@@ -0,0 +1,267 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIVersionConfig is the configuration for API versioning, see Server.SetAPIVersion.
+//
+// The version of request is extracted in order from: the path prefix like "/v2/user", the header,
+// the Accept media type and the default version. The request without version in path is routed
+// to the handler of the extracted version if it exists, or else the handler of the path itself.
+type APIVersionConfig struct {
+	// Versions are the known versions like "v1" and "v2", for recognizing the version in path prefix.
+	// The versions registered by Server.Version and RouterGroup.Version are added automatically.
+	Versions []string
+
+	// Header is the request header name carrying the version, like "X-API-Version", it is disabled if empty.
+	// The header value can be either "v2" or "2".
+	Header string
+
+	// MediaType is the vendor media type in "Accept" header carrying the version, like "application/vnd.example",
+	// which accepts "application/vnd.example.v2+json" and "application/vnd.example+json; version=2".
+	// It is disabled if empty.
+	MediaType string
+
+	// Default is the version for the request that does not specify version.
+	Default string
+}
+
+// APIVersionDeprecation is the deprecation information of an API version,
+// which is responded in the "Deprecation", "Sunset" and "Link" headers automatically.
+type APIVersionDeprecation struct {
+	Since  time.Time // Time the version is deprecated since, the "Deprecation" header is "true" if it is zero.
+	Sunset time.Time // Time the version will be removed, no "Sunset" header if it is zero.
+	Link   string    // Link of the migration document, which is responded as `Link: <url>; rel="deprecation"`.
+}
+
+// apiVersionManager manages the API versions of server.
+type apiVersionManager struct {
+	mu           sync.RWMutex
+	config       APIVersionConfig
+	versions     map[string]struct{}              // Known versions.
+	prefixes     []string                         // Route prefixes of the versioned groups without version, like "/api".
+	deprecations map[string]APIVersionDeprecation // Deprecated versions.
+}
+
+const (
+	headerDeprecation = "Deprecation"
+	headerSunset      = "Sunset"
+	headerLink        = "Link"
+)
+
+// SetAPIVersion enables API versioning for server with `config`.
+func (s *Server) SetAPIVersion(config APIVersionConfig) {
+	m := s.getAPIVersionManager()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.config = config
+	for _, version := range config.Versions {
+		m.versions[version] = struct{}{}
+	}
+	if config.Default != "" {
+		m.versions[config.Default] = struct{}{}
+	}
+}
+
+// DeprecateAPIVersion marks API version `version` as deprecated, the responses of the version
+// have the deprecation headers automatically.
+func (s *Server) DeprecateAPIVersion(version string, deprecation APIVersionDeprecation) {
+	m := s.getAPIVersionManager()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deprecations[version] = deprecation
+}
+
+// Version creates and returns a RouterGroup of API version `version`, the routes of which
+// are prefixed with the version, like "/v2/user".
+func (s *Server) Version(version string, groups ...func(group *RouterGroup)) *RouterGroup {
+	return s.Group("/").Version(version, groups...)
+}
+
+// Version creates and returns a subgroup of API version `version`, the routes of which are
+// prefixed with the version after the group prefix, like "/api/v2/user".
+func (g *RouterGroup) Version(version string, groups ...func(group *RouterGroup)) *RouterGroup {
+	g.server.getAPIVersionManager().addVersion(g.getPrefix(), version)
+	return g.Group("/"+version, groups...)
+}
+
+// GetAPIVersion returns the API version of the request, see Server.SetAPIVersion.
+func (r *Request) GetAPIVersion() string {
+	return r.apiVersion
+}
+
+// getAPIVersionManager returns the API version manager, it creates one if it does not exist.
+func (s *Server) getAPIVersionManager() *apiVersionManager {
+	if s.apiVersion == nil {
+		s.apiVersion = &apiVersionManager{
+			versions:     make(map[string]struct{}),
+			deprecations: make(map[string]APIVersionDeprecation),
+		}
+	}
+	return s.apiVersion
+}
+
+// handleAPIVersion extracts the API version of the request, routes the request to the handler of
+// the version, and responds the deprecation headers if the version is deprecated.
+func (s *Server) handleAPIVersion(r *Request) {
+	m := s.apiVersion
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var (
+		prefixes           = m.getPrefixes()
+		version, versioned = m.getVersionFromPath(prefixes, r.URL.Path)
+	)
+	if !versioned {
+		version = m.getVersionFromRequest(r)
+	}
+	if version == "" {
+		return
+	}
+	r.apiVersion = version
+	if !versioned {
+		// Routes to the handler of the version if it exists.
+		path := r.URL.Path
+		for _, prefix := range prefixes {
+			if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+				continue
+			}
+			r.URL.Path = prefix + "/" + version + strings.TrimSuffix(path[len(prefix):], "/")
+			if _, _, hasServe := s.getHandlersWithCache(r); hasServe {
+				break
+			}
+			r.URL.Path = path
+		}
+	}
+	if deprecation, ok := m.deprecations[version]; ok {
+		header := r.Response.Header()
+		if deprecation.Since.IsZero() {
+			header.Set(headerDeprecation, "true")
+		} else {
+			header.Set(headerDeprecation, deprecation.Since.UTC().Format(http.TimeFormat))
+		}
+		if !deprecation.Sunset.IsZero() {
+			header.Set(headerSunset, deprecation.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if deprecation.Link != "" {
+			header.Add(headerLink, fmt.Sprintf(`<%s>; rel="deprecation"`, deprecation.Link))
+		}
+	}
+}
+
+// addVersion adds known version `version` of the versioned group prefix `prefix`.
+func (m *apiVersionManager) addVersion(prefix string, version string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.versions[version] = struct{}{}
+	for _, v := range m.prefixes {
+		if v == prefix {
+			return
+		}
+	}
+	m.prefixes = append(m.prefixes, prefix)
+	// The longer prefix has higher priority.
+	sort.Slice(m.prefixes, func(i, j int) bool {
+		return len(m.prefixes[i]) > len(m.prefixes[j])
+	})
+}
+
+// getPrefixes returns the route prefixes of the versioned groups, which is the root in default.
+func (m *apiVersionManager) getPrefixes() []string {
+	if len(m.prefixes) == 0 {
+		return []string{""}
+	}
+	return m.prefixes
+}
+
+// getVersionFromPath returns the version in `path` after the versioned group prefixes `prefixes`.
+func (m *apiVersionManager) getVersionFromPath(prefixes []string, path string) (version string, ok bool) {
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+		segment := path[len(prefix)+1:]
+		if pos := strings.IndexByte(segment, '/'); pos != -1 {
+			segment = segment[:pos]
+		}
+		if _, ok = m.versions[segment]; ok {
+			return segment, true
+		}
+	}
+	return "", false
+}
+
+// getVersionFromRequest returns the version from the header, Accept media type or the default version.
+func (m *apiVersionManager) getVersionFromRequest(r *Request) string {
+	if m.config.Header != "" {
+		if v := m.normalizeVersion(r.Header.Get(m.config.Header)); v != "" {
+			return v
+		}
+	}
+	if m.config.MediaType != "" {
+		if v := m.getVersionFromMediaType(r.Header.Get("Accept")); v != "" {
+			return v
+		}
+	}
+	return m.config.Default
+}
+
+// getVersionFromMediaType returns the version in the vendor media types of Accept header `accept`.
+func (m *apiVersionManager) getVersionFromMediaType(accept string) string {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		var (
+			params    = strings.Split(mediaRange, ";")
+			mediaType = strings.TrimSpace(params[0])
+		)
+		if !strings.HasPrefix(mediaType, m.config.MediaType) {
+			continue
+		}
+		// Like: application/vnd.example.v2+json
+		suffix := mediaType[len(m.config.MediaType):]
+		if pos := strings.IndexByte(suffix, '+'); pos != -1 {
+			suffix = suffix[:pos]
+		}
+		if strings.HasPrefix(suffix, ".") {
+			if v := m.normalizeVersion(suffix[1:]); v != "" {
+				return v
+			}
+		}
+		// Like: application/vnd.example+json; version=2
+		for _, param := range params[1:] {
+			array := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(array) == 2 && strings.EqualFold(array[0], "version") {
+				if v := m.normalizeVersion(strings.Trim(array[1], `"`)); v != "" {
+					return v
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// normalizeVersion returns the known version of `version`, which can be like "v2" or "2".
+// It returns `version` itself if there is no known versions, or else an empty string if it is unknown.
+func (m *apiVersionManager) normalizeVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if version == "" || len(m.versions) == 0 {
+		return version
+	}
+	if _, ok := m.versions[version]; ok {
+		return version
+	}
+	if _, ok := m.versions["v"+version]; ok {
+		return "v" + version
+	}
+	return ""
+}
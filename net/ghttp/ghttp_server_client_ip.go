@@ -0,0 +1,160 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+var (
+	// defaultClientIpHeaders is the default headers in precedence for resolving client ip from trusted proxies.
+	defaultClientIpHeaders = []string{"X-Forwarded-For", "Forwarded", "X-Real-IP"}
+)
+
+// SetTrustedProxies sets the IPs or CIDRs of the trusted proxies for resolving client ip.
+// It returns error if any of `proxies` is neither valid IP nor CIDR.
+func (s *Server) SetTrustedProxies(proxies []string) error {
+	if _, err := parseTrustedProxies(proxies); err != nil {
+		return err
+	}
+	s.config.TrustedProxies = proxies
+	return nil
+}
+
+// SetClientIpHeaders sets the headers in precedence for resolving client ip from trusted proxies.
+func (s *Server) SetClientIpHeaders(headers []string) {
+	s.config.ClientIpHeaders = headers
+}
+
+// GetClientIpHeaders returns the headers in precedence for resolving client ip from trusted proxies.
+func (s *Server) GetClientIpHeaders() []string {
+	if len(s.config.ClientIpHeaders) == 0 {
+		return defaultClientIpHeaders
+	}
+	return s.config.ClientIpHeaders
+}
+
+// resolveClientIp resolves and returns the client ip of request `r` from the headers of trusted proxies.
+// The addresses in the list headers like X-Forwarded-For are checked from right to left, as the right
+// ones are appended by the nearer proxies, and the first one that is not trusted proxy is the client ip,
+// so the addresses forged by the client are ignored.
+func (s *Server) resolveClientIp(r *Request) string {
+	var remoteIp = r.GetRemoteIp()
+	trustedNets, err := parseTrustedProxies(s.config.TrustedProxies)
+	if err != nil {
+		s.handleErrorLog(err, r)
+		return remoteIp
+	}
+	if !isTrustedProxy(trustedNets, remoteIp) {
+		return remoteIp
+	}
+	for _, header := range s.GetClientIpHeaders() {
+		var addresses []string
+		switch http.CanonicalHeaderKey(header) {
+		case "X-Forwarded-For":
+			for _, value := range r.Header.Values(header) {
+				addresses = append(addresses, strings.Split(value, ",")...)
+			}
+		case "Forwarded":
+			for _, value := range r.Header.Values(header) {
+				addresses = append(addresses, parseForwardedFor(value)...)
+			}
+		default:
+			addresses = []string{r.Header.Get(header)}
+		}
+		var clientIp string
+		for i := len(addresses) - 1; i >= 0; i-- {
+			ip := parseAddressIp(addresses[i])
+			if ip == "" {
+				// Invalid address, the addresses on the left are not reliable.
+				break
+			}
+			clientIp = ip
+			if !isTrustedProxy(trustedNets, ip) {
+				break
+			}
+		}
+		if clientIp != "" {
+			return clientIp
+		}
+	}
+	return remoteIp
+}
+
+// parseTrustedProxies parses and returns the networks of IPs or CIDRs `proxies`.
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, gerror.NewCodef(gcode.CodeInvalidConfiguration, `invalid trusted proxy "%s"`, proxy)
+			}
+			if ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, gerror.WrapCodef(gcode.CodeInvalidConfiguration, err, `invalid trusted proxy "%s"`, proxy)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// isTrustedProxy checks whether `ip` is in the trusted networks `nets`.
+func isTrustedProxy(nets []*net.IPNet, ip string) bool {
+	parsedIp := net.ParseIP(ip)
+	if parsedIp == nil {
+		return false
+	}
+	for _, ipNet := range nets {
+		if ipNet.Contains(parsedIp) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseForwardedFor returns the "for" addresses of "Forwarded" header value `value`,
+// like: for=192.0.2.60;proto=http;by=203.0.113.43, for="[2001:db8:cafe::17]:4711".
+// See https://www.rfc-editor.org/rfc/rfc7239 .
+func parseForwardedFor(value string) []string {
+	var addresses []string
+	for _, element := range strings.Split(value, ",") {
+		for _, pair := range strings.Split(element, ";") {
+			pair = strings.TrimSpace(pair)
+			if len(pair) > 4 && strings.EqualFold(pair[:4], "for=") {
+				addresses = append(addresses, strings.Trim(pair[4:], `"`))
+			}
+		}
+	}
+	return addresses
+}
+
+// parseAddressIp returns the normalized ip of `address`, which might have port like "192.0.2.60:8080"
+// or "[2001:db8::1]:4711". It returns empty string if `address` is not a valid ip.
+func parseAddressIp(address string) string {
+	address = strings.TrimSpace(address)
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+	address = strings.Trim(address, "[]")
+	if ip := net.ParseIP(address); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"strings"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/encoding/gmsgpack"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// BodyCodec is the interface for decoding request body and encoding response content
+// of certain content types, like MessagePack, Protocol Buffers and CBOR, see RegisterBodyCodec.
+type BodyCodec interface {
+	// Decode decodes request body `body` to parameter map, which is used for request parameters
+	// retrieving and parsing, like Request.Get and Request.Parse.
+	Decode(body []byte) (map[string]interface{}, error)
+
+	// Encode encodes response content `content` to bytes, which is used for the response
+	// negotiated by "Accept" header, see Response.WriteByAccept.
+	Encode(content interface{}) ([]byte, error)
+}
+
+// bodyCodecMsgpack is the BodyCodec of MessagePack.
+type bodyCodecMsgpack struct{}
+
+var (
+	// BodyCodecMsgpack is the BodyCodec of MessagePack, which is registered for content types
+	// "application/msgpack", "application/x-msgpack" and "application/vnd.msgpack" in default.
+	BodyCodecMsgpack BodyCodec = bodyCodecMsgpack{}
+
+	// bodyCodecs is the mapping of content type to BodyCodec.
+	bodyCodecs = gmap.NewStrAnyMap(true)
+)
+
+func init() {
+	RegisterBodyCodec(BodyCodecMsgpack, contentTypeMsgpack, "application/x-msgpack", "application/vnd.msgpack")
+}
+
+// RegisterBodyCodec registers `codec` for content types `contentTypes`, like "application/x-protobuf"
+// or "application/cbor", which overrides the registered one of the same content type.
+//
+// The request body of the content types is decoded by `codec` for parameters, and the response content
+// is encoded by `codec` if the content types are negotiated by "Accept" header, see Response.WriteByAccept.
+func RegisterBodyCodec(codec BodyCodec, contentTypes ...string) {
+	for _, contentType := range contentTypes {
+		if contentType = normalizeContentType(contentType); contentType != "" {
+			bodyCodecs.Set(contentType, codec)
+		}
+	}
+}
+
+// GetBodyCodec returns the BodyCodec registered for content type `contentType`,
+// which can contain parameters like "application/msgpack; charset=utf-8".
+// It returns nil if no BodyCodec registered.
+func GetBodyCodec(contentType string) BodyCodec {
+	if v := bodyCodecs.Get(normalizeContentType(contentType)); v != nil {
+		return v.(BodyCodec)
+	}
+	return nil
+}
+
+// normalizeContentType returns the lower-case media type of `contentType` without parameters.
+func normalizeContentType(contentType string) string {
+	if index := strings.IndexByte(contentType, ';'); index != -1 {
+		contentType = contentType[:index]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// Decode implements interface BodyCodec.
+func (bodyCodecMsgpack) Decode(body []byte) (map[string]interface{}, error) {
+	value, err := gmsgpack.Decode(body)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := value.(map[string]interface{}); ok {
+		return m, nil
+	}
+	if m := gconv.Map(value); m != nil {
+		return m, nil
+	}
+	return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid msgpack body of type %T, map expected`, value)
+}
+
+// Encode implements interface BodyCodec.
+func (bodyCodecMsgpack) Encode(content interface{}) ([]byte, error) {
+	return gmsgpack.Encode(content)
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/gogf/gf/v2/internal/intlog"
 	"github.com/gogf/gf/v2/net/gtcp"
+	"github.com/gogf/gf/v2/os/gctx"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/glog"
 	"github.com/gogf/gf/v2/os/gres"
@@ -51,6 +52,7 @@ type ServerConfig struct {
 
 	// Address specifies the server listening address like "port" or ":port",
 	// multiple addresses joined using ','.
+	// The unix domain socket address is like "unix:/var/run/app.sock".
 	Address string `json:"address"`
 
 	// HTTPSAddr specifies the HTTPS addresses, multiple addresses joined using char ','.
@@ -131,6 +133,15 @@ type ServerConfig struct {
 	// The server responses HTTP status code 403 if it is false.
 	IndexFolder bool `json:"indexFolder"`
 
+	// IndexFolderTemplate specifies the gview template file for listing sub-files when requesting folder,
+	// which uses the built-in HTML if it is empty. The template variables are:
+	// Path(string), Parent(string, empty for root) and Files(slice of ListDirItem), which are escaped already.
+	IndexFolderTemplate string `json:"indexFolderTemplate"`
+
+	// StaticPrecompressed enables serving the pre-compressed sibling files on disk with extension ".br" or ".gz"
+	// of the requested static file if the client accepts the encoding, eg: "app.js.br" for "app.js".
+	StaticPrecompressed bool `json:"staticPrecompressed"`
+
 	// ServerRoot specifies the root directory for static service.
 	ServerRoot string `json:"serverRoot"`
 
@@ -209,6 +220,32 @@ type ServerConfig struct {
 	AccessLogEnabled bool         `json:"accessLogEnabled"` // AccessLogEnabled enables access logging content to files.
 	AccessLogPattern string       `json:"accessLogPattern"` // AccessLogPattern specifies the error log file pattern like: access-{Ymd}.log
 
+	// ======================================================================================================
+	// Request ID.
+	// ======================================================================================================
+
+	// RequestIdEnabled enables the request id feature, which honors the request id in request header
+	// or generates a new one, and attaches it to the context, response header and error response.
+	// The request id is printed by logger and propagated by gclient automatically.
+	RequestIdEnabled bool `json:"requestIdEnabled"`
+
+	// RequestIdHeader specifies the header name of request id, default is "X-Request-ID".
+	RequestIdHeader string `json:"requestIdHeader"`
+
+	// ======================================================================================================
+	// Client IP.
+	// ======================================================================================================
+
+	// TrustedProxies specifies the IPs or CIDRs of the trusted proxies, like: 10.0.0.0/8, 192.168.1.1.
+	// The client ip is resolved from the headers of ClientIpHeaders only if the request is from trusted proxies,
+	// in which the proxies are skipped. It uses the legacy resolving logic trusting any header if it is empty.
+	TrustedProxies []string `json:"trustedProxies"`
+
+	// ClientIpHeaders specifies the headers in precedence for resolving client ip from trusted proxies,
+	// which can be: X-Forwarded-For, Forwarded, X-Real-IP, CF-Connecting-IP, True-Client-IP, etc.
+	// It's X-Forwarded-For, Forwarded and X-Real-IP in default.
+	ClientIpHeaders []string `json:"clientIpHeaders"`
+
 	// ======================================================================================================
 	// PProf.
 	// ======================================================================================================
@@ -223,6 +260,33 @@ type ServerConfig struct {
 	OpenApiPath string `json:"openapiPath"` // OpenApiPath specifies the OpenApi specification file path.
 	SwaggerPath string `json:"swaggerPath"` // SwaggerPath specifies the swagger UI path for route registering.
 
+	// ======================================================================================================
+	// Maintenance.
+	// ======================================================================================================
+
+	// Maintenance enables the maintenance mode, in which the requests are responded with status 503
+	// except the allowed ones. See Server.SetMaintenance.
+	Maintenance bool `json:"maintenance"`
+
+	// MaintenanceOption specifies the response and allowed paths and IPs of the maintenance mode.
+	MaintenanceOption MaintenanceOption `json:"maintenanceOption"`
+
+	// ======================================================================================================
+	// Tracing.
+	// ======================================================================================================
+
+	// TracingSampling specifies the paths always or never sampled and the sampling of failed requests,
+	// which overrides the configured sampler of gtrace. See Server.SetTracingSampling.
+	TracingSampling TracingSamplingOption `json:"tracingSampling"`
+
+	// ======================================================================================================
+	// Diagnostics.
+	// ======================================================================================================
+
+	// PanicDiagnostics specifies capturing diagnostics bundle when handler panics, which contains the stack,
+	// request summary, recent logs and goroutine count for postmortem analysis. See Server.SetPanicDiagnostics.
+	PanicDiagnostics PanicDiagnosticsOption `json:"panicDiagnostics"`
+
 	// ======================================================================================================
 	// Other.
 	// ======================================================================================================
@@ -292,6 +356,7 @@ func NewConfig() ServerConfig {
 		ErrorLogPattern:     "error-{Ymd}.log",
 		AccessLogEnabled:    false,
 		AccessLogPattern:    "access-{Ymd}.log",
+		RequestIdHeader:     gctx.RequestIdHeader,
 		DumpRouterMap:       true,
 		ClientMaxBodySize:   8 * 1024 * 1024, // 8MB
 		FormParsingMemory:   1024 * 1024,     // 1MB
@@ -374,13 +439,17 @@ func (s *Server) SetConfig(c ServerConfig) error {
 	if err := s.config.Logger.SetLevelStr(s.config.LogLevel); err != nil {
 		intlog.Errorf(context.TODO(), `%+v`, err)
 	}
+	// Maintenance.
+	if err := s.SetMaintenance(c.Maintenance, c.MaintenanceOption); err != nil {
+		return err
+	}
 	gracefulEnabled = c.Graceful
 	intlog.Printf(context.TODO(), "SetConfig: %+v", s.config)
 	return nil
 }
 
 // SetAddr sets the listening address for the server.
-// The address is like ':80', '0.0.0.0:80', '127.0.0.1:80', '180.18.99.10:80', etc.
+// The address is like ':80', '0.0.0.0:80', '127.0.0.1:80', '180.18.99.10:80', 'unix:/var/run/app.sock', etc.
 func (s *Server) SetAddr(address string) {
 	s.config.Address = address
 }
@@ -429,7 +498,11 @@ func (s *Server) SetListener(listeners ...net.Listener) error {
 			if v == nil {
 				return gerror.NewCodef(gcode.CodeInvalidParameter, "SetListener failed: listener can not be nil")
 			}
-			ports[k] = fmt.Sprintf(":%d", (v.Addr().(*net.TCPAddr)).Port)
+			if tcpAddr, ok := v.Addr().(*net.TCPAddr); ok {
+				ports[k] = fmt.Sprintf(":%d", tcpAddr.Port)
+			} else {
+				ports[k] = unixAddressPrefix + v.Addr().String()
+			}
 		}
 		s.config.Address = strings.Join(ports, ",")
 		s.config.Listeners = listeners
@@ -441,31 +514,11 @@ func (s *Server) SetListener(listeners ...net.Listener) error {
 // The optional parameter `tlsConfig` specifies custom TLS configuration.
 func (s *Server) EnableHTTPS(certFile, keyFile string, tlsConfig ...*tls.Config) {
 	var ctx = context.TODO()
-	certFileRealPath := gfile.RealPath(certFile)
-	if certFileRealPath == "" {
-		certFileRealPath = gfile.RealPath(gfile.Pwd() + gfile.Separator + certFile)
-		if certFileRealPath == "" {
-			certFileRealPath = gfile.RealPath(gfile.MainPkgPath() + gfile.Separator + certFile)
-		}
-	}
-	// Resource.
-	if certFileRealPath == "" && gres.Contains(certFile) {
-		certFileRealPath = certFile
-	}
+	certFileRealPath := searchCertFilePath(certFile)
 	if certFileRealPath == "" {
 		s.Logger().Fatalf(ctx, `EnableHTTPS failed: certFile "%s" does not exist`, certFile)
 	}
-	keyFileRealPath := gfile.RealPath(keyFile)
-	if keyFileRealPath == "" {
-		keyFileRealPath = gfile.RealPath(gfile.Pwd() + gfile.Separator + keyFile)
-		if keyFileRealPath == "" {
-			keyFileRealPath = gfile.RealPath(gfile.MainPkgPath() + gfile.Separator + keyFile)
-		}
-	}
-	// Resource.
-	if keyFileRealPath == "" && gres.Contains(keyFile) {
-		keyFileRealPath = keyFile
-	}
+	keyFileRealPath := searchCertFilePath(keyFile)
 	if keyFileRealPath == "" {
 		s.Logger().Fatal(ctx, `EnableHTTPS failed: keyFile "%s" does not exist`, keyFile)
 	}
@@ -476,6 +529,23 @@ func (s *Server) EnableHTTPS(certFile, keyFile string, tlsConfig ...*tls.Config)
 	}
 }
 
+// searchCertFilePath searches and returns the real path of certification or key `file`,
+// which might be a resource file. It returns empty string if not found.
+func searchCertFilePath(file string) string {
+	realPath := gfile.RealPath(file)
+	if realPath == "" {
+		realPath = gfile.RealPath(gfile.Pwd() + gfile.Separator + file)
+		if realPath == "" {
+			realPath = gfile.RealPath(gfile.MainPkgPath() + gfile.Separator + file)
+		}
+	}
+	// Resource.
+	if realPath == "" && gres.Contains(file) {
+		realPath = file
+	}
+	return realPath
+}
+
 // SetTLSConfig sets custom TLS configuration and enables HTTPS feature for the server.
 func (s *Server) SetTLSConfig(tlsConfig *tls.Config) {
 	s.config.TLSConfig = tlsConfig
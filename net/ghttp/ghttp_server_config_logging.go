@@ -33,6 +33,8 @@ func (s *Server) SetLogger(logger *glog.Logger) {
 
 // Logger is alias of GetLogger.
 func (s *Server) Logger() *glog.Logger {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
 	return s.config.Logger
 }
 
@@ -63,15 +65,21 @@ func (s *Server) SetErrorStack(enabled bool) {
 
 // GetLogPath returns the log path.
 func (s *Server) GetLogPath() string {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
 	return s.config.LogPath
 }
 
 // IsAccessLogEnabled checks whether the access log enabled.
 func (s *Server) IsAccessLogEnabled() bool {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
 	return s.config.AccessLogEnabled
 }
 
 // IsErrorLogEnabled checks whether the error log enabled.
 func (s *Server) IsErrorLogEnabled() bool {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
 	return s.config.ErrorLogEnabled
 }
@@ -0,0 +1,196 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"crypto/tls"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gres"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// ReloadConfig applies the runtime reloadable subset of configuration `c` to the server,
+// which does not restart the listeners. The reloadable configurations are:
+//
+// Logging: LogPath, LogLevel, LogStdout, ErrorStack, ErrorLogEnabled, ErrorLogPattern,
+// AccessLogEnabled and AccessLogPattern.
+//
+// Timeouts: ReadTimeout, WriteTimeout and IdleTimeout, which take effect for new connections.
+// The underlying http.Server is replaced for the new connections if they are changed, and the
+// previous one is shut down gracefully.
+//
+// Static: ServerRoot, SearchPaths, StaticPaths, IndexFiles, IndexFolder and FileServerEnabled.
+//
+// HTTPS: HTTPSCertPath and HTTPSKeyPath, which take effect for new TLS handshakes. The certification
+// files are reloaded even if the paths are not changed, so renewed certifications can be applied.
+// It is only available if the certification is loaded from files but not the custom TLSConfig.
+//
+// Maintenance: Maintenance and MaintenanceOption, see SetMaintenance.
+//
+// The other configurations like listening address are ignored. The static paths, logging and HTTPS
+// certification are checked before applying anything, so the server keeps serving with previous ones if
+// they are invalid.
+func (s *Server) ReloadConfig(c ServerConfig) error {
+	var ctx = context.TODO()
+	s.configMu.RLock()
+	config := s.config
+	s.configMu.RUnlock()
+
+	// Static paths, which are checked in advance to apply nothing if any path is invalid.
+	// The real path of previous server root is in the current search paths, which is removed if changed.
+	var (
+		searchPaths  = make([]string, 0)
+		prevRootPath string
+	)
+	if c.ServerRoot != "" {
+		rootPath, err := searchStaticDirPath(c.ServerRoot)
+		if err != nil {
+			return err
+		}
+		searchPaths = append(searchPaths, rootPath)
+	}
+	if c.ServerRoot != config.ServerRoot && config.ServerRoot != "" {
+		prevRootPath, _ = searchStaticDirPath(config.ServerRoot)
+	}
+	for _, path := range c.SearchPaths {
+		realPath, err := searchStaticDirPath(path)
+		if err != nil {
+			return err
+		}
+		if realPath != prevRootPath && !gstr.InArray(searchPaths, realPath) {
+			searchPaths = append(searchPaths, realPath)
+		}
+	}
+	// Maintenance allowed IPs.
+	if _, err := parseTrustedProxies(c.MaintenanceOption.AllowIps); err != nil {
+		return err
+	}
+	// HTTPS certification.
+	var (
+		certificate       *tls.Certificate
+		certFile, keyFile string
+	)
+	if s.tlsCertificate.Val() != nil && c.HTTPSCertPath != "" {
+		certFile = searchCertFilePath(c.HTTPSCertPath)
+		keyFile = searchCertFilePath(c.HTTPSKeyPath)
+		if certFile == "" || keyFile == "" {
+			return gerror.NewCodef(
+				gcode.CodeInvalidConfiguration,
+				`certFile "%s" or keyFile "%s" does not exist`,
+				c.HTTPSCertPath, c.HTTPSKeyPath,
+			)
+		}
+		var err error
+		if certificate, err = loadCertificate(certFile, keyFile); err != nil {
+			return err
+		}
+	}
+	// Logging, which uses a copy of current logger as the logger might be in use.
+	var logger = config.Logger
+	if (c.LogPath != "" && c.LogPath != logger.GetPath()) || (c.LogLevel != "" && c.LogLevel != config.LogLevel) {
+		logger = logger.Clone()
+		if c.LogPath != "" && c.LogPath != logger.GetPath() {
+			if err := logger.SetPath(c.LogPath); err != nil {
+				return err
+			}
+		}
+		if c.LogLevel != "" {
+			if err := logger.SetLevelStr(c.LogLevel); err != nil {
+				return err
+			}
+		}
+	}
+
+	// All checks passed, it applies the configurations.
+	if certificate != nil {
+		s.tlsCertificate.Set(certificate)
+	}
+	s.configMu.Lock()
+	if certificate != nil {
+		s.config.HTTPSCertPath = certFile
+		s.config.HTTPSKeyPath = keyFile
+	}
+
+	// Logging.
+	s.config.Logger = logger
+	if c.LogLevel != "" {
+		s.config.LogLevel = c.LogLevel
+	}
+	s.config.LogPath = c.LogPath
+	s.config.LogStdout = c.LogStdout
+	s.config.ErrorStack = c.ErrorStack
+	s.config.ErrorLogEnabled = c.ErrorLogEnabled
+	s.config.ErrorLogPattern = c.ErrorLogPattern
+	s.config.AccessLogEnabled = c.AccessLogEnabled
+	s.config.AccessLogPattern = c.AccessLogPattern
+
+	// Timeouts.
+	timeoutsChanged := c.ReadTimeout != s.config.ReadTimeout ||
+		c.WriteTimeout != s.config.WriteTimeout ||
+		c.IdleTimeout != s.config.IdleTimeout
+	s.config.ReadTimeout = c.ReadTimeout
+	s.config.WriteTimeout = c.WriteTimeout
+	s.config.IdleTimeout = c.IdleTimeout
+
+	// Static.
+	s.config.ServerRoot = c.ServerRoot
+	s.config.SearchPaths = searchPaths
+	s.config.StaticPaths = c.StaticPaths
+	s.config.IndexFiles = c.IndexFiles
+	s.config.IndexFolder = c.IndexFolder
+	s.config.FileServerEnabled = c.FileServerEnabled || len(searchPaths) > 0 || len(c.StaticPaths) > 0
+	s.configMu.Unlock()
+
+	// The timeouts of the running http.Server cannot be changed,
+	// so the http.Server is replaced with a new one for new connections.
+	if timeoutsChanged {
+		for _, server := range s.servers {
+			server.replaceHttpServer(ctx)
+		}
+	}
+
+	// Maintenance.
+	if err := s.SetMaintenance(c.Maintenance, c.MaintenanceOption); err != nil {
+		return err
+	}
+
+	intlog.Printf(ctx, "ReloadConfig: %+v", c)
+	return nil
+}
+
+// ReloadConfigWithMap applies the runtime reloadable subset of configuration map `m` to the server,
+// which is usually called with the configuration map from configuration file changes.
+// The keys not in `m` keep their current values. See ReloadConfig.
+func (s *Server) ReloadConfigWithMap(m map[string]interface{}) error {
+	s.configMu.RLock()
+	config := s.config
+	s.configMu.RUnlock()
+	if err := gconv.Struct(m, &config); err != nil {
+		return err
+	}
+	return s.ReloadConfig(config)
+}
+
+// searchStaticDirPath searches and returns the real path of static directory `path`,
+// which might be a resource directory.
+func searchStaticDirPath(path string) (string, error) {
+	if gres.Contains(path) {
+		return path, nil
+	}
+	realPath, err := gfile.Search(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(realPath, gfile.Separator), nil
+}
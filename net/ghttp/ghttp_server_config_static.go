@@ -31,6 +31,8 @@ func (s *Server) SetIndexFiles(indexFiles []string) {
 
 // GetIndexFiles retrieves and returns the index files from the server.
 func (s *Server) GetIndexFiles() []string {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
 	return s.config.IndexFiles
 }
 
@@ -130,3 +132,14 @@ func (s *Server) AddStaticPath(prefix string, path string) {
 	}
 	s.config.FileServerEnabled = true
 }
+
+// SetIndexFolderTemplate sets the gview template file for listing sub-files when requesting folder.
+func (s *Server) SetIndexFolderTemplate(template string) {
+	s.config.IndexFolderTemplate = template
+}
+
+// SetStaticPrecompressed enables/disables serving the pre-compressed ".br" or ".gz" sibling files
+// of static files.
+func (s *Server) SetStaticPrecompressed(enabled bool) {
+	s.config.StaticPrecompressed = enabled
+}
@@ -0,0 +1,230 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// PanicDiagnosticsOption is the option capturing diagnostics bundle when handler panics,
+// see Server.SetPanicDiagnostics.
+type PanicDiagnosticsOption struct {
+	// Enabled enables capturing diagnostics bundle when handler panics.
+	Enabled bool `json:"enabled"`
+
+	// Path specifies the directory storing the diagnostics bundle files in json,
+	// which are named like "panic-20060102-150405.000-{random}.json".
+	Path string `json:"path"`
+
+	// Sink specifies the custom function receiving the diagnostics bundle, which is called
+	// in addition to storing file if Path is configured.
+	Sink func(ctx context.Context, bundle *PanicDiagnostics) `json:"-"`
+
+	// LogSize specifies the count of recent logging lines of server logger retained in bundle,
+	// which is 100 in default. It disables capturing logs if it is negative.
+	LogSize int `json:"logSize"`
+
+	// RedactKeys specifies the extra keys of request headers and parameters whose values are redacted
+	// in bundle, in addition to the default ones like "Authorization", "Cookie", "password" and "token".
+	// The key is redacted if it contains any of them case-insensitively.
+	RedactKeys []string `json:"redactKeys"`
+}
+
+// PanicDiagnostics is the diagnostics bundle captured when handler panics.
+type PanicDiagnostics struct {
+	Time       time.Time               `json:"time"`       // Time when the panic is captured.
+	Error      string                  `json:"error"`      // Error message of the panic.
+	Stack      string                  `json:"stack"`      // Stack of the panic.
+	Request    PanicDiagnosticsRequest `json:"request"`    // Summary of the request, in which the sensitive values are redacted.
+	RecentLogs []string                `json:"recentLogs"` // Recent logging lines of server logger.
+	Goroutines int                     `json:"goroutines"` // Count of goroutines when the panic is captured.
+}
+
+// PanicDiagnosticsRequest is the request summary of diagnostics bundle.
+type PanicDiagnosticsRequest struct {
+	Method    string                 `json:"method"`
+	Url       string                 `json:"url"`
+	Host      string                 `json:"host"`
+	Proto     string                 `json:"proto"`
+	ClientIp  string                 `json:"clientIp"`
+	RequestId string                 `json:"requestId,omitempty"`
+	Header    map[string]string      `json:"header"`
+	Params    map[string]interface{} `json:"params"`
+}
+
+// logRingBuffer retains the recent logging lines, which implements io.Writer for logger output.
+type logRingBuffer struct {
+	mu    sync.Mutex
+	lines []string // Ring of logging lines.
+	next  int      // Index for next line.
+	full  bool     // Whether the ring is full.
+}
+
+const (
+	redactedValue              = "******"
+	defaultPanicDiagnosticsLog = 100
+)
+
+var (
+	// defaultRedactKeys are the keys redacted in request summary of diagnostics bundle.
+	defaultRedactKeys = []string{
+		"authorization", "cookie", "password", "passwd", "secret", "token", "api-key", "api_key", "apikey",
+	}
+)
+
+// SetPanicDiagnostics sets the option capturing diagnostics bundle when handler panics.
+// It should be called before the server starts, and the recent logs are captured from
+// the logger configured when the server starts.
+func (s *Server) SetPanicDiagnostics(option PanicDiagnosticsOption) {
+	s.config.PanicDiagnostics = option
+}
+
+// initPanicDiagnostics initializes the recent logs capturing of panic diagnostics if enabled.
+func (s *Server) initPanicDiagnostics() {
+	option := s.config.PanicDiagnostics
+	if !option.Enabled || option.LogSize < 0 || s.diagnosticsLogs != nil {
+		return
+	}
+	size := option.LogSize
+	if size == 0 {
+		size = defaultPanicDiagnosticsLog
+	}
+	s.diagnosticsLogs = &logRingBuffer{
+		lines: make([]string, size),
+	}
+	s.Logger().AddOutput(glog.Output{
+		Writer: s.diagnosticsLogs,
+		Level:  s.Logger().GetLevel(),
+	})
+}
+
+// handlePanicDiagnostics captures diagnostics bundle for the panic `err` of request `r` if enabled.
+func (s *Server) handlePanicDiagnostics(r *Request, err error) {
+	option := s.config.PanicDiagnostics
+	if !option.Enabled || (option.Path == "" && option.Sink == nil) {
+		return
+	}
+	var (
+		ctx    = r.Context()
+		bundle = &PanicDiagnostics{
+			Time:       time.Now(),
+			Error:      err.Error(),
+			Stack:      gerror.Stack(err),
+			Request:    s.newPanicDiagnosticsRequest(r),
+			Goroutines: runtime.NumGoroutine(),
+		}
+	)
+	if s.diagnosticsLogs != nil {
+		bundle.RecentLogs = s.diagnosticsLogs.Lines()
+	}
+	if option.Path != "" {
+		var (
+			fileName = fmt.Sprintf(
+				`panic-%s-%s.json`, bundle.Time.Format("20060102-150405.000"), grand.S(6),
+			)
+			filePath = gfile.Join(option.Path, fileName)
+		)
+		if content, err := json.MarshalIndent(bundle, "", "\t"); err != nil {
+			intlog.Errorf(ctx, `%+v`, err)
+		} else if err = gfile.PutBytes(filePath, content); err != nil {
+			s.Logger().Errorf(ctx, `saving panic diagnostics to "%s" failed: %+v`, filePath, err)
+		}
+	}
+	if option.Sink != nil {
+		option.Sink(ctx, bundle)
+	}
+}
+
+// newPanicDiagnosticsRequest creates and returns the request summary of `r` with sensitive values redacted.
+func (s *Server) newPanicDiagnosticsRequest(r *Request) PanicDiagnosticsRequest {
+	var (
+		redactKeys = append(defaultRedactKeys, s.config.PanicDiagnostics.RedactKeys...)
+		summary    = PanicDiagnosticsRequest{
+			Method:    r.Method,
+			Host:      r.Host,
+			Proto:     r.Proto,
+			ClientIp:  r.GetClientIp(),
+			RequestId: r.GetRequestId(),
+			Header:    make(map[string]string, len(r.Header)),
+			Params:    make(map[string]interface{}),
+		}
+	)
+	// Url with redacted query parameters.
+	u := *r.URL
+	if query := u.Query(); len(query) > 0 {
+		for k := range query {
+			if isRedactKey(k, redactKeys) {
+				query.Set(k, redactedValue)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+	summary.Url = u.String()
+	// Headers.
+	for k := range r.Header {
+		if isRedactKey(k, redactKeys) {
+			summary.Header[k] = redactedValue
+		} else {
+			summary.Header[k] = r.Header.Get(k)
+		}
+	}
+	// Parameters.
+	for k, v := range r.GetRequestMap() {
+		if isRedactKey(k, redactKeys) {
+			summary.Params[k] = redactedValue
+		} else {
+			summary.Params[k] = v
+		}
+	}
+	return summary
+}
+
+// isRedactKey checks whether `key` contains any of `redactKeys` case-insensitively.
+func isRedactKey(key string, redactKeys []string) bool {
+	key = strings.ToLower(key)
+	for _, redactKey := range redactKeys {
+		if redactKey != "" && strings.Contains(key, strings.ToLower(redactKey)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Write implements the io.Writer interface, which retains `p` as a logging line.
+func (b *logRingBuffer) Write(p []byte) (n int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lines[b.next] = string(bytes.TrimRight(p, "\r\n"))
+	if b.next++; b.next == len(b.lines) {
+		b.next = 0
+		b.full = true
+	}
+	return len(p), nil
+}
+
+// Lines returns the retained logging lines in writing order.
+func (b *logRingBuffer) Lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.full {
+		return append([]string{}, b.lines[:b.next]...)
+	}
+	return append(append([]string{}, b.lines[b.next:]...), b.lines[:b.next]...)
+}
@@ -24,24 +24,25 @@ import (
 
 // gracefulServer wraps the net/http.Server with graceful reload/restart feature.
 type gracefulServer struct {
-	server      *Server      // Belonged server.
-	fd          uintptr      // File descriptor for passing to the child process when graceful reload.
-	address     string       // Listening address like:":80", ":8080".
-	httpServer  *http.Server // Underlying http.Server.
-	rawListener net.Listener // Underlying net.Listener.
-	rawLnMu     sync.RWMutex // Concurrent safety mutex for `rawListener`.
-	listener    net.Listener // Wrapped net.Listener.
-	isHttps     bool         // Is HTTPS.
-	status      int          // Status of current server.
+	server       *Server          // Belonged server.
+	fd           uintptr          // File descriptor for passing to the child process when graceful reload.
+	address      string           // Listening address like:":80", ":8080".
+	httpServer   *http.Server     // Underlying http.Server, which is replaced by replaceHttpServer.
+	httpServerMu sync.RWMutex     // Concurrent safety mutex for `httpServer` and `serving`.
+	rawListener  net.Listener     // Underlying net.Listener.
+	rawLnMu      sync.RWMutex     // Concurrent safety mutex for `rawListener`.
+	listener     net.Listener     // Wrapped net.Listener.
+	shared       *sharedListener  // Shared `listener` for the replaced http.Server.
+	serving      *servingListener // The net.Listener that current `httpServer` is serving.
+	isHttps      bool             // Is HTTPS.
+	status       int              // Status of current server.
 }
 
 // newGracefulServer creates and returns a graceful http server with a given address.
 // The optional parameter `fd` specifies the file descriptor which is passed from parent server.
 func (s *Server) newGracefulServer(address string, fd ...int) *gracefulServer {
 	// Change port to address like: 80 -> :80
-	if gstr.IsNumeric(address) {
-		address = ":" + address
-	}
+	address = normalizeListenAddress(address)
 	gs := &gracefulServer{
 		server:     s,
 		address:    address,
@@ -51,15 +52,24 @@ func (s *Server) newGracefulServer(address string, fd ...int) *gracefulServer {
 		gs.fd = uintptr(fd[0])
 	}
 	if s.config.Listeners != nil {
-		addrArray := gstr.SplitAndTrim(address, ":")
-		addrPort, err := strconv.Atoi(addrArray[len(addrArray)-1])
-		if err == nil {
+		if network, addr := parseListenAddress(address); network == "unix" {
 			for _, v := range s.config.Listeners {
-				if listenerPort := (v.Addr().(*net.TCPAddr)).Port; listenerPort == addrPort {
+				if v.Addr().Network() == network && v.Addr().String() == addr {
 					gs.rawListener = v
 					break
 				}
 			}
+		} else {
+			addrArray := gstr.SplitAndTrim(address, ":")
+			addrPort, err := strconv.Atoi(addrArray[len(addrArray)-1])
+			if err == nil {
+				for _, v := range s.config.Listeners {
+					if tcpAddr, ok := v.Addr().(*net.TCPAddr); ok && tcpAddr.Port == addrPort {
+						gs.rawListener = v
+						break
+					}
+				}
+			}
 		}
 	}
 	return gs
@@ -74,7 +84,8 @@ func (s *Server) newHttpServer(address string) *http.Server {
 		WriteTimeout:   s.config.WriteTimeout,
 		IdleTimeout:    s.config.IdleTimeout,
 		MaxHeaderBytes: s.config.MaxHeaderBytes,
-		ErrorLog:       log.New(&errorLogger{logger: s.config.Logger}, "", 0),
+		ErrorLog:       log.New(&errorLogger{logger: s.Logger()}, "", 0),
+		ConnContext:    withListenerAddress(address),
 	}
 	server.SetKeepAlivesEnabled(s.config.KeepAlive)
 	return server
@@ -94,8 +105,8 @@ func (s *gracefulServer) ListenAndServe() error {
 // Fd retrieves and returns the file descriptor of the current server.
 // It is available ony in *nix like operating systems like linux, unix, darwin.
 func (s *gracefulServer) Fd() uintptr {
-	if ln := s.getRawListener(); ln != nil {
-		file, err := ln.(*net.TCPListener).File()
+	if ln, ok := s.getRawListener().(interface{ File() (*os.File, error) }); ok {
+		file, err := ln.File()
 		if err == nil {
 			return file.Fd()
 		}
@@ -118,28 +129,25 @@ func (s *gracefulServer) ListenAndServeTLS(certFile, keyFile string, tlsConfig .
 	)
 	if len(tlsConfig) > 0 && tlsConfig[0] != nil {
 		config = tlsConfig[0]
-	} else if s.httpServer.TLSConfig != nil {
-		config = s.httpServer.TLSConfig
+	} else if httpServer := s.getHttpServer(); httpServer.TLSConfig != nil {
+		config = httpServer.TLSConfig
 	} else {
 		config = &tls.Config{}
 	}
 	if config.NextProtos == nil {
 		config.NextProtos = []string{"http/1.1"}
 	}
-	var err error
-	if len(config.Certificates) == 0 {
-		config.Certificates = make([]tls.Certificate, 1)
-		if gres.Contains(certFile) {
-			config.Certificates[0], err = tls.X509KeyPair(
-				gres.GetContent(certFile),
-				gres.GetContent(keyFile),
-			)
-		} else {
-			config.Certificates[0], err = tls.LoadX509KeyPair(certFile, keyFile)
+	if len(config.Certificates) == 0 && config.GetCertificate == nil {
+		certificate, err := loadCertificate(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		// The certificate is retrieved from server for each handshake,
+		// so that it can be replaced by Server.ReloadConfig.
+		s.server.tlsCertificate.Set(certificate)
+		config.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return s.server.tlsCertificate.Val().(*tls.Certificate), nil
 		}
-	}
-	if err != nil {
-		return gerror.Wrapf(err, `open certFile "%s" and keyFile "%s" failed`, certFile, keyFile)
 	}
 	ln, err := s.getNetListener()
 	if err != nil {
@@ -151,14 +159,44 @@ func (s *gracefulServer) ListenAndServeTLS(certFile, keyFile string, tlsConfig .
 	return s.doServe(ctx)
 }
 
+// loadCertificate loads and returns the certificate of `certFile` and `keyFile`,
+// which might be resource files.
+func loadCertificate(certFile, keyFile string) (*tls.Certificate, error) {
+	var (
+		certificate tls.Certificate
+		err         error
+	)
+	if gres.Contains(certFile) {
+		certificate, err = tls.X509KeyPair(
+			gres.GetContent(certFile),
+			gres.GetContent(keyFile),
+		)
+	} else {
+		certificate, err = tls.LoadX509KeyPair(certFile, keyFile)
+	}
+	if err != nil {
+		return nil, gerror.Wrapf(err, `open certFile "%s" and keyFile "%s" failed`, certFile, keyFile)
+	}
+	return &certificate, nil
+}
+
 // GetListenedPort retrieves and returns one port which is listened to by current server.
+// It returns 0 if the server is not listening on TCP, like the unix domain socket.
 func (s *gracefulServer) GetListenedPort() int {
 	if ln := s.getRawListener(); ln != nil {
-		return ln.Addr().(*net.TCPAddr).Port
+		if tcpAddr, ok := ln.Addr().(*net.TCPAddr); ok {
+			return tcpAddr.Port
+		}
 	}
 	return 0
 }
 
+// isUnix checks and returns whether current server is listening on the unix domain socket.
+func (s *gracefulServer) isUnix() bool {
+	network, _ := parseListenAddress(s.address)
+	return network == "unix"
+}
+
 // getProto retrieves and returns the proto string of current server.
 func (s *gracefulServer) getProto() string {
 	proto := "http"
@@ -180,11 +218,66 @@ func (s *gracefulServer) doServe(ctx context.Context) error {
 		gproc.Pid(), s.getProto(), action, s.address,
 	)
 	s.status = ServerStatusRunning
-	err := s.httpServer.Serve(s.listener)
+	s.httpServerMu.Lock()
+	s.shared = newSharedListener(s.listener)
+	s.httpServerMu.Unlock()
+	var err error
+	for {
+		s.httpServerMu.Lock()
+		var (
+			httpServer = s.httpServer
+			serving    = s.shared.newServingListener()
+		)
+		s.serving = serving
+		s.httpServerMu.Unlock()
+		err = httpServer.Serve(serving)
+		// It serves with the new http.Server if current one is replaced.
+		if err == http.ErrServerClosed && s.getHttpServer() != httpServer {
+			continue
+		}
+		break
+	}
 	s.status = ServerStatusStopped
 	return err
 }
 
+// getHttpServer returns the underlying http.Server of current server.
+func (s *gracefulServer) getHttpServer() *http.Server {
+	s.httpServerMu.RLock()
+	defer s.httpServerMu.RUnlock()
+	return s.httpServer
+}
+
+// replaceHttpServer replaces the underlying http.Server with a new one created from current
+// configuration, which serves the new connections without closing the listener.
+// The previous http.Server is shut down gracefully, so that its connections are not affected.
+func (s *gracefulServer) replaceHttpServer(ctx context.Context) {
+	s.httpServerMu.Lock()
+	var (
+		previous = s.httpServer
+		serving  = s.serving
+	)
+	s.httpServer = s.server.newHttpServer(s.address)
+	s.httpServer.TLSConfig = previous.TLSConfig
+	s.httpServerMu.Unlock()
+	if serving == nil {
+		// It is not serving yet.
+		return
+	}
+	serving.detach()
+	go func() {
+		timeoutCtx, cancelFunc := context.WithTimeout(ctx, gracefulShutdownTimeout)
+		defer cancelFunc()
+		if err := previous.Shutdown(timeoutCtx); err != nil {
+			s.server.Logger().Errorf(
+				ctx,
+				"%d: %s server [%s] shutdown replaced server error: %v",
+				gproc.Pid(), s.getProto(), s.address, err,
+			)
+		}
+	}()
+}
+
 // getNetListener retrieves and returns the wrapped net.Listener.
 func (s *gracefulServer) getNetListener() (net.Listener, error) {
 	if s.rawListener != nil {
@@ -202,10 +295,7 @@ func (s *gracefulServer) getNetListener() (net.Listener, error) {
 			return nil, err
 		}
 	} else {
-		ln, err = net.Listen("tcp", s.httpServer.Addr)
-		if err != nil {
-			err = gerror.Wrapf(err, "%d: net.Listen failed", gproc.Pid())
-		}
+		ln, err = listen(s.address)
 	}
 	return ln, err
 }
@@ -217,13 +307,25 @@ func (s *gracefulServer) shutdown(ctx context.Context) {
 	}
 	timeoutCtx, cancelFunc := context.WithTimeout(ctx, gracefulShutdownTimeout)
 	defer cancelFunc()
-	if err := s.httpServer.Shutdown(timeoutCtx); err != nil {
+	if err := s.getHttpServer().Shutdown(timeoutCtx); err != nil {
 		s.server.Logger().Errorf(
 			ctx,
 			"%d: %s server [%s] shutdown error: %v",
 			gproc.Pid(), s.getProto(), s.address, err,
 		)
 	}
+	s.closeSharedListener()
+}
+
+// closeSharedListener closes the shared listener, which might be not closed by the http.Server
+// if the http.Server is replaced but not serving yet.
+func (s *gracefulServer) closeSharedListener() {
+	s.httpServerMu.RLock()
+	shared := s.shared
+	s.httpServerMu.RUnlock()
+	if shared != nil {
+		_ = shared.close()
+	}
 }
 
 // setRawListener sets `rawListener` with given net.Listener.
@@ -245,11 +347,12 @@ func (s *gracefulServer) close(ctx context.Context) {
 	if s.status == ServerStatusStopped {
 		return
 	}
-	if err := s.httpServer.Close(); err != nil {
+	if err := s.getHttpServer().Close(); err != nil {
 		s.server.Logger().Errorf(
 			ctx,
 			"%d: %s server [%s] closed error: %v",
 			gproc.Pid(), s.getProto(), s.address, err,
 		)
 	}
+	s.closeSharedListener()
 }
@@ -0,0 +1,139 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net"
+	"sync"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// sharedListener accepts the connections of the underlying net.Listener for the http.Server serving it,
+// so that the http.Server can be replaced with a new one without closing the net.Listener.
+type sharedListener struct {
+	net.Listener                   // The underlying net.Listener.
+	acceptOnce   sync.Once         // Starts the accepting loop only once.
+	closeOnce    sync.Once         // Closes the underlying net.Listener only once.
+	accepted     chan acceptResult // Accepted connections of the underlying net.Listener.
+	closed       chan struct{}     // Closed if the underlying net.Listener is closed.
+	done         chan struct{}     // Closed if the underlying net.Listener fails accepting permanently.
+	err          error             // The permanent accepting error of the underlying net.Listener.
+}
+
+// acceptResult is the result of accepting of the underlying net.Listener.
+type acceptResult struct {
+	conn net.Conn
+	err  error
+}
+
+// servingListener is the net.Listener for one http.Server, which accepts connections from sharedListener.
+type servingListener struct {
+	shared    *sharedListener
+	closeOnce sync.Once
+	closed    chan struct{}
+	detached  *gtype.Bool // If detached, closing does not close the underlying net.Listener.
+}
+
+// errServingListenerClosed is the accepting error of a closed servingListener.
+var errServingListenerClosed = gerror.NewCode(gcode.CodeInvalidOperation, "use of closed network connection")
+
+// newSharedListener creates and returns a sharedListener for `ln`.
+func newSharedListener(ln net.Listener) *sharedListener {
+	return &sharedListener{
+		Listener: ln,
+		accepted: make(chan acceptResult),
+		closed:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// newServingListener creates and returns a servingListener accepting connections from `l`.
+func (l *sharedListener) newServingListener() *servingListener {
+	l.acceptOnce.Do(func() {
+		go l.acceptLoop()
+	})
+	return &servingListener{
+		shared:   l,
+		closed:   make(chan struct{}),
+		detached: gtype.NewBool(),
+	}
+}
+
+// acceptLoop accepts connections of the underlying net.Listener until it fails permanently.
+func (l *sharedListener) acceptLoop() {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			if netErr, ok := err.(net.Error); !ok || !netErr.Temporary() {
+				l.err = err
+				close(l.done)
+				return
+			}
+		}
+		select {
+		case l.accepted <- acceptResult{conn: conn, err: err}:
+		case <-l.closed:
+			if conn != nil {
+				_ = conn.Close()
+			}
+			return
+		}
+	}
+}
+
+// close closes the underlying net.Listener.
+func (l *sharedListener) close() (err error) {
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		err = l.Listener.Close()
+	})
+	return
+}
+
+// Accept waits for and returns the next connection of the underlying net.Listener.
+func (l *servingListener) Accept() (net.Conn, error) {
+	select {
+	case <-l.closed:
+		return nil, errServingListenerClosed
+	default:
+	}
+	select {
+	case result := <-l.shared.accepted:
+		return result.conn, result.err
+	case <-l.shared.done:
+		return nil, l.shared.err
+	case <-l.shared.closed:
+		return nil, errServingListenerClosed
+	case <-l.closed:
+		return nil, errServingListenerClosed
+	}
+}
+
+// Close stops accepting and closes the underlying net.Listener if it is not detached.
+func (l *servingListener) Close() (err error) {
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		if !l.detached.Val() {
+			err = l.shared.close()
+		}
+	})
+	return
+}
+
+// Addr returns the address of the underlying net.Listener.
+func (l *servingListener) Addr() net.Addr {
+	return l.shared.Listener.Addr()
+}
+
+// detach makes closing not close the underlying net.Listener, which is used when the http.Server
+// serving it is replaced.
+func (l *servingListener) detach() {
+	l.detached.Set(true)
+}
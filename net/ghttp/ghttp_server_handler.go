@@ -44,6 +44,9 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Create a new request object.
 	request := newRequest(s, r, w)
 
+	// Request id handling.
+	s.handleRequestId(request)
+
 	defer func() {
 		request.LeaveTime = gtime.TimestampMilli()
 		// error log handling.
@@ -52,15 +55,18 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		} else {
 			if exception := recover(); exception != nil {
 				request.Response.WriteStatus(http.StatusInternalServerError)
+				var err error
 				if v, ok := exception.(error); ok {
 					if code := gerror.Code(v); code != gcode.CodeNil {
-						s.handleErrorLog(v, request)
+						err = v
 					} else {
-						s.handleErrorLog(gerror.WrapCodeSkip(gcode.CodeInternalError, 1, v, ""), request)
+						err = gerror.WrapCodeSkip(gcode.CodeInternalError, 1, v, "")
 					}
 				} else {
-					s.handleErrorLog(gerror.NewCodeSkipf(gcode.CodeInternalError, 1, "%+v", exception), request)
+					err = gerror.NewCodeSkipf(gcode.CodeInternalError, 1, "%+v", exception)
 				}
+				s.handlePanicDiagnostics(request, err)
+				s.handleErrorLog(err, request)
 			}
 		}
 		// access log handling.
@@ -85,14 +91,25 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
+	// Maintenance mode handling, which responds 503 and exits the request.
+	s.handleMaintenance(request)
+
 	// ============================================================
 	// Priority:
 	// Static File > Dynamic Service > Static Directory
 	// ============================================================
 
+	// API version handling, which might route the request to the handler of the version.
+	if s.apiVersion != nil {
+		s.handleAPIVersion(request)
+	}
+
 	// Search the static file with most high priority,
 	// which also handle the index files feature.
-	if s.config.FileServerEnabled {
+	s.configMu.RLock()
+	fileServerEnabled := s.config.FileServerEnabled
+	s.configMu.RUnlock()
+	if fileServerEnabled {
 		request.StaticFile = s.searchStaticFile(r.URL.Path)
 		if request.StaticFile != nil {
 			request.isFileRequest = true
@@ -102,13 +119,20 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Search the dynamic service handler.
 	request.handlers, request.hasHookHandler, request.hasServeHandler = s.getHandlersWithCache(request)
 
+	// Listener middleware handling.
+	if s.listenerHandlers != nil && !request.isFileRequest {
+		s.handleListenerMiddleware(request)
+	}
+
 	// Check the service type static or dynamic for current request.
 	if request.StaticFile != nil && request.StaticFile.IsDir && request.hasServeHandler {
 		request.isFileRequest = false
 	}
 
 	// HOOK - BeforeServe
-	s.callHookHandler(HookBeforeServe, request)
+	if !request.IsExited() {
+		s.callHookHandler(HookBeforeServe, request)
+	}
 
 	// Core serving handling.
 	if !request.IsExited() {
@@ -150,7 +174,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			request.Response.WriteHeader(http.StatusOK)
 		} else if err := request.GetError(); err != nil {
 			if request.Response.BufferLength() == 0 {
-				request.Response.Write(err.Error())
+				request.Response.Write(gerror.Redact(err))
 			}
 			request.Response.WriteHeader(http.StatusInternalServerError)
 		} else {
@@ -192,27 +216,32 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 // searchStaticFile searches the file with given URI.
 // It returns a file struct specifying the file information.
 func (s *Server) searchStaticFile(uri string) *staticFile {
+	s.configMu.RLock()
 	var (
-		file *gres.File
-		path string
-		dir  bool
+		file        *gres.File
+		path        string
+		dir         bool
+		staticPaths = s.config.StaticPaths
+		searchPaths = s.config.SearchPaths
+		indexFiles  = s.config.IndexFiles
 	)
+	s.configMu.RUnlock()
 	// Firstly search the StaticPaths mapping.
-	if len(s.config.StaticPaths) > 0 {
-		for _, item := range s.config.StaticPaths {
+	if len(staticPaths) > 0 {
+		for _, item := range staticPaths {
 			if len(uri) >= len(item.prefix) && strings.EqualFold(item.prefix, uri[0:len(item.prefix)]) {
 				// To avoid case like: /static/style -> /static/style.css
 				if len(uri) > len(item.prefix) && uri[len(item.prefix)] != '/' {
 					continue
 				}
-				file = gres.GetWithIndex(item.path+uri[len(item.prefix):], s.config.IndexFiles)
+				file = gres.GetWithIndex(item.path+uri[len(item.prefix):], indexFiles)
 				if file != nil {
 					return &staticFile{
 						File:  file,
 						IsDir: file.FileInfo().IsDir(),
 					}
 				}
-				path, dir = gspath.Search(item.path, uri[len(item.prefix):], s.config.IndexFiles...)
+				path, dir = gspath.Search(item.path, uri[len(item.prefix):], indexFiles...)
 				if path != "" {
 					return &staticFile{
 						Path:  path,
@@ -223,16 +252,16 @@ func (s *Server) searchStaticFile(uri string) *staticFile {
 		}
 	}
 	// Secondly search the root and searching paths.
-	if len(s.config.SearchPaths) > 0 {
-		for _, p := range s.config.SearchPaths {
-			file = gres.GetWithIndex(p+uri, s.config.IndexFiles)
+	if len(searchPaths) > 0 {
+		for _, p := range searchPaths {
+			file = gres.GetWithIndex(p+uri, indexFiles)
 			if file != nil {
 				return &staticFile{
 					File:  file,
 					IsDir: file.FileInfo().IsDir(),
 				}
 			}
-			if path, dir = gspath.Search(p, uri, s.config.IndexFiles...); path != "" {
+			if path, dir = gspath.Search(p, uri, indexFiles...); path != "" {
 				return &staticFile{
 					Path:  path,
 					IsDir: dir,
@@ -241,8 +270,8 @@ func (s *Server) searchStaticFile(uri string) *staticFile {
 		}
 	}
 	// Lastly search the resource manager.
-	if len(s.config.StaticPaths) == 0 && len(s.config.SearchPaths) == 0 {
-		if file = gres.GetWithIndex(uri, s.config.IndexFiles); file != nil {
+	if len(staticPaths) == 0 && len(searchPaths) == 0 {
+		if file = gres.GetWithIndex(uri, indexFiles); file != nil {
 			return &staticFile{
 				File:  file,
 				IsDir: file.FileInfo().IsDir(),
@@ -255,10 +284,13 @@ func (s *Server) searchStaticFile(uri string) *staticFile {
 // serveFile serves the static file for the client.
 // The optional parameter `allowIndex` specifies if allowing directory listing if `f` is a directory.
 func (s *Server) serveFile(r *Request, f *staticFile, allowIndex ...bool) {
+	s.configMu.RLock()
+	indexFolder := s.config.IndexFolder
+	s.configMu.RUnlock()
 	// Use resource file from memory.
 	if f.File != nil {
 		if f.IsDir {
-			if s.config.IndexFolder || (len(allowIndex) > 0 && allowIndex[0]) {
+			if indexFolder || (len(allowIndex) > 0 && allowIndex[0]) {
 				s.listDir(r, f.File)
 			} else {
 				r.Response.WriteStatus(http.StatusForbidden)
@@ -284,12 +316,15 @@ func (s *Server) serveFile(r *Request, f *staticFile, allowIndex ...bool) {
 
 	info, _ := file.Stat()
 	if info.IsDir() {
-		if s.config.IndexFolder || (len(allowIndex) > 0 && allowIndex[0]) {
+		if indexFolder || (len(allowIndex) > 0 && allowIndex[0]) {
 			s.listDir(r, file)
 		} else {
 			r.Response.WriteStatus(http.StatusForbidden)
 		}
 	} else {
+		if s.config.StaticPrecompressed && s.servePrecompressedFile(r, f.Path) {
+			return
+		}
 		r.Response.wroteHeader = true
 		http.ServeContent(r.Response.Writer.RawWriter(), r.Request, info.Name(), info.ModTime(), file)
 	}
@@ -312,6 +347,10 @@ func (s *Server) listDir(r *Request, f http.File) {
 		}
 		return files[i].Name() < files[j].Name()
 	})
+	if s.config.IndexFolderTemplate != "" {
+		s.listDirWithTemplate(r, files)
+		return
+	}
 	if r.Response.Header().Get("Content-Type") == "" {
 		r.Response.Header().Set("Content-Type", "text/html; charset=utf-8")
 	}
@@ -322,12 +361,12 @@ func (s *Server) listDir(r *Request, f http.File) {
 	r.Response.Write(`</style>`)
 	r.Response.Write(`</head>`)
 	r.Response.Write(`<body>`)
-	r.Response.Writef(`<h1>Index of %s</h1>`, r.URL.Path)
+	r.Response.Writef(`<h1>Index of %s</h1>`, ghtml.SpecialChars(r.URL.Path))
 	r.Response.Writef(`<hr />`)
 	r.Response.Write(`<table>`)
 	if r.URL.Path != "/" {
 		r.Response.Write(`<tr>`)
-		r.Response.Writef(`<td><a href="%s">..</a></td>`, gfile.Dir(r.URL.Path))
+		r.Response.Writef(`<td><a href="%s">..</a></td>`, escapeListDirHref(gfile.Dir(r.URL.Path)))
 		r.Response.Write(`</tr>`)
 	}
 	name := ""
@@ -341,7 +380,7 @@ func (s *Server) listDir(r *Request, f http.File) {
 			size = "-"
 		}
 		r.Response.Write(`<tr>`)
-		r.Response.Writef(`<td><a href="%s/%s">%s</a></td>`, prefix, name, ghtml.SpecialChars(name))
+		r.Response.Writef(`<td><a href="%s">%s</a></td>`, escapeListDirHref(prefix+"/"+name), ghtml.SpecialChars(name))
 		r.Response.Writef(`<td style="width:300px;text-align:center;">%s</td>`, gtime.New(file.ModTime()).ISO8601())
 		r.Response.Writef(`<td style="width:80px;text-align:right;">%s</td>`, size)
 		r.Response.Write(`</tr>`)
@@ -0,0 +1,144 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gproc"
+)
+
+const (
+	// unixAddressPrefix is the prefix of the unix domain socket address, like "unix:/var/run/app.sock".
+	unixAddressPrefix = "unix:"
+
+	// ctxKeyForListenerAddress is the context key of the connection for its listening address.
+	ctxKeyForListenerAddress gctx.StrKey = "gHttpListenerAddress"
+)
+
+// BindListenerMiddleware registers middleware `handlers` for the requests accepted by the listener of
+// `address`, which is one of the addresses in Address or HTTPSAddr configuration, like ":8080",
+// ":8443" or "unix:/var/run/app.sock". The listener middleware are called before any other middleware
+// and handlers of the request, even if no route matches the request.
+//
+// All the listeners of the server share the same router, the middleware can be used to restrict or
+// decorate the requests of specified listener, like authentication for the public TCP listener.
+// See MiddlewareListenerOnly for serving routes on specified listeners only.
+func (s *Server) BindListenerMiddleware(address string, handlers ...HandlerFunc) {
+	if s.listenerHandlers == nil {
+		s.listenerHandlers = make(map[string][]HandlerFunc)
+	}
+	address = normalizeListenAddress(address)
+	s.listenerHandlers[address] = append(s.listenerHandlers[address], handlers...)
+}
+
+// MiddlewareListenerOnly returns a middleware handler that serves the request only if it is accepted
+// by the listener of one of `addresses`, or else it responds 404, which is usually bound to a group
+// to serve the routes on specified listeners only, like the admin APIs on the unix domain socket:
+//
+//	s.SetAddress(":8080,unix:/var/run/app.sock")
+//	s.Group("/admin", func(group *ghttp.RouterGroup) {
+//		group.Middleware(ghttp.MiddlewareListenerOnly("unix:/var/run/app.sock"))
+//		group.ALL("/reload", reload)
+//	})
+func MiddlewareListenerOnly(addresses ...string) HandlerFunc {
+	allowed := make(map[string]struct{}, len(addresses))
+	for _, address := range addresses {
+		allowed[normalizeListenAddress(address)] = struct{}{}
+	}
+	return func(r *Request) {
+		if _, ok := allowed[r.GetListenerAddress()]; !ok {
+			r.Response.WriteStatus(http.StatusNotFound)
+			return
+		}
+		r.Middleware.Next()
+	}
+}
+
+// GetListenerAddress returns the address of the listener accepting the request,
+// like ":8080" or "unix:/var/run/app.sock".
+func (r *Request) GetListenerAddress() string {
+	if v := r.Request.Context().Value(ctxKeyForListenerAddress); v != nil {
+		return v.(string)
+	}
+	return ""
+}
+
+// handleListenerMiddleware adds the middleware of the listener accepting the request
+// in front of the handlers of the request.
+func (s *Server) handleListenerMiddleware(r *Request) {
+	handlers := s.listenerHandlers[r.GetListenerAddress()]
+	if len(handlers) == 0 {
+		return
+	}
+	// The handlers of request are cached and shared, so it creates a new slice.
+	items := make([]*handlerParsedItem, 0, len(handlers)+len(r.handlers))
+	for _, handler := range handlers {
+		items = append(items, &handlerParsedItem{
+			Handler: &HandlerItem{
+				Name: "listener middleware",
+				Type: HandlerTypeMiddleware,
+				Info: handlerFuncInfo{
+					Func: handler,
+				},
+			},
+		})
+	}
+	r.handlers = append(items, r.handlers...)
+}
+
+// normalizeListenAddress changes port to address like: 80 -> :80,
+// the unix domain socket address is returned as it is.
+func normalizeListenAddress(address string) string {
+	address = strings.TrimSpace(address)
+	if address != "" && strings.Trim(address, "0123456789") == "" {
+		return ":" + address
+	}
+	return address
+}
+
+// parseListenAddress returns the network and address for net.Listen of listening `address`.
+func parseListenAddress(address string) (network, addr string) {
+	if strings.HasPrefix(address, unixAddressPrefix) {
+		return "unix", address[len(unixAddressPrefix):]
+	}
+	return "tcp", address
+}
+
+// listen announces on the local `address`, which might be a unix domain socket address.
+// The stale socket file left by the crashed process is removed before listening.
+func listen(address string) (net.Listener, error) {
+	network, addr := parseListenAddress(address)
+	if network == "unix" {
+		if conn, err := net.Dial(network, addr); err == nil {
+			_ = conn.Close()
+			return nil, gerror.Newf(`%d: unix socket "%s" is in use`, gproc.Pid(), addr)
+		}
+		if err := os.Remove(addr); err != nil && !os.IsNotExist(err) {
+			return nil, gerror.Wrapf(err, `%d: remove stale unix socket "%s" failed`, gproc.Pid(), addr)
+		}
+	}
+	ln, err := net.Listen(network, addr)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "%d: net.Listen failed", gproc.Pid())
+	}
+	return ln, nil
+}
+
+// withListenerAddress returns a function for http.Server.ConnContext,
+// which stores listening `address` in the connection context.
+func withListenerAddress(address string) func(ctx context.Context, c net.Conn) context.Context {
+	return func(ctx context.Context, c net.Conn) context.Context {
+		return context.WithValue(ctx, ctxKeyForListenerAddress, address)
+	}
+}
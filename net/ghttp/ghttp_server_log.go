@@ -18,16 +18,21 @@ func (s *Server) handleAccessLog(r *Request) {
 	if !s.IsAccessLogEnabled() {
 		return
 	}
+	s.configMu.RLock()
 	var (
-		scheme = "http"
-		proto  = r.Header.Get("X-Forwarded-Proto")
+		scheme  = "http"
+		proto   = r.Header.Get("X-Forwarded-Proto")
+		logger  = s.config.Logger
+		pattern = s.config.AccessLogPattern
+		stdout  = s.config.LogStdout
 	)
+	s.configMu.RUnlock()
 
 	if r.TLS != nil || gstr.Equal(proto, "https") {
 		scheme = "https"
 	}
-	s.Logger().File(s.config.AccessLogPattern).
-		Stdout(s.config.LogStdout).
+	logger.File(pattern).
+		Stdout(stdout).
 		Printf(
 			r.Context(),
 			`%d "%s %s %s %s %s" %.3f, %s, "%s", "%s"`,
@@ -63,7 +68,15 @@ func (s *Server) handleErrorLog(err error, r *Request) {
 		r.GetClientIp(), r.Referer(), r.UserAgent(),
 		code.Code(), code.Message(), codeDetailStr,
 	)
-	if s.config.ErrorStack {
+	s.configMu.RLock()
+	var (
+		logger     = s.config.Logger
+		pattern    = s.config.ErrorLogPattern
+		stdout     = s.config.LogStdout
+		errorStack = s.config.ErrorStack
+	)
+	s.configMu.RUnlock()
+	if errorStack {
 		if stack := gerror.Stack(err); stack != "" {
 			content += "\nStack:\n" + stack
 		} else {
@@ -72,7 +85,7 @@ func (s *Server) handleErrorLog(err error, r *Request) {
 	} else {
 		content += ", " + err.Error()
 	}
-	s.Logger().File(s.config.ErrorLogPattern).
-		Stdout(s.config.LogStdout).
+	logger.File(pattern).
+		Stdout(stdout).
 		Print(r.Context(), content)
 }
@@ -0,0 +1,141 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"html"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+)
+
+// MaintenanceOption is the option for maintenance mode, see Server.SetMaintenance.
+type MaintenanceOption struct {
+	// Message is the message responded to the clients, default is "Service is under maintenance".
+	Message string `json:"message"`
+
+	// Content is the custom HTML page content responded to the browsers.
+	// A simple page showing Message is responded if it is empty.
+	Content string `json:"content"`
+
+	// RetryAfter is responded in "Retry-After" header, which tells clients when to retry.
+	RetryAfter time.Duration `json:"retryAfter"`
+
+	// AllowPaths are the paths still served in maintenance mode, like "/health".
+	// The path ending with "*" matches its prefix, like "/admin/*".
+	AllowPaths []string `json:"allowPaths"`
+
+	// AllowIps are the client IPs or CIDRs still served in maintenance mode, like "10.0.0.0/8".
+	AllowIps []string `json:"allowIps"`
+}
+
+// maintenanceManager manages the maintenance mode of server.
+type maintenanceManager struct {
+	mu        sync.RWMutex
+	enabled   bool
+	option    MaintenanceOption
+	allowNets []*net.IPNet // Parsed AllowIps.
+	adminPath string       // Path of the administration page, which is always served, see EnableAdmin.
+}
+
+const (
+	defaultMaintenanceMessage = "Service is under maintenance"
+	maintenancePageTemplate   = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>503 Service Unavailable</title></head>
+<body><h1>503 Service Unavailable</h1><p>%s</p></body>
+</html>`
+)
+
+// SetMaintenance enables or disables the maintenance mode of the server at runtime.
+// The requests are responded with status 503 and a page or JSON according to the "Accept" header
+// in maintenance mode, except the ones of the allowed paths and client IPs in `option`.
+// The current option is kept if `option` is not given.
+//
+// It is also configurable with configuration "maintenance" and "maintenanceOption",
+// which are applied by Server.ReloadConfig when configuration file changes, and
+// with the "maintenance" page of administration, see EnableAdmin.
+func (s *Server) SetMaintenance(enabled bool, option ...MaintenanceOption) error {
+	m := s.maintenance
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(option) > 0 {
+		allowNets, err := parseTrustedProxies(option[0].AllowIps)
+		if err != nil {
+			return err
+		}
+		m.option = option[0]
+		m.allowNets = allowNets
+	}
+	m.enabled = enabled
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+	if len(option) > 0 {
+		s.config.MaintenanceOption = option[0]
+	}
+	s.config.Maintenance = enabled
+	return nil
+}
+
+// IsMaintenance checks and returns whether the server is in maintenance mode.
+func (s *Server) IsMaintenance() bool {
+	s.maintenance.mu.RLock()
+	defer s.maintenance.mu.RUnlock()
+	return s.maintenance.enabled
+}
+
+// handleMaintenance responds the request with status 503 and exits it
+// if the server is in maintenance mode and the request is not allowed.
+func (s *Server) handleMaintenance(r *Request) {
+	m := s.maintenance
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.enabled || m.isAllowed(r) {
+		return
+	}
+	message := m.option.Message
+	if message == "" {
+		message = defaultMaintenanceMessage
+	}
+	if m.option.RetryAfter > 0 {
+		r.Response.Header().Set("Retry-After", strconv.FormatInt(int64(m.option.RetryAfter/time.Second), 10))
+	}
+	r.Response.WriteHeader(http.StatusServiceUnavailable)
+	if strings.Contains(r.Header.Get("Accept"), contentTypeJson) {
+		r.Response.WriteJson(DefaultHandlerResponse{
+			Code:      gcode.CodeServerBusy.Code(),
+			Message:   message,
+			RequestId: r.GetRequestId(),
+		})
+	} else {
+		r.Response.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if m.option.Content != "" {
+			r.Response.Write(m.option.Content)
+		} else {
+			r.Response.Writef(maintenancePageTemplate, html.EscapeString(message))
+		}
+	}
+	// It is not in handler, so it marks the request exited without panic like ExitAll.
+	r.exitAll = true
+}
+
+// isAllowed checks and returns whether the request is still served in maintenance mode.
+func (m *maintenanceManager) isAllowed(r *Request) bool {
+	path := r.URL.Path
+	if m.adminPath != "" && (path == m.adminPath || strings.HasPrefix(path, m.adminPath+"/")) {
+		return true
+	}
+	if isPathMatched(path, m.option.AllowPaths) {
+		return true
+	}
+	return len(m.allowNets) > 0 && isTrustedProxy(m.allowNets, r.GetClientIp())
+}
@@ -11,6 +11,7 @@ import (
 	runpprof "runtime/pprof"
 	"strings"
 
+	"github.com/gogf/gf/v2/debug/gdebug"
 	"github.com/gogf/gf/v2/internal/intlog"
 	"github.com/gogf/gf/v2/os/gview"
 )
@@ -54,6 +55,24 @@ func (d *Domain) EnablePProf(pattern ...string) {
 	})
 }
 
+// EnableProfiling enables the guarded profiling endpoints of gdebug.ProfilingHandler under `path` for server,
+// which are guarded by `auth`. All the requests are denied if `auth` is nil.
+func (s *Server) EnableProfiling(path string, auth gdebug.ProfilingAuthFunc) {
+	s.Domain(DefaultDomainName).EnableProfiling(path, auth)
+}
+
+// EnableProfiling enables the guarded profiling endpoints of gdebug.ProfilingHandler under `path` for server
+// of specified domain, which are guarded by `auth`. All the requests are denied if `auth` is nil.
+func (d *Domain) EnableProfiling(path string, auth gdebug.ProfilingAuthFunc) {
+	if path == "" {
+		path = gdebug.DefaultProfilingPath
+	}
+	path = "/" + strings.Trim(path, "/")
+	handler := WrapH(gdebug.ProfilingHandler(path, auth))
+	d.BindHandler(path, handler)
+	d.BindHandler(path+"/*any", handler)
+}
+
 // Index shows the PProf index page.
 func (p *utilPProf) Index(r *Request) {
 	var (
@@ -0,0 +1,72 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+const (
+	// maxRequestIdLength is the max length of request id from client, the longer one is replaced.
+	maxRequestIdLength = 128
+)
+
+// SetRequestIdEnabled enables/disables the request id feature for server.
+func (s *Server) SetRequestIdEnabled(enabled bool) {
+	s.config.RequestIdEnabled = enabled
+}
+
+// SetRequestIdHeader sets the header name of request id for server.
+func (s *Server) SetRequestIdHeader(header string) {
+	s.config.RequestIdHeader = header
+}
+
+// GetRequestIdHeader returns the header name of request id of server.
+func (s *Server) GetRequestIdHeader() string {
+	if s.config.RequestIdHeader == "" {
+		return gctx.RequestIdHeader
+	}
+	return s.config.RequestIdHeader
+}
+
+// GetRequestId returns the request id of current request, which is only available if
+// the request id feature is enabled or the request id is attached to context manually.
+func (r *Request) GetRequestId() string {
+	return gctx.RequestId(r.Context())
+}
+
+// handleRequestId honors the request id in request header or generates a new one,
+// and attaches it to the context and response header.
+func (s *Server) handleRequestId(r *Request) {
+	if !s.config.RequestIdEnabled {
+		return
+	}
+	var (
+		header = s.GetRequestIdHeader()
+		id     = r.Header.Get(header)
+	)
+	if !isValidRequestId(id) {
+		id = guid.S()
+	}
+	r.SetCtx(gctx.WithRequestId(r.Context(), id))
+	r.Response.Header().Set(header, id)
+}
+
+// isValidRequestId checks whether `id` from client is valid, which should be printable ASCII
+// characters without space and not too long, avoiding log injection.
+func isValidRequestId(id string) bool {
+	if id == "" || len(id) > maxRequestIdLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
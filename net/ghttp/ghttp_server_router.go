@@ -109,6 +109,9 @@ func (s *Server) setHandler(ctx context.Context, in setHandlerInput) {
 		if v := gmeta.Get(objectReq, goai.TagNameDomain); !v.IsEmpty() {
 			domain = v.String()
 		}
+		if v := gmeta.Get(objectReq, responseEnvelopeTagName); !v.IsEmpty() {
+			handler.Info.Envelope = v.String()
+		}
 	}
 
 	// Prefix for URI feature.
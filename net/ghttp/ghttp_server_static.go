@@ -0,0 +1,155 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/ghtml"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gview"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// ListDirItem is the item of sub-file for the template of folder listing.
+// Its Name and Href are escaped already, so they can be output in template directly.
+type ListDirItem struct {
+	Name       string    // HTML escaped file name, which has suffix "/" for directory.
+	Href       string    // URL and HTML escaped link of the file.
+	Size       int64     // File size in bytes.
+	SizeFormat string    // Formatted file size like "1.00K", it is "-" for directory.
+	ModTime    time.Time // Modification time.
+	IsDir      bool      // Whether it is directory.
+}
+
+// precompressedEncodings are the supported encodings of pre-compressed files in priority order.
+var precompressedEncodings = []struct {
+	Encoding  string
+	Extension string
+}{
+	{Encoding: "br", Extension: ".br"},
+	{Encoding: "gzip", Extension: ".gz"},
+}
+
+// listDirWithTemplate lists `files` of current directory with the gview template of IndexFolderTemplate.
+// The file names and paths are escaped for the template, as they might contain html special chars.
+func (s *Server) listDirWithTemplate(r *Request, files []os.FileInfo) {
+	var (
+		prefix = gstr.TrimRight(r.URL.Path, "/")
+		parent string
+		items  = make([]ListDirItem, 0, len(files))
+	)
+	if r.URL.Path != "/" {
+		parent = escapeListDirHref(gfile.Dir(r.URL.Path))
+	}
+	for _, file := range files {
+		var (
+			name = file.Name()
+			item = ListDirItem{
+				Size:       file.Size(),
+				SizeFormat: gfile.FormatSize(file.Size()),
+				ModTime:    file.ModTime(),
+				IsDir:      file.IsDir(),
+			}
+		)
+		if item.IsDir {
+			name += "/"
+			item.SizeFormat = "-"
+		}
+		item.Name = ghtml.SpecialChars(name)
+		item.Href = escapeListDirHref(prefix + "/" + name)
+		items = append(items, item)
+	}
+	if r.Response.Header().Get("Content-Type") == "" {
+		r.Response.Header().Set("Content-Type", "text/html; charset=utf-8")
+	}
+	err := r.Response.WriteTpl(s.config.IndexFolderTemplate, gview.Params{
+		"Path":   ghtml.SpecialChars(r.URL.Path),
+		"Parent": parent,
+		"Files":  items,
+	})
+	if err != nil {
+		r.SetError(err)
+		r.Response.ClearBuffer()
+		r.Response.WriteStatus(http.StatusInternalServerError, "Error rendering directory")
+	}
+}
+
+// escapeListDirHref escapes `path` for the link of folder listing, which is URL escaped for the path
+// and then HTML escaped for the attribute.
+func escapeListDirHref(path string) string {
+	return ghtml.SpecialChars((&url.URL{Path: path}).EscapedPath())
+}
+
+// servePrecompressedFile serves the pre-compressed sibling file of `path` if the client accepts its encoding.
+// It returns false if there's no acceptable pre-compressed file, in which case nothing is written.
+func (s *Server) servePrecompressedFile(r *Request, path string) bool {
+	acceptEncoding := r.Header.Get("Accept-Encoding")
+	if acceptEncoding == "" || r.Header.Get("Content-Encoding") != "" {
+		return false
+	}
+	for _, item := range precompressedEncodings {
+		if !isEncodingAccepted(acceptEncoding, item.Encoding) {
+			continue
+		}
+		file, err := os.Open(path + item.Extension)
+		if err != nil {
+			continue
+		}
+		info, err := file.Stat()
+		if err != nil || info.IsDir() {
+			file.Close()
+			continue
+		}
+		header := r.Response.Header()
+		if header.Get("Content-Type") == "" {
+			contentType := mime.TypeByExtension(filepath.Ext(path))
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+			header.Set("Content-Type", contentType)
+		}
+		header.Set("Content-Encoding", item.Encoding)
+		header.Add("Vary", "Accept-Encoding")
+		r.Response.wroteHeader = true
+		http.ServeContent(r.Response.Writer.RawWriter(), r.Request, gfile.Basename(path), info.ModTime(), file)
+		file.Close()
+		return true
+	}
+	return false
+}
+
+// isEncodingAccepted checks whether `encoding` is accepted by the Accept-Encoding header value `accept`,
+// in which the encoding with "q=0" is considered not accepted.
+func isEncodingAccepted(accept, encoding string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		var (
+			params = strings.Split(part, ";")
+			name   = strings.TrimSpace(params[0])
+		)
+		if !strings.EqualFold(name, encoding) && name != "*" {
+			continue
+		}
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil && q <= 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
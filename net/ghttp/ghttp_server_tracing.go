@@ -0,0 +1,77 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gogf/gf/v2/net/gtrace"
+)
+
+// TracingSamplingOption is the option overriding the trace sampling of routes, see Server.SetTracingSampling.
+//
+// Note that the path overriding takes effect only for the sampler of gtrace, see gtrace.GetSampler.
+type TracingSamplingOption struct {
+	// AlwaysPaths are the paths always sampled regardless of the configured sampler, like "/admin/*".
+	// The path ending with "*" matches its prefix.
+	AlwaysPaths []string `json:"alwaysPaths"`
+
+	// NeverPaths are the paths never sampled, like "/health".
+	// The path ending with "*" matches its prefix.
+	NeverPaths []string `json:"neverPaths"`
+
+	// AlwaysError records a sampled span for the request that is not sampled but fails
+	// with error or status >= 500, which has the same trace id and start time as the request.
+	AlwaysError bool `json:"alwaysError"`
+}
+
+// SetTracingSampling sets the option overriding the trace sampling of routes.
+// It should be called before the server starts.
+func (s *Server) SetTracingSampling(option TracingSamplingOption) {
+	s.config.TracingSampling = option
+}
+
+// withTracingSampling returns a new context with the sampling decision of the request path
+// if it is configured in TracingSamplingOption.
+func (s *Server) withTracingSampling(ctx context.Context, r *Request) context.Context {
+	var (
+		path   = r.URL.Path
+		option = s.config.TracingSampling
+	)
+	switch {
+	case isPathMatched(path, option.AlwaysPaths):
+		return gtrace.WithSampling(ctx, gtrace.SamplingAlways)
+	case isPathMatched(path, option.NeverPaths):
+		return gtrace.WithSampling(ctx, gtrace.SamplingNever)
+	}
+	return ctx
+}
+
+// isTracingErrorSampled checks whether a sampled span should be recorded for the failed request.
+func (s *Server) isTracingErrorSampled(r *Request) bool {
+	if !s.config.TracingSampling.AlwaysError {
+		return false
+	}
+	return r.GetError() != nil || r.Response.Status >= http.StatusInternalServerError
+}
+
+// isPathMatched checks whether `path` matches any of `patterns`.
+// The pattern ending with "*" matches its prefix, like "/admin/*".
+func isPathMatched(path string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "*") {
+			if strings.HasPrefix(path, pattern[:len(pattern)-1]) {
+				return true
+			}
+		} else if path == pattern {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,308 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/text/gregex"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// TusOption is the option for TusHandler.
+type TusOption struct {
+	// BasePath is the URI the handler bound to, like "/files", which is used for the upload URL.
+	// The handler should be bound to both BasePath and sub paths, eg: "/files" and "/files/*".
+	BasePath string
+
+	// Storage is the storage backend of uploads, it is required.
+	Storage TusStorage
+
+	// MaxSize is the max size of an upload in bytes, it is not limited if it is 0.
+	MaxSize int64
+
+	// Expiration is the duration an upload expires after its creation, default is 24 hours.
+	// The expired uploads are removed when accessed.
+	Expiration time.Duration
+
+	// OnComplete is called when an upload completes, in which the uploaded content can be processed.
+	OnComplete func(r *Request, upload *TusUpload)
+}
+
+const (
+	tusVersion           = "1.0.0"
+	tusExtensions        = "creation,expiration,termination"
+	tusContentType       = "application/offset+octet-stream"
+	tusUploadIdPattern   = `^[\w\-]{1,128}$`
+	defaultTusExpiration = 24 * time.Hour
+)
+
+// TusHandler returns a handler implementing the core protocol of tus resumable upload 1.0.0 along with
+// extensions creation, expiration and termination, so the clients can resume large uploads
+// over flaky networks. See https://tus.io/protocols/resumable-upload.
+//
+// Example:
+// storage, _ := ghttp.NewTusStorageFile("/tmp/uploads")
+// handler := ghttp.TusHandler(ghttp.TusOption{BasePath: "/files", Storage: storage})
+// s.BindHandler("/files", handler)
+// s.BindHandler("/files/*", handler)
+//
+// It panics if option.Storage is nil.
+func TusHandler(option TusOption) HandlerFunc {
+	if option.Storage == nil {
+		panic(`tus Storage cannot be nil`)
+	}
+	if option.Expiration <= 0 {
+		option.Expiration = defaultTusExpiration
+	}
+	option.BasePath = strings.TrimRight(option.BasePath, "/")
+	h := &tusHandler{
+		option:    option,
+		uploading: make(map[string]struct{}),
+	}
+	return h.serve
+}
+
+// tusHandler is the handler of tus protocol.
+type tusHandler struct {
+	option    TusOption
+	mu        sync.Mutex          // Mutex for `uploading`.
+	uploading map[string]struct{} // Ids of uploads in processing.
+}
+
+// serve handles the request.
+func (h *tusHandler) serve(r *Request) {
+	header := r.Response.Header()
+	header.Set("Tus-Resumable", tusVersion)
+	if r.Method == http.MethodOptions {
+		header.Set("Tus-Version", tusVersion)
+		header.Set("Tus-Extension", tusExtensions)
+		if h.option.MaxSize > 0 {
+			header.Set("Tus-Max-Size", strconv.FormatInt(h.option.MaxSize, 10))
+		}
+		r.Response.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Header.Get("Tus-Resumable") != tusVersion {
+		header.Set("Tus-Version", tusVersion)
+		r.Response.WriteStatus(http.StatusPreconditionFailed)
+		return
+	}
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, h.option.BasePath), "/")
+	if id == "" {
+		if r.Method == http.MethodPost {
+			h.create(r)
+		} else {
+			r.Response.WriteStatus(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+	if !gregex.IsMatchString(tusUploadIdPattern, id) {
+		r.Response.WriteStatus(http.StatusNotFound)
+		return
+	}
+	// The requests of the same upload are serialized.
+	if !h.lock(id) {
+		r.Response.WriteStatus(http.StatusLocked)
+		return
+	}
+	defer h.unlock(id)
+
+	upload, err := h.option.Storage.Get(r.Context(), id)
+	if err != nil {
+		h.writeError(r, err)
+		return
+	}
+	if upload != nil && !upload.ExpiresAt.IsZero() && upload.ExpiresAt.Before(time.Now()) {
+		if err = h.option.Storage.Remove(r.Context(), id); err != nil {
+			h.writeError(r, err)
+			return
+		}
+		upload = nil
+	}
+	if upload == nil {
+		r.Response.WriteStatus(http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodHead:
+		h.head(r, upload)
+	case http.MethodPatch:
+		h.patch(r, upload)
+	case http.MethodDelete:
+		if err = h.option.Storage.Remove(r.Context(), id); err != nil {
+			h.writeError(r, err)
+			return
+		}
+		r.Response.WriteHeader(http.StatusNoContent)
+	default:
+		r.Response.WriteStatus(http.StatusMethodNotAllowed)
+	}
+}
+
+// lock marks upload `id` in processing, it returns false if it is already in processing.
+func (h *tusHandler) lock(id string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.uploading[id]; ok {
+		return false
+	}
+	h.uploading[id] = struct{}{}
+	return true
+}
+
+// unlock unmarks upload `id` in processing.
+func (h *tusHandler) unlock(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.uploading, id)
+}
+
+// create handles the creation request.
+func (h *tusHandler) create(r *Request) {
+	length, err := strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+	if err != nil || length < 0 {
+		r.Response.WriteStatus(http.StatusBadRequest, "invalid Upload-Length")
+		return
+	}
+	if h.option.MaxSize > 0 && length > h.option.MaxSize {
+		r.Response.WriteStatus(http.StatusRequestEntityTooLarge)
+		return
+	}
+	metadata, ok := parseTusMetadata(r.Header.Get("Upload-Metadata"))
+	if !ok {
+		r.Response.WriteStatus(http.StatusBadRequest, "invalid Upload-Metadata")
+		return
+	}
+	upload := &TusUpload{
+		Id:        guid.S(),
+		Length:    length,
+		Metadata:  metadata,
+		ExpiresAt: time.Now().Add(h.option.Expiration),
+	}
+	if err = h.option.Storage.Create(r.Context(), upload); err != nil {
+		h.writeError(r, err)
+		return
+	}
+	header := r.Response.Header()
+	header.Set("Location", h.option.BasePath+"/"+upload.Id)
+	header.Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	r.Response.WriteHeader(http.StatusCreated)
+	if length == 0 {
+		h.complete(r, upload)
+	}
+}
+
+// head handles the offset retrieving request.
+func (h *tusHandler) head(r *Request, upload *TusUpload) {
+	header := r.Response.Header()
+	header.Set("Cache-Control", "no-store")
+	header.Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	header.Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	if len(upload.Metadata) > 0 {
+		header.Set("Upload-Metadata", formatTusMetadata(upload.Metadata))
+	}
+	if !upload.ExpiresAt.IsZero() {
+		header.Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	}
+	r.Response.WriteHeader(http.StatusOK)
+}
+
+// patch handles the content appending request.
+func (h *tusHandler) patch(r *Request, upload *TusUpload) {
+	if r.Header.Get("Content-Type") != tusContentType {
+		r.Response.WriteStatus(http.StatusUnsupportedMediaType)
+		return
+	}
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		r.Response.WriteStatus(http.StatusBadRequest, "invalid Upload-Offset")
+		return
+	}
+	if offset != upload.Offset {
+		r.Response.WriteStatus(http.StatusConflict)
+		return
+	}
+	remaining := upload.Length - upload.Offset
+	if r.ContentLength > remaining {
+		r.Response.WriteStatus(http.StatusRequestEntityTooLarge)
+		return
+	}
+	// The content exceeding the upload length is ignored.
+	n, err := h.option.Storage.Append(r.Context(), upload.Id, io.LimitReader(r.Body, remaining))
+	upload.Offset += n
+	if err != nil {
+		h.writeError(r, err)
+		return
+	}
+	header := r.Response.Header()
+	header.Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	if !upload.ExpiresAt.IsZero() {
+		header.Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	}
+	r.Response.WriteHeader(http.StatusNoContent)
+	if upload.Offset == upload.Length {
+		h.complete(r, upload)
+	}
+}
+
+// complete calls the OnComplete callback of completed `upload`.
+func (h *tusHandler) complete(r *Request, upload *TusUpload) {
+	if h.option.OnComplete != nil {
+		h.option.OnComplete(r, upload)
+	}
+}
+
+// writeError logs `err` and responses with 500.
+func (h *tusHandler) writeError(r *Request, err error) {
+	r.Server.handleErrorLog(err, r)
+	r.Response.WriteStatus(http.StatusInternalServerError)
+}
+
+// parseTusMetadata parses the "Upload-Metadata" header value `value`, which is comma separated
+// key value pairs, of which the value is base64 encoded, like: "filename d29ybGRfZG9taW5hdGlvbl9wbGFuLnBkZg==,is_confidential".
+func parseTusMetadata(value string) (map[string]string, bool) {
+	metadata := make(map[string]string)
+	if value == "" {
+		return metadata, true
+	}
+	for _, pair := range strings.Split(value, ",") {
+		array := strings.Fields(pair)
+		switch len(array) {
+		case 1:
+			metadata[array[0]] = ""
+		case 2:
+			b, err := base64.StdEncoding.DecodeString(array[1])
+			if err != nil {
+				return nil, false
+			}
+			metadata[array[0]] = string(b)
+		default:
+			return nil, false
+		}
+	}
+	return metadata, true
+}
+
+// formatTusMetadata formats `metadata` to "Upload-Metadata" header value.
+func formatTusMetadata(metadata map[string]string) string {
+	pairs := make([]string, 0, len(metadata))
+	for k, v := range metadata {
+		if v == "" {
+			pairs = append(pairs, k)
+		} else {
+			pairs = append(pairs, k+" "+base64.StdEncoding.EncodeToString([]byte(v)))
+		}
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,144 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+// TusUpload is the information of a resumable upload.
+type TusUpload struct {
+	Id        string            `json:"id"`        // Upload id.
+	Length    int64             `json:"length"`    // Total length of the upload in bytes.
+	Offset    int64             `json:"offset"`    // Count of bytes received.
+	Metadata  map[string]string `json:"metadata"`  // Metadata from "Upload-Metadata" header, like file name and type.
+	ExpiresAt time.Time         `json:"expiresAt"` // Expiration time of the upload, which is zero if it never expires.
+}
+
+// TusStorage is the storage backend of resumable uploads.
+// The upload id passed to storage is always valid and the calls of the same upload are serialized by TusHandler.
+type TusStorage interface {
+	// Create creates an upload with `upload`, of which the offset is 0.
+	Create(ctx context.Context, upload *TusUpload) error
+
+	// Get returns the upload of `id`, it returns nil if not found.
+	Get(ctx context.Context, id string) (*TusUpload, error)
+
+	// Append appends content from `reader` to the upload of `id`, and updates its offset.
+	// It returns the count of bytes appended, which should be persisted even if error occurs
+	// in reading, so that the client can resume from there.
+	Append(ctx context.Context, id string, reader io.Reader) (n int64, err error)
+
+	// Remove removes the upload of `id` and its content.
+	Remove(ctx context.Context, id string) error
+}
+
+// TusStorageFile is the TusStorage storing uploads in local files.
+// The content of an upload is stored in file "{id}", and its information is stored in file "{id}.info".
+type TusStorageFile struct {
+	path string // Storage directory path.
+}
+
+const (
+	tusStorageFileInfoExt = ".info"
+)
+
+// NewTusStorageFile creates and returns a TusStorage storing uploads in directory `path`,
+// which is created if it does not exist.
+func NewTusStorageFile(path string) (*TusStorageFile, error) {
+	if !gfile.Exists(path) {
+		if err := gfile.Mkdir(path); err != nil {
+			return nil, err
+		}
+	}
+	return &TusStorageFile{
+		path: path,
+	}, nil
+}
+
+// Path returns the storage directory path.
+func (s *TusStorageFile) Path() string {
+	return s.path
+}
+
+// FilePath returns the content file path of upload `id`, which is usually used to move the file
+// when the upload completes.
+func (s *TusStorageFile) FilePath(id string) string {
+	return gfile.Join(s.path, id)
+}
+
+// Create implements TusStorage.
+func (s *TusStorageFile) Create(ctx context.Context, upload *TusUpload) error {
+	if err := gfile.PutBytes(s.FilePath(upload.Id), nil); err != nil {
+		return err
+	}
+	return s.setInfo(upload)
+}
+
+// Get implements TusStorage.
+func (s *TusStorageFile) Get(ctx context.Context, id string) (*TusUpload, error) {
+	infoPath := s.FilePath(id) + tusStorageFileInfoExt
+	if !gfile.Exists(infoPath) {
+		return nil, nil
+	}
+	var upload *TusUpload
+	if err := json.Unmarshal(gfile.GetBytes(infoPath), &upload); err != nil {
+		return nil, gerror.Wrapf(err, `invalid upload info file "%s"`, infoPath)
+	}
+	return upload, nil
+}
+
+// Append implements TusStorage.
+func (s *TusStorageFile) Append(ctx context.Context, id string, reader io.Reader) (n int64, err error) {
+	upload, err := s.Get(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if upload == nil {
+		return 0, gerror.NewCodef(gcode.CodeNotFound, `upload "%s" not found`, id)
+	}
+	file, err := gfile.OpenWithFlagPerm(s.FilePath(id), os.O_WRONLY|os.O_APPEND, gfile.DefaultPermOpen)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	n, err = io.Copy(file, reader)
+	// The received content is persisted even if the reading fails.
+	upload.Offset += n
+	if infoErr := s.setInfo(upload); infoErr != nil {
+		return n, infoErr
+	}
+	if err != nil {
+		return n, gerror.Wrapf(err, `append upload "%s" failed`, id)
+	}
+	return n, nil
+}
+
+// Remove implements TusStorage.
+func (s *TusStorageFile) Remove(ctx context.Context, id string) error {
+	if err := gfile.Remove(s.FilePath(id)); err != nil {
+		return err
+	}
+	return gfile.Remove(s.FilePath(id) + tusStorageFileInfoExt)
+}
+
+// setInfo stores the information of `upload`.
+func (s *TusStorageFile) setInfo(upload *TusUpload) error {
+	b, err := json.Marshal(upload)
+	if err != nil {
+		return gerror.Wrap(err, `marshal upload info failed`)
+	}
+	return gfile.PutBytes(s.FilePath(upload.Id)+tusStorageFileInfoExt, b)
+}
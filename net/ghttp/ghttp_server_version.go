@@ -0,0 +1,32 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"github.com/gogf/gf/v2/os/gbuild"
+)
+
+const (
+	defaultVersionPattern = "/version"
+)
+
+// EnableVersion enables the version handler for server, which responds the build metadata of
+// the binary as JSON, see gbuild.GetMetadata.
+// The optional parameter `pattern` specifies the route pattern, which is "/version" in default.
+//
+// Note that the custom build data is not responded as it might contain sensitive information.
+func (s *Server) EnableVersion(pattern ...string) {
+	p := defaultVersionPattern
+	if len(pattern) > 0 && pattern[0] != "" {
+		p = pattern[0]
+	}
+	s.BindHandler(p, func(r *Request) {
+		metadata := gbuild.GetMetadata()
+		metadata.Data = nil
+		r.Response.WriteJson(metadata)
+	})
+}
@@ -0,0 +1,456 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// WsHub manages the websocket connections with named rooms and users, supporting broadcasting
+// messages to all connections, to a room or to a user. The broadcasts are also fanned out to the
+// hubs of other instances through WsHubBroker if configured, like NewWsHubRedisBroker.
+type WsHub struct {
+	mu      sync.RWMutex
+	id      string                        // Unique id of the hub, for ignoring the messages published by itself.
+	options WsHubOptions                  // Options of the hub.
+	conns   map[string]*WsConn            // All the connections, the key is the connection id.
+	rooms   map[string]map[string]*WsConn // Connections of rooms, the key is the room name.
+	users   map[string]map[string]*WsConn // Connections of users, the key is the user id.
+	closed  *gtype.Bool                   // Whether the hub is closed.
+}
+
+// WsHubOptions is the options for WsHub.
+type WsHubOptions struct {
+	// Broker fans out the broadcasts to the hubs of other instances, the broadcasts are local only if it is nil.
+	Broker WsHubBroker
+
+	// SendBufferSize is the size of sending buffer of each connection, default is 256.
+	// The connection is closed if its buffer is full, which is usually a slow client.
+	SendBufferSize int
+
+	// WriteTimeout is the timeout writing a message to connection, default is 10 seconds.
+	WriteTimeout time.Duration
+
+	// PingInterval is the interval sending ping message to connection for keeping alive, default is 30 seconds.
+	// The connection is closed if no message or pong received in twice of the interval.
+	PingInterval time.Duration
+
+	// OnMessage is called when a message is received from connection.
+	OnMessage func(conn *WsConn, msgType int, data []byte)
+
+	// OnClose is called after a connection is closed and removed from the hub.
+	OnClose func(conn *WsConn)
+}
+
+// WsConn is a websocket connection managed by WsHub.
+type WsConn struct {
+	*WebSocket
+	Id     string              // Unique id of the connection.
+	UserId string              // User id of the connection, which can be empty for anonymous user.
+	hub    *WsHub              // Hub the connection belongs to.
+	ctx    context.Context     // Context of the request upgrading the connection.
+	rooms  map[string]struct{} // Rooms the connection joined, which is protected by hub.mu.
+	send   chan wsHubFrame     // Sending buffer of the connection.
+	done   chan struct{}       // Closed when the connection is closed.
+	once   sync.Once           // For closing the connection only once.
+}
+
+// WsHubBroker is the interface fanning out the broadcasts among the hubs of multiple instances.
+type WsHubBroker interface {
+	// Publish publishes `message` to the hubs of all instances.
+	Publish(ctx context.Context, message *WsHubMessage) error
+
+	// Subscribe receives the published messages and calls `handler` until the broker is closed.
+	// It should not block the caller.
+	Subscribe(ctx context.Context, handler func(message *WsHubMessage)) error
+
+	// Close stops subscribing and releases the resources.
+	Close(ctx context.Context) error
+}
+
+// WsHubMessage is the broadcast message published by WsHubBroker.
+type WsHubMessage struct {
+	Origin string `json:"origin"`           // Id of the hub publishing the message.
+	Room   string `json:"room,omitempty"`   // Target room, the message is sent to all connections if both Room and UserId are empty.
+	UserId string `json:"userId,omitempty"` // Target user.
+	Type   int    `json:"type"`             // Websocket message type, like WsMsgText.
+	Data   []byte `json:"data"`             // Message content.
+}
+
+// wsHubFrame is a message waiting for sending in connection buffer.
+type wsHubFrame struct {
+	msgType int
+	data    []byte
+}
+
+const (
+	defaultWsHubSendBufferSize = 256
+	defaultWsHubWriteTimeout   = 10 * time.Second
+	defaultWsHubPingInterval   = 30 * time.Second
+)
+
+// NewWsHub creates and returns a new websocket hub.
+// It starts subscribing from the broker if `options.Broker` is configured.
+func NewWsHub(options ...WsHubOptions) (*WsHub, error) {
+	hub := &WsHub{
+		id:     guid.S(),
+		conns:  make(map[string]*WsConn),
+		rooms:  make(map[string]map[string]*WsConn),
+		users:  make(map[string]map[string]*WsConn),
+		closed: gtype.NewBool(),
+	}
+	if len(options) > 0 {
+		hub.options = options[0]
+	}
+	if hub.options.SendBufferSize <= 0 {
+		hub.options.SendBufferSize = defaultWsHubSendBufferSize
+	}
+	if hub.options.WriteTimeout <= 0 {
+		hub.options.WriteTimeout = defaultWsHubWriteTimeout
+	}
+	if hub.options.PingInterval <= 0 {
+		hub.options.PingInterval = defaultWsHubPingInterval
+	}
+	if hub.options.Broker != nil {
+		if err := hub.options.Broker.Subscribe(context.Background(), hub.handleBrokerMessage); err != nil {
+			return nil, err
+		}
+	}
+	return hub, nil
+}
+
+// Serve upgrades the request `r` as websocket connection, registers it to the hub with `userId`,
+// and reads messages from the connection until it is closed. The `userId` can be empty for
+// anonymous user. It blocks until the connection is closed, so it is usually the last call in handler.
+func (h *WsHub) Serve(r *Request, userId string) error {
+	ws, err := r.WebSocket()
+	if err != nil {
+		return err
+	}
+	conn, err := h.Register(r.Context(), ws, userId)
+	if err != nil {
+		_ = ws.Close()
+		return err
+	}
+	conn.readLoop()
+	return nil
+}
+
+// Register registers the upgraded websocket connection `ws` to the hub with `userId` and starts
+// writing messages to it. The caller should read messages from the connection by itself, and
+// calls WsConn.Close after reading failed. It is usually used for customizing the reading, or else
+// use Serve instead.
+func (h *WsHub) Register(ctx context.Context, ws *WebSocket, userId string) (*WsConn, error) {
+	if h.closed.Val() {
+		return nil, gerror.NewCode(gcode.CodeInvalidOperation, `websocket hub is closed`)
+	}
+	conn := &WsConn{
+		WebSocket: ws,
+		Id:        guid.S(),
+		UserId:    userId,
+		hub:       h,
+		ctx:       ctx,
+		rooms:     make(map[string]struct{}),
+		send:      make(chan wsHubFrame, h.options.SendBufferSize),
+		done:      make(chan struct{}),
+	}
+	h.mu.Lock()
+	h.conns[conn.Id] = conn
+	if userId != "" {
+		if h.users[userId] == nil {
+			h.users[userId] = make(map[string]*WsConn)
+		}
+		h.users[userId][conn.Id] = conn
+	}
+	h.mu.Unlock()
+	go conn.writeLoop()
+	return conn, nil
+}
+
+// Join adds the connection `conn` to room `room`.
+func (h *WsHub) Join(conn *WsConn, room string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.conns[conn.Id]; !ok {
+		return
+	}
+	if h.rooms[room] == nil {
+		h.rooms[room] = make(map[string]*WsConn)
+	}
+	h.rooms[room][conn.Id] = conn
+	conn.rooms[room] = struct{}{}
+}
+
+// Leave removes the connection `conn` from room `room`.
+func (h *WsHub) Leave(conn *WsConn, room string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.doLeave(conn, room)
+}
+
+// Broadcast sends message to all connections of all instances.
+func (h *WsHub) Broadcast(ctx context.Context, msgType int, data []byte) error {
+	return h.publish(ctx, &WsHubMessage{Type: msgType, Data: data})
+}
+
+// BroadcastRoom sends message to the connections in room `room` of all instances.
+func (h *WsHub) BroadcastRoom(ctx context.Context, room string, msgType int, data []byte) error {
+	return h.publish(ctx, &WsHubMessage{Room: room, Type: msgType, Data: data})
+}
+
+// SendUser sends message to the connections of user `userId` of all instances.
+func (h *WsHub) SendUser(ctx context.Context, userId string, msgType int, data []byte) error {
+	return h.publish(ctx, &WsHubMessage{UserId: userId, Type: msgType, Data: data})
+}
+
+// Count returns the count of local connections.
+func (h *WsHub) Count() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.conns)
+}
+
+// Rooms returns the names of local rooms that have connections.
+func (h *WsHub) Rooms() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	rooms := make([]string, 0, len(h.rooms))
+	for room := range h.rooms {
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// RoomCount returns the count of local connections in room `room`.
+func (h *WsHub) RoomCount(room string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.rooms[room])
+}
+
+// IsOnline checks and returns whether user `userId` has local connections.
+func (h *WsHub) IsOnline(userId string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.users[userId]) > 0
+}
+
+// Close closes all the connections and the broker of the hub.
+func (h *WsHub) Close(ctx context.Context) error {
+	if !h.closed.Cas(false, true) {
+		return nil
+	}
+	h.mu.RLock()
+	conns := make([]*WsConn, 0, len(h.conns))
+	for _, conn := range h.conns {
+		conns = append(conns, conn)
+	}
+	h.mu.RUnlock()
+	for _, conn := range conns {
+		conn.Close()
+	}
+	if h.options.Broker != nil {
+		return h.options.Broker.Close(ctx)
+	}
+	return nil
+}
+
+// publish delivers the message to local connections, and publishes it to other instances through broker.
+func (h *WsHub) publish(ctx context.Context, message *WsHubMessage) error {
+	if h.closed.Val() {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `websocket hub is closed`)
+	}
+	message.Origin = h.id
+	h.deliver(message)
+	if h.options.Broker != nil {
+		return h.options.Broker.Publish(ctx, message)
+	}
+	return nil
+}
+
+// handleBrokerMessage delivers the message from other instances to local connections.
+func (h *WsHub) handleBrokerMessage(message *WsHubMessage) {
+	if message.Origin == h.id {
+		return
+	}
+	h.deliver(message)
+}
+
+// deliver sends the message to the target local connections.
+func (h *WsHub) deliver(message *WsHubMessage) {
+	var targets map[string]*WsConn
+	h.mu.RLock()
+	switch {
+	case message.Room != "":
+		targets = h.rooms[message.Room]
+	case message.UserId != "":
+		targets = h.users[message.UserId]
+	default:
+		targets = h.conns
+	}
+	conns := make([]*WsConn, 0, len(targets))
+	for _, conn := range targets {
+		conns = append(conns, conn)
+	}
+	h.mu.RUnlock()
+	for _, conn := range conns {
+		conn.enqueue(message.Type, message.Data)
+	}
+}
+
+// remove removes the connection from the hub and all its rooms.
+func (h *WsHub) remove(conn *WsConn) {
+	h.mu.Lock()
+	for room := range conn.rooms {
+		h.doLeave(conn, room)
+	}
+	delete(h.conns, conn.Id)
+	if users := h.users[conn.UserId]; users != nil {
+		delete(users, conn.Id)
+		if len(users) == 0 {
+			delete(h.users, conn.UserId)
+		}
+	}
+	h.mu.Unlock()
+}
+
+// doLeave removes the connection from room, it should be called with the write lock.
+func (h *WsHub) doLeave(conn *WsConn, room string) {
+	delete(conn.rooms, room)
+	if conns := h.rooms[room]; conns != nil {
+		delete(conns, conn.Id)
+		if len(conns) == 0 {
+			delete(h.rooms, room)
+		}
+	}
+}
+
+// Context returns the context of the request upgrading the connection.
+func (c *WsConn) Context() context.Context {
+	return c.ctx
+}
+
+// Join adds the connection to room `room`.
+func (c *WsConn) Join(room string) {
+	c.hub.Join(c, room)
+}
+
+// Leave removes the connection from room `room`.
+func (c *WsConn) Leave(room string) {
+	c.hub.Leave(c, room)
+}
+
+// Rooms returns the rooms the connection joined.
+func (c *WsConn) Rooms() []string {
+	c.hub.mu.RLock()
+	defer c.hub.mu.RUnlock()
+	rooms := make([]string, 0, len(c.rooms))
+	for room := range c.rooms {
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// Send sends message to the connection asynchronously.
+// Note that the underlying WriteMessage should not be used for connection managed by hub,
+// as the websocket connection does not support concurrent writers.
+func (c *WsConn) Send(msgType int, data []byte) error {
+	if !c.enqueue(msgType, data) {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `websocket connection is closed`)
+	}
+	return nil
+}
+
+// Close removes the connection from the hub and closes it.
+func (c *WsConn) Close() {
+	c.once.Do(func() {
+		close(c.done)
+		c.hub.remove(c)
+		_ = c.WebSocket.Close()
+		if c.hub.options.OnClose != nil {
+			c.hub.options.OnClose(c)
+		}
+	})
+}
+
+// enqueue puts the message into sending buffer, it closes the connection if the buffer is full.
+func (c *WsConn) enqueue(msgType int, data []byte) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+	select {
+	case c.send <- wsHubFrame{msgType: msgType, data: data}:
+		return true
+	default:
+		intlog.Printf(c.ctx, `websocket connection "%s" sending buffer is full, close it`, c.Id)
+		go c.Close()
+		return false
+	}
+}
+
+// readLoop reads messages from the connection until it is closed.
+func (c *WsConn) readLoop() {
+	defer c.Close()
+	var (
+		options  = c.hub.options
+		deadline = 2 * options.PingInterval
+	)
+	_ = c.SetReadDeadline(time.Now().Add(deadline))
+	c.SetPongHandler(func(string) error {
+		return c.SetReadDeadline(time.Now().Add(deadline))
+	})
+	for {
+		msgType, data, err := c.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = c.SetReadDeadline(time.Now().Add(deadline))
+		if options.OnMessage != nil {
+			options.OnMessage(c, msgType, data)
+		}
+	}
+}
+
+// writeLoop writes the messages in sending buffer and the ping messages to the connection.
+func (c *WsConn) writeLoop() {
+	var (
+		options = c.hub.options
+		ticker  = time.NewTicker(options.PingInterval)
+	)
+	defer func() {
+		ticker.Stop()
+		c.Close()
+	}()
+	for {
+		select {
+		case <-c.done:
+			return
+
+		case frame := <-c.send:
+			_ = c.SetWriteDeadline(time.Now().Add(options.WriteTimeout))
+			if err := c.WriteMessage(frame.msgType, frame.data); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			_ = c.SetWriteDeadline(time.Now().Add(options.WriteTimeout))
+			if err := c.WriteMessage(WsMsgPing, nil); err != nil {
+				return
+			}
+		}
+	}
+}
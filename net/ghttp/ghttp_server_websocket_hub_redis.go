@@ -0,0 +1,115 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// wsHubRedisBroker is the WsHubBroker using redis pub/sub.
+type wsHubRedisBroker struct {
+	mu      sync.Mutex
+	redis   *gredis.Redis
+	channel string
+	conn    *gredis.RedisConn // Connection for subscribing.
+	closed  bool
+}
+
+const (
+	defaultWsHubRedisChannel = "gf:ghttp:wshub"
+	wsHubRedisRetryInterval  = time.Second
+)
+
+// NewWsHubRedisBroker creates and returns a WsHubBroker using the pub/sub of `redis`,
+// which fans out the broadcasts among the hubs of multiple instances sharing the same redis.
+// The optional parameter `channel` specifies the pub/sub channel, which is "gf:ghttp:wshub" in default,
+// the hubs of different businesses should use different channels.
+func NewWsHubRedisBroker(redis *gredis.Redis, channel ...string) WsHubBroker {
+	broker := &wsHubRedisBroker{
+		redis:   redis,
+		channel: defaultWsHubRedisChannel,
+	}
+	if len(channel) > 0 && channel[0] != "" {
+		broker.channel = channel[0]
+	}
+	return broker
+}
+
+// Publish implements WsHubBroker.
+func (b *wsHubRedisBroker) Publish(ctx context.Context, message *WsHubMessage) error {
+	content, err := json.Marshal(message)
+	if err != nil {
+		return gerror.Wrap(err, `json.Marshal websocket hub message failed`)
+	}
+	_, err = b.redis.Do(ctx, "PUBLISH", b.channel, content)
+	return err
+}
+
+// Subscribe implements WsHubBroker.
+func (b *wsHubRedisBroker) Subscribe(ctx context.Context, handler func(message *WsHubMessage)) error {
+	conn, err := b.redis.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err = conn.Do(ctx, "SUBSCRIBE", b.channel); err != nil {
+		_ = conn.Close(ctx)
+		return err
+	}
+	b.mu.Lock()
+	b.conn = conn
+	b.mu.Unlock()
+	go b.receive(ctx, conn, handler)
+	return nil
+}
+
+// Close implements WsHubBroker.
+func (b *wsHubRedisBroker) Close(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	if b.conn != nil {
+		return b.conn.Close(ctx)
+	}
+	return nil
+}
+
+// receive receives the published messages until the connection is closed.
+func (b *wsHubRedisBroker) receive(ctx context.Context, conn *gredis.RedisConn, handler func(message *WsHubMessage)) {
+	for {
+		v, err := conn.Receive(ctx)
+		if err != nil {
+			b.mu.Lock()
+			closed := b.closed
+			b.mu.Unlock()
+			if closed {
+				return
+			}
+			// The subscription is recovered automatically by redis client after reconnected.
+			intlog.Errorf(ctx, `websocket hub receives from redis failed: %+v`, err)
+			time.Sleep(wsHubRedisRetryInterval)
+			continue
+		}
+		msg, ok := v.Val().(*gredis.Message)
+		if !ok {
+			// Like the subscription reply.
+			continue
+		}
+		var message *WsHubMessage
+		if err = json.UnmarshalUseNumber([]byte(msg.Payload), &message); err != nil {
+			intlog.Errorf(ctx, `websocket hub message "%s" is invalid: %+v`, msg.Payload, err)
+			continue
+		}
+		handler(message)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_APIVersion(t *testing.T) {
+	s := g.Server(guid.S())
+	s.SetAPIVersion(ghttp.APIVersionConfig{
+		Header:    "X-API-Version",
+		MediaType: "application/vnd.example",
+		Default:   "v1",
+	})
+	s.DeprecateAPIVersion("v1", ghttp.APIVersionDeprecation{
+		Sunset: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		Link:   "https://example.com/migration",
+	})
+	s.Version("v1", func(group *ghttp.RouterGroup) {
+		group.GET("/user", func(r *ghttp.Request) {
+			r.Response.Write("v1 user ", r.GetAPIVersion())
+		})
+	})
+	s.Version("v2", func(group *ghttp.RouterGroup) {
+		group.GET("/user", func(r *ghttp.Request) {
+			r.Response.Write("v2 user ", r.GetAPIVersion())
+		})
+	})
+	s.Group("/api").Version("v2").GET("/order", func(r *ghttp.Request) {
+		r.Response.Write("v2 order")
+	})
+	s.BindHandler("/health", func(r *ghttp.Request) {
+		r.Response.Write("ok")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx    = context.Background()
+			client = g.Client()
+		)
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Version in path.
+		t.Assert(client.GetContent(ctx, "/v2/user"), "v2 user v2")
+		resp, err := client.Get(ctx, "/v1/user")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "v1 user v1")
+		t.Assert(resp.Header.Get("Deprecation"), "true")
+		t.Assert(resp.Header.Get("Sunset"), "Tue, 01 Jan 2030 00:00:00 GMT")
+		t.Assert(resp.Header.Get("Link"), `<https://example.com/migration>; rel="deprecation"`)
+		resp.Close()
+
+		// Version in header and media type.
+		t.Assert(client.Header(g.MapStrStr{"X-API-Version": "2"}).GetContent(ctx, "/user"), "v2 user v2")
+		t.Assert(client.Header(g.MapStrStr{"Accept": "application/vnd.example.v2+json"}).GetContent(ctx, "/user"), "v2 user v2")
+		t.Assert(client.Header(g.MapStrStr{"Accept": "application/vnd.example+json; version=2"}).GetContent(ctx, "/user"), "v2 user v2")
+		t.Assert(client.Header(g.MapStrStr{"X-API-Version": "v2"}).GetContent(ctx, "/api/order"), "v2 order")
+
+		// Default version.
+		resp, err = client.Get(ctx, "/user")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "v1 user v1")
+		t.Assert(resp.Header.Get("Deprecation"), "true")
+		resp.Close()
+
+		// Not versioned route and unknown version.
+		t.Assert(client.GetContent(ctx, "/health"), "ok")
+		t.Assert(client.Header(g.MapStrStr{"X-API-Version": "v3"}).GetContent(ctx, "/user"), "v1 user v1")
+		t.Assert(client.GetContent(ctx, "/v3/user"), "Not Found")
+	})
+}
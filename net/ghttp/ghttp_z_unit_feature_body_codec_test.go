@@ -0,0 +1,113 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gmsgpack"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+type testBodyCodecReq struct {
+	g.Meta `path:"/codec" method:"post"`
+	Name   string
+	Age    int
+}
+
+type testBodyCodecRes struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+type testBodyCodecController struct{}
+
+func (testBodyCodecController) Codec(ctx context.Context, req *testBodyCodecReq) (res *testBodyCodecRes, err error) {
+	return &testBodyCodecRes{Name: req.Name, Age: req.Age}, nil
+}
+
+// testKvCodec is a BodyCodec of plain "key:value" lines for testing.
+type testKvCodec struct{}
+
+func (testKvCodec) Decode(body []byte) (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	for _, line := range gstr.SplitAndTrim(string(body), "\n") {
+		if array := strings.SplitN(line, ":", 2); len(array) == 2 {
+			m[array[0]] = array[1]
+		}
+	}
+	return m, nil
+}
+
+func (testKvCodec) Encode(content interface{}) ([]byte, error) {
+	var lines []string
+	for k, v := range gconv.Map(content) {
+		lines = append(lines, fmt.Sprintf("%s:%v", k, v))
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+func Test_BodyCodec(t *testing.T) {
+	ghttp.RegisterBodyCodec(testKvCodec{}, "application/x-test-kv")
+
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.Bind(testBodyCodecController{})
+	})
+	s.BindHandler("/raw", func(r *ghttp.Request) {
+		r.Response.WriteByAccept(r.GetMap())
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(ghttp.GetBodyCodec("application/msgpack; charset=utf-8"), ghttp.BodyCodecMsgpack)
+		t.Assert(ghttp.GetBodyCodec("application/cbor"), nil)
+
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// MessagePack request body for structured handler.
+		body, err := gmsgpack.Encode(g.Map{"name": "john", "age": 18})
+		t.AssertNil(err)
+		content := client.ContentType("application/msgpack").PostContent(ctx, "/codec", body)
+		t.Assert(content, `{"code":0,"message":"","data":{"name":"john","age":18}}`)
+
+		// MessagePack request and response.
+		resp, err := client.Clone().
+			ContentType("application/x-msgpack").
+			SetHeader("Accept", "application/x-msgpack").
+			Post(ctx, "/raw", body)
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Type"), "application/msgpack")
+		v, err := gmsgpack.Decode(resp.ReadAll())
+		t.AssertNil(err)
+		t.Assert(gconv.Map(v)["name"], "john")
+		resp.Close()
+
+		// Custom codec request and response.
+		resp, err = client.Clone().
+			ContentType("application/x-test-kv").
+			SetHeader("Accept", "application/x-test-kv").
+			Post(ctx, "/raw", "name:john")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Type"), "application/x-test-kv")
+		t.Assert(resp.ReadAllString(), "name:john")
+		resp.Close()
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_ClientIp_TrustedProxies(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/ip", func(r *ghttp.Request) {
+		r.Response.Write(r.GetClientIp())
+	})
+	gtest.AssertNE(s.SetTrustedProxies([]string{"invalid"}), nil)
+	gtest.AssertNil(s.SetTrustedProxies([]string{"127.0.0.1", "10.0.0.0/8"}))
+	s.SetClientIpHeaders([]string{"X-Forwarded-For", "Forwarded", "CF-Connecting-IP"})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(client.GetContent(ctx, "/ip"), "127.0.0.1")
+
+		// The forged address on the left is ignored, and the trusted proxies on the right are skipped.
+		t.Assert(client.Clone().SetHeader(
+			"X-Forwarded-For", "1.1.1.1, 2.2.2.2, 10.0.0.2",
+		).GetContent(ctx, "/ip"), "2.2.2.2")
+
+		// All addresses are trusted proxies.
+		t.Assert(client.Clone().SetHeader(
+			"X-Forwarded-For", "10.0.0.1, 10.0.0.2",
+		).GetContent(ctx, "/ip"), "10.0.0.1")
+
+		// Forwarded header with port and IPv6.
+		t.Assert(client.Clone().SetHeader(
+			"Forwarded", `for=192.0.2.60:8080;proto=http;by=203.0.113.43, for="[2001:db8:cafe::17]:4711"`,
+		).GetContent(ctx, "/ip"), "2001:db8:cafe::17")
+
+		// Header precedence.
+		t.Assert(client.Clone().SetHeaderMap(g.MapStrStr{
+			"CF-Connecting-IP": "3.3.3.3",
+			"X-Forwarded-For":  "4.4.4.4",
+		}).GetContent(ctx, "/ip"), "4.4.4.4")
+		t.Assert(client.Clone().SetHeaderMap(g.MapStrStr{
+			"CF-Connecting-IP": "3.3.3.3",
+			"X-Real-IP":        "5.5.5.5",
+		}).GetContent(ctx, "/ip"), "3.3.3.3")
+
+		// Invalid address.
+		t.Assert(client.Clone().SetHeader(
+			"X-Forwarded-For", "invalid",
+		).GetContent(ctx, "/ip"), "127.0.0.1")
+	})
+}
+
+func Test_ClientIp_UntrustedRemote(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/ip", func(r *ghttp.Request) {
+		r.Response.Write(r.GetClientIp())
+	})
+	gtest.AssertNil(s.SetTrustedProxies([]string{"10.0.0.0/8"}))
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(client.Clone().SetHeaderMap(g.MapStrStr{
+			"X-Forwarded-For": "1.1.1.1",
+			"X-Real-IP":       "2.2.2.2",
+		}).GetContent(ctx, "/ip"), "127.0.0.1")
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/glog"
 	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/text/gstr"
@@ -169,3 +170,67 @@ func Test_ClientMaxBodySize_File(t *testing.T) {
 		)
 	})
 }
+
+func Test_Server_ReloadConfig(t *testing.T) {
+	var (
+		dir1 = gfile.Temp(gtime.TimestampNanoStr())
+		dir2 = gfile.Temp(gtime.TimestampNanoStr())
+	)
+	defer gfile.Remove(dir1)
+	defer gfile.Remove(dir2)
+	gtest.AssertNil(gfile.PutContents(gfile.Join(dir1, "index.html"), "dir1"))
+	gtest.AssertNil(gfile.PutContents(gfile.Join(dir2, "index.html"), "dir2"))
+
+	s := g.Server(guid.S())
+	gtest.AssertNil(s.SetConfigWithMap(g.Map{
+		"ServerRoot": dir1,
+		"LogLevel":   "all",
+	}))
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		c := g.Client()
+		c.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(c.GetContent(ctx, "/"), "dir1")
+
+		t.AssertNil(s.ReloadConfigWithMap(g.Map{
+			"ServerRoot":  dir2,
+			"LogLevel":    "error",
+			"ReadTimeout": "10s",
+		}))
+		t.Assert(c.GetContent(ctx, "/"), "dir2")
+		t.Assert(s.Logger().GetLevel()&glog.LEVEL_INFO, 0)
+
+		// Invalid configuration applies nothing.
+		t.AssertNE(s.ReloadConfigWithMap(g.Map{
+			"ServerRoot": gfile.Temp(gtime.TimestampNanoStr()),
+			"LogLevel":   "all",
+		}), nil)
+		t.Assert(c.GetContent(ctx, "/"), "dir2")
+		t.Assert(s.Logger().GetLevel()&glog.LEVEL_INFO, 0)
+	})
+	// Reloaded timeouts take effect for new connections.
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(s.ReloadConfigWithMap(g.Map{
+			"ReadTimeout": "200ms",
+		}))
+		conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", s.GetListenedPort()))
+		t.AssertNil(err)
+		defer conn.Close()
+		_, err = conn.Write([]byte("GET / HTTP/1.1\r\n"))
+		t.AssertNil(err)
+		t.AssertNil(conn.SetReadDeadline(time.Now().Add(3 * time.Second)))
+		// The server closes the connection as the request is not completed in time.
+		_, err = conn.Read(make([]byte, 1024))
+		t.AssertNE(err, nil)
+		netErr, ok := err.(net.Error)
+		t.Assert(ok && netErr.Timeout(), false)
+
+		c := g.Client()
+		c.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(c.GetContent(ctx, "/"), "dir2")
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_CORSPolicy(t *testing.T) {
+	policy := ghttp.NewCORSPolicy(ghttp.CORSPolicyOption{
+		AllowOrigins:     []string{"https://example.com", "https://*.example.org"},
+		AllowHeaders:     []string{"Content-Type", "X-Token"},
+		ExposeHeaders:    []string{"X-Total"},
+		AllowCredentials: true,
+		MaxAge:           time.Hour,
+	}).Route("/public/*", ghttp.CORSPolicyOption{
+		AllowOrigins:        []string{"*"},
+		AllowMethods:        []string{"GET"},
+		AllowPrivateNetwork: true,
+	})
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(policy.Middleware)
+		group.ALL("/user/{id}", func(r *ghttp.Request) {
+			r.Response.Write("user")
+		})
+		group.GET("/public/data", func(r *ghttp.Request) {
+			r.Response.Write("data")
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Actual request of allowed origin.
+		resp, err := client.Clone().SetHeader("Origin", "https://example.com").Get(ctx, "/user/1")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "user")
+		t.Assert(resp.Header.Get("Access-Control-Allow-Origin"), "https://example.com")
+		t.Assert(resp.Header.Get("Access-Control-Allow-Credentials"), "true")
+		t.Assert(resp.Header.Get("Access-Control-Expose-Headers"), "X-Total")
+		t.Assert(resp.Header.Get("Vary"), "Origin")
+		resp.Close()
+
+		// Actual request of disallowed origin.
+		resp, err = client.Clone().SetHeader("Origin", "https://evil.com").Get(ctx, "/user/1")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "user")
+		t.Assert(resp.Header.Get("Access-Control-Allow-Origin"), "")
+		resp.Close()
+
+		// Preflight of wildcard subdomain origin.
+		preflight := client.Clone().SetHeaderMap(g.MapStrStr{
+			"Origin":                         "https://a.b.example.org",
+			"Access-Control-Request-Method":  "PUT",
+			"Access-Control-Request-Headers": "content-type,x-token",
+		})
+		resp, err = preflight.Options(ctx, "/user/1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNoContent)
+		t.Assert(resp.ReadAllString(), "")
+		t.Assert(resp.Header.Get("Access-Control-Allow-Origin"), "https://a.b.example.org")
+		t.Assert(resp.Header.Get("Access-Control-Allow-Methods"), "GET,HEAD,POST,PUT,PATCH,DELETE")
+		t.Assert(resp.Header.Get("Access-Control-Allow-Headers"), "content-type,x-token")
+		t.Assert(resp.Header.Get("Access-Control-Max-Age"), "3600")
+		resp.Close()
+
+		// Preflight of disallowed header.
+		resp, err = preflight.Clone().SetHeader("Access-Control-Request-Headers", "x-other").Options(ctx, "/user/1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusForbidden)
+		resp.Close()
+
+		// Preflight of private network, which is not allowed by default policy.
+		resp, err = preflight.Clone().SetHeader("Access-Control-Request-Private-Network", "true").Options(ctx, "/user/1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusForbidden)
+		resp.Close()
+
+		// Route override.
+		resp, err = client.Clone().SetHeaderMap(g.MapStrStr{
+			"Origin":                                 "https://any.com",
+			"Access-Control-Request-Method":          "GET",
+			"Access-Control-Request-Private-Network": "true",
+		}).Options(ctx, "/public/data")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNoContent)
+		t.Assert(resp.Header.Get("Access-Control-Allow-Origin"), "*")
+		t.Assert(resp.Header.Get("Access-Control-Allow-Credentials"), "")
+		t.Assert(resp.Header.Get("Access-Control-Allow-Methods"), "GET")
+		t.Assert(resp.Header.Get("Access-Control-Allow-Private-Network"), "true")
+		resp.Close()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		ghttp.NewCORSPolicy(ghttp.CORSPolicyOption{
+			AllowOrigins:     []string{"*"},
+			AllowCredentials: true,
+		})
+	})
+}
@@ -126,3 +126,44 @@ func Test_HTTPS_HTTP_Basic(t *testing.T) {
 		t.Assert(c.GetContent(ctx, "/test"), "test")
 	})
 }
+
+func Test_HTTPS_ReloadCertificate(t *testing.T) {
+	var (
+		dir      = gfile.Temp(gtime.TimestampNanoStr())
+		certFile = gfile.Join(dir, "server.crt")
+		keyFile  = gfile.Join(dir, "server.key")
+	)
+	defer gfile.Remove(dir)
+	gtest.AssertNil(gfile.Copy(gtest.DataPath("https", "files", "server.crt"), certFile))
+	gtest.AssertNil(gfile.Copy(gtest.DataPath("https", "files", "server.key"), keyFile))
+
+	s := g.Server(guid.S())
+	s.BindHandler("/test", func(r *ghttp.Request) {
+		r.Response.Write("test")
+	})
+	s.EnableHTTPS(certFile, keyFile)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		c := g.Client()
+		c.SetPrefix(fmt.Sprintf("https://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(c.GetContent(ctx, "/test"), "test")
+
+		t.AssertNil(s.ReloadConfigWithMap(g.Map{
+			"HTTPSCertPath": certFile,
+			"HTTPSKeyPath":  keyFile,
+		}))
+		t.Assert(c.GetContent(ctx, "/test"), "test")
+
+		// Invalid certification keeps the previous one.
+		t.AssertNil(gfile.PutContents(keyFile, "invalid"))
+		t.AssertNE(s.ReloadConfigWithMap(g.Map{
+			"HTTPSCertPath": certFile,
+			"HTTPSKeyPath":  keyFile,
+		}), nil)
+		t.Assert(c.GetContent(ctx, "/test"), "test")
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/net/gtcp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Server_MultiListener(t *testing.T) {
+	var (
+		sockPath   = gfile.Temp(guid.S() + ".sock")
+		unixAddr   = "unix:" + sockPath
+		ports, err = gtcp.GetFreePorts(2)
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gfile.Remove(sockPath)
+
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.GET("/listener", func(r *ghttp.Request) {
+			r.Response.Write(r.GetListenerAddress())
+		})
+	})
+	s.Group("/admin", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareListenerOnly(unixAddr))
+		group.GET("/status", func(r *ghttp.Request) {
+			r.Response.Write("ok")
+		})
+	})
+	s.BindListenerMiddleware(fmt.Sprintf("%d", ports[0]), func(r *ghttp.Request) {
+		r.Response.Header().Set("X-Listener", "tcp")
+		r.Middleware.Next()
+	})
+	s.EnableHTTPS(
+		gtest.DataPath("https", "files", "server.crt"),
+		gtest.DataPath("https", "files", "server.key"),
+	)
+	s.SetAddr(fmt.Sprintf(":%d,%s", ports[0], unixAddr))
+	s.SetHTTPSPort(ports[1])
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// TCP.
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gfile.Exists(sockPath), true)
+		t.AssertIN(ports[0], s.GetListenedPorts())
+		t.AssertIN(ports[1], s.GetListenedPorts())
+
+		c := g.Client()
+		c.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", ports[0]))
+		resp, err := c.Get(ctx, "/listener")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), fmt.Sprintf(":%d", ports[0]))
+		t.Assert(resp.Header.Get("X-Listener"), "tcp")
+		t.Assert(c.GetContent(ctx, "/admin/status"), "Not Found")
+	})
+	// TLS.
+	gtest.C(t, func(t *gtest.T) {
+		c := g.Client()
+		c.SetPrefix(fmt.Sprintf("https://127.0.0.1:%d", ports[1]))
+		resp, err := c.Get(ctx, "/listener")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.ReadAllString(), fmt.Sprintf(":%d", ports[1]))
+		t.Assert(resp.Header.Get("X-Listener"), "")
+		t.Assert(c.GetContent(ctx, "/admin/status"), "Not Found")
+	})
+	// Unix domain socket.
+	gtest.C(t, func(t *gtest.T) {
+		client := &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					return net.Dial("unix", sockPath)
+				},
+			},
+		}
+		get := func(path string) (int, string) {
+			resp, err := client.Get("http://unix" + path)
+			t.AssertNil(err)
+			defer resp.Body.Close()
+			body, err := ioutil.ReadAll(resp.Body)
+			t.AssertNil(err)
+			return resp.StatusCode, string(body)
+		}
+		status, body := get("/listener")
+		t.Assert(status, http.StatusOK)
+		t.Assert(body, unixAddr)
+		status, body = get("/admin/status")
+		t.Assert(status, http.StatusOK)
+		t.Assert(body, "ok")
+	})
+}
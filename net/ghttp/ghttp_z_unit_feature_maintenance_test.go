@@ -0,0 +1,111 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Server_Maintenance(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.ALL("/user", func(r *ghttp.Request) {
+			r.Response.Write("user")
+		})
+		group.ALL("/health", func(r *ghttp.Request) {
+			r.Response.Write("ok")
+		})
+	})
+	s.EnableAdmin()
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(prefix)
+		t.Assert(s.IsMaintenance(), false)
+		t.Assert(client.GetContent(ctx, "/user"), "user")
+
+		t.AssertNil(s.SetMaintenance(true, ghttp.MaintenanceOption{
+			Message:    "upgrading",
+			RetryAfter: time.Minute,
+			AllowPaths: []string{"/health"},
+		}))
+		t.Assert(s.IsMaintenance(), true)
+
+		resp, err := client.Get(ctx, "/user")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 503)
+		t.Assert(resp.Header.Get("Retry-After"), "60")
+		t.Assert(gstr.Contains(resp.ReadAllString(), "<p>upgrading</p>"), true)
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{"Accept": "application/json"}).Get(ctx, "/user")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 503)
+		t.Assert(resp.ReadAllString(), `{"code":63,"message":"upgrading","data":null}`)
+		resp.Close()
+
+		// Allowed path.
+		t.Assert(client.GetContent(ctx, "/health"), "ok")
+		// Administration page.
+		t.Assert(client.GetContent(ctx, "/debug/admin/maintenance?enabled=false"), "maintenance: false")
+		t.Assert(s.IsMaintenance(), false)
+		t.Assert(client.GetContent(ctx, "/user"), "user")
+		t.Assert(client.GetContent(ctx, "/debug/admin/maintenance?enabled=true"), "maintenance: true")
+		t.Assert(s.IsMaintenance(), true)
+		t.Assert(client.GetContent(ctx, "/user") != "user", true)
+	})
+
+	// Allowed IP.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(prefix)
+		t.AssertNil(s.SetMaintenance(true, ghttp.MaintenanceOption{
+			AllowIps: []string{"127.0.0.0/8"},
+		}))
+		t.Assert(client.GetContent(ctx, "/user"), "user")
+		t.AssertNE(s.SetMaintenance(true, ghttp.MaintenanceOption{
+			AllowIps: []string{"invalid"},
+		}), nil)
+		t.AssertNil(s.SetMaintenance(false, ghttp.MaintenanceOption{}))
+	})
+
+	// Configuration.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(prefix)
+		t.AssertNil(s.ReloadConfigWithMap(g.Map{
+			"maintenance": true,
+			"maintenanceOption": g.Map{
+				"content": "<h1>maintenance</h1>",
+			},
+		}))
+		resp, err := client.Get(ctx, "/user")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 503)
+		t.Assert(resp.ReadAllString(), "<h1>maintenance</h1>")
+		resp.Close()
+
+		t.AssertNil(s.ReloadConfigWithMap(g.Map{
+			"maintenance": false,
+		}))
+		t.Assert(client.GetContent(ctx, "/user"), "user")
+	})
+}
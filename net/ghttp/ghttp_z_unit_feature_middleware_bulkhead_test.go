@@ -0,0 +1,90 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Bulkhead(t *testing.T) {
+	var (
+		inflight    = gtype.NewInt()
+		maxInflight = gtype.NewInt()
+	)
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareBulkhead(ghttp.BulkheadOption{
+			MaxConcurrent: 2,
+			MaxQueue:      1,
+			QueueTimeout:  50 * time.Millisecond,
+			RetryAfter:    1500 * time.Millisecond,
+		}))
+		group.GET("/slow", func(r *ghttp.Request) {
+			n := inflight.Add(1)
+			if n > maxInflight.Val() {
+				maxInflight.Set(n)
+			}
+			time.Sleep(300 * time.Millisecond)
+			inflight.Add(-1)
+			r.Response.Write("ok")
+		})
+		group.GET("/fast", func(r *ghttp.Request) {
+			r.Response.Write("fast")
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg       sync.WaitGroup
+			rejected = gtype.NewInt()
+			served   = gtype.NewInt()
+			prefix   = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+		)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp, err := g.Client().Get(ctx, prefix+"/slow")
+				if err != nil {
+					return
+				}
+				defer resp.Close()
+				switch resp.StatusCode {
+				case http.StatusOK:
+					served.Add(1)
+				case http.StatusServiceUnavailable:
+					if resp.Header.Get("Retry-After") == "2" {
+						rejected.Add(1)
+					}
+				}
+			}()
+		}
+		time.Sleep(100 * time.Millisecond)
+		// Other routes are not affected.
+		t.Assert(g.Client().GetContent(ctx, prefix+"/fast"), "fast")
+		wg.Wait()
+		t.Assert(served.Val(), 2)
+		t.Assert(rejected.Val(), 3)
+		t.Assert(maxInflight.Val(), 2)
+
+		// Slots are released.
+		t.Assert(g.Client().GetContent(ctx, prefix+"/slow"), "ok")
+	})
+}
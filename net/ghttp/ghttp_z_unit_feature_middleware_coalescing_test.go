@@ -0,0 +1,80 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Coalescing(t *testing.T) {
+	count := gtype.NewInt()
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCoalescing())
+		group.ALL("/report", func(r *ghttp.Request) {
+			time.Sleep(300 * time.Millisecond)
+			r.Response.Header().Set("X-Report", "report")
+			r.Response.Write(fmt.Sprintf("report-%d", count.Add(1)))
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	request := func(method, url string, header g.MapStrStr) *garray.StrArray {
+		var (
+			wg       sync.WaitGroup
+			contents = garray.NewStrArray(true)
+		)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				client := g.Client().Header(header)
+				client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+				resp, err := client.DoRequest(ctx, method, url)
+				if err != nil {
+					return
+				}
+				defer resp.Close()
+				contents.Append(resp.Header.Get("X-Report") + ":" + resp.ReadAllString())
+			}()
+		}
+		wg.Wait()
+		return contents
+	}
+	gtest.C(t, func(t *gtest.T) {
+		// Identical requests.
+		contents := request("GET", "/report?a=1&b=2", nil)
+		t.Assert(contents.Len(), 5)
+		t.Assert(contents.Unique().Slice(), []string{"report:report-1"})
+		t.Assert(count.Val(), 1)
+
+		// Requests of another principal.
+		contents = request("GET", "/report?b=2&a=1", g.MapStrStr{"Authorization": "token"})
+		t.Assert(contents.Unique().Slice(), []string{"report:report-2"})
+		t.Assert(count.Val(), 2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// Not coalesced for other methods.
+		contents := request("POST", "/report", nil)
+		t.Assert(contents.Len(), 5)
+		t.Assert(contents.Unique().Len(), 5)
+		t.Assert(count.Val(), 7)
+	})
+}
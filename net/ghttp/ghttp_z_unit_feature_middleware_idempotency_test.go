@@ -0,0 +1,91 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Idempotency(t *testing.T) {
+	var (
+		count  = gtype.NewInt()
+		failed = gtype.NewInt()
+	)
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareIdempotency())
+		group.POST("/pay", func(r *ghttp.Request) {
+			r.Response.Header().Set("X-Order", "order")
+			r.Response.WriteStatus(http.StatusCreated, fmt.Sprintf("paid-%d", count.Add(1)))
+		})
+		group.POST("/fail", func(r *ghttp.Request) {
+			r.Response.WriteStatus(http.StatusBadGateway, fmt.Sprintf("failed-%d", failed.Add(1)))
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Without key.
+		t.Assert(client.PostContent(ctx, "/pay", "amount=1"), "paid-1")
+		t.Assert(client.PostContent(ctx, "/pay", "amount=1"), "paid-2")
+
+		// First request of key.
+		keyClient := client.Clone().SetHeader("Idempotency-Key", "k1")
+		resp, err := keyClient.Post(ctx, "/pay", "amount=1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusCreated)
+		t.Assert(resp.ReadAllString(), "paid-3")
+		t.Assert(resp.Header.Get("Idempotent-Replayed"), "")
+		resp.Close()
+
+		// Replayed.
+		resp, err = keyClient.Post(ctx, "/pay", "amount=1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusCreated)
+		t.Assert(resp.ReadAllString(), "paid-3")
+		t.Assert(resp.Header.Get("Idempotent-Replayed"), "true")
+		t.Assert(resp.Header.Get("X-Order"), "order")
+		resp.Close()
+		t.Assert(count.Val(), 3)
+
+		// Same key with different body.
+		resp, err = keyClient.Post(ctx, "/pay", "amount=2")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusUnprocessableEntity)
+		resp.Close()
+
+		// Server errors are not stored.
+		t.Assert(keyClient.PostContent(ctx, "/fail", "amount=1"), "failed-1")
+		t.Assert(keyClient.PostContent(ctx, "/fail", "amount=1"), "failed-2")
+
+		// Same key of other callers is not replayed.
+		resp, err = keyClient.Clone().SetHeader("Authorization", "Bearer other").Post(ctx, "/pay", "amount=1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusCreated)
+		t.Assert(resp.ReadAllString(), "paid-4")
+		t.Assert(resp.Header.Get("Idempotent-Replayed"), "")
+		resp.Close()
+		resp, err = keyClient.Clone().SetHeader("Cookie", "gfsessionid=other").Post(ctx, "/pay", "amount=1")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "paid-5")
+		resp.Close()
+	})
+}
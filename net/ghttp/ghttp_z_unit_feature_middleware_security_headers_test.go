@@ -0,0 +1,84 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gview"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gregex"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_SecurityHeaders(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/relaxed", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareSecurityHeaders())
+		group.ALL("/", func(r *ghttp.Request) {
+			r.Response.Write("relaxed")
+		})
+	})
+	s.Group("/strict", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareSecurityHeaders(ghttp.SecurityHeadersStrict()))
+		group.ALL("/", func(r *ghttp.Request) {
+			r.Response.WriteTplContent(`<script nonce="{{.CspNonce}}"></script>`)
+		})
+		group.ALL("/nonce", func(r *ghttp.Request) {
+			r.Response.Write(gview.CspNonceFromCtx(r.Context()))
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Get(ctx, "/relaxed")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "relaxed")
+		t.Assert(resp.Header.Get("Content-Security-Policy"), "object-src 'none'; base-uri 'self'; frame-ancestors 'self'")
+		t.Assert(resp.Header.Get("X-Content-Type-Options"), "nosniff")
+		t.Assert(resp.Header.Get("X-Frame-Options"), "SAMEORIGIN")
+		t.Assert(resp.Header.Get("Referrer-Policy"), "strict-origin-when-cross-origin")
+		t.Assert(resp.Header.Get("Strict-Transport-Security"), "")
+		resp.Close()
+
+		// HSTS for request forwarded from HTTPS.
+		resp, err = client.Header(g.MapStrStr{"X-Forwarded-Proto": "https"}).Get(ctx, "/strict")
+		t.AssertNil(err)
+		var (
+			content = resp.ReadAllString()
+			csp     = resp.Header.Get("Content-Security-Policy")
+		)
+		match, _ := gregex.MatchString(`script-src 'self' 'nonce-([^']+)'`, csp)
+		t.Assert(len(match), 2)
+		t.Assert(content, fmt.Sprintf(`<script nonce="%s"></script>`, match[1]))
+		t.Assert(strings.Count(csp, match[1]), 2)
+		t.Assert(resp.Header.Get("Strict-Transport-Security"), "max-age=63072000; includeSubDomains")
+		t.Assert(resp.Header.Get("X-Frame-Options"), "DENY")
+		t.Assert(resp.Header.Get("Cross-Origin-Opener-Policy"), "same-origin")
+		resp.Close()
+
+		// The nonce is different for each request.
+		resp, err = client.Get(ctx, "/strict/nonce")
+		t.AssertNil(err)
+		nonce := resp.ReadAllString()
+		t.AssertNE(nonce, "")
+		t.AssertNE(nonce, match[1])
+		t.Assert(strings.Contains(resp.Header.Get("Content-Security-Policy"), "'nonce-"+nonce+"'"), true)
+		resp.Close()
+	})
+}
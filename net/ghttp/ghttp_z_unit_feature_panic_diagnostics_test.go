@@ -0,0 +1,99 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Server_PanicDiagnostics(t *testing.T) {
+	var (
+		path    = gfile.Temp(guid.S())
+		bundles = make(chan *ghttp.PanicDiagnostics, 1)
+	)
+	defer gfile.Remove(path)
+
+	s := g.Server(guid.S())
+	s.BindHandler("/ok", func(r *ghttp.Request) {
+		r.Response.Write("ok")
+	})
+	s.BindHandler("/panic", func(r *ghttp.Request) {
+		r.Server.Logger().Info(r.Context(), "before panic")
+		panic("boom")
+	})
+	s.SetPanicDiagnostics(ghttp.PanicDiagnosticsOption{
+		Enabled:    true,
+		Path:       path,
+		LogSize:    10,
+		RedactKeys: []string{"card"},
+		Sink: func(ctx context.Context, bundle *ghttp.PanicDiagnostics) {
+			bundles <- bundle
+		},
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(client.GetContent(ctx, "/ok"), "ok")
+		t.Assert(gfile.Exists(path), false)
+
+		resp, err := client.Header(g.MapStrStr{
+			"Authorization": "Bearer secret",
+			"X-Custom":      "custom",
+		}).Post(ctx, "/panic?password=123&name=john", g.Map{
+			"card_no": "6222",
+			"age":     18,
+		})
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 500)
+		resp.Close()
+
+		var bundle *ghttp.PanicDiagnostics
+		select {
+		case bundle = <-bundles:
+		case <-time.After(time.Second):
+			t.Fatal("no panic diagnostics captured")
+		}
+		t.Assert(gstr.Contains(bundle.Error, "boom"), true)
+		t.AssertNE(bundle.Stack, "")
+		t.Assert(bundle.Goroutines > 0, true)
+		t.Assert(bundle.Request.Method, "POST")
+		t.Assert(gstr.Contains(bundle.Request.Url, "name=john"), true)
+		t.Assert(gstr.Contains(bundle.Request.Url, "123"), false)
+		t.Assert(bundle.Request.Header["Authorization"], "******")
+		t.Assert(bundle.Request.Header["X-Custom"], "custom")
+		t.Assert(bundle.Request.Params["password"], "******")
+		t.Assert(bundle.Request.Params["card_no"], "******")
+		t.Assert(bundle.Request.Params["age"], 18)
+		t.Assert(len(bundle.RecentLogs) > 0, true)
+		t.Assert(gstr.Contains(bundle.RecentLogs[len(bundle.RecentLogs)-1], "before panic"), true)
+
+		files, err := gfile.ScanDirFile(path, "panic-*.json")
+		t.AssertNil(err)
+		t.Assert(len(files), 1)
+		var saved *ghttp.PanicDiagnostics
+		t.AssertNil(json.Unmarshal(gfile.GetBytes(files[0]), &saved))
+		t.Assert(saved.Error, bundle.Error)
+		t.Assert(saved.Request.Header["Authorization"], "******")
+	})
+}
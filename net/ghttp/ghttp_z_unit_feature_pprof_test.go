@@ -10,11 +10,13 @@ package ghttp_test
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/gogf/gf/v2/frame/g"
 	. "github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
 	"github.com/gogf/gf/v2/util/guid"
 )
 
@@ -56,3 +58,34 @@ func TestServer_EnablePProf(t *testing.T) {
 	})
 
 }
+
+func TestServer_EnableProfiling(t *testing.T) {
+	C(t, func(t *T) {
+		s := g.Server(guid.S())
+		s.EnableProfiling("/profiling", func(r *http.Request) bool {
+			return r.Header.Get("Token") == "secret"
+		})
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		r, err := client.Get(ctx, "/profiling")
+		Assert(err, nil)
+		Assert(r.StatusCode, 403)
+		r.Close()
+
+		client.SetHeader("Token", "secret")
+		Assert(gstr.Contains(client.GetContent(ctx, "/profiling"), "dump/goroutine"), true)
+		Assert(gstr.Contains(client.GetContent(ctx, "/profiling/dump/goroutine"), "goroutine"), true)
+		Assert(client.GetContent(ctx, "/profiling/toggle/block?rate=1"), "rate: 0 -> 1\n")
+		Assert(client.GetContent(ctx, "/profiling/toggle/block?rate=0"), "rate: 1 -> 0\n")
+
+		r, err = client.Get(ctx, "/profiling/not-exist")
+		Assert(err, nil)
+		Assert(r.StatusCode, 404)
+		r.Close()
+	})
+}
@@ -241,3 +241,47 @@ func Test_Params_File_Upload_Required(t *testing.T) {
 		t.Assert(content, `{"code":51,"message":"upload file is required","data":null}`)
 	})
 }
+
+func Test_Params_File_Upload_Rules(t *testing.T) {
+	type Req struct {
+		gmeta.Meta `method:"post" mime:"multipart/form-data"`
+		File       *ghttp.UploadFile `type:"file" v:"required|max-size:1k|ext-in:txt,csv|mime-in:text/plain"`
+	}
+	type Res struct{}
+
+	s := g.Server(guid.S())
+	s.Use(ghttp.MiddlewareHandlerResponse)
+	s.BindHandler("/upload/rules", func(ctx context.Context, req *Req) (res *Res, err error) {
+		return
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		content := client.PostContent(ctx, "/upload/rules", g.Map{
+			"file": "@file:" + gtest.DataPath("upload", "file1.txt"),
+		})
+		t.Assert(content, `{"code":0,"message":"","data":null}`)
+
+		largePath := gfile.Temp(guid.S() + ".txt")
+		defer gfile.Remove(largePath)
+		t.AssertNil(gfile.PutContents(largePath, gstr.Repeat("a", 2048)))
+		content = client.PostContent(ctx, "/upload/rules", g.Map{
+			"file": "@file:" + largePath,
+		})
+		t.Assert(content, `{"code":51,"message":"The File file size must be equal or lesser than 1k","data":null}`)
+
+		renamedPath := gfile.Temp(guid.S() + ".html")
+		defer gfile.Remove(renamedPath)
+		t.AssertNil(gfile.PutContents(renamedPath, "content"))
+		content = client.PostContent(ctx, "/upload/rules", g.Map{
+			"file": "@file:" + renamedPath,
+		})
+		t.Assert(content, `{"code":51,"message":"The File file extension must be in: txt,csv","data":null}`)
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_RequestId(t *testing.T) {
+	s := g.Server(guid.S())
+	s.SetRequestIdEnabled(true)
+	s.BindHandler("/id", func(r *ghttp.Request) {
+		r.Response.Write(r.GetRequestId())
+	})
+	s.BindHandler("/echo", func(r *ghttp.Request) {
+		r.Response.Write(r.Header.Get("X-Request-ID"))
+	})
+	s.BindHandler("/proxy", func(r *ghttp.Request) {
+		url := fmt.Sprintf("http://127.0.0.1:%d/echo", r.Server.GetListenedPort())
+		// Propagated to the outgoing request with the request context.
+		r.Response.Write(g.Client().GetContent(r.Context(), url))
+	})
+	s.Group("/api", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.GET("/error", func(r *ghttp.Request) {
+			r.SetError(gerror.New("error"))
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Generated.
+		resp, err := client.Get(ctx, "/id")
+		t.AssertNil(err)
+		id := resp.ReadAllString()
+		t.AssertNE(id, "")
+		t.Assert(resp.Header.Get("X-Request-ID"), id)
+		resp.Close()
+
+		// Honored.
+		idClient := client.Clone().SetHeader("X-Request-ID", "my-request-id")
+		t.Assert(idClient.GetContent(ctx, "/id"), "my-request-id")
+		t.Assert(idClient.GetContent(ctx, "/proxy"), "my-request-id")
+
+		// Invalid one is replaced.
+		invalidClient := client.Clone().SetHeader("X-Request-ID", strings.Repeat("a", 200))
+		id = invalidClient.GetContent(ctx, "/id")
+		t.AssertNE(id, "")
+		t.AssertNE(id, strings.Repeat("a", 200))
+
+		// Error response.
+		j, err := gjson.LoadContent(idClient.GetContent(ctx, "/api/error"))
+		t.AssertNil(err)
+		t.Assert(j.Get("message"), "error")
+		t.Assert(j.Get("requestId"), "my-request-id")
+	})
+}
+
+func Test_RequestId_Disabled(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/id", func(r *ghttp.Request) {
+		r.Response.Write(r.GetRequestId())
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		client.SetHeader("X-Request-ID", "my-request-id")
+		resp, err := client.Get(ctx, "/id")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "")
+		t.Assert(resp.Header.Get("X-Request-ID"), "")
+		resp.Close()
+	})
+}
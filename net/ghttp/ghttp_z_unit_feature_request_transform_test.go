@@ -0,0 +1,85 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Params_Transform(t *testing.T) {
+	ghttp.RegisterTransform("reverse", func(value string) string {
+		runes := []rune(value)
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes)
+	})
+	type User struct {
+		Name    string   `tf:"trim|default:guest"`
+		Email   string   `tf:"trim|lower" v:"required|email"`
+		Content string   `transform:"striptags|collapse"`
+		Title   string   `tf:"html"`
+		Tags    []string `tf:"trim|upper"`
+		Code    string   `tf:"reverse"`
+	}
+	s := g.Server(guid.S())
+	s.BindHandler("/user", func(r *ghttp.Request) {
+		var user *User
+		if err := r.Parse(&user); err != nil {
+			r.Response.Write(err.Error())
+			return
+		}
+		r.Response.WriteJson(user)
+	})
+	s.BindHandler("/query", func(r *ghttp.Request) {
+		var user *User
+		if err := r.ParseQuery(&user); err != nil {
+			r.Response.Write(err.Error())
+			return
+		}
+		r.Response.Write(user.Name, ",", user.Email)
+	})
+	s.BindHandler("/invalid", func(r *ghttp.Request) {
+		var req struct {
+			Name string `tf:"unknown"`
+		}
+		if err := r.Parse(&req); err != nil {
+			r.Response.Write(err.Error())
+		}
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(client.ContentJson().PostContent(ctx, "/user", g.Map{
+			"name":    "  ",
+			"email":   " John@Example.COM ",
+			"content": "<p>hello   <b>world</b></p> ",
+			"title":   "<script>",
+			"tags":    g.Slice{" a ", "b "},
+			"code":    "abc",
+		}), `{"Name":"guest","Email":"john@example.com","Content":"hello world","Title":"\u0026lt;script\u0026gt;","Tags":["A","B"],"Code":"cba"}`)
+
+		// Transformed before validation.
+		t.Assert(client.GetContent(ctx, "/query?name=%20john%20&email=%20JOHN@EXAMPLE.COM"), "john,john@example.com")
+		t.Assert(strings.Contains(client.GetContent(ctx, "/query?email=%20%20"), "Email"), true)
+
+		t.Assert(client.GetContent(ctx, "/invalid?name=john"), `invalid transformation rule "unknown"`)
+	})
+}
@@ -0,0 +1,215 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/encoding/gmsgpack"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+type testEnvelopeReq struct {
+	g.Meta `path:"/envelope" method:"get"`
+	Name   string
+}
+
+type testEnvelopeRawReq struct {
+	g.Meta `path:"/envelope-raw" method:"get" envelope:"test-raw"`
+	Name   string
+}
+
+type testEnvelopeRes struct {
+	Name string `json:"name"`
+}
+
+type testEnvelopeController struct{}
+
+func (testEnvelopeController) Envelope(ctx context.Context, req *testEnvelopeReq) (res *testEnvelopeRes, err error) {
+	if req.Name == "" {
+		return nil, gerror.New("name required")
+	}
+	return &testEnvelopeRes{Name: req.Name}, nil
+}
+
+func (testEnvelopeController) EnvelopeRaw(ctx context.Context, req *testEnvelopeRawReq) (res *testEnvelopeRes, err error) {
+	return &testEnvelopeRes{Name: req.Name}, nil
+}
+
+func Test_ResponseEnvelope_Negotiation(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.Bind(testEnvelopeController{})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// JSON in default.
+		resp, err := client.Get(ctx, "/envelope?name=john")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Type"), "application/json")
+		t.Assert(resp.ReadAllString(), `{"code":0,"message":"","data":{"name":"john"}}`)
+		resp.Close()
+
+		// XML.
+		resp, err = client.Clone().SetHeader("Accept", "application/xml").Get(ctx, "/envelope?name=john")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Type"), "text/xml")
+		t.Assert(resp.Header.Get("Vary"), "Accept")
+		j, err := gjson.LoadContent(resp.ReadAll())
+		t.AssertNil(err)
+		t.Assert(j.Get("doc.data.name"), "john")
+		resp.Close()
+
+		// JSON is preferred if it is acceptable, like the requests of browsers.
+		resp, err = client.Clone().
+			SetHeader("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8").
+			Get(ctx, "/envelope?name=john")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Type"), "application/json")
+		t.Assert(resp.ReadAllString(), `{"code":0,"message":"","data":{"name":"john"}}`)
+		resp.Close()
+
+		// MessagePack with quality, JSON is not acceptable for quality 0.
+		resp, err = client.Clone().
+			SetHeader("Accept", "application/json;q=0, application/xml;q=0.5, application/msgpack").
+			Get(ctx, "/envelope")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get("Content-Type"), "application/msgpack")
+		v, err := gmsgpack.Decode(resp.ReadAll())
+		t.AssertNil(err)
+		m := g.Map(v.(map[string]interface{}))
+		t.Assert(m["code"], 50)
+		t.Assert(m["message"], "name required")
+		resp.Close()
+	})
+}
+
+func Test_ResponseEnvelope_Override(t *testing.T) {
+	ghttp.RegisterResponseEnvelope("test-raw", ghttp.ResponseEnvelopeFunc(
+		func(r *ghttp.Request, res interface{}, err error) interface{} {
+			return res
+		},
+	))
+	s := g.Server(guid.S())
+	s.SetResponseEnvelope(ghttp.ResponseEnvelopeFunc(func(r *ghttp.Request, res interface{}, err error) interface{} {
+		if err != nil {
+			return g.Map{"ok": false, "error": err.Error()}
+		}
+		return g.Map{"ok": true, "result": res}
+	}))
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.Bind(testEnvelopeController{})
+	})
+	s.Group("/default", func(group *ghttp.RouterGroup) {
+		group.Middleware(
+			ghttp.MiddlewareHandlerResponse,
+			ghttp.MiddlewareResponseEnvelope(ghttp.DefaultResponseEnvelope),
+		)
+		group.Bind(testEnvelopeController{})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Global.
+		t.Assert(client.GetContent(ctx, "/envelope?name=john"), `{"ok":true,"result":{"name":"john"}}`)
+		t.Assert(client.GetContent(ctx, "/envelope"), `{"error":"name required","ok":false}`)
+
+		// Route meta tag.
+		t.Assert(client.GetContent(ctx, "/envelope-raw?name=john"), `{"name":"john"}`)
+
+		// Group middleware.
+		t.Assert(client.GetContent(ctx, "/default/envelope?name=john"), `{"code":0,"message":"","data":{"name":"john"}}`)
+	})
+}
+
+func Test_ResponseEnvelope_HttpStatus(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.GET("/not-found", func(r *ghttp.Request) {
+			r.SetError(gerror.NewCode(gcode.CodeNotFound, "user not found"))
+		})
+		group.GET("/busy", func(r *ghttp.Request) {
+			r.SetError(gerror.NewCode(gcode.CodeServerBusy, "busy"))
+		})
+		group.GET("/business", func(r *ghttp.Request) {
+			r.SetError(gerror.NewCode(gcode.New(10001, "Insufficient Balance", nil), "balance"))
+		})
+		group.GET("/custom-status", func(r *ghttp.Request) {
+			r.Response.WriteHeader(http.StatusConflict)
+			r.SetError(gerror.NewCode(gcode.CodeNotFound, "conflict"))
+		})
+		group.Bind(testEnvelopeController{})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Get(ctx, "/envelope?name=john")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		resp.Close()
+
+		// Error without code.
+		resp, err = client.Get(ctx, "/envelope")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusInternalServerError)
+		t.Assert(gjson.New(resp.ReadAll()).Get("code"), gcode.CodeInternalError.Code())
+		resp.Close()
+
+		resp, err = client.Get(ctx, "/not-found")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNotFound)
+		t.Assert(gjson.New(resp.ReadAll()).Get("code"), gcode.CodeNotFound.Code())
+		resp.Close()
+
+		resp, err = client.Get(ctx, "/busy")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		resp.Close()
+
+		// Business code without mapping.
+		resp, err = client.Get(ctx, "/business")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(gjson.New(resp.ReadAll()).Get("code"), 10001)
+		resp.Close()
+
+		// Status set by handler is kept.
+		resp, err = client.Get(ctx, "/custom-status")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusConflict)
+		resp.Close()
+	})
+}
@@ -248,3 +248,27 @@ func Test_Issue1708(t *testing.T) {
 		)
 	})
 }
+
+func Test_Router_Handler_Strict_ErrorRedact(t *testing.T) {
+	type TestReq struct{}
+	type TestRes struct{}
+	s := g.Server(guid.S())
+	s.Use(ghttp.MiddlewareHandlerResponse)
+	s.BindHandler("/test/error", func(ctx context.Context, req *TestReq) (res *TestRes, err error) {
+		return nil, gerror.New("open /home/john/app/config.yaml: no such file")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	gerror.AddRedactFunc(gerror.RedactFilePath)
+	defer gerror.ClearRedactFuncs()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(client.GetContent(ctx, "/test/error"), `{"code":50,"message":"open config.yaml: no such file","data":null}`)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/net/gtcp"
+	"github.com/gogf/gf/v2/os/gsession"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/util/guid"
 )
@@ -191,3 +192,66 @@ func Test_Session_Custom_Id(t *testing.T) {
 		t.Assert(client.GetContent(ctx, "/value"), value)
 	})
 }
+
+func Test_Session_RememberMe(t *testing.T) {
+	var (
+		rememberMe = gsession.NewRememberMe()
+		cookieName = rememberMe.CookieName()
+	)
+	s := g.Server(guid.S())
+	s.Use(ghttp.MiddlewareRememberMe(rememberMe))
+	s.BindHandler("/login", func(r *ghttp.Request) {
+		r.Session.Set(rememberMe.SessionKey(), "john")
+		if err := r.IssueRememberMe(rememberMe, "john", g.Map{"role": "admin"}); err != nil {
+			r.Response.Write(err.Error())
+		}
+	})
+	s.BindHandler("/user", func(r *ghttp.Request) {
+		r.Response.Write(r.Session.MustGet(rememberMe.SessionKey()), r.Session.MustGet("role"))
+	})
+	s.BindHandler("/logout", func(r *ghttp.Request) {
+		r.Session.RemoveAll()
+		if err := r.RevokeRememberMe(rememberMe); err != nil {
+			r.Response.Write(err.Error())
+		}
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+		client := g.Client()
+		client.SetBrowserMode(true)
+		client.SetPrefix(prefix)
+		r, err := client.Get(ctx, "/login")
+		t.AssertNil(err)
+		token := r.GetCookie(cookieName)
+		r.Close()
+		t.AssertNE(token, "")
+		t.Assert(client.GetContent(ctx, "/user"), "john")
+
+		// New client without session, which is restored by the token.
+		client2 := g.Client().SetPrefix(prefix).SetCookie(cookieName, token)
+		r, err = client2.Get(ctx, "/user")
+		t.AssertNil(err)
+		t.Assert(r.ReadAllString(), "johnadmin")
+		newToken := r.GetCookie(cookieName)
+		r.Close()
+		t.AssertNE(newToken, "")
+		t.AssertNE(newToken, token)
+
+		// The rotated token is reusable once.
+		client3 := g.Client().SetPrefix(prefix).SetCookie(cookieName, newToken)
+		t.Assert(client3.GetContent(ctx, "/user"), "johnadmin")
+
+		// The used token is rejected.
+		client4 := g.Client().SetPrefix(prefix).SetCookie(cookieName, token)
+		t.Assert(client4.GetContent(ctx, "/user"), "")
+
+		// Logout revokes the token.
+		t.Assert(client.GetContent(ctx, "/logout"), "")
+		t.Assert(client.GetContent(ctx, "/user"), "")
+	})
+}
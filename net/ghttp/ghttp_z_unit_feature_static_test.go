@@ -272,3 +272,131 @@ func Test_Static_Rewrite(t *testing.T) {
 		t.Assert(client.GetContent(ctx, "/my-test2"), "test2")
 	})
 }
+
+func Test_Static_IndexFolderTemplate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := g.Server(guid.S())
+		path := fmt.Sprintf(`%s/ghttp/static/test/%s`, gfile.Temp(), guid.S())
+		defer gfile.Remove(path)
+		gfile.PutContents(path+"/root/sub/a.txt", "a")
+		gfile.PutContents(path+"/root/b.txt", "b")
+		gfile.PutContents(
+			path+"/index.tpl",
+			`{{.Path}}|{{.Parent}}|{{range .Files}}{{.Name}},{{.Href}},{{.IsDir}};{{end}}`,
+		)
+		s.SetServerRoot(path + "/root")
+		s.SetIndexFolder(true)
+		s.SetIndexFolderTemplate(path + "/index.tpl")
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(client.GetContent(ctx, "/"), "/||sub/,/sub/,true;b.txt,/b.txt,false;")
+		t.Assert(client.GetContent(ctx, "/sub"), "/sub|/|a.txt,/sub/a.txt,false;")
+	})
+	// The file names are escaped.
+	gtest.C(t, func(t *gtest.T) {
+		s := g.Server(guid.S())
+		path := fmt.Sprintf(`%s/ghttp/static/test/%s`, gfile.Temp(), guid.S())
+		defer gfile.Remove(path)
+		gfile.PutContents(path+`/root/<img src=x onerror="alert(1)">#?.txt`, "a")
+		gfile.PutContents(
+			path+"/index.tpl",
+			`{{range .Files}}<a href="{{.Href}}">{{.Name}}</a>{{end}}`,
+		)
+		s.SetServerRoot(path + "/root")
+		s.SetIndexFolder(true)
+		s.SetIndexFolderTemplate(path + "/index.tpl")
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(
+			client.GetContent(ctx, "/"),
+			`<a href="/%3Cimg%20src=x%20onerror=%22alert%281%29%22%3E%23%3F.txt">&lt;img src=x onerror=&#34;alert(1)&#34;&gt;#?.txt</a>`,
+		)
+	})
+}
+
+func Test_Static_Precompressed(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := g.Server(guid.S())
+		path := fmt.Sprintf(`%s/ghttp/static/test/%s`, gfile.Temp(), guid.S())
+		defer gfile.Remove(path)
+		gfile.PutContents(path+"/app.js", "raw")
+		gfile.PutContents(path+"/app.js.gz", "gzip-content")
+		gfile.PutContents(path+"/app.js.br", "br-content")
+		gfile.PutContents(path+"/style.css", "raw-css")
+		gfile.PutContents(path+"/style.css.gz", "gzip-css")
+		s.SetServerRoot(path)
+		s.SetStaticPrecompressed(true)
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+		prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+
+		// Not accepted.
+		t.Assert(g.Client().SetHeader("Accept-Encoding", "identity").GetContent(ctx, prefix+"/app.js"), "raw")
+
+		// Brotli is preferred.
+		resp, err := g.Client().SetHeader("Accept-Encoding", "gzip, br").Get(ctx, prefix+"/app.js")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "br-content")
+		t.Assert(resp.Header.Get("Content-Encoding"), "br")
+		t.Assert(gstr.Contains(resp.Header.Get("Content-Type"), "javascript"), true)
+		t.Assert(resp.Header.Get("Vary"), "Accept-Encoding")
+		resp.Close()
+
+		// Brotli is refused by q=0.
+		resp, err = g.Client().SetHeader("Accept-Encoding", "gzip, br;q=0").Get(ctx, prefix+"/app.js")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "gzip-content")
+		t.Assert(resp.Header.Get("Content-Encoding"), "gzip")
+		resp.Close()
+
+		// Only gzip sibling exists.
+		resp, err = g.Client().SetHeader("Accept-Encoding", "br, gzip").Get(ctx, prefix+"/style.css")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "gzip-css")
+		t.Assert(resp.Header.Get("Content-Encoding"), "gzip")
+		t.Assert(gstr.Contains(resp.Header.Get("Content-Type"), "text/css"), true)
+		resp.Close()
+	})
+}
+
+func Test_Static_MultiRange(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := g.Server(guid.S())
+		path := fmt.Sprintf(`%s/ghttp/static/test/%s`, gfile.Temp(), guid.S())
+		defer gfile.Remove(path)
+		gfile.PutContents(path+"/data.txt", "0123456789")
+		s.SetServerRoot(path)
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+		prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+
+		resp, err := g.Client().SetHeader("Range", "bytes=2-4").Get(ctx, prefix+"/data.txt")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 206)
+		t.Assert(resp.ReadAllString(), "234")
+		resp.Close()
+
+		resp, err = g.Client().SetHeader("Range", "bytes=0-1,8-9").Get(ctx, prefix+"/data.txt")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 206)
+		t.Assert(gstr.HasPrefix(resp.Header.Get("Content-Type"), "multipart/byteranges"), true)
+		content := resp.ReadAllString()
+		t.Assert(gstr.Contains(content, "01"), true)
+		t.Assert(gstr.Contains(content, "89"), true)
+		resp.Close()
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Server_TracingSampling(t *testing.T) {
+	var (
+		recorder         = tracetest.NewSpanRecorder()
+		originalProvider = otel.GetTracerProvider()
+		serverSpans      = func() []sdkTrace.ReadOnlySpan {
+			spans := make([]sdkTrace.ReadOnlySpan, 0)
+			for _, span := range recorder.Ended() {
+				if span.SpanKind() == trace.SpanKindServer {
+					spans = append(spans, span)
+				}
+			}
+			return spans
+		}
+	)
+	otel.SetTracerProvider(sdkTrace.NewTracerProvider(
+		sdkTrace.WithSampler(gtrace.GetSampler()),
+		sdkTrace.WithSpanProcessor(recorder),
+	))
+	gtrace.SetSampler(gtrace.NewRatioSampler(0))
+	defer func() {
+		otel.SetTracerProvider(originalProvider)
+		gtrace.SetSampler(nil)
+	}()
+
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		handler := func(r *ghttp.Request) {
+			r.Response.Write(trace.SpanContextFromContext(r.Context()).IsSampled())
+		}
+		group.ALL("/user", handler)
+		group.ALL("/admin/user", handler)
+		group.ALL("/health", handler)
+		group.ALL("/error", func(r *ghttp.Request) {
+			panic(gerror.New("error"))
+		})
+	})
+	s.SetTracingSampling(ghttp.TracingSamplingOption{
+		AlwaysPaths: []string{"/admin/*"},
+		NeverPaths:  []string{"/health"},
+		AlwaysError: true,
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(client.GetContent(ctx, "/user"), "false")
+		t.Assert(client.GetContent(ctx, "/admin/user"), "true")
+		t.Assert(len(serverSpans()), 1)
+
+		gtrace.SetSampler(gtrace.NewRatioSampler(1))
+		t.Assert(client.GetContent(ctx, "/user"), "true")
+		t.Assert(client.GetContent(ctx, "/health"), "false")
+		t.Assert(len(serverSpans()), 2)
+
+		// Failed request is recorded.
+		gtrace.SetSampler(gtrace.NewRatioSampler(0))
+		resp, err := client.Get(ctx, "/error")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 500)
+		resp.Close()
+		time.Sleep(100 * time.Millisecond)
+		spans := serverSpans()
+		t.Assert(len(spans), 3)
+		t.Assert(spans[2].SpanContext().IsSampled(), true)
+		t.Assert(spans[2].Name(), "/error")
+	})
+}
@@ -0,0 +1,166 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Tus(t *testing.T) {
+	var (
+		dir       = gfile.Temp(gtime.TimestampNanoStr())
+		completed = gtype.NewString()
+	)
+	defer gfile.Remove(dir)
+	storage, err := ghttp.NewTusStorageFile(dir)
+	gtest.AssertNil(err)
+
+	s := g.Server(guid.S())
+	handler := ghttp.TusHandler(ghttp.TusOption{
+		BasePath: "/files",
+		Storage:  storage,
+		MaxSize:  100,
+		OnComplete: func(r *ghttp.Request, upload *ghttp.TusUpload) {
+			completed.Set(upload.Metadata["filename"] + ":" + gfile.GetContents(storage.FilePath(upload.Id)))
+		},
+	})
+	s.BindHandler("/files", handler)
+	s.BindHandler("/files/*", handler)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Options.
+		resp, err := client.Options(ctx, "/files")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNoContent)
+		t.Assert(resp.Header.Get("Tus-Version"), "1.0.0")
+		t.Assert(resp.Header.Get("Tus-Max-Size"), "100")
+		resp.Close()
+
+		// Missing Tus-Resumable.
+		resp, err = client.Post(ctx, "/files")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusPreconditionFailed)
+		resp.Close()
+
+		tusClient := client.Clone().SetHeader("Tus-Resumable", "1.0.0")
+
+		// Too large.
+		resp, err = tusClient.Clone().SetHeader("Upload-Length", "101").Post(ctx, "/files")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusRequestEntityTooLarge)
+		resp.Close()
+
+		// Creation, "filename" is "a.txt" in base64.
+		resp, err = tusClient.Clone().SetHeaderMap(g.MapStrStr{
+			"Upload-Length":   "10",
+			"Upload-Metadata": "filename YS50eHQ=",
+		}).Post(ctx, "/files")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusCreated)
+		t.AssertNE(resp.Header.Get("Upload-Expires"), "")
+		location := resp.Header.Get("Location")
+		t.AssertNE(location, "")
+		resp.Close()
+
+		// Append.
+		patchClient := tusClient.Clone().ContentType("application/offset+octet-stream")
+		resp, err = patchClient.Clone().SetHeader("Upload-Offset", "0").Patch(ctx, location, "hello")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNoContent)
+		t.Assert(resp.Header.Get("Upload-Offset"), "5")
+		resp.Close()
+
+		// Offset.
+		resp, err = tusClient.Head(ctx, location)
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.Header.Get("Upload-Offset"), "5")
+		t.Assert(resp.Header.Get("Upload-Length"), "10")
+		t.Assert(resp.Header.Get("Upload-Metadata"), "filename YS50eHQ=")
+		resp.Close()
+
+		// Mismatched offset.
+		resp, err = patchClient.Clone().SetHeader("Upload-Offset", "0").Patch(ctx, location, "world")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusConflict)
+		resp.Close()
+
+		// Resume to complete.
+		t.Assert(completed.Val(), "")
+		resp, err = patchClient.Clone().SetHeader("Upload-Offset", "5").Patch(ctx, location, "world")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNoContent)
+		t.Assert(resp.Header.Get("Upload-Offset"), "10")
+		resp.Close()
+		t.Assert(completed.Val(), "a.txt:helloworld")
+
+		// Termination.
+		resp, err = tusClient.Delete(ctx, location)
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNoContent)
+		resp.Close()
+		resp, err = tusClient.Head(ctx, location)
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNotFound)
+		resp.Close()
+		t.Assert(gfile.Exists(storage.FilePath(gfile.Basename(location))), false)
+	})
+}
+
+func Test_Tus_Expiration(t *testing.T) {
+	dir := gfile.Temp(gtime.TimestampNanoStr())
+	defer gfile.Remove(dir)
+	storage, err := ghttp.NewTusStorageFile(dir)
+	gtest.AssertNil(err)
+
+	s := g.Server(guid.S())
+	handler := ghttp.TusHandler(ghttp.TusOption{
+		BasePath:   "/files",
+		Storage:    storage,
+		Expiration: 500 * time.Millisecond,
+	})
+	s.BindHandler("/files", handler)
+	s.BindHandler("/files/*", handler)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().SetHeader("Tus-Resumable", "1.0.0")
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Clone().SetHeader("Upload-Length", "10").Post(ctx, "/files")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusCreated)
+		location := resp.Header.Get("Location")
+		resp.Close()
+
+		time.Sleep(time.Second)
+		resp, err = client.Head(ctx, location)
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusNotFound)
+		resp.Close()
+		t.Assert(gfile.Exists(storage.FilePath(gfile.Basename(location))), false)
+	})
+}
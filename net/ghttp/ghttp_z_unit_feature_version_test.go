@@ -0,0 +1,38 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/frame/g"
+	. "github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func TestServer_EnableVersion(t *testing.T) {
+	C(t, func(t *T) {
+		s := g.Server(guid.S())
+		s.EnableVersion()
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		content := client.GetContent(ctx, "/version")
+		Assert(gstr.Contains(content, `"goFrame":"`+gf.VERSION+`"`), true)
+		Assert(gstr.Contains(content, `"golang":"`+runtime.Version()+`"`), true)
+		Assert(gstr.Contains(content, `"data"`), false)
+	})
+}
@@ -0,0 +1,132 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+	"github.com/gorilla/websocket"
+)
+
+// wsHubMemoryBroker is the in-memory WsHubBroker connecting the hubs in the same process.
+type wsHubMemoryBroker struct {
+	mu       sync.RWMutex
+	handlers []func(message *ghttp.WsHubMessage)
+}
+
+func (b *wsHubMemoryBroker) Publish(ctx context.Context, message *ghttp.WsHubMessage) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, handler := range b.handlers {
+		handler(message)
+	}
+	return nil
+}
+
+func (b *wsHubMemoryBroker) Subscribe(ctx context.Context, handler func(message *ghttp.WsHubMessage)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+	return nil
+}
+
+func (b *wsHubMemoryBroker) Close(ctx context.Context) error {
+	return nil
+}
+
+func Test_WebSocket_Hub(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		broker  = &wsHubMemoryBroker{}
+		hubs    = make([]*ghttp.WsHub, 2)
+		servers = make([]*ghttp.Server, 2)
+	)
+	for i := range hubs {
+		hub, err := ghttp.NewWsHub(ghttp.WsHubOptions{
+			Broker: broker,
+			OnMessage: func(conn *ghttp.WsConn, msgType int, data []byte) {
+				conn.Join(string(data))
+				_ = conn.Send(msgType, []byte("joined "+string(data)))
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := g.Server(guid.S())
+		s.BindHandler("/ws", func(r *ghttp.Request) {
+			_ = hub.Serve(r, r.Get("user").String())
+		})
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		defer hub.Close(ctx)
+		hubs[i], servers[i] = hub, s
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	dial := func(t *gtest.T, port int, user string) *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://127.0.0.1:%d/ws?user=%s", port, user), nil)
+		t.AssertNil(err)
+		return conn
+	}
+	read := func(t *gtest.T, conn *websocket.Conn) string {
+		t.AssertNil(conn.SetReadDeadline(time.Now().Add(time.Second)))
+		_, data, err := conn.ReadMessage()
+		t.AssertNil(err)
+		return string(data)
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			john = dial(t, servers[0].GetListenedPort(), "john")
+			mary = dial(t, servers[1].GetListenedPort(), "mary")
+			anon = dial(t, servers[1].GetListenedPort(), "")
+		)
+		defer john.Close()
+		defer mary.Close()
+		defer anon.Close()
+
+		t.AssertNil(john.WriteMessage(websocket.TextMessage, []byte("room1")))
+		t.Assert(read(t, john), "joined room1")
+		t.AssertNil(mary.WriteMessage(websocket.TextMessage, []byte("room1")))
+		t.Assert(read(t, mary), "joined room1")
+		t.Assert(hubs[0].Count(), 1)
+		t.Assert(hubs[1].Count(), 2)
+		t.Assert(hubs[0].RoomCount("room1"), 1)
+		t.Assert(hubs[0].IsOnline("john"), true)
+		t.Assert(hubs[0].IsOnline("mary"), false)
+
+		// Room broadcast reaches the connections of other instance.
+		t.AssertNil(hubs[0].BroadcastRoom(ctx, "room1", ghttp.WsMsgText, []byte("hello room1")))
+		t.Assert(read(t, john), "hello room1")
+		t.Assert(read(t, mary), "hello room1")
+
+		// User message.
+		t.AssertNil(hubs[0].SendUser(ctx, "mary", ghttp.WsMsgText, []byte("hello mary")))
+		t.Assert(read(t, mary), "hello mary")
+
+		// Broadcast to all.
+		t.AssertNil(hubs[1].Broadcast(ctx, ghttp.WsMsgText, []byte("hello all")))
+		t.Assert(read(t, john), "hello all")
+		t.Assert(read(t, mary), "hello all")
+		t.Assert(read(t, anon), "hello all")
+
+		// Leave by closing.
+		t.AssertNil(john.Close())
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(hubs[0].Count(), 0)
+		t.Assert(hubs[0].RoomCount("room1"), 0)
+		t.Assert(hubs[0].Rooms(), []string{})
+	})
+}
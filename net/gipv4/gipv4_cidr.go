@@ -0,0 +1,81 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package gipv4
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// ParseCIDR parses `cidr` like "192.168.1.0/24" as IPv4 network.
+// A single ip address like "192.168.1.1" is also accepted, which is parsed as "192.168.1.1/32".
+func ParseCIDR(cidr string) (*net.IPNet, error) {
+	if !strings.Contains(cidr, "/") {
+		cidr += "/32"
+	}
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid CIDR "%s"`, cidr)
+	}
+	if ipNet.IP.To4() == nil {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid IPv4 CIDR "%s"`, cidr)
+	}
+	return ipNet, nil
+}
+
+// IsInCIDR checks whether `ip` is in the network of `cidr`.
+// Eg: IsInCIDR("192.168.1.1", "192.168.1.0/24") -> true
+func IsInCIDR(ip, cidr string) bool {
+	netIp := net.ParseIP(ip)
+	if netIp == nil {
+		return false
+	}
+	ipNet, err := ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	return ipNet.Contains(netIp)
+}
+
+// GetCIDRRange returns the first and last ip address of the network of `cidr`.
+// Eg: 192.168.1.0/24 -> 192.168.1.0, 192.168.1.255
+func GetCIDRRange(cidr string) (first, last string, err error) {
+	ipNet, err := ParseCIDR(cidr)
+	if err != nil {
+		return "", "", err
+	}
+	start, end := cidrRange(ipNet)
+	return Long2ip(start), Long2ip(end), nil
+}
+
+// IterateCIDR calls `f` with each ip address of the network of `cidr` in ascending order.
+// It stops iterating if `f` returns false.
+func IterateCIDR(cidr string, f func(ip string) bool) error {
+	ipNet, err := ParseCIDR(cidr)
+	if err != nil {
+		return err
+	}
+	start, end := cidrRange(ipNet)
+	for long := start; ; long++ {
+		if !f(Long2ip(long)) || long == end {
+			break
+		}
+	}
+	return nil
+}
+
+// cidrRange returns the first and last ip address of `ipNet` as uint32 integers.
+func cidrRange(ipNet *net.IPNet) (start, end uint32) {
+	start = binary.BigEndian.Uint32(ipNet.IP.To4())
+	mask := binary.BigEndian.Uint32(ipNet.Mask[len(ipNet.Mask)-net.IPv4len:])
+	return start, start | ^mask
+}
@@ -0,0 +1,158 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package gipv4
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// IpSet is a compiled ip address set, which matches ip address against lots of
+// ip addresses and CIDR networks in constant time using radix tree.
+// It supports both IPv4 and IPv6 addresses, and it is concurrent safe.
+//
+// It is usually used for allowlist/denylist filtering, eg: in ghttp middleware.
+type IpSet struct {
+	mu   sync.RWMutex
+	v4   *ipSetNode // Radix tree for IPv4 networks.
+	v6   *ipSetNode // Radix tree for IPv6 networks.
+	size int        // Count of added networks.
+}
+
+// ipSetNode is the node of binary radix tree, each level of which represents one bit of ip address.
+type ipSetNode struct {
+	children [2]*ipSetNode
+	end      bool // Whether a network ends at this node.
+}
+
+// NewIpSet creates and returns an ip set with given ip addresses or CIDR networks.
+// Eg: NewIpSet("127.0.0.1", "192.168.0.0/16", "fe80::/10")
+func NewIpSet(patterns ...string) (*IpSet, error) {
+	s := &IpSet{
+		v4: &ipSetNode{},
+		v6: &ipSetNode{},
+	}
+	if err := s.Add(patterns...); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add adds ip addresses or CIDR networks to the set.
+func (s *IpSet) Add(patterns ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		ip, ones, err := parseIpSetPattern(pattern)
+		if err != nil {
+			return err
+		}
+		root := s.v6
+		if len(ip) == net.IPv4len {
+			root = s.v4
+		}
+		node := root
+		for i := 0; i < ones && !node.end; i++ {
+			bit := ipBit(ip, i)
+			if node.children[bit] == nil {
+				node.children[bit] = &ipSetNode{}
+			}
+			node = node.children[bit]
+		}
+		if !node.end {
+			// The sub networks are covered by current network.
+			node.end = true
+			node.children = [2]*ipSetNode{}
+		}
+		s.size++
+	}
+	return nil
+}
+
+// Size returns the count of added ip addresses and CIDR networks.
+func (s *IpSet) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.size
+}
+
+// Contains checks whether `ip` is in the set.
+func (s *IpSet) Contains(ip string) bool {
+	return s.ContainsIP(net.ParseIP(ip))
+}
+
+// ContainsIP checks whether `ip` is in the set.
+func (s *IpSet) ContainsIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	root := s.v6
+	if v4 := ip.To4(); v4 != nil {
+		ip, root = v4, s.v4
+	} else if ip = ip.To16(); ip == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	node := root
+	for i := 0; node != nil; i++ {
+		if node.end {
+			return true
+		}
+		if i >= len(ip)*8 {
+			break
+		}
+		node = node.children[ipBit(ip, i)]
+	}
+	return false
+}
+
+// parseIpSetPattern parses ip address or CIDR network `pattern`,
+// and returns the ip in 4 bytes for IPv4 or 16 bytes for IPv6 with its prefix length.
+func parseIpSetPattern(pattern string) (ip net.IP, ones int, err error) {
+	if !strings.Contains(pattern, "/") {
+		if ip = net.ParseIP(pattern); ip == nil {
+			return nil, 0, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid ip address "%s"`, pattern)
+		}
+		if v4 := ip.To4(); v4 != nil {
+			return v4, net.IPv4len * 8, nil
+		}
+		return ip, net.IPv6len * 8, nil
+	}
+	_, ipNet, err := net.ParseCIDR(pattern)
+	if err != nil {
+		return nil, 0, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid CIDR "%s"`, pattern)
+	}
+	ones, bits := ipNet.Mask.Size()
+	if v4 := ipNet.IP.To4(); v4 != nil {
+		// The IPv4-mapped IPv6 network, eg: ::ffff:0.0.0.0/96, whose prefix length counts the 96 bits of prefix.
+		if bits == net.IPv6len*8 {
+			ones -= bits - net.IPv4len*8
+		}
+		if ones >= 0 && ones <= net.IPv4len*8 {
+			return v4, ones, nil
+		}
+	}
+	if ip = ipNet.IP.To16(); ip == nil || ones < 0 || ones > net.IPv6len*8 {
+		return nil, 0, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid CIDR "%s"`, pattern)
+	}
+	return ip, ones, nil
+}
+
+// ipBit returns the bit at position `i` of `ip`, from the most significant bit.
+func ipBit(ip net.IP, i int) int {
+	return int(ip[i/8]>>(7-uint(i%8))) & 1
+}
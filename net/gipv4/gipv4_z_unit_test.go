@@ -0,0 +1,103 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gipv4_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/net/gipv4"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_CIDR(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gipv4.ParseCIDR("192.168.1.0/24")
+		t.AssertNil(err)
+		_, err = gipv4.ParseCIDR("192.168.1.1")
+		t.AssertNil(err)
+		_, err = gipv4.ParseCIDR("fe80::/10")
+		t.AssertNE(err, nil)
+		_, err = gipv4.ParseCIDR("192.168.1.0/33")
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gipv4.IsInCIDR("192.168.1.1", "192.168.1.0/24"), true)
+		t.Assert(gipv4.IsInCIDR("192.168.2.1", "192.168.1.0/24"), false)
+		t.Assert(gipv4.IsInCIDR("192.168.1.1", "192.168.1.1"), true)
+		t.Assert(gipv4.IsInCIDR("invalid", "192.168.1.0/24"), false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		first, last, err := gipv4.GetCIDRRange("192.168.1.100/24")
+		t.AssertNil(err)
+		t.Assert(first, "192.168.1.0")
+		t.Assert(last, "192.168.1.255")
+
+		var ips []string
+		err = gipv4.IterateCIDR("10.0.0.0/30", func(ip string) bool {
+			ips = append(ips, ip)
+			return true
+		})
+		t.AssertNil(err)
+		t.Assert(ips, []string{"10.0.0.0", "10.0.0.1", "10.0.0.2", "10.0.0.3"})
+
+		ips = ips[:0]
+		err = gipv4.IterateCIDR("255.255.255.255/32", func(ip string) bool {
+			ips = append(ips, ip)
+			return true
+		})
+		t.AssertNil(err)
+		t.Assert(ips, []string{"255.255.255.255"})
+	})
+}
+
+func Test_IpSet(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		set, err := gipv4.NewIpSet("127.0.0.1", "192.168.0.0/16", "192.168.1.0/24", "fe80::/10")
+		t.AssertNil(err)
+		t.Assert(set.Size(), 4)
+		t.Assert(set.Contains("127.0.0.1"), true)
+		t.Assert(set.Contains("127.0.0.2"), false)
+		t.Assert(set.Contains("192.168.100.1"), true)
+		t.Assert(set.Contains("192.169.0.1"), false)
+		t.Assert(set.Contains("fe80::1"), true)
+		t.Assert(set.Contains("2001:db8::1"), false)
+		t.Assert(set.Contains("::ffff:127.0.0.1"), true)
+		t.Assert(set.Contains("invalid"), false)
+
+		t.AssertNil(set.Add("0.0.0.0/0"))
+		t.Assert(set.Contains("8.8.8.8"), true)
+		t.Assert(set.Contains("2001:db8::1"), false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gipv4.NewIpSet("192.168.0.0/99")
+		t.AssertNE(err, nil)
+		_, err = gipv4.NewIpSet("invalid")
+		t.AssertNE(err, nil)
+	})
+	// IPv4-mapped IPv6 network.
+	gtest.C(t, func(t *gtest.T) {
+		set, err := gipv4.NewIpSet("::ffff:0.0.0.0/96")
+		t.AssertNil(err)
+		t.Assert(set.Contains("8.8.8.8"), true)
+		t.Assert(set.Contains("2001:db8::1"), false)
+
+		set, err = gipv4.NewIpSet("::ffff:192.168.0.0/112")
+		t.AssertNil(err)
+		t.Assert(set.Contains("192.168.1.1"), true)
+		t.Assert(set.Contains("192.169.1.1"), false)
+
+		set, err = gipv4.NewIpSet("::ffff:192.168.1.1")
+		t.AssertNil(err)
+		t.Assert(set.Contains("192.168.1.1"), true)
+		t.Assert(set.Contains("192.168.1.2"), false)
+
+		set, err = gipv4.NewIpSet("::/80")
+		t.AssertNil(err)
+		t.Assert(set.Contains("::1"), true)
+		t.Assert(set.Contains("fe80::1"), false)
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gipv6
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// ParseCIDR parses `cidr` like "fe80::/10" as IPv6 network.
+// A single ip address like "fe80::1" is also accepted, which is parsed as "fe80::1/128".
+func ParseCIDR(cidr string) (*net.IPNet, error) {
+	if !strings.Contains(cidr, "/") {
+		cidr += "/128"
+	}
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid CIDR "%s"`, cidr)
+	}
+	if ipNet.IP.To4() != nil {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid IPv6 CIDR "%s"`, cidr)
+	}
+	return ipNet, nil
+}
+
+// IsInCIDR checks whether `ip` is in the network of `cidr`.
+// Eg: IsInCIDR("fe80::1", "fe80::/10") -> true
+func IsInCIDR(ip, cidr string) bool {
+	netIp := net.ParseIP(ip)
+	if netIp == nil {
+		return false
+	}
+	ipNet, err := ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	return ipNet.Contains(netIp)
+}
+
+// GetCIDRRange returns the first and last ip address of the network of `cidr`.
+// Eg: fe80::/120 -> fe80::, fe80::ff
+func GetCIDRRange(cidr string) (first, last string, err error) {
+	ipNet, err := ParseCIDR(cidr)
+	if err != nil {
+		return "", "", err
+	}
+	lastIp := make(net.IP, net.IPv6len)
+	for i := range ipNet.IP {
+		lastIp[i] = ipNet.IP[i] | ^ipNet.Mask[i]
+	}
+	return ipNet.IP.String(), lastIp.String(), nil
+}
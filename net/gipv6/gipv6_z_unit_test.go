@@ -0,0 +1,62 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gipv6_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/net/gipv6"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_ParseCIDR(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		ipNet, err := gipv6.ParseCIDR("fe80::/10")
+		t.AssertNil(err)
+		t.Assert(ipNet.String(), "fe80::/10")
+
+		ipNet, err = gipv6.ParseCIDR("fe80::1")
+		t.AssertNil(err)
+		t.Assert(ipNet.String(), "fe80::1/128")
+
+		_, err = gipv6.ParseCIDR("192.168.1.0/24")
+		t.AssertNE(err, nil)
+		_, err = gipv6.ParseCIDR("fe80::/129")
+		t.AssertNE(err, nil)
+		_, err = gipv6.ParseCIDR("invalid")
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_IsInCIDR(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gipv6.IsInCIDR("fe80::1", "fe80::/10"), true)
+		t.Assert(gipv6.IsInCIDR("febf::1", "fe80::/10"), true)
+		t.Assert(gipv6.IsInCIDR("fec0::1", "fe80::/10"), false)
+		t.Assert(gipv6.IsInCIDR("2001:db8::1", "2001:db8::1"), true)
+		t.Assert(gipv6.IsInCIDR("2001:db8::2", "2001:db8::1"), false)
+		t.Assert(gipv6.IsInCIDR("invalid", "fe80::/10"), false)
+		t.Assert(gipv6.IsInCIDR("fe80::1", "invalid"), false)
+	})
+}
+
+func Test_GetCIDRRange(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		first, last, err := gipv6.GetCIDRRange("fe80::/120")
+		t.AssertNil(err)
+		t.Assert(first, "fe80::")
+		t.Assert(last, "fe80::ff")
+
+		first, last, err = gipv6.GetCIDRRange("2001:db8::1")
+		t.AssertNil(err)
+		t.Assert(first, "2001:db8::1")
+		t.Assert(last, "2001:db8::1")
+
+		_, _, err = gipv6.GetCIDRRange("10.0.0.0/8")
+		t.AssertNE(err, nil)
+	})
+}
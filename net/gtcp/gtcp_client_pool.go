@@ -0,0 +1,370 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gtimer"
+)
+
+// ClientPool is a managed client connection pool for a TCP address, which limits the connections,
+// expires the idle and long-lived connections, retries dialing with backoff, and validates
+// the connections before reusing them.
+type ClientPool struct {
+	mu      sync.Mutex
+	option  ClientPoolOption
+	idle    []*ClientPoolConn    // Idle connections, the last one is the most recently used.
+	slots   chan struct{}        // Slots limiting the count of open connections, which is nil if no limit.
+	handoff chan *ClientPoolConn // Hands off the connection put back to the one waiting for slots.
+	done    chan struct{}        // Closed when the pool is closed.
+	open    int                  // Count of open connections, including the idle ones and the ones in use.
+	closed  bool                 // Whether the pool is closed.
+	entry   *gtimer.Entry        // Timer entry of the health checks.
+}
+
+// ClientPoolOption is the option for ClientPool.
+type ClientPoolOption struct {
+	Address        string                 // Address of the backend, like: "127.0.0.1:8999".
+	TLSConfig      *tls.Config            // TLS configuration, it dials TLS connections if it is given.
+	DialTimeout    time.Duration          // Timeout for dialing, default is 30s.
+	DialRetry      int                    // Retry count for dialing failure, it does not retry in default.
+	DialBackoff    time.Duration          // Initial backoff between dialing retries, which doubles after each retry, default is 100ms.
+	DialMaxBackoff time.Duration          // Max backoff between dialing retries, default is 3s.
+	MaxIdle        int                    // Max count of idle connections, default is 2. It keeps no idle connection if it is negative.
+	MaxActive      int                    // Max count of open connections, it is no limit if it is not given.
+	IdleTimeout    time.Duration          // Max duration of idle connections, default is 10s. It is no limit if it is negative.
+	MaxLifetime    time.Duration          // Max lifetime of connections, it is no limit if it is not given.
+	Validate       func(conn *Conn) error // Validates the idle connection before reusing it, which is closed if it returns error.
+	CheckInterval  time.Duration          // Interval of checking the idle connections in background, it is no checking if it is not given.
+}
+
+// ClientPoolConn is the connection retrieved from ClientPool, which is put back to the pool by Close.
+type ClientPoolConn struct {
+	*Conn                 // Underlying connection object.
+	pool      *ClientPool // Pool that the connection belongs to.
+	createdAt time.Time   // Creation time of the connection.
+	idleAt    time.Time   // Time the connection was put back to the pool.
+	broken    *gtype.Bool // Whether the connection failed reading or writing.
+	released  *gtype.Bool // Whether the connection was put back to the pool or closed.
+}
+
+// clientPoolNetConn wraps net.Conn marking the pool connection broken if it fails reading or writing.
+type clientPoolNetConn struct {
+	net.Conn
+	broken *gtype.Bool
+}
+
+const (
+	defaultClientPoolMaxIdle        = 2
+	defaultClientPoolDialBackoff    = 100 * time.Millisecond
+	defaultClientPoolDialMaxBackoff = 3 * time.Second
+)
+
+// NewClientPool creates and returns a managed client connection pool with `option`.
+func NewClientPool(option ClientPoolOption) (*ClientPool, error) {
+	if option.Address == "" {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `address cannot be empty`)
+	}
+	if option.MaxIdle == 0 {
+		option.MaxIdle = defaultClientPoolMaxIdle
+	}
+	if option.IdleTimeout == 0 {
+		option.IdleTimeout = defaultPoolExpire
+	}
+	if option.DialTimeout <= 0 {
+		option.DialTimeout = defaultConnTimeout
+	}
+	if option.DialBackoff <= 0 {
+		option.DialBackoff = defaultClientPoolDialBackoff
+	}
+	if option.DialMaxBackoff <= 0 {
+		option.DialMaxBackoff = defaultClientPoolDialMaxBackoff
+	}
+	pool := &ClientPool{
+		option:  option,
+		idle:    make([]*ClientPoolConn, 0),
+		handoff: make(chan *ClientPoolConn),
+		done:    make(chan struct{}),
+	}
+	if option.MaxActive > 0 {
+		pool.slots = make(chan struct{}, option.MaxActive)
+	}
+	if option.CheckInterval > 0 {
+		pool.entry = gtimer.AddSingleton(context.Background(), option.CheckInterval, pool.checkIdleConns)
+	}
+	return pool, nil
+}
+
+// Get retrieves an idle connection from the pool, or dials a new one if there's no idle connection.
+// It blocks until a connection is put back or closed if the count of open connections reaches MaxActive,
+// or until `ctx` is done.
+func (p *ClientPool) Get(ctx context.Context) (*ClientPoolConn, error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, gerror.NewCode(gcode.CodeInvalidOperation, `pool is closed`)
+		}
+		if len(p.idle) == 0 {
+			p.mu.Unlock()
+			break
+		}
+		conn := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		p.mu.Unlock()
+		if p.isExpired(conn, time.Now()) || !p.isValid(conn) {
+			_ = p.closeConn(conn)
+			continue
+		}
+		conn.released.Set(false)
+		return conn, nil
+	}
+	if p.slots != nil {
+		select {
+		case p.slots <- struct{}{}:
+		case conn := <-p.handoff:
+			conn.released.Set(false)
+			return conn, nil
+		case <-p.done:
+			return nil, gerror.NewCode(gcode.CodeInvalidOperation, `pool is closed`)
+		case <-ctx.Done():
+			return nil, gerror.WrapCode(gcode.CodeOperationFailed, ctx.Err(), `waiting for connection failed`)
+		}
+	}
+	conn, err := p.dial(ctx)
+	if err != nil {
+		p.releaseSlot()
+		return nil, err
+	}
+	p.mu.Lock()
+	p.open++
+	p.mu.Unlock()
+	return conn, nil
+}
+
+// Stats returns the count of idle connections and the count of all open connections.
+func (p *ClientPool) Stats() (idle, open int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.idle), p.open
+}
+
+// Close closes the pool and all its idle connections.
+// The connections in use are closed when they are put back.
+func (p *ClientPool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.done)
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+	if p.entry != nil {
+		p.entry.Close()
+	}
+	for _, conn := range idle {
+		_ = p.closeConn(conn)
+	}
+}
+
+// Close puts back the connection to the pool, or closes it if it is broken, expired,
+// or the pool has enough idle connections.
+func (c *ClientPoolConn) Close() error {
+	if !c.released.Cas(false, true) {
+		return nil
+	}
+	return c.pool.put(c)
+}
+
+// Discard closes the connection instead of putting it back to the pool,
+// which is usually used if the connection is in unknown protocol state.
+func (c *ClientPoolConn) Discard() error {
+	if !c.released.Cas(false, true) {
+		return nil
+	}
+	return c.pool.closeConn(c)
+}
+
+// Read implements net.Conn.Read, which marks the connection broken if it fails.
+func (c *clientPoolNetConn) Read(b []byte) (n int, err error) {
+	if n, err = c.Conn.Read(b); err != nil && !isTimeout(err) {
+		c.broken.Set(true)
+	}
+	return
+}
+
+// Write implements net.Conn.Write, which marks the connection broken if it fails.
+func (c *clientPoolNetConn) Write(b []byte) (n int, err error) {
+	if n, err = c.Conn.Write(b); err != nil && !isTimeout(err) {
+		c.broken.Set(true)
+	}
+	return
+}
+
+// put puts back `conn` to the pool or closes it.
+func (p *ClientPool) put(conn *ClientPoolConn) error {
+	now := time.Now()
+	if conn.broken.Val() || p.isExpired(conn, now) {
+		return p.closeConn(conn)
+	}
+	// Resets the deadlines set by user.
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return p.closeConn(conn)
+	}
+	p.mu.Lock()
+	if !p.closed {
+		// Hands off directly if someone is waiting for connection.
+		select {
+		case p.handoff <- conn:
+			p.mu.Unlock()
+			return nil
+		default:
+		}
+	}
+	if p.closed || len(p.idle) >= p.option.MaxIdle {
+		p.mu.Unlock()
+		return p.closeConn(conn)
+	}
+	conn.idleAt = now
+	p.idle = append(p.idle, conn)
+	p.mu.Unlock()
+	return nil
+}
+
+// dial creates a new connection, it retries with backoff if it fails.
+func (p *ClientPool) dial(ctx context.Context) (*ClientPoolConn, error) {
+	var (
+		err     error
+		netConn net.Conn
+		backoff = p.option.DialBackoff
+	)
+	for i := 0; ; i++ {
+		if p.option.TLSConfig != nil {
+			netConn, err = NewNetConnTLS(p.option.Address, p.option.TLSConfig, p.option.DialTimeout)
+		} else {
+			netConn, err = NewNetConn(p.option.Address, p.option.DialTimeout)
+		}
+		if err == nil {
+			break
+		}
+		if i >= p.option.DialRetry {
+			return nil, err
+		}
+		intlog.Printf(ctx, `dial "%s" failed, retry after %s: %+v`, p.option.Address, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, gerror.WrapCode(gcode.CodeOperationFailed, ctx.Err(), `dialing canceled`)
+		}
+		if backoff *= 2; backoff > p.option.DialMaxBackoff {
+			backoff = p.option.DialMaxBackoff
+		}
+	}
+	broken := gtype.NewBool()
+	return &ClientPoolConn{
+		Conn:      NewConnByNetConn(&clientPoolNetConn{Conn: netConn, broken: broken}),
+		pool:      p,
+		createdAt: time.Now(),
+		broken:    broken,
+		released:  gtype.NewBool(),
+	}, nil
+}
+
+// closeConn closes `conn` and releases its slot.
+func (p *ClientPool) closeConn(conn *ClientPoolConn) error {
+	p.mu.Lock()
+	p.open--
+	p.mu.Unlock()
+	p.releaseSlot()
+	return conn.Conn.Close()
+}
+
+// releaseSlot releases a slot of open connections.
+func (p *ClientPool) releaseSlot() {
+	if p.slots != nil {
+		<-p.slots
+	}
+}
+
+// isExpired checks whether `conn` exceeds its idle timeout or lifetime at `now`.
+func (p *ClientPool) isExpired(conn *ClientPoolConn, now time.Time) bool {
+	if p.option.MaxLifetime > 0 && now.Sub(conn.createdAt) >= p.option.MaxLifetime {
+		return true
+	}
+	if p.option.IdleTimeout > 0 && !conn.idleAt.IsZero() && now.Sub(conn.idleAt) >= p.option.IdleTimeout {
+		return true
+	}
+	return false
+}
+
+// isValid checks `conn` using the Validate function of option.
+func (p *ClientPool) isValid(conn *ClientPoolConn) bool {
+	if p.option.Validate == nil {
+		return true
+	}
+	if err := p.option.Validate(conn.Conn); err != nil {
+		intlog.Printf(context.TODO(), `validate connection to "%s" failed: %+v`, p.option.Address, err)
+		return false
+	}
+	return !conn.broken.Val()
+}
+
+// checkIdleConns closes the expired and invalid idle connections, which is called periodically.
+func (p *ClientPool) checkIdleConns(ctx context.Context) {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = make([]*ClientPoolConn, 0, len(idle))
+	p.mu.Unlock()
+	var (
+		now   = time.Now()
+		valid = make([]*ClientPoolConn, 0, len(idle))
+	)
+	for _, conn := range idle {
+		if p.isExpired(conn, now) || !p.isValid(conn) {
+			_ = p.closeConn(conn)
+		} else {
+			valid = append(valid, conn)
+		}
+	}
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		for _, conn := range valid {
+			_ = p.closeConn(conn)
+		}
+		return
+	}
+	// The connections put back during checking are more recently used.
+	p.idle = append(valid, p.idle...)
+	var (
+		overflow []*ClientPoolConn
+		maxIdle  = p.option.MaxIdle
+	)
+	if maxIdle < 0 {
+		maxIdle = 0
+	}
+	if len(p.idle) > maxIdle {
+		overflow = p.idle[:len(p.idle)-maxIdle]
+		p.idle = p.idle[len(p.idle)-maxIdle:]
+	}
+	p.mu.Unlock()
+	for _, conn := range overflow {
+		_ = p.closeConn(conn)
+	}
+}
@@ -24,6 +24,8 @@ type Conn struct {
 	receiveDeadline   time.Time     // Timeout point for reading.
 	sendDeadline      time.Time     // Timeout point for writing.
 	receiveBufferWait time.Duration // Interval duration for reading buffer.
+	stats             *connStats    // Counters of the connection.
+	server            *Server       // Server accepting the connection, which is nil for client connection.
 }
 
 const (
@@ -68,6 +70,7 @@ func NewConnByNetConn(conn net.Conn) *Conn {
 		receiveDeadline:   time.Time{},
 		sendDeadline:      time.Time{},
 		receiveBufferWait: receiveAllWaitTimeout,
+		stats:             &connStats{},
 	}
 }
 
@@ -81,6 +84,7 @@ func (c *Conn) Send(data []byte, retry ...Retry) error {
 			}
 			// Still failed even after retrying.
 			if len(retry) == 0 || retry[0].Count == 0 {
+				c.stats.each(func(s *connStats) { s.addError() })
 				err = gerror.Wrap(err, `Write data failed`)
 				return err
 			}
@@ -92,6 +96,7 @@ func (c *Conn) Send(data []byte, retry ...Retry) error {
 				time.Sleep(retry[0].Interval)
 			}
 		} else {
+			c.stats.each(func(s *connStats) { s.addSent(len(data)) })
 			return nil
 		}
 	}
@@ -107,6 +112,12 @@ func (c *Conn) Send(data []byte, retry ...Retry) error {
 // 3. If length > 0, which means it blocks reading data from connection until length size was received.
 //    It is the most commonly used length value for data receiving.
 func (c *Conn) Recv(length int, retry ...Retry) ([]byte, error) {
+	data, err := c.doRecv(length, retry...)
+	return c.recordRecv(data, len(data) > 0, err)
+}
+
+// doRecv receives and returns data from the connection without counting the message.
+func (c *Conn) doRecv(length int, retry ...Retry) ([]byte, error) {
 	var (
 		err        error  // Reading error.
 		size       int    // Reading size.
@@ -130,6 +141,7 @@ func (c *Conn) Recv(length int, retry ...Retry) ([]byte, error) {
 		}
 		size, err = c.reader.Read(buffer[index:])
 		if size > 0 {
+			c.stats.each(func(s *connStats) { s.addReceived(size) })
 			index += size
 			if length > 0 {
 				// It reads til `length` size if `length` is specified.
@@ -193,7 +205,7 @@ func (c *Conn) RecvLine(retry ...Retry) ([]byte, error) {
 		data   = make([]byte, 0)
 	)
 	for {
-		buffer, err = c.Recv(1, retry...)
+		buffer, err = c.doRecv(1, retry...)
 		if len(buffer) > 0 {
 			if buffer[0] == '\n' {
 				data = append(data, buffer[:len(buffer)-1]...)
@@ -206,7 +218,7 @@ func (c *Conn) RecvLine(retry ...Retry) ([]byte, error) {
 			break
 		}
 	}
-	return data, err
+	return c.recordRecv(data, err == nil || len(data) > 0, err)
 }
 
 // RecvTill reads data from the connection until reads bytes `til`.
@@ -219,7 +231,7 @@ func (c *Conn) RecvTill(til []byte, retry ...Retry) ([]byte, error) {
 		length = len(til)
 	)
 	for {
-		buffer, err = c.Recv(1, retry...)
+		buffer, err = c.doRecv(1, retry...)
 		if len(buffer) > 0 {
 			if length > 0 &&
 				len(data) >= length-1 &&
@@ -235,7 +247,7 @@ func (c *Conn) RecvTill(til []byte, retry ...Retry) ([]byte, error) {
 			break
 		}
 	}
-	return data, err
+	return c.recordRecv(data, err == nil || len(data) > 0, err)
 }
 
 // RecvWithTimeout reads data from the connection with timeout.
@@ -103,9 +103,9 @@ func (c *Conn) RecvPkg(option ...PkgOption) (result []byte, err error) {
 		return nil, err
 	}
 	// Header field.
-	buffer, err = c.Recv(pkgOption.HeaderSize, pkgOption.Retry)
+	buffer, err = c.doRecv(pkgOption.HeaderSize, pkgOption.Retry)
 	if err != nil {
-		return nil, err
+		return c.recordRecv(nil, false, err)
 	}
 	switch pkgOption.HeaderSize {
 	case 1:
@@ -121,14 +121,17 @@ func (c *Conn) RecvPkg(option ...PkgOption) (result []byte, err error) {
 	// It here validates the size of the package.
 	// It clears the buffer and returns error immediately if it validates failed.
 	if length < 0 || length > pkgOption.MaxDataSize {
-		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid package size %d`, length)
+		return c.recordRecv(
+			nil, false, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid package size %d`, length),
+		)
 	}
 	// Empty package.
 	if length == 0 {
-		return nil, nil
+		return c.recordRecv(nil, true, nil)
 	}
 	// Data field.
-	return c.Recv(length, pkgOption.Retry)
+	result, err = c.doRecv(length, pkgOption.Retry)
+	return c.recordRecv(result, len(result) > 0, err)
 }
 
 // RecvPkgWithTimeout reads data from connection with timeout using simple package protocol.
@@ -0,0 +1,98 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimiter limits the rate of each key, like remote address, using token bucket.
+type rateLimiter struct {
+	mu        sync.Mutex
+	rate      float64                // Allowed count per second.
+	burst     float64                // Max tokens in bucket.
+	buckets   map[string]*rateBucket // Key to its token bucket.
+	lastSweep time.Time              // Last time removing the idle buckets.
+}
+
+// rateBucket is the token bucket of a key.
+type rateBucket struct {
+	tokens float64   // Available tokens.
+	last   time.Time // Last time refilling tokens.
+}
+
+const (
+	// rateLimiterSweepInterval is the interval removing the idle buckets, which are full of tokens.
+	rateLimiterSweepInterval = time.Minute
+)
+
+// SetRateLimit limits the received messages of each remote address to `rate` per second with bursts
+// of up to `burst` messages, which is shared by all connections from the same remote IP.
+// The receiving, like Conn.Recv and Conn.RecvPkg, returns error if the message exceeds the limit,
+// and the handler usually closes the connection for it.
+// It disables the rate limiting if `rate` <= 0, and the `burst` is `rate` if it is not greater than 0.
+//
+// It should be called before the server runs.
+func (s *Server) SetRateLimit(rate float64, burst int) {
+	if rate <= 0 {
+		s.limiter = nil
+		return
+	}
+	s.limiter = newRateLimiter(rate, burst)
+}
+
+// newRateLimiter creates and returns a rate limiter allowing `rate` per second with bursts of `burst`.
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	l := &rateLimiter{
+		rate:      rate,
+		burst:     float64(burst),
+		buckets:   make(map[string]*rateBucket),
+		lastSweep: time.Now(),
+	}
+	if l.burst <= 0 {
+		l.burst = math.Max(rate, 1)
+	}
+	return l
+}
+
+// Allow checks and returns whether `key` is allowed, which takes a token from its bucket.
+func (l *rateLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if now.Sub(l.lastSweep) >= rateLimiterSweepInterval {
+		l.sweep(now)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &rateBucket{
+			tokens: l.burst,
+			last:   now,
+		}
+		l.buckets[key] = b
+	} else {
+		b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sweep removes the buckets that would be refilled full, which behave the same as absent buckets.
+func (l *rateLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
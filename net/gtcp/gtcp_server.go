@@ -10,6 +10,7 @@ import (
 	"crypto/tls"
 	"net"
 	"sync"
+	"sync/atomic"
 
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -25,11 +26,14 @@ const (
 
 // Server is a TCP server.
 type Server struct {
-	mu        sync.Mutex   // Used for Server.listen concurrent safety.
-	listen    net.Listener // Listener.
-	address   string       // Server listening address.
-	handler   func(*Conn)  // Connection handler.
-	tlsConfig *tls.Config  // TLS configuration.
+	mu        sync.Mutex    // Used for Server.listen concurrent safety.
+	listen    net.Listener  // Listener.
+	address   string        // Server listening address.
+	handler   func(*Conn)   // Connection handler.
+	tlsConfig *tls.Config   // TLS configuration.
+	tlsCerts  *tlsCertStore // SNI certificates, which can be hot reloaded from disk.
+	stats     *connStats    // Counters of all accepted connections.
+	limiter   *rateLimiter  // Rate limiter of received messages for each remote address.
 }
 
 // Map for name to server, for singleton purpose.
@@ -52,8 +56,10 @@ func GetServer(name ...interface{}) *Server {
 // The parameter `name` is optional, which is used to specify the instance name of the server.
 func NewServer(address string, handler func(*Conn), name ...string) *Server {
 	s := &Server{
-		address: address,
-		handler: handler,
+		address:  address,
+		handler:  handler,
+		tlsCerts: newTLSCertStore(),
+		stats:    &connStats{},
 	}
 	if len(name) > 0 && name[0] != "" {
 		serverMapping.Set(name[0], s)
@@ -109,10 +115,11 @@ func (s *Server) SetTLSConfig(tlsConfig *tls.Config) {
 	s.tlsConfig = tlsConfig
 }
 
-// Close closes the listener and shutdowns the server.
+// Close closes the listener and shutdowns the server, which also disables the certificate hot reloading.
 func (s *Server) Close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.tlsCerts.Close()
 	if s.listen == nil {
 		return nil
 	}
@@ -125,10 +132,10 @@ func (s *Server) Run() (err error) {
 		err = gerror.NewCode(gcode.CodeMissingConfiguration, "start running failed: socket handler not defined")
 		return
 	}
-	if s.tlsConfig != nil {
+	if tlsConfig := s.getTLSConfig(); tlsConfig != nil {
 		// TLS Server
 		s.mu.Lock()
-		s.listen, err = tls.Listen("tcp", s.address, s.tlsConfig)
+		s.listen, err = tls.Listen("tcp", s.address, tlsConfig)
 		s.mu.Unlock()
 		if err != nil {
 			err = gerror.Wrapf(err, `tls.Listen failed for address "%s"`, s.address)
@@ -156,7 +163,18 @@ func (s *Server) Run() (err error) {
 			err = gerror.Wrapf(err, `Listener.Accept failed`)
 			return err
 		} else if conn != nil {
-			go s.handler(NewConnByNetConn(conn))
+			go s.handleConn(conn)
 		}
 	}
 }
+
+// handleConn handles the accepted connection `conn` with the handler, which counts it for the server.
+func (s *Server) handleConn(conn net.Conn) {
+	c := NewConnByNetConn(conn)
+	c.server = s
+	c.stats.parent = s.stats
+	atomic.AddUint64(&s.stats.connections, 1)
+	atomic.AddInt64(&s.stats.connectionsOpen, 1)
+	defer atomic.AddInt64(&s.stats.connectionsOpen, -1)
+	s.handler(c)
+}
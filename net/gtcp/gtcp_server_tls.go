@@ -0,0 +1,227 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gfsnotify"
+)
+
+// tlsCertStore manages certificates selected by SNI server name,
+// which can be reloaded from disk without dropping established connections.
+type tlsCertStore struct {
+	mu        sync.RWMutex
+	certs     *gmap.StrAnyMap // Server name(lower case) to *tlsCertEntry.
+	reload    bool            // Whether hot reloading from disk is enabled.
+	callbacks []int           // Callback ids registered to gfsnotify.
+}
+
+// tlsCertEntry is a loaded certificate with its source files.
+type tlsCertEntry struct {
+	CrtPath string           // Absolute path of certificate file.
+	KeyPath string           // Absolute path of key file.
+	Cert    *tls.Certificate // Loaded certificate.
+}
+
+func newTLSCertStore() *tlsCertStore {
+	return &tlsCertStore{
+		certs: gmap.NewStrAnyMap(true),
+	}
+}
+
+// AddTLSKeyCrt loads the certificate and key file and binds them to `serverName` for SNI selection.
+// The `serverName` can be an exact host name like "www.goframe.org", a wildcard name like "*.goframe.org",
+// or empty string, which specifies the default certificate if no server name matches.
+//
+// It also enables TLS for the server if it is not enabled yet.
+func (s *Server) AddTLSKeyCrt(serverName, crtFile, keyFile string) error {
+	if err := s.tlsCerts.Add(serverName, crtFile, keyFile); err != nil {
+		return err
+	}
+	if s.tlsConfig == nil {
+		s.tlsConfig = &tls.Config{
+			Time: time.Now,
+			Rand: rand.Reader,
+		}
+	}
+	return nil
+}
+
+// SetTLSCertReload enables or disables hot reloading of the certificates added by AddTLSKeyCrt.
+// If enabled, the certificate is reloaded once its certificate or key file changes on disk.
+// The established connections are not affected, only the new handshakes use the reloaded certificate.
+// Note that the reloading is disabled when the server is closed.
+func (s *Server) SetTLSCertReload(enabled bool) error {
+	return s.tlsCerts.SetReload(enabled)
+}
+
+// getTLSConfig returns the TLS configuration of server, which selects SNI certificates
+// from the certificate store if any certificate is added.
+func (s *Server) getTLSConfig() *tls.Config {
+	if s.tlsConfig == nil || s.tlsCerts.IsEmpty() || s.tlsConfig.GetCertificate != nil {
+		return s.tlsConfig
+	}
+	tlsConfig := s.tlsConfig.Clone()
+	tlsConfig.GetCertificate = s.tlsCerts.GetCertificate
+	return tlsConfig
+}
+
+// Add loads and binds certificate to `serverName`.
+func (store *tlsCertStore) Add(serverName, crtFile, keyFile string) error {
+	crtPath, err := gfile.Search(crtFile)
+	if err != nil {
+		return err
+	}
+	keyPath, err := gfile.Search(keyFile)
+	if err != nil {
+		return err
+	}
+	entry := &tlsCertEntry{
+		CrtPath: crtPath,
+		KeyPath: keyPath,
+	}
+	if err = entry.Load(); err != nil {
+		return err
+	}
+	store.certs.Set(strings.ToLower(serverName), entry)
+	store.mu.RLock()
+	reload := store.reload
+	store.mu.RUnlock()
+	if reload {
+		return store.watch(crtPath, keyPath)
+	}
+	return nil
+}
+
+// IsEmpty checks and returns whether no certificate is added.
+func (store *tlsCertStore) IsEmpty() bool {
+	return store.certs.IsEmpty()
+}
+
+// GetCertificate selects certificate for the client hello by server name,
+// which is used as tls.Config.GetCertificate.
+//
+// It returns nil certificate if no certificate matches, so the tls.Config.Certificates is used.
+func (store *tlsCertStore) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	name := strings.ToLower(strings.TrimSuffix(hello.ServerName, "."))
+	if v := store.certs.Get(name); v != nil {
+		return v.(*tlsCertEntry).Cert, nil
+	}
+	// Wildcard certificate matches only one level of subdomain.
+	if pos := strings.IndexByte(name, '.'); pos > 0 {
+		if v := store.certs.Get("*" + name[pos:]); v != nil {
+			return v.(*tlsCertEntry).Cert, nil
+		}
+	}
+	if v := store.certs.Get(""); v != nil {
+		return v.(*tlsCertEntry).Cert, nil
+	}
+	return nil, nil
+}
+
+// SetReload enables or disables hot reloading of certificates.
+func (store *tlsCertStore) SetReload(enabled bool) error {
+	store.mu.Lock()
+	if store.reload == enabled {
+		store.mu.Unlock()
+		return nil
+	}
+	store.reload = enabled
+	store.mu.Unlock()
+	if !enabled {
+		store.removeCallbacks()
+		return nil
+	}
+	var err error
+	store.certs.Iterator(func(k string, v interface{}) bool {
+		entry := v.(*tlsCertEntry)
+		err = store.watch(entry.CrtPath, entry.KeyPath)
+		return err == nil
+	})
+	return err
+}
+
+// Close disables hot reloading and removes all file watching callbacks of the store,
+// so that the reloading can be enabled again by SetReload.
+func (store *tlsCertStore) Close() {
+	store.mu.Lock()
+	store.reload = false
+	store.mu.Unlock()
+	store.removeCallbacks()
+}
+
+// removeCallbacks removes all file watching callbacks of the store.
+func (store *tlsCertStore) removeCallbacks() {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for _, id := range store.callbacks {
+		_ = gfsnotify.RemoveCallback(id)
+	}
+	store.callbacks = nil
+}
+
+// watch monitors the certificate files and reloads the certificates using them if changed.
+func (store *tlsCertStore) watch(paths ...string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for _, path := range paths {
+		callback, err := gfsnotify.Add(path, func(event *gfsnotify.Event) {
+			if event.IsWrite() || event.IsCreate() || event.IsRename() {
+				store.reloadByPath(event.Path)
+			}
+		}, false)
+		if err != nil {
+			return err
+		}
+		store.callbacks = append(store.callbacks, callback.Id)
+	}
+	return nil
+}
+
+// reloadByPath reloads all certificates that use file `path`.
+// The old certificate is kept if the reloading fails, for example the key file is not written yet.
+func (store *tlsCertStore) reloadByPath(path string) {
+	// It uses a copy of the map as it updates the map during iterating.
+	for k, v := range store.certs.Map() {
+		entry := v.(*tlsCertEntry)
+		if entry.CrtPath != path && entry.KeyPath != path {
+			continue
+		}
+		newEntry := &tlsCertEntry{
+			CrtPath: entry.CrtPath,
+			KeyPath: entry.KeyPath,
+		}
+		if err := newEntry.Load(); err != nil {
+			intlog.Errorf(context.TODO(), `reload TLS certificate failed: %+v`, err)
+			continue
+		}
+		store.certs.Set(k, newEntry)
+	}
+}
+
+// Load loads the certificate from files.
+func (entry *tlsCertEntry) Load() error {
+	crt, err := tls.LoadX509KeyPair(entry.CrtPath, entry.KeyPath)
+	if err != nil {
+		return gerror.Wrapf(err,
+			`tls.LoadX509KeyPair failed for certFile "%s" and keyFile "%s"`,
+			entry.CrtPath, entry.KeyPath,
+		)
+	}
+	entry.Cert = &crt
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp
+
+import (
+	"io"
+	"net"
+	"sync/atomic"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Stats is the snapshot of the counters of a connection or server.
+type Stats struct {
+	BytesReceived uint64 // Count of received bytes.
+	BytesSent     uint64 // Count of sent bytes.
+	MsgsReceived  uint64 // Count of received messages by Recv/RecvLine/RecvTill/RecvPkg, including the ones rejected by rate limiting.
+	MsgsSent      uint64 // Count of sent messages, which is counted by each succeeded call of Send/SendPkg.
+	Errors        uint64 // Count of failed receiving and sending, not including closing by remote side.
+	Limited       uint64 // Count of received messages rejected by rate limiting, see Server.SetRateLimit.
+}
+
+// ServerStats is the snapshot of the counters of a server.
+type ServerStats struct {
+	Stats                  // Counters of all connections accepted by the server.
+	Connections     uint64 // Count of accepted connections.
+	ConnectionsOpen int64  // Count of connections being handled.
+}
+
+// connStats holds the counters, which also adds the counters to its parent, like the server.
+type connStats struct {
+	bytesReceived   uint64
+	bytesSent       uint64
+	msgsReceived    uint64
+	msgsSent        uint64
+	errors          uint64
+	limited         uint64
+	connections     uint64
+	connectionsOpen int64
+	parent          *connStats
+}
+
+// Stats returns the snapshot of the counters of the connection.
+func (c *Conn) Stats() Stats {
+	return c.stats.Stats()
+}
+
+// Stats returns the snapshot of the counters of the server, which is the sum of all connections
+// accepted by the server.
+func (s *Server) Stats() ServerStats {
+	return ServerStats{
+		Stats:           s.stats.Stats(),
+		Connections:     atomic.LoadUint64(&s.stats.connections),
+		ConnectionsOpen: atomic.LoadInt64(&s.stats.connectionsOpen),
+	}
+}
+
+// recordRecv counts the receiving result and applies the rate limiting of server for the message.
+// The parameter `received` specifies whether a message is received.
+// It returns nil data with error if the message is rejected by rate limiting.
+func (c *Conn) recordRecv(data []byte, received bool, err error) ([]byte, error) {
+	if err != nil && err != io.EOF {
+		c.stats.each(func(s *connStats) { s.addError() })
+	}
+	if !received {
+		return data, err
+	}
+	c.stats.each(func(s *connStats) { s.addMsgReceived() })
+	if c.server == nil || c.server.limiter == nil {
+		return data, err
+	}
+	key := c.RemoteAddr().String()
+	if host, _, splitErr := net.SplitHostPort(key); splitErr == nil {
+		key = host
+	}
+	if !c.server.limiter.Allow(key) {
+		c.stats.each(func(s *connStats) { s.addLimited() })
+		return nil, gerror.NewCodef(gcode.CodeServerBusy, `rate limit exceeded for remote address "%s"`, key)
+	}
+	return data, err
+}
+
+// each calls `f` with the counters and its parents.
+func (s *connStats) each(f func(s *connStats)) {
+	for ; s != nil; s = s.parent {
+		f(s)
+	}
+}
+
+func (s *connStats) addReceived(size int) {
+	atomic.AddUint64(&s.bytesReceived, uint64(size))
+}
+
+func (s *connStats) addMsgReceived() {
+	atomic.AddUint64(&s.msgsReceived, 1)
+}
+
+func (s *connStats) addSent(size int) {
+	atomic.AddUint64(&s.bytesSent, uint64(size))
+	atomic.AddUint64(&s.msgsSent, 1)
+}
+
+func (s *connStats) addError() {
+	atomic.AddUint64(&s.errors, 1)
+}
+
+func (s *connStats) addLimited() {
+	atomic.AddUint64(&s.limited, 1)
+}
+
+// Stats returns the snapshot of the counters.
+func (s *connStats) Stats() Stats {
+	return Stats{
+		BytesReceived: atomic.LoadUint64(&s.bytesReceived),
+		BytesSent:     atomic.LoadUint64(&s.bytesSent),
+		MsgsReceived:  atomic.LoadUint64(&s.msgsReceived),
+		MsgsSent:      atomic.LoadUint64(&s.msgsSent),
+		Errors:        atomic.LoadUint64(&s.errors),
+		Limited:       atomic.LoadUint64(&s.limited),
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/net/gtcp"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_ClientPool_Basic(t *testing.T) {
+	p, _ := gtcp.GetFreePort()
+	s := gtcp.NewServer(fmt.Sprintf(`:%d`, p), func(conn *gtcp.Conn) {
+		defer conn.Close()
+		for {
+			data, err := conn.RecvPkg()
+			if err != nil {
+				break
+			}
+			conn.SendPkg(data)
+		}
+	})
+	go s.Run()
+	defer s.Close()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx      = context.TODO()
+			validate = gtype.NewInt()
+		)
+		pool, err := gtcp.NewClientPool(gtcp.ClientPoolOption{
+			Address: fmt.Sprintf("127.0.0.1:%d", p),
+			MaxIdle: 1,
+			Validate: func(conn *gtcp.Conn) error {
+				validate.Add(1)
+				return nil
+			},
+		})
+		t.AssertNil(err)
+		defer pool.Close()
+
+		conn1, err := pool.Get(ctx)
+		t.AssertNil(err)
+		result, err := conn1.SendRecvPkg([]byte("hello"))
+		t.AssertNil(err)
+		t.Assert(result, "hello")
+		conn2, err := pool.Get(ctx)
+		t.AssertNil(err)
+		t.Assert(conn1 != conn2, true)
+		idle, open := pool.Stats()
+		t.Assert(idle, 0)
+		t.Assert(open, 2)
+
+		// Only one idle connection is kept.
+		t.AssertNil(conn1.Close())
+		t.AssertNil(conn2.Close())
+		t.AssertNil(conn2.Close())
+		idle, open = pool.Stats()
+		t.Assert(idle, 1)
+		t.Assert(open, 1)
+
+		// Reuse.
+		conn3, err := pool.Get(ctx)
+		t.AssertNil(err)
+		t.Assert(conn3 == conn1, true)
+		t.Assert(validate.Val(), 1)
+		result, err = conn3.SendRecvPkg([]byte("world"))
+		t.AssertNil(err)
+		t.Assert(result, "world")
+
+		// Discard.
+		t.AssertNil(conn3.Discard())
+		idle, open = pool.Stats()
+		t.Assert(idle, 0)
+		t.Assert(open, 0)
+
+		pool.Close()
+		_, err = pool.Get(ctx)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_ClientPool_Limit(t *testing.T) {
+	p, _ := gtcp.GetFreePort()
+	s := gtcp.NewServer(fmt.Sprintf(`:%d`, p), func(conn *gtcp.Conn) {
+		defer conn.Close()
+		for {
+			if _, err := conn.RecvPkg(); err != nil {
+				break
+			}
+		}
+	})
+	go s.Run()
+	defer s.Close()
+	time.Sleep(100 * time.Millisecond)
+	// Max active.
+	gtest.C(t, func(t *gtest.T) {
+		pool, err := gtcp.NewClientPool(gtcp.ClientPoolOption{
+			Address:   fmt.Sprintf("127.0.0.1:%d", p),
+			MaxActive: 1,
+		})
+		t.AssertNil(err)
+		defer pool.Close()
+
+		conn, err := pool.Get(context.TODO())
+		t.AssertNil(err)
+		ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+		defer cancel()
+		_, err = pool.Get(ctx)
+		t.AssertNE(err, nil)
+
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			conn.Close()
+		}()
+		conn2, err := pool.Get(context.TODO())
+		t.AssertNil(err)
+		t.Assert(conn2 == conn, true)
+	})
+	// Max lifetime and validation.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx    = context.TODO()
+			failed = gtype.NewBool()
+		)
+		pool, err := gtcp.NewClientPool(gtcp.ClientPoolOption{
+			Address:     fmt.Sprintf("127.0.0.1:%d", p),
+			MaxLifetime: 200 * time.Millisecond,
+			Validate: func(conn *gtcp.Conn) error {
+				if failed.Val() {
+					return errors.New("invalid")
+				}
+				return nil
+			},
+		})
+		t.AssertNil(err)
+		defer pool.Close()
+
+		conn1, err := pool.Get(ctx)
+		t.AssertNil(err)
+		t.AssertNil(conn1.Close())
+		failed.Set(true)
+		conn2, err := pool.Get(ctx)
+		t.AssertNil(err)
+		t.Assert(conn2 == conn1, false)
+		failed.Set(false)
+
+		t.AssertNil(conn2.Close())
+		time.Sleep(300 * time.Millisecond)
+		conn3, err := pool.Get(ctx)
+		t.AssertNil(err)
+		t.Assert(conn3 == conn2, false)
+		t.AssertNil(conn3.Close())
+		_, open := pool.Stats()
+		t.Assert(open, 1)
+	})
+	// Health checks in background.
+	gtest.C(t, func(t *gtest.T) {
+		pool, err := gtcp.NewClientPool(gtcp.ClientPoolOption{
+			Address:       fmt.Sprintf("127.0.0.1:%d", p),
+			IdleTimeout:   100 * time.Millisecond,
+			CheckInterval: 100 * time.Millisecond,
+		})
+		t.AssertNil(err)
+		defer pool.Close()
+
+		conn, err := pool.Get(context.TODO())
+		t.AssertNil(err)
+		t.AssertNil(conn.Close())
+		idle, _ := pool.Stats()
+		t.Assert(idle, 1)
+		time.Sleep(500 * time.Millisecond)
+		idle, open := pool.Stats()
+		t.Assert(idle, 0)
+		t.Assert(open, 0)
+	})
+}
+
+func Test_ClientPool_Broken(t *testing.T) {
+	p, _ := gtcp.GetFreePort()
+	s := gtcp.NewServer(fmt.Sprintf(`:%d`, p), func(conn *gtcp.Conn) {
+		conn.Close()
+	})
+	go s.Run()
+	defer s.Close()
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		pool, err := gtcp.NewClientPool(gtcp.ClientPoolOption{
+			Address: fmt.Sprintf("127.0.0.1:%d", p),
+		})
+		t.AssertNil(err)
+		defer pool.Close()
+
+		conn, err := pool.Get(context.TODO())
+		t.AssertNil(err)
+		_, err = conn.RecvPkg()
+		t.AssertNE(err, nil)
+		t.AssertNil(conn.Close())
+		idle, open := pool.Stats()
+		t.Assert(idle, 0)
+		t.Assert(open, 0)
+	})
+}
+
+func Test_ClientPool_DialRetry(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		p, _ := gtcp.GetFreePort()
+		pool, err := gtcp.NewClientPool(gtcp.ClientPoolOption{
+			Address:     fmt.Sprintf("127.0.0.1:%d", p),
+			DialRetry:   2,
+			DialBackoff: 100 * time.Millisecond,
+		})
+		t.AssertNil(err)
+		defer pool.Close()
+
+		start := time.Now()
+		_, err = pool.Get(context.TODO())
+		t.AssertNE(err, nil)
+		t.Assert(time.Since(start) >= 300*time.Millisecond, true)
+		_, open := pool.Stats()
+		t.Assert(open, 0)
+
+		// Canceled.
+		ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+		defer cancel()
+		_, err = pool.Get(ctx)
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gtcp.NewClientPool(gtcp.ClientPoolOption{})
+		t.AssertNE(err, nil)
+	})
+}
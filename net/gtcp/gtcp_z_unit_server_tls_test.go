@@ -0,0 +1,133 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp_test
+
+import (
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/net/gtcp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Server_AddTLSKeyCrt(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		addr := getFreePortAddr()
+		s := gtcp.NewServer(addr, func(conn *gtcp.Conn) {
+			defer conn.Close()
+			data, err := conn.Recv(-1)
+			if err == nil {
+				conn.Send(data)
+			}
+		})
+		t.AssertNil(s.AddTLSKeyCrt("localhost", crtFile, keyFile))
+		t.AssertNil(s.AddTLSKeyCrt("*.goframe.org", crtFile, keyFile))
+		t.AssertNE(s.AddTLSKeyCrt("", "none.crt", keyFile), nil)
+		go s.Run()
+		defer s.Close()
+		time.Sleep(simpleTimeout)
+
+		for _, serverName := range []string{"localhost", "www.goframe.org"} {
+			conn, err := gtcp.NewConnTLS(addr, &tls.Config{
+				ServerName:         serverName,
+				InsecureSkipVerify: true,
+			})
+			t.AssertNil(err)
+			result, err := conn.SendRecv(sendData, -1)
+			t.AssertNil(err)
+			t.Assert(result, sendData)
+			conn.Close()
+		}
+		// No certificate matches the server name.
+		_, err := gtcp.NewConnTLS(addr, &tls.Config{
+			ServerName:         "unknown.org",
+			InsecureSkipVerify: true,
+		})
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Server_SetTLSCertReload(t *testing.T) {
+	// getOrganization returns the organization of the certificate served by the server.
+	getOrganization := func(addr string) string {
+		conn, err := gtcp.NewConnTLS(addr, &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			return ""
+		}
+		defer conn.Close()
+		if result, err := conn.SendRecv(sendData, -1); err != nil || string(result) != string(sendData) {
+			return ""
+		}
+		organization := conn.Conn.(*tls.Conn).ConnectionState().PeerCertificates[0].Subject.Organization
+		if len(organization) == 0 {
+			return ""
+		}
+		return organization[0]
+	}
+	// waitOrganization waits until the server serves the certificate of `organization`.
+	waitOrganization := func(addr, organization string) string {
+		var result string
+		for i := 0; i < 50; i++ {
+			if result = getOrganization(addr); result == organization {
+				break
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		return result
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dir         = gfile.Temp(gtime.TimestampNanoStr())
+			tempCrtFile = gfile.Join(dir, "server.crt")
+			tempKeyFile = gfile.Join(dir, "server.key")
+		)
+		t.AssertNil(gfile.Copy(crtFile, tempCrtFile))
+		t.AssertNil(gfile.Copy(keyFile, tempKeyFile))
+		defer gfile.Remove(dir)
+
+		addr := getFreePortAddr()
+		s := gtcp.NewServer(addr, func(conn *gtcp.Conn) {
+			defer conn.Close()
+			data, err := conn.Recv(-1)
+			if err == nil {
+				conn.Send(data)
+			}
+		})
+		t.AssertNil(s.AddTLSKeyCrt("", tempCrtFile, tempKeyFile))
+		t.AssertNil(s.SetTLSCertReload(true))
+		go s.Run()
+		defer s.Close()
+		time.Sleep(simpleTimeout)
+		t.Assert(getOrganization(addr), "Default Company Ltd")
+
+		// Broken certificate content does not replace the loaded one.
+		t.AssertNil(gfile.PutContents(tempCrtFile, "invalid"))
+		time.Sleep(simpleTimeout)
+		t.Assert(getOrganization(addr), "Default Company Ltd")
+
+		// A different certificate replaces the loaded one.
+		t.AssertNil(gfile.Copy(keyFile2, tempKeyFile))
+		t.AssertNil(gfile.Copy(crtFile2, tempCrtFile))
+		t.Assert(waitOrganization(addr, "GoFrame Reload"), "GoFrame Reload")
+
+		// Closing the server disables the reloading, which can be enabled again.
+		t.AssertNil(s.Close())
+		time.Sleep(simpleTimeout)
+		t.AssertNil(s.SetTLSCertReload(true))
+		go s.Run()
+		time.Sleep(simpleTimeout)
+		t.Assert(getOrganization(addr), "GoFrame Reload")
+
+		t.AssertNil(gfile.Copy(keyFile, tempKeyFile))
+		t.AssertNil(gfile.Copy(crtFile, tempCrtFile))
+		t.Assert(waitOrganization(addr, "Default Company Ltd"), "Default Company Ltd")
+		t.AssertNil(s.SetTLSCertReload(false))
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtcp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gtcp"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Stats(t *testing.T) {
+	var (
+		addr    = getFreePortAddr()
+		errChan = make(chan error, 1)
+	)
+	s := gtcp.NewServer(addr, func(conn *gtcp.Conn) {
+		defer conn.Close()
+		for {
+			data, err := conn.RecvPkg()
+			if err != nil {
+				errChan <- err
+				break
+			}
+			conn.SendPkg(data)
+		}
+	})
+	s.SetRateLimit(1, 3)
+	go s.Run()
+	defer s.Close()
+	time.Sleep(simpleTimeout)
+
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gtcp.NewConn(addr)
+		t.AssertNil(err)
+		defer conn.Close()
+		for i := 0; i < 3; i++ {
+			result, err := conn.SendRecvPkg(sendData)
+			t.AssertNil(err)
+			t.Assert(result, sendData)
+		}
+		// The 4th package exceeds the burst, which makes the server close the connection.
+		_, err = conn.SendRecvPkg(sendData)
+		t.AssertNE(err, nil)
+		t.Assert(gerror.Code(<-errChan), gcode.CodeServerBusy)
+		time.Sleep(simpleTimeout)
+
+		clientStats := conn.Stats()
+		t.Assert(clientStats.MsgsSent, 4)
+		t.Assert(clientStats.BytesSent, 4*(2+len(sendData)))
+		t.Assert(clientStats.MsgsReceived, 3)
+		t.Assert(clientStats.BytesReceived, 3*(2+len(sendData)))
+
+		serverStats := s.Stats()
+		t.Assert(serverStats.Connections, 1)
+		t.Assert(serverStats.ConnectionsOpen, 0)
+		t.Assert(serverStats.MsgsReceived, 4)
+		t.Assert(serverStats.BytesReceived, 4*(2+len(sendData)))
+		t.Assert(serverStats.MsgsSent, 3)
+		t.Assert(serverStats.BytesSent, 3*(2+len(sendData)))
+		t.Assert(serverStats.Limited, 1)
+		t.Assert(serverStats.Errors, 0)
+	})
+}
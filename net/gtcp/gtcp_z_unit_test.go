@@ -24,6 +24,8 @@ var (
 	invalidAddr   = "127.0.0.1:99999"
 	crtFile       = gfile.Dir(gdebug.CallerFilePath()) + gfile.Separator + "testdata/server.crt"
 	keyFile       = gfile.Dir(gdebug.CallerFilePath()) + gfile.Separator + "testdata/server.key"
+	crtFile2      = gfile.Dir(gdebug.CallerFilePath()) + gfile.Separator + "testdata/server2.crt"
+	keyFile2      = gfile.Dir(gdebug.CallerFilePath()) + gfile.Separator + "testdata/server2.key"
 )
 
 func getFreePortAddr() string {
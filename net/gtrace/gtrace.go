@@ -55,7 +55,7 @@ func init() {
 		tracingMaxContentLogSize = maxContentLogSize
 	}
 	// Default trace provider.
-	otel.SetTracerProvider(provider.New())
+	otel.SetTracerProvider(provider.New(defaultSampler))
 	CheckSetDefaultTextMapPropagator()
 }
 
@@ -146,6 +146,31 @@ func GetBaggageVar(ctx context.Context, key string) *gvar.Var {
 	return NewBaggage(ctx).GetVar(key)
 }
 
+// GetBaggageString retrieves and returns the value of `key` from baggage as string.
+func GetBaggageString(ctx context.Context, key string) string {
+	return NewBaggage(ctx).GetString(key)
+}
+
+// GetBaggageInt retrieves and returns the value of `key` from baggage as int.
+func GetBaggageInt(ctx context.Context, key string) int {
+	return NewBaggage(ctx).GetInt(key)
+}
+
+// GetBaggageInt64 retrieves and returns the value of `key` from baggage as int64.
+func GetBaggageInt64(ctx context.Context, key string) int64 {
+	return NewBaggage(ctx).GetInt64(key)
+}
+
+// GetBaggageFloat64 retrieves and returns the value of `key` from baggage as float64.
+func GetBaggageFloat64(ctx context.Context, key string) float64 {
+	return NewBaggage(ctx).GetFloat64(key)
+}
+
+// GetBaggageBool retrieves and returns the value of `key` from baggage as bool.
+func GetBaggageBool(ctx context.Context, key string) bool {
+	return NewBaggage(ctx).GetBool(key)
+}
+
 // WithTraceID injects custom trace id into context to propagate.
 func WithTraceID(ctx context.Context, traceID string) (context.Context, error) {
 	generatedTraceID, err := trace.TraceIDFromHex(traceID)
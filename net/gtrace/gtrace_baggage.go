@@ -37,27 +37,39 @@ func (b *Baggage) Ctx() context.Context {
 }
 
 // SetValue is a convenient function for adding one key-value pair to baggage.
-// Note that it uses attribute.Any to set the key-value pair.
+// The value is converted to string, and the existing value of the same key is replaced.
 func (b *Baggage) SetValue(key string, value interface{}) context.Context {
-	member, _ := baggage.NewMember(key, gconv.String(value))
-	bag, _ := baggage.New(member)
-	b.ctx = baggage.ContextWithBaggage(b.ctx, bag)
-	return b.ctx
+	return b.SetMap(map[string]interface{}{
+		key: value,
+	})
 }
 
 // SetMap is a convenient function for adding map key-value pairs to baggage.
-// Note that it uses attribute.Any to set the key-value pair.
+// The values are converted to string, and the existing values of the same keys are replaced.
+// Note that the values are set as they are, and the invalid keys or values for baggage,
+// like the values containing spaces or commas, are ignored.
+// The values should be encoded by the caller if necessary.
 func (b *Baggage) SetMap(data map[string]interface{}) context.Context {
-	members := make([]baggage.Member, 0)
+	bag := baggage.FromContext(b.ctx)
 	for k, v := range data {
-		member, _ := baggage.NewMember(k, gconv.String(v))
-		members = append(members, member)
+		member, err := baggage.NewMember(k, gconv.String(v))
+		if err != nil {
+			continue
+		}
+		if newBag, err := bag.SetMember(member); err == nil {
+			bag = newBag
+		}
 	}
-	bag, _ := baggage.New(members...)
 	b.ctx = baggage.ContextWithBaggage(b.ctx, bag)
 	return b.ctx
 }
 
+// Remove deletes the value of `key` from baggage.
+func (b *Baggage) Remove(key string) context.Context {
+	b.ctx = baggage.ContextWithBaggage(b.ctx, baggage.FromContext(b.ctx).DeleteMember(key))
+	return b.ctx
+}
+
 // GetMap retrieves and returns the baggage values as map.
 func (b *Baggage) GetMap() *gmap.StrAnyMap {
 	m := gmap.NewStrAnyMap()
@@ -73,3 +85,33 @@ func (b *Baggage) GetVar(key string) *gvar.Var {
 	value := baggage.FromContext(b.ctx).Member(key).Value()
 	return gvar.New(value)
 }
+
+// GetString retrieves and returns the value of `key` from baggage as string.
+// It returns an empty string if `key` does not exist.
+func (b *Baggage) GetString(key string) string {
+	return b.GetVar(key).String()
+}
+
+// GetInt retrieves and returns the value of `key` from baggage as int.
+// It returns 0 if `key` does not exist or its value is not a number.
+func (b *Baggage) GetInt(key string) int {
+	return b.GetVar(key).Int()
+}
+
+// GetInt64 retrieves and returns the value of `key` from baggage as int64.
+// It returns 0 if `key` does not exist or its value is not a number.
+func (b *Baggage) GetInt64(key string) int64 {
+	return b.GetVar(key).Int64()
+}
+
+// GetFloat64 retrieves and returns the value of `key` from baggage as float64.
+// It returns 0 if `key` does not exist or its value is not a number.
+func (b *Baggage) GetFloat64(key string) float64 {
+	return b.GetVar(key).Float64()
+}
+
+// GetBool retrieves and returns the value of `key` from baggage as bool.
+// It returns false if `key` does not exist, or its value is empty, "0", "false" or "off".
+func (b *Baggage) GetBool(key string) bool {
+	return b.GetVar(key).Bool()
+}
@@ -7,6 +7,10 @@
 package gtrace
 
 import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+
 	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/util/gconv"
 )
@@ -22,6 +26,23 @@ func NewCarrier(data ...map[string]interface{}) Carrier {
 	return make(map[string]interface{})
 }
 
+// InjectCarrier injects the tracing content and baggage of `ctx` into a new Carrier and returns it,
+// which can be delivered with messages, like the items of message queue, to propagate the tracing.
+func InjectCarrier(ctx context.Context) Carrier {
+	carrier := NewCarrier()
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier
+}
+
+// ExtractCarrier extracts the tracing content and baggage from `carrier` into `ctx` and returns it,
+// which is the opposite operation of InjectCarrier.
+func ExtractCarrier(ctx context.Context, carrier Carrier) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
 // Get returns the value associated with the passed key.
 func (c Carrier) Get(k string) string {
 	return gconv.String(c[k])
@@ -0,0 +1,170 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SamplingDecision is the sampling decision forced by context, see WithSampling.
+type SamplingDecision int
+
+const (
+	SamplingDefault SamplingDecision = iota // Uses the configured sampler.
+	SamplingAlways                          // Always samples the spans.
+	SamplingNever                           // Never samples the spans.
+)
+
+// ctxKeySampling is the context key for the forced sampling decision.
+type ctxKeySampling struct{}
+
+// sampler is the sampler of gtrace, which uses the sampling decision forced by context,
+// or else delegates to the configured sampler.
+type sampler struct {
+	mu       sync.RWMutex
+	delegate sdkTrace.Sampler // The configured sampler.
+}
+
+// rateLimitSampler samples at most given count of spans per second using token bucket.
+type rateLimitSampler struct {
+	mu     sync.Mutex
+	rate   float64   // Sampled spans per second.
+	burst  float64   // Max tokens in bucket.
+	tokens float64   // Available tokens.
+	last   time.Time // Last time refilling tokens.
+}
+
+var (
+	// defaultSampler is the sampler of the default trace provider.
+	defaultSampler = &sampler{
+		delegate: sdkTrace.ParentBased(sdkTrace.AlwaysSample()),
+	}
+)
+
+// WithSampling returns a new context forcing the sampling decision `decision` for the spans
+// started with it, which overrides the configured sampler. It is usually used for routes that
+// should always or never be traced, like administration or health checking routes.
+//
+// Note that it takes effect only for the sampler returned by GetSampler, which is used by
+// the default trace provider, or the custom trace provider configured with it.
+func WithSampling(ctx context.Context, decision SamplingDecision) context.Context {
+	return context.WithValue(ctx, ctxKeySampling{}, decision)
+}
+
+// SamplingFromCtx returns the sampling decision forced by WithSampling in `ctx`.
+func SamplingFromCtx(ctx context.Context) SamplingDecision {
+	if ctx == nil {
+		return SamplingDefault
+	}
+	if v, ok := ctx.Value(ctxKeySampling{}).(SamplingDecision); ok {
+		return v
+	}
+	return SamplingDefault
+}
+
+// SetSampler sets the sampler `s` used by the sampler of GetSampler at runtime.
+// It resets to the default sampler, ParentBased(AlwaysSample), if `s` is nil.
+func SetSampler(s sdkTrace.Sampler) {
+	if s == nil {
+		s = sdkTrace.ParentBased(sdkTrace.AlwaysSample())
+	}
+	defaultSampler.mu.Lock()
+	defaultSampler.delegate = s
+	defaultSampler.mu.Unlock()
+}
+
+// GetSampler returns the sampler of gtrace, which uses the sampler configured by SetSampler and
+// respects the sampling decision forced by WithSampling. It is used by the default trace provider,
+// and can be used by the custom trace provider like: sdkTrace.WithSampler(gtrace.GetSampler()).
+func GetSampler() sdkTrace.Sampler {
+	return defaultSampler
+}
+
+// NewRatioSampler creates and returns a sampler sampling given fraction `ratio` of traces,
+// which samples all if `ratio` >= 1 and none if `ratio` <= 0.
+func NewRatioSampler(ratio float64) sdkTrace.Sampler {
+	return sdkTrace.TraceIDRatioBased(ratio)
+}
+
+// NewRateLimitSampler creates and returns a sampler sampling at most `perSecond` spans per second,
+// which allows bursts of up to `perSecond` spans. It samples none if `perSecond` <= 0.
+func NewRateLimitSampler(perSecond float64) sdkTrace.Sampler {
+	s := &rateLimitSampler{
+		last: time.Now(),
+	}
+	if perSecond > 0 {
+		s.rate = perSecond
+		s.burst = math.Max(perSecond, 1)
+		s.tokens = s.burst
+	}
+	return s
+}
+
+// NewParentBasedSampler creates and returns a sampler following the sampling decision of
+// the parent span, and using `root` for the spans without parent.
+func NewParentBasedSampler(root sdkTrace.Sampler) sdkTrace.Sampler {
+	return sdkTrace.ParentBased(root)
+}
+
+// ShouldSample implements sdkTrace.Sampler.
+func (s *sampler) ShouldSample(p sdkTrace.SamplingParameters) sdkTrace.SamplingResult {
+	switch SamplingFromCtx(p.ParentContext) {
+	case SamplingAlways:
+		return sdkTrace.SamplingResult{
+			Decision:   sdkTrace.RecordAndSample,
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	case SamplingNever:
+		return sdkTrace.SamplingResult{
+			Decision:   sdkTrace.Drop,
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	}
+	return s.getDelegate().ShouldSample(p)
+}
+
+// Description implements sdkTrace.Sampler.
+func (s *sampler) Description() string {
+	return fmt.Sprintf(`GoFrameSampler{%s}`, s.getDelegate().Description())
+}
+
+// getDelegate returns the configured sampler.
+func (s *sampler) getDelegate() sdkTrace.Sampler {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.delegate
+}
+
+// ShouldSample implements sdkTrace.Sampler.
+func (s *rateLimitSampler) ShouldSample(p sdkTrace.SamplingParameters) sdkTrace.SamplingResult {
+	result := sdkTrace.SamplingResult{
+		Decision:   sdkTrace.Drop,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.tokens = math.Min(s.burst, s.tokens+now.Sub(s.last).Seconds()*s.rate)
+	s.last = now
+	if s.tokens >= 1 {
+		s.tokens--
+		result.Decision = sdkTrace.RecordAndSample
+	}
+	return result
+}
+
+// Description implements sdkTrace.Sampler.
+func (s *rateLimitSampler) Description() string {
+	return fmt.Sprintf(`RateLimitSampler{%g}`, s.rate)
+}
@@ -8,8 +8,15 @@ package gtrace
 
 import (
 	"context"
+	"fmt"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gconv"
 )
 
 // Span warps trace.Span for compatibility and extension.
@@ -24,3 +31,59 @@ func NewSpan(ctx context.Context, spanName string, opts ...trace.SpanStartOption
 		Span: span,
 	}
 }
+
+// WithSpan creates a span using default tracer, calls `f` with the context of the span
+// and ends the span after `f` returns.
+// The error returned by `f` is recorded to the span and returned.
+// The panic in `f` is also recorded to the span, and then it panics again.
+func WithSpan(
+	ctx context.Context, spanName string, f func(ctx context.Context) error, opts ...trace.SpanStartOption,
+) (err error) {
+	ctx, span := NewSpan(ctx, spanName, opts...)
+	defer span.End()
+	defer func() {
+		if exception := recover(); exception != nil {
+			if v, ok := exception.(error); ok && gerror.HasStack(v) {
+				span.SetError(v)
+			} else {
+				span.SetError(gerror.NewCodef(gcode.CodeInternalError, "%+v", exception))
+			}
+			panic(exception)
+		}
+	}()
+	if err = f(ctx); err != nil {
+		span.SetError(err)
+	}
+	return
+}
+
+// SetError records `err` to the span and marks the span status as error.
+// It does nothing if `err` is nil.
+func (s *Span) SetError(err error) {
+	if err == nil {
+		return
+	}
+	s.RecordError(err)
+	s.SetStatus(codes.Error, fmt.Sprintf(`%+v`, err))
+}
+
+// SetAttributesMap sets the map key-value pairs as attributes of the span.
+// The values are converted to string.
+func (s *Span) SetAttributesMap(data map[string]interface{}) {
+	s.SetAttributes(mapToAttributes(data)...)
+}
+
+// AddEventMap adds an event named `name` with the map key-value pairs as its attributes to the span.
+// The values are converted to string.
+func (s *Span) AddEventMap(name string, data map[string]interface{}) {
+	s.AddEvent(name, trace.WithAttributes(mapToAttributes(data)...))
+}
+
+// mapToAttributes converts map to attributes.
+func mapToAttributes(data map[string]interface{}) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(data))
+	for k, v := range data {
+		attrs = append(attrs, attribute.String(k, gconv.String(v)))
+	}
+	return attrs
+}
@@ -0,0 +1,86 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace_test
+
+import (
+	"context"
+	"testing"
+
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func TestSampler(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		ctx := context.TODO()
+		defer gtrace.SetSampler(nil)
+
+		_, span := gtrace.NewSpan(ctx, "default")
+		t.Assert(span.SpanContext().IsSampled(), true)
+		span.End()
+
+		gtrace.SetSampler(gtrace.NewRatioSampler(0))
+		_, span = gtrace.NewSpan(ctx, "ratio")
+		t.Assert(span.SpanContext().IsSampled(), false)
+		span.End()
+
+		// Forced by context.
+		spanCtx, span := gtrace.NewSpan(gtrace.WithSampling(ctx, gtrace.SamplingAlways), "always")
+		t.Assert(span.SpanContext().IsSampled(), true)
+		t.Assert(gtrace.SamplingFromCtx(spanCtx), gtrace.SamplingAlways)
+		span.End()
+
+		gtrace.SetSampler(nil)
+		_, span = gtrace.NewSpan(gtrace.WithSampling(ctx, gtrace.SamplingNever), "never")
+		t.Assert(span.SpanContext().IsSampled(), false)
+		span.End()
+		t.Assert(gtrace.SamplingFromCtx(ctx), gtrace.SamplingDefault)
+	})
+}
+
+func TestSampler_Builtin(t *testing.T) {
+	var (
+		traceID, _ = trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanID, _  = trace.SpanIDFromHex("00f067aa0ba902b7")
+		params     = sdkTrace.SamplingParameters{
+			ParentContext: context.TODO(),
+			TraceID:       traceID,
+		}
+	)
+	gtest.C(t, func(t *gtest.T) {
+		sampler := gtrace.NewRateLimitSampler(2)
+		t.Assert(sampler.ShouldSample(params).Decision, sdkTrace.RecordAndSample)
+		t.Assert(sampler.ShouldSample(params).Decision, sdkTrace.RecordAndSample)
+		t.Assert(sampler.ShouldSample(params).Decision, sdkTrace.Drop)
+		t.Assert(sampler.Description(), "RateLimitSampler{2}")
+
+		sampler = gtrace.NewRateLimitSampler(0)
+		t.Assert(sampler.ShouldSample(params).Decision, sdkTrace.Drop)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gtrace.NewRatioSampler(1).ShouldSample(params).Decision, sdkTrace.RecordAndSample)
+		t.Assert(gtrace.NewRatioSampler(0).ShouldSample(params).Decision, sdkTrace.Drop)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			sampler   = gtrace.NewParentBasedSampler(gtrace.NewRatioSampler(1))
+			parentCtx = trace.ContextWithSpanContext(context.TODO(), trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  spanID,
+				Remote:  true,
+			}))
+		)
+		t.Assert(sampler.ShouldSample(params).Decision, sdkTrace.RecordAndSample)
+		t.Assert(sampler.ShouldSample(sdkTrace.SamplingParameters{
+			ParentContext: parentCtx,
+			TraceID:       traceID,
+		}).Decision, sdkTrace.Drop)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/net/gtrace"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/text/gstr"
@@ -34,3 +35,69 @@ func TestWithTraceID(t *testing.T) {
 		t.Assert(gtrace.GetTraceID(newCtx), traceId)
 	})
 }
+
+func TestBaggage(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		ctx := gtrace.SetBaggageValue(context.Background(), "k1", "v1")
+		ctx = gtrace.SetBaggageMap(ctx, map[string]interface{}{
+			"k2": 2,
+			"k3": "v%203",
+			// Invalid value is ignored.
+			"k4": "v 4,;",
+		})
+		t.Assert(gtrace.GetBaggageVar(ctx, "k1").String(), "v1")
+		t.Assert(gtrace.GetBaggageVar(ctx, "k2").Int(), 2)
+		t.Assert(gtrace.GetBaggageVar(ctx, "k3").String(), "v%203")
+		t.Assert(gtrace.GetBaggageVar(ctx, "k4").String(), "")
+		t.Assert(gtrace.GetBaggageMap(ctx).Size(), 3)
+
+		t.Assert(gtrace.GetBaggageString(ctx, "k1"), "v1")
+		t.Assert(gtrace.GetBaggageInt(ctx, "k2"), 2)
+		t.Assert(gtrace.GetBaggageInt64(ctx, "k2"), 2)
+		t.Assert(gtrace.GetBaggageFloat64(ctx, "k2"), 2)
+		t.Assert(gtrace.GetBaggageBool(ctx, "k2"), true)
+		t.Assert(gtrace.GetBaggageBool(ctx, "k4"), false)
+		t.Assert(gtrace.GetBaggageInt(ctx, "k1"), 0)
+
+		ctx = gtrace.NewBaggage(ctx).Remove("k1")
+		t.Assert(gtrace.GetBaggageVar(ctx, "k1").String(), "")
+		t.Assert(gtrace.GetBaggageMap(ctx).Size(), 2)
+	})
+}
+
+func TestCarrier_InjectExtract(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		ctx := gtrace.SetBaggageValue(context.Background(), "name", "john")
+		carrier := gtrace.InjectCarrier(ctx)
+		t.AssertNE(carrier.Get("baggage"), "")
+
+		newCtx := gtrace.ExtractCarrier(context.Background(), carrier)
+		t.Assert(gtrace.GetBaggageVar(newCtx, "name").String(), "john")
+	})
+}
+
+func TestWithSpan(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var spanCtx context.Context
+		err := gtrace.WithSpan(context.Background(), "test", func(ctx context.Context) error {
+			spanCtx = ctx
+			return nil
+		})
+		t.AssertNil(err)
+		t.AssertNE(spanCtx, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		err := gtrace.WithSpan(context.Background(), "test", func(ctx context.Context) error {
+			return gerror.New("error")
+		})
+		t.Assert(err, "error")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		defer func() {
+			t.Assert(recover(), "panic")
+		}()
+		_ = gtrace.WithSpan(context.Background(), "test", func(ctx context.Context) error {
+			panic("panic")
+		})
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/errors/gerror"
 )
 
@@ -21,6 +22,10 @@ type Conn struct {
 	receiveDeadline   time.Time     // Timeout point for reading data.
 	sendDeadline      time.Time     // Timeout point for writing data.
 	receiveBufferWait time.Duration // Interval duration for reading buffer.
+	seq               *gtype.Uint32 // Sequence generator for SendRecvSeq.
+	seqWindow         *seqWindow    // Received sequences window for RecvSeq deduplication.
+	stats             *connStats    // Counters of the connection.
+	limiter           *rateLimiter  // Rate limiter of received packages for each remote address, which is set by server.
 }
 
 const (
@@ -51,13 +56,17 @@ func NewConnByNetConn(udp *net.UDPConn) *Conn {
 		receiveDeadline:   time.Time{},
 		sendDeadline:      time.Time{},
 		receiveBufferWait: receiveAllWaitTimeout,
+		seq:               gtype.NewUint32(),
+		seqWindow:         newSeqWindow(defaultSeqWindowSize),
+		stats:             &connStats{},
 	}
 }
 
 // Send writes data to remote address.
 func (c *Conn) Send(data []byte, retry ...Retry) (err error) {
 	for {
-		if c.remoteAddr != nil {
+		// It cannot use WriteToUDP for connection created by DialUDP, which is already connected.
+		if c.remoteAddr != nil && c.UDPConn.RemoteAddr() == nil {
 			_, err = c.WriteToUDP(data, c.remoteAddr)
 		} else {
 			_, err = c.Write(data)
@@ -69,6 +78,7 @@ func (c *Conn) Send(data []byte, retry ...Retry) (err error) {
 			}
 			// Still failed even after retrying.
 			if len(retry) == 0 || retry[0].Count == 0 {
+				c.stats.addError()
 				err = gerror.Wrap(err, `Write data failed`)
 				return err
 			}
@@ -80,6 +90,7 @@ func (c *Conn) Send(data []byte, retry ...Retry) (err error) {
 				time.Sleep(retry[0].Interval)
 			}
 		} else {
+			c.stats.addSent(len(data))
 			return nil
 		}
 	}
@@ -107,6 +118,11 @@ func (c *Conn) Recv(buffer int, retry ...Retry) ([]byte, error) {
 	for {
 		size, remoteAddr, err = c.ReadFromUDP(data)
 		if err == nil {
+			c.stats.addReceived(size)
+			// The package exceeding the rate limit is dropped, and it continues receiving.
+			if !c.allow(remoteAddr) {
+				continue
+			}
 			c.remoteAddr = remoteAddr
 		}
 		if err != nil {
@@ -126,6 +142,7 @@ func (c *Conn) Recv(buffer int, retry ...Retry) ([]byte, error) {
 				time.Sleep(retry[0].Interval)
 				continue
 			}
+			c.stats.addError()
 			err = gerror.Wrap(err, `ReadFromUDP failed`)
 			break
 		}
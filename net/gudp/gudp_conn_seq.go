@@ -0,0 +1,207 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gudp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// SeqOption is the option for sequenced request-response messaging.
+type SeqOption struct {
+	Timeout time.Duration // Waiting timeout for response of each transmission, default is 1 second.
+	Retry   int           // Retransmission count if no response received in Timeout.
+}
+
+const (
+	seqHeaderSize        = 5    // Header size of sequenced package: 1 byte magic + 4 bytes sequence.
+	seqHeaderMagic       = 0xF5 // Magic byte of sequenced package.
+	defaultSeqWindowSize = 1024 // Default count of remembered sequences for deduplication.
+	defaultSeqTimeout    = time.Second
+	defaultSeqRetry      = 3
+)
+
+// seqWindow remembers recently received sequences and their responses for deduplication.
+type seqWindow struct {
+	mu        sync.Mutex
+	size      int               // Max count of remembered sequences.
+	keys      []string          // Remembered keys in receiving order, used as ring buffer.
+	index     int               // Next position of ring buffer.
+	responses map[string][]byte // Sequence key to response package, nil if not responded yet.
+}
+
+func newSeqWindow(size int) *seqWindow {
+	if size <= 0 {
+		size = defaultSeqWindowSize
+	}
+	return &seqWindow{
+		size:      size,
+		keys:      make([]string, size),
+		responses: make(map[string][]byte, size),
+	}
+}
+
+// Seen checks whether the `key` was received. It remembers the `key` if it was not received,
+// or else it returns the cached response package for the `key`.
+func (w *seqWindow) Seen(key string) (seen bool, response []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if response, seen = w.responses[key]; seen {
+		return
+	}
+	if old := w.keys[w.index]; old != "" {
+		delete(w.responses, old)
+	}
+	w.keys[w.index] = key
+	w.index = (w.index + 1) % w.size
+	w.responses[key] = nil
+	return false, nil
+}
+
+// SetResponse caches the response package for the `key` if it is still in the window.
+func (w *seqWindow) SetResponse(key string, response []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.responses[key]; ok {
+		w.responses[key] = response
+	}
+}
+
+// SetSeqWindow sets the count of remembered sequences for deduplication of RecvSeq.
+// It also clears the sequences remembered before.
+func (c *Conn) SetSeqWindow(size int) {
+	c.seqWindow = newSeqWindow(size)
+}
+
+// SendRecvSeq sends `data` with an auto-increasing sequence number and blocks reading the response
+// of the same sequence number. It retransmits the data if no response received in timeout, and
+// the responses of other sequence numbers, like a late response of retransmission, are ignored.
+//
+// The remote side should use RecvSeq and SendSeq to handle the sequenced data.
+// The parameter `receive` specifies the max size of response data.
+// It retransmits 3 times with 1 second timeout each if no `option` given.
+func (c *Conn) SendRecvSeq(data []byte, receive int, option ...SeqOption) ([]byte, error) {
+	var (
+		opt = SeqOption{
+			Timeout: defaultSeqTimeout,
+			Retry:   defaultSeqRetry,
+		}
+		seq    = c.seq.Add(1)
+		pkg    = packSeq(seq, data)
+		buffer []byte
+	)
+	if len(option) > 0 {
+		if option[0].Timeout > 0 {
+			opt.Timeout = option[0].Timeout
+		}
+		opt.Retry = option[0].Retry
+	}
+	if receive > 0 {
+		buffer = make([]byte, receive+seqHeaderSize)
+	} else {
+		buffer = make([]byte, defaultReadBufferSize)
+	}
+	defer c.SetRecvDeadline(time.Time{})
+	for i := 0; i <= opt.Retry; i++ {
+		if err := c.Send(pkg); err != nil {
+			return nil, err
+		}
+		if err := c.SetRecvDeadline(time.Now().Add(opt.Timeout)); err != nil {
+			return nil, err
+		}
+		for {
+			size, remoteAddr, err := c.ReadFromUDP(buffer)
+			if err != nil {
+				if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+					break
+				}
+				c.stats.addError()
+				return nil, gerror.Wrap(err, `ReadFromUDP failed`)
+			}
+			c.stats.addReceived(size)
+			c.remoteAddr = remoteAddr
+			if respSeq, respData, ok := unpackSeq(buffer[:size]); ok && respSeq == seq {
+				return respData, nil
+			}
+		}
+	}
+	return nil, gerror.NewCodef(
+		gcode.CodeOperationFailed,
+		`no response received for sequence %d after %d retransmissions`,
+		seq, opt.Retry,
+	)
+}
+
+// RecvSeq receives and returns the sequenced data sent by SendRecvSeq from remote address.
+// The duplicated data, which is retransmitted by remote side, is dropped automatically,
+// and the response of it is sent again if it was responded by SendSeq.
+// The non-sequenced data is dropped too.
+//
+// The parameter `buffer` is used for customizing the receiving buffer size of data.
+func (c *Conn) RecvSeq(buffer int, retry ...Retry) (seq uint32, data []byte, err error) {
+	if buffer > 0 {
+		buffer += seqHeaderSize
+	}
+	for {
+		var pkg []byte
+		if pkg, err = c.Recv(buffer, retry...); err != nil {
+			return 0, nil, err
+		}
+		var ok bool
+		if seq, data, ok = unpackSeq(pkg); !ok {
+			continue
+		}
+		seen, response := c.seqWindow.Seen(c.seqKey(seq))
+		if !seen {
+			return seq, data, nil
+		}
+		if response != nil {
+			if err = c.Send(response); err != nil {
+				return 0, nil, err
+			}
+		}
+	}
+}
+
+// SendSeq sends the response `data` of sequence `seq` to remote address,
+// which is received by RecvSeq.
+func (c *Conn) SendSeq(seq uint32, data []byte, retry ...Retry) error {
+	pkg := packSeq(seq, data)
+	c.seqWindow.SetResponse(c.seqKey(seq), pkg)
+	return c.Send(pkg, retry...)
+}
+
+// seqKey returns the deduplication key of `seq` for current remote address.
+func (c *Conn) seqKey(seq uint32) string {
+	if c.remoteAddr == nil {
+		return fmt.Sprintf(`%d`, seq)
+	}
+	return fmt.Sprintf(`%s#%d`, c.remoteAddr.String(), seq)
+}
+
+// packSeq packs `data` with sequence header.
+func packSeq(seq uint32, data []byte) []byte {
+	pkg := make([]byte, seqHeaderSize+len(data))
+	pkg[0] = seqHeaderMagic
+	binary.BigEndian.PutUint32(pkg[1:seqHeaderSize], seq)
+	copy(pkg[seqHeaderSize:], data)
+	return pkg
+}
+
+// unpackSeq parses the sequence header from `pkg`. It returns false if `pkg` is not a sequenced package.
+func unpackSeq(pkg []byte) (seq uint32, data []byte, ok bool) {
+	if len(pkg) < seqHeaderSize || pkg[0] != seqHeaderMagic {
+		return 0, nil, false
+	}
+	return binary.BigEndian.Uint32(pkg[1:seqHeaderSize]), pkg[seqHeaderSize:], true
+}
@@ -0,0 +1,76 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gudp
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// NewMulticastConn joins the multicast group `groupAddress` like "239.0.0.1:9999" and returns
+// the connection, which receives the data sent to the group.
+// The optional parameter `interfaceName` specifies the network interface to join the group,
+// or else the system assigned interface is used.
+func NewMulticastConn(groupAddress string, interfaceName ...string) (*Conn, error) {
+	var (
+		network = `udp`
+		iface   *net.Interface
+	)
+	groupAddr, err := net.ResolveUDPAddr(network, groupAddress)
+	if err != nil {
+		return nil, gerror.Wrapf(
+			err,
+			`net.ResolveUDPAddr failed for network "%s", address "%s"`,
+			network, groupAddress,
+		)
+	}
+	if !groupAddr.IP.IsMulticast() {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`address "%s" is not a multicast address`,
+			groupAddress,
+		)
+	}
+	if len(interfaceName) > 0 && interfaceName[0] != "" {
+		if iface, err = net.InterfaceByName(interfaceName[0]); err != nil {
+			return nil, gerror.Wrapf(err, `net.InterfaceByName failed for name "%s"`, interfaceName[0])
+		}
+	}
+	conn, err := net.ListenMulticastUDP(network, iface, groupAddr)
+	if err != nil {
+		return nil, gerror.Wrapf(
+			err,
+			`net.ListenMulticastUDP failed for network "%s", address "%s"`,
+			network, groupAddress,
+		)
+	}
+	return NewConnByNetConn(conn), nil
+}
+
+// SendMulticast writes data to multicast group `groupAddress` and then closes the connection.
+func SendMulticast(groupAddress string, data []byte, retry ...Retry) error {
+	groupAddr, err := net.ResolveUDPAddr(`udp`, groupAddress)
+	if err != nil {
+		return gerror.Wrapf(err, `net.ResolveUDPAddr failed for address "%s"`, groupAddress)
+	}
+	if !groupAddr.IP.IsMulticast() {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`address "%s" is not a multicast address`,
+			groupAddress,
+		)
+	}
+	return Send(groupAddress, data, retry...)
+}
+
+// SendBroadcast writes data to all hosts of local network on `port` and then closes the connection.
+func SendBroadcast(port int, data []byte, retry ...Retry) error {
+	return Send(fmt.Sprintf(`%s:%d`, net.IPv4bcast.String(), port), data, retry...)
+}
@@ -22,9 +22,11 @@ const (
 
 // Server is the UDP server.
 type Server struct {
-	conn    *Conn       // UDP server connection object.
-	address string      // UDP server listening address.
-	handler func(*Conn) // Handler for UDP connection.
+	conn    *Conn        // UDP server connection object.
+	address string       // UDP server listening address.
+	handler func(*Conn)  // Handler for UDP connection.
+	stats   *connStats   // Counters of the server connection.
+	limiter *rateLimiter // Rate limiter of received packages for each remote address.
 }
 
 var (
@@ -53,6 +55,7 @@ func NewServer(address string, handler func(*Conn), name ...string) *Server {
 	s := &Server{
 		address: address,
 		handler: handler,
+		stats:   &connStats{},
 	}
 	if len(name) > 0 && name[0] != "" {
 		serverMapping.Set(name[0], s)
@@ -97,6 +100,8 @@ func (s *Server) Run() error {
 		return err
 	}
 	s.conn = NewConnByNetConn(conn)
+	s.conn.stats = s.stats
+	s.conn.limiter = s.limiter
 	s.handler(s.conn)
 	return nil
 }
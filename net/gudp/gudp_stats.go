@@ -0,0 +1,81 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gudp
+
+import (
+	"net"
+	"sync/atomic"
+)
+
+// Stats is the snapshot of the counters of a connection or server.
+type Stats struct {
+	BytesReceived uint64 // Count of received bytes.
+	BytesSent     uint64 // Count of sent bytes.
+	MsgsReceived  uint64 // Count of received packages, including the ones dropped by rate limiting.
+	MsgsSent      uint64 // Count of sent packages.
+	Errors        uint64 // Count of failed receiving and sending.
+	Limited       uint64 // Count of received packages dropped by rate limiting, see Server.SetRateLimit.
+}
+
+// connStats holds the counters of a connection.
+type connStats struct {
+	bytesReceived uint64
+	bytesSent     uint64
+	msgsReceived  uint64
+	msgsSent      uint64
+	errors        uint64
+	limited       uint64
+}
+
+// Stats returns the snapshot of the counters of the connection.
+func (c *Conn) Stats() Stats {
+	return c.stats.Stats()
+}
+
+// Stats returns the snapshot of the counters of the server, which is the same as its connection.
+func (s *Server) Stats() Stats {
+	return s.stats.Stats()
+}
+
+// allow checks and returns whether the package from `remoteAddr` is allowed by the rate limiting.
+// It counts the package as limited if it is not allowed.
+func (c *Conn) allow(remoteAddr *net.UDPAddr) bool {
+	if c.limiter == nil || remoteAddr == nil {
+		return true
+	}
+	if c.limiter.Allow(remoteAddr.IP.String()) {
+		return true
+	}
+	atomic.AddUint64(&c.stats.limited, 1)
+	return false
+}
+
+func (s *connStats) addReceived(size int) {
+	atomic.AddUint64(&s.bytesReceived, uint64(size))
+	atomic.AddUint64(&s.msgsReceived, 1)
+}
+
+func (s *connStats) addSent(size int) {
+	atomic.AddUint64(&s.bytesSent, uint64(size))
+	atomic.AddUint64(&s.msgsSent, 1)
+}
+
+func (s *connStats) addError() {
+	atomic.AddUint64(&s.errors, 1)
+}
+
+// Stats returns the snapshot of the counters.
+func (s *connStats) Stats() Stats {
+	return Stats{
+		BytesReceived: atomic.LoadUint64(&s.bytesReceived),
+		BytesSent:     atomic.LoadUint64(&s.bytesSent),
+		MsgsReceived:  atomic.LoadUint64(&s.msgsReceived),
+		MsgsSent:      atomic.LoadUint64(&s.msgsSent),
+		Errors:        atomic.LoadUint64(&s.errors),
+		Limited:       atomic.LoadUint64(&s.limited),
+	}
+}
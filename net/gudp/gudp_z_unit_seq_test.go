@@ -0,0 +1,74 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gudp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/net/gudp"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Conn_SendRecvSeq(t *testing.T) {
+	var (
+		received = gtype.NewInt()
+		delayed  = gtype.NewBool()
+	)
+	p, _ := gudp.GetFreePort()
+	s := gudp.NewServer(fmt.Sprintf("127.0.0.1:%d", p), func(conn *gudp.Conn) {
+		defer conn.Close()
+		for {
+			seq, data, err := conn.RecvSeq(-1)
+			if err != nil {
+				break
+			}
+			received.Add(1)
+			// Delays the first response to make client retransmit the request.
+			if delayed.Cas(false, true) {
+				time.Sleep(simpleTimeout * 2)
+			}
+			conn.SendSeq(seq, append([]byte("> "), data...))
+		}
+	})
+	go s.Run()
+	defer s.Close()
+	time.Sleep(simpleTimeout)
+
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gudp.NewConn(fmt.Sprintf("127.0.0.1:%d", p))
+		t.AssertNil(err)
+		defer conn.Close()
+		option := gudp.SeqOption{Timeout: simpleTimeout, Retry: 3}
+		for i := 0; i < 10; i++ {
+			result, err := conn.SendRecvSeq([]byte(fmt.Sprint(i)), -1, option)
+			t.AssertNil(err)
+			t.Assert(string(result), fmt.Sprintf(`> %d`, i))
+		}
+		// The retransmitted request is deduplicated.
+		t.Assert(received.Val(), 10)
+	})
+	// No response.
+	gtest.C(t, func(t *gtest.T) {
+		port, _ := gudp.GetFreePort()
+		conn, err := gudp.NewConn(fmt.Sprintf("127.0.0.1:%d", port))
+		t.AssertNil(err)
+		defer conn.Close()
+		_, err = conn.SendRecvSeq(sendData, -1, gudp.SeqOption{Timeout: simpleTimeout, Retry: 1})
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Multicast(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gudp.NewMulticastConn("127.0.0.1:9999")
+		t.AssertNE(err, nil)
+		t.AssertNE(gudp.SendMulticast("127.0.0.1:9999", sendData), nil)
+	})
+}
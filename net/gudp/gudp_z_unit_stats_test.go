@@ -0,0 +1,62 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gudp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/net/gudp"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Stats(t *testing.T) {
+	p, _ := gudp.GetFreePort()
+	s := gudp.NewServer(fmt.Sprintf("127.0.0.1:%d", p), func(conn *gudp.Conn) {
+		defer conn.Close()
+		for {
+			data, err := conn.Recv(-1)
+			if err != nil {
+				break
+			}
+			conn.Send(data)
+		}
+	})
+	s.SetRateLimit(1, 3)
+	go s.Run()
+	defer s.Close()
+	time.Sleep(simpleTimeout)
+
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gudp.NewConn(fmt.Sprintf("127.0.0.1:%d", p))
+		t.AssertNil(err)
+		defer conn.Close()
+		for i := 0; i < 3; i++ {
+			result, err := conn.SendRecv(sendData, -1)
+			t.AssertNil(err)
+			t.Assert(result, sendData)
+		}
+		// The 4th package exceeds the burst, which is dropped by the server.
+		_, err = conn.SendRecvWithTimeout(sendData, -1, simpleTimeout)
+		t.AssertNE(err, nil)
+
+		clientStats := conn.Stats()
+		t.Assert(clientStats.MsgsSent, 4)
+		t.Assert(clientStats.BytesSent, 4*len(sendData))
+		t.Assert(clientStats.MsgsReceived, 3)
+		t.Assert(clientStats.BytesReceived, 3*len(sendData))
+		t.Assert(clientStats.Errors, 1)
+
+		serverStats := s.Stats()
+		t.Assert(serverStats.MsgsReceived, 4)
+		t.Assert(serverStats.BytesReceived, 4*len(sendData))
+		t.Assert(serverStats.MsgsSent, 3)
+		t.Assert(serverStats.Limited, 1)
+		t.Assert(serverStats.Errors, 0)
+	})
+}
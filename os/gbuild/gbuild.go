@@ -9,6 +9,7 @@ package gbuild
 
 import (
 	"context"
+	"net/url"
 	"runtime"
 
 	"github.com/gogf/gf/v2"
@@ -28,23 +29,42 @@ type BuildInfo struct {
 }
 
 const (
-	gfVersion = `gfVersion`
-	goVersion = `goVersion`
-	builtGit  = `builtGit`
-	builtTime = `builtTime`
+	gfVersion     = `gfVersion`
+	goVersion     = `goVersion`
+	builtGit      = `builtGit`
+	builtTime     = `builtTime`
+	builtVersion  = `builtVersion`
+	builtGitSha   = `builtGitSha`
+	builtGitDirty = `builtGitDirty`
 )
 
 var (
 	builtInVarStr = ""                       // Raw variable base64 string, which is injected by go build flags.
 	builtInVarMap = map[string]interface{}{} // Binary custom variable map decoded.
+
+	// builtInVarQuery is the custom key-value pairs in URL query format like "builtGitSha=abc&team=infra",
+	// which is injected by go build flags without gf-cli tool, eg:
+	// go build -ldflags "-X 'github.com/gogf/gf/v2/os/gbuild.builtInVarQuery=builtVersion=v1.0.0&builtGitDirty=true'"
+	builtInVarQuery = ""
 )
 
 func init() {
-	// The `builtInVarStr` is injected by go build flags.
-	if builtInVarStr != "" {
-		err := json.UnmarshalUseNumber(gbase64.MustDecodeString(builtInVarStr), &builtInVarMap)
-		if err != nil {
-			intlog.Errorf(context.TODO(), `%+v`, err)
+	// The `builtInVarStr` and `builtInVarQuery` are injected by go build flags.
+	if builtInVarStr != "" || builtInVarQuery != "" {
+		if builtInVarStr != "" {
+			err := json.UnmarshalUseNumber(gbase64.MustDecodeString(builtInVarStr), &builtInVarMap)
+			if err != nil {
+				intlog.Errorf(context.TODO(), `%+v`, err)
+			}
+		}
+		if builtInVarQuery != "" {
+			values, err := url.ParseQuery(builtInVarQuery)
+			if err != nil {
+				intlog.Errorf(context.TODO(), `%+v`, err)
+			}
+			for k := range values {
+				builtInVarMap[k] = values.Get(k)
+			}
 		}
 		builtInVarMap[gfVersion] = gf.VERSION
 		builtInVarMap[goVersion] = runtime.Version()
@@ -0,0 +1,60 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gbuild
+
+import (
+	"runtime"
+	"strings"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Metadata is the typed build metadata of current binary.
+type Metadata struct {
+	Version  string                 `json:"version"`        // Binary version, from variable "builtVersion".
+	GitSha   string                 `json:"gitSha"`         // Git commit hash, from variable "builtGitSha" or "builtGit", or else from vcs info of go build.
+	GitDirty bool                   `json:"gitDirty"`       // Whether the working tree has uncommitted changes when building.
+	Time     string                 `json:"time"`           // Built datetime, from variable "builtTime".
+	GoFrame  string                 `json:"goFrame"`        // GoFrame version.
+	Golang   string                 `json:"golang"`         // Golang version that builds the binary.
+	Data     map[string]interface{} `json:"data,omitempty"` // All custom built data key-value pairs.
+}
+
+// GetMetadata returns the typed build metadata of the binary.
+//
+// The metadata is injected by "gf build", or by go build flags like:
+// go build -ldflags "-X 'github.com/gogf/gf/v2/os/gbuild.builtInVarQuery=builtVersion=v1.0.0&builtGitSha=abc'"
+// The git information falls back to the vcs information stamped by go build if it is not injected.
+func GetMetadata() Metadata {
+	metadata := Metadata{
+		Version: Get(builtVersion, "").String(),
+		GitSha:  Get(builtGitSha, "").String(),
+		Time:    Get(builtTime, "").String(),
+		GoFrame: gf.VERSION,
+		Golang:  runtime.Version(),
+		Data:    Data(),
+	}
+	if v := Get(builtGitDirty); v != nil {
+		metadata.GitDirty = v.Bool()
+	}
+	if metadata.GitSha == "" {
+		// The variable "builtGit" of "gf build" is like "2022-01-01 12:00:00 7f2a...".
+		if fields := strings.Fields(Get(builtGit, "").String()); len(fields) > 0 {
+			metadata.GitSha = fields[len(fields)-1]
+		} else {
+			metadata.GitSha, metadata.GitDirty = vcsInfo()
+		}
+	}
+	return metadata
+}
+
+// Scan scans the custom build-in variables to struct `pointer`, which is usually used for
+// retrieving custom keys injected at link time as typed struct.
+func Scan(pointer interface{}) error {
+	return gconv.Scan(Data(), pointer)
+}
@@ -0,0 +1,15 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+//go:build !go1.18
+// +build !go1.18
+
+package gbuild
+
+// vcsInfo returns empty as the vcs information is not stamped by go build before go1.18.
+func vcsInfo() (sha string, dirty bool) {
+	return
+}
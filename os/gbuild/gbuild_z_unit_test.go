@@ -7,8 +7,10 @@
 package gbuild_test
 
 import (
+	"runtime"
 	"testing"
 
+	"github.com/gogf/gf/v2"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gbuild"
 	"github.com/gogf/gf/v2/test/gtest"
@@ -41,3 +43,24 @@ func Test_Map(t *testing.T) {
 		t.Assert(gbuild.Data(), map[string]interface{}{})
 	})
 }
+
+func Test_GetMetadata(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		metadata := gbuild.GetMetadata()
+		t.Assert(metadata.Version, "")
+		t.Assert(metadata.Time, "")
+		t.Assert(metadata.GoFrame, gf.VERSION)
+		t.Assert(metadata.Golang, runtime.Version())
+		t.Assert(metadata.Data, g.Map{})
+	})
+}
+
+func Test_Scan(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var data struct {
+			Team string
+		}
+		t.AssertNil(gbuild.Scan(&data))
+		t.Assert(data.Team, "")
+	})
+}
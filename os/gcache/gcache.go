@@ -19,6 +19,9 @@ import (
 // Func is the cache function that calculates and returns the value.
 type Func func(ctx context.Context) (value interface{}, err error)
 
+// ExpireFunc is the callback function that is called when the cache item of `key` is expired.
+type ExpireFunc func(ctx context.Context, key interface{}, value interface{})
+
 // Default cache object.
 var defaultCache = New()
 
@@ -30,6 +33,12 @@ func Set(ctx context.Context, key interface{}, value interface{}, duration time.
 	return defaultCache.Set(ctx, key, value, duration)
 }
 
+// SetWithCallback sets cache with `key`-`value` pair, which is expired after `duration`,
+// and calls `onExpire` when it is expired.
+func SetWithCallback(ctx context.Context, key interface{}, value interface{}, duration time.Duration, onExpire ExpireFunc) error {
+	return defaultCache.SetWithCallback(ctx, key, value, duration, onExpire)
+}
+
 // SetMap batch sets cache with key-value pairs by `data` map, which is expired after `duration`.
 //
 // It does not expire if `duration` == 0.
@@ -115,6 +124,14 @@ func GetOrSetFuncLock(ctx context.Context, key interface{}, f Func, duration tim
 	return defaultCache.GetOrSetFuncLock(ctx, key, f, duration)
 }
 
+// GetOrSetFuncRefresh retrieves and returns the value of `key` in refresh-ahead mode, or sets `key`
+// with result of function `f` if `key` does not exist in the cache. The key-value pair expires after
+// `hardTTL`, and it is refreshed asynchronously by `f` when it is retrieved after `softTTL`, during
+// which the stale value is still returned.
+func GetOrSetFuncRefresh(ctx context.Context, key interface{}, f Func, softTTL, hardTTL time.Duration) (*gvar.Var, error) {
+	return defaultCache.GetOrSetFuncRefresh(ctx, key, f, softTTL, hardTTL)
+}
+
 // Contains checks and returns true if `key` exists in the cache, or else returns false.
 func Contains(ctx context.Context, key interface{}) (bool, error) {
 	return defaultCache.Contains(ctx, key)
@@ -184,6 +201,16 @@ func Values(ctx context.Context) ([]interface{}, error) {
 	return defaultCache.Values(ctx)
 }
 
+// SaveTo saves the snapshot of all unexpired items in the default cache to file `path`.
+func SaveTo(ctx context.Context, path string) error {
+	return defaultCache.SaveTo(ctx, path)
+}
+
+// LoadFrom loads the snapshot saved by SaveTo from file `path` into the default cache.
+func LoadFrom(ctx context.Context, path string) error {
+	return defaultCache.LoadFrom(ctx, path)
+}
+
 // MustGet acts like Get, but it panics if any error occurs.
 func MustGet(ctx context.Context, key interface{}) *gvar.Var {
 	return defaultCache.MustGet(ctx, key)
@@ -0,0 +1,631 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/os/gtimer"
+)
+
+// ChainPolicy is the writing policy of AdapterChain.
+type ChainPolicy int
+
+const (
+	// ChainWriteThrough writes all the layers synchronously, from the last layer to the first one.
+	ChainWriteThrough ChainPolicy = iota
+
+	// ChainWriteBehind writes the first layer synchronously, and the other layers asynchronously
+	// in batch, which are flushed periodically or when the pending writes reach the flush size.
+	ChainWriteBehind
+)
+
+const (
+	defaultChainFlushInterval = time.Second
+	defaultChainFlushSize     = 100
+)
+
+// ChainOption is the option for AdapterChain.
+type ChainOption struct {
+	// Policy is the writing policy, which is ChainWriteThrough in default.
+	Policy ChainPolicy
+
+	// FlushInterval is the interval flushing the pending writes in write-behind policy, default is 1 second.
+	FlushInterval time.Duration
+
+	// FlushSize triggers flushing when the pending keys reach it in write-behind policy, default is 100.
+	FlushSize int
+
+	// FillTTL limits the expiration of the value back-filled to the upper layers when it is read from
+	// a lower layer, which bounds the staleness of the upper layers. The value is back-filled with its
+	// expiration in the lower layer if FillTTL is 0.
+	FillTTL time.Duration
+
+	// AfterWrite is called after `keys` are written to or removed from all the layers, which is
+	// usually used for consistency among instances, like broadcasting the keys to other instances
+	// to invalidate their local memory layers by AdapterChain.Invalidate.
+	AfterWrite func(ctx context.Context, keys []interface{})
+
+	// OnFlushError is called when writing `key` to the lower layers fails in write-behind policy.
+	// The written value is still in the first layer.
+	OnFlushError func(ctx context.Context, key interface{}, err error)
+}
+
+// AdapterChain is an adapter composed of multiple layers of adapters, like memory+Redis or Redis+DB.
+// The first layer is the fastest one, and the last layer is the most authoritative one.
+//
+// Reading tries the layers in order, and back-fills the upper layers if it hits a lower layer.
+// Writing follows the ChainPolicy.
+type AdapterChain struct {
+	layers   []Adapter
+	option   ChainOption
+	mu       sync.Mutex                // mu is for the "Lock" functions like GetOrSetFuncLock.
+	pendMu   sync.Mutex                // pendMu guards the pending writes.
+	pending  map[interface{}][]chainOp // Pending writes of keys in write-behind policy.
+	pendKeys []interface{}             // Pending keys in writing order.
+	flushMu  sync.Mutex                // flushMu makes flushing in order.
+	closed   *gtype.Bool
+}
+
+// chainOpType is the type of pending writing operation.
+type chainOpType int
+
+const (
+	chainOpSet chainOpType = iota
+	chainOpRemove
+	chainOpUpdate
+	chainOpUpdateExpire
+)
+
+// chainOp is a pending writing operation in write-behind policy.
+type chainOp struct {
+	typ      chainOpType
+	value    interface{}
+	expireAt int64         // Expiration timestamp in milliseconds for chainOpSet, 0 means no expiration.
+	duration time.Duration // Duration for chainOpUpdateExpire.
+}
+
+// NewChain creates and returns a cache composed of `layers` with write-through policy,
+// like NewChain(memoryAdapter, redisAdapter).
+func NewChain(layers ...Adapter) *Cache {
+	return NewWithAdapter(NewAdapterChain(ChainOption{}, layers...))
+}
+
+// NewAdapterChain creates and returns an adapter composed of `layers` with `option`.
+// It panics if no layer is given.
+func NewAdapterChain(option ChainOption, layers ...Adapter) *AdapterChain {
+	if len(layers) == 0 {
+		panic(gerror.NewCode(gcode.CodeInvalidParameter, `at least one layer is required for cache chain`))
+	}
+	if option.FlushInterval <= 0 {
+		option.FlushInterval = defaultChainFlushInterval
+	}
+	if option.FlushSize <= 0 {
+		option.FlushSize = defaultChainFlushSize
+	}
+	c := &AdapterChain{
+		layers:  layers,
+		option:  option,
+		pending: make(map[interface{}][]chainOp),
+		closed:  gtype.NewBool(),
+	}
+	if option.Policy == ChainWriteBehind {
+		gtimer.AddSingleton(context.Background(), option.FlushInterval, c.flushTimerJob)
+	}
+	return c
+}
+
+// Layers returns the layers of the chain.
+func (c *AdapterChain) Layers() []Adapter {
+	return c.layers
+}
+
+// Set sets cache with `key`-`value` pair, which is expired after `duration`.
+//
+// It does not expire if `duration` == 0.
+// It deletes the keys of `data` if `duration` < 0 or given `value` is nil.
+func (c *AdapterChain) Set(ctx context.Context, key interface{}, value interface{}, duration time.Duration) error {
+	return c.SetMap(ctx, map[interface{}]interface{}{key: value}, duration)
+}
+
+// SetMap batch sets cache with key-value pairs by `data` map, which is expired after `duration`.
+//
+// It does not expire if `duration` == 0.
+// It deletes the keys of `data` if `duration` < 0 or given `value` is nil.
+func (c *AdapterChain) SetMap(ctx context.Context, data map[interface{}]interface{}, duration time.Duration) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if c.option.Policy == ChainWriteBehind {
+		if err := c.layers[0].SetMap(ctx, data, duration); err != nil {
+			return err
+		}
+		var expireAt int64
+		if duration != 0 {
+			expireAt = gtime.TimestampMilli() + duration.Milliseconds()
+		}
+		for k, v := range data {
+			if v == nil || duration < 0 {
+				c.addPending(ctx, k, chainOp{typ: chainOpRemove})
+			} else {
+				c.addPending(ctx, k, chainOp{typ: chainOpSet, value: v, expireAt: expireAt})
+			}
+		}
+		return nil
+	}
+	for i := len(c.layers) - 1; i >= 0; i-- {
+		if err := c.layers[i].SetMap(ctx, data, duration); err != nil {
+			return err
+		}
+	}
+	c.afterWrite(ctx, mapKeys(data))
+	return nil
+}
+
+// SetIfNotExist sets cache with `key`-`value` pair which is expired after `duration`
+// if `key` does not exist in the cache. It returns true the `key` does not exist in the
+// cache, and it sets `value` successfully to the cache, or else it returns false.
+//
+// It does not expire if `duration` == 0.
+// It deletes the `key` if `duration` < 0 or given `value` is nil.
+func (c *AdapterChain) SetIfNotExist(ctx context.Context, key interface{}, value interface{}, duration time.Duration) (bool, error) {
+	return c.SetIfNotExistFunc(ctx, key, func(ctx context.Context) (interface{}, error) {
+		return value, nil
+	}, duration)
+}
+
+// SetIfNotExistFunc sets `key` with result of function `f` and returns true
+// if `key` does not exist in the cache, or else it does nothing and returns false if `key` already exists.
+//
+// It does not expire if `duration` == 0.
+// It deletes the `key` if `duration` < 0 or given `value` is nil.
+func (c *AdapterChain) SetIfNotExistFunc(ctx context.Context, key interface{}, f Func, duration time.Duration) (bool, error) {
+	isContained, err := c.Contains(ctx, key)
+	if err != nil || isContained {
+		return false, err
+	}
+	value, err := f(ctx)
+	if err != nil {
+		return false, err
+	}
+	if err = c.Set(ctx, key, value, duration); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetIfNotExistFuncLock sets `key` with result of function `f` and returns true
+// if `key` does not exist in the cache, or else it does nothing and returns false if `key` already exists.
+//
+// It does not expire if `duration` == 0.
+// It deletes the `key` if `duration` < 0 or given `value` is nil.
+//
+// Note that the function `f` is executed within the mutex lock of the chain.
+func (c *AdapterChain) SetIfNotExistFuncLock(ctx context.Context, key interface{}, f Func, duration time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.SetIfNotExistFunc(ctx, key, f, duration)
+}
+
+// Get retrieves and returns the associated value of given `key`.
+// It tries the layers in order, and back-fills the upper layers if the value is found in a lower layer.
+func (c *AdapterChain) Get(ctx context.Context, key interface{}) (*gvar.Var, error) {
+	for i, layer := range c.layers {
+		v, err := layer.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil || v.IsNil() {
+			continue
+		}
+		if i > 0 {
+			if err = c.backFill(ctx, i, key, v.Val()); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	}
+	return nil, nil
+}
+
+// GetOrSet retrieves and returns the value of `key`, or sets `key`-`value` pair and
+// returns `value` if `key` does not exist in the cache. The key-value pair expires
+// after `duration`.
+//
+// It does not expire if `duration` == 0.
+// It deletes the `key` if `duration` < 0 or given `value` is nil.
+func (c *AdapterChain) GetOrSet(ctx context.Context, key interface{}, value interface{}, duration time.Duration) (*gvar.Var, error) {
+	return c.GetOrSetFunc(ctx, key, func(ctx context.Context) (interface{}, error) {
+		return value, nil
+	}, duration)
+}
+
+// GetOrSetFunc retrieves and returns the value of `key`, or sets `key` with result of
+// function `f` and returns its result if `key` does not exist in the cache. The key-value
+// pair expires after `duration`.
+//
+// It does not expire if `duration` == 0.
+// It deletes the `key` if `duration` < 0 or given `value` is nil, but it does nothing
+// if the function result is nil.
+func (c *AdapterChain) GetOrSetFunc(ctx context.Context, key interface{}, f Func, duration time.Duration) (*gvar.Var, error) {
+	v, err := c.Get(ctx, key)
+	if err != nil || v != nil {
+		return v, err
+	}
+	value, err := f(ctx)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	if err = c.Set(ctx, key, value, duration); err != nil {
+		return nil, err
+	}
+	return gvar.New(value), nil
+}
+
+// GetOrSetFuncLock retrieves and returns the value of `key`, or sets `key` with result of
+// function `f` and returns its result if `key` does not exist in the cache. The key-value
+// pair expires after `duration`.
+//
+// It does not expire if `duration` == 0.
+// It deletes the `key` if `duration` < 0 or given `value` is nil, but it does nothing
+// if the function result is nil.
+//
+// Note that the function `f` is executed within the mutex lock of the chain.
+func (c *AdapterChain) GetOrSetFuncLock(ctx context.Context, key interface{}, f Func, duration time.Duration) (*gvar.Var, error) {
+	v, err := c.Get(ctx, key)
+	if err != nil || v != nil {
+		return v, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.GetOrSetFunc(ctx, key, f, duration)
+}
+
+// Contains checks and returns true if `key` exists in any layer, or else returns false.
+func (c *AdapterChain) Contains(ctx context.Context, key interface{}) (bool, error) {
+	for _, layer := range c.layers {
+		ok, err := layer.Contains(ctx, key)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// Size returns the number of items in the last layer, after flushing the pending writes.
+func (c *AdapterChain) Size(ctx context.Context) (int, error) {
+	if err := c.Flush(ctx); err != nil {
+		return 0, err
+	}
+	return c.lastLayer().Size(ctx)
+}
+
+// Data returns a copy of all key-value pairs in the last layer, after flushing the pending writes.
+func (c *AdapterChain) Data(ctx context.Context) (map[interface{}]interface{}, error) {
+	if err := c.Flush(ctx); err != nil {
+		return nil, err
+	}
+	return c.lastLayer().Data(ctx)
+}
+
+// Keys returns all keys in the last layer, after flushing the pending writes.
+func (c *AdapterChain) Keys(ctx context.Context) ([]interface{}, error) {
+	if err := c.Flush(ctx); err != nil {
+		return nil, err
+	}
+	return c.lastLayer().Keys(ctx)
+}
+
+// Values returns all values in the last layer, after flushing the pending writes.
+func (c *AdapterChain) Values(ctx context.Context) ([]interface{}, error) {
+	if err := c.Flush(ctx); err != nil {
+		return nil, err
+	}
+	return c.lastLayer().Values(ctx)
+}
+
+// Update updates the value of `key` without changing its expiration and returns the old value.
+// The returned value `exist` is false if the `key` does not exist in the cache.
+//
+// It deletes the `key` if given `value` is nil.
+// It does nothing if `key` does not exist in the cache.
+func (c *AdapterChain) Update(ctx context.Context, key interface{}, value interface{}) (oldValue *gvar.Var, exist bool, err error) {
+	if c.option.Policy == ChainWriteBehind {
+		// Makes sure the key is in the first layer.
+		if _, err = c.Get(ctx, key); err != nil {
+			return
+		}
+		if oldValue, exist, err = c.layers[0].Update(ctx, key, value); err != nil || !exist {
+			return
+		}
+		c.addPending(ctx, key, chainOp{typ: chainOpUpdate, value: value})
+		return
+	}
+	for i := len(c.layers) - 1; i >= 0; i-- {
+		v, ok, err := c.layers[i].Update(ctx, key, value)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			oldValue, exist = v, true
+		}
+	}
+	if exist {
+		c.afterWrite(ctx, []interface{}{key})
+	}
+	return
+}
+
+// UpdateExpire updates the expiration of `key` and returns the old expiration duration value.
+//
+// It returns -1 and does nothing if the `key` does not exist in the cache.
+// It deletes the `key` if `duration` < 0.
+func (c *AdapterChain) UpdateExpire(ctx context.Context, key interface{}, duration time.Duration) (oldDuration time.Duration, err error) {
+	if c.option.Policy == ChainWriteBehind {
+		if _, err = c.Get(ctx, key); err != nil {
+			return
+		}
+		if oldDuration, err = c.layers[0].UpdateExpire(ctx, key, duration); err != nil || oldDuration == -1 {
+			return
+		}
+		c.addPending(ctx, key, chainOp{typ: chainOpUpdateExpire, duration: duration})
+		return
+	}
+	oldDuration = -1
+	for i := len(c.layers) - 1; i >= 0; i-- {
+		d, err := c.layers[i].UpdateExpire(ctx, key, duration)
+		if err != nil {
+			return -1, err
+		}
+		if d != -1 {
+			oldDuration = d
+		}
+	}
+	if oldDuration != -1 {
+		c.afterWrite(ctx, []interface{}{key})
+	}
+	return
+}
+
+// GetExpire retrieves and returns the expiration of `key` in the first layer containing it.
+//
+// It returns 0 if the `key` does not expire.
+// It returns -1 if the `key` does not exist in the cache.
+func (c *AdapterChain) GetExpire(ctx context.Context, key interface{}) (time.Duration, error) {
+	for _, layer := range c.layers {
+		ok, err := layer.Contains(ctx, key)
+		if err != nil {
+			return -1, err
+		}
+		if ok {
+			return layer.GetExpire(ctx, key)
+		}
+	}
+	return -1, nil
+}
+
+// Remove deletes one or more keys from all the layers, and returns its value.
+// If multiple keys are given, it returns the value of the last deleted item.
+func (c *AdapterChain) Remove(ctx context.Context, keys ...interface{}) (lastValue *gvar.Var, err error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	if c.option.Policy == ChainWriteBehind {
+		if lastValue, err = c.layers[0].Remove(ctx, keys...); err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			c.addPending(ctx, key, chainOp{typ: chainOpRemove})
+		}
+		return
+	}
+	for i := len(c.layers) - 1; i >= 0; i-- {
+		v, err := c.layers[i].Remove(ctx, keys...)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil && !v.IsNil() {
+			lastValue = v
+		}
+	}
+	c.afterWrite(ctx, keys)
+	return
+}
+
+// Invalidate removes `keys` from all the layers except the last one, which is usually called when
+// the keys are changed by other instances sharing the last layer, see ChainOption.AfterWrite.
+func (c *AdapterChain) Invalidate(ctx context.Context, keys ...interface{}) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	for i := 0; i < len(c.layers)-1; i++ {
+		if _, err := c.layers[i].Remove(ctx, keys...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Clear clears all data of all the layers, including the pending writes.
+// Note that this function is sensitive and should be carefully used.
+func (c *AdapterChain) Clear(ctx context.Context) error {
+	c.pendMu.Lock()
+	c.pending = make(map[interface{}][]chainOp)
+	c.pendKeys = nil
+	c.pendMu.Unlock()
+	for i := len(c.layers) - 1; i >= 0; i-- {
+		if err := c.layers[i].Clear(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close flushes the pending writes and closes all the layers.
+func (c *AdapterChain) Close(ctx context.Context) error {
+	if !c.closed.Cas(false, true) {
+		return nil
+	}
+	if err := c.Flush(ctx); err != nil {
+		return err
+	}
+	for _, layer := range c.layers {
+		if err := layer.Close(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes the pending writes to the lower layers in write-behind policy.
+// The failed writes are reported to ChainOption.OnFlushError, and the first error is returned.
+func (c *AdapterChain) Flush(ctx context.Context) (err error) {
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+	c.pendMu.Lock()
+	var (
+		pending  = c.pending
+		pendKeys = c.pendKeys
+	)
+	c.pending = make(map[interface{}][]chainOp)
+	c.pendKeys = nil
+	c.pendMu.Unlock()
+	if len(pendKeys) == 0 {
+		return nil
+	}
+	written := make([]interface{}, 0, len(pendKeys))
+	for _, key := range pendKeys {
+		if flushErr := c.flushKey(ctx, key, pending[key]); flushErr != nil {
+			if c.option.OnFlushError != nil {
+				c.option.OnFlushError(ctx, key, flushErr)
+			}
+			if err == nil {
+				err = flushErr
+			}
+			continue
+		}
+		written = append(written, key)
+	}
+	c.afterWrite(ctx, written)
+	return
+}
+
+// flushKey writes `ops` of `key` to the lower layers.
+func (c *AdapterChain) flushKey(ctx context.Context, key interface{}, ops []chainOp) (err error) {
+	for _, op := range ops {
+		for i := len(c.layers) - 1; i > 0; i-- {
+			layer := c.layers[i]
+			switch op.typ {
+			case chainOpSet:
+				var duration time.Duration
+				if op.expireAt > 0 {
+					duration = time.Duration(op.expireAt-gtime.TimestampMilli()) * time.Millisecond
+					if duration <= 0 {
+						// It is already expired.
+						_, err = layer.Remove(ctx, key)
+						break
+					}
+				}
+				err = layer.Set(ctx, key, op.value, duration)
+
+			case chainOpRemove:
+				_, err = layer.Remove(ctx, key)
+
+			case chainOpUpdate:
+				_, _, err = layer.Update(ctx, key, op.value)
+
+			case chainOpUpdateExpire:
+				_, err = layer.UpdateExpire(ctx, key, op.duration)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addPending adds writing operation `op` of `key` to the pending writes,
+// and it triggers flushing if the pending keys reach the flush size.
+func (c *AdapterChain) addPending(ctx context.Context, key interface{}, op chainOp) {
+	c.pendMu.Lock()
+	ops, ok := c.pending[key]
+	if !ok {
+		c.pendKeys = append(c.pendKeys, key)
+	}
+	switch op.typ {
+	case chainOpSet, chainOpRemove:
+		// It overwrites the previous operations of the key.
+		ops = []chainOp{op}
+	default:
+		ops = append(ops, op)
+	}
+	c.pending[key] = ops
+	needFlush := len(c.pendKeys) >= c.option.FlushSize
+	c.pendMu.Unlock()
+	if needFlush {
+		go func() {
+			_ = c.Flush(ctx)
+		}()
+	}
+}
+
+// flushTimerJob flushes the pending writes periodically.
+func (c *AdapterChain) flushTimerJob(ctx context.Context) {
+	if c.closed.Val() {
+		gtimer.Exit()
+		return
+	}
+	_ = c.Flush(ctx)
+}
+
+// backFill sets `key`-`value` pair read from layer `index` to its upper layers.
+func (c *AdapterChain) backFill(ctx context.Context, index int, key interface{}, value interface{}) error {
+	duration, err := c.layers[index].GetExpire(ctx, key)
+	if err != nil {
+		return err
+	}
+	if duration < 0 {
+		// It is expired or removed in the meantime.
+		return nil
+	}
+	if c.option.FillTTL > 0 && (duration == 0 || duration > c.option.FillTTL) {
+		duration = c.option.FillTTL
+	}
+	for i := index - 1; i >= 0; i-- {
+		if err = c.layers[i].Set(ctx, key, value, duration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// afterWrite calls ChainOption.AfterWrite with written `keys`.
+func (c *AdapterChain) afterWrite(ctx context.Context, keys []interface{}) {
+	if c.option.AfterWrite != nil && len(keys) > 0 {
+		c.option.AfterWrite(ctx, keys)
+	}
+}
+
+func (c *AdapterChain) lastLayer() Adapter {
+	return c.layers[len(c.layers)-1]
+}
+
+func mapKeys(data map[interface{}]interface{}) []interface{} {
+	keys := make([]interface{}, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -39,6 +39,7 @@ type AdapterMemory struct {
 type adapterMemoryItem struct {
 	v interface{} // Value.
 	e int64       // Expire timestamp in milliseconds.
+	f ExpireFunc  // Callback function when expired, see SetWithCallback.
 }
 
 // Internal event item.
@@ -87,6 +88,27 @@ func (c *AdapterMemory) Set(ctx context.Context, key interface{}, value interfac
 	return nil
 }
 
+// SetWithCallback sets cache with `key`-`value` pair, which is expired after `duration`,
+// and calls `onExpire` when it is expired.
+//
+// The `onExpire` is called in the asynchronous expiration clearing goroutine, but not called if the
+// `key` is removed, evicted by LRU or overwritten before expired. So it should return quickly.
+func (c *AdapterMemory) SetWithCallback(
+	ctx context.Context, key interface{}, value interface{}, duration time.Duration, onExpire ExpireFunc,
+) error {
+	expireTime := c.getInternalExpire(duration)
+	c.data.Set(key, adapterMemoryItem{
+		v: value,
+		e: expireTime,
+		f: onExpire,
+	})
+	c.eventList.PushBack(&adapterMemoryEvent{
+		k: key,
+		e: expireTime,
+	})
+	return nil
+}
+
 // SetMap batch sets cache with key-value pairs by `data` map, which is expired after `duration`.
 //
 // It does not expire if `duration` == 0.
@@ -452,7 +474,7 @@ func (c *AdapterMemory) syncEventAndClearExpired(ctx context.Context) {
 		if expireSet = c.expireSets.Get(expireTime); expireSet != nil {
 			// Iterating the set to delete all keys in it.
 			expireSet.Iterator(func(key interface{}) bool {
-				c.clearByKey(key)
+				c.clearByKey(ctx, key)
 				return true
 			})
 			// Deleting the set after all of its keys are deleted.
@@ -463,9 +485,11 @@ func (c *AdapterMemory) syncEventAndClearExpired(ctx context.Context) {
 
 // clearByKey deletes the key-value pair with given `key`.
 // The parameter `force` specifies whether doing this deleting forcibly.
-func (c *AdapterMemory) clearByKey(key interface{}, force ...bool) {
+func (c *AdapterMemory) clearByKey(ctx context.Context, key interface{}, force ...bool) {
 	// Doubly check before really deleting it from cache.
-	c.data.DeleteWithDoubleCheck(key, force...)
+	if expiredItem := c.data.DeleteWithDoubleCheck(key, force...); expiredItem != nil {
+		expiredItem.f(ctx, key, expiredItem.v)
+	}
 
 	// Deleting its expiration time from `expireTimes`.
 	c.expireTimes.Delete(key)
@@ -37,6 +37,7 @@ func (d *adapterMemoryData) Update(key interface{}, value interface{}) (oldValue
 		d.data[key] = adapterMemoryItem{
 			v: value,
 			e: item.e,
+			f: item.f,
 		}
 		return item.v, true, nil
 	}
@@ -54,6 +55,7 @@ func (d *adapterMemoryData) UpdateExpire(key interface{}, expireTime int64) (old
 		d.data[key] = adapterMemoryItem{
 			v: item.v,
 			e: expireTime,
+			f: item.f,
 		}
 		return time.Duration(item.e-gtime.TimestampMilli()) * time.Millisecond, nil
 	}
@@ -90,6 +92,31 @@ func (d *adapterMemoryData) Data() (map[interface{}]interface{}, error) {
 	return m, nil
 }
 
+// Items returns a copy of all unexpired items in the cache as map type.
+func (d *adapterMemoryData) Items() map[interface{}]adapterMemoryItem {
+	d.mu.RLock()
+	m := make(map[interface{}]adapterMemoryItem, len(d.data))
+	for k, v := range d.data {
+		if !v.IsExpired() {
+			m[k] = v
+		}
+	}
+	d.mu.RUnlock()
+	return m
+}
+
+// SetIfNotExist sets `key` with `item` if `key` does not exist or is expired in the cache.
+// It returns true if it sets successfully.
+func (d *adapterMemoryData) SetIfNotExist(key interface{}, item adapterMemoryItem) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if v, ok := d.data[key]; ok && !v.IsExpired() {
+		return false
+	}
+	d.data[key] = item
+	return true
+}
+
 // Keys returns all keys in the cache as slice.
 func (d *adapterMemoryData) Keys() ([]interface{}, error) {
 	d.mu.RLock()
@@ -196,11 +223,19 @@ func (d *adapterMemoryData) SetWithLock(ctx context.Context, key interface{}, va
 	return value, nil
 }
 
-func (d *adapterMemoryData) DeleteWithDoubleCheck(key interface{}, force ...bool) {
+// DeleteWithDoubleCheck deletes `key` if it is expired or `force` is true,
+// and returns the deleted expired item which has expiration callback.
+func (d *adapterMemoryData) DeleteWithDoubleCheck(key interface{}, force ...bool) (expiredItem *adapterMemoryItem) {
 	d.mu.Lock()
 	// Doubly check before really deleting it from cache.
-	if item, ok := d.data[key]; (ok && item.IsExpired()) || (len(force) > 0 && force[0]) {
+	if item, ok := d.data[key]; ok && item.IsExpired() {
+		delete(d.data, key)
+		if item.f != nil {
+			expiredItem = &item
+		}
+	} else if len(force) > 0 && force[0] {
 		delete(d.data, key)
 	}
 	d.mu.Unlock()
+	return
 }
@@ -96,7 +96,7 @@ func (lru *adapterMemoryLru) SyncAndClear(ctx context.Context) {
 	// Data cleaning up.
 	for clearLength := lru.Size() - lru.cache.cap; clearLength > 0; clearLength-- {
 		if topKey := lru.Pop(); topKey != nil {
-			lru.cache.clearByKey(topKey, true)
+			lru.cache.clearByKey(ctx, topKey, true)
 		}
 	}
 }
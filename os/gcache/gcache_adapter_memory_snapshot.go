@@ -0,0 +1,171 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"bufio"
+	"context"
+	"encoding/gob"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// adapterMemorySnapshotHeader is the header of the snapshot file.
+type adapterMemorySnapshotHeader struct {
+	Version int   // Version of snapshot format.
+	Time    int64 // Snapshot timestamp in milliseconds.
+	Size    int   // Count of items.
+}
+
+// adapterMemorySnapshotItem is the item of the snapshot file.
+type adapterMemorySnapshotItem struct {
+	Key    interface{} // Key.
+	Value  interface{} // Value.
+	Expire int64       // Expire timestamp in milliseconds.
+}
+
+const (
+	adapterMemorySnapshotVersion = 1
+)
+
+// SaveTo saves the snapshot of all unexpired items in the cache to file `path`, which is usually
+// called on shutdown and loaded by LoadFrom on startup, so that the warm cache survives restarts.
+//
+// The items are encoded using encoding/gob, so the custom types of keys and values should be
+// registered using gob.Register before saving and loading. The expiration callbacks of items are
+// not saved. The file is written atomically, which is renamed from a temporary file after writing.
+func (c *AdapterMemory) SaveTo(ctx context.Context, path string) (err error) {
+	var (
+		items   = c.data.Items()
+		tmpPath = path + ".tmp"
+	)
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return gerror.Wrapf(err, `create directory of "%s" failed`, path)
+	}
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return gerror.Wrapf(err, `create file "%s" failed`, tmpPath)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmpPath)
+		}
+	}()
+	var (
+		writer  = bufio.NewWriter(file)
+		encoder = gob.NewEncoder(writer)
+	)
+	err = encoder.Encode(adapterMemorySnapshotHeader{
+		Version: adapterMemorySnapshotVersion,
+		Time:    gtime.TimestampMilli(),
+		Size:    len(items),
+	})
+	for k, v := range items {
+		if err != nil {
+			break
+		}
+		err = encoder.Encode(adapterMemorySnapshotItem{
+			Key:    k,
+			Value:  v.v,
+			Expire: v.e,
+		})
+		if err != nil {
+			err = gerror.Wrapf(err, `encode cache item of key "%v" failed, type might not be registered by gob.Register`, k)
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return gerror.Wrapf(err, `save cache snapshot to "%s" failed`, path)
+	}
+	if err = os.Rename(tmpPath, path); err != nil {
+		return gerror.Wrapf(err, `rename "%s" to "%s" failed`, tmpPath, path)
+	}
+	return nil
+}
+
+// LoadFrom loads the snapshot saved by SaveTo from file `path` into the cache.
+//
+// The items keep their expiration timestamps in the snapshot, which means the TTL of items is
+// reduced by the time elapsed since the snapshot is saved, and the items expired are dropped.
+// The keys already existing in the cache are not overwritten, as they are fresher than the snapshot.
+func (c *AdapterMemory) LoadFrom(ctx context.Context, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return gerror.Wrapf(err, `open file "%s" failed`, path)
+	}
+	defer file.Close()
+	var (
+		header  adapterMemorySnapshotHeader
+		decoder = gob.NewDecoder(bufio.NewReader(file))
+	)
+	if err = decoder.Decode(&header); err != nil {
+		return gerror.Wrapf(err, `decode cache snapshot header from "%s" failed`, path)
+	}
+	if header.Version != adapterMemorySnapshotVersion {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`unsupported cache snapshot version "%d" of "%s"`,
+			header.Version, path,
+		)
+	}
+	var (
+		loaded int
+		now    = gtime.TimestampMilli()
+	)
+	for {
+		var item adapterMemorySnapshotItem
+		if err = decoder.Decode(&item); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return gerror.Wrapf(err, `decode cache snapshot item from "%s" failed`, path)
+		}
+		if item.Expire <= now || item.Value == nil {
+			continue
+		}
+		if c.data.SetIfNotExist(item.Key, adapterMemoryItem{v: item.Value, e: item.Expire}) {
+			c.eventList.PushBack(&adapterMemoryEvent{
+				k: item.Key,
+				e: item.Expire,
+			})
+			loaded++
+		}
+	}
+	intlog.Printf(ctx, `loaded %d of %d items from cache snapshot "%s"`, loaded, header.Size, path)
+	return nil
+}
+
+// SaveTo saves the snapshot of all unexpired items in the cache to file `path`.
+// It is only supported by the memory adapter, see AdapterMemory.SaveTo.
+func (c *Cache) SaveTo(ctx context.Context, path string) error {
+	adapter, ok := c.localAdapter.(*AdapterMemory)
+	if !ok {
+		return gerror.NewCode(gcode.CodeNotSupported, `cache snapshot is only supported by memory adapter`)
+	}
+	return adapter.SaveTo(ctx, path)
+}
+
+// LoadFrom loads the snapshot saved by SaveTo from file `path` into the cache.
+// It is only supported by the memory adapter, see AdapterMemory.LoadFrom.
+func (c *Cache) LoadFrom(ctx context.Context, path string) error {
+	adapter, ok := c.localAdapter.(*AdapterMemory)
+	if !ok {
+		return gerror.NewCode(gcode.CodeNotSupported, `cache snapshot is only supported by memory adapter`)
+	}
+	return adapter.LoadFrom(ctx, path)
+}
@@ -8,6 +8,7 @@ package gcache
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/gogf/gf/v2/os/gtimer"
@@ -17,6 +18,8 @@ import (
 // Cache struct.
 type Cache struct {
 	localAdapter
+	refreshAt  sync.Map // Soft expiration timestamps in milliseconds of keys in refresh-ahead mode.
+	refreshing sync.Map // Keys being refreshed, which ensures only one refreshing of a key at the same time.
 }
 
 // localAdapter is alias of Adapter, for embedded attribute purpose only.
@@ -0,0 +1,126 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// iAdapterSetWithCallback is the interface for adapter supporting expiration callback.
+type iAdapterSetWithCallback interface {
+	SetWithCallback(
+		ctx context.Context, key interface{}, value interface{}, duration time.Duration, onExpire ExpireFunc,
+	) error
+}
+
+// refreshContext is the context for asynchronous refreshing, which keeps the values of
+// the context but is never canceled along with the context, like the finished request.
+type refreshContext struct {
+	context.Context
+}
+
+func (refreshContext) Deadline() (deadline time.Time, ok bool) { return }
+func (refreshContext) Done() <-chan struct{}                   { return nil }
+func (refreshContext) Err() error                              { return nil }
+
+// SetWithCallback sets cache with `key`-`value` pair, which is expired after `duration`,
+// and calls `onExpire` when it is expired, like releasing the resources of the value.
+//
+// The `onExpire` is called asynchronously after expiration, but not called if the `key` is
+// removed or overwritten before expired. It returns error if the adapter does not support it,
+// which is supported by the default memory adapter.
+func (c *Cache) SetWithCallback(
+	ctx context.Context, key interface{}, value interface{}, duration time.Duration, onExpire ExpireFunc,
+) error {
+	if adapter, ok := c.localAdapter.(iAdapterSetWithCallback); ok {
+		return adapter.SetWithCallback(ctx, key, value, duration, onExpire)
+	}
+	return gerror.NewCodef(
+		gcode.CodeNotSupported,
+		`adapter "%T" does not support expiration callback`, c.localAdapter,
+	)
+}
+
+// GetOrSetFuncRefresh retrieves and returns the value of `key` in refresh-ahead mode, or sets `key`
+// with result of function `f` and returns its result if `key` does not exist in the cache.
+//
+// The key-value pair expires after `hardTTL`, and it does not expire if `hardTTL` == 0. When it is
+// retrieved after `softTTL`, it is refreshed asynchronously by `f` and the stale value is returned
+// until the refreshing is done, so the hot keys never miss the cache. Only one refreshing of a key
+// runs at the same time, and the stale value is kept if `f` fails or returns nil.
+//
+// Note that it refreshes only the keys set by this function.
+func (c *Cache) GetOrSetFuncRefresh(
+	ctx context.Context, key interface{}, f Func, softTTL, hardTTL time.Duration,
+) (*gvar.Var, error) {
+	if softTTL <= 0 || (hardTTL != 0 && softTTL >= hardTTL) {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`invalid soft TTL "%s", which should be positive and less than hard TTL "%s"`,
+			softTTL, hardTTL,
+		)
+	}
+	v, err := c.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		value, err := f(ctx)
+		if err != nil || value == nil {
+			return nil, err
+		}
+		if err = c.setRefreshValue(ctx, key, value, softTTL, hardTTL); err != nil {
+			return nil, err
+		}
+		return gvar.New(value), nil
+	}
+	if refreshAt, ok := c.refreshAt.Load(key); ok && gtime.TimestampMilli() >= refreshAt.(int64) {
+		if _, refreshing := c.refreshing.LoadOrStore(key, struct{}{}); !refreshing {
+			go c.doRefresh(refreshContext{ctx}, key, f, softTTL, hardTTL)
+		}
+	}
+	return v, nil
+}
+
+// doRefresh refreshes the value of `key` with result of function `f`.
+func (c *Cache) doRefresh(ctx context.Context, key interface{}, f Func, softTTL, hardTTL time.Duration) {
+	defer c.refreshing.Delete(key)
+	defer func() {
+		if exception := recover(); exception != nil {
+			intlog.Errorf(ctx, `refreshing cache key "%v" panics: %+v`, key, exception)
+		}
+	}()
+	value, err := f(ctx)
+	if err != nil {
+		intlog.Errorf(ctx, `refreshing cache key "%v" failed: %+v`, key, err)
+		return
+	}
+	if value == nil {
+		return
+	}
+	if err = c.setRefreshValue(ctx, key, value, softTTL, hardTTL); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+}
+
+// setRefreshValue sets `key`-`value` pair in refresh-ahead mode.
+func (c *Cache) setRefreshValue(
+	ctx context.Context, key interface{}, value interface{}, softTTL, hardTTL time.Duration,
+) error {
+	if err := c.Set(ctx, key, value, hardTTL); err != nil {
+		return err
+	}
+	c.refreshAt.Store(key, gtime.TimestampMilli()+softTTL.Milliseconds())
+	return nil
+}
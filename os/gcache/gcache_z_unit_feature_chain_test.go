@@ -0,0 +1,184 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// failingAdapter is the adapter of which all writes fail.
+type failingAdapter struct {
+	gcache.Adapter
+}
+
+func (a failingAdapter) Set(ctx context.Context, key interface{}, value interface{}, duration time.Duration) error {
+	return gerror.New("set failed")
+}
+
+func (a failingAdapter) SetMap(ctx context.Context, data map[interface{}]interface{}, duration time.Duration) error {
+	return gerror.New("set failed")
+}
+
+func TestCache_Chain_WriteThrough(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			l1    = gcache.New()
+			l2    = gcache.New()
+			cache = gcache.NewChain(l1, l2)
+		)
+		t.AssertNil(cache.Set(ctx, "k1", "v1", 0))
+		t.Assert(l1.MustGet(ctx, "k1"), "v1")
+		t.Assert(l2.MustGet(ctx, "k1"), "v1")
+
+		// Back-filling.
+		t.AssertNil(l2.Set(ctx, "k2", "v2", time.Minute))
+		t.Assert(l1.MustGet(ctx, "k2"), nil)
+		t.Assert(cache.MustGet(ctx, "k2"), "v2")
+		t.Assert(l1.MustGet(ctx, "k2"), "v2")
+		expire, err := l1.GetExpire(ctx, "k2")
+		t.AssertNil(err)
+		t.Assert(expire > 50*time.Second, true)
+
+		// Update.
+		oldValue, exist, err := cache.Update(ctx, "k1", "v11")
+		t.AssertNil(err)
+		t.Assert(exist, true)
+		t.Assert(oldValue, "v1")
+		t.Assert(l1.MustGet(ctx, "k1"), "v11")
+		t.Assert(l2.MustGet(ctx, "k1"), "v11")
+
+		// Remove.
+		value, err := cache.Remove(ctx, "k1")
+		t.AssertNil(err)
+		t.Assert(value, "v11")
+		t.Assert(l1.MustGet(ctx, "k1"), nil)
+		t.Assert(l2.MustGet(ctx, "k1"), nil)
+
+		// GetOrSetFunc.
+		v, err := cache.GetOrSetFuncLock(ctx, "k3", func(ctx context.Context) (interface{}, error) {
+			return "v3", nil
+		}, 0)
+		t.AssertNil(err)
+		t.Assert(v, "v3")
+		t.Assert(l2.MustGet(ctx, "k3"), "v3")
+		t.Assert(cache.MustSize(ctx), 2)
+	})
+	// Lower layer fails.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			l1    = gcache.New()
+			cache = gcache.NewChain(l1, failingAdapter{gcache.New()})
+		)
+		t.AssertNE(cache.Set(ctx, "k", "v", 0), nil)
+		t.Assert(l1.MustGet(ctx, "k"), nil)
+	})
+}
+
+func TestCache_Chain_FillTTL(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			l1    = gcache.New()
+			l2    = gcache.New()
+			cache = gcache.NewWithAdapter(gcache.NewAdapterChain(gcache.ChainOption{
+				FillTTL: time.Second,
+			}, l1, l2))
+		)
+		t.AssertNil(l2.Set(ctx, "k", "v", 0))
+		t.Assert(cache.MustGet(ctx, "k"), "v")
+		expire, err := l1.GetExpire(ctx, "k")
+		t.AssertNil(err)
+		t.Assert(expire > 0 && expire <= time.Second, true)
+	})
+}
+
+func TestCache_Chain_WriteBehind(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			l1      = gcache.New()
+			l2      = gcache.New()
+			written = garray.NewArray(true)
+			chain   = gcache.NewAdapterChain(gcache.ChainOption{
+				Policy:        gcache.ChainWriteBehind,
+				FlushInterval: time.Hour,
+				AfterWrite: func(ctx context.Context, keys []interface{}) {
+					written.Append(keys...)
+				},
+			}, l1, l2)
+			cache = gcache.NewWithAdapter(chain)
+		)
+		t.AssertNil(cache.Set(ctx, "k1", "v1", 0))
+		t.AssertNil(cache.Set(ctx, "k2", "v2", time.Minute))
+		t.AssertNil(cache.Set(ctx, "k2", "v22", time.Minute))
+		_, err := cache.UpdateExpire(ctx, "k2", time.Hour)
+		t.AssertNil(err)
+		t.AssertNil(cache.Set(ctx, "k3", "v3", 0))
+		_, err = cache.Remove(ctx, "k3")
+		t.AssertNil(err)
+		t.Assert(l1.MustGet(ctx, "k1"), "v1")
+		t.Assert(l2.MustGet(ctx, "k1"), nil)
+		t.Assert(written.Len(), 0)
+
+		t.AssertNil(chain.Flush(ctx))
+		t.Assert(l2.MustGet(ctx, "k1"), "v1")
+		t.Assert(l2.MustGet(ctx, "k2"), "v22")
+		t.Assert(l2.MustGet(ctx, "k3"), nil)
+		expire, err := l2.GetExpire(ctx, "k2")
+		t.AssertNil(err)
+		t.Assert(expire > time.Minute, true)
+		t.Assert(written.Slice(), []interface{}{"k1", "k2", "k3"})
+
+		// Invalidate.
+		t.AssertNil(chain.Invalidate(ctx, "k1"))
+		t.Assert(l1.MustGet(ctx, "k1"), nil)
+		t.Assert(cache.MustGet(ctx, "k1"), "v1")
+	})
+	// Flushing by size and error hook.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			failed = garray.NewArray(true)
+			chain  = gcache.NewAdapterChain(gcache.ChainOption{
+				Policy:        gcache.ChainWriteBehind,
+				FlushInterval: time.Hour,
+				FlushSize:     2,
+				OnFlushError: func(ctx context.Context, key interface{}, err error) {
+					failed.Append(key)
+				},
+			}, gcache.New(), failingAdapter{gcache.New()})
+		)
+		t.AssertNil(chain.Set(ctx, "k1", "v1", 0))
+		t.AssertNil(chain.Set(ctx, "k2", "v2", 0))
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(failed.Len(), 2)
+		v, err := chain.Get(ctx, "k1")
+		t.AssertNil(err)
+		t.Assert(v, "v1")
+	})
+	// Flushing periodically and closing.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			l2    = gcache.New()
+			chain = gcache.NewAdapterChain(gcache.ChainOption{
+				Policy:        gcache.ChainWriteBehind,
+				FlushInterval: 100 * time.Millisecond,
+			}, gcache.New(), l2)
+		)
+		t.AssertNil(chain.Set(ctx, "k1", "v1", 0))
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(l2.MustGet(ctx, "k1"), "v1")
+
+		t.AssertNil(chain.Set(ctx, "k2", "v2", 0))
+		t.AssertNil(chain.Close(ctx))
+		t.Assert(l2.MustGet(ctx, "k2"), "v2")
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func TestCache_SetWithCallback(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cache    = gcache.New()
+			expired  = garray.NewStrArray(true)
+			onExpire = func(ctx context.Context, key interface{}, value interface{}) {
+				expired.Append(key.(string) + "=" + value.(string))
+			}
+		)
+		t.AssertNil(cache.SetWithCallback(ctx, "k1", "v1", 100*time.Millisecond, onExpire))
+		t.AssertNil(cache.SetWithCallback(ctx, "k2", "v2", 100*time.Millisecond, onExpire))
+		t.AssertNil(cache.SetWithCallback(ctx, "k3", "v3", 100*time.Millisecond, onExpire))
+		t.AssertNil(cache.SetWithCallback(ctx, "k4", "v4", 0, onExpire))
+		// Updating value keeps the callback.
+		_, _, err := cache.Update(ctx, "k2", "v22")
+		t.AssertNil(err)
+		// Removed and overwritten keys have no callback.
+		_, err = cache.Remove(ctx, "k3")
+		t.AssertNil(err)
+		t.AssertNil(cache.Set(ctx, "k1", "v11", 100*time.Millisecond))
+
+		time.Sleep(3 * time.Second)
+		t.Assert(expired.Slice(), []string{"k2=v22"})
+		t.Assert(cache.MustGet(ctx, "k4"), "v4")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		cache := gcache.NewWithAdapter(gcache.NewAdapterRedis(nil))
+		err := cache.SetWithCallback(ctx, "k", "v", time.Second, func(ctx context.Context, key, value interface{}) {})
+		t.AssertNE(err, nil)
+	})
+}
+
+func TestCache_GetOrSetFuncRefresh(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cache   = gcache.New()
+			counter = gtype.NewInt()
+			loader  = func(ctx context.Context) (interface{}, error) {
+				time.Sleep(50 * time.Millisecond)
+				return counter.Add(1), nil
+			}
+		)
+		v, err := cache.GetOrSetFuncRefresh(ctx, "k", loader, 200*time.Millisecond, time.Minute)
+		t.AssertNil(err)
+		t.Assert(v, 1)
+
+		// Fresh.
+		v, err = cache.GetOrSetFuncRefresh(ctx, "k", loader, 200*time.Millisecond, time.Minute)
+		t.AssertNil(err)
+		t.Assert(v, 1)
+
+		// Stale value is served while refreshing, and it refreshes only once.
+		time.Sleep(300 * time.Millisecond)
+		for i := 0; i < 10; i++ {
+			v, err = cache.GetOrSetFuncRefresh(ctx, "k", loader, 200*time.Millisecond, time.Minute)
+			t.AssertNil(err)
+			t.Assert(v, 1)
+		}
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(counter.Val(), 2)
+		v, err = cache.GetOrSetFuncRefresh(ctx, "k", loader, 200*time.Millisecond, time.Minute)
+		t.AssertNil(err)
+		t.Assert(v, 2)
+	})
+	// Stale value is kept if refreshing fails.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			cache  = gcache.New()
+			failed = gtype.NewBool()
+			loader = func(ctx context.Context) (interface{}, error) {
+				if failed.Val() {
+					return nil, errors.New("loading failed")
+				}
+				return "v", nil
+			}
+		)
+		v, err := cache.GetOrSetFuncRefresh(ctx, "k", loader, 100*time.Millisecond, time.Minute)
+		t.AssertNil(err)
+		t.Assert(v, "v")
+
+		failed.Set(true)
+		time.Sleep(200 * time.Millisecond)
+		v, err = cache.GetOrSetFuncRefresh(ctx, "k", loader, 100*time.Millisecond, time.Minute)
+		t.AssertNil(err)
+		t.Assert(v, "v")
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(cache.MustGet(ctx, "k"), "v")
+	})
+	// Invalid TTL.
+	gtest.C(t, func(t *gtest.T) {
+		loader := func(ctx context.Context) (interface{}, error) {
+			return "v", nil
+		}
+		_, err := gcache.GetOrSetFuncRefresh(ctx, "k", loader, time.Minute, time.Second)
+		t.AssertNE(err, nil)
+		_, err = gcache.GetOrSetFuncRefresh(ctx, "k", loader, 0, time.Second)
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,66 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache_test
+
+import (
+	"context"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+type snapshotUser struct {
+	Id   int
+	Name string
+}
+
+func init() {
+	gob.Register(snapshotUser{})
+}
+
+func TestCache_SaveTo_LoadFrom(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx   = context.TODO()
+			path  = gfile.Join(gfile.Temp(guid.S()), "cache.snapshot")
+			cache = gcache.New()
+		)
+		defer gfile.Remove(gfile.Dir(path))
+
+		t.AssertNil(cache.Set(ctx, 1, "int key", 0))
+		t.AssertNil(cache.Set(ctx, "user", snapshotUser{Id: 1, Name: "john"}, time.Minute))
+		t.AssertNil(cache.Set(ctx, "short", "v", 500*time.Millisecond))
+		t.AssertNil(cache.Set(ctx, "exist", "old", 0))
+		t.AssertNil(cache.SaveTo(ctx, path))
+		t.Assert(gfile.Exists(path), true)
+		t.Assert(gfile.Exists(path+".tmp"), false)
+
+		time.Sleep(600 * time.Millisecond)
+		restored := gcache.New()
+		t.AssertNil(restored.Set(ctx, "exist", "new", 0))
+		t.AssertNil(restored.LoadFrom(ctx, path))
+		t.Assert(restored.MustGet(ctx, 1), "int key")
+		t.Assert(restored.MustGet(ctx, "user").Val(), snapshotUser{Id: 1, Name: "john"})
+		t.Assert(restored.MustContains(ctx, "short"), false)
+		t.Assert(restored.MustGet(ctx, "exist"), "new")
+		expire := restored.MustGetExpire(ctx, "user")
+		t.Assert(expire > 0 && expire < time.Minute, true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx   = context.TODO()
+			cache = gcache.NewWithAdapter(gcache.NewAdapterRedis(nil))
+		)
+		t.AssertNE(cache.SaveTo(ctx, gfile.Temp(guid.S())), nil)
+		t.AssertNE(gcache.New().LoadFrom(ctx, gfile.Temp(guid.S())), nil)
+	})
+}
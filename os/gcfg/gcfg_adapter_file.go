@@ -26,6 +26,7 @@ type AdapterFile struct {
 	defaultName   string           // Default configuration file name.
 	searchPaths   *garray.StrArray // Searching path array.
 	jsonMap       *gmap.StrAnyMap  // The pared JSON objects for configuration files.
+	watchers      *gmap.StrAnyMap  // Watcher functions for configuration file changes, which is name to AdapterFileWatchFunc.
 	violenceCheck bool             // Whether it does violence check in value index searching. It affects the performance when set true(false in default).
 }
 
@@ -68,6 +69,7 @@ func NewAdapterFile(file ...string) (*AdapterFile, error) {
 		defaultName: name,
 		searchPaths: garray.NewStrArray(true),
 		jsonMap:     gmap.NewStrAnyMap(true),
+		watchers:    gmap.NewStrAnyMap(true),
 	}
 	// Customized dir path from env/cmd.
 	if customPath := command.GetOptWithEnv(commandEnvKeyForPath); customPath != "" {
@@ -280,6 +282,11 @@ func (c *AdapterFile) getJson(fileName ...string) (configJson *gjson.Json, err e
 		if filePath != "" && !gres.Contains(filePath) {
 			_, err = gfsnotify.Add(filePath, func(event *gfsnotify.Event) {
 				c.jsonMap.Remove(usedFileName)
+				// The watchers are not notified for the removed file, which cannot be reloaded.
+				if event.IsRemove() || !gfile.Exists(event.Path) {
+					return
+				}
+				c.notifyWatchers(usedFileName)
 			})
 			if err != nil {
 				return nil
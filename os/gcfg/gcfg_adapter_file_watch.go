@@ -0,0 +1,47 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// AdapterFileWatchFunc is the function called when a loaded configuration file changes.
+// The parameter `file` is the configuration file name, like "config.yaml".
+type AdapterFileWatchFunc func(ctx context.Context, file string)
+
+// AddWatcher adds watcher function `f` with unique `name`, which is called when any loaded
+// configuration file of current adapter changes. The configuration cache of the file is
+// already refreshed when `f` is called, so `f` can retrieve the latest configuration.
+//
+// Note that only the files that have been loaded are watched.
+func (c *AdapterFile) AddWatcher(name string, f AdapterFileWatchFunc) {
+	c.watchers.Set(name, f)
+}
+
+// RemoveWatcher removes the watcher function of `name`.
+func (c *AdapterFile) RemoveWatcher(name string) {
+	c.watchers.Remove(name)
+}
+
+// GetWatcherNames returns the names of all the watcher functions.
+func (c *AdapterFile) GetWatcherNames() []string {
+	return c.watchers.Keys()
+}
+
+// notifyWatchers calls all the watcher functions for changed `file`.
+func (c *AdapterFile) notifyWatchers(file string) {
+	var ctx = gctx.New()
+	// The functions are called without lock, so that they can add or remove watchers.
+	for _, v := range c.watchers.Values() {
+		if f, ok := v.(AdapterFileWatchFunc); ok {
+			f(ctx, file)
+		}
+	}
+}
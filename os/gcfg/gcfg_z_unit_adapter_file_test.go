@@ -9,11 +9,16 @@
 package gcfg_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/os/gcfg"
 	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gconv"
 )
 
 func TestAdapterFile_SetPath(t *testing.T) {
@@ -119,3 +124,40 @@ func TestAdapterFile_Set(t *testing.T) {
 		t.Assert(c.MustGet(ctx, "log-path").String(), "custom-logs")
 	})
 }
+
+func TestAdapterFile_Watcher(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dirPath  = gfile.Temp(gtime.TimestampNanoStr())
+			filePath = gfile.Join(dirPath, "config.yaml")
+			changed  = gtype.NewInt()
+		)
+		t.AssertNil(gfile.PutContents(filePath, "name: john"))
+		defer gfile.Remove(dirPath)
+
+		c, err := gcfg.NewAdapterFile("config.yaml")
+		t.AssertNil(err)
+		t.AssertNil(c.SetPath(dirPath))
+		t.Assert(c.MustGet(ctx, "name"), "john")
+
+		c.AddWatcher("test", func(ctx context.Context, file string) {
+			if file != "config.yaml" {
+				return
+			}
+			if v, err := c.Get(ctx, "name"); err == nil && gconv.String(v) == "smith" {
+				changed.Set(1)
+			}
+		})
+		defer c.RemoveWatcher("test")
+		t.Assert(c.GetWatcherNames(), []string{"test"})
+
+		t.AssertNil(gfile.PutContents(filePath, "name: smith"))
+		for i := 0; i < 50 && changed.Val() == 0; i++ {
+			time.Sleep(100 * time.Millisecond)
+		}
+		t.Assert(changed.Val(), 1)
+
+		c.RemoveWatcher("test")
+		t.Assert(len(c.GetWatcherNames()), 0)
+	})
+}
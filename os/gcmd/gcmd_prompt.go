@@ -0,0 +1,274 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package gcmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gvalid"
+)
+
+// Prompt reads user inputs interactively, which is usually used in command handlers
+// for setup wizards, like confirmation, selection, password and text inputs.
+type Prompt struct {
+	in     io.Reader     // Input stream of user inputs.
+	reader *bufio.Reader // Buffered reader of `in`.
+	out    io.Writer     // Output stream of prompt messages.
+}
+
+var (
+	// defaultPrompt is the default prompt reading from stdin and writing to stdout.
+	defaultPrompt = NewPrompt(nil, nil)
+)
+
+// NewPrompt creates and returns a Prompt reading user inputs from `in` and writing messages to `out`.
+// It uses os.Stdin and os.Stdout if `in` or `out` is nil.
+func NewPrompt(in io.Reader, out io.Writer) *Prompt {
+	if in == nil {
+		in = os.Stdin
+	}
+	if out == nil {
+		out = os.Stdout
+	}
+	return &Prompt{
+		in:     in,
+		reader: bufio.NewReader(in),
+		out:    out,
+	}
+}
+
+// Confirm asks a yes/no question using the default prompt, see Prompt.Confirm.
+func Confirm(message string, def ...bool) bool {
+	return defaultPrompt.Confirm(message, def...)
+}
+
+// Select asks to select one of `options` using the default prompt, see Prompt.Select.
+func Select(message string, options []string, def ...int) (int, error) {
+	return defaultPrompt.Select(message, options, def...)
+}
+
+// MultiSelect asks to select some of `options` using the default prompt, see Prompt.MultiSelect.
+func MultiSelect(message string, options []string, def ...[]int) ([]int, error) {
+	return defaultPrompt.MultiSelect(message, options, def...)
+}
+
+// Password asks a password without echoing using the default prompt, see Prompt.Password.
+func Password(message string) (string, error) {
+	return defaultPrompt.Password(message)
+}
+
+// Input asks a text validated by `rules` using the default prompt, see Prompt.Input.
+func Input(ctx context.Context, message string, rules string, def ...string) (string, error) {
+	return defaultPrompt.Input(ctx, message, rules, def...)
+}
+
+// Confirm prints `message` and asks a yes/no question, which accepts "y", "yes", "n" and "no"
+// case-insensitively. It returns `def` if user inputs nothing or the input stream ends,
+// and the default value is false if `def` is not given.
+func (p *Prompt) Confirm(message string, def ...bool) bool {
+	var (
+		defValue = len(def) > 0 && def[0]
+		hint     = "[y/N]"
+	)
+	if defValue {
+		hint = "[Y/n]"
+	}
+	for {
+		p.printf("%s %s: ", message, hint)
+		input, err := p.readline()
+		if err != nil {
+			return defValue
+		}
+		switch strings.ToLower(input) {
+		case "":
+			return defValue
+		case "y", "yes":
+			return true
+		case "n", "no":
+			return false
+		}
+		p.printf("Please input y or n\n")
+	}
+}
+
+// Select prints `message` with numbered `options`, and asks to select one of them by number.
+// It returns the index of the selected option in `options`.
+// The optional parameter `def` specifies the index selected if user inputs nothing.
+// It asks again if the input is invalid, and returns error if the input stream ends.
+func (p *Prompt) Select(message string, options []string, def ...int) (int, error) {
+	if len(options) == 0 {
+		return -1, gerror.NewCode(gcode.CodeInvalidParameter, `options cannot be empty`)
+	}
+	defIndex := -1
+	if len(def) > 0 && def[0] >= 0 && def[0] < len(options) {
+		defIndex = def[0]
+	}
+	p.printOptions(message, options)
+	for {
+		if defIndex >= 0 {
+			p.printf("Please select [1-%d] (default %d): ", len(options), defIndex+1)
+		} else {
+			p.printf("Please select [1-%d]: ", len(options))
+		}
+		input, err := p.readline()
+		if err != nil {
+			return -1, err
+		}
+		if input == "" && defIndex >= 0 {
+			return defIndex, nil
+		}
+		if index, ok := p.parseOption(input, len(options)); ok {
+			return index, nil
+		}
+		p.printf("Invalid selection: %s\n", input)
+	}
+}
+
+// MultiSelect prints `message` with numbered `options`, and asks to select some of them by numbers
+// separated with ',' or spaces, like: "1,3". It returns the indexes of the selected options in `options`
+// in inputting order without duplicates.
+// The optional parameter `def` specifies the indexes selected if user inputs nothing.
+// It asks again if the input is invalid, and returns error if the input stream ends.
+func (p *Prompt) MultiSelect(message string, options []string, def ...[]int) ([]int, error) {
+	if len(options) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `options cannot be empty`)
+	}
+	p.printOptions(message, options)
+	for {
+		if len(def) > 0 && len(def[0]) > 0 {
+			numbers := make([]string, len(def[0]))
+			for i, index := range def[0] {
+				numbers[i] = strconv.Itoa(index + 1)
+			}
+			p.printf("Please select [1-%d], separated by ',' (default %s): ", len(options), strings.Join(numbers, ","))
+		} else {
+			p.printf("Please select [1-%d], separated by ',': ", len(options))
+		}
+		input, err := p.readline()
+		if err != nil {
+			return nil, err
+		}
+		if input == "" {
+			if len(def) > 0 {
+				return def[0], nil
+			}
+			return []int{}, nil
+		}
+		var (
+			valid   = true
+			indexes = make([]int, 0)
+			exists  = make(map[int]struct{})
+		)
+		for _, field := range strings.FieldsFunc(input, func(r rune) bool {
+			return r == ',' || r == ' '
+		}) {
+			index, ok := p.parseOption(field, len(options))
+			if !ok {
+				p.printf("Invalid selection: %s\n", field)
+				valid = false
+				break
+			}
+			if _, ok = exists[index]; !ok {
+				exists[index] = struct{}{}
+				indexes = append(indexes, index)
+			}
+		}
+		if valid {
+			return indexes, nil
+		}
+	}
+}
+
+// Password prints `message` and reads a password from user input.
+// The input is not echoed if the prompt reads from a terminal.
+func (p *Prompt) Password(message string) (string, error) {
+	p.printf("%s: ", message)
+	if file, ok := p.in.(*os.File); ok {
+		if restore, err := disableEcho(file); err == nil {
+			defer func() {
+				restore()
+				p.printf("\n")
+			}()
+		}
+	}
+	return p.readline()
+}
+
+// Input prints `message` and reads a text from user input, which is validated by `rules`
+// using gvalid, like: "required|email". It prints the validation error and asks again if
+// the input is invalid. The optional parameter `def` specifies the text if user inputs nothing,
+// which is validated as well. It returns error if the input stream ends.
+func (p *Prompt) Input(ctx context.Context, message string, rules string, def ...string) (string, error) {
+	var defValue string
+	if len(def) > 0 {
+		defValue = def[0]
+	}
+	for {
+		if defValue != "" {
+			p.printf("%s (default %s): ", message, defValue)
+		} else {
+			p.printf("%s: ", message)
+		}
+		input, err := p.readline()
+		if err != nil {
+			return "", err
+		}
+		if input == "" {
+			input = defValue
+		}
+		if rules == "" {
+			return input, nil
+		}
+		if err := gvalid.New().Rules(rules).Data(input).Run(ctx); err != nil {
+			p.printf("%s\n", err.FirstError().Error())
+			continue
+		}
+		return input, nil
+	}
+}
+
+// printOptions prints `message` and numbered `options`.
+func (p *Prompt) printOptions(message string, options []string) {
+	p.printf("%s\n", message)
+	for i, option := range options {
+		p.printf("  %d) %s\n", i+1, option)
+	}
+}
+
+// parseOption parses the option number `input` and returns its index, which should be in [1, count].
+func (p *Prompt) parseOption(input string, count int) (int, bool) {
+	number, err := strconv.Atoi(input)
+	if err != nil || number < 1 || number > count {
+		return -1, false
+	}
+	return number - 1, true
+}
+
+// printf prints formatted message to the output stream.
+func (p *Prompt) printf(format string, values ...interface{}) {
+	_, _ = fmt.Fprintf(p.out, format, values...)
+}
+
+// readline reads and returns a trimmed line from the input stream.
+// It returns io.EOF if the input stream ends without any content.
+func (p *Prompt) readline() (string, error) {
+	s, err := p.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || s == "") {
+		return "", err
+	}
+	return gstr.Trim(s), nil
+}
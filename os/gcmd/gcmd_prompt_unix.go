@@ -0,0 +1,40 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+//go:build !windows
+// +build !windows
+
+package gcmd
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// disableEcho disables the echo of terminal `file` using stty, and returns the function restoring it.
+// It returns error if `file` is not a terminal.
+func disableEcho(file *os.File) (restore func(), err error) {
+	state, err := stty(file, "-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = stty(file, "-echo"); err != nil {
+		return nil, err
+	}
+	return func() {
+		_, _ = stty(file, strings.TrimSpace(state))
+	}, nil
+}
+
+// stty executes stty with `arg` on terminal `file`.
+func stty(file *os.File, arg string) (string, error) {
+	cmd := exec.Command("stty", arg)
+	cmd.Stdin = file
+	output, err := cmd.Output()
+	return string(output), err
+}
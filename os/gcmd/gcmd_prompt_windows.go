@@ -0,0 +1,46 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+//go:build windows
+// +build windows
+
+package gcmd
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// enableEchoInput is the console mode flag echoing the input characters.
+	enableEchoInput = 0x0004
+)
+
+var (
+	kernel32           = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleMode = kernel32.NewProc("GetConsoleMode")
+	procSetConsoleMode = kernel32.NewProc("SetConsoleMode")
+)
+
+// disableEcho disables the echo of console `file`, and returns the function restoring it.
+// It returns error if `file` is not a console.
+func disableEcho(file *os.File) (restore func(), err error) {
+	var (
+		mode   uint32
+		handle = file.Fd()
+	)
+	if r, _, e := procGetConsoleMode.Call(handle, uintptr(unsafe.Pointer(&mode))); r == 0 {
+		return nil, e
+	}
+	if r, _, e := procSetConsoleMode.Call(handle, uintptr(mode&^enableEchoInput)); r == 0 {
+		return nil, e
+	}
+	return func() {
+		_, _, _ = procSetConsoleMode.Call(handle, uintptr(mode))
+	}, nil
+}
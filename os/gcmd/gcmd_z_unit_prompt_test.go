@@ -0,0 +1,118 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go -bench=".*" -benchmem
+
+package gcmd_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gcmd"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Prompt_Confirm(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			out    = bytes.NewBuffer(nil)
+			prompt = gcmd.NewPrompt(strings.NewReader("Y\nno\n\nx\nyes\n"), out)
+		)
+		t.Assert(prompt.Confirm("Continue?"), true)
+		t.Assert(prompt.Confirm("Continue?", true), false)
+		t.Assert(prompt.Confirm("Continue?", true), true)
+		t.Assert(prompt.Confirm("Continue?"), true)
+		// End of input.
+		t.Assert(prompt.Confirm("Continue?", true), true)
+		t.Assert(strings.Contains(out.String(), "Continue? [y/N]: "), true)
+		t.Assert(strings.Contains(out.String(), "Continue? [Y/n]: "), true)
+		t.Assert(strings.Contains(out.String(), "Please input y or n"), true)
+	})
+}
+
+func Test_Prompt_Select(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			out     = bytes.NewBuffer(nil)
+			options = []string{"mysql", "pgsql", "sqlite"}
+			prompt  = gcmd.NewPrompt(strings.NewReader("2\n0\nx\n3\n\n"), out)
+		)
+		index, err := prompt.Select("Database:", options)
+		t.AssertNil(err)
+		t.Assert(index, 1)
+		t.Assert(strings.Contains(out.String(), "  1) mysql\n  2) pgsql\n  3) sqlite\n"), true)
+
+		index, err = prompt.Select("Database:", options)
+		t.AssertNil(err)
+		t.Assert(index, 2)
+		t.Assert(strings.Count(out.String(), "Invalid selection"), 2)
+
+		index, err = prompt.Select("Database:", options, 0)
+		t.AssertNil(err)
+		t.Assert(index, 0)
+
+		_, err = prompt.Select("Database:", options)
+		t.AssertNE(err, nil)
+		_, err = prompt.Select("Database:", nil)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Prompt_MultiSelect(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			options = []string{"a", "b", "c"}
+			prompt  = gcmd.NewPrompt(strings.NewReader("3, 1,3\n1,4\n2\n\n\n"), bytes.NewBuffer(nil))
+		)
+		indexes, err := prompt.MultiSelect("Features:", options)
+		t.AssertNil(err)
+		t.Assert(indexes, []int{2, 0})
+
+		indexes, err = prompt.MultiSelect("Features:", options)
+		t.AssertNil(err)
+		t.Assert(indexes, []int{1})
+
+		indexes, err = prompt.MultiSelect("Features:", options, []int{0, 1})
+		t.AssertNil(err)
+		t.Assert(indexes, []int{0, 1})
+
+		indexes, err = prompt.MultiSelect("Features:", options)
+		t.AssertNil(err)
+		t.Assert(len(indexes), 0)
+
+		_, err = prompt.MultiSelect("Features:", options)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Prompt_Input(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx    = context.TODO()
+			out    = bytes.NewBuffer(nil)
+			prompt = gcmd.NewPrompt(strings.NewReader("\nnot-email\njohn@goframe.org\n\n secret \n"), out)
+		)
+		value, err := prompt.Input(ctx, "Email", "required|email")
+		t.AssertNil(err)
+		t.Assert(value, "john@goframe.org")
+		t.Assert(strings.Count(out.String(), "Email: "), 3)
+
+		value, err = prompt.Input(ctx, "Port", "integer", "8000")
+		t.AssertNil(err)
+		t.Assert(value, "8000")
+		t.Assert(strings.Contains(out.String(), "Port (default 8000): "), true)
+
+		value, err = prompt.Password("Password")
+		t.AssertNil(err)
+		t.Assert(value, "secret")
+
+		_, err = prompt.Input(ctx, "Name", "")
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gctx
+
+import (
+	"context"
+)
+
+// RequestIdHeader is the common http header name for request id.
+const RequestIdHeader = "X-Request-ID"
+
+// ctxKeyForRequestId is the context key for request id.
+const ctxKeyForRequestId StrKey = "GoFrameRequestId"
+
+// WithRequestId creates and returns a context containing request id `id` upon given parent context `ctx`.
+// The request id is the baseline correlation id among services, which is printed by glog and
+// propagated by gclient automatically.
+func WithRequestId(ctx context.Context, id string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, ctxKeyForRequestId, id)
+}
+
+// RequestId retrieves and returns the request id from context, it returns empty string if not found.
+func RequestId(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if v, ok := ctx.Value(ctxKeyForRequestId).(string); ok {
+		return v
+	}
+	return ""
+}
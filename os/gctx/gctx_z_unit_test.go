@@ -39,3 +39,11 @@ func Test_SetInitCtx(t *testing.T) {
 		t.Assert(gctx.GetInitCtx().Value("TEST"), 1)
 	})
 }
+
+func Test_RequestId(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gctx.RequestId(context.TODO()), "")
+		ctx := gctx.WithRequestId(context.TODO(), "123")
+		t.Assert(gctx.RequestId(ctx), "123")
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gfile
+
+import (
+	"os"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// FileLock is an advisory lock on a file, which is used for synchronization between processes,
+// like file-based storages or single-instance tools.
+//
+// It wraps flock on unix-like platforms and LockFileEx on windows. Note that it is advisory,
+// which means it does not prevent other processes from reading or writing the file without locking.
+type FileLock struct {
+	mu        sync.Mutex
+	path      string   // Path of the locked file.
+	file      *os.File // Opened file holding the lock, which is nil if it is unlocked.
+	exclusive bool     // Whether it is an exclusive lock or a shared lock.
+}
+
+// Lock acquires an exclusive lock on file `path`, it blocks until the lock is acquired.
+// The file is created if it does not exist.
+func Lock(path string) (*FileLock, error) {
+	lock, _, err := doLock(path, true, true)
+	return lock, err
+}
+
+// RLock acquires a shared lock on file `path`, it blocks until the lock is acquired.
+// Multiple processes can hold shared locks on the same file at the same time,
+// but an exclusive lock cannot be acquired if any shared lock is held, and vice versa.
+// The file is created if it does not exist.
+func RLock(path string) (*FileLock, error) {
+	lock, _, err := doLock(path, false, true)
+	return lock, err
+}
+
+// TryLock tries acquiring an exclusive lock on file `path` without blocking.
+// It returns false if the lock is held by others.
+func TryLock(path string) (lock *FileLock, ok bool, err error) {
+	return doLock(path, true, false)
+}
+
+// TryRLock tries acquiring a shared lock on file `path` without blocking.
+// It returns false if an exclusive lock is held by others.
+func TryRLock(path string) (lock *FileLock, ok bool, err error) {
+	return doLock(path, false, false)
+}
+
+// Path returns the path of the locked file.
+func (l *FileLock) Path() string {
+	return l.path
+}
+
+// IsExclusive checks and returns whether it is an exclusive lock.
+func (l *FileLock) IsExclusive() bool {
+	return l.exclusive
+}
+
+// Unlock releases the lock and closes the file. It does nothing if it is already unlocked.
+func (l *FileLock) Unlock() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := doUnlockFile(l.file)
+	if closeErr := l.file.Close(); err == nil && closeErr != nil {
+		err = gerror.Wrapf(closeErr, `close locked file "%s" failed`, l.path)
+	}
+	l.file = nil
+	return err
+}
+
+// doLock acquires lock on file `path`, it returns false if `block` is false and the lock is held by others.
+func doLock(path string, exclusive, block bool) (*FileLock, bool, error) {
+	if dir := Dir(path); !Exists(dir) {
+		if err := Mkdir(dir); err != nil {
+			return nil, false, err
+		}
+	}
+	file, err := OpenFile(path, os.O_CREATE|os.O_RDWR, DefaultPermOpen)
+	if err != nil {
+		return nil, false, err
+	}
+	ok, err := doLockFile(file, exclusive, block)
+	if err != nil || !ok {
+		_ = file.Close()
+		if err != nil {
+			err = gerror.Wrapf(err, `lock file "%s" failed`, path)
+		}
+		return nil, false, err
+	}
+	return &FileLock{
+		path:      path,
+		file:      file,
+		exclusive: exclusive,
+	}, true, nil
+}
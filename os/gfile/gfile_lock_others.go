@@ -0,0 +1,27 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+package gfile
+
+import (
+	"os"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// doLockFile is not supported on current platform.
+func doLockFile(file *os.File, exclusive, block bool) (bool, error) {
+	return false, gerror.NewCode(gcode.CodeNotSupported, `file locking is not supported on current platform`)
+}
+
+// doUnlockFile is not supported on current platform.
+func doUnlockFile(file *os.File) error {
+	return gerror.NewCode(gcode.CodeNotSupported, `file locking is not supported on current platform`)
+}
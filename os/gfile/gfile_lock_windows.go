@@ -0,0 +1,60 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+//go:build windows
+// +build windows
+
+package gfile
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	lockFileFailImmediately = 0x00000001 // LOCKFILE_FAIL_IMMEDIATELY
+	lockFileExclusiveLock   = 0x00000002 // LOCKFILE_EXCLUSIVE_LOCK
+	errorLockViolation      = syscall.Errno(33)
+)
+
+var (
+	modKernel32      = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = modKernel32.NewProc("LockFileEx")
+	procUnlockFileEx = modKernel32.NewProc("UnlockFileEx")
+)
+
+// doLockFile locks `file` using LockFileEx, it returns false if `block` is false and the lock is held by others.
+func doLockFile(file *os.File, exclusive, block bool) (bool, error) {
+	var flags uint32
+	if exclusive {
+		flags |= lockFileExclusiveLock
+	}
+	if !block {
+		flags |= lockFileFailImmediately
+	}
+	overlapped := new(syscall.Overlapped)
+	r1, _, err := procLockFileEx.Call(
+		file.Fd(), uintptr(flags), 0, 1, 0, uintptr(unsafe.Pointer(overlapped)),
+	)
+	if r1 != 0 {
+		return true, nil
+	}
+	if err == errorLockViolation {
+		return false, nil
+	}
+	return false, err
+}
+
+// doUnlockFile unlocks `file` using UnlockFileEx.
+func doUnlockFile(file *os.File) error {
+	overlapped := new(syscall.Overlapped)
+	r1, _, err := procUnlockFileEx.Call(file.Fd(), 0, 1, 0, uintptr(unsafe.Pointer(overlapped)))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
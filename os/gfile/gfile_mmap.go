@@ -0,0 +1,158 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gfile
+
+import (
+	"io"
+	"math"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// MmapReader is a read-only memory-mapped file, which accesses the file content without copying it into heap.
+// It is useful for serving large static datasets, like geo databases or lookup tables.
+//
+// It uses mmap on unix-like platforms and MapViewOfFile on windows, and it falls back to reading
+// the whole file into memory on other platforms.
+// It is concurrent-safe, and it implements io.ReaderAt.
+type MmapReader struct {
+	mu     sync.RWMutex
+	path   string // Path of the mapped file.
+	data   []byte // Mapped content of the file.
+	closed bool   // Whether the reader is closed and the file is unmapped.
+}
+
+// OpenMmap opens file `path` and maps its content into memory read-only.
+// The returned reader should be closed using Close to unmap the file if it is no longer used.
+func OpenMmap(path string) (*MmapReader, error) {
+	file, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, gerror.Wrapf(err, `stat file "%s" failed`, path)
+	}
+	if info.IsDir() {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `"%s" is a directory`, path)
+	}
+	size := info.Size()
+	if size > math.MaxInt32 && int64(int(size)) != size {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `file "%s" is too large to map`, path)
+	}
+	reader := &MmapReader{
+		path: path,
+		data: []byte{},
+	}
+	// Empty file cannot be mapped.
+	if size > 0 {
+		if reader.data, err = doMmap(file, int(size)); err != nil {
+			return nil, gerror.Wrapf(err, `mmap file "%s" failed`, path)
+		}
+	}
+	return reader, nil
+}
+
+// Path returns the path of the mapped file.
+func (r *MmapReader) Path() string {
+	return r.path
+}
+
+// Len returns the size of the mapped file.
+func (r *MmapReader) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.data)
+}
+
+// Bytes returns the mapped content of the file, which is read-only.
+//
+// Note that the returned slice refers to the mapped memory directly, accessing it after Close
+// might crash the process. Use RLockFunc, ReadAt or Slice for safe accessing.
+func (r *MmapReader) Bytes() []byte {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.data
+}
+
+// RLockFunc calls `f` with the mapped content of the file, during which the file is not unmapped
+// by Close. The `data` is read-only, and it should not be retained after `f` returns.
+// It returns error if the reader is closed.
+func (r *MmapReader) RLockFunc(f func(data []byte)) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return r.closedError()
+	}
+	f(r.data)
+	return nil
+}
+
+// Slice returns a copy of the content in range [offset, offset+length).
+func (r *MmapReader) Slice(offset, length int) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return nil, r.closedError()
+	}
+	if offset < 0 || length < 0 || offset+length > len(r.data) {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter, `range [%d, %d) out of size %d`, offset, offset+length, len(r.data),
+		)
+	}
+	b := make([]byte, length)
+	copy(b, r.data[offset:])
+	return b, nil
+}
+
+// ReadAt implements the interface io.ReaderAt, which copies the content at offset `off` into `p`.
+func (r *MmapReader) ReadAt(p []byte, off int64) (n int, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return 0, r.closedError()
+	}
+	if off < 0 {
+		return 0, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid offset %d`, off)
+	}
+	if off >= int64(len(r.data)) {
+		return 0, io.EOF
+	}
+	n = copy(p, r.data[off:])
+	if n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Close unmaps the file, it waits for the accessing using RLockFunc, Slice or ReadAt done.
+// It does nothing if it is already closed.
+func (r *MmapReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	data := r.data
+	r.data = []byte{}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := doMunmap(data); err != nil {
+		return gerror.Wrapf(err, `munmap file "%s" failed`, r.path)
+	}
+	return nil
+}
+
+// closedError returns the error for accessing closed reader.
+func (r *MmapReader) closedError() error {
+	return gerror.NewCodef(gcode.CodeInvalidOperation, `mmap reader of file "%s" is closed`, r.path)
+}
@@ -0,0 +1,25 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package gfile
+
+import (
+	"os"
+	"syscall"
+)
+
+// doMmap maps `size` bytes of `file` into memory read-only using mmap.
+func doMmap(file *os.File, size int) ([]byte, error) {
+	return syscall.Mmap(int(file.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+// doMunmap unmaps `data` using munmap.
+func doMunmap(data []byte) error {
+	return syscall.Munmap(data)
+}
@@ -0,0 +1,47 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+//go:build windows
+// +build windows
+
+package gfile
+
+import (
+	"os"
+	"reflect"
+	"syscall"
+	"unsafe"
+)
+
+// doMmap maps `size` bytes of `file` into memory read-only using MapViewOfFile.
+func doMmap(file *os.File, size int) ([]byte, error) {
+	handle, err := syscall.CreateFileMapping(
+		syscall.Handle(file.Fd()), nil, syscall.PAGE_READONLY, uint32(uint64(size)>>32), uint32(size), nil,
+	)
+	if err != nil {
+		return nil, os.NewSyscallError("CreateFileMapping", err)
+	}
+	// The mapping object is kept by the view, so the handle can be closed after mapping.
+	defer syscall.CloseHandle(handle)
+	addr, err := syscall.MapViewOfFile(handle, syscall.FILE_MAP_READ, 0, 0, uintptr(size))
+	if err != nil {
+		return nil, os.NewSyscallError("MapViewOfFile", err)
+	}
+	var data []byte
+	header := (*reflect.SliceHeader)(unsafe.Pointer(&data))
+	header.Data = addr
+	header.Len = size
+	header.Cap = size
+	return data, nil
+}
+
+// doMunmap unmaps `data` using UnmapViewOfFile.
+func doMunmap(data []byte) error {
+	if err := syscall.UnmapViewOfFile(uintptr(unsafe.Pointer(&data[0]))); err != nil {
+		return os.NewSyscallError("UnmapViewOfFile", err)
+	}
+	return nil
+}
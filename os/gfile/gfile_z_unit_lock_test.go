@@ -0,0 +1,79 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gfile_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+func Test_Lock(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Temp("gfile_lock_"+gconv.String(gtime.TimestampNano()), "test.lock")
+		defer gfile.Remove(gfile.Dir(path))
+
+		lock, err := gfile.Lock(path)
+		t.AssertNil(err)
+		t.Assert(lock.Path(), path)
+		t.Assert(lock.IsExclusive(), true)
+		t.Assert(gfile.Exists(path), true)
+
+		_, ok, err := gfile.TryLock(path)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+		_, ok, err = gfile.TryRLock(path)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+
+		// Blocking until unlocked.
+		unlocked := make(chan struct{})
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			close(unlocked)
+			_ = lock.Unlock()
+		}()
+		lock2, err := gfile.Lock(path)
+		t.AssertNil(err)
+		select {
+		case <-unlocked:
+		default:
+			t.Error("lock is acquired before unlocked")
+		}
+		t.AssertNil(lock2.Unlock())
+		t.AssertNil(lock2.Unlock())
+		t.AssertNil(lock.Unlock())
+	})
+}
+
+func Test_RLock(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Temp("gfile_rlock_"+gconv.String(gtime.TimestampNano()), "test.lock")
+		defer gfile.Remove(gfile.Dir(path))
+
+		lock1, err := gfile.RLock(path)
+		t.AssertNil(err)
+		t.Assert(lock1.IsExclusive(), false)
+		lock2, ok, err := gfile.TryRLock(path)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+		_, ok, err = gfile.TryLock(path)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+
+		t.AssertNil(lock1.Unlock())
+		t.AssertNil(lock2.Unlock())
+		lock3, ok, err := gfile.TryLock(path)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+		t.AssertNil(lock3.Unlock())
+	})
+}
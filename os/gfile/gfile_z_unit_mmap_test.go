@@ -0,0 +1,77 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gfile_test
+
+import (
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+func Test_OpenMmap(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Temp("gfile_mmap_"+gconv.String(gtime.TimestampNano()), "data.bin")
+		defer gfile.Remove(gfile.Dir(path))
+		t.AssertNil(gfile.PutContents(path, "0123456789"))
+
+		reader, err := gfile.OpenMmap(path)
+		t.AssertNil(err)
+		t.Assert(reader.Path(), path)
+		t.Assert(reader.Len(), 10)
+		t.Assert(reader.Bytes(), "0123456789")
+
+		b, err := reader.Slice(2, 3)
+		t.AssertNil(err)
+		t.Assert(b, "234")
+		_, err = reader.Slice(8, 3)
+		t.AssertNE(err, nil)
+
+		p := make([]byte, 4)
+		n, err := reader.ReadAt(p, 8)
+		t.Assert(err, io.EOF)
+		t.Assert(p[:n], "89")
+		n, err = reader.ReadAt(p, 0)
+		t.AssertNil(err)
+		t.Assert(p[:n], "0123")
+		_, err = ioutil.ReadAll(io.NewSectionReader(reader, 5, 5))
+		t.AssertNil(err)
+
+		t.AssertNil(reader.RLockFunc(func(data []byte) {
+			t.Assert(data[9], '9')
+		}))
+
+		t.AssertNil(reader.Close())
+		t.AssertNil(reader.Close())
+		t.Assert(reader.Len(), 0)
+		_, err = reader.ReadAt(p, 0)
+		t.AssertNE(err, nil)
+		_, err = reader.Slice(0, 1)
+		t.AssertNE(err, nil)
+		t.AssertNE(reader.RLockFunc(func(data []byte) {}), nil)
+	})
+	// Empty file, directory and not existing file.
+	gtest.C(t, func(t *gtest.T) {
+		path := gfile.Temp("gfile_mmap_"+gconv.String(gtime.TimestampNano()), "empty.bin")
+		defer gfile.Remove(gfile.Dir(path))
+		t.AssertNil(gfile.PutContents(path, ""))
+
+		reader, err := gfile.OpenMmap(path)
+		t.AssertNil(err)
+		t.Assert(reader.Len(), 0)
+		t.AssertNil(reader.Close())
+
+		_, err = gfile.OpenMmap(gfile.Dir(path))
+		t.AssertNE(err, nil)
+		_, err = gfile.OpenMmap(path + ".none")
+		t.AssertNE(err, nil)
+	})
+}
@@ -63,6 +63,21 @@ func GetWriter() io.Writer {
 	return defaultLogger.GetWriter()
 }
 
+// AddOutput adds extra output destination `output` for default logger.
+func AddOutput(output Output) {
+	defaultLogger.AddOutput(output)
+}
+
+// SetOutputs sets the extra output destinations for default logger.
+func SetOutputs(outputs []Output) {
+	defaultLogger.SetOutputs(outputs)
+}
+
+// GetOutputs returns the extra output destinations of default logger.
+func GetOutputs() []Output {
+	return defaultLogger.GetOutputs()
+}
+
 // SetDebug enables/disables the debug level for default defaultLogger.
 // The debug level is enabled in default.
 func SetDebug(debug bool) {
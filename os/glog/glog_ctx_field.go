@@ -0,0 +1,81 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// CtxFieldExtractor extracts and returns the fields from context for logging,
+// like user id or tenant placed in context by middleware.
+type CtxFieldExtractor func(ctx context.Context) map[string]interface{}
+
+var (
+	ctxFieldExtractorsMu sync.RWMutex
+	ctxFieldExtractors   []CtxFieldExtractor // Registered extractors for all loggers.
+)
+
+// AddCtxFieldExtractor registers `extractor` for all loggers, of which the extracted fields
+// are printed automatically in every logging content with context. The fields of the latter
+// registered extractor override the ones of the same names from the former registered ones.
+func AddCtxFieldExtractor(extractor CtxFieldExtractor) {
+	if extractor == nil {
+		return
+	}
+	ctxFieldExtractorsMu.Lock()
+	defer ctxFieldExtractorsMu.Unlock()
+	ctxFieldExtractors = append(ctxFieldExtractors, extractor)
+}
+
+// ClearCtxFieldExtractors removes all registered extractors.
+func ClearCtxFieldExtractors() {
+	ctxFieldExtractorsMu.Lock()
+	defer ctxFieldExtractorsMu.Unlock()
+	ctxFieldExtractors = nil
+}
+
+// extractCtxFields extracts and returns the fields from `ctx` using the registered extractors.
+// It returns nil if there's no field.
+func extractCtxFields(ctx context.Context) map[string]interface{} {
+	ctxFieldExtractorsMu.RLock()
+	extractors := ctxFieldExtractors
+	ctxFieldExtractorsMu.RUnlock()
+	var fields map[string]interface{}
+	for _, extractor := range extractors {
+		for k, v := range extractor(ctx) {
+			if fields == nil {
+				fields = make(map[string]interface{})
+			}
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// formatCtxFields formats `fields` as string sorted by name, like: "tenant=a, user=1".
+func formatCtxFields(fields map[string]interface{}) string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var builder strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(name)
+		builder.WriteByte('=')
+		builder.WriteString(gconv.String(fields[name]))
+	}
+	return builder.String()
+}
@@ -192,6 +192,10 @@ func (l *Logger) print(ctx context.Context, level int, stack string, values ...i
 		if traceId := spanCtx.TraceID(); traceId.IsValid() {
 			input.TraceId = traceId.String()
 		}
+		// Request id.
+		input.RequestId = gctx.RequestId(ctx)
+		// Context fields from registered extractors.
+		input.CtxFields = extractCtxFields(ctx)
 		// Context values.
 		if len(l.config.CtxKeys) > 0 {
 			for _, ctxKey := range l.config.CtxKeys {
@@ -242,6 +246,10 @@ func (l *Logger) print(ctx context.Context, level int, stack string, values ...i
 // doDefaultPrint outputs the logging content according configuration.
 func (l *Logger) doDefaultPrint(ctx context.Context, input *HandlerInput) *bytes.Buffer {
 	var buffer *bytes.Buffer
+	// The level might be enabled only for outputs.
+	if !isLevelEnabled(l.config.Level, input.Level) {
+		return nil
+	}
 	if l.config.Writer == nil {
 		// Allow output to stdout?
 		if l.config.StdoutPrint {
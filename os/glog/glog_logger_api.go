@@ -140,7 +140,7 @@ func (l *Logger) Criticalf(ctx context.Context, format string, v ...interface{})
 	}
 }
 
-// checkLevel checks whether the given `level` could be output.
+// checkLevel checks whether the given `level` could be output by the logger or any of its outputs.
 func (l *Logger) checkLevel(level int) bool {
-	return l.config.Level&level > 0
+	return l.config.Level&level > 0 || l.getOutputsLevel()&level > 0
 }
@@ -24,6 +24,7 @@ import (
 type Config struct {
 	Handlers             []Handler      `json:"-"`                    // Logger handlers which implement feature similar as middleware.
 	Writer               io.Writer      `json:"-"`                    // Customized io.Writer.
+	Outputs              []Output       `json:"-"`                    // Extra output destinations with their own levels and formats.
 	Flags                int            `json:"flags"`                // Extra flags for logging output features.
 	Path                 string         `json:"path"`                 // Logging directory path.
 	File                 string         `json:"file"`                 // Format pattern for logging file.
@@ -18,21 +18,23 @@ type Handler func(ctx context.Context, in *HandlerInput)
 // HandlerInput is the input parameter struct for logging Handler.
 type HandlerInput struct {
 	internalHandlerInfo
-	Logger      *Logger       // Logger.
-	Buffer      *bytes.Buffer // Buffer for logging content outputs.
-	Time        time.Time     // Logging time, which is the time that logging triggers.
-	TimeFormat  string        // Formatted time string, like "2016-01-09 12:00:00".
-	Color       int           // Using color, like COLOR_RED, COLOR_BLUE, etc. Eg: 34
-	Level       int           // Using level, like LEVEL_INFO, LEVEL_ERRO, etc. Eg: 256
-	LevelFormat string        // Formatted level string, like "DEBU", "ERRO", etc. Eg: ERRO
-	CallerFunc  string        // The source function name that calls logging, only available if F_CALLER_FN set.
-	CallerPath  string        // The source file path and its line number that calls logging, only available if F_FILE_SHORT or F_FILE_LONG set.
-	CtxStr      string        // The retrieved context value string from context, only available if Config.CtxKeys configured.
-	TraceId     string        // Trace id, only available if tracing is enabled.
-	Prefix      string        // Custom prefix string for logging content.
-	Content     string        // Content is the main logging content without error stack string produced by logger.
-	Stack       string        // Stack string produced by logger, only available if Config.StStatus configured.
-	IsAsync     bool          // IsAsync marks it is in asynchronous logging.
+	Logger      *Logger                // Logger.
+	Buffer      *bytes.Buffer          // Buffer for logging content outputs.
+	Time        time.Time              // Logging time, which is the time that logging triggers.
+	TimeFormat  string                 // Formatted time string, like "2016-01-09 12:00:00".
+	Color       int                    // Using color, like COLOR_RED, COLOR_BLUE, etc. Eg: 34
+	Level       int                    // Using level, like LEVEL_INFO, LEVEL_ERRO, etc. Eg: 256
+	LevelFormat string                 // Formatted level string, like "DEBU", "ERRO", etc. Eg: ERRO
+	CallerFunc  string                 // The source function name that calls logging, only available if F_CALLER_FN set.
+	CallerPath  string                 // The source file path and its line number that calls logging, only available if F_FILE_SHORT or F_FILE_LONG set.
+	CtxStr      string                 // The retrieved context value string from context, only available if Config.CtxKeys configured.
+	CtxFields   map[string]interface{} // The fields extracted from context, only available if any CtxFieldExtractor registered.
+	TraceId     string                 // Trace id, only available if tracing is enabled.
+	RequestId   string                 // Request id, only available if context contains request id.
+	Prefix      string                 // Custom prefix string for logging content.
+	Content     string                 // Content is the main logging content without error stack string produced by logger.
+	Stack       string                 // Stack string produced by logger, only available if Config.StStatus configured.
+	IsAsync     bool                   // IsAsync marks it is in asynchronous logging.
 }
 
 type internalHandlerInfo struct {
@@ -47,9 +49,10 @@ var defaultHandler Handler
 // This handler outputs logging content to file/stdout/write if any of them configured.
 func defaultPrintHandler(ctx context.Context, in *HandlerInput) {
 	buffer := in.Logger.doDefaultPrint(ctx, in)
-	if in.Buffer.Len() == 0 {
+	if buffer != nil && in.Buffer.Len() == 0 {
 		in.Buffer = buffer
 	}
+	in.Logger.printToOutputs(ctx, in)
 }
 
 // SetDefaultHandler sets default handler for package.
@@ -99,9 +102,15 @@ func (in *HandlerInput) getDefaultBuffer(withColor bool) *bytes.Buffer {
 	if in.TraceId != "" {
 		in.addStringToBuffer(buffer, "{"+in.TraceId+"}")
 	}
+	if in.RequestId != "" {
+		in.addStringToBuffer(buffer, "{"+in.RequestId+"}")
+	}
 	if in.CtxStr != "" {
 		in.addStringToBuffer(buffer, "{"+in.CtxStr+"}")
 	}
+	if len(in.CtxFields) > 0 {
+		in.addStringToBuffer(buffer, "{"+formatCtxFields(in.CtxFields)+"}")
+	}
 	if in.Logger.config.HeaderPrint {
 		if in.Prefix != "" {
 			in.addStringToBuffer(buffer, in.Prefix)
@@ -0,0 +1,110 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+const (
+	OutputFormatText = "text" // Outputs logging content as text like the default printing.
+	OutputFormatJson = "json" // Outputs logging content as single json like HandlerJson.
+)
+
+// Output is an extra output destination of logger, which has its own level and format.
+// The logging content is written to every output accepting its level, in addition to
+// the stdout, file or writer configured for the logger, eg: console text at INFO,
+// file json at DEBU and remote collector at ERRO.
+type Output struct {
+	Writer io.Writer // Writer is the destination of logging content.
+	Level  int       // Level is the output level of this destination, like LEVEL_INFO | LEVEL_ERRO. It outputs all levels if 0.
+	Format string    // Format is the content format, OutputFormatText in default.
+	Color  bool      // Color enables level prefix with color for OutputFormatText.
+}
+
+// AddOutput adds extra output destination `output` to the logger.
+//
+// Note that the level of logger (Config.Level) controls only the stdout, file or writer
+// configured for the logger, and the logging content at any level accepted by an output
+// is passed to the logging handlers.
+func (l *Logger) AddOutput(output Output) {
+	outputs := make([]Output, len(l.config.Outputs), len(l.config.Outputs)+1)
+	copy(outputs, l.config.Outputs)
+	l.config.Outputs = append(outputs, output)
+}
+
+// SetOutputs sets the extra output destinations of the logger, which replaces the previous ones.
+func (l *Logger) SetOutputs(outputs []Output) {
+	l.config.Outputs = outputs
+}
+
+// GetOutputs returns the extra output destinations of the logger.
+func (l *Logger) GetOutputs() []Output {
+	return l.config.Outputs
+}
+
+// getOutputsLevel returns the union level of all extra outputs.
+func (l *Logger) getOutputsLevel() int {
+	level := LEVEL_NONE
+	for _, output := range l.config.Outputs {
+		level |= output.getLevel()
+	}
+	return level
+}
+
+// printToOutputs writes the logging content to each extra output accepting its level.
+func (l *Logger) printToOutputs(ctx context.Context, in *HandlerInput) {
+	var (
+		err        error
+		textBuffer = make(map[bool]*bytes.Buffer)
+		jsonBytes  []byte
+	)
+	for _, output := range l.config.Outputs {
+		if output.Writer == nil || !isLevelEnabled(output.getLevel(), in.Level) {
+			continue
+		}
+		var content []byte
+		switch output.Format {
+		case OutputFormatJson:
+			if jsonBytes == nil {
+				if jsonBytes, err = in.getJsonBytes(); err != nil {
+					intlog.Errorf(ctx, `%+v`, err)
+					continue
+				}
+				jsonBytes = append(jsonBytes, '\n')
+			}
+			content = jsonBytes
+
+		default:
+			if textBuffer[output.Color] == nil {
+				textBuffer[output.Color] = in.getDefaultBuffer(output.Color)
+			}
+			content = textBuffer[output.Color].Bytes()
+		}
+		if _, err = output.Writer.Write(content); err != nil {
+			intlog.Errorf(ctx, `%+v`, err)
+		}
+	}
+}
+
+// getLevel returns the output level, which is LEVEL_ALL if not configured.
+func (o Output) getLevel() int {
+	if o.Level == LEVEL_NONE {
+		return LEVEL_ALL
+	}
+	return o.Level
+}
+
+// isLevelEnabled checks whether logging content at `level` could be output with level configuration `mask`.
+// Note that the levels without level checks, like that of Print, Panic and Fatal, are always enabled.
+func isLevelEnabled(mask int, level int) bool {
+	return level&LEVEL_ALL == 0 || mask&level > 0
+}
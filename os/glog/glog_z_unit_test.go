@@ -14,6 +14,7 @@ import (
 
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gctx"
 	"github.com/gogf/gf/v2/os/glog"
 	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gogf/gf/v2/test/gtest"
@@ -54,6 +55,107 @@ func Test_Ctx_Config(t *testing.T) {
 	})
 }
 
+func Test_Ctx_RequestId(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		w := bytes.NewBuffer(nil)
+		l := glog.NewWithWriter(w)
+		ctx := gctx.WithRequestId(context.Background(), "request-id-123")
+		l.Print(ctx, 1, 2, 3)
+		t.Assert(gstr.Count(w.String(), "{request-id-123}"), 1)
+		t.Assert(gstr.Count(w.String(), "1 2 3"), 1)
+	})
+}
+
+func Test_Ctx_FieldExtractor(t *testing.T) {
+	type ctxKey string
+	gtest.C(t, func(t *gtest.T) {
+		defer glog.ClearCtxFieldExtractors()
+		glog.AddCtxFieldExtractor(func(ctx context.Context) map[string]interface{} {
+			if userId := ctx.Value(ctxKey("UserId")); userId != nil {
+				return map[string]interface{}{"user": userId, "tenant": "default"}
+			}
+			return nil
+		})
+		glog.AddCtxFieldExtractor(func(ctx context.Context) map[string]interface{} {
+			if tenant := ctx.Value(ctxKey("Tenant")); tenant != nil {
+				return map[string]interface{}{"tenant": tenant}
+			}
+			return nil
+		})
+		var (
+			w   = bytes.NewBuffer(nil)
+			l   = glog.NewWithWriter(w)
+			ctx = context.WithValue(context.Background(), ctxKey("UserId"), 100)
+		)
+		l.Print(ctx, 1, 2, 3)
+		t.Assert(gstr.Count(w.String(), "{tenant=default, user=100} 1 2 3"), 1)
+
+		w.Reset()
+		l.Print(context.WithValue(ctx, ctxKey("Tenant"), "t1"), 1, 2, 3)
+		t.Assert(gstr.Count(w.String(), "{tenant=t1, user=100} 1 2 3"), 1)
+
+		w.Reset()
+		l.Print(context.Background(), 1, 2, 3)
+		t.Assert(gstr.Contains(w.String(), "{"), false)
+
+		// Json.
+		w.Reset()
+		l.SetHandlers(glog.HandlerJson)
+		l.Print(ctx, 1, 2, 3)
+		t.Assert(gstr.Contains(w.String(), `"CtxFields":{"tenant":"default","user":100}`), true)
+	})
+}
+
+func Test_Outputs(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx        = context.TODO()
+			mainWriter = bytes.NewBuffer(nil)
+			textWriter = bytes.NewBuffer(nil)
+			jsonWriter = bytes.NewBuffer(nil)
+			errWriter  = bytes.NewBuffer(nil)
+			l          = glog.NewWithWriter(mainWriter)
+		)
+		t.AssertNil(l.SetLevelStr("WARN"))
+		l.AddOutput(glog.Output{
+			Writer: textWriter,
+			Level:  glog.LEVEL_INFO | glog.LEVEL_ERRO,
+		})
+		l.AddOutput(glog.Output{
+			Writer: jsonWriter,
+			Format: glog.OutputFormatJson,
+		})
+		l.AddOutput(glog.Output{
+			Writer: errWriter,
+			Level:  glog.LEVEL_ERRO,
+		})
+		t.Assert(len(l.GetOutputs()), 3)
+
+		l.Debug(ctx, "debug-content")
+		l.Info(ctx, "info-content")
+		l.Error(ctx, "error-content")
+
+		t.Assert(gstr.Count(mainWriter.String(), "debug-content"), 0)
+		t.Assert(gstr.Count(mainWriter.String(), "info-content"), 0)
+		t.Assert(gstr.Count(mainWriter.String(), "error-content"), 1)
+
+		t.Assert(gstr.Count(textWriter.String(), "debug-content"), 0)
+		t.Assert(gstr.Count(textWriter.String(), "[INFO] info-content"), 1)
+		t.Assert(gstr.Count(textWriter.String(), "[ERRO] error-content"), 1)
+
+		t.Assert(gstr.Count(jsonWriter.String(), `"Level":"DEBU"`), 1)
+		t.Assert(gstr.Count(jsonWriter.String(), `"Content":"info-content"`), 1)
+		t.Assert(gstr.Count(jsonWriter.String(), `"Content":"error-content"`), 1)
+
+		t.Assert(gstr.Count(errWriter.String(), "info-content"), 0)
+		t.Assert(gstr.Count(errWriter.String(), "error-content"), 1)
+
+		l.SetOutputs(nil)
+		l.Debug(ctx, "debug-content")
+		t.Assert(gstr.Count(jsonWriter.String(), `"Level":"DEBU"`), 1)
+	})
+}
+
 func Test_Concurrent(t *testing.T) {
 	gtest.C(t, func(t *gtest.T) {
 		c := 1000
@@ -0,0 +1,143 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gproc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gtimer"
+)
+
+// Names of the resource usage limits, which are passed to UsageLimitHandler.
+const (
+	UsageLimitCPU     = "cpu"
+	UsageLimitRSS     = "rss"
+	UsageLimitFDs     = "fds"
+	UsageLimitThreads = "threads"
+)
+
+// ProcessUsage is the resource usage of a process.
+type ProcessUsage struct {
+	Pid        int           // Process id.
+	CPUUser    time.Duration // CPU time spent in user mode.
+	CPUSystem  time.Duration // CPU time spent in kernel mode.
+	CPUPercent float64       // CPU usage percent since last check of UsageWatcher, 100 means one core fully used.
+	RSS        int64         // Resident set size in bytes.
+	FDs        int           // Count of open file descriptors, which is -1 if not supported on current platform.
+	Threads    int           // Count of threads, which is -1 if not supported on current platform.
+	Time       time.Time     // Time of the usage retrieved.
+}
+
+// UsageLimit is the resource usage limits for UsageWatcher, the zero value of any limit means no limit.
+type UsageLimit struct {
+	CPUPercent float64 // Max CPU usage percent between checks, 100 means one core fully used.
+	RSS        int64   // Max resident set size in bytes.
+	FDs        int     // Max count of open file descriptors.
+	Threads    int     // Max count of threads.
+}
+
+// UsageLimitHandler is the callback function for UsageWatcher, which is called if process of `usage`
+// exceeds the limits, in which `exceeded` is the names of the exceeded limits, like UsageLimitRSS.
+type UsageLimitHandler func(ctx context.Context, usage *ProcessUsage, exceeded []string)
+
+// UsageWatcher watches the resource usage of processes periodically, and calls the handler
+// if any process exceeds the limits, which is useful for building lightweight process guards.
+type UsageWatcher struct {
+	mu      sync.Mutex
+	pids    func() []int         // Returns the pids of processes to watch.
+	limit   UsageLimit           // Resource usage limits.
+	handler UsageLimitHandler    // Callback handler if any process exceeds the limits.
+	last    map[int]ProcessUsage // Last usage of processes for CPU percent calculation.
+	entry   *gtimer.Entry        // Timer entry of periodical checks.
+}
+
+// Usage retrieves and returns the resource usage of process `pid`.
+// It is fully supported on linux, partly supported on other unix-like platforms using "ps" command,
+// and not supported on windows.
+//
+// Note that the CPUPercent of the returned usage is always 0, use UsageWatcher for CPU usage percent.
+func Usage(pid int) (*ProcessUsage, error) {
+	usage, err := doUsage(pid)
+	if err != nil {
+		return nil, err
+	}
+	usage.Pid = pid
+	usage.Time = time.Now()
+	return usage, nil
+}
+
+// NewUsageWatcher creates and starts a watcher checking the resource usage in every `interval`
+// of processes returned by `pids`, and calls `handler` if any process exceeds `limit`.
+func NewUsageWatcher(
+	ctx context.Context, interval time.Duration, pids func() []int, limit UsageLimit, handler UsageLimitHandler,
+) *UsageWatcher {
+	w := &UsageWatcher{
+		pids:    pids,
+		limit:   limit,
+		handler: handler,
+		last:    make(map[int]ProcessUsage),
+	}
+	w.entry = gtimer.AddSingleton(ctx, interval, w.Check)
+	return w
+}
+
+// WatchUsage creates and starts a watcher checking the resource usage of all processes of the manager,
+// and calls `handler` if any process exceeds `limit`. Also see NewUsageWatcher.
+func (m *Manager) WatchUsage(ctx context.Context, interval time.Duration, limit UsageLimit, handler UsageLimitHandler) *UsageWatcher {
+	return NewUsageWatcher(ctx, interval, m.Pids, limit, handler)
+}
+
+// Check checks the resource usage of processes immediately, which is called periodically by the watcher.
+func (w *UsageWatcher) Check(ctx context.Context) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	current := make(map[int]ProcessUsage)
+	for _, pid := range w.pids() {
+		usage, err := Usage(pid)
+		if err != nil {
+			intlog.Errorf(ctx, `retrieve usage of process "%d" failed: %+v`, pid, err)
+			continue
+		}
+		if last, ok := w.last[pid]; ok {
+			if elapsed := usage.Time.Sub(last.Time); elapsed > 0 {
+				cpu := (usage.CPUUser + usage.CPUSystem) - (last.CPUUser + last.CPUSystem)
+				usage.CPUPercent = float64(cpu) / float64(elapsed) * 100
+			}
+		}
+		current[pid] = *usage
+		if exceeded := w.exceeded(usage); len(exceeded) > 0 {
+			w.handler(ctx, usage, exceeded)
+		}
+	}
+	w.last = current
+}
+
+// Close stops the watcher.
+func (w *UsageWatcher) Close() {
+	w.entry.Close()
+}
+
+// exceeded returns the names of limits that `usage` exceeds.
+func (w *UsageWatcher) exceeded(usage *ProcessUsage) []string {
+	var exceeded []string
+	if w.limit.CPUPercent > 0 && usage.CPUPercent > w.limit.CPUPercent {
+		exceeded = append(exceeded, UsageLimitCPU)
+	}
+	if w.limit.RSS > 0 && usage.RSS > w.limit.RSS {
+		exceeded = append(exceeded, UsageLimitRSS)
+	}
+	if w.limit.FDs > 0 && usage.FDs > w.limit.FDs {
+		exceeded = append(exceeded, UsageLimitFDs)
+	}
+	if w.limit.Threads > 0 && usage.Threads > w.limit.Threads {
+		exceeded = append(exceeded, UsageLimitThreads)
+	}
+	return exceeded
+}
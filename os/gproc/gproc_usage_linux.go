@@ -0,0 +1,64 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+//go:build linux
+// +build linux
+
+package gproc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// clockTicksPerSecond is the USER_HZ of linux kernel, which is 100 on almost all platforms.
+const clockTicksPerSecond = 100
+
+// doUsage retrieves the resource usage of process `pid` from the proc filesystem.
+func doUsage(pid int) (*ProcessUsage, error) {
+	statPath := fmt.Sprintf(`/proc/%d/stat`, pid)
+	content, err := ioutil.ReadFile(statPath)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `read "%s" failed`, statPath)
+	}
+	// The process name in the second field might contain spaces and parentheses,
+	// so the fields are parsed after the last ')'.
+	stat := string(content)
+	pos := strings.LastIndexByte(stat, ')')
+	if pos == -1 {
+		return nil, gerror.NewCodef(gcode.CodeInternalError, `invalid content of "%s"`, statPath)
+	}
+	// The fields start from the third field "state".
+	fields := strings.Fields(stat[pos+1:])
+	if len(fields) < 22 {
+		return nil, gerror.NewCodef(gcode.CodeInternalError, `invalid content of "%s"`, statPath)
+	}
+	var (
+		utime, _   = strconv.ParseInt(fields[11], 10, 64)
+		stime, _   = strconv.ParseInt(fields[12], 10, 64)
+		threads, _ = strconv.Atoi(fields[17])
+		rss, _     = strconv.ParseInt(fields[21], 10, 64)
+		usage      = &ProcessUsage{
+			CPUUser:   time.Duration(utime) * time.Second / clockTicksPerSecond,
+			CPUSystem: time.Duration(stime) * time.Second / clockTicksPerSecond,
+			RSS:       rss * int64(os.Getpagesize()),
+			FDs:       -1,
+			Threads:   threads,
+		}
+	)
+	// It might have no permission reading file descriptors of other users' processes.
+	if fds, err := ioutil.ReadDir(fmt.Sprintf(`/proc/%d/fd`, pid)); err == nil {
+		usage.FDs = len(fds)
+	}
+	return usage, nil
+}
@@ -0,0 +1,58 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+//go:build !linux && !windows
+// +build !linux,!windows
+
+package gproc
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// doUsage retrieves the resource usage of process `pid` using "ps" command,
+// which does not support FDs and Threads.
+func doUsage(pid int) (*ProcessUsage, error) {
+	output, err := exec.Command("ps", "-o", "rss=,time=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return nil, gerror.Wrapf(err, `retrieve usage of process "%d" using ps failed`, pid)
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) < 2 {
+		return nil, gerror.NewCodef(gcode.CodeInternalError, `invalid output of ps: %s`, output)
+	}
+	rss, _ := strconv.ParseInt(fields[0], 10, 64)
+	return &ProcessUsage{
+		// It cannot distinguish user and system time from ps, so all is taken as user time.
+		CPUUser: parsePsTime(fields[1]),
+		RSS:     rss * 1024,
+		FDs:     -1,
+		Threads: -1,
+	}, nil
+}
+
+// parsePsTime parses the cumulative CPU time of ps in format "[dd-]hh:mm:ss" or "mm:ss.cc".
+func parsePsTime(s string) time.Duration {
+	var (
+		duration time.Duration
+		days     int64
+	)
+	if pos := strings.IndexByte(s, '-'); pos != -1 {
+		days, _ = strconv.ParseInt(s[:pos], 10, 64)
+		s = s[pos+1:]
+	}
+	for _, part := range strings.Split(s, ":") {
+		seconds, _ := strconv.ParseFloat(part, 64)
+		duration = duration*60 + time.Duration(seconds*float64(time.Second))
+	}
+	return duration + time.Duration(days)*24*time.Hour
+}
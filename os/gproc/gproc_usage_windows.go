@@ -0,0 +1,20 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+//go:build windows
+// +build windows
+
+package gproc
+
+import (
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// doUsage is not supported on windows platform.
+func doUsage(pid int) (*ProcessUsage, error) {
+	return nil, gerror.NewCodef(gcode.CodeNotSupported, `retrieving usage of process "%d" is not supported on windows`, pid)
+}
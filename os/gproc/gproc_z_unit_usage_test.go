@@ -0,0 +1,59 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gproc_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gproc"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Usage(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("only fully supported on linux")
+	}
+	gtest.C(t, func(t *gtest.T) {
+		usage, err := gproc.Usage(gproc.Pid())
+		t.AssertNil(err)
+		t.Assert(usage.Pid, gproc.Pid())
+		t.Assert(usage.RSS > 0, true)
+		t.Assert(usage.Threads > 0, true)
+		t.Assert(usage.FDs > 0, true)
+
+		_, err = gproc.Usage(-1)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_UsageWatcher(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("only fully supported on linux")
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx      = gctx.New()
+			exceeded = garray.NewStrArray(true)
+			watcher  = gproc.NewUsageWatcher(ctx, time.Hour, func() []int {
+				return []int{gproc.Pid(), -1}
+			}, gproc.UsageLimit{
+				RSS:     1,
+				Threads: 1 << 20,
+			}, func(ctx context.Context, usage *gproc.ProcessUsage, names []string) {
+				exceeded.Append(names...)
+			})
+		)
+		defer watcher.Close()
+		watcher.Check(ctx)
+		t.Assert(exceeded.Slice(), []string{gproc.UsageLimitRSS})
+	})
+}
@@ -33,20 +33,57 @@ func init() {
 `
 )
 
+// PackOption is the option for resource packing.
+type PackOption struct {
+	// Prefix indicates the prefix for each file packed into the result bytes.
+	Prefix string
+
+	// Include specifies the glob patterns of files that are packed, all files are packed if it is empty.
+	// The pattern without "/" matches the file name, like "*.html", or else it matches the path
+	// relative to the source path, like "template/**/*.html", in which "**" matches any directories.
+	Include []string
+
+	// Exclude specifies the glob patterns of files and directories that are not packed,
+	// which has higher priority than Include. The files under excluded directories are also excluded.
+	Exclude []string
+
+	// Transforms specifies the hooks transforming the file content before packed, like minifying
+	// or template pre-compiling, which are called in sequence.
+	Transforms []PackTransform
+}
+
+// PackTransform is the hook transforming the content of the files matching Pattern.
+type PackTransform struct {
+	// Pattern is the glob pattern of files to be transformed, which matches like PackOption.Include.
+	// All files are transformed if it is empty.
+	Pattern string
+
+	// Handler transforms and returns the `content` of file, in which `name` is the file path in resource.
+	Handler func(name string, content []byte) ([]byte, error)
+}
+
 // Pack packs the path specified by `srcPaths` into bytes.
 // The unnecessary parameter `keyPrefix` indicates the prefix for each file
 // packed into the result bytes.
 //
 // Note that parameter `srcPaths` supports multiple paths join with ','.
 func Pack(srcPaths string, keyPrefix ...string) ([]byte, error) {
-	var (
-		buffer       = bytes.NewBuffer(nil)
-		headerPrefix = ""
-	)
+	option := PackOption{}
 	if len(keyPrefix) > 0 && keyPrefix[0] != "" {
-		headerPrefix = keyPrefix[0]
+		option.Prefix = keyPrefix[0]
 	}
-	err := zipPathWriter(srcPaths, buffer, headerPrefix)
+	return PackWithOption(srcPaths, option)
+}
+
+// PackWithOption packs the path specified by `srcPaths` into bytes with custom `option`,
+// which supports include/exclude patterns and content transforms.
+//
+// Note that parameter `srcPaths` supports multiple paths join with ','.
+func PackWithOption(srcPaths string, option PackOption) ([]byte, error) {
+	var (
+		buffer = bytes.NewBuffer(nil)
+	)
+	err := zipPathWriter(srcPaths, buffer, option)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +104,18 @@ func PackToFile(srcPaths, dstPath string, keyPrefix ...string) error {
 	return gfile.PutBytes(dstPath, data)
 }
 
+// PackToFileWithOption packs the path specified by `srcPaths` to target file `dstPath`
+// with custom `option`.
+//
+// Note that parameter `srcPaths` supports multiple paths join with ','.
+func PackToFileWithOption(srcPaths, dstPath string, option PackOption) error {
+	data, err := PackWithOption(srcPaths, option)
+	if err != nil {
+		return err
+	}
+	return gfile.PutBytes(dstPath, data)
+}
+
 // PackToGoFile packs the path specified by `srcPaths` to target go file `goFilePath`
 // with given package name `pkgName`.
 //
@@ -85,6 +134,21 @@ func PackToGoFile(srcPath, goFilePath, pkgName string, keyPrefix ...string) erro
 	)
 }
 
+// PackToGoFileWithOption packs the path specified by `srcPaths` to target go file `goFilePath`
+// with given package name `pkgName` and custom `option`.
+//
+// Note that parameter `srcPaths` supports multiple paths join with ','.
+func PackToGoFileWithOption(srcPath, goFilePath, pkgName string, option PackOption) error {
+	data, err := PackWithOption(srcPath, option)
+	if err != nil {
+		return err
+	}
+	return gfile.PutContents(
+		goFilePath,
+		fmt.Sprintf(gstr.TrimLeft(packedGoSourceTemplate), pkgName, gbase64.EncodeToString(data)),
+	)
+}
+
 // Unpack unpacks the content specified by `path` to []*File.
 func Unpack(path string) ([]*File, error) {
 	realPath, err := gfile.Search(path)
@@ -8,9 +8,12 @@ package gres
 
 import (
 	"archive/zip"
+	"bytes"
 	"context"
 	"io"
+	"io/ioutil"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -22,16 +25,16 @@ import (
 )
 
 // ZipPathWriter compresses `paths` to `writer` using zip compressing algorithm.
-// The unnecessary parameter `prefix` indicates the path prefix for zip file.
+// The parameter `option` specifies the path prefix, include/exclude patterns and transforms for zip file.
 //
 // Note that the parameter `paths` can be either a directory or a file, which
 // supports multiple paths join with ','.
-func zipPathWriter(paths string, writer io.Writer, prefix ...string) error {
+func zipPathWriter(paths string, writer io.Writer, option PackOption) error {
 	zipWriter := zip.NewWriter(writer)
 	defer zipWriter.Close()
 	for _, path := range strings.Split(paths, ",") {
 		path = strings.TrimSpace(path)
-		if err := doZipPathWriter(path, "", zipWriter, prefix...); err != nil {
+		if err := doZipPathWriter(path, zipWriter, option); err != nil {
 			return err
 		}
 	}
@@ -39,10 +42,8 @@ func zipPathWriter(paths string, writer io.Writer, prefix ...string) error {
 }
 
 // doZipPathWriter compresses the file of given `path` and writes the content to `zipWriter`.
-// The parameter `exclude` specifies the exclusive file path that is not compressed to `zipWriter`,
-// commonly the destination zip file path.
-// The unnecessary parameter `prefix` indicates the path prefix for zip file.
-func doZipPathWriter(path string, exclude string, zipWriter *zip.Writer, prefix ...string) error {
+// The parameter `option` specifies the path prefix, include/exclude patterns and transforms for zip file.
+func doZipPathWriter(path string, zipWriter *zip.Writer, option PackOption) error {
 	var (
 		err   error
 		files []string
@@ -59,11 +60,7 @@ func doZipPathWriter(path string, exclude string, zipWriter *zip.Writer, prefix
 	} else {
 		files = []string{path}
 	}
-	headerPrefix := ""
-	if len(prefix) > 0 && prefix[0] != "" {
-		headerPrefix = prefix[0]
-	}
-	headerPrefix = strings.TrimRight(headerPrefix, `\/`)
+	headerPrefix := strings.TrimRight(option.Prefix, `\/`)
 	if len(headerPrefix) > 0 && gfile.IsDir(path) {
 		headerPrefix += "/"
 	}
@@ -72,15 +69,19 @@ func doZipPathWriter(path string, exclude string, zipWriter *zip.Writer, prefix
 	}
 	headerPrefix = strings.Replace(headerPrefix, `//`, `/`, -1)
 	for _, file := range files {
-		if exclude == file {
+		subFilePath := file[len(path):]
+		relativePath := strings.Trim(strings.Replace(subFilePath, `\`, `/`, -1), `/`)
+		if relativePath == "" {
+			relativePath = gfile.Basename(file)
+		}
+		if !isPackIncluded(relativePath, gfile.IsDir(file), option) {
 			intlog.Printf(context.TODO(), `exclude file path: %s`, file)
 			continue
 		}
-		subFilePath := file[len(path):]
 		if subFilePath != "" {
 			subFilePath = gfile.Dir(subFilePath)
 		}
-		if err = zipFile(file, headerPrefix+subFilePath, zipWriter); err != nil {
+		if err = zipFile(file, headerPrefix+subFilePath, relativePath, zipWriter, option.Transforms); err != nil {
 			return err
 		}
 	}
@@ -104,7 +105,9 @@ func doZipPathWriter(path string, exclude string, zipWriter *zip.Writer, prefix
 
 // zipFile compresses the file of given `path` and writes the content to `zw`.
 // The parameter `prefix` indicates the path prefix for zip file.
-func zipFile(path string, prefix string, zw *zip.Writer) error {
+// The parameter `relativePath` is the path relative to the packing path, which is used for
+// matching the `transforms`.
+func zipFile(path string, prefix string, relativePath string, zw *zip.Writer, transforms []PackTransform) error {
 	prefix = strings.Replace(prefix, `//`, `/`, -1)
 	file, err := os.Open(path)
 	if err != nil {
@@ -129,15 +132,97 @@ func zipFile(path string, prefix string, zw *zip.Writer) error {
 		err = gerror.Wrapf(err, `create zip header failed for %#v`, header)
 		return err
 	}
-	if !info.IsDir() {
-		if _, err = io.Copy(writer, file); err != nil {
-			err = gerror.Wrapf(err, `io.Copy failed for file "%s"`, path)
+	if info.IsDir() {
+		return nil
+	}
+	var reader io.Reader = file
+	for _, transform := range transforms {
+		if transform.Pattern != "" && !matchPackPattern(transform.Pattern, relativePath) {
+			continue
+		}
+		if reader, err = transformFile(reader, header.Name, transform); err != nil {
 			return err
 		}
 	}
+	if _, err = io.Copy(writer, reader); err != nil {
+		err = gerror.Wrapf(err, `io.Copy failed for file "%s"`, path)
+		return err
+	}
 	return nil
 }
 
+// transformFile reads all content from `reader`, and returns the reader of content transformed by `transform`.
+func transformFile(reader io.Reader, name string, transform PackTransform) (io.Reader, error) {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, gerror.Wrapf(err, `read content failed for file "%s"`, name)
+	}
+	if content, err = transform.Handler(name, content); err != nil {
+		return nil, gerror.Wrapf(err, `transform content failed for file "%s"`, name)
+	}
+	return bytes.NewReader(content), nil
+}
+
+// isPackIncluded checks and returns whether the file of `relativePath` is packed with include/exclude
+// patterns of `option`. The file is excluded if any of its parent directories is excluded.
+func isPackIncluded(relativePath string, isDir bool, option PackOption) bool {
+	for path := relativePath; path != "" && path != "."; path = gfile.Dir(path) {
+		for _, pattern := range option.Exclude {
+			if matchPackPattern(pattern, path) {
+				return false
+			}
+		}
+		if !strings.Contains(path, "/") {
+			break
+		}
+	}
+	// The include patterns only match files.
+	if isDir || len(option.Include) == 0 {
+		return true
+	}
+	for _, pattern := range option.Include {
+		if matchPackPattern(pattern, relativePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPackPattern checks whether glob `pattern` matches `relativePath`.
+// The pattern without "/" matches the file name, or else it matches the whole relative path,
+// in which "**" matches any directories, "*" matches any characters except "/" and "?" matches one character.
+func matchPackPattern(pattern, relativePath string) bool {
+	pattern = strings.Trim(strings.Replace(pattern, `\`, `/`, -1), `/`)
+	if !strings.Contains(pattern, "/") {
+		relativePath = gfile.Basename(relativePath)
+	}
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					// "**/" matches zero or more directories.
+					i++
+					expr.WriteString(`(.*/)?`)
+				} else {
+					expr.WriteString(`.*`)
+				}
+			} else {
+				expr.WriteString(`[^/]*`)
+			}
+		case '?':
+			expr.WriteString(`[^/]`)
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+	return gregex.IsMatchString(expr.String(), relativePath)
+}
+
 func zipFileVirtual(info os.FileInfo, path string, zw *zip.Writer) error {
 	header, err := createFileHeader(info, "")
 	if err != nil {
@@ -12,6 +12,7 @@ import (
 
 	_ "github.com/gogf/gf/v2/os/gres/testdata/data"
 
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/gres"
@@ -354,3 +355,56 @@ func TestFile_Export(t *testing.T) {
 		t.Assert(len(files), 2)
 	})
 }
+
+func Test_PackWithOption(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		data, err := gres.PackWithOption(gtest.DataPath("files"), gres.PackOption{
+			Prefix:  "www",
+			Include: []string{"*.html", "root/**/*.css", "i18n-res/en.toml"},
+			Exclude: []string{"layout2", "template-res/layout1/footer.html"},
+			Transforms: []gres.PackTransform{
+				{
+					Pattern: "*.css",
+					Handler: func(name string, content []byte) ([]byte, error) {
+						return []byte("/* " + name + " */"), nil
+					},
+				},
+				{
+					Handler: func(name string, content []byte) ([]byte, error) {
+						return []byte(strings.TrimSpace(string(content))), nil
+					},
+				},
+			},
+		})
+		t.AssertNil(err)
+
+		r := gres.New()
+		t.AssertNil(r.Add(string(data)))
+		t.Assert(r.Contains("www/template-res/index.html"), true)
+		t.Assert(r.Contains("www/template-res/layout1/header.html"), true)
+		t.Assert(r.Contains("www/template-res/layout1/footer.html"), false)
+		t.Assert(r.Contains("www/template-res/layout2"), false)
+		t.Assert(r.Contains("www/template-res/layout2/main/main1.html"), false)
+		t.Assert(r.Contains("www/root/index.html"), true)
+		t.Assert(r.Contains("www/root/image/logo.png"), false)
+		t.Assert(r.Contains("www/config-custom/config.toml"), false)
+		t.Assert(r.Contains("www/i18n-res/en.toml"), true)
+		t.Assert(r.Contains("www/i18n-res/ja.toml"), false)
+		t.Assert(r.GetContent("www/root/css/style.css"), "/* www/root/css/style.css */")
+		t.Assert(
+			r.GetContent("www/root/index.html"),
+			strings.TrimSpace(gfile.GetContents(gtest.DataPath("files", "root", "index.html"))),
+		)
+	})
+	// Transform error.
+	gtest.C(t, func(t *gtest.T) {
+		err := gres.PackToFileWithOption(gtest.DataPath("files"), gfile.Temp(gtime.TimestampNanoStr()), gres.PackOption{
+			Transforms: []gres.PackTransform{{
+				Handler: func(name string, content []byte) ([]byte, error) {
+					return nil, gerror.New("transform failed")
+				},
+			}},
+		})
+		t.AssertNE(err, nil)
+	})
+}
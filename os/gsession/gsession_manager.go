@@ -8,13 +8,25 @@ package gsession
 
 import (
 	"context"
+	"sync"
 	"time"
+
+	"github.com/gogf/gf/v2/os/gcache"
 )
 
 // Manager for sessions.
 type Manager struct {
-	ttl     time.Duration // TTL for sessions.
-	storage Storage       // Storage interface for session storage.
+	ttl            time.Duration  // TTL for sessions.
+	storage        Storage        // Storage interface for session storage.
+	maxSize        int            // Max serialized size in bytes for a session, no limit if it is 0.
+	oversizePolicy OversizePolicy // Policy handling the session exceeding max size.
+	interceptors   []Interceptor  // Interceptors for session data loading and saving.
+	binding        *BindingOption // Option binding sessions to the client.
+
+	maxUserSessions int           // Max count of concurrent sessions per user, no limit if it is 0.
+	userIndex       *gcache.Cache // User to sessions index.
+	userIndexOnce   sync.Once     // Lazy initialization for userIndex.
+	userMu          sync.Mutex    // Serializes the read-modify-write of userIndex.
 }
 
 // New creates and returns a new session manager.
@@ -65,3 +77,18 @@ func (m *Manager) SetTTL(ttl time.Duration) {
 func (m *Manager) GetTTL() time.Duration {
 	return m.ttl
 }
+
+// SetMaxSize sets the max serialized size in bytes for a session, which is checked when the session
+// is dirty and closing. The optional parameter `policy` specifies how to handle the oversize session,
+// which is OversizePolicyError in default. It is not limited if `size` is 0.
+func (m *Manager) SetMaxSize(size int, policy ...OversizePolicy) {
+	m.maxSize = size
+	if len(policy) > 0 {
+		m.oversizePolicy = policy[0]
+	}
+}
+
+// GetMaxSize returns the max serialized size in bytes for a session and the oversize policy.
+func (m *Manager) GetMaxSize() (size int, policy OversizePolicy) {
+	return m.maxSize, m.oversizePolicy
+}
@@ -0,0 +1,70 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+)
+
+// GetSessionHandler is the handler loading session data from storage, which has the same signature as Storage.GetSession.
+type GetSessionHandler func(ctx context.Context, sessionId string, ttl time.Duration) (*gmap.StrAnyMap, error)
+
+// SetSessionHandler is the handler saving session data to storage, which has the same signature as Storage.SetSession.
+type SetSessionHandler func(ctx context.Context, sessionId string, sessionData *gmap.StrAnyMap, ttl time.Duration) error
+
+// Interceptor intercepts the session data loading and saving of Manager, which is used for cross-cutting
+// concerns like tracing, metrics, data scrubbing and read-only enforcement without wrapping the Storage.
+//
+// Both of the fields are optional. An interceptor calls `next` to continue the chain, or else it returns
+// without calling `next` to stop the chain, like the ghttp middleware.
+type Interceptor struct {
+	// GetSession intercepts the loading of session data when session starts.
+	GetSession func(ctx context.Context, sessionId string, ttl time.Duration, next GetSessionHandler) (*gmap.StrAnyMap, error)
+
+	// SetSession intercepts the saving of session data when dirty session closes.
+	// Note that `sessionData` is the data of the session, modifying it changes the session data.
+	SetSession func(ctx context.Context, sessionId string, sessionData *gmap.StrAnyMap, ttl time.Duration, next SetSessionHandler) error
+}
+
+// Use adds interceptors to the manager. The interceptors are called in order of they are added,
+// which means the first added interceptor is the outermost one.
+//
+// Note that it should be called before the manager is used, as it is not concurrent-safe.
+func (m *Manager) Use(interceptors ...Interceptor) {
+	m.interceptors = append(m.interceptors, interceptors...)
+}
+
+// getSession loads session data from storage through the interceptor chain.
+func (m *Manager) getSession(ctx context.Context, sessionId string, ttl time.Duration) (*gmap.StrAnyMap, error) {
+	var handler GetSessionHandler = m.storage.GetSession
+	for i := len(m.interceptors) - 1; i >= 0; i-- {
+		if intercept := m.interceptors[i].GetSession; intercept != nil {
+			next := handler
+			handler = func(ctx context.Context, sessionId string, ttl time.Duration) (*gmap.StrAnyMap, error) {
+				return intercept(ctx, sessionId, ttl, next)
+			}
+		}
+	}
+	return handler(ctx, sessionId, ttl)
+}
+
+// setSession saves session data to storage through the interceptor chain.
+func (m *Manager) setSession(ctx context.Context, sessionId string, sessionData *gmap.StrAnyMap, ttl time.Duration) error {
+	var handler SetSessionHandler = m.storage.SetSession
+	for i := len(m.interceptors) - 1; i >= 0; i-- {
+		if intercept := m.interceptors[i].SetSession; intercept != nil {
+			next := handler
+			handler = func(ctx context.Context, sessionId string, sessionData *gmap.StrAnyMap, ttl time.Duration) error {
+				return intercept(ctx, sessionId, sessionData, ttl, next)
+			}
+		}
+	}
+	return handler(ctx, sessionId, sessionData, ttl)
+}
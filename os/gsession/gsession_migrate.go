@@ -0,0 +1,200 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// MigrateOption is the option for function Migrate.
+type MigrateOption struct {
+	// TTL is the session TTL of the source storage, which is usually the TTL of its Manager.
+	// It is used for reading sessions from source storage, and also as the TTL of migrated
+	// sessions that do not expire in source storage. It is required.
+	TTL time.Duration
+
+	// BatchSize is the count of sessions migrated in a batch, default is 100.
+	BatchSize int
+
+	// ContinueOnError specifies whether it continues migrating the other sessions if any session
+	// fails migrating, the failed sessions are counted in MigrateResult.Failed.
+	// It stops and returns the error in default.
+	ContinueOnError bool
+
+	// Progress is called after each batch migrated with the result till now.
+	Progress func(ctx context.Context, result MigrateResult)
+}
+
+// MigrateResult is the result of function Migrate.
+type MigrateResult struct {
+	Migrated int // Count of migrated sessions.
+	Skipped  int // Count of skipped sessions that are empty or expired during migrating.
+	Failed   int // Count of failed sessions.
+}
+
+const (
+	defaultMigrateBatchSize = 100
+	redisScanCount          = 100
+)
+
+// Migrate copies all live sessions with their remaining TTLs from storage `from` to storage `to`,
+// which is usually used for moving sessions from file storage to Redis without logging every user out.
+// The storage `from` should implement the interface StorageIterator.
+//
+// Note that the sessions written to `from` during migrating might not be migrated, so it is suggested
+// migrating during low traffic and switching the storage after that.
+func Migrate(ctx context.Context, from, to Storage, option MigrateOption) (result MigrateResult, err error) {
+	iterator, ok := from.(StorageIterator)
+	if !ok {
+		return result, gerror.NewCodef(
+			gcode.CodeNotSupported, `source storage "%T" does not support iterating sessions`, from,
+		)
+	}
+	if option.TTL <= 0 {
+		return result, gerror.NewCode(gcode.CodeInvalidParameter, `migrate option TTL is required`)
+	}
+	if option.BatchSize <= 0 {
+		option.BatchSize = defaultMigrateBatchSize
+	}
+	type batchItem struct {
+		id  string
+		ttl time.Duration
+	}
+	var (
+		batch      = make([]batchItem, 0, option.BatchSize)
+		flushBatch = func() error {
+			for _, item := range batch {
+				migrated, migrateErr := migrateSession(ctx, from, to, item.id, item.ttl, option.TTL)
+				switch {
+				case migrateErr != nil:
+					result.Failed++
+					if !option.ContinueOnError {
+						return migrateErr
+					}
+					intlog.Errorf(ctx, `%+v`, migrateErr)
+				case migrated:
+					result.Migrated++
+				default:
+					result.Skipped++
+				}
+			}
+			batch = batch[:0]
+			if option.Progress != nil {
+				option.Progress(ctx, result)
+			}
+			return nil
+		}
+	)
+	iterateErr := iterator.Iterate(ctx, func(sessionId string, ttl time.Duration) bool {
+		if ttl <= 0 {
+			ttl = option.TTL
+		}
+		batch = append(batch, batchItem{id: sessionId, ttl: ttl})
+		if len(batch) >= option.BatchSize {
+			if err = flushBatch(); err != nil {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return result, err
+	}
+	if iterateErr != nil {
+		return result, iterateErr
+	}
+	if len(batch) > 0 {
+		err = flushBatch()
+	}
+	return result, err
+}
+
+// migrateSession copies session `sessionId` from storage `from` to storage `to` with `ttl`.
+// It returns false if the session is empty or does not exist.
+func migrateSession(
+	ctx context.Context, from, to Storage, sessionId string, ttl, sessionTTL time.Duration,
+) (migrated bool, err error) {
+	// The session data might be retrieved by Data or GetSession depending on the storage.
+	data, err := from.Data(ctx, sessionId)
+	if err != nil && err != ErrorDisabled {
+		return false, gerror.Wrapf(err, `read session "%s" failed`, sessionId)
+	}
+	if err == ErrorDisabled {
+		var sessionData *gmap.StrAnyMap
+		if sessionData, err = from.GetSession(ctx, sessionId, sessionTTL); err != nil {
+			return false, gerror.Wrapf(err, `read session "%s" failed`, sessionId)
+		}
+		if sessionData != nil {
+			data = sessionData.Map()
+		}
+	}
+	if len(data) == 0 {
+		return false, nil
+	}
+	// The session data might be written by SetMap or SetSession depending on the storage.
+	if err = to.SetMap(ctx, sessionId, data, ttl); err != nil && err != ErrorDisabled {
+		return false, gerror.Wrapf(err, `write session "%s" failed`, sessionId)
+	}
+	if err = to.SetSession(ctx, sessionId, gmap.NewStrAnyMapFrom(data, true), ttl); err != nil {
+		return false, gerror.Wrapf(err, `write session "%s" failed`, sessionId)
+	}
+	return true, nil
+}
+
+// iterateRedisSessions iterates the session keys with `prefix` in redis with their remaining TTL.
+func iterateRedisSessions(
+	ctx context.Context, redis *gredis.Redis, prefix string, f func(sessionId string, ttl time.Duration) bool,
+) error {
+	if prefix == "" {
+		return gerror.NewCode(
+			gcode.CodeNotSupported, `iterating sessions requires the storage created with key prefix`,
+		)
+	}
+	var cursor = "0"
+	for {
+		v, err := redis.Do(ctx, "SCAN", cursor, "MATCH", prefix+"*", "COUNT", redisScanCount)
+		if err != nil {
+			return err
+		}
+		array := v.Interfaces()
+		if len(array) != 2 {
+			return gerror.NewCodef(gcode.CodeInternalError, `invalid redis SCAN result: %v`, array)
+		}
+		cursor = gconv.String(array[0])
+		for _, key := range gconv.Strings(array[1]) {
+			if isRedisTokenKey(key) {
+				continue
+			}
+			r, err := redis.Do(ctx, "PTTL", key)
+			if err != nil {
+				return err
+			}
+			// -2: key does not exist, -1: key does not expire.
+			milli := r.Int64()
+			if milli == -2 {
+				continue
+			}
+			if milli < 0 {
+				milli = 0
+			}
+			if !f(key[len(prefix):], time.Duration(milli)*time.Millisecond) {
+				return nil
+			}
+		}
+		if cursor == "0" {
+			return nil
+		}
+	}
+}
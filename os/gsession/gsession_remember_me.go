@@ -0,0 +1,287 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// RememberMe is the companion store of long-lived "remember me" tokens for persistent login,
+// which restores the session of a user after the session expires.
+//
+// A token is composed of a selector and a validator like "selector:validator". Only the SHA256 hash of
+// the validator is stored, which is compared in constant time, so the leaking of the store does not leak
+// usable tokens. The token is rotated each time it is used. If a token of which the selector is valid
+// but the validator is not is presented, which means the token might be stolen and used already,
+// all the tokens of the user are revoked.
+type RememberMe struct {
+	cache      *gcache.Cache // Cache storing the token records, which can be redis for distributed services.
+	ttl        time.Duration // TTL of tokens.
+	prefix     string        // Key prefix in cache.
+	cookieName string        // Cookie name for the token in ghttp.
+	sessionKey string        // Session key storing the user id of the restored session.
+}
+
+// RememberMeOption is the option for NewRememberMe.
+type RememberMeOption struct {
+	TTL        time.Duration // TTL of tokens, default is 30 days.
+	Cache      *gcache.Cache // Cache storing the token records, default is in-memory cache.
+	Prefix     string        // Key prefix in cache, default is "gf_remember_me:".
+	CookieName string        // Cookie name for the token used by ghttp, default is "gf_remember_me".
+	SessionKey string        // Session key storing the user id of the restored session, default is "_gf_remember_me_user".
+}
+
+// RememberMeRecord is the record of a remember-me token.
+type RememberMeRecord struct {
+	UserId string                 `json:"userId"` // User id the token bound to.
+	Data   map[string]interface{} `json:"data"`   // Custom data restored to the session.
+	Hash   string                 `json:"hash"`   // SHA256 hash of the validator in hex.
+}
+
+const (
+	DefaultRememberMeTTL        = 30 * 24 * time.Hour
+	DefaultRememberMePrefix     = "gf_remember_me:"
+	DefaultRememberMeCookieName = "gf_remember_me"
+	DefaultRememberMeSessionKey = "_gf_remember_me_user"
+
+	rememberMeSelectorBytes  = 12
+	rememberMeValidatorBytes = 24
+	rememberMeSeparator      = ":"
+)
+
+// NewRememberMe creates and returns a remember-me token store.
+func NewRememberMe(option ...RememberMeOption) *RememberMe {
+	var opt RememberMeOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	r := &RememberMe{
+		cache:      opt.Cache,
+		ttl:        opt.TTL,
+		prefix:     opt.Prefix,
+		cookieName: opt.CookieName,
+		sessionKey: opt.SessionKey,
+	}
+	if r.cache == nil {
+		r.cache = gcache.New()
+	}
+	if r.ttl <= 0 {
+		r.ttl = DefaultRememberMeTTL
+	}
+	if r.prefix == "" {
+		r.prefix = DefaultRememberMePrefix
+	}
+	if r.cookieName == "" {
+		r.cookieName = DefaultRememberMeCookieName
+	}
+	if r.sessionKey == "" {
+		r.sessionKey = DefaultRememberMeSessionKey
+	}
+	return r
+}
+
+// TTL returns the TTL of tokens.
+func (r *RememberMe) TTL() time.Duration {
+	return r.ttl
+}
+
+// CookieName returns the cookie name for the token.
+func (r *RememberMe) CookieName() string {
+	return r.cookieName
+}
+
+// SessionKey returns the session key storing the user id of the restored session.
+func (r *RememberMe) SessionKey() string {
+	return r.sessionKey
+}
+
+// Issue issues and returns a token for `userId`, which is usually called when user logs in with
+// "remember me" checked. The optional parameter `data` is restored to the session along with the user id.
+func (r *RememberMe) Issue(ctx context.Context, userId string, data ...map[string]interface{}) (token string, err error) {
+	if userId == "" {
+		return "", gerror.NewCode(gcode.CodeInvalidParameter, `user id cannot be empty`)
+	}
+	record := &RememberMeRecord{
+		UserId: userId,
+	}
+	if len(data) > 0 {
+		record.Data = data[0]
+	}
+	return r.issue(ctx, record)
+}
+
+// Consume validates `token` and returns its record, it also rotates the token and returns the new one,
+// which should be sent to the client to replace the old one.
+// It returns nil record if the token is invalid or expired.
+func (r *RememberMe) Consume(ctx context.Context, token string) (record *RememberMeRecord, newToken string, err error) {
+	selector, validator := r.parseToken(token)
+	if selector == "" {
+		return nil, "", nil
+	}
+	if record, err = r.getRecord(ctx, selector); err != nil || record == nil {
+		return nil, "", err
+	}
+	if subtle.ConstantTimeCompare([]byte(record.Hash), []byte(hashRememberMeValidator(validator))) != 1 {
+		// The selector is valid but the validator is not, the token might be stolen.
+		return nil, "", r.RevokeUser(ctx, record.UserId)
+	}
+	if err = r.removeSelectors(ctx, record.UserId, selector); err != nil {
+		return nil, "", err
+	}
+	if newToken, err = r.issue(ctx, record); err != nil {
+		return nil, "", err
+	}
+	return record, newToken, nil
+}
+
+// Revoke invalidates `token`, which is usually called when user logs out.
+func (r *RememberMe) Revoke(ctx context.Context, token string) error {
+	selector, _ := r.parseToken(token)
+	if selector == "" {
+		return nil
+	}
+	record, err := r.getRecord(ctx, selector)
+	if err != nil || record == nil {
+		return err
+	}
+	return r.removeSelectors(ctx, record.UserId, selector)
+}
+
+// RevokeUser invalidates all the tokens of `userId`, which is usually called when user changes password.
+func (r *RememberMe) RevokeUser(ctx context.Context, userId string) error {
+	selectors, err := r.getUserSelectors(ctx, userId)
+	if err != nil {
+		return err
+	}
+	keys := make([]interface{}, 0, len(selectors)+1)
+	for _, selector := range selectors {
+		keys = append(keys, r.selectorKey(selector))
+	}
+	keys = append(keys, r.userKey(userId))
+	return r.cache.Removes(ctx, keys)
+}
+
+// Restore restores `record` to `session`, which sets the user id of the record with session key
+// and the custom data of the record.
+func (r *RememberMe) Restore(session *Session, record *RememberMeRecord) error {
+	data := make(map[string]interface{}, len(record.Data)+1)
+	for k, v := range record.Data {
+		data[k] = v
+	}
+	data[r.sessionKey] = record.UserId
+	return session.SetMap(data)
+}
+
+// issue stores `record` with a new token and returns the token.
+func (r *RememberMe) issue(ctx context.Context, record *RememberMeRecord) (token string, err error) {
+	var (
+		selector  = grand.Secure.Token(rememberMeSelectorBytes)
+		validator = grand.Secure.Token(rememberMeValidatorBytes)
+	)
+	// It stores a copy, as the record might be returned to and modified by caller.
+	stored := *record
+	stored.Hash = hashRememberMeValidator(validator)
+	if err = r.cache.Set(ctx, r.selectorKey(selector), &stored, r.ttl); err != nil {
+		return "", err
+	}
+	selectors, err := r.getUserSelectors(ctx, record.UserId)
+	if err != nil {
+		return "", err
+	}
+	if err = r.cache.Set(ctx, r.userKey(record.UserId), append(selectors, selector), r.ttl); err != nil {
+		return "", err
+	}
+	return selector + rememberMeSeparator + validator, nil
+}
+
+// getRecord retrieves the record of `selector`, it returns nil if not found.
+func (r *RememberMe) getRecord(ctx context.Context, selector string) (*RememberMeRecord, error) {
+	v, err := r.cache.Get(ctx, r.selectorKey(selector))
+	if err != nil || v.IsNil() {
+		return nil, err
+	}
+	if record, ok := v.Val().(*RememberMeRecord); ok {
+		return record, nil
+	}
+	var record *RememberMeRecord
+	if err = v.Scan(&record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// getUserSelectors retrieves the live selectors of `userId`.
+func (r *RememberMe) getUserSelectors(ctx context.Context, userId string) ([]string, error) {
+	v, err := r.cache.Get(ctx, r.userKey(userId))
+	if err != nil || v.IsNil() {
+		return nil, err
+	}
+	selectors := make([]string, 0)
+	for _, selector := range v.Strings() {
+		// Filters out the expired ones.
+		ok, err := r.cache.Contains(ctx, r.selectorKey(selector))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			selectors = append(selectors, selector)
+		}
+	}
+	return selectors, nil
+}
+
+// removeSelectors removes the records of `selectors` and removes them from the index of `userId`.
+func (r *RememberMe) removeSelectors(ctx context.Context, userId string, selectors ...string) error {
+	keys := make([]interface{}, 0, len(selectors))
+	for _, selector := range selectors {
+		keys = append(keys, r.selectorKey(selector))
+	}
+	if err := r.cache.Removes(ctx, keys); err != nil {
+		return err
+	}
+	remaining, err := r.getUserSelectors(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if len(remaining) == 0 {
+		return r.cache.Removes(ctx, []interface{}{r.userKey(userId)})
+	}
+	return r.cache.Set(ctx, r.userKey(userId), remaining, r.ttl)
+}
+
+// parseToken parses `token` to selector and validator, it returns empty strings if the token is invalid.
+func (r *RememberMe) parseToken(token string) (selector, validator string) {
+	array := strings.Split(token, rememberMeSeparator)
+	if len(array) != 2 || array[0] == "" || array[1] == "" {
+		return "", ""
+	}
+	return array[0], array[1]
+}
+
+func (r *RememberMe) selectorKey(selector string) string {
+	return r.prefix + "token:" + selector
+}
+
+func (r *RememberMe) userKey(userId string) string {
+	return r.prefix + "user:" + userId
+}
+
+// hashRememberMeValidator returns the SHA256 hash in hex of `validator`.
+func hashRememberMeValidator(validator string) string {
+	sum := sha256.Sum256([]byte(validator))
+	return hex.EncodeToString(sum[:])
+}
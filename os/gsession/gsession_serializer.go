@@ -0,0 +1,140 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/encoding/gmsgpack"
+	"github.com/gogf/gf/v2/errors/gerror"
+	ijson "github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Serializer is the interface for serializing session data map to bytes, which is used by storages
+// that persist the whole session data as one value, like StorageFile and StorageRedis.
+//
+// Note that the session data serialized by one Serializer cannot be deserialized by another,
+// so changing the serializer of a storage makes the existing sessions in it unreadable.
+type Serializer interface {
+	// Serialize serializes session data map `data` to bytes.
+	Serialize(data map[string]interface{}) ([]byte, error)
+
+	// Deserialize deserializes `content` to session data map.
+	Deserialize(content []byte) (map[string]interface{}, error)
+}
+
+// iSerializerGetter is the interface for storages that support configuring serializer.
+type iSerializerGetter interface {
+	GetSerializer() Serializer
+}
+
+type (
+	serializerJson    struct{}
+	serializerGob     struct{}
+	serializerMsgpack struct{}
+)
+
+var (
+	// SerializerJson serializes session data with JSON, which is the default serializer.
+	// The numbers are deserialized as json.Number.
+	SerializerJson Serializer = serializerJson{}
+
+	// SerializerGob serializes session data with encoding/gob, which keeps the Go types of values.
+	// The custom types stored in session should be registered with gob.Register.
+	SerializerGob Serializer = serializerGob{}
+
+	// SerializerMsgpack serializes session data with MessagePack, which produces smaller payload than JSON
+	// and keeps the integers as int64/uint64.
+	SerializerMsgpack Serializer = serializerMsgpack{}
+)
+
+func init() {
+	// Registers the common types that might be stored in session as interface{} for gob.
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+	gob.Register(time.Time{})
+	gob.Register(json.Number(""))
+}
+
+// Serialize implements interface Serializer.
+func (serializerJson) Serialize(data map[string]interface{}) ([]byte, error) {
+	return ijson.Marshal(data)
+}
+
+// Deserialize implements interface Serializer.
+func (serializerJson) Deserialize(content []byte) (map[string]interface{}, error) {
+	var m map[string]interface{}
+	if err := ijson.UnmarshalUseNumber(content, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Serialize implements interface Serializer.
+func (serializerGob) Serialize(data map[string]interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(data); err != nil {
+		return nil, gerror.Wrap(err, `gob encode session data failed`)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Deserialize implements interface Serializer.
+func (serializerGob) Deserialize(content []byte) (map[string]interface{}, error) {
+	var m map[string]interface{}
+	if err := gob.NewDecoder(bytes.NewReader(content)).Decode(&m); err != nil {
+		return nil, gerror.Wrap(err, `gob decode session data failed`)
+	}
+	return m, nil
+}
+
+// Serialize implements interface Serializer.
+func (serializerMsgpack) Serialize(data map[string]interface{}) ([]byte, error) {
+	return gmsgpack.Encode(data)
+}
+
+// Deserialize implements interface Serializer.
+func (serializerMsgpack) Deserialize(content []byte) (map[string]interface{}, error) {
+	v, err := gmsgpack.Decode(content)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		return m, nil
+	}
+	return gconv.Map(v), nil
+}
+
+// serializeSessionData serializes `sessionData` with `serializer`, which uses SerializerJson if it is nil.
+func serializeSessionData(serializer Serializer, sessionData *gmap.StrAnyMap) ([]byte, error) {
+	if serializer == nil {
+		serializer = SerializerJson
+	}
+	var data map[string]interface{}
+	if sessionData != nil {
+		data = sessionData.Map()
+	}
+	return serializer.Serialize(data)
+}
+
+// deserializeSessionData deserializes `content` with `serializer`, which uses SerializerJson if it is nil.
+// It returns nil if there's no data in `content`.
+func deserializeSessionData(serializer Serializer, content []byte) (*gmap.StrAnyMap, error) {
+	if serializer == nil {
+		serializer = SerializerJson
+	}
+	m, err := serializer.Deserialize(content)
+	if err != nil || m == nil {
+		return nil, err
+	}
+	return gmap.NewStrAnyMapFrom(m, true), nil
+}
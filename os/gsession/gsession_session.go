@@ -8,6 +8,7 @@ package gsession
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/gogf/gf/v2/container/gmap"
@@ -17,16 +18,28 @@ import (
 	"github.com/gogf/gf/v2/internal/intlog"
 )
 
+const (
+	// internalKeyPrefix is the key prefix of the internal keys storing the metadata of session.
+	internalKeyPrefix = "_gf_"
+)
+
 // Session struct for storing single session data, which is bound to a single request.
 // The Session struct is the interface with user, but the Storage is the underlying adapter designed interface
 // for functionality implements.
 type Session struct {
-	id      string          // Session id. It retrieves the session if id is custom specified.
-	ctx     context.Context // Context for current session. Please note that, session lives along with context.
-	data    *gmap.StrAnyMap // Current Session data, which is retrieved from Storage.
-	dirty   bool            // Used to mark session is modified.
-	start   bool            // Used to mark session is started.
-	manager *Manager        // Parent session Manager.
+	id       string           // Session id. It retrieves the session if id is custom specified.
+	ctx      context.Context  // Context for current session. Please note that, session lives along with context.
+	data     *gmap.StrAnyMap  // Current Session data, which is retrieved from Storage.
+	dirty    bool             // Used to mark session is modified.
+	readOnly bool             // Used to mark session is read-only.
+	start    bool             // Used to mark session is started.
+	exists   bool             // Used to mark session exists in storage when it starts.
+	manager  *Manager         // Parent session Manager.
+	keyTTLs  map[string]int64 // TTLs of keys cached in memory, which is retrieved from storage only once.
+
+	clientIp        string // Client IP for the client binding.
+	clientUserAgent string // Client User-Agent for the client binding.
+	clientSet       bool   // Whether the client information is set.
 
 	// idFunc is a callback function used for creating custom session id.
 	// This is called if session id is empty ever when session starts.
@@ -39,44 +52,59 @@ func (s *Session) init() error {
 	if s.start {
 		return nil
 	}
-	var err error
+	var (
+		err      error
+		restored = s.id != ""
+	)
 	// Session retrieving.
 	if s.id != "" {
 		// Retrieve stored session data from storage.
 		if s.manager.storage != nil {
-			s.data, err = s.manager.storage.GetSession(s.ctx, s.id, s.manager.GetTTL())
+			s.data, err = s.manager.getSession(s.ctx, s.id, s.manager.GetTTL())
 			if err != nil && err != ErrorDisabled {
 				intlog.Errorf(s.ctx, `session restoring failed for id "%s": %+v`, s.id, err)
 				return err
 			}
+			s.exists = s.data != nil
 		}
 	}
 	// Session id creation.
 	if s.id == "" {
-		if s.idFunc != nil {
-			// Use custom session id creating function.
-			s.id = s.idFunc(s.manager.ttl)
-		} else {
-			// Use default session id creating function of storage.
-			s.id, err = s.manager.storage.New(s.ctx, s.manager.ttl)
-			if err != nil && err != ErrorDisabled {
-				intlog.Errorf(s.ctx, "create session id failed: %+v", err)
-				return err
-			}
-			// If session storage does not implements id generating functionality,
-			// it then uses default session id creating function.
-			if s.id == "" {
-				s.id = NewSessionId()
-			}
+		if s.id, err = s.newId(); err != nil {
+			return err
 		}
 	}
 	if s.data == nil {
 		s.data = gmap.NewStrAnyMap(true)
 	}
 	s.start = true
+	// Client binding checks for restored session.
+	if restored {
+		return s.checkBinding()
+	}
 	return nil
 }
 
+// newId creates and returns a new session id.
+func (s *Session) newId() (id string, err error) {
+	if s.idFunc != nil {
+		// Use custom session id creating function.
+		return s.idFunc(s.manager.ttl), nil
+	}
+	// Use default session id creating function of storage.
+	id, err = s.manager.storage.New(s.ctx, s.manager.ttl)
+	if err != nil && err != ErrorDisabled {
+		intlog.Errorf(s.ctx, "create session id failed: %+v", err)
+		return "", err
+	}
+	// If session storage does not implements id generating functionality,
+	// it then uses default session id creating function.
+	if id == "" {
+		id = NewSessionId()
+	}
+	return id, nil
+}
+
 // Close closes current session and updates its ttl in the session manager.
 // If this session is dirty, it also exports it to storage.
 //
@@ -85,14 +113,37 @@ func (s *Session) Close() error {
 	if s.manager.storage == nil {
 		return nil
 	}
+	// The read-only session is not saved or renewed, unless it is modified internally,
+	// like handling the client binding mismatching.
+	if s.readOnly {
+		if !s.dirty {
+			return nil
+		}
+		s.readOnly = false
+		defer func() { s.readOnly = true }()
+	}
 	if s.start && s.id != "" {
+		// Clean the expired keys before updating to storage.
+		if err := s.clearExpiredKeys(); err != nil {
+			return err
+		}
+		if s.dirty {
+			if err := s.saveBinding(); err != nil {
+				return err
+			}
+			if err := s.checkSize(); err != nil {
+				return err
+			}
+		}
 		size := s.data.Size()
 		if s.dirty {
-			err := s.manager.storage.SetSession(s.ctx, s.id, s.data, s.manager.ttl)
+			err := s.manager.setSession(s.ctx, s.id, s.data, s.manager.ttl)
 			if err != nil && err != ErrorDisabled {
 				return err
 			}
-		} else if size > 0 {
+		} else if size > 0 || s.exists {
+			// The existing session is renewed even if its data is not in memory,
+			// like the storage of which the data is retrieved by key.
 			err := s.manager.storage.UpdateTTL(s.ctx, s.id, s.manager.ttl)
 			if err != nil && err != ErrorDisabled {
 				return err
@@ -103,7 +154,16 @@ func (s *Session) Close() error {
 }
 
 // Set sets key-value pair to this session.
+// It also clears the TTL of `key` if it is set by SetWithTTL before.
 func (s *Session) Set(key string, value interface{}) (err error) {
+	return s.doSetWithMeta(map[string]interface{}{key: value}, 0)
+}
+
+// doSet sets key-value pair to this session without TTL handling.
+func (s *Session) doSet(key string, value interface{}) (err error) {
+	if err = s.checkWritable(); err != nil {
+		return err
+	}
 	if err = s.init(); err != nil {
 		return err
 	}
@@ -119,10 +179,72 @@ func (s *Session) Set(key string, value interface{}) (err error) {
 }
 
 // SetMap batch sets the session using map.
+// It also clears the TTLs of the keys if they are set by SetWithTTL before.
 func (s *Session) SetMap(data map[string]interface{}) (err error) {
+	return s.doSetWithMeta(data, 0)
+}
+
+// doSetWithMeta sets `data` to this session along with the metadata of its keys in one storage writing,
+// which are the TTLs of the keys and their set times for OversizePolicyTruncate.
+// The keys expire after `ttl` if it is positive, or else their TTLs are cleared.
+func (s *Session) doSetWithMeta(data map[string]interface{}, ttl time.Duration) (err error) {
+	if err = s.checkWritable(); err != nil {
+		return err
+	}
 	if err = s.init(); err != nil {
 		return err
 	}
+	var (
+		keys     = make([]string, 0, len(data))
+		metaData = make(map[string]interface{}, len(data)+2)
+	)
+	for key, value := range data {
+		keys = append(keys, key)
+		metaData[key] = value
+	}
+	setTimes, err := s.touchKeys(keys...)
+	if err != nil {
+		return err
+	}
+	if setTimes != nil {
+		metaData[keySetTimesKey] = setTimes
+	}
+	ttls, err := s.getKeyTTLs()
+	if err != nil {
+		return err
+	}
+	var (
+		ttlsChanged bool
+		expire      = time.Now().Add(ttl).UnixNano() / int64(time.Millisecond)
+	)
+	for _, key := range keys {
+		if ttl > 0 {
+			ttls[key] = expire
+			ttlsChanged = true
+		} else if _, ok := ttls[key]; ok {
+			delete(ttls, key)
+			ttlsChanged = true
+		}
+	}
+	if ttlsChanged && len(ttls) > 0 {
+		metaData[keyTTLsKey] = keyTTLsToMap(ttls)
+	}
+	if err = s.doSetMap(metaData); err != nil {
+		return err
+	}
+	if !ttlsChanged {
+		return nil
+	}
+	if len(ttls) == 0 {
+		// No TTL left, the storing key is removed.
+		return s.setKeyTTLs(ttls)
+	}
+	s.keyTTLs = ttls
+	return nil
+}
+
+// doSetMap batch sets the session using map without metadata handling.
+func (s *Session) doSetMap(data map[string]interface{}) (err error) {
 	if err = s.manager.storage.SetMap(s.ctx, s.id, data, s.manager.ttl); err != nil {
 		if err == ErrorDisabled {
 			s.data.Sets(data)
@@ -136,9 +258,20 @@ func (s *Session) SetMap(data map[string]interface{}) (err error) {
 
 // Remove removes key along with its value from this session.
 func (s *Session) Remove(keys ...string) (err error) {
+	if err = s.doRemove(keys...); err != nil {
+		return err
+	}
+	return s.removeKeyTTLs(keys...)
+}
+
+// doRemove removes key along with its value from this session without TTL handling.
+func (s *Session) doRemove(keys ...string) (err error) {
 	if s.id == "" {
 		return nil
 	}
+	if err = s.checkWritable(); err != nil {
+		return err
+	}
 	if err = s.init(); err != nil {
 		return err
 	}
@@ -160,6 +293,9 @@ func (s *Session) RemoveAll() (err error) {
 	if s.id == "" {
 		return nil
 	}
+	if err = s.checkWritable(); err != nil {
+		return err
+	}
 	if err = s.init(); err != nil {
 		return err
 	}
@@ -172,6 +308,7 @@ func (s *Session) RemoveAll() (err error) {
 	if s.data != nil {
 		s.data.Clear()
 	}
+	s.keyTTLs = nil
 	s.dirty = true
 	return nil
 }
@@ -207,7 +344,22 @@ func (s *Session) SetIdFunc(f func(ttl time.Duration) string) error {
 
 // Data returns all data as map.
 // Note that it's using value copy internally for concurrent-safe purpose.
+//
+// The internal keys with prefix "_gf_", like the key TTLs and tokens, are not included.
 func (s *Session) Data() (sessionData map[string]interface{}, err error) {
+	if sessionData, err = s.allData(); err != nil {
+		return nil, err
+	}
+	for key := range sessionData {
+		if isInternalKey(key) {
+			delete(sessionData, key)
+		}
+	}
+	return sessionData, nil
+}
+
+// allData returns all data as map, including the internal keys.
+func (s *Session) allData() (sessionData map[string]interface{}, err error) {
 	if s.id == "" {
 		return map[string]interface{}{}, nil
 	}
@@ -218,28 +370,32 @@ func (s *Session) Data() (sessionData map[string]interface{}, err error) {
 	if err != nil && err != ErrorDisabled {
 		intlog.Errorf(s.ctx, `%+v`, err)
 	}
-	if sessionData != nil {
-		return sessionData, nil
+	if sessionData == nil {
+		sessionData = s.data.Map()
+	}
+	expiredKeys, err := s.getExpiredKeys()
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range expiredKeys {
+		delete(sessionData, key)
 	}
-	return s.data.Map(), nil
+	return sessionData, nil
 }
 
-// Size returns the size of the session.
+// Size returns the size of the session, which is the count of keys not including the internal keys.
 func (s *Session) Size() (size int, err error) {
-	if s.id == "" {
-		return 0, nil
-	}
-	if err = s.init(); err != nil {
+	data, err := s.Data()
+	if err != nil {
 		return 0, err
 	}
-	size, err = s.manager.storage.GetSize(s.ctx, s.id)
-	if err != nil && err != ErrorDisabled {
-		intlog.Errorf(s.ctx, `%+v`, err)
-	}
-	if size > 0 {
-		return size, nil
-	}
-	return s.data.Size(), nil
+	return len(data), nil
+}
+
+// isInternalKey checks whether `key` is the internal key storing the metadata of session,
+// which is not exposed by Data and Size.
+func isInternalKey(key string) bool {
+	return strings.HasPrefix(key, internalKeyPrefix)
 }
 
 // Contains checks whether key exist in the session.
@@ -257,6 +413,26 @@ func (s *Session) Contains(key string) (ok bool, err error) {
 	return !v.IsNil(), nil
 }
 
+// ReadOnly marks the session read-only, which is usually used for the handlers only reading
+// the session data. The read-only session is neither saved nor renewed with TTL when it closes,
+// which saves the writing of storage, and the modifying of it fails with error.
+func (s *Session) ReadOnly() {
+	s.readOnly = true
+}
+
+// IsReadOnly checks whether the session is read-only.
+func (s *Session) IsReadOnly() bool {
+	return s.readOnly
+}
+
+// checkWritable returns error if the session is read-only.
+func (s *Session) checkWritable() error {
+	if s.readOnly {
+		return gerror.NewCode(gcode.CodeInvalidOperation, "session is read-only")
+	}
+	return nil
+}
+
 // IsDirty checks whether there's any data changes in the session.
 func (s *Session) IsDirty() bool {
 	return s.dirty
@@ -277,10 +453,20 @@ func (s *Session) Get(key string, def ...interface{}) (value *gvar.Var, err erro
 		intlog.Errorf(s.ctx, `%+v`, err)
 		return nil, err
 	}
-	if v != nil {
-		return gvar.New(v), nil
+	if v == nil {
+		v = s.data.Get(key)
+	}
+	if v != nil && key != keyTTLsKey {
+		// The key with TTL is considered as not existing after it expires.
+		var expired bool
+		if expired, err = s.isKeyExpired(key); err != nil {
+			return nil, err
+		}
+		if expired {
+			v = nil
+		}
 	}
-	if v = s.data.Get(key); v != nil {
+	if v != nil {
 		return gvar.New(v), nil
 	}
 	if len(def) > 0 {
@@ -0,0 +1,230 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/crypto/gmd5"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// BindingAction is the action handling the session of which the client mismatches its binding.
+type BindingAction int
+
+// BindingPolicy is the callback deciding the action on client mismatching, which is usually
+// used for logging or alerting the anomaly of stolen session cookies.
+type BindingPolicy func(ctx context.Context, s *Session, mismatch *BindingMismatch) BindingAction
+
+// BindingOption is the option binding sessions to the client, see Manager.SetBinding.
+type BindingOption struct {
+	IPv4Prefix int           // Prefix bits of IPv4 range that the session is bound to, eg: 24, it disables IPv4 binding if it is 0.
+	IPv6Prefix int           // Prefix bits of IPv6 range that the session is bound to, eg: 64, it disables IPv6 binding if it is 0.
+	UserAgent  bool          // Whether the session is bound to the fingerprint of client User-Agent.
+	Policy     BindingPolicy // Callback deciding the action on mismatching, it is BindingActionDestroy if it is nil.
+}
+
+// BindingMismatch is the detail of the client mismatching the session binding.
+type BindingMismatch struct {
+	BoundIp             string // IP range that the session is bound to, like: 192.168.1.0/24.
+	ClientIp            string // IP of current client.
+	ClientUserAgent     string // User-Agent of current client.
+	IpMismatched        bool   // Whether the client IP is out of the bound range.
+	UserAgentMismatched bool   // Whether the client User-Agent mismatches the bound fingerprint.
+}
+
+const (
+	// BindingActionDestroy removes all the data of the session, which makes it a new empty session
+	// with a new session id.
+	BindingActionDestroy BindingAction = iota
+	// BindingActionReauth unbinds the user of the session and rebinds the session to current client,
+	// which requires the user to authenticate again but keeps the other session data in a new session id.
+	BindingActionReauth
+	// BindingActionIgnore keeps the session and its binding as it is.
+	BindingActionIgnore
+)
+
+const (
+	// bindingKey is the session key storing the client binding.
+	bindingKey = "_gf_binding"
+)
+
+// SetBinding sets the option binding sessions to the client, which detects the session used by other
+// clients, eg: stolen session cookies. The session is bound to the client information set by
+// Session.SetClient when it is saved or restored without binding, and the binding is checked when
+// the session is restored.
+// It disables the binding if `option` is nil.
+func (m *Manager) SetBinding(option *BindingOption) {
+	m.binding = option
+}
+
+// GetBinding returns the option binding sessions to the client.
+func (m *Manager) GetBinding() *BindingOption {
+	return m.binding
+}
+
+// SetClient sets the client IP and User-Agent of current session for the client binding,
+// which should be called before session starts. It returns error if it is called after session starts.
+func (s *Session) SetClient(ip, userAgent string) error {
+	if s.start {
+		return gerror.NewCode(gcode.CodeInvalidOperation, "session already started")
+	}
+	s.clientIp = ip
+	s.clientUserAgent = userAgent
+	s.clientSet = true
+	return nil
+}
+
+// checkBinding checks the binding of restored session with current client,
+// and handles the mismatching with the binding policy.
+func (s *Session) checkBinding() error {
+	option := s.manager.binding
+	if option == nil || !s.clientSet {
+		return nil
+	}
+	v, err := s.Get(bindingKey)
+	if err != nil {
+		return err
+	}
+	// The session restored without binding, like the one created before the binding is enabled,
+	// is bound to current client at once.
+	if v == nil {
+		if s.readOnly {
+			s.readOnly = false
+			defer func() { s.readOnly = true }()
+		}
+		return s.doSet(bindingKey, newBindingData(option, s.clientIp, s.clientUserAgent))
+	}
+	var (
+		bound    = v.MapStrStr()
+		current  = newBindingData(option, s.clientIp, s.clientUserAgent)
+		mismatch = &BindingMismatch{
+			BoundIp:         bound["ip"],
+			ClientIp:        s.clientIp,
+			ClientUserAgent: s.clientUserAgent,
+		}
+	)
+	if current["ip"] != "" && bound["ip"] != "" {
+		mismatch.IpMismatched = current["ip"] != bound["ip"]
+	}
+	if current["ua"] != "" && bound["ua"] != "" {
+		mismatch.UserAgentMismatched = current["ua"] != bound["ua"]
+	}
+	if !mismatch.IpMismatched && !mismatch.UserAgentMismatched {
+		return nil
+	}
+	action := BindingActionDestroy
+	if option.Policy != nil {
+		action = option.Policy(s.ctx, s, mismatch)
+	}
+	// The mismatching is handled even if the session is read-only.
+	if s.readOnly && action != BindingActionIgnore {
+		s.readOnly = false
+		defer func() { s.readOnly = true }()
+	}
+	switch action {
+	case BindingActionIgnore:
+		return nil
+
+	case BindingActionReauth:
+		if err = s.UnbindUser(); err != nil {
+			return err
+		}
+		if err = s.regenerateId(true); err != nil {
+			return err
+		}
+		return s.doSet(bindingKey, current)
+
+	default:
+		if err = s.RemoveAll(); err != nil {
+			return err
+		}
+		// The new empty session is bound to current client when it is saved.
+		return s.regenerateId(false)
+	}
+}
+
+// regenerateId removes current session from storage and moves the session to a new session id,
+// so that the mismatching client cannot use the session id anymore.
+// It keeps the session data in the new session if `keepData` is true.
+func (s *Session) regenerateId(keepData bool) error {
+	var (
+		err  error
+		data map[string]interface{}
+	)
+	if keepData {
+		if data, err = s.allData(); err != nil {
+			return err
+		}
+	}
+	if err = s.manager.storage.RemoveAll(s.ctx, s.id); err != nil && err != ErrorDisabled {
+		return err
+	}
+	if s.id, err = s.newId(); err != nil {
+		return err
+	}
+	s.data = gmap.NewStrAnyMapFrom(data, true)
+	s.exists = false
+	s.keyTTLs = nil
+	s.dirty = true
+	return nil
+}
+
+// saveBinding binds the session to current client if it is not bound yet.
+func (s *Session) saveBinding() error {
+	option := s.manager.binding
+	if option == nil || !s.clientSet {
+		return nil
+	}
+	v, err := s.Get(bindingKey)
+	if err != nil || v != nil {
+		return err
+	}
+	return s.doSet(bindingKey, newBindingData(option, s.clientIp, s.clientUserAgent))
+}
+
+// newBindingData creates and returns the binding data of client by `option`.
+func newBindingData(option *BindingOption, ip, userAgent string) map[string]string {
+	data := make(map[string]string)
+	if ipRange := getBindingIpRange(option, ip); ipRange != "" {
+		data["ip"] = ipRange
+	}
+	if option.UserAgent {
+		data["ua"] = gmd5.MustEncryptString(userAgent)
+	}
+	return data
+}
+
+// getBindingIpRange returns the IP range of `ip` by the prefix bits of `option`, eg: 192.168.1.0/24.
+// It returns empty string if the binding of the IP family is disabled.
+func getBindingIpRange(option *BindingOption, ip string) string {
+	parsedIp := net.ParseIP(ip)
+	if parsedIp == nil {
+		if option.IPv4Prefix > 0 || option.IPv6Prefix > 0 {
+			return ip
+		}
+		return ""
+	}
+	var (
+		bits   = 128
+		prefix = option.IPv6Prefix
+	)
+	if v4 := parsedIp.To4(); v4 != nil {
+		parsedIp, bits, prefix = v4, 32, option.IPv4Prefix
+	}
+	if prefix <= 0 {
+		return ""
+	}
+	if prefix > bits {
+		prefix = bits
+	}
+	return fmt.Sprintf(`%s/%d`, parsedIp.Mask(net.CIDRMask(prefix, bits)).String(), prefix)
+}
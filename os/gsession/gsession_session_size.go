@@ -0,0 +1,166 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// OversizePolicy is the policy handling the session exceeding the max size of Manager.
+type OversizePolicy int
+
+const (
+	// OversizePolicyError returns error when closing the oversize session, and the session is not updated to storage.
+	// Note that for the storage updating key-value pairs directly like StorageRedisHashTable and StorageRedis in hash mode,
+	// the pairs are already stored.
+	OversizePolicyError OversizePolicy = iota
+
+	// OversizePolicyTruncate removes the least recently set keys of the oversize session until its size fits.
+	OversizePolicyTruncate
+
+	// OversizePolicyLog only logs a warning for the oversize session.
+	OversizePolicyLog
+)
+
+const (
+	// keySetTimesKey is the session key storing the last set time of keys, which is map[key]milli.
+	// It is only maintained for OversizePolicyTruncate.
+	keySetTimesKey = "_gf_key_set_times"
+)
+
+// checkSize checks the serialized size of the session and handles it with the oversize policy of manager.
+func (s *Session) checkSize() error {
+	maxSize, policy := s.manager.GetMaxSize()
+	if maxSize <= 0 {
+		return nil
+	}
+	data, err := s.allData()
+	if err != nil {
+		return err
+	}
+	size, err := s.serializedSize(data)
+	if err != nil || size <= maxSize {
+		return err
+	}
+	switch policy {
+	case OversizePolicyTruncate:
+		return s.truncate(data, size, maxSize)
+
+	case OversizePolicyLog:
+		glog.Warningf(s.ctx, `session "%s" size %d exceeds max size %d`, s.id, size, maxSize)
+		return nil
+
+	default:
+		return gerror.NewCodef(
+			gcode.CodeInvalidOperation,
+			`session "%s" size %d exceeds max size %d`, s.id, size, maxSize,
+		)
+	}
+}
+
+// truncate removes the least recently set keys of `data` until its serialized size fits `maxSize`.
+// The keys without set time are removed first in order of their names.
+func (s *Session) truncate(data map[string]interface{}, size, maxSize int) (err error) {
+	var (
+		setTimes = gconv.Map(data[keySetTimesKey])
+		keys     = make([]string, 0, len(data))
+	)
+	for key := range data {
+		switch key {
+		case keySetTimesKey, keyTTLsKey:
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ti, tj := gconv.Int64(setTimes[keys[i]]), gconv.Int64(setTimes[keys[j]])
+		if ti != tj {
+			return ti < tj
+		}
+		return keys[i] < keys[j]
+	})
+	removed := make([]string, 0)
+	for _, key := range keys {
+		if size <= maxSize {
+			break
+		}
+		delete(data, key)
+		delete(setTimes, key)
+		data[keySetTimesKey] = setTimes
+		removed = append(removed, key)
+		if size, err = s.serializedSize(data); err != nil {
+			return err
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	glog.Warningf(s.ctx, `session "%s" exceeds max size %d, keys truncated: %v`, s.id, maxSize, removed)
+	if err = s.doRemove(removed...); err != nil {
+		return err
+	}
+	if err = s.removeKeyTTLs(removed...); err != nil {
+		return err
+	}
+	return s.setKeySetTimes(setTimes)
+}
+
+// touchKeys returns the set times of keys in which the set time of `keys` is updated,
+// which is stored along with the keys for OversizePolicyTruncate.
+// It returns nil if the set times are not maintained.
+func (s *Session) touchKeys(keys ...string) (map[string]interface{}, error) {
+	if _, policy := s.manager.GetMaxSize(); policy != OversizePolicyTruncate || len(keys) == 0 {
+		return nil, nil
+	}
+	v, err := s.manager.storage.Get(s.ctx, s.id, keySetTimesKey)
+	if err != nil && err != ErrorDisabled {
+		return nil, err
+	}
+	if v == nil {
+		v = s.data.Get(keySetTimesKey)
+	}
+	var (
+		setTimes = gconv.Map(v)
+		now      = time.Now().UnixNano()
+	)
+	if setTimes == nil {
+		setTimes = make(map[string]interface{})
+	}
+	for i, key := range keys {
+		// Nanoseconds with index keeps the order of keys in the same batch.
+		setTimes[key] = now + int64(i)
+	}
+	return setTimes, nil
+}
+
+// setKeySetTimes stores the set time of keys, it removes the storing key if no key left.
+func (s *Session) setKeySetTimes(setTimes map[string]interface{}) error {
+	if len(setTimes) == 0 {
+		return s.doRemove(keySetTimesKey)
+	}
+	return s.doSet(keySetTimesKey, setTimes)
+}
+
+// serializedSize returns the serialized size of session data,
+// which uses the serializer of the storage if it supports configuring serializer.
+func (s *Session) serializedSize(data map[string]interface{}) (int, error) {
+	var serializer = SerializerJson
+	if v, ok := s.manager.storage.(iSerializerGetter); ok && v.GetSerializer() != nil {
+		serializer = v.GetSerializer()
+	}
+	content, err := serializer.Serialize(data)
+	if err != nil {
+		return 0, gerror.Wrap(err, `serialize session data failed`)
+	}
+	return len(content), nil
+}
@@ -0,0 +1,80 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+const (
+	// tokenBytesLength is the random bytes length of a one-time token.
+	tokenBytesLength = 24
+)
+
+// IssueToken issues and returns a one-time token for `scope`, which expires after `ttl`
+// independently of the session. It is usually used for CSRF tokens and email verification steps,
+// which are validated and invalidated by ConsumeToken.
+//
+// The tokens are stored in the storage with one key per token rather than in the session data,
+// so the storage should implement the interface StorageToken.
+func (s *Session) IssueToken(scope string, ttl time.Duration) (token string, err error) {
+	if ttl <= 0 {
+		return "", gerror.NewCodef(gcode.CodeInvalidParameter, `invalid token ttl "%s"`, ttl)
+	}
+	storage, err := s.tokenStorage()
+	if err != nil {
+		return "", err
+	}
+	token = grand.Secure.Token(tokenBytesLength)
+	if err = storage.SetToken(s.ctx, s.id, tokenKey(scope, token), ttl); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ConsumeToken validates `token` of `scope` issued by IssueToken and invalidates it,
+// which returns true only for the first consuming of a token before it expires.
+//
+// The token is removed atomically in the storage, so only one of the concurrent requests
+// replaying the same token consumes it successfully.
+func (s *Session) ConsumeToken(scope, token string) (ok bool, err error) {
+	if token == "" {
+		return false, nil
+	}
+	storage, err := s.tokenStorage()
+	if err != nil {
+		return false, err
+	}
+	return storage.RemoveToken(s.ctx, s.id, tokenKey(scope, token))
+}
+
+// tokenStorage initializes the session and returns the storage as StorageToken.
+func (s *Session) tokenStorage() (StorageToken, error) {
+	storage, ok := s.manager.storage.(StorageToken)
+	if !ok {
+		return nil, gerror.NewCodef(
+			gcode.CodeNotSupported, `storage "%T" does not support one-time tokens`, s.manager.storage,
+		)
+	}
+	if err := s.init(); err != nil {
+		return nil, err
+	}
+	return storage, nil
+}
+
+// tokenKey returns the storage key of `token` for `scope`, which is hashed so that the key
+// has fixed length and safe characters for all storages.
+func tokenKey(scope, token string) string {
+	sum := sha256.Sum256([]byte(scope + "\n" + token))
+	return hex.EncodeToString(sum[:])
+}
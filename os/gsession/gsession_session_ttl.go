@@ -0,0 +1,150 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+const (
+	// keyTTLsKey is the session key storing the expiration of keys set by SetWithTTL,
+	// which is map[key]expireMilli.
+	keyTTLsKey = "_gf_key_ttls"
+)
+
+// SetWithTTL sets key-value pair to this session, which expires after `ttl` independently of the session.
+// It is usually used for short-lived flags like OTP verified or captcha passed, which should not live as long as the login.
+//
+// The expired key is considered as not existing when reading, and it is removed from the session when the session closes.
+func (s *Session) SetWithTTL(key string, value interface{}, ttl time.Duration) (err error) {
+	if ttl <= 0 {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid key ttl "%s"`, ttl)
+	}
+	return s.doSetWithMeta(map[string]interface{}{key: value}, ttl)
+}
+
+// MustSetWithTTL performs as function SetWithTTL, but it panics if any error occurs.
+func (s *Session) MustSetWithTTL(key string, value interface{}, ttl time.Duration) {
+	if err := s.SetWithTTL(key, value, ttl); err != nil {
+		panic(err)
+	}
+}
+
+// isKeyExpired checks whether `key` is set with TTL and expired.
+func (s *Session) isKeyExpired(key string) (bool, error) {
+	ttls, err := s.getKeyTTLs()
+	if err != nil {
+		return false, err
+	}
+	expire, ok := ttls[key]
+	return ok && expire <= time.Now().UnixNano()/int64(time.Millisecond), nil
+}
+
+// getExpiredKeys returns the keys that are set with TTL and expired.
+func (s *Session) getExpiredKeys() ([]string, error) {
+	ttls, err := s.getKeyTTLs()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		keys = make([]string, 0)
+		now  = time.Now().UnixNano() / int64(time.Millisecond)
+	)
+	for key, expire := range ttls {
+		if expire <= now {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// clearExpiredKeys removes the expired keys along with their TTLs from the session.
+func (s *Session) clearExpiredKeys() error {
+	keys, err := s.getExpiredKeys()
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+	if err = s.doRemove(keys...); err != nil {
+		return err
+	}
+	return s.removeKeyTTLs(keys...)
+}
+
+// removeKeyTTLs removes the TTLs of `keys`, which makes them live as long as the session.
+func (s *Session) removeKeyTTLs(keys ...string) error {
+	ttls, err := s.getKeyTTLs()
+	if err != nil || len(ttls) == 0 {
+		return err
+	}
+	var removed bool
+	for _, key := range keys {
+		if _, ok := ttls[key]; ok {
+			delete(ttls, key)
+			removed = true
+		}
+	}
+	if !removed {
+		return nil
+	}
+	return s.setKeyTTLs(ttls)
+}
+
+// getKeyTTLs retrieves and returns a copy of the TTLs of keys as map[key]expireMilli.
+// The TTLs are retrieved from storage only once and cached in the session,
+// so that reading and setting keys do not retrieve them every time.
+func (s *Session) getKeyTTLs() (map[string]int64, error) {
+	ttls := make(map[string]int64)
+	if s.id == "" {
+		return ttls, nil
+	}
+	if err := s.init(); err != nil {
+		return nil, err
+	}
+	if s.keyTTLs == nil {
+		v, err := s.manager.storage.Get(s.ctx, s.id, keyTTLsKey)
+		if err != nil && err != ErrorDisabled {
+			return nil, err
+		}
+		if v == nil {
+			v = s.data.Get(keyTTLsKey)
+		}
+		s.keyTTLs = make(map[string]int64)
+		for key, expire := range gconv.Map(v) {
+			s.keyTTLs[key] = gconv.Int64(expire)
+		}
+	}
+	for key, expire := range s.keyTTLs {
+		ttls[key] = expire
+	}
+	return ttls, nil
+}
+
+// setKeyTTLs stores the TTLs of keys, it removes the storing key if no TTL left.
+func (s *Session) setKeyTTLs(ttls map[string]int64) (err error) {
+	if len(ttls) == 0 {
+		err = s.doRemove(keyTTLsKey)
+	} else {
+		err = s.doSet(keyTTLsKey, keyTTLsToMap(ttls))
+	}
+	if err == nil {
+		s.keyTTLs = ttls
+	}
+	return err
+}
+
+// keyTTLsToMap converts the TTLs of keys to map for storing.
+func keyTTLsToMap(ttls map[string]int64) map[string]interface{} {
+	data := make(map[string]interface{}, len(ttls))
+	for key, expire := range ttls {
+		data[key] = expire
+	}
+	return data
+}
@@ -0,0 +1,220 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gcache"
+)
+
+const (
+	// userIdKey is the session key storing the user id bound by BindUser.
+	userIdKey = "_gf_user_id"
+	// userIndexKeyPrefix is the key prefix of user to sessions index in cache.
+	userIndexKeyPrefix = "gf_session_user:"
+)
+
+// userSessionItem is an item of user to sessions index.
+type userSessionItem struct {
+	Id   string `json:"id"`   // Session id.
+	Time int64  `json:"time"` // Bind time in milliseconds.
+}
+
+// SetMaxUserSessions sets the max count of concurrent sessions per user bound by Session.BindUser.
+// The oldest sessions of the user are removed from storage when it is exceeded,
+// eg: setting it to 1 enforces single-device login. It is not limited if `max` is 0.
+func (m *Manager) SetMaxUserSessions(max int) {
+	m.maxUserSessions = max
+}
+
+// GetMaxUserSessions returns the max count of concurrent sessions per user.
+func (m *Manager) GetMaxUserSessions() int {
+	return m.maxUserSessions
+}
+
+// SetUserIndex sets the cache storing the user to sessions index, which is in-memory cache in default.
+// It should be a shared cache like redis for distributed services.
+func (m *Manager) SetUserIndex(cache *gcache.Cache) {
+	m.userIndex = cache
+}
+
+// UserSessionIds returns the ids of sessions bound to `userId`, which are ordered by bind time.
+func (m *Manager) UserSessionIds(ctx context.Context, userId string) ([]string, error) {
+	m.userMu.Lock()
+	defer m.userMu.Unlock()
+	items, err := m.getUserSessions(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.Id
+	}
+	return ids, nil
+}
+
+// RemoveUserSessions removes all the sessions bound to `userId` from storage,
+// which is usually used for kicking user out of all devices.
+func (m *Manager) RemoveUserSessions(ctx context.Context, userId string) error {
+	m.userMu.Lock()
+	defer m.userMu.Unlock()
+	items, err := m.getUserSessions(ctx, userId)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err = m.storage.RemoveAll(ctx, item.Id); err != nil && err != ErrorDisabled {
+			return err
+		}
+	}
+	_, err = m.getUserIndex().Remove(ctx, userIndexKeyPrefix+userId)
+	return err
+}
+
+// BindUser binds current session to `userId`, which is usually called when user logs in.
+// If the count of sessions of the user exceeds the max count set by Manager.SetMaxUserSessions,
+// the oldest sessions of the user are removed from storage.
+//
+// Note that the index is updated with read-modify-write, which is serialized in current process only.
+func (s *Session) BindUser(userId string) (err error) {
+	if userId == "" {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `user id cannot be empty`)
+	}
+	if err = s.init(); err != nil {
+		return err
+	}
+	// Unbinds the previous user if changed.
+	if v := s.data.Get(userIdKey); v != nil && v != userId {
+		if err = s.UnbindUser(); err != nil {
+			return err
+		}
+	}
+	if err = s.Set(userIdKey, userId); err != nil {
+		return err
+	}
+	m := s.manager
+	m.userMu.Lock()
+	defer m.userMu.Unlock()
+	items, err := m.getUserSessions(s.ctx, userId)
+	if err != nil {
+		return err
+	}
+	for i, item := range items {
+		if item.Id == s.id {
+			items = append(items[:i], items[i+1:]...)
+			break
+		}
+	}
+	items = append(items, userSessionItem{
+		Id:   s.id,
+		Time: time.Now().UnixNano() / int64(time.Millisecond),
+	})
+	if m.maxUserSessions > 0 && len(items) > m.maxUserSessions {
+		if items, err = m.evictUserSessions(s.ctx, items); err != nil {
+			return err
+		}
+	}
+	return m.setUserSessions(s.ctx, userId, items)
+}
+
+// UnbindUser unbinds current session from its user, which is usually called when user logs out.
+func (s *Session) UnbindUser() (err error) {
+	userId, err := s.UserId()
+	if err != nil || userId == "" {
+		return err
+	}
+	if err = s.Remove(userIdKey); err != nil {
+		return err
+	}
+	m := s.manager
+	m.userMu.Lock()
+	defer m.userMu.Unlock()
+	items, err := m.getUserSessions(s.ctx, userId)
+	if err != nil {
+		return err
+	}
+	for i, item := range items {
+		if item.Id == s.id {
+			items = append(items[:i], items[i+1:]...)
+			break
+		}
+	}
+	return m.setUserSessions(s.ctx, userId, items)
+}
+
+// UserId returns the user id bound by BindUser, it returns empty string if no user bound.
+func (s *Session) UserId() (string, error) {
+	v, err := s.Get(userIdKey)
+	if err != nil || v == nil {
+		return "", err
+	}
+	return v.String(), nil
+}
+
+// evictUserSessions removes the oldest sessions in `items` from storage until the count fits
+// the max count, and returns the remaining ones. The already expired sessions are discarded first.
+func (m *Manager) evictUserSessions(ctx context.Context, items []userSessionItem) ([]userSessionItem, error) {
+	// The last one is the current binding session, which is never evicted.
+	alive := make([]userSessionItem, 0, len(items))
+	for _, item := range items[:len(items)-1] {
+		data, err := m.storage.GetSession(ctx, item.Id, m.ttl)
+		if err != nil && err != ErrorDisabled {
+			return nil, err
+		}
+		// The storages not supporting GetSession cannot tell whether it is expired.
+		if data != nil || err == ErrorDisabled {
+			alive = append(alive, item)
+		}
+	}
+	alive = append(alive, items[len(items)-1])
+	for len(alive) > m.maxUserSessions {
+		if err := m.storage.RemoveAll(ctx, alive[0].Id); err != nil && err != ErrorDisabled {
+			return nil, err
+		}
+		alive = alive[1:]
+	}
+	return alive, nil
+}
+
+// getUserSessions retrieves the sessions index of `userId`.
+func (m *Manager) getUserSessions(ctx context.Context, userId string) ([]userSessionItem, error) {
+	v, err := m.getUserIndex().Get(ctx, userIndexKeyPrefix+userId)
+	if err != nil || v.IsNil() {
+		return nil, err
+	}
+	if items, ok := v.Val().([]userSessionItem); ok {
+		return append([]userSessionItem(nil), items...), nil
+	}
+	var items []userSessionItem
+	if err = v.Scan(&items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// setUserSessions stores the sessions index of `userId`, which expires along with the session TTL.
+func (m *Manager) setUserSessions(ctx context.Context, userId string, items []userSessionItem) error {
+	if len(items) == 0 {
+		_, err := m.getUserIndex().Remove(ctx, userIndexKeyPrefix+userId)
+		return err
+	}
+	return m.getUserIndex().Set(ctx, userIndexKeyPrefix+userId, items, m.ttl)
+}
+
+// getUserIndex returns the cache of user to sessions index, which is lazily created.
+func (m *Manager) getUserIndex() *gcache.Cache {
+	m.userIndexOnce.Do(func() {
+		if m.userIndex == nil {
+			m.userIndex = gcache.New()
+		}
+	})
+	return m.userIndex
+}
@@ -62,3 +62,23 @@ type Storage interface {
 	// This function is called ever after session, which is not dirty, is closed.
 	UpdateTTL(ctx context.Context, sessionId string, ttl time.Duration) error
 }
+
+// StorageIterator is the optional interface for session storage, which supports iterating
+// all live sessions, eg: for migrating sessions between storages.
+type StorageIterator interface {
+	// Iterate iterates all live sessions in the storage with their remaining TTL,
+	// the remaining TTL is 0 if the session does not expire.
+	// It stops iterating if `f` returns false.
+	Iterate(ctx context.Context, f func(sessionId string, ttl time.Duration) bool) error
+}
+
+// StorageToken is the optional interface for session storage, which stores the one-time tokens of
+// sessions with one key per token, so that a token is consumed atomically, see Session.ConsumeToken.
+type StorageToken interface {
+	// SetToken stores token `key` for session `sessionId`, which expires after `ttl`.
+	SetToken(ctx context.Context, sessionId string, key string, ttl time.Duration) error
+
+	// RemoveToken removes token `key` of session `sessionId` atomically. It returns true only if the
+	// token exists and is not expired, and only one of the concurrent callers gets true for a token.
+	RemoveToken(ctx context.Context, sessionId string, key string) (ok bool, err error)
+}
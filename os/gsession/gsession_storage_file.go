@@ -9,7 +9,9 @@ package gsession
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gogf/gf/v2/container/gmap"
@@ -19,7 +21,6 @@ import (
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/intlog"
-	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gogf/gf/v2/os/gtimer"
@@ -28,17 +29,29 @@ import (
 // StorageFile implements the Session Storage interface with file system.
 type StorageFile struct {
 	StorageBase
-	path          string        // Session file storage folder path.
-	ttl           time.Duration // Session TTL.
-	cryptoKey     []byte        // Used when enable crypto feature.
-	cryptoEnabled bool          // Used when enable crypto feature.
-	updatingIdSet *gset.StrSet  // To be batched updated session id set.
+	path          string         // Session file storage folder path.
+	ttl           time.Duration  // Session TTL.
+	cryptoKey     []byte         // Used when enable crypto feature.
+	cryptoEnabled bool           // Used when enable crypto feature.
+	updatingIdSet *gset.StrSet   // To be batched updated session id set.
+	serializer    Serializer     // Serializer for session data, default is SerializerJson.
+	shardLevel    int            // Levels of sharding directories by session id prefix, 0 for no sharding.
+	gc            *storageFileGc // Incremental expiry GC of session files.
 }
 
 const (
 	DefaultStorageFileCryptoEnabled        = false
 	DefaultStorageFileUpdateTTLInterval    = 10 * time.Second
 	DefaultStorageFileClearExpiredInterval = time.Hour
+	DefaultStorageFileGcPace               = 1000
+	DefaultStorageFileGcStepInterval       = time.Second
+)
+
+const (
+	storageFileShardLength = 2 // Length of session id prefix for each level of sharding directory.
+	storageFileMaxShards   = 4 // Maximum levels of sharding directories.
+	storageFileExt         = "session"
+	storageFileTokenExt    = "token" // Extension of the files storing one-time tokens, see StorageToken.
 )
 
 var (
@@ -72,10 +85,12 @@ func NewStorageFile(path string, ttl time.Duration) *StorageFile {
 		cryptoKey:     DefaultStorageFileCryptoKey,
 		cryptoEnabled: DefaultStorageFileCryptoEnabled,
 		updatingIdSet: gset.NewStrSet(true),
+		serializer:    SerializerJson,
+		gc:            newStorageFileGc(),
 	}
 
 	gtimer.AddSingleton(ctx, DefaultStorageFileUpdateTTLInterval, s.timelyUpdateSessionTTL)
-	gtimer.AddSingleton(ctx, DefaultStorageFileClearExpiredInterval, s.timelyClearExpiredSessionFile)
+	s.gc.timer = gtimer.AddSingleton(ctx, DefaultStorageFileGcStepInterval, s.timelyClearExpiredSessionFiles)
 	return s
 }
 
@@ -96,20 +111,6 @@ func (s *StorageFile) timelyUpdateSessionTTL(ctx context.Context) {
 	}
 }
 
-// timelyClearExpiredSessionFile deletes all expired files timely.
-func (s *StorageFile) timelyClearExpiredSessionFile(ctx context.Context) {
-	files, err := gfile.ScanDirFile(s.path, "*.session", false)
-	if err != nil {
-		intlog.Errorf(ctx, `%+v`, err)
-		return
-	}
-	for _, file := range files {
-		if err = s.checkAndClearSessionFile(ctx, file); err != nil {
-			intlog.Errorf(ctx, `%+v`, err)
-		}
-	}
-}
-
 // SetCryptoKey sets the crypto key for session storage.
 // The crypto key is used when crypto feature is enabled.
 func (s *StorageFile) SetCryptoKey(key []byte) {
@@ -121,13 +122,81 @@ func (s *StorageFile) SetCryptoEnabled(enabled bool) {
 	s.cryptoEnabled = enabled
 }
 
+// SetSerializer sets the serializer for session data, which is SerializerJson in default.
+// The nil `serializer` resets it to SerializerJson.
+func (s *StorageFile) SetSerializer(serializer Serializer) {
+	if serializer == nil {
+		serializer = SerializerJson
+	}
+	s.serializer = serializer
+}
+
+// GetSerializer returns the serializer for session data.
+func (s *StorageFile) GetSerializer() Serializer {
+	return s.serializer
+}
+
+// SetShardLevel sets the levels of sharding directories, which shards the session files into
+// sub-directories by session id prefix, eg: session file "abcdef.session" is stored as
+// "ab/cd/abcdef.session" for level 2. It avoids a flat directory with huge number of session files,
+// which makes expiry GC and directory listing pathological. The level is 0 in default, and
+// it is limited to 4.
+//
+// The session files stored before sharding are still retrieved, and they are moved into sharding
+// directories when they are saved again.
+// Note that it should be called before the storage is used, as it is not concurrent-safe.
+func (s *StorageFile) SetShardLevel(level int) {
+	if level < 0 {
+		level = 0
+	}
+	if level > storageFileMaxShards {
+		level = storageFileMaxShards
+	}
+	s.shardLevel = level
+}
+
+// GetShardLevel returns the levels of sharding directories.
+func (s *StorageFile) GetShardLevel() int {
+	return s.shardLevel
+}
+
 // sessionFilePath returns the storage file path for given session id.
 func (s *StorageFile) sessionFilePath(sessionId string) string {
-	return gfile.Join(s.path, sessionId) + ".session"
+	if s.shardLevel > 0 && len(sessionId) >= s.shardLevel*storageFileShardLength {
+		paths := make([]string, 0, s.shardLevel+2)
+		paths = append(paths, s.path)
+		for i := 0; i < s.shardLevel; i++ {
+			paths = append(paths, sessionId[i*storageFileShardLength:(i+1)*storageFileShardLength])
+		}
+		return gfile.Join(append(paths, sessionId)...) + "." + storageFileExt
+	}
+	return s.flatSessionFilePath(sessionId)
+}
+
+// flatSessionFilePath returns the storage file path without sharding for given session id.
+func (s *StorageFile) flatSessionFilePath(sessionId string) string {
+	return gfile.Join(s.path, sessionId) + "." + storageFileExt
+}
+
+// existingSessionFilePath returns the path of existing storage file for given session id,
+// which falls back to the path without sharding if the sharding one does not exist.
+func (s *StorageFile) existingSessionFilePath(sessionId string) string {
+	path := s.sessionFilePath(sessionId)
+	if s.shardLevel > 0 && !gfile.Exists(path) {
+		if flatPath := s.flatSessionFilePath(sessionId); gfile.Exists(flatPath) {
+			return flatPath
+		}
+	}
+	return path
 }
 
 // RemoveAll deletes all key-value pairs from storage.
 func (s *StorageFile) RemoveAll(ctx context.Context, sessionId string) error {
+	if s.shardLevel > 0 {
+		if err := gfile.Remove(s.flatSessionFilePath(sessionId)); err != nil {
+			return err
+		}
+	}
 	return gfile.Remove(s.sessionFilePath(sessionId))
 }
 
@@ -140,7 +209,7 @@ func (s *StorageFile) RemoveAll(ctx context.Context, sessionId string) error {
 // This function is called ever when session starts.
 func (s *StorageFile) GetSession(ctx context.Context, sessionId string, ttl time.Duration) (sessionData *gmap.StrAnyMap, err error) {
 	var (
-		path    = s.sessionFilePath(sessionId)
+		path    = s.existingSessionFilePath(sessionId)
 		content = gfile.GetBytes(path)
 	)
 	// It updates the TTL only if the session file already exists.
@@ -157,14 +226,7 @@ func (s *StorageFile) GetSession(ctx context.Context, sessionId string, ttl time
 				return nil, err
 			}
 		}
-		var m map[string]interface{}
-		if err = json.UnmarshalUseNumber(content, &m); err != nil {
-			return nil, err
-		}
-		if m == nil {
-			return nil, nil
-		}
-		return gmap.NewStrAnyMapFrom(m, true), nil
+		return deserializeSessionData(s.serializer, content)
 	}
 	return nil, nil
 }
@@ -175,7 +237,7 @@ func (s *StorageFile) GetSession(ctx context.Context, sessionId string, ttl time
 func (s *StorageFile) SetSession(ctx context.Context, sessionId string, sessionData *gmap.StrAnyMap, ttl time.Duration) error {
 	intlog.Printf(ctx, "StorageFile.SetSession: %s, %v, %v", sessionId, sessionData, ttl)
 	path := s.sessionFilePath(sessionId)
-	content, err := json.Marshal(sessionData)
+	content, err := serializeSessionData(s.serializer, sessionData)
 	if err != nil {
 		return err
 	}
@@ -186,6 +248,11 @@ func (s *StorageFile) SetSession(ctx context.Context, sessionId string, sessionD
 			return err
 		}
 	}
+	if s.shardLevel > 0 {
+		if err = gfile.Mkdir(gfile.Dir(path)); err != nil {
+			return err
+		}
+	}
 	file, err := gfile.OpenWithFlagPerm(
 		path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm,
 	)
@@ -201,6 +268,12 @@ func (s *StorageFile) SetSession(ctx context.Context, sessionId string, sessionD
 		err = gerror.Wrapf(err, `write data failed to file "%s"`, path)
 		return err
 	}
+	// Remove the session file stored before sharding.
+	if s.shardLevel > 0 {
+		if flatPath := s.flatSessionFilePath(sessionId); flatPath != path && gfile.Exists(flatPath) {
+			return gfile.Remove(flatPath)
+		}
+	}
 	return nil
 }
 
@@ -218,7 +291,7 @@ func (s *StorageFile) UpdateTTL(ctx context.Context, sessionId string, ttl time.
 // updateSessionTTL updates the TTL for specified session id.
 func (s *StorageFile) updateSessionTTl(ctx context.Context, sessionId string) error {
 	intlog.Printf(ctx, "StorageFile.updateSession: %s", sessionId)
-	path := s.sessionFilePath(sessionId)
+	path := s.existingSessionFilePath(sessionId)
 	file, err := gfile.OpenWithFlag(path, os.O_WRONLY)
 	if err != nil {
 		return err
@@ -266,3 +339,73 @@ func (s *StorageFile) checkAndClearSessionFile(ctx context.Context, path string)
 	}
 	return nil
 }
+
+// Iterate iterates all live sessions in the storage with their remaining TTL.
+// It stops iterating if `f` returns false.
+func (s *StorageFile) Iterate(ctx context.Context, f func(sessionId string, ttl time.Duration) bool) error {
+	files, err := gfile.ScanDirFile(s.path, "*."+storageFileExt, s.shardLevel > 0)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		content := gfile.GetBytesByTwoOffsetsByPath(file, 0, 8)
+		if len(content) != 8 {
+			continue
+		}
+		remaining := s.ttl - time.Duration(gtime.TimestampMilli()-gbinary.DecodeToInt64(content))*time.Millisecond
+		if remaining <= 0 {
+			continue
+		}
+		if !f(gfile.Name(file), remaining) {
+			break
+		}
+	}
+	return nil
+}
+
+// tokenFilePath returns the file path of token `key` for given session id.
+func (s *StorageFile) tokenFilePath(sessionId string, key string) string {
+	return strings.TrimSuffix(s.sessionFilePath(sessionId), "."+storageFileExt) + "." + key + "." + storageFileTokenExt
+}
+
+// SetToken stores token `key` for session `sessionId`, which expires after `ttl`.
+// The token is stored in its own file containing the expiring timestamp in milliseconds.
+func (s *StorageFile) SetToken(ctx context.Context, sessionId string, key string, ttl time.Duration) error {
+	return gfile.PutBytes(
+		s.tokenFilePath(sessionId, key),
+		gbinary.EncodeInt64(gtime.TimestampMilli()+ttl.Milliseconds()),
+	)
+}
+
+// RemoveToken removes token `key` of session `sessionId` atomically by removing its file,
+// which returns true only if the token exists and is not expired.
+func (s *StorageFile) RemoveToken(ctx context.Context, sessionId string, key string) (bool, error) {
+	var (
+		path         = s.tokenFilePath(sessionId, key)
+		content, err = ioutil.ReadFile(path)
+	)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, gerror.Wrapf(err, `read token file "%s" failed`, path)
+	}
+	// Only one of the concurrent callers removes the file successfully.
+	if err = os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, gerror.Wrapf(err, `remove token file "%s" failed`, path)
+	}
+	return len(content) == 8 && gbinary.DecodeToInt64(content) > gtime.TimestampMilli(), nil
+}
+
+// checkAndClearTokenFile deletes the token file of `path` if it is expired.
+func (s *StorageFile) checkAndClearTokenFile(ctx context.Context, path string) error {
+	content := gfile.GetBytes(path)
+	if len(content) == 8 && gbinary.DecodeToInt64(content) > gtime.TimestampMilli() {
+		return nil
+	}
+	intlog.Printf(ctx, `clear expired token file "%s"`, path)
+	return gfile.Remove(path)
+}
@@ -0,0 +1,153 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtimer"
+)
+
+// StorageFileGcOption is the option for the expiry GC of StorageFile, see StorageFile.SetGcOption.
+type StorageFileGcOption struct {
+	Interval     time.Duration // Interval of GC rounds, each of which walks all session files, it is DefaultStorageFileClearExpiredInterval in default.
+	Pace         int           // Maximum count of session files checked in each step, it is DefaultStorageFileGcPace in default.
+	StepInterval time.Duration // Interval of GC steps, it is DefaultStorageFileGcStepInterval in default.
+}
+
+// storageFileGc is the incremental expiry GC of StorageFile, which walks the storage directory
+// on demand and checks limited count of session files in each step.
+type storageFileGc struct {
+	mu      sync.Mutex
+	option  StorageFileGcOption
+	dirs    []string      // Directories to walk in current round.
+	files   []string      // Session files to check in current round.
+	roundAt time.Time     // Start time of current round.
+	timer   *gtimer.Entry // Timer of GC steps.
+}
+
+func newStorageFileGc() *storageFileGc {
+	return &storageFileGc{
+		option: StorageFileGcOption{
+			Interval:     DefaultStorageFileClearExpiredInterval,
+			Pace:         DefaultStorageFileGcPace,
+			StepInterval: DefaultStorageFileGcStepInterval,
+		},
+		roundAt: time.Now(),
+	}
+}
+
+// SetGcOption sets the option for the expiry GC of session files.
+//
+// The GC runs incrementally in rounds, each of which walks all the session files step by step,
+// and checks at most `option.Pace` files in each step. It spreads the IO of clearing huge number
+// of session files over time, instead of blocking in a burst. The zero fields of `option` are
+// set to the default values.
+func (s *StorageFile) SetGcOption(option StorageFileGcOption) {
+	if option.Interval <= 0 {
+		option.Interval = DefaultStorageFileClearExpiredInterval
+	}
+	if option.Pace <= 0 {
+		option.Pace = DefaultStorageFileGcPace
+	}
+	if option.StepInterval <= 0 {
+		option.StepInterval = DefaultStorageFileGcStepInterval
+	}
+	s.gc.mu.Lock()
+	defer s.gc.mu.Unlock()
+	if option.StepInterval != s.gc.option.StepInterval {
+		s.gc.timer.Close()
+		s.gc.timer = gtimer.AddSingleton(
+			context.TODO(), option.StepInterval, s.timelyClearExpiredSessionFiles,
+		)
+	}
+	s.gc.option = option
+}
+
+// GetGcOption returns the option for the expiry GC of session files.
+func (s *StorageFile) GetGcOption() StorageFileGcOption {
+	s.gc.mu.Lock()
+	defer s.gc.mu.Unlock()
+	return s.gc.option
+}
+
+// timelyClearExpiredSessionFiles deletes the expired session files of current GC step timely.
+func (s *StorageFile) timelyClearExpiredSessionFiles(ctx context.Context) {
+	for _, file := range s.gc.next(ctx, s.path) {
+		var err error
+		if gfile.ExtName(file) == storageFileTokenExt {
+			err = s.checkAndClearTokenFile(ctx, file)
+		} else {
+			err = s.checkAndClearSessionFile(ctx, file)
+		}
+		if err != nil {
+			intlog.Errorf(ctx, `%+v`, err)
+		}
+	}
+}
+
+// next returns the session files to check in current step, which walks the directories on demand,
+// and starts a new round if current round is done and the interval of rounds is reached.
+func (gc *storageFileGc) next(ctx context.Context, root string) []string {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	if len(gc.dirs) == 0 && len(gc.files) == 0 {
+		if time.Since(gc.roundAt) < gc.option.Interval {
+			return nil
+		}
+		gc.roundAt = time.Now()
+		gc.dirs = []string{root}
+	}
+	for len(gc.files) < gc.option.Pace && len(gc.dirs) > 0 {
+		dir := gc.dirs[0]
+		gc.dirs = gc.dirs[1:]
+		subDirs, files, err := readStorageFileDir(dir)
+		if err != nil {
+			intlog.Errorf(ctx, `%+v`, err)
+			continue
+		}
+		gc.dirs = append(gc.dirs, subDirs...)
+		gc.files = append(gc.files, files...)
+	}
+	count := gc.option.Pace
+	if count > len(gc.files) {
+		count = len(gc.files)
+	}
+	files := gc.files[:count]
+	if gc.files = gc.files[count:]; len(gc.files) == 0 {
+		gc.files = nil
+	}
+	return files
+}
+
+// readStorageFileDir reads and returns the sub-directories and session and token files of directory `dir`.
+func readStorageFileDir(dir string) (subDirs, files []string, err error) {
+	file, err := os.Open(dir)
+	if err != nil {
+		return nil, nil, gerror.Wrapf(err, `open directory "%s" failed`, dir)
+	}
+	defer file.Close()
+	infos, err := file.Readdir(-1)
+	if err != nil {
+		return nil, nil, gerror.Wrapf(err, `read directory "%s" failed`, dir)
+	}
+	for _, info := range infos {
+		path := gfile.Join(dir, info.Name())
+		if info.IsDir() {
+			subDirs = append(subDirs, path)
+		} else if ext := gfile.ExtName(path); ext == storageFileExt || ext == storageFileTokenExt {
+			files = append(files, path)
+		}
+	}
+	return subDirs, files, nil
+}
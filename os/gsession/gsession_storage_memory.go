@@ -13,6 +13,7 @@ import (
 	"github.com/gogf/gf/v2/container/gmap"
 	"github.com/gogf/gf/v2/container/gvar"
 	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/os/gtime"
 )
 
 // StorageMemory implements the Session Storage interface with memory.
@@ -24,12 +25,16 @@ type StorageMemory struct {
 	//
 	// Its value is type of `*gmap.StrAnyMap`.
 	cache *gcache.Cache
+	// tokens is the memory cache for one-time tokens, which maps token key to its expiring timestamp
+	// in milliseconds, see StorageToken.
+	tokens *gcache.Cache
 }
 
 // NewStorageMemory creates and returns a file storage object for session.
 func NewStorageMemory() *StorageMemory {
 	return &StorageMemory{
-		cache: gcache.New(),
+		cache:  gcache.New(),
+		tokens: gcache.New(),
 	}
 }
 
@@ -76,3 +81,41 @@ func (s *StorageMemory) UpdateTTL(ctx context.Context, sessionId string, ttl tim
 	_, err := s.cache.UpdateExpire(ctx, sessionId, ttl)
 	return err
 }
+
+// Iterate iterates all live sessions in the storage with their remaining TTL.
+// It stops iterating if `f` returns false.
+func (s *StorageMemory) Iterate(ctx context.Context, f func(sessionId string, ttl time.Duration) bool) error {
+	keys, err := s.cache.KeyStrings(ctx)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		ttl, err := s.cache.GetExpire(ctx, key)
+		if err != nil {
+			return err
+		}
+		if ttl < 0 {
+			continue
+		}
+		if !f(key, ttl) {
+			break
+		}
+	}
+	return nil
+}
+
+// SetToken stores token `key` for session `sessionId`, which expires after `ttl`.
+func (s *StorageMemory) SetToken(ctx context.Context, sessionId string, key string, ttl time.Duration) error {
+	return s.tokens.Set(ctx, sessionId+":"+key, gtime.TimestampMilli()+ttl.Milliseconds(), ttl)
+}
+
+// RemoveToken removes token `key` of session `sessionId` atomically, which returns true only if
+// the token exists and is not expired.
+func (s *StorageMemory) RemoveToken(ctx context.Context, sessionId string, key string) (bool, error) {
+	// The removed value is returned even if it is expired, so it checks the expiring timestamp.
+	v, err := s.tokens.Remove(ctx, sessionId+":"+key)
+	if err != nil || v.IsNil() {
+		return false, err
+	}
+	return v.Int64() > gtime.TimestampMilli(), nil
+}
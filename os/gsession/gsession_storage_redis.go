@@ -13,16 +13,17 @@ import (
 	"github.com/gogf/gf/v2/container/gmap"
 	"github.com/gogf/gf/v2/database/gredis"
 	"github.com/gogf/gf/v2/internal/intlog"
-	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/os/gtimer"
 )
 
 // StorageRedis implements the Session Storage interface with redis.
 type StorageRedis struct {
 	StorageBase
-	redis         *gredis.Redis   // Redis client for session storage.
-	prefix        string          // Redis key prefix for session id.
-	updatingIdMap *gmap.StrIntMap // Updating TTL set for session id.
+	redis         *gredis.Redis          // Redis client for session storage.
+	prefix        string                 // Redis key prefix for session id.
+	updatingIdMap *gmap.StrIntMap        // Updating TTL set for session id.
+	serializer    Serializer             // Serializer for session data, default is SerializerJson.
+	hashTable     *StorageRedisHashTable // Storage for the key-level operations in hash mode, which is nil if disabled.
 }
 
 const (
@@ -40,6 +41,7 @@ func NewStorageRedis(redis *gredis.Redis, prefix ...string) *StorageRedis {
 	s := &StorageRedis{
 		redis:         redis,
 		updatingIdMap: gmap.NewStrIntMap(true),
+		serializer:    SerializerJson,
 	}
 	if len(prefix) > 0 && prefix[0] != "" {
 		s.prefix = prefix[0]
@@ -66,6 +68,23 @@ func NewStorageRedis(redis *gredis.Redis, prefix ...string) *StorageRedis {
 	return s
 }
 
+// SetSerializer sets the serializer for session data, which is SerializerJson in default.
+// The nil `serializer` resets it to SerializerJson.
+func (s *StorageRedis) SetSerializer(serializer Serializer) {
+	if serializer == nil {
+		serializer = SerializerJson
+	}
+	s.serializer = serializer
+	if s.hashTable != nil {
+		s.hashTable.SetSerializer(serializer)
+	}
+}
+
+// GetSerializer returns the serializer for session data.
+func (s *StorageRedis) GetSerializer() Serializer {
+	return s.serializer
+}
+
 // RemoveAll deletes all key-value pairs from storage.
 func (s *StorageRedis) RemoveAll(ctx context.Context, sessionId string) error {
 	_, err := s.redis.Do(ctx, "DEL", s.sessionIdToRedisKey(sessionId))
@@ -81,6 +100,9 @@ func (s *StorageRedis) RemoveAll(ctx context.Context, sessionId string) error {
 // This function is called ever when session starts.
 func (s *StorageRedis) GetSession(ctx context.Context, sessionId string, ttl time.Duration) (*gmap.StrAnyMap, error) {
 	intlog.Printf(ctx, "StorageRedis.GetSession: %s, %v", sessionId, ttl)
+	if s.hashTable != nil {
+		return s.hashTable.GetSession(ctx, sessionId, ttl)
+	}
 	r, err := s.redis.Do(ctx, "GET", s.sessionIdToRedisKey(sessionId))
 	if err != nil {
 		return nil, err
@@ -89,14 +111,7 @@ func (s *StorageRedis) GetSession(ctx context.Context, sessionId string, ttl tim
 	if len(content) == 0 {
 		return nil, nil
 	}
-	var m map[string]interface{}
-	if err = json.UnmarshalUseNumber(content, &m); err != nil {
-		return nil, err
-	}
-	if m == nil {
-		return nil, nil
-	}
-	return gmap.NewStrAnyMapFrom(m, true), nil
+	return deserializeSessionData(s.serializer, content)
 }
 
 // SetSession updates the data map for specified session id.
@@ -104,7 +119,10 @@ func (s *StorageRedis) GetSession(ctx context.Context, sessionId string, ttl tim
 // This copy all session data map from memory to storage.
 func (s *StorageRedis) SetSession(ctx context.Context, sessionId string, sessionData *gmap.StrAnyMap, ttl time.Duration) error {
 	intlog.Printf(ctx, "StorageRedis.SetSession: %s, %v, %v", sessionId, sessionData, ttl)
-	content, err := json.Marshal(sessionData)
+	if s.hashTable != nil {
+		return s.hashTable.SetSession(ctx, sessionId, sessionData, ttl)
+	}
+	content, err := serializeSessionData(s.serializer, sessionData)
 	if err != nil {
 		return err
 	}
@@ -134,3 +152,22 @@ func (s *StorageRedis) doUpdateExpireForSession(ctx context.Context, sessionId s
 func (s *StorageRedis) sessionIdToRedisKey(sessionId string) string {
 	return s.prefix + sessionId
 }
+
+// Iterate iterates all live sessions in the storage with their remaining TTL.
+// It stops iterating if `f` returns false.
+//
+// Note that it requires the storage created with key prefix, as it scans the keys by the prefix.
+func (s *StorageRedis) Iterate(ctx context.Context, f func(sessionId string, ttl time.Duration) bool) error {
+	return iterateRedisSessions(ctx, s.redis, s.prefix, f)
+}
+
+// SetToken stores token `key` for session `sessionId`, which expires after `ttl`.
+func (s *StorageRedis) SetToken(ctx context.Context, sessionId string, key string, ttl time.Duration) error {
+	return setRedisToken(ctx, s.redis, s.sessionIdToRedisKey(sessionId)+redisTokenKeyInfix+key, ttl)
+}
+
+// RemoveToken removes token `key` of session `sessionId` atomically with redis command DEL,
+// which returns true only if the token exists and is not expired.
+func (s *StorageRedis) RemoveToken(ctx context.Context, sessionId string, key string) (bool, error) {
+	return removeRedisToken(ctx, s.redis, s.sessionIdToRedisKey(sessionId)+redisTokenKeyInfix+key)
+}
@@ -0,0 +1,97 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"context"
+	"time"
+)
+
+// SetHashMode enables or disables the hash mode of the storage, which is disabled in default.
+//
+// In hash mode, the session data is stored as a redis hash of which each field is a session key,
+// so the key-level operations like Get, Set and Remove map to HGET, HSET and HDEL, and the large
+// session does not need to be loaded and rewritten as a whole on every change. Each value is
+// serialized separately with the serializer of the storage, which keeps its type like the default mode.
+// It works the same as StorageRedisHashTable with the serializer of the storage.
+//
+// Note that the sessions stored in one mode cannot be read in the other mode,
+// so changing the mode makes the existing sessions in the storage unreadable.
+func (s *StorageRedis) SetHashMode(enabled bool) {
+	if !enabled {
+		s.hashTable = nil
+		return
+	}
+	s.hashTable = &StorageRedisHashTable{
+		redis:      s.redis,
+		prefix:     s.prefix,
+		serializer: s.serializer,
+	}
+}
+
+// IsHashMode checks and returns whether the storage is in hash mode.
+func (s *StorageRedis) IsHashMode() bool {
+	return s.hashTable != nil
+}
+
+// Get retrieves session value with given key.
+// It returns nil if the key does not exist in the session.
+// It returns ErrorDisabled if the storage is not in hash mode.
+func (s *StorageRedis) Get(ctx context.Context, sessionId string, key string) (value interface{}, err error) {
+	if s.hashTable == nil {
+		return nil, ErrorDisabled
+	}
+	return s.hashTable.Get(ctx, sessionId, key)
+}
+
+// Data retrieves all key-value pairs as map from storage.
+// It returns ErrorDisabled if the storage is not in hash mode.
+func (s *StorageRedis) Data(ctx context.Context, sessionId string) (data map[string]interface{}, err error) {
+	if s.hashTable == nil {
+		return nil, ErrorDisabled
+	}
+	return s.hashTable.Data(ctx, sessionId)
+}
+
+// GetSize retrieves the size of key-value pairs from storage.
+// It returns ErrorDisabled if the storage is not in hash mode.
+func (s *StorageRedis) GetSize(ctx context.Context, sessionId string) (size int, err error) {
+	if s.hashTable == nil {
+		return 0, ErrorDisabled
+	}
+	return s.hashTable.GetSize(ctx, sessionId)
+}
+
+// Set sets key-value session pair to the storage.
+// The parameter `ttl` specifies the TTL for the session id (not for the key-value pair).
+// It returns ErrorDisabled if the storage is not in hash mode.
+func (s *StorageRedis) Set(ctx context.Context, sessionId string, key string, value interface{}, ttl time.Duration) error {
+	if s.hashTable == nil {
+		return ErrorDisabled
+	}
+	return s.hashTable.Set(ctx, sessionId, key, value, ttl)
+}
+
+// SetMap batch sets key-value session pairs with map to the storage.
+// The parameter `ttl` specifies the TTL for the session id(not for the key-value pair).
+// The data and the TTL are written in one round trip in hash mode.
+// It returns ErrorDisabled if the storage is not in hash mode.
+func (s *StorageRedis) SetMap(ctx context.Context, sessionId string, data map[string]interface{}, ttl time.Duration) error {
+	if s.hashTable == nil {
+		return ErrorDisabled
+	}
+	return s.hashTable.SetMap(ctx, sessionId, data, ttl)
+}
+
+// Remove deletes key with its value from storage.
+// It returns ErrorDisabled if the storage is not in hash mode.
+func (s *StorageRedis) Remove(ctx context.Context, sessionId string, key string) error {
+	if s.hashTable == nil {
+		return ErrorDisabled
+	}
+	return s.hashTable.Remove(ctx, sessionId, key)
+}
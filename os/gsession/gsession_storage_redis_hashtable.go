@@ -16,11 +16,24 @@ import (
 	"github.com/gogf/gf/v2/util/gconv"
 )
 
+const (
+	// redisHashSetScript sets the fields of the session hash and updates its TTL in milliseconds
+	// in one round trip. The TTL is set along with the data, as the session might not be closed,
+	// like the process crashes.
+	redisHashSetScript = `redis.call('HMSET', KEYS[1], unpack(ARGV, 2))
+return redis.call('PEXPIRE', KEYS[1], ARGV[1])`
+)
+
 // StorageRedisHashTable implements the Session Storage interface with redis hash table.
+//
+// The session data is stored as a redis hash of which each field is a session key, so the key-level
+// operations like Get, Set and Remove map to HGET, HSET and HDEL, and the large session does not need
+// to be loaded and rewritten as a whole on every change.
 type StorageRedisHashTable struct {
 	StorageBase
-	redis  *gredis.Redis // Redis client for session storage.
-	prefix string        // Redis key prefix for session id.
+	redis      *gredis.Redis // Redis client for session storage.
+	prefix     string        // Redis key prefix for session id.
+	serializer Serializer    // Serializer for each session value, which stores the value as string if it is nil.
 }
 
 // NewStorageRedisHashTable creates and returns a redis hash table storage object for session.
@@ -38,6 +51,20 @@ func NewStorageRedisHashTable(redis *gredis.Redis, prefix ...string) *StorageRed
 	return s
 }
 
+// SetSerializer sets the serializer for each session value, which keeps the type of the value,
+// like integers and maps. The value is stored as string if the serializer is nil, which is the default.
+//
+// Note that the values stored with one serializer cannot be read with another,
+// so changing the serializer makes the existing sessions in the storage unreadable.
+func (s *StorageRedisHashTable) SetSerializer(serializer Serializer) {
+	s.serializer = serializer
+}
+
+// GetSerializer returns the serializer for each session value.
+func (s *StorageRedisHashTable) GetSerializer() Serializer {
+	return s.serializer
+}
+
 // Get retrieves session value with given key.
 // It returns nil if the key does not exist in the session.
 func (s *StorageRedisHashTable) Get(ctx context.Context, sessionId string, key string) (value interface{}, err error) {
@@ -48,7 +75,7 @@ func (s *StorageRedisHashTable) Get(ctx context.Context, sessionId string, key s
 	if v.IsNil() {
 		return nil, nil
 	}
-	return v.String(), nil
+	return s.decodeValue(key, v.Bytes())
 }
 
 // Data retrieves all key-value pairs as map from storage.
@@ -59,11 +86,14 @@ func (s *StorageRedisHashTable) Data(ctx context.Context, sessionId string) (dat
 	}
 	data = make(map[string]interface{})
 	array := v.Interfaces()
-	for i := 0; i < len(array); i += 2 {
-		if array[i+1] != nil {
-			data[gconv.String(array[i])] = gconv.String(array[i+1])
-		} else {
-			data[gconv.String(array[i])] = array[i+1]
+	for i := 0; i+1 < len(array); i += 2 {
+		key := gconv.String(array[i])
+		if array[i+1] == nil {
+			data[key] = nil
+			continue
+		}
+		if data[key], err = s.decodeValue(key, gconv.Bytes(array[i+1])); err != nil {
+			return nil, err
 		}
 	}
 	return data, nil
@@ -81,23 +111,25 @@ func (s *StorageRedisHashTable) GetSize(ctx context.Context, sessionId string) (
 // Set sets key-value session pair to the storage.
 // The parameter `ttl` specifies the TTL for the session id (not for the key-value pair).
 func (s *StorageRedisHashTable) Set(ctx context.Context, sessionId string, key string, value interface{}, ttl time.Duration) error {
-	_, err := s.redis.Do(ctx, "HSET", s.sessionIdToRedisKey(sessionId), key, value)
-	return err
+	return s.SetMap(ctx, sessionId, map[string]interface{}{key: value}, ttl)
 }
 
 // SetMap batch sets key-value session pairs with map to the storage.
 // The parameter `ttl` specifies the TTL for the session id(not for the key-value pair).
 func (s *StorageRedisHashTable) SetMap(ctx context.Context, sessionId string, data map[string]interface{}, ttl time.Duration) error {
-	array := make([]interface{}, len(data)*2+1)
-	array[0] = s.sessionIdToRedisKey(sessionId)
-
-	index := 1
+	if len(data) == 0 {
+		return nil
+	}
+	array := make([]interface{}, 0, len(data)*2+4)
+	array = append(array, redisHashSetScript, 1, s.sessionIdToRedisKey(sessionId), ttlMilliseconds(ttl))
 	for k, v := range data {
-		array[index] = k
-		array[index+1] = v
-		index += 2
+		value, err := s.encodeValue(k, v)
+		if err != nil {
+			return err
+		}
+		array = append(array, k, value)
 	}
-	_, err := s.redis.Do(ctx, "HMSET", array...)
+	_, err := s.redis.Do(ctx, "EVAL", array...)
 	return err
 }
 
@@ -134,11 +166,14 @@ func (s *StorageRedisHashTable) GetSession(ctx context.Context, sessionId string
 
 // SetSession updates the data map for specified session id.
 // This function is called ever after session, which is changed dirty, is closed.
-// This copy all session data map from memory to storage.
+// The session data is already written to storage by key-level operations, so it only writes the data
+// in memory, which is usually empty, and updates the TTL.
 func (s *StorageRedisHashTable) SetSession(ctx context.Context, sessionId string, sessionData *gmap.StrAnyMap, ttl time.Duration) error {
 	intlog.Printf(ctx, "StorageRedisHashTable.SetSession: %s, %v", sessionId, ttl)
-	_, err := s.redis.Do(ctx, "EXPIRE", s.sessionIdToRedisKey(sessionId), int64(ttl.Seconds()))
-	return err
+	if sessionData != nil && sessionData.Size() > 0 {
+		return s.SetMap(ctx, sessionId, sessionData.Map(), ttl)
+	}
+	return s.doUpdateTTL(ctx, sessionId, ttl)
 }
 
 // UpdateTTL updates the TTL for specified session id.
@@ -146,11 +181,67 @@ func (s *StorageRedisHashTable) SetSession(ctx context.Context, sessionId string
 // It just adds the session id to the async handling queue.
 func (s *StorageRedisHashTable) UpdateTTL(ctx context.Context, sessionId string, ttl time.Duration) error {
 	intlog.Printf(ctx, "StorageRedisHashTable.UpdateTTL: %s, %v", sessionId, ttl)
-	_, err := s.redis.Do(ctx, "EXPIRE", s.sessionIdToRedisKey(sessionId), int64(ttl.Seconds()))
+	return s.doUpdateTTL(ctx, sessionId, ttl)
+}
+
+// doUpdateTTL updates the TTL for session id in milliseconds.
+func (s *StorageRedisHashTable) doUpdateTTL(ctx context.Context, sessionId string, ttl time.Duration) error {
+	_, err := s.redis.Do(ctx, "PEXPIRE", s.sessionIdToRedisKey(sessionId), ttlMilliseconds(ttl))
 	return err
 }
 
+// ttlMilliseconds returns `ttl` in milliseconds, which is at least 1 millisecond,
+// as the zero TTL deletes the session immediately.
+func ttlMilliseconds(ttl time.Duration) int64 {
+	milliseconds := ttl.Milliseconds()
+	if milliseconds < 1 {
+		milliseconds = 1
+	}
+	return milliseconds
+}
+
+// encodeValue encodes `value` of `key` for storing in the hash field.
+// It serializes `value` as a single entry map with the serializer, so that any serializer
+// for the whole session data can be used for the hash fields.
+func (s *StorageRedisHashTable) encodeValue(key string, value interface{}) (interface{}, error) {
+	if s.serializer == nil {
+		return value, nil
+	}
+	return s.serializer.Serialize(map[string]interface{}{key: value})
+}
+
+// decodeValue decodes `content` of `key` encoded by encodeValue.
+func (s *StorageRedisHashTable) decodeValue(key string, content []byte) (interface{}, error) {
+	if s.serializer == nil {
+		return string(content), nil
+	}
+	m, err := s.serializer.Deserialize(content)
+	if err != nil {
+		return nil, err
+	}
+	return m[key], nil
+}
+
 // sessionIdToRedisKey converts and returns the redis key for given session id.
 func (s *StorageRedisHashTable) sessionIdToRedisKey(sessionId string) string {
 	return s.prefix + sessionId
 }
+
+// Iterate iterates all live sessions in the storage with their remaining TTL.
+// It stops iterating if `f` returns false.
+//
+// Note that it requires the storage created with key prefix, as it scans the keys by the prefix.
+func (s *StorageRedisHashTable) Iterate(ctx context.Context, f func(sessionId string, ttl time.Duration) bool) error {
+	return iterateRedisSessions(ctx, s.redis, s.prefix, f)
+}
+
+// SetToken stores token `key` for session `sessionId`, which expires after `ttl`.
+func (s *StorageRedisHashTable) SetToken(ctx context.Context, sessionId string, key string, ttl time.Duration) error {
+	return setRedisToken(ctx, s.redis, s.sessionIdToRedisKey(sessionId)+redisTokenKeyInfix+key, ttl)
+}
+
+// RemoveToken removes token `key` of session `sessionId` atomically with redis command DEL,
+// which returns true only if the token exists and is not expired.
+func (s *StorageRedisHashTable) RemoveToken(ctx context.Context, sessionId string, key string) (bool, error) {
+	return removeRedisToken(ctx, s.redis, s.sessionIdToRedisKey(sessionId)+redisTokenKeyInfix+key)
+}
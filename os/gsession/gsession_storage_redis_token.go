@@ -0,0 +1,41 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+)
+
+const (
+	// redisTokenKeyInfix is the infix of the redis keys storing the one-time tokens,
+	// which is like: prefix + session id + infix + token key.
+	redisTokenKeyInfix = ":_gf_token_:"
+)
+
+// setRedisToken stores token of redis key `key` which expires after `ttl`.
+func setRedisToken(ctx context.Context, redis *gredis.Redis, key string, ttl time.Duration) error {
+	_, err := redis.Do(ctx, "SET", key, 1, "PX", ttl.Milliseconds())
+	return err
+}
+
+// removeRedisToken removes token of redis key `key`, it returns true if the key is deleted by this call.
+func removeRedisToken(ctx context.Context, redis *gredis.Redis, key string) (bool, error) {
+	v, err := redis.Do(ctx, "DEL", key)
+	if err != nil {
+		return false, err
+	}
+	return v.Int() == 1, nil
+}
+
+// isRedisTokenKey checks whether redis key `key` is the key of a one-time token.
+func isRedisTokenKey(key string) bool {
+	return strings.Contains(key, redisTokenKeyInfix)
+}
@@ -0,0 +1,89 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gsession"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Manager_Use(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.TODO()
+			calls   []string
+			manager = gsession.New(time.Minute, gsession.NewStorageMemory())
+		)
+		for _, name := range []string{"a", "b"} {
+			name := name
+			manager.Use(gsession.Interceptor{
+				GetSession: func(
+					ctx context.Context, sessionId string, ttl time.Duration, next gsession.GetSessionHandler,
+				) (*gmap.StrAnyMap, error) {
+					calls = append(calls, "get-"+name)
+					return next(ctx, sessionId, ttl)
+				},
+				SetSession: func(
+					ctx context.Context, sessionId string, data *gmap.StrAnyMap, ttl time.Duration, next gsession.SetSessionHandler,
+				) error {
+					calls = append(calls, "set-"+name)
+					return next(ctx, sessionId, data, ttl)
+				},
+			})
+		}
+		// Data scrubbing.
+		manager.Use(gsession.Interceptor{
+			SetSession: func(
+				ctx context.Context, sessionId string, data *gmap.StrAnyMap, ttl time.Duration, next gsession.SetSessionHandler,
+			) error {
+				data.Remove("password")
+				return next(ctx, sessionId, data, ttl)
+			},
+		})
+		s := manager.New(ctx)
+		t.AssertNil(s.Set("name", "john"))
+		t.AssertNil(s.Set("password", "123456"))
+		id := s.MustId()
+		t.AssertNil(s.Close())
+		t.Assert(calls, []string{"set-a", "set-b"})
+
+		s = manager.New(ctx, id)
+		t.Assert(s.MustGet("name"), "john")
+		t.Assert(s.MustGet("password"), nil)
+		t.Assert(calls, []string{"set-a", "set-b", "get-a", "get-b"})
+	})
+}
+
+func Test_Manager_Use_ReadOnly(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.TODO()
+			storage = gsession.NewStorageFile("", time.Minute)
+			manager = gsession.New(time.Minute, storage)
+		)
+		manager.Use(gsession.Interceptor{
+			SetSession: func(
+				ctx context.Context, sessionId string, data *gmap.StrAnyMap, ttl time.Duration, next gsession.SetSessionHandler,
+			) error {
+				return gerror.New("session is read-only")
+			},
+		})
+		s := manager.New(ctx)
+		t.AssertNil(s.Set("name", "john"))
+		id := s.MustId()
+		t.Assert(s.Close(), "session is read-only")
+
+		s = manager.New(ctx, id)
+		t.Assert(s.MustGet("name"), nil)
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gsession"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Migrate(t *testing.T) {
+	var (
+		ctx  = context.TODO()
+		path = gfile.Temp(guid.S())
+		ids  = make([]string, 0)
+	)
+	gtest.AssertNil(gfile.Mkdir(path))
+	defer gfile.Remove(path)
+
+	var (
+		fileStorage   = gsession.NewStorageFile(path, time.Hour)
+		memoryStorage = gsession.NewStorageMemory()
+		fileManager   = gsession.New(time.Hour, fileStorage)
+		memoryManager = gsession.New(time.Hour, memoryStorage)
+	)
+	gtest.C(t, func(t *gtest.T) {
+		for i := 0; i < 5; i++ {
+			s := fileManager.New(ctx)
+			s.MustSet("index", i)
+			ids = append(ids, s.MustId())
+			t.AssertNil(s.Close())
+		}
+		// Empty session is skipped.
+		t.AssertNil(fileStorage.SetSession(ctx, guid.S(), nil, time.Hour))
+
+		var progresses []string
+		result, err := gsession.Migrate(ctx, fileStorage, memoryStorage, gsession.MigrateOption{
+			TTL:       time.Hour,
+			BatchSize: 2,
+			Progress: func(ctx context.Context, result gsession.MigrateResult) {
+				progresses = append(progresses, fmt.Sprint(result.Migrated+result.Skipped))
+			},
+		})
+		t.AssertNil(err)
+		t.Assert(result.Migrated, 5)
+		t.Assert(result.Skipped, 1)
+		t.Assert(result.Failed, 0)
+		t.Assert(progresses, []string{"2", "4", "6"})
+
+		for i, id := range ids {
+			s := memoryManager.New(ctx, id)
+			t.Assert(s.MustGet("index"), i)
+		}
+	})
+	// Remaining TTL is kept.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			source = gsession.NewStorageMemory()
+			target = gsession.NewStorageMemory()
+			id     = guid.S()
+		)
+		s := gsession.New(100*time.Millisecond, source).New(ctx, id)
+		s.MustSet("k", "v")
+		t.AssertNil(s.Close())
+
+		result, err := gsession.Migrate(ctx, source, target, gsession.MigrateOption{TTL: time.Hour})
+		t.AssertNil(err)
+		t.Assert(result.Migrated, 1)
+		t.Assert(gsession.New(time.Hour, target).New(ctx, id).MustGet("k"), "v")
+
+		time.Sleep(200 * time.Millisecond)
+		t.Assert(gsession.New(time.Hour, target).New(ctx, id).MustGet("k"), nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gsession.Migrate(ctx, memoryStorage, fileStorage, gsession.MigrateOption{})
+		t.AssertNE(err, nil)
+		_, err = gsession.Migrate(ctx, &gsession.StorageBase{}, fileStorage, gsession.MigrateOption{TTL: time.Hour})
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gsession"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_RememberMe(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx        = context.TODO()
+			rememberMe = gsession.NewRememberMe()
+		)
+		_, err := rememberMe.Issue(ctx, "")
+		t.AssertNE(err, nil)
+
+		token, err := rememberMe.Issue(ctx, "john", g.Map{"role": "admin"})
+		t.AssertNil(err)
+		t.Assert(strings.Count(token, ":"), 1)
+
+		record, newToken, err := rememberMe.Consume(ctx, token)
+		t.AssertNil(err)
+		t.Assert(record.UserId, "john")
+		t.Assert(record.Data["role"], "admin")
+		t.AssertNE(newToken, token)
+
+		// The token is rotated.
+		record, _, err = rememberMe.Consume(ctx, token)
+		t.AssertNil(err)
+		t.Assert(record, nil)
+
+		// Restore to session.
+		manager := gsession.New(time.Minute, gsession.NewStorageMemory())
+		s := manager.New(ctx)
+		record, newToken, err = rememberMe.Consume(ctx, newToken)
+		t.AssertNil(err)
+		t.AssertNil(rememberMe.Restore(s, record))
+		t.Assert(s.MustGet(rememberMe.SessionKey()), "john")
+		t.Assert(s.MustGet("role"), "admin")
+
+		// Revoke.
+		t.AssertNil(rememberMe.Revoke(ctx, newToken))
+		record, _, err = rememberMe.Consume(ctx, newToken)
+		t.AssertNil(err)
+		t.Assert(record, nil)
+
+		// Invalid token.
+		record, _, err = rememberMe.Consume(ctx, "invalid")
+		t.AssertNil(err)
+		t.Assert(record, nil)
+	})
+}
+
+func Test_RememberMe_Theft(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx        = context.TODO()
+			rememberMe = gsession.NewRememberMe(gsession.RememberMeOption{TTL: time.Hour})
+		)
+		token1, err := rememberMe.Issue(ctx, "john")
+		t.AssertNil(err)
+		token2, err := rememberMe.Issue(ctx, "john")
+		t.AssertNil(err)
+
+		// Valid selector with invalid validator revokes all the tokens of the user.
+		selector := strings.Split(token1, ":")[0]
+		record, _, err := rememberMe.Consume(ctx, selector+":invalid")
+		t.AssertNil(err)
+		t.Assert(record, nil)
+
+		record, _, err = rememberMe.Consume(ctx, token1)
+		t.AssertNil(err)
+		t.Assert(record, nil)
+		record, _, err = rememberMe.Consume(ctx, token2)
+		t.AssertNil(err)
+		t.Assert(record, nil)
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gsession"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Serializer(t *testing.T) {
+	data := g.Map{
+		"id":   int64(1) << 60,
+		"name": "john",
+		"list": []interface{}{"a", "b"},
+	}
+	gtest.C(t, func(t *gtest.T) {
+		content, err := gsession.SerializerJson.Serialize(data)
+		t.AssertNil(err)
+		m, err := gsession.SerializerJson.Deserialize(content)
+		t.AssertNil(err)
+		t.Assert(m["id"], json.Number("1152921504606846976"))
+		t.Assert(m["name"], "john")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		content, err := gsession.SerializerGob.Serialize(data)
+		t.AssertNil(err)
+		m, err := gsession.SerializerGob.Deserialize(content)
+		t.AssertNil(err)
+		t.Assert(m["id"], int64(1)<<60)
+		t.Assert(m["name"], "john")
+		t.Assert(m["list"], []interface{}{"a", "b"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		jsonContent, err := gsession.SerializerJson.Serialize(data)
+		t.AssertNil(err)
+		content, err := gsession.SerializerMsgpack.Serialize(data)
+		t.AssertNil(err)
+		t.AssertLT(len(content), len(jsonContent))
+		m, err := gsession.SerializerMsgpack.Deserialize(content)
+		t.AssertNil(err)
+		t.Assert(m["id"], int64(1)<<60)
+		t.Assert(m["name"], "john")
+		t.Assert(m["list"], []interface{}{"a", "b"})
+	})
+}
+
+func Test_StorageFile_Serializer(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		storage := gsession.NewStorageFile("", time.Second)
+		t.Assert(storage.GetSerializer(), gsession.SerializerJson)
+		storage.SetSerializer(nil)
+		t.Assert(storage.GetSerializer(), gsession.SerializerJson)
+	})
+	for _, serializer := range []gsession.Serializer{gsession.SerializerGob, gsession.SerializerMsgpack} {
+		gtest.C(t, func(t *gtest.T) {
+			var (
+				ctx     = context.TODO()
+				storage = gsession.NewStorageFile("", time.Minute)
+				manager = gsession.New(time.Minute, storage)
+			)
+			storage.SetSerializer(serializer)
+			s := manager.New(ctx)
+			t.AssertNil(s.Set("count", int64(1)<<60))
+			t.AssertNil(s.Set("name", "john"))
+			id := s.MustId()
+			t.AssertNil(s.Close())
+
+			s = manager.New(ctx, id)
+			defer s.RemoveAll()
+			t.Assert(s.MustGet("count").Val(), int64(1)<<60)
+			t.Assert(s.MustGet("name"), "john")
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gsession"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Session_Binding(t *testing.T) {
+	var (
+		ctx       = context.TODO()
+		userAgent = "Mozilla/5.0"
+		manager   = gsession.New(time.Minute, gsession.NewStorageMemory())
+		// newSession creates a session with data from client `ip` and `ua`.
+		newSession = func(t *gtest.T, ip, ua string) string {
+			s := manager.New(ctx)
+			t.AssertNil(s.SetClient(ip, ua))
+			t.AssertNil(s.Set("name", "john"))
+			t.AssertNil(s.BindUser("john"))
+			t.AssertNil(s.Close())
+			return s.MustId()
+		}
+		// getSession restores session `id` from client `ip` and `ua`.
+		getSession = func(t *gtest.T, id, ip, ua string) *gsession.Session {
+			s := manager.New(ctx, id)
+			t.AssertNil(s.SetClient(ip, ua))
+			return s
+		}
+	)
+	manager.SetBinding(&gsession.BindingOption{
+		IPv4Prefix: 24,
+		IPv6Prefix: 64,
+		UserAgent:  true,
+	})
+
+	// Same IP range and User-Agent.
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(manager.GetBinding().IPv4Prefix, 24)
+		id := newSession(t, "192.168.1.10", userAgent)
+		s := getSession(t, id, "192.168.1.20", userAgent)
+		t.Assert(s.MustGet("name"), "john")
+		t.AssertNE(s.SetClient("127.0.0.1", userAgent), nil)
+
+		id = newSession(t, "2001:db8::1", userAgent)
+		t.Assert(getSession(t, id, "2001:db8::2", userAgent).MustGet("name"), "john")
+	})
+	// Destroyed in default.
+	gtest.C(t, func(t *gtest.T) {
+		id := newSession(t, "192.168.1.10", userAgent)
+		s := getSession(t, id, "192.168.2.10", userAgent)
+		t.Assert(s.MustGet("name"), nil)
+		// The session id is regenerated, and the previous one is no longer usable.
+		t.AssertNE(s.MustId(), id)
+		t.AssertNil(s.Close())
+		t.Assert(getSession(t, id, "192.168.1.10", userAgent).MustGet("name"), nil)
+
+		id = newSession(t, "192.168.1.10", userAgent)
+		t.Assert(getSession(t, id, "192.168.1.10", "curl/7.0").MustGet("name"), nil)
+	})
+	// The session restored without binding is bound at once.
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(ctx)
+		t.AssertNil(s.Set("name", "john"))
+		t.AssertNil(s.Close())
+		id := s.MustId()
+
+		s = getSession(t, id, "192.168.1.10", userAgent)
+		t.Assert(s.MustGet("name"), "john")
+		t.AssertNil(s.Close())
+
+		s = getSession(t, id, "192.168.2.10", userAgent)
+		t.Assert(s.MustGet("name"), nil)
+		t.AssertNE(s.MustId(), id)
+	})
+	// Policy.
+	gtest.C(t, func(t *gtest.T) {
+		var mismatches []*gsession.BindingMismatch
+		manager.SetBinding(&gsession.BindingOption{
+			IPv4Prefix: 24,
+			Policy: func(ctx context.Context, s *gsession.Session, mismatch *gsession.BindingMismatch) gsession.BindingAction {
+				mismatches = append(mismatches, mismatch)
+				if mismatch.ClientIp == "10.0.0.1" {
+					return gsession.BindingActionIgnore
+				}
+				return gsession.BindingActionReauth
+			},
+		})
+		defer manager.SetBinding(nil)
+
+		id := newSession(t, "192.168.1.10", userAgent)
+		// User-Agent is not bound.
+		t.Assert(getSession(t, id, "192.168.1.10", "curl/7.0").MustGet("name"), "john")
+		t.Assert(len(mismatches), 0)
+
+		// Ignore.
+		s := getSession(t, id, "10.0.0.1", userAgent)
+		t.Assert(s.MustGet("name"), "john")
+		userId, err := s.UserId()
+		t.AssertNil(err)
+		t.Assert(userId, "john")
+		t.Assert(len(mismatches), 1)
+		t.Assert(mismatches[0].BoundIp, "192.168.1.0/24")
+		t.Assert(mismatches[0].IpMismatched, true)
+		t.Assert(mismatches[0].UserAgentMismatched, false)
+
+		// Reauth, which rebinds the session to new client.
+		s = getSession(t, id, "172.16.0.1", userAgent)
+		t.Assert(s.MustGet("name"), "john")
+		userId, err = s.UserId()
+		t.AssertNil(err)
+		t.Assert(userId, "")
+		t.AssertNE(s.MustId(), id)
+		t.AssertNil(s.Close())
+		t.Assert(len(mismatches), 2)
+		t.Assert(getSession(t, s.MustId(), "172.16.0.2", userAgent).MustGet("name"), "john")
+		t.Assert(len(mismatches), 2)
+		t.Assert(getSession(t, id, "172.16.0.2", userAgent).MustGet("name"), nil)
+	})
+}
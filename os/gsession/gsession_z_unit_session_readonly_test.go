@@ -0,0 +1,89 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/os/gsession"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// countingStorage counts the writing of the session storage.
+type countingStorage struct {
+	*gsession.StorageMemory
+	writes *gtype.Int
+}
+
+func (s *countingStorage) SetSession(ctx context.Context, sessionId string, sessionData *gmap.StrAnyMap, ttl time.Duration) error {
+	s.writes.Add(1)
+	return s.StorageMemory.SetSession(ctx, sessionId, sessionData, ttl)
+}
+
+func (s *countingStorage) UpdateTTL(ctx context.Context, sessionId string, ttl time.Duration) error {
+	s.writes.Add(1)
+	return s.StorageMemory.UpdateTTL(ctx, sessionId, ttl)
+}
+
+func Test_Session_ReadOnly(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		storage = &countingStorage{
+			StorageMemory: gsession.NewStorageMemory(),
+			writes:        gtype.NewInt(),
+		}
+		manager = gsession.New(time.Minute, storage)
+	)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(ctx)
+		t.AssertNil(s.Set("name", "john"))
+		t.AssertNil(s.Close())
+		id := s.MustId()
+
+		storage.writes.Set(0)
+		s = manager.New(ctx, id)
+		s.ReadOnly()
+		t.Assert(s.IsReadOnly(), true)
+		t.Assert(s.MustGet("name"), "john")
+		t.AssertNE(s.Set("name", "smith"), nil)
+		t.AssertNE(s.Remove("name"), nil)
+		t.AssertNE(s.SetMap(map[string]interface{}{"name": "smith"}), nil)
+		t.AssertNE(s.RemoveAll(), nil)
+		t.AssertNil(s.Close())
+		t.Assert(storage.writes.Val(), 0)
+
+		s = manager.New(ctx, id)
+		t.Assert(s.MustGet("name"), "john")
+		t.AssertNil(s.Close())
+		t.Assert(storage.writes.Val(), 1)
+	})
+	// The binding mismatching is handled even if the session is read-only.
+	gtest.C(t, func(t *gtest.T) {
+		manager.SetBinding(&gsession.BindingOption{IPv4Prefix: 24})
+		defer manager.SetBinding(nil)
+
+		s := manager.New(ctx)
+		t.AssertNil(s.SetClient("192.168.1.1", ""))
+		t.AssertNil(s.Set("name", "john"))
+		t.AssertNil(s.Close())
+		id := s.MustId()
+
+		s = manager.New(ctx, id)
+		t.AssertNil(s.SetClient("10.0.0.1", ""))
+		s.ReadOnly()
+		t.Assert(s.MustGet("name"), nil)
+		t.Assert(s.IsReadOnly(), true)
+		t.AssertNil(s.Close())
+
+		s = manager.New(ctx, id)
+		t.Assert(s.MustGet("name"), nil)
+	})
+}
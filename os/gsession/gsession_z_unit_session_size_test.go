@@ -0,0 +1,78 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gsession"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Session_MaxSize(t *testing.T) {
+	var (
+		ctx   = context.TODO()
+		large = strings.Repeat("x", 100)
+	)
+	// Error policy.
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(time.Hour, gsession.NewStorageFile("", time.Hour))
+		manager.SetMaxSize(150)
+		size, policy := manager.GetMaxSize()
+		t.Assert(size, 150)
+		t.Assert(policy, gsession.OversizePolicyError)
+
+		s := manager.New(ctx)
+		s.MustSet("k1", large)
+		t.AssertNil(s.Close())
+		id := s.MustId()
+
+		s = manager.New(ctx, id)
+		s.MustSet("k2", large)
+		err := s.Close()
+		t.AssertNE(err, nil)
+		t.Assert(gerror.Code(err), gcode.CodeInvalidOperation)
+		// The oversize session is not stored.
+		t.Assert(manager.New(ctx, id).MustGet("k2"), nil)
+	})
+	// Truncate policy.
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(time.Hour, gsession.NewStorageFile("", time.Hour))
+		manager.SetMaxSize(300, gsession.OversizePolicyTruncate)
+
+		s := manager.New(ctx)
+		s.MustSet("k1", large)
+		s.MustSet("k2", large)
+		t.AssertNil(s.Close())
+		id := s.MustId()
+
+		s = manager.New(ctx, id)
+		// Setting k1 again makes k2 the least recently set key.
+		s.MustSet("k1", large)
+		s.MustSet("k3", large)
+		t.AssertNil(s.Close())
+
+		s = manager.New(ctx, id)
+		t.Assert(s.MustGet("k1"), large)
+		t.Assert(s.MustGet("k2"), nil)
+		t.Assert(s.MustGet("k3"), large)
+	})
+	// Log policy.
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(time.Hour, gsession.NewStorageMemory())
+		manager.SetMaxSize(50, gsession.OversizePolicyLog)
+		s := manager.New(ctx)
+		s.MustSet("k1", large)
+		t.AssertNil(s.Close())
+		t.Assert(manager.New(ctx, s.MustId()).MustGet("k1"), large)
+	})
+}
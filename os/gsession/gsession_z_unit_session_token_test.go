@@ -0,0 +1,164 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/os/gsession"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Session_Token(t *testing.T) {
+	manager := gsession.New(time.Hour, gsession.NewStorageMemory())
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(context.TODO())
+		defer s.Close()
+		token1, err := s.IssueToken("csrf", time.Minute)
+		t.AssertNil(err)
+		token2, err := s.IssueToken("csrf", time.Minute)
+		t.AssertNil(err)
+		t.AssertNE(token1, token2)
+
+		// Different scope.
+		ok, err := s.ConsumeToken("email", token1)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+
+		ok, err = s.ConsumeToken("csrf", token1)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+		// Replay.
+		ok, err = s.ConsumeToken("csrf", token1)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+
+		ok, err = s.ConsumeToken("csrf", token2)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+		// Tokens are not stored in the session data.
+		t.Assert(s.MustSize(), 0)
+
+		_, err = s.IssueToken("csrf", 0)
+		t.AssertNE(err, nil)
+	})
+	// Expiry.
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(context.TODO())
+		defer s.Close()
+		token, err := s.IssueToken("csrf", 50*time.Millisecond)
+		t.AssertNil(err)
+		time.Sleep(100 * time.Millisecond)
+		ok, err := s.ConsumeToken("csrf", token)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+	})
+}
+
+func Test_Session_Token_StorageFile(t *testing.T) {
+	var (
+		manager = gsession.New(time.Hour, gsession.NewStorageFile("", time.Hour))
+		token   string
+		id      string
+	)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(context.TODO())
+		var err error
+		token, err = s.IssueToken("verify", time.Minute)
+		t.AssertNil(err)
+		id = s.MustId()
+		t.AssertNil(s.Close())
+	})
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(context.TODO(), id)
+		defer s.Close()
+		ok, err := s.ConsumeToken("verify", token)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+	})
+}
+
+func Test_Session_Token_ConcurrentConsume(t *testing.T) {
+	storages := []gsession.Storage{
+		gsession.NewStorageMemory(),
+		gsession.NewStorageFile("", time.Hour),
+	}
+	for _, storage := range storages {
+		manager := gsession.New(time.Hour, storage)
+		gtest.C(t, func(t *gtest.T) {
+			s := manager.New(context.TODO())
+			token, err := s.IssueToken("payment", time.Minute)
+			t.AssertNil(err)
+			id := s.MustId()
+			t.AssertNil(s.Close())
+
+			var (
+				wg       sync.WaitGroup
+				consumed = gtype.NewInt()
+			)
+			// Every request consumes the token with its own session of the same id.
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					s := manager.New(context.TODO(), id)
+					defer s.Close()
+					ok, err := s.ConsumeToken("payment", token)
+					t.AssertNil(err)
+					if ok {
+						consumed.Add(1)
+					}
+				}()
+			}
+			wg.Wait()
+			t.Assert(consumed.Val(), 1)
+		})
+		// Concurrently issued tokens are all consumable.
+		gtest.C(t, func(t *gtest.T) {
+			var (
+				wg     sync.WaitGroup
+				id     = gsession.NewSessionId()
+				tokens = make([]string, 20)
+			)
+			for i := range tokens {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					s := manager.New(context.TODO(), id)
+					defer s.Close()
+					token, err := s.IssueToken("csrf", time.Minute)
+					t.AssertNil(err)
+					tokens[i] = token
+				}(i)
+			}
+			wg.Wait()
+			s := manager.New(context.TODO(), id)
+			defer s.Close()
+			for _, token := range tokens {
+				ok, err := s.ConsumeToken("csrf", token)
+				t.AssertNil(err)
+				t.Assert(ok, true)
+			}
+		})
+	}
+}
+
+func Test_Session_Token_NotSupported(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(time.Hour, &gsession.StorageBase{})
+		s := manager.New(context.TODO())
+		defer s.Close()
+		_, err := s.IssueToken("csrf", time.Minute)
+		t.AssertNE(err, nil)
+		_, err = s.ConsumeToken("csrf", "token")
+		t.AssertNE(err, nil)
+	})
+}
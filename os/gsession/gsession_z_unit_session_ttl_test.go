@@ -0,0 +1,201 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/os/gsession"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+func Test_Session_SetWithTTL(t *testing.T) {
+	var (
+		storages = []gsession.Storage{
+			gsession.NewStorageMemory(),
+			gsession.NewStorageFile("", time.Hour),
+		}
+	)
+	for _, storage := range storages {
+		var (
+			manager = gsession.New(time.Hour, storage)
+			id      string
+		)
+		gtest.C(t, func(t *gtest.T) {
+			s := manager.New(context.TODO())
+			s.MustSet("user", "john")
+			s.MustSetWithTTL("otp", true, 100*time.Millisecond)
+			s.MustSetWithTTL("captcha", true, 100*time.Millisecond)
+			// Set clears the TTL.
+			s.MustSet("captcha", "passed")
+			t.Assert(s.MustGet("otp"), true)
+			t.Assert(s.MustContains("otp"), true)
+			t.Assert(s.MustData()["otp"], true)
+			id = s.MustId()
+			t.AssertNil(s.Close())
+		})
+
+		time.Sleep(200 * time.Millisecond)
+		gtest.C(t, func(t *gtest.T) {
+			s := manager.New(context.TODO(), id)
+			t.Assert(s.MustGet("otp"), nil)
+			t.Assert(s.MustGet("otp", "default"), "default")
+			t.Assert(s.MustContains("otp"), false)
+			t.Assert(s.MustGet("captcha"), "passed")
+			t.Assert(s.MustGet("user"), "john")
+			_, ok := s.MustData()["otp"]
+			t.Assert(ok, false)
+			t.AssertNil(s.Close())
+		})
+
+		// Cleaned when closing.
+		gtest.C(t, func(t *gtest.T) {
+			s := manager.New(context.TODO(), id)
+			defer s.Close()
+			t.Assert(s.MustData(), map[string]interface{}{
+				"user":    "john",
+				"captcha": "passed",
+			})
+		})
+	}
+	gtest.C(t, func(t *gtest.T) {
+		s := gsession.New(time.Hour, gsession.NewStorageMemory()).New(context.TODO())
+		t.AssertNE(s.SetWithTTL("k", "v", 0), nil)
+	})
+}
+
+func Test_Session_Data_InternalKeys(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		manager = gsession.New(time.Hour, gsession.NewStorageMemory())
+	)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(ctx)
+		t.AssertNil(s.Set("a", 1))
+		t.AssertNil(s.SetWithTTL("otp", true, time.Minute))
+		_, err := s.IssueToken("csrf", time.Minute)
+		t.AssertNil(err)
+		t.AssertNil(s.BindUser("1"))
+		t.AssertNil(s.Close())
+
+		s = manager.New(ctx, s.MustId())
+		t.Assert(s.MustData(), map[string]interface{}{"a": 1, "otp": true})
+		t.Assert(s.MustSize(), 2)
+		userId, err := s.UserId()
+		t.AssertNil(err)
+		t.Assert(userId, "1")
+	})
+}
+
+// keyLevelStorage is the storage updating key-value pairs directly, which counts its key-level operations.
+type keyLevelStorage struct {
+	gsession.StorageBase
+	mu     sync.Mutex
+	data   map[string]interface{}
+	gets   int
+	writes int
+}
+
+func (s *keyLevelStorage) Get(ctx context.Context, sessionId string, key string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gets++
+	return s.data[key], nil
+}
+
+func (s *keyLevelStorage) Data(ctx context.Context, sessionId string) (map[string]interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data := make(map[string]interface{}, len(s.data))
+	for k, v := range s.data {
+		data[k] = v
+	}
+	return data, nil
+}
+
+func (s *keyLevelStorage) Set(ctx context.Context, sessionId string, key string, value interface{}, ttl time.Duration) error {
+	return s.SetMap(ctx, sessionId, map[string]interface{}{key: value}, ttl)
+}
+
+func (s *keyLevelStorage) SetMap(ctx context.Context, sessionId string, data map[string]interface{}, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writes++
+	for k, v := range data {
+		s.data[k] = v
+	}
+	return nil
+}
+
+func (s *keyLevelStorage) Remove(ctx context.Context, sessionId string, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writes++
+	delete(s.data, key)
+	return nil
+}
+
+func (s *keyLevelStorage) GetSession(ctx context.Context, sessionId string, ttl time.Duration) (*gmap.StrAnyMap, error) {
+	return gmap.NewStrAnyMap(true), nil
+}
+
+func (s *keyLevelStorage) SetSession(ctx context.Context, sessionId string, sessionData *gmap.StrAnyMap, ttl time.Duration) error {
+	return nil
+}
+
+func (s *keyLevelStorage) UpdateTTL(ctx context.Context, sessionId string, ttl time.Duration) error {
+	return nil
+}
+
+func Test_Session_Set_KeyLevelStorage(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		storage = &keyLevelStorage{data: make(map[string]interface{})}
+		manager = gsession.New(time.Hour, storage)
+	)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(ctx, "id")
+		defer s.Close()
+		// The key TTLs are retrieved only once, and each setting is one writing along with the metadata.
+		t.AssertNil(s.Set("k1", "v1"))
+		t.AssertNil(s.Set("k2", "v2"))
+		t.AssertNil(s.SetMap(map[string]interface{}{"k3": "v3", "k4": "v4"}))
+		t.AssertNil(s.SetWithTTL("k5", "v5", time.Minute))
+		t.AssertNil(s.SetWithTTL("k6", "v6", time.Minute))
+		t.Assert(storage.gets, 1)
+		t.Assert(storage.writes, 5)
+		t.Assert(len(gconv.Map(storage.data["_gf_key_ttls"])), 2)
+
+		// Setting clears the TTL along with the value.
+		t.AssertNil(s.Set("k5", "v5"))
+		t.Assert(storage.writes, 6)
+		t.Assert(len(gconv.Map(storage.data["_gf_key_ttls"])), 1)
+		// The storing key is removed if no TTL left.
+		t.AssertNil(s.SetMap(map[string]interface{}{"k6": "v6"}))
+		t.Assert(storage.writes, 8)
+		_, ok := storage.data["_gf_key_ttls"]
+		t.Assert(ok, false)
+		t.Assert(storage.gets, 1)
+		t.Assert(s.MustSize(), 6)
+	})
+	// The set times are stored along with the value for OversizePolicyTruncate.
+	gtest.C(t, func(t *gtest.T) {
+		manager.SetMaxSize(1024*1024, gsession.OversizePolicyTruncate)
+		defer manager.SetMaxSize(0, gsession.OversizePolicyError)
+		s := manager.New(ctx, "id")
+		defer s.Close()
+		writes := storage.writes
+		t.AssertNil(s.Set("k1", "v1"))
+		t.Assert(storage.writes, writes+1)
+		t.Assert(len(gconv.Map(storage.data["_gf_key_set_times"])), 1)
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gsession"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Session_BindUser(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.TODO()
+			manager = gsession.New(time.Minute, gsession.NewStorageMemory())
+			ids     []string
+		)
+		manager.SetMaxUserSessions(2)
+		t.Assert(manager.GetMaxUserSessions(), 2)
+
+		for i := 0; i < 3; i++ {
+			s := manager.New(ctx)
+			t.AssertNil(s.Set("device", i))
+			t.AssertNil(s.BindUser("john"))
+			t.Assert(s.MustId() != "", true)
+			userId, err := s.UserId()
+			t.AssertNil(err)
+			t.Assert(userId, "john")
+			ids = append(ids, s.MustId())
+			t.AssertNil(s.Close())
+		}
+		// The oldest session is evicted.
+		sessionIds, err := manager.UserSessionIds(ctx, "john")
+		t.AssertNil(err)
+		t.Assert(sessionIds, ids[1:])
+		t.Assert(manager.New(ctx, ids[0]).MustGet("device"), nil)
+		t.Assert(manager.New(ctx, ids[1]).MustGet("device"), 1)
+		t.Assert(manager.New(ctx, ids[2]).MustGet("device"), 2)
+
+		// Rebinding does not duplicate.
+		s := manager.New(ctx, ids[1])
+		t.AssertNil(s.BindUser("john"))
+		sessionIds, err = manager.UserSessionIds(ctx, "john")
+		t.AssertNil(err)
+		t.Assert(sessionIds, []string{ids[2], ids[1]})
+
+		// Unbind.
+		t.AssertNil(s.UnbindUser())
+		userId, err := s.UserId()
+		t.AssertNil(err)
+		t.Assert(userId, "")
+		sessionIds, err = manager.UserSessionIds(ctx, "john")
+		t.AssertNil(err)
+		t.Assert(sessionIds, []string{ids[2]})
+
+		// Remove all sessions of user.
+		t.AssertNil(manager.RemoveUserSessions(ctx, "john"))
+		t.Assert(manager.New(ctx, ids[2]).MustGet("device"), nil)
+		sessionIds, err = manager.UserSessionIds(ctx, "john")
+		t.AssertNil(err)
+		t.Assert(len(sessionIds), 0)
+
+		t.AssertNE(manager.New(ctx).BindUser(""), nil)
+	})
+}
+
+func Test_Session_BindUser_SingleDevice(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.TODO()
+			manager = gsession.New(time.Minute, gsession.NewStorageFile("", time.Minute))
+		)
+		manager.SetMaxUserSessions(1)
+		s1 := manager.New(ctx)
+		t.AssertNil(s1.BindUser("john"))
+		t.AssertNil(s1.Close())
+		id1 := s1.MustId()
+
+		s2 := manager.New(ctx)
+		t.AssertNil(s2.BindUser("john"))
+		t.AssertNil(s2.Close())
+		id2 := s2.MustId()
+		defer manager.New(ctx, id2).RemoveAll()
+
+		t.Assert(manager.New(ctx, id1).MustGet("_gf_user_id"), nil)
+		userId, err := manager.New(ctx, id2).UserId()
+		t.AssertNil(err)
+		t.Assert(userId, "john")
+	})
+}
@@ -12,8 +12,10 @@ import (
 	"time"
 
 	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/gsession"
 	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
 )
 
 func Test_StorageFile(t *testing.T) {
@@ -80,3 +82,78 @@ func Test_StorageFile(t *testing.T) {
 		t.Assert(s.MustGet("k6"), nil)
 	})
 }
+
+func Test_StorageFile_Shard(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx  = context.TODO()
+			path = gfile.Temp(guid.S())
+		)
+		t.AssertNil(gfile.Mkdir(path))
+		defer gfile.Remove(path)
+
+		storage := gsession.NewStorageFile(path, time.Minute)
+		manager := gsession.New(time.Minute, storage)
+		s := manager.New(ctx)
+		t.AssertNil(s.Set("k", "v1"))
+		t.AssertNil(s.Close())
+		id := s.MustId()
+		t.Assert(gfile.Exists(gfile.Join(path, id+".session")), true)
+
+		// The session stored before sharding is retrieved and moved into sharding directories.
+		storage.SetShardLevel(2)
+		t.Assert(storage.GetShardLevel(), 2)
+		shardPath := gfile.Join(path, id[0:2], id[2:4], id+".session")
+		s = manager.New(ctx, id)
+		t.Assert(s.MustGet("k"), "v1")
+		t.AssertNil(s.Set("k", "v2"))
+		t.AssertNil(s.Close())
+		t.Assert(gfile.Exists(gfile.Join(path, id+".session")), false)
+		t.Assert(gfile.Exists(shardPath), true)
+
+		s = manager.New(ctx, id)
+		t.Assert(s.MustGet("k"), "v2")
+		t.AssertNil(s.Close())
+
+		var ids []string
+		t.AssertNil(storage.Iterate(ctx, func(sessionId string, ttl time.Duration) bool {
+			ids = append(ids, sessionId)
+			return true
+		}))
+		t.Assert(ids, []string{id})
+	})
+}
+
+func Test_StorageFile_Gc(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx  = context.TODO()
+			path = gfile.Temp(guid.S())
+		)
+		t.AssertNil(gfile.Mkdir(path))
+		defer gfile.Remove(path)
+
+		storage := gsession.NewStorageFile(path, 500*time.Millisecond)
+		storage.SetShardLevel(1)
+		storage.SetGcOption(gsession.StorageFileGcOption{
+			Interval:     200 * time.Millisecond,
+			Pace:         1,
+			StepInterval: 50 * time.Millisecond,
+		})
+		t.Assert(storage.GetGcOption().Pace, 1)
+		manager := gsession.New(500*time.Millisecond, storage)
+		for i := 0; i < 3; i++ {
+			s := manager.New(ctx)
+			t.AssertNil(s.Set("k", i))
+			t.AssertNil(s.Close())
+		}
+		files, err := gfile.ScanDirFile(path, "*.session", true)
+		t.AssertNil(err)
+		t.Assert(len(files), 3)
+
+		time.Sleep(1500 * time.Millisecond)
+		files, err = gfile.ScanDirFile(path, "*.session", true)
+		t.AssertNil(err)
+		t.Assert(len(files), 0)
+	})
+}
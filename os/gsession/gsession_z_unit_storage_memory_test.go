@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gsession"
 	"github.com/gogf/gf/v2/test/gtest"
@@ -73,3 +74,35 @@ func Test_StorageMemory(t *testing.T) {
 		t.Assert(s.MustGet("k6"), nil)
 	})
 }
+
+func Test_Session_RenewExistingEmpty(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		storage = &countingStorage{
+			StorageMemory: gsession.NewStorageMemory(),
+			writes:        gtype.NewInt(),
+		}
+		manager = gsession.New(time.Minute, storage)
+	)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(ctx)
+		t.AssertNil(s.Set("name", "john"))
+		t.AssertNil(s.Remove("name"))
+		t.AssertNil(s.Close())
+		id := s.MustId()
+
+		// The existing session is renewed even if it has no data in memory.
+		storage.writes.Set(0)
+		s = manager.New(ctx, id)
+		t.Assert(s.MustGet("name"), nil)
+		t.AssertNil(s.Close())
+		t.Assert(storage.writes.Val(), 1)
+
+		// The new session without data is not stored.
+		storage.writes.Set(0)
+		s = manager.New(ctx)
+		t.Assert(s.MustGet("name"), nil)
+		t.AssertNil(s.Close())
+		t.Assert(storage.writes.Val(), 0)
+	})
+}
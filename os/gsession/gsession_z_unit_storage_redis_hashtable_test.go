@@ -147,3 +147,55 @@ func Test_StorageRedisHashTablePrefix(t *testing.T) {
 		t.Assert(s.MustGet("k6"), nil)
 	})
 }
+
+func Test_StorageRedisHashTable_Serializer(t *testing.T) {
+	redis, err := gredis.New(&gredis.Config{
+		Address: "127.0.0.1:6379",
+		Db:      0,
+	})
+	gtest.AssertNil(err)
+
+	storage := gsession.NewStorageRedisHashTable(redis, "s_hash_")
+	storage.SetSerializer(gsession.SerializerJson)
+	manager := gsession.New(time.Second, storage)
+	sessionId := ""
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(context.TODO())
+		defer s.Close()
+		s.Set("k1", 1)
+		s.SetMap(g.Map{
+			"k2": g.Map{"name": "john"},
+		})
+		sessionId = s.MustId()
+
+		// Key-level operations write through to redis directly.
+		v, err := storage.Get(context.TODO(), sessionId, "k1")
+		t.AssertNil(err)
+		t.Assert(v, 1)
+	})
+
+	// The session is renewed by reading without changing.
+	time.Sleep(700 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(context.TODO(), sessionId)
+		t.Assert(s.MustGet("k1").Int(), 1)
+		t.Assert(s.MustGet("k2").MapStrStr()["name"], "john")
+		t.AssertNil(s.Close())
+	})
+	time.Sleep(700 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(context.TODO(), sessionId)
+		t.Assert(s.MustSize(), 2)
+	})
+
+	// The sub-second TTL does not delete the session immediately.
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(500*time.Millisecond, storage)
+		s := manager.New(context.TODO())
+		t.AssertNil(s.Set("k1", 1))
+		t.AssertNil(s.Close())
+
+		s = manager.New(context.TODO(), s.MustId())
+		t.Assert(s.MustGet("k1"), 1)
+	})
+}
@@ -145,3 +145,62 @@ func Test_StorageRedisPrefix(t *testing.T) {
 		t.Assert(s.MustGet("k6"), nil)
 	})
 }
+
+func Test_StorageRedis_HashMode(t *testing.T) {
+	redis, err := gredis.New(&gredis.Config{
+		Address: "127.0.0.1:6379",
+		Db:      0,
+	})
+	gtest.AssertNil(err)
+
+	storage := gsession.NewStorageRedis(redis, "s_hash_")
+	storage.SetHashMode(true)
+	manager := gsession.New(time.Second, storage)
+	sessionId := ""
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(storage.IsHashMode(), true)
+		s := manager.New(context.TODO())
+		defer s.Close()
+		s.Set("k1", "v1")
+		s.Set("k2", 2)
+		s.SetMap(g.Map{
+			"k3": "v3",
+			"k4": g.Map{"name": "john"},
+		})
+		t.Assert(s.IsDirty(), true)
+		sessionId = s.MustId()
+
+		// Key-level operations write through to redis directly.
+		v, err := storage.Get(context.TODO(), sessionId, "k1")
+		t.AssertNil(err)
+		t.Assert(v, "v1")
+	})
+
+	time.Sleep(500 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(context.TODO(), sessionId)
+		t.Assert(s.MustGet("k1"), "v1")
+		t.Assert(s.MustGet("k2").Int(), 2)
+		t.Assert(s.MustGet("k3"), "v3")
+		t.Assert(s.MustGet("k4").MapStrStr()["name"], "john")
+		t.Assert(len(s.MustData()), 4)
+		t.Assert(s.MustSize(), 4)
+		t.Assert(s.MustContains("k5"), false)
+		s.Remove("k4")
+		t.Assert(s.MustSize(), 3)
+		t.Assert(s.MustContains("k4"), false)
+		s.RemoveAll()
+		t.Assert(s.MustSize(), 0)
+		t.Assert(s.MustContains("k1"), false)
+		s.Set("k5", "v5")
+		t.Assert(s.MustSize(), 1)
+		s.Close()
+	})
+
+	time.Sleep(1500 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(context.TODO(), sessionId)
+		t.Assert(s.MustSize(), 0)
+		t.Assert(s.MustGet("k5"), nil)
+	})
+}
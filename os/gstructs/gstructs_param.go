@@ -0,0 +1,210 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstructs
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Parameter locations of request struct, which are specified by tag "in", like `in:"header"`.
+const (
+	ParamInQuery  = "query"
+	ParamInHeader = "header"
+	ParamInPath   = "path"
+	ParamInCookie = "cookie"
+	ParamInBody   = "body"
+)
+
+const (
+	// ParamTagIn is the tag specifying the location of the parameter.
+	ParamTagIn = "in"
+
+	metaFieldName     = "Meta"   // Name of the embedded meta field, like g.Meta.
+	metaTagPath       = "path"   // Tag of meta field for the route path.
+	metaTagMethod     = "method" // Tag of meta field for the HTTP method.
+	ruleRequired      = "required"
+	ruleAliasSplitter = "@"
+	ruleMsgSplitter   = "#"
+)
+
+var (
+	// ParamNameTagPriority is the priority tags for the parameter name, which is the same as gconv.StructTagPriority
+	// that ghttp uses for parameters binding.
+	ParamNameTagPriority = []string{"gconv", "param", "params", "c", "p", "json"}
+
+	// ParamRuleTagPriority is the priority tags for the validation rules, which is the same as gvalid.GetTags.
+	ParamRuleTagPriority = []string{"gvalid", "valid", "v"}
+
+	// requestInfoCache is the cache for RequestInfo, which is map[reflect.Type]*RequestInfo.
+	requestInfoCache sync.Map
+)
+
+// RequestInfo is the parameter metadata of a request struct, which is shared by ghttp parameters binding,
+// gclient request building and docs tooling, so that the client and server are symmetric.
+//
+// It is read-only and concurrent-safe after created, DO NOT modify its content.
+type RequestInfo struct {
+	Method string       // HTTP method in upper case from the "method" tag of meta field, like g.Meta.
+	Path   string       // Route path from the "path" tag of meta field, like "/user/{id}".
+	Params []*ParamInfo // Parameters of the request struct.
+}
+
+// ParamInfo is the metadata of a request parameter.
+type ParamInfo struct {
+	Name     string     // Canonical parameter name from ParamNameTagPriority tags, or the field name if no tag.
+	In       string     // Location of the parameter, see ParamInQuery, ParamInHeader, etc.
+	Rules    string     // Validation rules without alias and messages, like "required|length:6,16".
+	Required bool       // Whether the parameter is required, which is true for path parameter or "required" rule.
+	Field    *FieldInfo // The struct field of the parameter.
+}
+
+// RequestInfoOf retrieves and returns the cached parameter metadata of request struct `object`, which should
+// be type of struct/*struct, or reflect.Type/reflect.Value of them.
+//
+// The location of parameter is specified by tag "in", or else it is detected automatically:
+// it is "path" if the route path contains "{name}", "query" for the methods of GET/DELETE/HEAD
+// or no method specified, and "body" for the other methods.
+func RequestInfoOf(object interface{}) (*RequestInfo, error) {
+	typeInfo, err := TypeInfoOf(object)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := requestInfoCache.Load(typeInfo.Type); ok {
+		return v.(*RequestInfo), nil
+	}
+	info, err := newRequestInfo(typeInfo)
+	if err != nil {
+		return nil, err
+	}
+	v, _ := requestInfoCache.LoadOrStore(typeInfo.Type, info)
+	return v.(*RequestInfo), nil
+}
+
+// Param retrieves and returns the parameter of given `name`.
+func (r *RequestInfo) Param(name string) (*ParamInfo, bool) {
+	for _, param := range r.Params {
+		if param.Name == name {
+			return param, true
+		}
+	}
+	return nil, false
+}
+
+// ParamsIn returns the parameters of location `in`.
+func (r *RequestInfo) ParamsIn(in string) []*ParamInfo {
+	params := make([]*ParamInfo, 0)
+	for _, param := range r.Params {
+		if param.In == in {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// newRequestInfo creates and returns the RequestInfo of struct `typeInfo`.
+func newRequestInfo(typeInfo *TypeInfo) (*RequestInfo, error) {
+	info := &RequestInfo{
+		Params: make([]*ParamInfo, 0),
+	}
+	if metaField, ok := typeInfo.Field(metaFieldName); ok && metaField.Embedded {
+		info.Method = strings.ToUpper(metaField.Tag(metaTagMethod))
+		info.Path = metaField.Tag(metaTagPath)
+	}
+	for _, field := range typeInfo.Fields {
+		if field.Embedded {
+			// The fields of embedded struct are promoted.
+			continue
+		}
+		param := &ParamInfo{
+			Name:  field.Name,
+			In:    strings.ToLower(field.Tag(ParamTagIn)),
+			Field: field,
+		}
+		for _, tag := range ParamNameTagPriority {
+			if name := field.TagName(tag); name != "" {
+				param.Name = name
+				break
+			}
+		}
+		if param.Name == "-" {
+			continue
+		}
+		for _, tag := range ParamRuleTagPriority {
+			if rules, ok := field.TagLookup(tag); ok {
+				param.Rules = parseParamRules(rules)
+				break
+			}
+		}
+		if param.In == "" {
+			param.In = detectParamIn(info.Method, info.Path, param.Name)
+		}
+		switch param.In {
+		case ParamInPath:
+			param.Required = true
+
+		case ParamInQuery, ParamInHeader, ParamInCookie, ParamInBody:
+			for _, rule := range strings.Split(param.Rules, "|") {
+				if strings.TrimSpace(rule) == ruleRequired {
+					param.Required = true
+					break
+				}
+			}
+
+		default:
+			return nil, gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`invalid tag value "%s" for "%s" of field "%s"`,
+				param.In, ParamTagIn, field.Name,
+			)
+		}
+		info.Params = append(info.Params, param)
+	}
+	return info, nil
+}
+
+// detectParamIn detects and returns the location of parameter `name` of request `method` and `path`.
+func detectParamIn(method, path, name string) string {
+	if strings.Contains(strings.ToLower(path), "{"+strings.ToLower(name)+"}") {
+		return ParamInPath
+	}
+	switch method {
+	case "", http.MethodGet, http.MethodDelete, http.MethodHead:
+		return ParamInQuery
+	}
+	return ParamInBody
+}
+
+// parseParamRules returns the rules of validation tag value `tag`, in which the alias and messages are removed,
+// eg: "name@required|length:2,20#message" is "required|length:2,20".
+func parseParamRules(tag string) string {
+	if pos := strings.Index(tag, ruleMsgSplitter); pos != -1 {
+		tag = tag[:pos]
+	}
+	if pos := strings.Index(tag, ruleAliasSplitter); pos != -1 && isParamRuleAlias(tag[:pos]) {
+		tag = tag[pos+1:]
+	}
+	return strings.TrimSpace(tag)
+}
+
+// isParamRuleAlias checks whether `s` is a valid alias name of validation tag, which contains only word chars.
+func isParamRuleAlias(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !(c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,318 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstructs
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/utils"
+)
+
+// TypeInfo is the cached reflection information of a struct type, which is shared among
+// framework and extensions like middlewares and codecs to avoid duplicated reflection metadata.
+//
+// It is read-only and concurrent-safe after created, DO NOT modify its content.
+type TypeInfo struct {
+	Type     reflect.Type          // The struct type.
+	Fields   []*FieldInfo          // Exported fields, including the promoted ones of embedded structs.
+	fieldMap map[string]*FieldInfo // Fields map by name.
+}
+
+// FieldInfo is the cached reflection information of a struct field.
+type FieldInfo struct {
+	Name     string              // Field name.
+	Index    []int               // Index sequence for reflect.Value.FieldByIndex, which is longer than 1 for promoted field.
+	Type     reflect.Type        // Field type.
+	Field    reflect.StructField // The underlying struct field.
+	Embedded bool                // Whether the field itself is an embedded(anonymous) field.
+	Promoted bool                // Whether the field is promoted from an embedded struct.
+	tags     map[string]string   // Parsed tags with gtag variables replaced.
+}
+
+var (
+	// typeInfoCache is the cache for TypeInfo, which is map[reflect.Type]*TypeInfo.
+	typeInfoCache sync.Map
+)
+
+// TypeInfoOf retrieves and returns the cached TypeInfo of `object`, which should be type of
+// struct/*struct/[]struct/[]*struct, or reflect.Type/reflect.Value of them.
+//
+// The fields of embedded structs are flattened by the promotion rules of Go: the shallower field
+// hides the deeper one with the same name, and the fields with the same name at the same depth
+// hide each other. The unexported fields are ignored, but the exported fields of unexported
+// embedded structs are promoted.
+//
+// Note that the tag variables of gtag are replaced when the TypeInfo is created, so the tags
+// should be registered in boot procedure before any retrieving.
+func TypeInfoOf(object interface{}) (*TypeInfo, error) {
+	var reflectType reflect.Type
+	switch v := object.(type) {
+	case reflect.Type:
+		reflectType = v
+	case reflect.Value:
+		reflectType = v.Type()
+	default:
+		if object == nil {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, `object cannot be nil`)
+		}
+		reflectType = reflect.TypeOf(object)
+	}
+	for {
+		switch reflectType.Kind() {
+		case reflect.Ptr, reflect.Array, reflect.Slice:
+			reflectType = reflectType.Elem()
+			continue
+		}
+		break
+	}
+	if reflectType.Kind() != reflect.Struct {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`invalid object kind "%s", kind of "struct" is required`,
+			reflectType.Kind(),
+		)
+	}
+	if v, ok := typeInfoCache.Load(reflectType); ok {
+		return v.(*TypeInfo), nil
+	}
+	info := newTypeInfo(reflectType)
+	v, _ := typeInfoCache.LoadOrStore(reflectType, info)
+	return v.(*TypeInfo), nil
+}
+
+// newTypeInfo creates and returns the TypeInfo of struct type `structType`.
+func newTypeInfo(structType reflect.Type) *TypeInfo {
+	type queueItem struct {
+		typ   reflect.Type
+		index []int
+	}
+	var (
+		info = &TypeInfo{
+			Type:     structType,
+			Fields:   make([]*FieldInfo, 0),
+			fieldMap: make(map[string]*FieldInfo),
+		}
+		current = []queueItem{{typ: structType}}
+		visited = map[reflect.Type]bool{}
+		hidden  = map[string]bool{}
+	)
+	// Breadth-first traversal of the embedded structs, depth by depth.
+	for len(current) > 0 {
+		var (
+			next       []queueItem
+			levelCount = map[string]int{}
+			levelField = map[string]*FieldInfo{}
+			levelNames = make([]string, 0)
+		)
+		for _, item := range current {
+			if visited[item.typ] {
+				continue
+			}
+			visited[item.typ] = true
+			for i := 0; i < item.typ.NumField(); i++ {
+				var (
+					field = item.typ.Field(i)
+					index = make([]int, len(item.index)+1)
+				)
+				copy(index, item.index)
+				index[len(item.index)] = i
+				if field.Anonymous {
+					embeddedType := field.Type
+					if embeddedType.Kind() == reflect.Ptr {
+						embeddedType = embeddedType.Elem()
+					}
+					if embeddedType.Kind() == reflect.Struct {
+						next = append(next, queueItem{typ: embeddedType, index: index})
+					}
+				}
+				if field.PkgPath != "" {
+					continue
+				}
+				if _, ok := levelCount[field.Name]; !ok {
+					levelNames = append(levelNames, field.Name)
+				}
+				levelCount[field.Name]++
+				levelField[field.Name] = &FieldInfo{
+					Name:     field.Name,
+					Index:    index,
+					Type:     field.Type,
+					Field:    field,
+					Embedded: field.Anonymous,
+					Promoted: len(index) > 1,
+					tags:     parseFieldTags(field),
+				}
+			}
+		}
+		for _, name := range levelNames {
+			if hidden[name] {
+				continue
+			}
+			// The name is hidden for deeper fields, no matter whether it is ambiguous at current depth.
+			hidden[name] = true
+			if levelCount[name] > 1 {
+				continue
+			}
+			info.Fields = append(info.Fields, levelField[name])
+			info.fieldMap[name] = levelField[name]
+		}
+		current = next
+	}
+	return info
+}
+
+// parseFieldTags parses and returns the tags of `field` with gtag variables replaced.
+func parseFieldTags(field reflect.StructField) map[string]string {
+	tags := ParseTag(string(field.Tag))
+	for k, v := range tags {
+		tags[k] = utils.StripSlashes(v)
+	}
+	return tags
+}
+
+// Field retrieves and returns the field of given `name`.
+func (t *TypeInfo) Field(name string) (*FieldInfo, bool) {
+	field, ok := t.fieldMap[name]
+	return field, ok
+}
+
+// FieldByTag retrieves and returns the first field, the tag `key` value of which is `value`.
+// The tag value is compared with its options stripped, eg: `json:"name,omitempty"` is "name".
+func (t *TypeInfo) FieldByTag(key, value string) (*FieldInfo, bool) {
+	for _, field := range t.Fields {
+		if field.TagName(key) == value {
+			return field, true
+		}
+	}
+	return nil, false
+}
+
+// Tag returns the value of tag `key` of the field, or empty string if the tag does not exist.
+func (f *FieldInfo) Tag(key string) string {
+	return f.tags[key]
+}
+
+// TagLookup returns the value of tag `key` of the field and whether the tag exists.
+func (f *FieldInfo) TagLookup(key string) (value string, ok bool) {
+	value, ok = f.tags[key]
+	return
+}
+
+// TagName returns the value of tag `key` without options, eg: "name" for `json:"name,omitempty"`.
+func (f *FieldInfo) TagName(key string) string {
+	value := f.tags[key]
+	for i := 0; i < len(value); i++ {
+		if value[i] == ',' {
+			return value[:i]
+		}
+	}
+	return value
+}
+
+// TagMap returns a copy of all the tags of the field as map.
+func (f *FieldInfo) TagMap() map[string]string {
+	data := make(map[string]string, len(f.tags))
+	for k, v := range f.tags {
+		data[k] = v
+	}
+	return data
+}
+
+// Value retrieves and returns the field value of `structValue`, which is struct/*struct reflect.Value.
+// It returns an invalid reflect.Value if the field is promoted through a nil embedded pointer.
+func (f *FieldInfo) Value(structValue reflect.Value) reflect.Value {
+	for structValue.Kind() == reflect.Ptr {
+		if structValue.IsNil() {
+			return reflect.Value{}
+		}
+		structValue = structValue.Elem()
+	}
+	for i, x := range f.Index {
+		if i > 0 && structValue.Kind() == reflect.Ptr {
+			if structValue.IsNil() {
+				return reflect.Value{}
+			}
+			structValue = structValue.Elem()
+		}
+		structValue = structValue.Field(x)
+	}
+	return structValue
+}
+
+// Set sets the field of struct `pointer`, which is *struct or reflect.Value of *struct/addressable struct,
+// with `value`. The nil embedded pointers along the way are automatically created.
+// The `value` should be assignable or convertible to the field type, or else it returns error.
+// Note that the integer `value` is not convertible to string field, as reflection converts it to
+// the char of the code point, eg: 65 to "A".
+func (f *FieldInfo) Set(pointer interface{}, value interface{}) error {
+	var structValue reflect.Value
+	if v, ok := pointer.(reflect.Value); ok {
+		structValue = v
+	} else {
+		structValue = reflect.ValueOf(pointer)
+	}
+	for structValue.Kind() == reflect.Ptr {
+		if structValue.IsNil() {
+			return gerror.NewCode(gcode.CodeInvalidParameter, `object pointer cannot be nil`)
+		}
+		structValue = structValue.Elem()
+	}
+	if !structValue.CanAddr() {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `object of type "%s" is not addressable`, structValue.Type())
+	}
+	for i, x := range f.Index {
+		if i > 0 && structValue.Kind() == reflect.Ptr {
+			if structValue.IsNil() {
+				if !structValue.CanSet() {
+					return gerror.NewCodef(
+						gcode.CodeInvalidParameter,
+						`cannot create nil embedded pointer of type "%s"`, structValue.Type(),
+					)
+				}
+				structValue.Set(reflect.New(structValue.Type().Elem()))
+			}
+			structValue = structValue.Elem()
+		}
+		structValue = structValue.Field(x)
+	}
+	var reflectValue reflect.Value
+	if v, ok := value.(reflect.Value); ok {
+		reflectValue = v
+	} else if value == nil {
+		reflectValue = reflect.Zero(f.Type)
+	} else {
+		reflectValue = reflect.ValueOf(value)
+	}
+	switch {
+	case reflectValue.Type().AssignableTo(f.Type):
+	case reflectValue.Type().ConvertibleTo(f.Type) && !isIntegerToString(reflectValue.Type(), f.Type):
+		reflectValue = reflectValue.Convert(f.Type)
+	default:
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`cannot set value of type "%s" to field "%s" of type "%s"`,
+			reflectValue.Type(), f.Name, f.Type,
+		)
+	}
+	structValue.Set(reflectValue)
+	return nil
+}
+
+// isIntegerToString checks whether it is the conversion from integer type `from` to string type `to`.
+func isIntegerToString(from, to reflect.Type) bool {
+	if to.Kind() != reflect.String {
+		return false
+	}
+	switch from.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstructs_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gstructs"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gmeta"
+)
+
+type paramPage struct {
+	Page int `json:"page" v:"min:1"`
+	Size int `json:"size" v:"pageSize@between:1,100#Invalid page size"`
+}
+
+type paramUserUpdateReq struct {
+	gmeta.Meta `path:"/user/{id}" method:"put"`
+	paramPage
+	Id       int    `json:"id"`
+	Token    string `json:"Authorization" in:"header" v:"required"`
+	Session  string `p:"session" in:"cookie"`
+	Fields   string `in:"query"`
+	Name     string `json:"name" v:"required|length:2,20#Name is required|Name length should be between 2 and 20"`
+	Internal string `json:"-"`
+}
+
+func Test_RequestInfoOf(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		info, err := gstructs.RequestInfoOf(&paramUserUpdateReq{})
+		t.AssertNil(err)
+		t.Assert(info.Method, "PUT")
+		t.Assert(info.Path, "/user/{id}")
+		t.Assert(len(info.Params), 7)
+
+		param, ok := info.Param("id")
+		t.Assert(ok, true)
+		t.Assert(param.In, gstructs.ParamInPath)
+		t.Assert(param.Required, true)
+		t.Assert(param.Field.Name, "Id")
+
+		param, _ = info.Param("Authorization")
+		t.Assert(param.In, gstructs.ParamInHeader)
+		t.Assert(param.Rules, "required")
+		t.Assert(param.Required, true)
+
+		param, _ = info.Param("session")
+		t.Assert(param.In, gstructs.ParamInCookie)
+		t.Assert(param.Required, false)
+
+		param, _ = info.Param("Fields")
+		t.Assert(param.In, gstructs.ParamInQuery)
+
+		param, _ = info.Param("name")
+		t.Assert(param.In, gstructs.ParamInBody)
+		t.Assert(param.Rules, "required|length:2,20")
+		t.Assert(param.Required, true)
+
+		param, _ = info.Param("size")
+		t.Assert(param.In, gstructs.ParamInBody)
+		t.Assert(param.Rules, "between:1,100")
+		t.Assert(param.Field.Promoted, true)
+
+		_, ok = info.Param("Internal")
+		t.Assert(ok, false)
+		_, ok = info.Param("-")
+		t.Assert(ok, false)
+
+		t.Assert(len(info.ParamsIn(gstructs.ParamInBody)), 3)
+		t.Assert(len(info.ParamsIn(gstructs.ParamInHeader)), 1)
+
+		// Cached.
+		info2, err := gstructs.RequestInfoOf(paramUserUpdateReq{})
+		t.AssertNil(err)
+		t.Assert(info2 == info, true)
+	})
+}
+
+func Test_RequestInfoOf_DefaultIn(t *testing.T) {
+	type UserListReq struct {
+		gmeta.Meta `path:"/user" method:"get"`
+		Name       string
+	}
+	type UserLoginReq struct {
+		Name string
+	}
+	gtest.C(t, func(t *gtest.T) {
+		info, err := gstructs.RequestInfoOf(&UserListReq{})
+		t.AssertNil(err)
+		t.Assert(info.Params[0].In, gstructs.ParamInQuery)
+
+		info, err = gstructs.RequestInfoOf(&UserLoginReq{})
+		t.AssertNil(err)
+		t.Assert(info.Method, "")
+		t.Assert(info.Params[0].In, gstructs.ParamInQuery)
+	})
+}
+
+func Test_RequestInfoOf_InvalidIn(t *testing.T) {
+	type Req struct {
+		Name string `in:"form"`
+	}
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gstructs.RequestInfoOf(&Req{})
+		t.AssertNE(err, nil)
+		_, err = gstructs.RequestInfoOf(1)
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstructs_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gstructs"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type typeInfoBase struct {
+	Id      int    `json:"id"`
+	Created string `json:"created" dc:"creation time"`
+}
+
+type TypeInfoExtra struct {
+	Remark string `json:"remark,omitempty"`
+	Name   string `json:"extra_name"`
+}
+
+type typeInfoUser struct {
+	typeInfoBase
+	*TypeInfoExtra
+	Name    string `json:"name" v:"required"`
+	Age     int
+	private string
+}
+
+func Test_TypeInfoOf(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		info, err := gstructs.TypeInfoOf(&typeInfoUser{})
+		t.AssertNil(err)
+		t.Assert(info.Type, reflect.TypeOf(typeInfoUser{}))
+
+		// Cached.
+		info2, err := gstructs.TypeInfoOf([]*typeInfoUser{})
+		t.AssertNil(err)
+		t.Assert(info == info2, true)
+		info3, err := gstructs.TypeInfoOf(reflect.TypeOf(typeInfoUser{}))
+		t.AssertNil(err)
+		t.Assert(info == info3, true)
+
+		names := make([]string, 0)
+		for _, field := range info.Fields {
+			names = append(names, field.Name)
+		}
+		// The unexported embedded struct is ignored, but its fields are promoted.
+		// The shallower Name field hides the embedded one.
+		t.Assert(names, []string{"TypeInfoExtra", "Name", "Age", "Id", "Created", "Remark"})
+
+		field, ok := info.Field("Created")
+		t.Assert(ok, true)
+		t.Assert(field.Index, []int{0, 1})
+		t.Assert(field.Promoted, true)
+		t.Assert(field.Tag("dc"), "creation time")
+		t.Assert(field.TagName("json"), "created")
+
+		field, ok = info.Field("Name")
+		t.Assert(ok, true)
+		t.Assert(field.Promoted, false)
+		t.Assert(field.Tag("v"), "required")
+		_, ok = field.TagLookup("dc")
+		t.Assert(ok, false)
+
+		field, ok = info.FieldByTag("json", "remark")
+		t.Assert(ok, true)
+		t.Assert(field.Name, "Remark")
+
+		_, ok = info.Field("private")
+		t.Assert(ok, false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gstructs.TypeInfoOf(1)
+		t.AssertNE(err, nil)
+		_, err = gstructs.TypeInfoOf(nil)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_TypeInfo_Ambiguous(t *testing.T) {
+	type A struct{ Name, A string }
+	type B struct{ Name, B string }
+	type C struct {
+		A
+		B
+	}
+	gtest.C(t, func(t *gtest.T) {
+		info, err := gstructs.TypeInfoOf(C{})
+		t.AssertNil(err)
+		_, ok := info.Field("Name")
+		t.Assert(ok, false)
+		_, ok = info.Field("A")
+		t.Assert(ok, true)
+		_, ok = info.Field("B")
+		t.Assert(ok, true)
+	})
+}
+
+func Test_FieldInfo_Value_Set(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			user    = &typeInfoUser{}
+			info, _ = gstructs.TypeInfoOf(user)
+			remark  = info.Fields[5]
+		)
+		t.Assert(remark.Name, "Remark")
+		// Promoted through nil embedded pointer.
+		t.Assert(remark.Value(reflect.ValueOf(user)).IsValid(), false)
+
+		t.AssertNil(remark.Set(user, "remark"))
+		t.AssertNE(user.TypeInfoExtra, nil)
+		t.Assert(user.Remark, "remark")
+		t.Assert(remark.Value(reflect.ValueOf(user)).String(), "remark")
+
+		id, _ := info.Field("Id")
+		t.AssertNil(id.Set(reflect.ValueOf(user), int64(10)))
+		t.Assert(user.Id, 10)
+		t.AssertNil(id.Set(user, nil))
+		t.Assert(user.Id, 0)
+
+		// Type mismatch.
+		t.AssertNE(id.Set(user, "10"), nil)
+		t.AssertNE(remark.Set(user, 65), nil)
+		t.AssertNE(remark.Set(user, uint8(65)), nil)
+		t.Assert(user.Remark, "remark")
+		t.AssertNil(remark.Set(user, []byte("bytes")))
+		t.Assert(user.Remark, "bytes")
+		// Not addressable.
+		t.AssertNE(id.Set(*user, 1), nil)
+	})
+}
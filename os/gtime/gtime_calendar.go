@@ -0,0 +1,271 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtime
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Calendar is a business calendar, which determines working days by weekends, holidays
+// and the adjusted working days that fall on weekends.
+// It is concurrent-safe.
+type Calendar struct {
+	mu       sync.RWMutex
+	weekends map[time.Weekday]struct{} // Weekdays that are not working days.
+	holidays map[string]struct{}       // Holidays like "2006-01-02", or recurring holidays like "01-02".
+	workdays map[string]struct{}       // Adjusted working days like "2006-01-02", which overrides weekends and holidays.
+}
+
+// CalendarConfig is the configuration for Calendar, which is usually loaded from configuration file:
+//
+//	calendar:
+//	  weekends: [0, 6]
+//	  holidays: ["01-01", "2022-10-03"]
+//	  workdays: ["2022-10-08"]
+//
+// The date of holidays and workdays is in format "2006-01-02", and holidays also support format "01-02",
+// which is the holiday recurring every year.
+type CalendarConfig struct {
+	Weekends []time.Weekday `json:"weekends"` // Weekdays that are not working days, default is Saturday and Sunday.
+	Holidays []string       `json:"holidays"` // Holidays that are not working days.
+	Workdays []string       `json:"workdays"` // Adjusted working days, which are working days even on weekends.
+}
+
+const (
+	calendarDateLayout      = "2006-01-02"
+	calendarRecurringLayout = "01-02"
+	// Max count of continuous non-business days searched, which avoids endless searching if
+	// the calendar is improperly configured.
+	calendarMaxSearchDays = 3660
+)
+
+// NewCalendar creates and returns a business calendar.
+// It uses Saturday and Sunday as weekends without any holiday if no `config` given.
+func NewCalendar(config ...CalendarConfig) (*Calendar, error) {
+	c := &Calendar{
+		weekends: map[time.Weekday]struct{}{
+			time.Saturday: {},
+			time.Sunday:   {},
+		},
+		holidays: make(map[string]struct{}),
+		workdays: make(map[string]struct{}),
+	}
+	if len(config) > 0 {
+		if err := c.SetConfig(config[0]); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// SetConfig sets the weekends, holidays and workdays of the calendar with `config`.
+// It replaces all the holidays and workdays set before.
+func (c *Calendar) SetConfig(config CalendarConfig) error {
+	holidays := make(map[string]struct{}, len(config.Holidays))
+	for _, date := range config.Holidays {
+		key, err := calendarKey(date, true)
+		if err != nil {
+			return err
+		}
+		holidays[key] = struct{}{}
+	}
+	workdays := make(map[string]struct{}, len(config.Workdays))
+	for _, date := range config.Workdays {
+		key, err := calendarKey(date, false)
+		if err != nil {
+			return err
+		}
+		workdays[key] = struct{}{}
+	}
+	if config.Weekends != nil {
+		if err := c.SetWeekends(config.Weekends...); err != nil {
+			return err
+		}
+	}
+	c.mu.Lock()
+	c.holidays = holidays
+	c.workdays = workdays
+	c.mu.Unlock()
+	return nil
+}
+
+// SetWeekends sets the weekdays that are not working days.
+// It clears the weekends if no `weekdays` given, that means every weekday is a working day.
+func (c *Calendar) SetWeekends(weekdays ...time.Weekday) error {
+	weekends := make(map[time.Weekday]struct{}, len(weekdays))
+	for _, weekday := range weekdays {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid weekday "%d"`, weekday)
+		}
+		weekends[weekday] = struct{}{}
+	}
+	if len(weekends) == 7 {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `all weekdays cannot be weekends`)
+	}
+	c.mu.Lock()
+	c.weekends = weekends
+	c.mu.Unlock()
+	return nil
+}
+
+// AddHolidays adds holidays to the calendar.
+// The parameter `dates` is in format "2006-01-02", or "01-02" for the holiday recurring every year.
+func (c *Calendar) AddHolidays(dates ...string) error {
+	keys := make([]string, 0, len(dates))
+	for _, date := range dates {
+		key, err := calendarKey(date, true)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+	c.mu.Lock()
+	for _, key := range keys {
+		c.holidays[key] = struct{}{}
+	}
+	c.mu.Unlock()
+	return nil
+}
+
+// AddWorkdays adds adjusted working days to the calendar, which are working days
+// even they are weekends or holidays. The parameter `dates` is in format "2006-01-02".
+func (c *Calendar) AddWorkdays(dates ...string) error {
+	keys := make([]string, 0, len(dates))
+	for _, date := range dates {
+		key, err := calendarKey(date, false)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+	c.mu.Lock()
+	for _, key := range keys {
+		c.workdays[key] = struct{}{}
+	}
+	c.mu.Unlock()
+	return nil
+}
+
+// IsHoliday checks and returns whether the date of `t` is a holiday of the calendar.
+func (c *Calendar) IsHoliday(t *Time) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.isHoliday(t.Time)
+}
+
+// IsBusinessDay checks and returns whether the date of `t` is a working day,
+// that is, an adjusted working day, or a day which is neither a weekend nor a holiday.
+func (c *Calendar) IsBusinessDay(t *Time) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.isBusinessDay(t.Time)
+}
+
+// NextBusinessDay returns the first working day after the date of `t`, with the same clock of `t`.
+// It returns nil if no working day found in a long period, which means improper configuration.
+func (c *Calendar) NextBusinessDay(t *Time) *Time {
+	return c.searchBusinessDay(t, 1)
+}
+
+// PrevBusinessDay returns the last working day before the date of `t`, with the same clock of `t`.
+// It returns nil if no working day found in a long period, which means improper configuration.
+func (c *Calendar) PrevBusinessDay(t *Time) *Time {
+	return c.searchBusinessDay(t, -1)
+}
+
+// AddBusinessDays adds `days` working days to `t` and returns the result, with the same clock of `t`.
+// The parameter `days` can be negative, which subtracts working days from `t`.
+// It returns a clone of `t` if `days` is 0, no matter it is a working day or not.
+//
+// Eg: the result of adding 1 working day to Friday is next Monday, if no holidays.
+func (c *Calendar) AddBusinessDays(t *Time, days int) *Time {
+	var (
+		step   = 1
+		result = t.Clone()
+	)
+	if days < 0 {
+		step, days = -1, -days
+	}
+	for i := 0; i < days; i++ {
+		if result = c.searchBusinessDay(result, step); result == nil {
+			return nil
+		}
+	}
+	return result
+}
+
+// BusinessDaysBetween returns the count of working days in date range (`from`, `to`],
+// which is negative if `to` is before `from`.
+func (c *Calendar) BusinessDaysBetween(from, to *Time) int {
+	var (
+		sign  = 1
+		count = 0
+	)
+	start, end := from.StartOfDay(), to.StartOfDay()
+	if end.Before(start) {
+		sign = -1
+		start, end = end, start
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for day := start.Time.AddDate(0, 0, 1); !day.After(end.Time); day = day.AddDate(0, 0, 1) {
+		if c.isBusinessDay(day) {
+			count++
+		}
+	}
+	return sign * count
+}
+
+// searchBusinessDay searches the closest working day from the next date of `t` in direction `step`.
+func (c *Calendar) searchBusinessDay(t *Time, step int) *Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	day := t.Time
+	for i := 0; i < calendarMaxSearchDays; i++ {
+		day = day.AddDate(0, 0, step)
+		if c.isBusinessDay(day) {
+			return NewFromTime(day)
+		}
+	}
+	return nil
+}
+
+func (c *Calendar) isBusinessDay(t time.Time) bool {
+	if _, ok := c.workdays[t.Format(calendarDateLayout)]; ok {
+		return true
+	}
+	if _, ok := c.weekends[t.Weekday()]; ok {
+		return false
+	}
+	return !c.isHoliday(t)
+}
+
+func (c *Calendar) isHoliday(t time.Time) bool {
+	if _, ok := c.holidays[t.Format(calendarDateLayout)]; ok {
+		return true
+	}
+	_, ok := c.holidays[t.Format(calendarRecurringLayout)]
+	return ok
+}
+
+// calendarKey validates and formats `date` as the key of calendar.
+func calendarKey(date string, allowRecurring bool) (string, error) {
+	if t, err := time.Parse(calendarDateLayout, date); err == nil {
+		return t.Format(calendarDateLayout), nil
+	}
+	if allowRecurring {
+		// It uses a leap year for parsing, as "02-29" is valid recurring date.
+		if t, err := time.Parse(calendarDateLayout, "2000-"+date); err == nil {
+			return t.Format(calendarRecurringLayout), nil
+		}
+	}
+	return "", gerror.NewCodef(gcode.CodeInvalidParameter, `invalid calendar date "%s"`, date)
+}
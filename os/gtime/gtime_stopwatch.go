@@ -0,0 +1,303 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtime
+
+import (
+	"sync"
+	"time"
+)
+
+// Stopwatch measures elapsed time using the monotonic clock, which is not affected
+// by the changes of wall clock, like NTP adjustment or manual setting of system time.
+// It also records laps, which are the durations between continuous Lap calls.
+// It is concurrent-safe.
+type Stopwatch struct {
+	mu      sync.RWMutex
+	start   time.Time       // Start time of current running, which contains monotonic clock reading.
+	lapAt   time.Time       // Time of last lap, or start time if no lap recorded.
+	elapsed time.Duration   // Accumulated duration of the previous runnings.
+	running bool            // Whether the stopwatch is running.
+	laps    []time.Duration // Recorded laps.
+}
+
+// NewStopwatch creates, starts and returns a Stopwatch.
+func NewStopwatch() *Stopwatch {
+	sw := &Stopwatch{}
+	sw.Start()
+	return sw
+}
+
+// Start starts or resumes the stopwatch. It does nothing if the stopwatch is running.
+func (sw *Stopwatch) Start() {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.running {
+		return
+	}
+	now := time.Now()
+	sw.start = now
+	sw.lapAt = now
+	sw.running = true
+}
+
+// Stop stops the stopwatch and returns the total elapsed duration.
+// The stopped stopwatch can be resumed by Start.
+func (sw *Stopwatch) Stop() time.Duration {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.running {
+		sw.elapsed += time.Since(sw.start)
+		sw.running = false
+	}
+	return sw.elapsed
+}
+
+// Reset stops the stopwatch and clears the elapsed duration and all recorded laps.
+func (sw *Stopwatch) Reset() {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.elapsed = 0
+	sw.running = false
+	sw.laps = nil
+}
+
+// Restart resets and starts the stopwatch.
+func (sw *Stopwatch) Restart() {
+	sw.Reset()
+	sw.Start()
+}
+
+// IsRunning checks and returns whether the stopwatch is running.
+func (sw *Stopwatch) IsRunning() bool {
+	sw.mu.RLock()
+	defer sw.mu.RUnlock()
+	return sw.running
+}
+
+// Elapsed returns the total elapsed duration of the stopwatch, excluding the stopped periods.
+func (sw *Stopwatch) Elapsed() time.Duration {
+	sw.mu.RLock()
+	defer sw.mu.RUnlock()
+	if sw.running {
+		return sw.elapsed + time.Since(sw.start)
+	}
+	return sw.elapsed
+}
+
+// Lap records and returns the duration since last Lap call, or since the stopwatch started
+// if it is the first lap. It returns 0 and records nothing if the stopwatch is not running.
+func (sw *Stopwatch) Lap() time.Duration {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if !sw.running {
+		return 0
+	}
+	now := time.Now()
+	lap := now.Sub(sw.lapAt)
+	sw.lapAt = now
+	sw.laps = append(sw.laps, lap)
+	return lap
+}
+
+// Laps returns a copy of all the recorded laps.
+func (sw *Stopwatch) Laps() []time.Duration {
+	sw.mu.RLock()
+	defer sw.mu.RUnlock()
+	laps := make([]time.Duration, len(sw.laps))
+	copy(laps, sw.laps)
+	return laps
+}
+
+// Histogram is a simple latency histogram, which counts durations into buckets.
+// It is concurrent-safe.
+type Histogram struct {
+	mu     sync.RWMutex
+	bounds []time.Duration // Upper bounds of the buckets in ascending order.
+	counts []int64         // Count of each bucket, the last one is for durations exceeding all bounds.
+	count  int64           // Total count of observed durations.
+	sum    time.Duration   // Sum of observed durations.
+	min    time.Duration   // Min observed duration.
+	max    time.Duration   // Max observed duration.
+}
+
+// HistogramBucket is a bucket of Histogram.
+type HistogramBucket struct {
+	UpperBound time.Duration // Upper bound of the bucket, which is inclusive. It is -1 for the overflow bucket.
+	Count      int64         // Count of the durations in this bucket.
+}
+
+// defaultHistogramBounds are the default bucket bounds for latency in common service.
+var defaultHistogramBounds = []time.Duration{
+	time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	20 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// NewHistogram creates and returns a Histogram with the upper `bounds` of buckets.
+// The `bounds` are sorted automatically, and default bounds from 1ms to 10s are used if no `bounds` given.
+// There's an overflow bucket for the durations exceeding all `bounds`.
+func NewHistogram(bounds ...time.Duration) *Histogram {
+	if len(bounds) == 0 {
+		bounds = defaultHistogramBounds
+	}
+	sorted := make([]time.Duration, len(bounds))
+	copy(sorted, bounds)
+	// Insertion sort, as the bounds are usually few and almost sorted.
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && sorted[j] < sorted[j-1]; j-- {
+			sorted[j], sorted[j-1] = sorted[j-1], sorted[j]
+		}
+	}
+	return &Histogram{
+		bounds: sorted,
+		counts: make([]int64, len(sorted)+1),
+	}
+}
+
+// Observe records duration `d` into the histogram.
+func (h *Histogram) Observe(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	index := len(h.bounds)
+	for i, bound := range h.bounds {
+		if d <= bound {
+			index = i
+			break
+		}
+	}
+	h.counts[index]++
+	if h.count == 0 || d < h.min {
+		h.min = d
+	}
+	if h.count == 0 || d > h.max {
+		h.max = d
+	}
+	h.count++
+	h.sum += d
+}
+
+// ObserveSince records the duration since `start` into the histogram, and returns the duration.
+// The `start` should be retrieved from time.Now, which contains monotonic clock reading.
+func (h *Histogram) ObserveSince(start time.Time) time.Duration {
+	d := time.Since(start)
+	h.Observe(d)
+	return d
+}
+
+// ObserveFunc calls `f` and records its execution duration into the histogram.
+func (h *Histogram) ObserveFunc(f func()) time.Duration {
+	start := time.Now()
+	f()
+	return h.ObserveSince(start)
+}
+
+// Count returns the total count of observed durations.
+func (h *Histogram) Count() int64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.count
+}
+
+// Sum returns the sum of observed durations.
+func (h *Histogram) Sum() time.Duration {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.sum
+}
+
+// Min returns the min observed duration, or 0 if nothing observed.
+func (h *Histogram) Min() time.Duration {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.min
+}
+
+// Max returns the max observed duration, or 0 if nothing observed.
+func (h *Histogram) Max() time.Duration {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.max
+}
+
+// Mean returns the average of observed durations, or 0 if nothing observed.
+func (h *Histogram) Mean() time.Duration {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / time.Duration(h.count)
+}
+
+// Quantile returns the estimated duration at quantile `q`, which is in range [0, 1], eg: 0.99 for P99.
+// The estimation is the upper bound of the bucket where the quantile falls in, which is limited by the max
+// observed duration. It returns 0 if nothing observed.
+func (h *Histogram) Quantile(q float64) time.Duration {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.count == 0 {
+		return 0
+	}
+	if q <= 0 {
+		return h.min
+	}
+	var (
+		rank       = int64(q*float64(h.count) + 0.5)
+		cumulative int64
+	)
+	if rank < 1 {
+		rank = 1
+	}
+	for i, count := range h.counts {
+		cumulative += count
+		if cumulative >= rank {
+			if i < len(h.bounds) && h.bounds[i] < h.max {
+				return h.bounds[i]
+			}
+			return h.max
+		}
+	}
+	return h.max
+}
+
+// Buckets returns a copy of the buckets of the histogram.
+// The last bucket is the overflow bucket, whose UpperBound is -1.
+func (h *Histogram) Buckets() []HistogramBucket {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	buckets := make([]HistogramBucket, len(h.counts))
+	for i, count := range h.counts {
+		buckets[i].Count = count
+		if i < len(h.bounds) {
+			buckets[i].UpperBound = h.bounds[i]
+		} else {
+			buckets[i].UpperBound = -1
+		}
+	}
+	return buckets
+}
+
+// Reset clears all the observed durations of the histogram.
+func (h *Histogram) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts = make([]int64, len(h.bounds)+1)
+	h.count = 0
+	h.sum = 0
+	h.min = 0
+	h.max = 0
+}
@@ -0,0 +1,107 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtime
+
+import (
+	"time"
+)
+
+// NewFromISOWeek creates and returns a Time object of the Monday of ISO-8601 week `week`
+// in ISO-8601 year `year`, its time is set to 00:00:00.
+// The optional parameter `location` specifies the location of the result, default is time.Local.
+func NewFromISOWeek(year, week int, location ...*time.Location) *Time {
+	loc := time.Local
+	if len(location) > 0 && location[0] != nil {
+		loc = location[0]
+	}
+	// The 4th of January is always in the first ISO week of the year.
+	jan4 := NewFromTime(time.Date(year, time.January, 4, 0, 0, 0, 0, loc))
+	return jan4.StartOfISOWeek().AddDate(0, 0, (week-1)*7)
+}
+
+// ISOWeekYear returns the ISO-8601 year of current time, which may differ from
+// the calendar year in the first or last days of the year.
+func (t *Time) ISOWeekYear() int {
+	year, _ := t.ISOWeek()
+	return year
+}
+
+// ISOWeekNumber returns the ISO-8601 week number of current time, which is in range [1, 53].
+func (t *Time) ISOWeekNumber() int {
+	_, week := t.ISOWeek()
+	return week
+}
+
+// ISOWeekday returns the ISO-8601 day of week, which is in range [1, 7] starting from Monday.
+func (t *Time) ISOWeekday() int {
+	if weekday := t.Weekday(); weekday != time.Sunday {
+		return int(weekday)
+	}
+	return 7
+}
+
+// Quarter returns the quarter of the year of current time, which is in range [1, 4].
+func (t *Time) Quarter() int {
+	return (t.Month()-1)/3 + 1
+}
+
+// StartOfISOWeek clones and returns a new time which is the Monday of the ISO-8601 week
+// and its time is set to 00:00:00.
+func (t *Time) StartOfISOWeek() *Time {
+	return t.StartOfDay().AddDate(0, 0, 1-t.ISOWeekday())
+}
+
+// EndOfISOWeek clones and returns a new time which is the Sunday of the ISO-8601 week
+// and its time is set to 23:59:59.
+func (t *Time) EndOfISOWeek() *Time {
+	return t.StartOfISOWeek().AddDate(0, 0, 7).Add(-time.Nanosecond)
+}
+
+// RangeByDay iterates the days from date of `from` to date of `to`, both inclusive,
+// with readonly function `f`. The time of each iterated day is set to 00:00:00.
+// If `f` returns true, then it continues iterating; or false to stop.
+func RangeByDay(from, to *Time, f func(day *Time) bool) {
+	rangeByPeriod(from.StartOfDay(), to, f, func(t *Time) *Time {
+		return t.AddDate(0, 0, 1)
+	})
+}
+
+// RangeByWeek iterates the ISO-8601 weeks from week of `from` to week of `to`, both inclusive,
+// with readonly function `f`. Each iterated week is the Monday of the week with time 00:00:00.
+// If `f` returns true, then it continues iterating; or false to stop.
+func RangeByWeek(from, to *Time, f func(week *Time) bool) {
+	rangeByPeriod(from.StartOfISOWeek(), to, f, func(t *Time) *Time {
+		return t.AddDate(0, 0, 7)
+	})
+}
+
+// RangeByMonth iterates the months from month of `from` to month of `to`, both inclusive,
+// with readonly function `f`. Each iterated month is the first day of the month with time 00:00:00.
+// If `f` returns true, then it continues iterating; or false to stop.
+func RangeByMonth(from, to *Time, f func(month *Time) bool) {
+	rangeByPeriod(from.StartOfMonth(), to, f, func(t *Time) *Time {
+		return t.AddDate(0, 1, 0)
+	})
+}
+
+// RangeByQuarter iterates the quarters from quarter of `from` to quarter of `to`, both inclusive,
+// with readonly function `f`. Each iterated quarter is the first day of the quarter with time 00:00:00.
+// If `f` returns true, then it continues iterating; or false to stop.
+func RangeByQuarter(from, to *Time, f func(quarter *Time) bool) {
+	rangeByPeriod(from.StartOfQuarter(), to, f, func(t *Time) *Time {
+		return t.AddDate(0, 3, 0)
+	})
+}
+
+// rangeByPeriod iterates from `start` to `to` using `next` for the next period.
+func rangeByPeriod(start, to *Time, f func(t *Time) bool, next func(t *Time) *Time) {
+	for t := start; !t.After(to); t = next(t) {
+		if !f(t.Clone()) {
+			break
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Calendar_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c, err := gtime.NewCalendar()
+		t.AssertNil(err)
+		// 2022-01-07 is Friday.
+		friday := gtime.NewFromStr("2022-01-07 10:00:00")
+		t.Assert(c.IsBusinessDay(friday), true)
+		t.Assert(c.IsBusinessDay(friday.AddDate(0, 0, 1)), false)
+		t.Assert(c.IsBusinessDay(friday.AddDate(0, 0, 2)), false)
+		t.Assert(c.NextBusinessDay(friday).String(), "2022-01-10 10:00:00")
+		t.Assert(c.PrevBusinessDay(friday.AddDate(0, 0, 3)).String(), "2022-01-07 10:00:00")
+		t.Assert(c.AddBusinessDays(friday, 0).String(), "2022-01-07 10:00:00")
+		t.Assert(c.AddBusinessDays(friday, 1).String(), "2022-01-10 10:00:00")
+		t.Assert(c.AddBusinessDays(friday, 6).String(), "2022-01-17 10:00:00")
+		t.Assert(c.AddBusinessDays(friday, -5).String(), "2021-12-31 10:00:00")
+		t.Assert(c.BusinessDaysBetween(friday, friday.AddDate(0, 0, 7)), 5)
+		t.Assert(c.BusinessDaysBetween(friday.AddDate(0, 0, 7), friday), -5)
+	})
+}
+
+func Test_Calendar_Holidays(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c, err := gtime.NewCalendar(gtime.CalendarConfig{
+			Holidays: []string{"01-01", "2022-10-03", "2022-10-04"},
+			Workdays: []string{"2022-10-08"},
+		})
+		t.AssertNil(err)
+		t.Assert(c.IsHoliday(gtime.NewFromStr("2023-01-01")), true)
+		t.Assert(c.IsHoliday(gtime.NewFromStr("2022-10-03")), true)
+		t.Assert(c.IsHoliday(gtime.NewFromStr("2023-10-03")), false)
+		// 2022-09-30 is Friday, 2022-10-08 is Saturday.
+		t.Assert(c.NextBusinessDay(gtime.NewFromStr("2022-09-30")).String(), "2022-10-05 00:00:00")
+		t.Assert(c.IsBusinessDay(gtime.NewFromStr("2022-10-08")), true)
+		t.Assert(c.AddBusinessDays(gtime.NewFromStr("2022-10-06"), 2).String(), "2022-10-08 00:00:00")
+
+		t.AssertNil(c.AddHolidays("2022-10-05"))
+		t.Assert(c.NextBusinessDay(gtime.NewFromStr("2022-09-30")).String(), "2022-10-06 00:00:00")
+		t.AssertNil(c.AddWorkdays("2022-10-02"))
+		t.Assert(c.NextBusinessDay(gtime.NewFromStr("2022-09-30")).String(), "2022-10-02 00:00:00")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gtime.NewCalendar(gtime.CalendarConfig{
+			Holidays: []string{"2022/10/01"},
+		})
+		t.AssertNE(err, nil)
+		_, err = gtime.NewCalendar(gtime.CalendarConfig{
+			Workdays: []string{"10-01"},
+		})
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Calendar_Weekends(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c, err := gtime.NewCalendar()
+		t.AssertNil(err)
+		t.AssertNil(c.SetWeekends(time.Friday, time.Saturday))
+		t.Assert(c.IsBusinessDay(gtime.NewFromStr("2022-01-09")), true)
+		t.Assert(c.IsBusinessDay(gtime.NewFromStr("2022-01-07")), false)
+		t.AssertNE(c.SetWeekends(
+			time.Sunday, time.Monday, time.Tuesday, time.Wednesday,
+			time.Thursday, time.Friday, time.Saturday,
+		), nil)
+		t.AssertNE(c.SetWeekends(time.Weekday(7)), nil)
+	})
+}
+
+func Test_Calendar_Config(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			config  gtime.CalendarConfig
+			content = `{"weekends": [0], "holidays": ["12-25"], "workdays": ["2022-12-18"]}`
+		)
+		t.AssertNil(gjson.New(content).Scan(&config))
+		c, err := gtime.NewCalendar(config)
+		t.AssertNil(err)
+		t.Assert(c.IsBusinessDay(gtime.NewFromStr("2022-12-17")), true)
+		t.Assert(c.IsBusinessDay(gtime.NewFromStr("2022-12-18")), true)
+		t.Assert(c.IsBusinessDay(gtime.NewFromStr("2022-12-25")), false)
+		t.Assert(c.IsBusinessDay(gtime.NewFromStr("2023-12-25")), false)
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_ISOWeek(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		// 2021-01-01 is Friday, which is in the 53rd week of 2020.
+		t1 := gtime.NewFromStr("2021-01-01 10:00:00")
+		t.Assert(t1.ISOWeekYear(), 2020)
+		t.Assert(t1.ISOWeekNumber(), 53)
+		t.Assert(t1.ISOWeekday(), 5)
+		t.Assert(t1.StartOfISOWeek().String(), "2020-12-28 00:00:00")
+		t.Assert(t1.EndOfISOWeek().String(), "2021-01-03 23:59:59")
+		t.Assert(gtime.NewFromStr("2021-01-03").ISOWeekday(), 7)
+		t.Assert(gtime.NewFromStr("2019-12-30").ISOWeekYear(), 2020)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gtime.NewFromISOWeek(2020, 53).String(), "2020-12-28 00:00:00")
+		t.Assert(gtime.NewFromISOWeek(2020, 1).String(), "2019-12-30 00:00:00")
+		t.Assert(gtime.NewFromISOWeek(2022, 10, time.UTC).String(), "2022-03-07 00:00:00")
+		t.Assert(gtime.NewFromISOWeek(2022, 10, time.UTC).Location(), time.UTC)
+	})
+}
+
+func Test_Quarter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gtime.NewFromStr("2022-01-01").Quarter(), 1)
+		t.Assert(gtime.NewFromStr("2022-03-31").Quarter(), 1)
+		t.Assert(gtime.NewFromStr("2022-04-01").Quarter(), 2)
+		t.Assert(gtime.NewFromStr("2022-09-30").Quarter(), 3)
+		t.Assert(gtime.NewFromStr("2022-12-31").Quarter(), 4)
+	})
+}
+
+func Test_RangeByDay(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var days []string
+		gtime.RangeByDay(
+			gtime.NewFromStr("2022-02-27 10:00:00"),
+			gtime.NewFromStr("2022-03-02 08:00:00"),
+			func(day *gtime.Time) bool {
+				days = append(days, day.Format("Y-m-d"))
+				return true
+			},
+		)
+		t.Assert(days, []string{"2022-02-27", "2022-02-28", "2022-03-01", "2022-03-02"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var days []string
+		gtime.RangeByDay(
+			gtime.NewFromStr("2022-02-27"),
+			gtime.NewFromStr("2022-03-02"),
+			func(day *gtime.Time) bool {
+				days = append(days, day.Format("Y-m-d"))
+				return len(days) < 2
+			},
+		)
+		t.Assert(days, []string{"2022-02-27", "2022-02-28"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		count := 0
+		gtime.RangeByDay(gtime.NewFromStr("2022-03-02"), gtime.NewFromStr("2022-03-01"), func(day *gtime.Time) bool {
+			count++
+			return true
+		})
+		t.Assert(count, 0)
+	})
+}
+
+func Test_RangeByPeriod(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var weeks []string
+		gtime.RangeByWeek(
+			gtime.NewFromStr("2022-01-01"),
+			gtime.NewFromStr("2022-01-10"),
+			func(week *gtime.Time) bool {
+				weeks = append(weeks, week.Format("Y-m-d"))
+				return true
+			},
+		)
+		t.Assert(weeks, []string{"2021-12-27", "2022-01-03", "2022-01-10"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var months []string
+		gtime.RangeByMonth(
+			gtime.NewFromStr("2021-11-30"),
+			gtime.NewFromStr("2022-02-01"),
+			func(month *gtime.Time) bool {
+				months = append(months, month.Format("Y-m"))
+				return true
+			},
+		)
+		t.Assert(months, []string{"2021-11", "2021-12", "2022-01", "2022-02"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var quarters []string
+		gtime.RangeByQuarter(
+			gtime.NewFromStr("2021-08-15"),
+			gtime.NewFromStr("2022-04-01"),
+			func(quarter *gtime.Time) bool {
+				quarters = append(quarters, quarter.Format("Y-m-d"))
+				return true
+			},
+		)
+		t.Assert(quarters, []string{"2021-07-01", "2021-10-01", "2022-01-01", "2022-04-01"})
+	})
+}
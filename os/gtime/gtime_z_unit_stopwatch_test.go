@@ -0,0 +1,88 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Stopwatch(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		sw := gtime.NewStopwatch()
+		t.Assert(sw.IsRunning(), true)
+		time.Sleep(20 * time.Millisecond)
+		lap1 := sw.Lap()
+		time.Sleep(20 * time.Millisecond)
+		lap2 := sw.Lap()
+		t.AssertGE(int64(lap1), int64(20*time.Millisecond))
+		t.AssertGE(int64(lap2), int64(20*time.Millisecond))
+		t.Assert(sw.Laps(), []time.Duration{lap1, lap2})
+
+		elapsed := sw.Stop()
+		t.Assert(sw.IsRunning(), false)
+		t.AssertGE(int64(elapsed), int64(lap1+lap2))
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(sw.Elapsed(), elapsed)
+		t.Assert(sw.Lap(), time.Duration(0))
+		t.Assert(len(sw.Laps()), 2)
+
+		sw.Start()
+		time.Sleep(10 * time.Millisecond)
+		t.AssertGE(int64(sw.Elapsed()), int64(elapsed+10*time.Millisecond))
+		t.AssertLT(int64(sw.Elapsed()), int64(elapsed+100*time.Millisecond))
+
+		sw.Restart()
+		t.Assert(sw.IsRunning(), true)
+		t.Assert(len(sw.Laps()), 0)
+		t.AssertLT(int64(sw.Elapsed()), int64(10*time.Millisecond))
+	})
+}
+
+func Test_Histogram(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		h := gtime.NewHistogram(100*time.Millisecond, 10*time.Millisecond, 50*time.Millisecond)
+		t.Assert(h.Quantile(0.5), time.Duration(0))
+		t.Assert(h.Mean(), time.Duration(0))
+		for i := 1; i <= 100; i++ {
+			h.Observe(time.Duration(i) * time.Millisecond)
+		}
+		h.Observe(time.Second)
+		t.Assert(h.Count(), 101)
+		t.Assert(h.Min(), time.Millisecond)
+		t.Assert(h.Max(), time.Second)
+		t.Assert(h.Sum(), 5050*time.Millisecond+time.Second)
+		t.Assert(h.Mean(), (5050*time.Millisecond+time.Second)/101)
+		t.Assert(h.Buckets(), []gtime.HistogramBucket{
+			{UpperBound: 10 * time.Millisecond, Count: 10},
+			{UpperBound: 50 * time.Millisecond, Count: 40},
+			{UpperBound: 100 * time.Millisecond, Count: 50},
+			{UpperBound: -1, Count: 1},
+		})
+		t.Assert(h.Quantile(0), time.Millisecond)
+		t.Assert(h.Quantile(0.05), 10*time.Millisecond)
+		t.Assert(h.Quantile(0.5), 100*time.Millisecond)
+		t.Assert(h.Quantile(1), time.Second)
+
+		h.Reset()
+		t.Assert(h.Count(), 0)
+		t.Assert(len(h.Buckets()), 4)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		h := gtime.NewHistogram()
+		d := h.ObserveFunc(func() {
+			time.Sleep(10 * time.Millisecond)
+		})
+		t.AssertGE(int64(d), int64(10*time.Millisecond))
+		t.Assert(h.Count(), 1)
+		t.Assert(h.Quantile(0.99), d)
+		t.Assert(len(h.Buckets()), 14)
+	})
+}
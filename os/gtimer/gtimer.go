@@ -33,10 +33,12 @@ import (
 // Timer is the timer manager, which uses ticks to calculate the timing interval.
 type Timer struct {
 	mu      sync.RWMutex
-	queue   *priorityQueue // queue is a priority queue based on heap structure.
-	status  *gtype.Int     // status is the current timer status.
-	ticks   *gtype.Int64   // ticks is the proceeded interval number by the timer.
-	options TimerOptions   // timer options is used for timer configuration.
+	queue   *priorityQueue      // queue is a priority queue based on heap structure.
+	status  *gtype.Int          // status is the current timer status.
+	ticks   *gtype.Int64        // ticks is the proceeded interval number by the timer.
+	options TimerOptions        // timer options is used for timer configuration.
+	entries map[*Entry]struct{} // entries is all the entries that are not removed from the timer.
+	names   map[string]*Entry   // names is the entries with names, which is map[name]*Entry.
 }
 
 // TimerOptions is the configuration object for Timer.
@@ -98,6 +100,46 @@ func Add(ctx context.Context, interval time.Duration, job JobFunc) *Entry {
 	return defaultTimer.Add(ctx, interval, job)
 }
 
+// AddNamed adds a timing job with unique `name` to the default timer, which runs in interval of `interval`.
+// It returns error if the `name` is already used.
+func AddNamed(ctx context.Context, name string, interval time.Duration, job JobFunc) (*Entry, error) {
+	return defaultTimer.AddNamed(ctx, name, interval, job)
+}
+
+// AddNamedEntry adds a timing job with unique `name` to the default timer with detailed parameters.
+// It returns error if the `name` is already used.
+// Also see AddEntry.
+func AddNamedEntry(ctx context.Context, name string, interval time.Duration, job JobFunc, isSingleton bool, times int, status int) (*Entry, error) {
+	return defaultTimer.AddNamedEntry(ctx, name, interval, job, isSingleton, times, status)
+}
+
+// Search returns the job of the default timer with given `name`, or nil if not found.
+func Search(name string) *Entry {
+	return defaultTimer.Search(name)
+}
+
+// Entries returns all the jobs of the default timer that are not closed, ordered by their next running time.
+func Entries() []*Entry {
+	return defaultTimer.Entries()
+}
+
+// Remove closes and removes the job of the default timer with given `name`.
+func Remove(name string) {
+	defaultTimer.Remove(name)
+}
+
+// Start starts the jobs of the default timer with given `name`.
+// If no `name` specified, it starts the default timer.
+func Start(name ...string) {
+	defaultTimer.Start(name...)
+}
+
+// Stop stops the jobs of the default timer with given `name`.
+// If no `name` specified, it stops the default timer.
+func Stop(name ...string) {
+	defaultTimer.Stop(name...)
+}
+
 // AddEntry adds a timing job to the default timer with detailed parameters.
 //
 // The parameter `interval` specifies the running interval of the job.
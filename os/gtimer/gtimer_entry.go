@@ -8,6 +8,7 @@ package gtimer
 
 import (
 	"context"
+	"time"
 
 	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -17,6 +18,7 @@ import (
 type Entry struct {
 	job         JobFunc         // The job function.
 	ctx         context.Context // The context for the job, for READ ONLY.
+	name        string          // Unique name of the job, which is empty if it is not named.
 	timer       *Timer          // Belonged timer.
 	ticks       int64           // The job runs every tick.
 	times       *gtype.Int      // Limit running times.
@@ -24,6 +26,7 @@ type Entry struct {
 	isSingleton *gtype.Bool     // Singleton mode.
 	nextTicks   *gtype.Int64    // Next run ticks of the job.
 	infinite    *gtype.Bool     // No times limit.
+	runCount    *gtype.Int64    // Count of runs of the job.
 }
 
 // JobFunc is the timing called job function in timer.
@@ -44,6 +47,7 @@ func (entry *Entry) Run() {
 			return
 		}
 	}
+	entry.runCount.Add(1)
 	go func() {
 		defer func() {
 			if exception := recover(); exception != nil {
@@ -129,6 +133,30 @@ func (entry *Entry) SetSingleton(enabled bool) {
 	entry.isSingleton.Set(enabled)
 }
 
+// Name returns the name of the job, which is empty if it is not named.
+func (entry *Entry) Name() string {
+	return entry.name
+}
+
+// Interval returns the running interval of the job, which is aligned to the interval of its timer.
+func (entry *Entry) Interval() time.Duration {
+	return time.Duration(entry.ticks) * entry.timer.options.Interval
+}
+
+// NextRunTime returns the estimated time of the next running of the job.
+func (entry *Entry) NextRunTime() time.Time {
+	leftTicks := entry.nextTicks.Val() - entry.timer.ticks.Val()
+	if leftTicks < 0 {
+		leftTicks = 0
+	}
+	return time.Now().Add(time.Duration(leftTicks) * entry.timer.options.Interval)
+}
+
+// RunCount returns the count of runs of the job.
+func (entry *Entry) RunCount() int64 {
+	return entry.runCount.Val()
+}
+
 // Job returns the job function of this job.
 func (entry *Entry) Job() JobFunc {
 	return entry.job
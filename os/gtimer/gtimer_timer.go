@@ -11,13 +11,17 @@ import (
 	"time"
 
 	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 )
 
 func New(options ...TimerOptions) *Timer {
 	t := &Timer{
-		queue:  newPriorityQueue(),
-		status: gtype.NewInt(StatusRunning),
-		ticks:  gtype.NewInt64(),
+		queue:   newPriorityQueue(),
+		status:  gtype.NewInt(StatusRunning),
+		ticks:   gtype.NewInt64(),
+		entries: make(map[*Entry]struct{}),
+		names:   make(map[string]*Entry),
 	}
 	if len(options) > 0 {
 		t.options = options[0]
@@ -30,7 +34,7 @@ func New(options ...TimerOptions) *Timer {
 
 // Add adds a timing job to the timer, which runs in interval of `interval`.
 func (t *Timer) Add(ctx context.Context, interval time.Duration, job JobFunc) *Entry {
-	return t.createEntry(createEntryInput{
+	entry, _ := t.createEntry(createEntryInput{
 		Ctx:         ctx,
 		Interval:    interval,
 		Job:         job,
@@ -38,6 +42,32 @@ func (t *Timer) Add(ctx context.Context, interval time.Duration, job JobFunc) *E
 		Times:       -1,
 		Status:      StatusReady,
 	})
+	return entry
+}
+
+// AddNamed adds a timing job with unique `name` to the timer, which runs in interval of `interval`.
+// The `name` is used for searching and managing the job, like Search, Start, Stop and Remove.
+// It returns error if the `name` is already used.
+func (t *Timer) AddNamed(ctx context.Context, name string, interval time.Duration, job JobFunc) (*Entry, error) {
+	return t.AddNamedEntry(ctx, name, interval, job, false, -1, StatusReady)
+}
+
+// AddNamedEntry adds a timing job with unique `name` to the timer with detailed parameters.
+// It returns error if the `name` is already used.
+// Also see AddEntry.
+func (t *Timer) AddNamedEntry(ctx context.Context, name string, interval time.Duration, job JobFunc, isSingleton bool, times int, status int) (*Entry, error) {
+	if name == "" {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `timer job name should not be empty`)
+	}
+	return t.createEntry(createEntryInput{
+		Ctx:         ctx,
+		Name:        name,
+		Interval:    interval,
+		Job:         job,
+		IsSingleton: isSingleton,
+		Times:       times,
+		Status:      status,
+	})
 }
 
 // AddEntry adds a timing job to the timer with detailed parameters.
@@ -52,7 +82,7 @@ func (t *Timer) Add(ctx context.Context, interval time.Duration, job JobFunc) *E
 //
 // The parameter `status` specifies the job status when it's firstly added to the timer.
 func (t *Timer) AddEntry(ctx context.Context, interval time.Duration, job JobFunc, isSingleton bool, times int, status int) *Entry {
-	return t.createEntry(createEntryInput{
+	entry, _ := t.createEntry(createEntryInput{
 		Ctx:         ctx,
 		Interval:    interval,
 		Job:         job,
@@ -60,11 +90,12 @@ func (t *Timer) AddEntry(ctx context.Context, interval time.Duration, job JobFun
 		Times:       times,
 		Status:      status,
 	})
+	return entry
 }
 
 // AddSingleton is a convenience function for add singleton mode job.
 func (t *Timer) AddSingleton(ctx context.Context, interval time.Duration, job JobFunc) *Entry {
-	return t.createEntry(createEntryInput{
+	entry, _ := t.createEntry(createEntryInput{
 		Ctx:         ctx,
 		Interval:    interval,
 		Job:         job,
@@ -72,11 +103,12 @@ func (t *Timer) AddSingleton(ctx context.Context, interval time.Duration, job Jo
 		Times:       -1,
 		Status:      StatusReady,
 	})
+	return entry
 }
 
 // AddOnce is a convenience function for adding a job which only runs once and then exits.
 func (t *Timer) AddOnce(ctx context.Context, interval time.Duration, job JobFunc) *Entry {
-	return t.createEntry(createEntryInput{
+	entry, _ := t.createEntry(createEntryInput{
 		Ctx:         ctx,
 		Interval:    interval,
 		Job:         job,
@@ -84,11 +116,12 @@ func (t *Timer) AddOnce(ctx context.Context, interval time.Duration, job JobFunc
 		Times:       1,
 		Status:      StatusReady,
 	})
+	return entry
 }
 
 // AddTimes is a convenience function for adding a job which is limited running times.
 func (t *Timer) AddTimes(ctx context.Context, interval time.Duration, times int, job JobFunc) *Entry {
-	return t.createEntry(createEntryInput{
+	entry, _ := t.createEntry(createEntryInput{
 		Ctx:         ctx,
 		Interval:    interval,
 		Job:         job,
@@ -96,6 +129,7 @@ func (t *Timer) AddTimes(ctx context.Context, interval time.Duration, times int,
 		Times:       times,
 		Status:      StatusReady,
 	})
+	return entry
 }
 
 // DelayAdd adds a timing job after delay of `interval` duration.
@@ -138,14 +172,32 @@ func (t *Timer) DelayAddTimes(ctx context.Context, delay time.Duration, interval
 	})
 }
 
-// Start starts the timer.
-func (t *Timer) Start() {
-	t.status.Set(StatusRunning)
+// Start starts the jobs with given `name`.
+// If no `name` specified, it starts the timer.
+func (t *Timer) Start(name ...string) {
+	if len(name) == 0 {
+		t.status.Set(StatusRunning)
+		return
+	}
+	for _, v := range name {
+		if entry := t.Search(v); entry != nil {
+			entry.Start()
+		}
+	}
 }
 
-// Stop stops the timer.
-func (t *Timer) Stop() {
-	t.status.Set(StatusStopped)
+// Stop stops the jobs with given `name`, the stopped jobs can be started again using Start.
+// If no `name` specified, it stops the timer.
+func (t *Timer) Stop(name ...string) {
+	if len(name) == 0 {
+		t.status.Set(StatusStopped)
+		return
+	}
+	for _, v := range name {
+		if entry := t.Search(v); entry != nil {
+			entry.Stop()
+		}
+	}
 }
 
 // Close closes the timer.
@@ -155,6 +207,7 @@ func (t *Timer) Close() {
 
 type createEntryInput struct {
 	Ctx         context.Context
+	Name        string
 	Interval    time.Duration
 	Job         JobFunc
 	IsSingleton bool
@@ -163,7 +216,8 @@ type createEntryInput struct {
 }
 
 // createEntry creates and adds a timing job to the timer.
-func (t *Timer) createEntry(in createEntryInput) *Entry {
+// It returns error if the name of the job is already used.
+func (t *Timer) createEntry(in createEntryInput) (*Entry, error) {
 	var (
 		infinite = false
 	)
@@ -183,6 +237,7 @@ func (t *Timer) createEntry(in createEntryInput) *Entry {
 		entry     = &Entry{
 			job:         in.Job,
 			ctx:         in.Ctx,
+			name:        in.Name,
 			timer:       t,
 			ticks:       intervalTicksOfJob,
 			times:       gtype.NewInt(in.Times),
@@ -190,8 +245,12 @@ func (t *Timer) createEntry(in createEntryInput) *Entry {
 			isSingleton: gtype.NewBool(in.IsSingleton),
 			nextTicks:   gtype.NewInt64(nextTicks),
 			infinite:    gtype.NewBool(infinite),
+			runCount:    gtype.NewInt64(),
 		}
 	)
+	if err := t.addToEntries(entry); err != nil {
+		return nil, err
+	}
 	t.queue.Push(entry, nextTicks)
-	return entry
+	return entry, nil
 }
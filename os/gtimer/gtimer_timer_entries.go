@@ -0,0 +1,80 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtimer
+
+import (
+	"sort"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Search returns the job with given `name`, or nil if not found or it is closed.
+func (t *Timer) Search(name string) *Entry {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if entry, ok := t.names[name]; ok && entry.Status() != StatusClosed {
+		return entry
+	}
+	return nil
+}
+
+// Entries returns all the jobs that are not closed, ordered by their next running time.
+// It is usually used for introspection of the background jobs, along with the job information methods
+// like Entry.Name, Entry.Interval, Entry.NextRunTime and Entry.RunCount.
+func (t *Timer) Entries() []*Entry {
+	t.mu.RLock()
+	entries := make([]*Entry, 0, len(t.entries))
+	for entry := range t.entries {
+		if entry.Status() != StatusClosed {
+			entries = append(entries, entry)
+		}
+	}
+	t.mu.RUnlock()
+	sort.Slice(entries, func(i, j int) bool {
+		if ticksI, ticksJ := entries[i].nextTicks.Val(), entries[j].nextTicks.Val(); ticksI != ticksJ {
+			return ticksI < ticksJ
+		}
+		return entries[i].name < entries[j].name
+	})
+	return entries
+}
+
+// Remove closes and removes the job with given `name`, so that the `name` can be used again.
+func (t *Timer) Remove(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if entry, ok := t.names[name]; ok {
+		entry.Close()
+		delete(t.names, name)
+	}
+}
+
+// addToEntries adds `entry` to the entries of the timer.
+// It returns error if the name of `entry` is already used by another job that is not closed.
+func (t *Timer) addToEntries(entry *Entry) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if entry.name != "" {
+		if v, ok := t.names[entry.name]; ok && v.Status() != StatusClosed {
+			return gerror.NewCodef(gcode.CodeInvalidOperation, `timer job "%s" already exists`, entry.name)
+		}
+		t.names[entry.name] = entry
+	}
+	t.entries[entry] = struct{}{}
+	return nil
+}
+
+// removeFromEntries removes the closed `entry` from the entries of the timer.
+func (t *Timer) removeFromEntries(entry *Entry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, entry)
+	if entry.name != "" && t.names[entry.name] == entry {
+		delete(t.names, entry.name)
+	}
+}
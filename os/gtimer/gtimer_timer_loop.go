@@ -62,6 +62,8 @@ func (t *Timer) proceed(currentTimerTicks int64) {
 		if entry.Status() != StatusClosed {
 			// It pushes the job back to queue for next running.
 			t.queue.Push(entry, entry.nextTicks.Val())
+		} else {
+			t.removeFromEntries(entry)
 		}
 	}
 }
@@ -266,3 +266,59 @@ func TestTimer_Exit(t *testing.T) {
 		t.Assert(array.Len(), 1)
 	})
 }
+
+func TestTimer_Named(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		timer := gtimer.New()
+		defer timer.Close()
+		array := garray.New(true)
+		entry1, err := timer.AddNamed(ctx, "job1", 200*time.Millisecond, func(ctx context.Context) {
+			array.Append(1)
+		})
+		t.AssertNil(err)
+		t.Assert(entry1.Name(), "job1")
+		t.Assert(entry1.Interval(), 200*time.Millisecond)
+		_, err = timer.AddNamed(ctx, "job1", time.Second, func(ctx context.Context) {})
+		t.AssertNE(err, nil)
+		_, err = timer.AddNamed(ctx, "", time.Second, func(ctx context.Context) {})
+		t.AssertNE(err, nil)
+
+		entry2, err := timer.AddNamedEntry(ctx, "job2", time.Minute, func(ctx context.Context) {}, true, -1, gtimer.StatusReady)
+		t.AssertNil(err)
+		entry3 := timer.Add(ctx, time.Second, func(ctx context.Context) {})
+
+		// Introspection.
+		t.Assert(timer.Search("job1") == entry1, true)
+		t.Assert(timer.Search("job3"), nil)
+		entries := timer.Entries()
+		t.Assert(len(entries), 3)
+		t.Assert(entries[0] == entry1, true)
+		t.Assert(entries[1] == entry3, true)
+		t.Assert(entries[2] == entry2, true)
+		t.Assert(entry2.NextRunTime().After(time.Now().Add(50*time.Second)), true)
+
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(entry1.RunCount(), 2)
+		t.Assert(array.Len(), 2)
+
+		// Pause and resume by name.
+		timer.Stop("job1")
+		t.Assert(entry1.Status(), gtimer.StatusStopped)
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(array.Len(), 2)
+		timer.Start("job1")
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(array.Len() > 2, true)
+
+		// Removing and reusing the name.
+		timer.Remove("job1")
+		t.Assert(timer.Search("job1"), nil)
+		t.Assert(len(timer.Entries()), 2)
+		_, err = timer.AddNamed(ctx, "job1", time.Second, func(ctx context.Context) {})
+		t.AssertNil(err)
+
+		entry2.Close()
+		t.Assert(timer.Search("job2"), nil)
+		t.Assert(len(timer.Entries()), 2)
+	})
+}
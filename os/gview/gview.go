@@ -8,6 +8,9 @@
 //
 // Reserved template variable names:
 //     I18nLanguage: Assign this variable to define i18n language for each page.
+//
+// The i18n build-in functions T, Tn, number, localdate and localdatetime use the language
+// of variable I18nLanguage or the language of the parsing context.
 package gview
 
 import (
@@ -24,11 +27,12 @@ import (
 
 // View object for template engine.
 type View struct {
-	searchPaths  *garray.StrArray       // Searching array for path, NOT concurrent-safe for performance purpose.
-	data         map[string]interface{} // Global template variables.
-	funcMap      map[string]interface{} // Global template function map.
-	fileCacheMap *gmap.StrAnyMap        // File cache map.
-	config       Config                 // Extra configuration for the view.
+	searchPaths   *garray.StrArray       // Searching array for path, NOT concurrent-safe for performance purpose.
+	data          map[string]interface{} // Global template variables.
+	funcMap       map[string]interface{} // Global template function map.
+	fileCacheMap  *gmap.StrAnyMap        // File cache map.
+	config        Config                 // Extra configuration for the view.
+	i18nFuncNames map[string]struct{}    // Names of i18n build-in functions that are bound to the language of each parsing.
 }
 
 type (
@@ -156,5 +160,7 @@ func New(path ...string) *View {
 		"times":      view.buildInFuncTimes,
 		"divide":     view.buildInFuncDivide,
 	})
+	// i18n build-in functions.
+	view.bindI18nFuncMap()
 	return view
 }
@@ -245,6 +245,7 @@ func (view *View) SetAutoEncode(enable bool) {
 // The `name` is the function name which can be called in template content.
 func (view *View) BindFunc(name string, function interface{}) {
 	view.funcMap[name] = function
+	delete(view.i18nFuncNames, name)
 	// Clear global template object cache.
 	templates.Clear()
 }
@@ -255,6 +256,7 @@ func (view *View) BindFunc(name string, function interface{}) {
 func (view *View) BindFuncMap(funcMap FuncMap) {
 	for k, v := range funcMap {
 		view.funcMap[k] = v
+		delete(view.i18nFuncNames, k)
 	}
 	// Clear global template object cache.
 	templates.Clear()
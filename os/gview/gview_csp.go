@@ -0,0 +1,48 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gview
+
+import (
+	"context"
+)
+
+const (
+	// cspNonceVariableName is the template variable name of the CSP nonce,
+	// which is used like: <script nonce="{{.CspNonce}}">.
+	cspNonceVariableName = "CspNonce"
+	ctxCspNonce          = "GViewCspNonce"
+)
+
+// WithCspNonce sets the Content-Security-Policy nonce to the context and returns a new context.
+// The nonce is assigned to the template variable "CspNonce" automatically when parsing with the context.
+func WithCspNonce(ctx context.Context, nonce string) context.Context {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	return context.WithValue(ctx, ctxCspNonce, nonce)
+}
+
+// CspNonceFromCtx retrieves and returns the Content-Security-Policy nonce from context.
+// It returns an empty string if it is not set previously.
+func CspNonceFromCtx(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if v, ok := ctx.Value(ctxCspNonce).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// setCspNonceFromCtx retrieves the CSP nonce from context and sets it to template variables map.
+func (view *View) setCspNonceFromCtx(ctx context.Context, variables map[string]interface{}) {
+	if _, ok := variables[cspNonceVariableName]; !ok {
+		if nonce := CspNonceFromCtx(ctx); nonce != "" {
+			variables[cspNonceVariableName] = nonce
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 
 	"github.com/gogf/gf/v2/i18n/gi18n"
+	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gogf/gf/v2/util/gconv"
 )
 
@@ -20,15 +21,130 @@ const (
 // i18nTranslate translate the content with i18n feature.
 func (view *View) i18nTranslate(ctx context.Context, content string, variables Params) string {
 	if view.config.I18nManager != nil {
-		// Compatible with old version.
-		if language, ok := variables[i18nLanguageVariableName]; ok {
-			ctx = gi18n.WithLanguage(ctx, gconv.String(language))
-		}
-		return view.config.I18nManager.T(ctx, content)
+		return view.config.I18nManager.T(view.i18nCtx(ctx, variables), content)
 	}
 	return content
 }
 
+// i18nCtx returns the context carrying the i18n language of current parsing.
+// The template variable "I18nLanguage" has priority over the language of `ctx`,
+// which is compatible with old version.
+func (view *View) i18nCtx(ctx context.Context, variables Params) context.Context {
+	if language, ok := variables[i18nLanguageVariableName]; ok {
+		return gi18n.WithLanguage(ctx, gconv.String(language))
+	}
+	return ctx
+}
+
+// i18nLanguage returns the i18n language of `ctx`, or the default language of the i18n manager.
+func (view *View) i18nLanguage(ctx context.Context) string {
+	if language := gi18n.LanguageFromCtx(ctx); language != "" {
+		return language
+	}
+	if view.config.I18nManager != nil {
+		return view.config.I18nManager.GetLanguage(ctx)
+	}
+	return ""
+}
+
+// bindI18nFuncMap binds the i18n build-in functions, which are rebound to the language of
+// each parsing by i18nFuncMap. The function rebound by BindFunc or BindFuncMap is kept as it is.
+func (view *View) bindI18nFuncMap() {
+	funcMap := view.i18nFuncMap(context.TODO())
+	view.BindFuncMap(funcMap)
+	view.i18nFuncNames = make(map[string]struct{}, len(funcMap))
+	for name := range funcMap {
+		view.i18nFuncNames[name] = struct{}{}
+	}
+}
+
+// i18nFuncMap returns the i18n build-in functions using the language of `ctx`.
+func (view *View) i18nFuncMap(ctx context.Context) FuncMap {
+	funcMap := FuncMap{
+		"T": func(key interface{}, values ...interface{}) string {
+			return view.i18nFuncT(ctx, key, values...)
+		},
+		"Tn": func(key interface{}, count interface{}, params ...map[string]interface{}) string {
+			return view.i18nFuncTn(ctx, key, count, params...)
+		},
+		"number": func(value interface{}, precision ...interface{}) string {
+			return view.i18nFuncNumber(ctx, value, precision...)
+		},
+		"localdate": func(value interface{}) string {
+			return view.i18nFuncDate(ctx, value, false)
+		},
+		"localdatetime": func(value interface{}) string {
+			return view.i18nFuncDate(ctx, value, true)
+		},
+	}
+	// Filter the functions rebound by user.
+	if view.i18nFuncNames != nil {
+		for name := range funcMap {
+			if _, ok := view.i18nFuncNames[name]; !ok {
+				delete(funcMap, name)
+			}
+		}
+	}
+	return funcMap
+}
+
+// i18nFuncT implements i18n build-in template function: T
+// It translates `key` with named parameters if `values` is a single map, like: {{T "hello" .Params}},
+// or else it translates `key` as format with `values`, like: {{T "hello %s" .Name}}.
+func (view *View) i18nFuncT(ctx context.Context, key interface{}, values ...interface{}) string {
+	var (
+		content = gconv.String(key)
+		manager = view.config.I18nManager
+	)
+	if manager == nil {
+		return content
+	}
+	if len(values) == 1 {
+		if params, ok := values[0].(map[string]interface{}); ok {
+			return manager.TranslateParams(ctx, content, params)
+		}
+	}
+	if len(values) > 0 {
+		return manager.TranslateFormat(ctx, content, values...)
+	}
+	return manager.Translate(ctx, content)
+}
+
+// i18nFuncTn implements i18n build-in template function: Tn
+// It translates `key` with the plural form for `count`, like: {{Tn "apple" .Count}}.
+func (view *View) i18nFuncTn(ctx context.Context, key interface{}, count interface{}, params ...map[string]interface{}) string {
+	if view.config.I18nManager == nil {
+		return gconv.String(key)
+	}
+	return view.config.I18nManager.TranslatePlural(ctx, gconv.String(key), count, params...)
+}
+
+// i18nFuncNumber implements i18n build-in template function: number
+// It formats number `value` with the separators of the language, like: {{number .Amount 2}}.
+func (view *View) i18nFuncNumber(ctx context.Context, value interface{}, precision ...interface{}) string {
+	if len(precision) > 0 {
+		return gi18n.FormatNumber(view.i18nLanguage(ctx), value, gconv.Int(precision[0]))
+	}
+	return gi18n.FormatNumber(view.i18nLanguage(ctx), value)
+}
+
+// i18nFuncDate implements i18n build-in template functions: localdate and localdatetime
+// It formats time `value` with the date or datetime layout of the language, like: {{localdate .Time}}.
+func (view *View) i18nFuncDate(ctx context.Context, value interface{}, withTime bool) string {
+	if value == nil {
+		return ""
+	}
+	t := gtime.New(value)
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	locale := gi18n.GetLocale(view.i18nLanguage(ctx))
+	if withTime {
+		return t.Format(locale.DatetimeLayout)
+	}
+	return t.Format(locale.DateLayout)
+}
+
 // setI18nLanguageFromCtx retrieves language name from context and sets it to template variables map.
 func (view *View) setI18nLanguageFromCtx(ctx context.Context, variables map[string]interface{}) {
 	if language, ok := variables[i18nLanguageVariableName]; !ok {
@@ -190,18 +190,27 @@ func (view *View) ParseOption(ctx context.Context, option Option) (result string
 		gutil.MapMerge(variables, view.data)
 	}
 	view.setI18nLanguageFromCtx(ctx, variables)
+	view.setCspNonceFromCtx(ctx, variables)
 
-	buffer := bytes.NewBuffer(nil)
+	// The i18n functions are bound to the language of current parsing on the cloned template.
+	var (
+		buffer  = bytes.NewBuffer(nil)
+		funcMap = view.i18nFuncMap(view.i18nCtx(ctx, variables))
+	)
 	if view.config.AutoEncode {
 		newTpl, err := tpl.(*htmltpl.Template).Clone()
 		if err != nil {
 			return "", err
 		}
-		if err = newTpl.Execute(buffer, variables); err != nil {
+		if err = newTpl.Funcs(funcMap).Execute(buffer, variables); err != nil {
 			return "", err
 		}
 	} else {
-		if err = tpl.(*texttpl.Template).Execute(buffer, variables); err != nil {
+		newTpl, err := tpl.(*texttpl.Template).Clone()
+		if err != nil {
+			return "", err
+		}
+		if err = newTpl.Funcs(funcMap).Execute(buffer, variables); err != nil {
 			return "", err
 		}
 	}
@@ -256,8 +265,13 @@ func (view *View) doParseContent(ctx context.Context, content string, params Par
 		gutil.MapMerge(variables, view.data)
 	}
 	view.setI18nLanguageFromCtx(ctx, variables)
+	view.setCspNonceFromCtx(ctx, variables)
 
-	buffer := bytes.NewBuffer(nil)
+	// The i18n functions are bound to the language of current parsing on the cloned template.
+	var (
+		buffer  = bytes.NewBuffer(nil)
+		funcMap = view.i18nFuncMap(view.i18nCtx(ctx, variables))
+	)
 	if view.config.AutoEncode {
 		var newTpl *htmltpl.Template
 		newTpl, err = tpl.(*htmltpl.Template).Clone()
@@ -265,12 +279,18 @@ func (view *View) doParseContent(ctx context.Context, content string, params Par
 			err = gerror.Wrapf(err, `template clone failed`)
 			return "", err
 		}
-		if err = newTpl.Execute(buffer, variables); err != nil {
+		if err = newTpl.Funcs(funcMap).Execute(buffer, variables); err != nil {
 			err = gerror.Wrapf(err, `template parsing failed`)
 			return "", err
 		}
 	} else {
-		if err = tpl.(*texttpl.Template).Execute(buffer, variables); err != nil {
+		var newTpl *texttpl.Template
+		newTpl, err = tpl.(*texttpl.Template).Clone()
+		if err != nil {
+			err = gerror.Wrapf(err, `template clone failed`)
+			return "", err
+		}
+		if err = newTpl.Funcs(funcMap).Execute(buffer, variables); err != nil {
 			err = gerror.Wrapf(err, `template parsing failed`)
 			return "", err
 		}
@@ -12,7 +12,9 @@ import (
 
 	"github.com/gogf/gf/v2/debug/gdebug"
 	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/i18n/gi18n"
 	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gview"
 	"github.com/gogf/gf/v2/test/gtest"
 )
 
@@ -76,3 +78,74 @@ func Test_I18n(t *testing.T) {
 		t.Assert(result3, expect3)
 	})
 }
+
+func Test_I18n_BuildInFuncs(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = context.TODO()
+			view    = gview.New()
+			manager = gi18n.New(gi18n.Options{Language: "en"})
+		)
+		err := manager.AddSource(ctx, gi18n.SourceFunc(func(ctx context.Context) (map[string]map[string]string, error) {
+			return map[string]map[string]string{
+				"en": {
+					"hello":       "Hello {name}",
+					"welcome":     "Welcome %s",
+					"apple.one":   "{count} apple",
+					"apple.other": "{count} apples",
+				},
+				"de": {
+					"hello":       "Hallo {name}",
+					"welcome":     "Willkommen %s",
+					"apple.one":   "{count} Apfel",
+					"apple.other": "{count} Äpfel",
+				},
+			}, nil
+		}))
+		t.AssertNil(err)
+		view.SetI18n(manager)
+
+		var (
+			content = `{{T "hello" .Args}}|{{T "welcome" .Name}}|{{Tn "apple" .Count}}|{{number .Amount 2}}|{{localdate .Time}}`
+			params  = g.Map{
+				"Args":   g.Map{"name": "john"},
+				"Name":   "john",
+				"Count":  1200,
+				"Amount": 1234.5,
+				"Time":   "2022-01-02 03:04:05",
+			}
+		)
+		result, err := view.ParseContent(ctx, content, params)
+		t.AssertNil(err)
+		t.Assert(result, `Hello john|Welcome john|1,200 apples|1,234.50|01/02/2022`)
+
+		// Language from context.
+		result, err = view.ParseContent(gi18n.WithLanguage(ctx, "de"), content, params)
+		t.AssertNil(err)
+		t.Assert(result, `Hallo john|Willkommen john|1.200 Äpfel|1.234,50|02.01.2022`)
+
+		// Language from template variable.
+		result, err = view.ParseContent(ctx, `{{localdatetime .Time}}`, g.Map{
+			"Time":         "2022-01-02 03:04:05",
+			"I18nLanguage": "zh-CN",
+		})
+		t.AssertNil(err)
+		t.Assert(result, `2022-01-02 03:04:05`)
+
+		// With auto encoding.
+		view.SetAutoEncode(true)
+		result, err = view.ParseContent(gi18n.WithLanguage(ctx, "de"), `{{T "hello" .Args}}`, params)
+		t.AssertNil(err)
+		t.Assert(result, `Hallo john`)
+	})
+	// Function rebound by user is kept.
+	gtest.C(t, func(t *gtest.T) {
+		view := gview.New()
+		view.BindFunc("number", func(v interface{}) string {
+			return "custom"
+		})
+		result, err := view.ParseContent(gi18n.WithLanguage(context.TODO(), "de"), `{{number 1000}}`)
+		t.AssertNil(err)
+		t.Assert(result, `custom`)
+	})
+}
@@ -570,3 +570,22 @@ func Test_Issue1416(t *testing.T) {
 		t.Assert(r, `test.tpl content, vars: world`)
 	})
 }
+
+func Test_CspNonce(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			v   = gview.New()
+			ctx = gview.WithCspNonce(context.TODO(), "abc")
+		)
+		t.Assert(gview.CspNonceFromCtx(ctx), "abc")
+		t.Assert(gview.CspNonceFromCtx(context.TODO()), "")
+
+		r, err := v.ParseContent(ctx, `<script nonce="{{.CspNonce}}"></script>`)
+		t.AssertNil(err)
+		t.Assert(r, `<script nonce="abc"></script>`)
+
+		r, err = v.ParseContent(ctx, `{{.CspNonce}}`, g.Map{"CspNonce": "custom"})
+		t.AssertNil(err)
+		t.Assert(r, `custom`)
+	})
+}
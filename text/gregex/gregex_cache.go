@@ -18,10 +18,48 @@ var (
 	// Cache for regex object.
 	// Note that:
 	// 1. It uses sync.RWMutex ensuring the concurrent safety.
-	// 2. There's no expiring logic for this map.
+	// 2. There's no expiring logic for this map, but the oldest one is evicted if cache size is limited.
 	regexMap = make(map[string]*regexp.Regexp)
+	// regexKeys holds the cached patterns in adding order for evicting.
+	regexKeys = make([]string, 0)
+	// regexCacheSize is the max count of cached patterns, no limit if it's less than or equal to 0.
+	regexCacheSize = 0
 )
 
+// SetCacheSize sets the max count of cached regular expression objects.
+// The oldest cached object is evicted if the cache is full.
+// It is no limit in default, which is suitable for the constant patterns in source codes,
+// but it is recommended limiting the cache size if the patterns are supplied by users.
+func SetCacheSize(size int) {
+	regexMu.Lock()
+	defer regexMu.Unlock()
+	regexCacheSize = size
+	evictRegexCache()
+}
+
+// GetCacheSize returns the max count of cached regular expression objects,
+// which is no limit if it's less than or equal to 0.
+func GetCacheSize() int {
+	regexMu.RLock()
+	defer regexMu.RUnlock()
+	return regexCacheSize
+}
+
+// CacheLen returns the count of cached regular expression objects.
+func CacheLen() int {
+	regexMu.RLock()
+	defer regexMu.RUnlock()
+	return len(regexMap)
+}
+
+// PurgeCache removes all cached regular expression objects.
+func PurgeCache() {
+	regexMu.Lock()
+	defer regexMu.Unlock()
+	regexMap = make(map[string]*regexp.Regexp)
+	regexKeys = make([]string, 0)
+}
+
 // getRegexp returns *regexp.Regexp object with given `pattern`.
 // It uses cache to enhance the performance for compiling regular expression pattern,
 // which means, it will return the same *regexp.Regexp object with the same regular
@@ -44,7 +82,24 @@ func getRegexp(pattern string) (regex *regexp.Regexp, err error) {
 	}
 	// Cache the result object using writing lock.
 	regexMu.Lock()
-	regexMap[pattern] = regex
+	if _, ok := regexMap[pattern]; !ok {
+		regexMap[pattern] = regex
+		regexKeys = append(regexKeys, pattern)
+		evictRegexCache()
+	}
 	regexMu.Unlock()
 	return
 }
+
+// evictRegexCache evicts the oldest cached objects if the cache size exceeds the limit.
+// Note that it should be called within writing lock.
+func evictRegexCache() {
+	if regexCacheSize <= 0 || len(regexKeys) <= regexCacheSize {
+		return
+	}
+	evictCount := len(regexKeys) - regexCacheSize
+	for _, key := range regexKeys[:evictCount] {
+		delete(regexMap, key)
+	}
+	regexKeys = append(regexKeys[:0:0], regexKeys[evictCount:]...)
+}
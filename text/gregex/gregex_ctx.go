@@ -0,0 +1,101 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gregex
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// IsMatchStringCtx checks whether given string `src` matches `pattern`, which returns error
+// if `ctx` is done before the matching finishes, eg: the deadline of `ctx` exceeds.
+//
+// The matching functions with context are used for user-supplied patterns or very large `src`,
+// so that the request handlers are not blocked by the slow matching.
+// Note that the matching itself cannot be interrupted, it keeps running in background
+// till it finishes, but the caller returns once `ctx` is done.
+func IsMatchStringCtx(ctx context.Context, pattern string, src string) (bool, error) {
+	result, err := doWithCtx(ctx, pattern, func(r *regexp.Regexp) interface{} {
+		return r.MatchString(src)
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.(bool), nil
+}
+
+// MatchStringCtx return strings that matched `pattern`, which returns error
+// if `ctx` is done before the matching finishes.
+// See IsMatchStringCtx.
+func MatchStringCtx(ctx context.Context, pattern string, src string) ([]string, error) {
+	result, err := doWithCtx(ctx, pattern, func(r *regexp.Regexp) interface{} {
+		return r.FindStringSubmatch(src)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]string), nil
+}
+
+// MatchAllStringCtx return all strings that matched `pattern`, which returns error
+// if `ctx` is done before the matching finishes.
+// See IsMatchStringCtx.
+func MatchAllStringCtx(ctx context.Context, pattern string, src string) ([][]string, error) {
+	result, err := doWithCtx(ctx, pattern, func(r *regexp.Regexp) interface{} {
+		return r.FindAllStringSubmatch(src, -1)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([][]string), nil
+}
+
+// ReplaceStringCtx replaces all matched `pattern` in string `src` with string `replace`,
+// which returns error if `ctx` is done before the replacing finishes.
+// See IsMatchStringCtx.
+func ReplaceStringCtx(ctx context.Context, pattern, replace, src string) (string, error) {
+	result, err := doWithCtx(ctx, pattern, func(r *regexp.Regexp) interface{} {
+		return r.ReplaceAllString(src, replace)
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.(string), nil
+}
+
+// doWithCtx compiles `pattern` and calls `f` with it in a new goroutine, and waits until `f`
+// finishes or `ctx` is done.
+func doWithCtx(ctx context.Context, pattern string, f func(r *regexp.Regexp) interface{}) (interface{}, error) {
+	r, err := getRegexp(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if ctx == nil || ctx.Done() == nil {
+		return f(r), nil
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, gerror.WrapCodef(
+			gcode.CodeOperationFailed, err, `regular expression matching canceled for pattern "%s"`, pattern,
+		)
+	}
+	// It is buffered, so the goroutine does not block if the caller returns for ctx.
+	resultChan := make(chan interface{}, 1)
+	go func() {
+		resultChan <- f(r)
+	}()
+	select {
+	case result := <-resultChan:
+		return result, nil
+	case <-ctx.Done():
+		return nil, gerror.WrapCodef(
+			gcode.CodeOperationFailed, ctx.Err(), `regular expression matching canceled for pattern "%s"`, pattern,
+		)
+	}
+}
@@ -9,8 +9,10 @@
 package gregex_test
 
 import (
+	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/text/gregex"
@@ -341,3 +343,57 @@ func Test_Split(t *testing.T) {
 
 	})
 }
+
+func Test_Cache(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		defer gregex.SetCacheSize(0)
+		gregex.PurgeCache()
+		t.Assert(gregex.CacheLen(), 0)
+		gregex.SetCacheSize(2)
+		t.Assert(gregex.GetCacheSize(), 2)
+		t.Assert(gregex.IsMatchString(`^\d+$`, "1"), true)
+		t.Assert(gregex.IsMatchString(`^\w+$`, "a"), true)
+		t.Assert(gregex.IsMatchString(`^\s+$`, " "), true)
+		t.Assert(gregex.CacheLen(), 2)
+		gregex.SetCacheSize(1)
+		t.Assert(gregex.CacheLen(), 1)
+		gregex.PurgeCache()
+		t.Assert(gregex.CacheLen(), 0)
+	})
+}
+
+func Test_MatchCtx(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		ctx := context.Background()
+		ok, err := gregex.IsMatchStringCtx(ctx, `\d+`, "abc123")
+		t.AssertNil(err)
+		t.Assert(ok, true)
+
+		match, err := gregex.MatchStringCtx(ctx, `(\w+)@(\w+)`, "john@goframe")
+		t.AssertNil(err)
+		t.Assert(match, []string{"john@goframe", "john", "goframe"})
+
+		matches, err := gregex.MatchAllStringCtx(ctx, `\d`, "a1b2")
+		t.AssertNil(err)
+		t.Assert(matches, [][]string{{"1"}, {"2"}})
+
+		replaced, err := gregex.ReplaceStringCtx(ctx, `\d`, "#", "a1b2")
+		t.AssertNil(err)
+		t.Assert(replaced, "a#b#")
+
+		_, err = gregex.IsMatchStringCtx(ctx, PatternErr, "a")
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		src := strings.Repeat("a", 10*1024*1024)
+		_, err := gregex.ReplaceStringCtx(ctx, `(a|b)*c`, "x", src)
+		t.AssertNE(err, nil)
+
+		ctx, cancel = context.WithCancel(context.Background())
+		cancel()
+		_, err = gregex.IsMatchStringCtx(ctx, `\d+`, "1")
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstr
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	runeZWJ                = '\u200D' // Zero width joiner, which joins emojis into one emoji.
+	runeRegionalIndicatorA = '\U0001F1E6'
+	runeRegionalIndicatorZ = '\U0001F1FF'
+)
+
+// SplitGraphemes splits `str` into user-perceived characters (grapheme clusters),
+// which keeps the combining marks, emoji sequences and flags as single characters.
+// Eg: "é👍🏻🇨🇳" -> ["é", "👍🏻", "🇨🇳"]
+func SplitGraphemes(str string) []string {
+	graphemes := make([]string, 0, len(str))
+	for len(str) > 0 {
+		size := nextGraphemeSize(str)
+		graphemes = append(graphemes, str[:size])
+		str = str[size:]
+	}
+	return graphemes
+}
+
+// LenGrapheme returns the count of user-perceived characters (grapheme clusters) of `str`.
+func LenGrapheme(str string) int {
+	count := 0
+	for len(str) > 0 {
+		str = str[nextGraphemeSize(str):]
+		count++
+	}
+	return count
+}
+
+// SubStrGrapheme returns a portion of string `str` specified by the `start` and `length` parameters,
+// which counts in user-perceived characters (grapheme clusters) and never splits a character,
+// like emoji or character with combining marks.
+// The parameter `length` is optional, it uses the length of `str` in default.
+func SubStrGrapheme(str string, start int, length ...int) (substr string) {
+	if start < 0 {
+		start = 0
+	}
+	for ; start > 0 && len(str) > 0; start-- {
+		str = str[nextGraphemeSize(str):]
+	}
+	if len(length) == 0 || length[0] < 0 {
+		return str
+	}
+	end := 0
+	for i := 0; i < length[0] && end < len(str); i++ {
+		end += nextGraphemeSize(str[end:])
+	}
+	return str[:end]
+}
+
+// StrLimitGrapheme returns a portion of string `str` specified by `length` parameters, if the length
+// of `str` is greater than `length`, then the `suffix` will be appended to the result string.
+// It counts in user-perceived characters (grapheme clusters) and never splits a character,
+// so it is suitable for truncating text for display.
+func StrLimitGrapheme(str string, length int, suffix ...string) string {
+	end := 0
+	for i := 0; i < length && end < len(str); i++ {
+		end += nextGraphemeSize(str[end:])
+	}
+	if end >= len(str) {
+		return str
+	}
+	suffixStr := defaultSuffixForStrLimit
+	if len(suffix) > 0 {
+		suffixStr = suffix[0]
+	}
+	return str[:end] + suffixStr
+}
+
+// nextGraphemeSize returns the byte size of the first grapheme cluster of non-empty `str`.
+//
+// It implements the commonly used rules of Unicode extended grapheme cluster boundaries:
+// CR LF, combining marks, variation selectors, emoji modifiers, emoji tag sequences,
+// zero width joiner sequences and regional indicator pairs.
+func nextGraphemeSize(str string) int {
+	r, size := utf8.DecodeRuneInString(str)
+	if r == '\r' && len(str) > size && str[size] == '\n' {
+		return size + 1
+	}
+	// A flag is composed of two regional indicators.
+	if isRegionalIndicator(r) {
+		if next, nextSize := utf8.DecodeRuneInString(str[size:]); isRegionalIndicator(next) {
+			size += nextSize
+		}
+	}
+	for size < len(str) {
+		next, nextSize := utf8.DecodeRuneInString(str[size:])
+		switch {
+		case isGraphemeExtend(next):
+			size += nextSize
+
+		case next == runeZWJ:
+			size += nextSize
+			// The ZWJ joins the following character into current cluster.
+			if size < len(str) {
+				_, joinedSize := utf8.DecodeRuneInString(str[size:])
+				size += joinedSize
+			}
+
+		default:
+			return size
+		}
+	}
+	return size
+}
+
+// isGraphemeExtend checks whether `r` extends the previous character rather than starts a new one.
+func isGraphemeExtend(r rune) bool {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true
+	case r >= '\uFE00' && r <= '\uFE0F': // Variation selectors.
+		return true
+	case r >= '\U0001F3FB' && r <= '\U0001F3FF': // Emoji skin tone modifiers.
+		return true
+	case r >= '\U000E0020' && r <= '\U000E007F': // Emoji tag sequences.
+		return true
+	case r >= '\U000E0100' && r <= '\U000E01EF': // Variation selectors supplement.
+		return true
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= runeRegionalIndicatorA && r <= runeRegionalIndicatorZ
+}
@@ -0,0 +1,197 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstr
+
+import (
+	"sort"
+	"strings"
+)
+
+// ReplacerAC is a compiled multi-pattern matcher using Aho-Corasick algorithm,
+// which searches thousands of patterns in one pass of the string.
+// It is concurrent safe after created.
+type ReplacerAC struct {
+	nodes    []acNode // Trie nodes, the first one is root.
+	patterns []string // Patterns in adding order.
+}
+
+// ACMatch is a matched pattern in string.
+type ACMatch struct {
+	Pattern string // Matched pattern.
+	Index   int    // Byte index of the matched pattern in string.
+}
+
+// acNode is the trie node of Aho-Corasick automaton.
+type acNode struct {
+	next       map[byte]int // Children nodes by byte.
+	fail       int          // Failure link node.
+	output     int          // Index of pattern that ends at this node, -1 if none.
+	dictSuffix int          // Nearest node in failure chain which has output, -1 if none.
+}
+
+// NewReplacerAC creates and returns a ReplacerAC with given `patterns`.
+// The empty patterns are ignored.
+func NewReplacerAC(patterns ...string) *ReplacerAC {
+	r := &ReplacerAC{
+		nodes: []acNode{newACNode()},
+	}
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		r.add(pattern)
+	}
+	r.build()
+	return r
+}
+
+// Contains checks whether `s` contains any pattern.
+func (r *ReplacerAC) Contains(s string) bool {
+	found := false
+	r.search(s, func(pattern, end int) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// FindAll returns all matched patterns in `s`, including the overlapped ones,
+// which are ordered by their end positions.
+func (r *ReplacerAC) FindAll(s string) []ACMatch {
+	matches := make([]ACMatch, 0)
+	r.search(s, func(pattern, end int) bool {
+		matches = append(matches, ACMatch{
+			Pattern: r.patterns[pattern],
+			Index:   end - len(r.patterns[pattern]),
+		})
+		return true
+	})
+	return matches
+}
+
+// Replace replaces all matched patterns in `s` with `replacement`.
+// The leftmost and then the longest pattern is replaced if the matched patterns overlap.
+func (r *ReplacerAC) Replace(s, replacement string) string {
+	return r.ReplaceFunc(s, func(pattern string) string {
+		return replacement
+	})
+}
+
+// ReplaceFunc replaces all matched patterns in `s` with the result of `f`,
+// which is called with the matched pattern.
+// The leftmost and then the longest pattern is replaced if the matched patterns overlap.
+func (r *ReplacerAC) ReplaceFunc(s string, f func(pattern string) string) string {
+	matches := r.FindAll(s)
+	if len(matches) == 0 {
+		return s
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Index != matches[j].Index {
+			return matches[i].Index < matches[j].Index
+		}
+		return len(matches[i].Pattern) > len(matches[j].Pattern)
+	})
+	var (
+		builder strings.Builder
+		last    = 0
+	)
+	builder.Grow(len(s))
+	for _, match := range matches {
+		if match.Index < last {
+			continue
+		}
+		builder.WriteString(s[last:match.Index])
+		builder.WriteString(f(match.Pattern))
+		last = match.Index + len(match.Pattern)
+	}
+	builder.WriteString(s[last:])
+	return builder.String()
+}
+
+func newACNode() acNode {
+	return acNode{
+		next:       make(map[byte]int),
+		output:     -1,
+		dictSuffix: -1,
+	}
+}
+
+// add adds `pattern` into the trie.
+func (r *ReplacerAC) add(pattern string) {
+	node := 0
+	for i := 0; i < len(pattern); i++ {
+		child, ok := r.nodes[node].next[pattern[i]]
+		if !ok {
+			child = len(r.nodes)
+			r.nodes = append(r.nodes, newACNode())
+			r.nodes[node].next[pattern[i]] = child
+		}
+		node = child
+	}
+	if r.nodes[node].output == -1 {
+		r.nodes[node].output = len(r.patterns)
+		r.patterns = append(r.patterns, pattern)
+	}
+}
+
+// build builds the failure links using breadth-first traversing of the trie.
+func (r *ReplacerAC) build() {
+	queue := make([]int, 0, len(r.nodes))
+	for _, child := range r.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for b, child := range r.nodes[node].next {
+			fail := r.nodes[node].fail
+			for {
+				if next, ok := r.nodes[fail].next[b]; ok {
+					r.nodes[child].fail = next
+					break
+				}
+				if fail == 0 {
+					r.nodes[child].fail = 0
+					break
+				}
+				fail = r.nodes[fail].fail
+			}
+			failNode := r.nodes[child].fail
+			if r.nodes[failNode].output != -1 {
+				r.nodes[child].dictSuffix = failNode
+			} else {
+				r.nodes[child].dictSuffix = r.nodes[failNode].dictSuffix
+			}
+			queue = append(queue, child)
+		}
+	}
+}
+
+// search scans `s` and calls `f` with index of matched pattern and its end byte position in `s`.
+// It stops scanning if `f` returns false.
+func (r *ReplacerAC) search(s string, f func(pattern, end int) bool) {
+	node := 0
+	for i := 0; i < len(s); i++ {
+		for {
+			if next, ok := r.nodes[node].next[s[i]]; ok {
+				node = next
+				break
+			}
+			if node == 0 {
+				break
+			}
+			node = r.nodes[node].fail
+		}
+		for out := node; out != -1; out = r.nodes[out].dictSuffix {
+			if r.nodes[out].output != -1 {
+				if !f(r.nodes[out].output, i+1) {
+					return
+				}
+			}
+		}
+	}
+}
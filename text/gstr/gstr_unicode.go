@@ -0,0 +1,49 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstr
+
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NFC returns the Unicode Normalization Form C (canonical composition) of `str`.
+// Eg: "e\u0301" -> "\u00e9".
+func NFC(str string) string {
+	return norm.NFC.String(str)
+}
+
+// NFD returns the Unicode Normalization Form D (canonical decomposition) of `str`.
+// Eg: "\u00e9" -> "e\u0301".
+func NFD(str string) string {
+	return norm.NFD.String(str)
+}
+
+// NFKC returns the Unicode Normalization Form KC (compatibility composition) of `str`.
+// Eg: "ﬁ" -> "fi".
+func NFKC(str string) string {
+	return norm.NFKC.String(str)
+}
+
+// NFKD returns the Unicode Normalization Form KD (compatibility decomposition) of `str`.
+func NFKD(str string) string {
+	return norm.NFKD.String(str)
+}
+
+// CaseFold returns the full Unicode case folding of `str`, which is used for caseless comparison.
+// Note that it is different from lower casing, eg: "Straße" -> "strasse".
+func CaseFold(str string) string {
+	return cases.Fold().String(str)
+}
+
+// EqualFoldNormalized reports whether `a` and `b` are equal under full Unicode case folding
+// after NFC normalization, which considers "Straße" equal to "STRASSE" and "\u00e9" equal to "e\u0301".
+//
+// It is stricter but slower than Equal, which uses simple case folding without normalization.
+func EqualFoldNormalized(a, b string) bool {
+	return CaseFold(NFC(a)) == CaseFold(NFC(b))
+}
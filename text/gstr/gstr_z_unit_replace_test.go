@@ -88,3 +88,26 @@ func Test_ReplaceI_2(t *testing.T) {
 		t.Assert(gstr.ReplaceI("aaa", "A", "AA", 4), `AAAAAA`)
 	})
 }
+
+func Test_ReplacerAC(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		r := gstr.NewReplacerAC("he", "she", "his", "hers", "")
+		t.Assert(r.Contains("ushers"), true)
+		t.Assert(r.Contains("abc"), false)
+		t.Assert(r.Contains(""), false)
+		t.Assert(r.FindAll("ushers"), []gstr.ACMatch{
+			{Pattern: "she", Index: 1},
+			{Pattern: "he", Index: 2},
+			{Pattern: "hers", Index: 2},
+		})
+		t.Assert(r.Replace("ushers and his", "*"), "u*rs and *")
+		t.Assert(r.ReplaceFunc("she is hers", func(pattern string) string {
+			return gstr.Repeat("*", len(pattern))
+		}), "*** is ****")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		r := gstr.NewReplacerAC("中国", "中国人", "人民")
+		t.Assert(r.Replace("我是中国人民", "#"), "我是#民")
+		t.Assert(r.Replace("no match", "#"), "no match")
+	})
+}
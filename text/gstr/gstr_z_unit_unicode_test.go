@@ -0,0 +1,63 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstr_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+func Test_Normalize(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			composed   = "\u00e9"
+			decomposed = "e\u0301"
+		)
+		t.Assert(gstr.NFC(decomposed), composed)
+		t.Assert(gstr.NFD(composed), decomposed)
+		t.Assert(gstr.NFKC("ﬁ"), "fi")
+		t.Assert(gstr.NFKD("ﬁ"), "fi")
+	})
+}
+
+func Test_CaseFold(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.CaseFold("Straße"), "strasse")
+		t.Assert(gstr.EqualFoldNormalized("Straße", "STRASSE"), true)
+		t.Assert(gstr.EqualFoldNormalized("Café", "CAFE\u0301"), true)
+		t.Assert(gstr.EqualFoldNormalized("Cafe", "Caf\u00e9"), false)
+	})
+}
+
+func Test_Grapheme(t *testing.T) {
+	var (
+		family = "\U0001F468\u200D\U0001F469\u200D\U0001F467" // Family emoji joined by ZWJ.
+		thumb  = "\U0001F44D\U0001F3FB"                       // Thumbs up with skin tone.
+		flag   = "\U0001F1E8\U0001F1F3"                       // Flag of China.
+		accent = "e\u0301"                                    // Letter with combining mark.
+		str    = "a" + family + thumb + flag + accent + "\r\n" + "好"
+	)
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.SplitGraphemes(str), []string{"a", family, thumb, flag, accent, "\r\n", "好"})
+		t.Assert(gstr.LenGrapheme(str), 7)
+		t.Assert(gstr.LenGrapheme(""), 0)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.SubStrGrapheme(str, 1, 2), family+thumb)
+		t.Assert(gstr.SubStrGrapheme(str, 4), accent+"\r\n好")
+		t.Assert(gstr.SubStrGrapheme(str, -1, 1), "a")
+		t.Assert(gstr.SubStrGrapheme(str, 10, 1), "")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.StrLimitGrapheme(str, 3), "a"+family+thumb+"...")
+		t.Assert(gstr.StrLimitGrapheme(str, 3, ""), "a"+family+thumb)
+		t.Assert(gstr.StrLimitGrapheme(str, 7), str)
+		t.Assert(gstr.StrLimitGrapheme(str, 100), str)
+	})
+}
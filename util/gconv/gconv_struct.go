@@ -42,6 +42,26 @@ func StructTag(params interface{}, pointer interface{}, priorityTag string) (err
 	return doStruct(params, pointer, nil, priorityTag)
 }
 
+// StructOption is the option for StructWithOption.
+type StructOption struct {
+	// Mapping specifies the mapping rules between the custom key name and the attribute name, see Struct.
+	Mapping map[string]string
+
+	// PriorityTag specifies the priority tags joined with char ',', see StructTag.
+	PriorityTag string
+
+	// KeyMatch specifies the strategy matching the param keys to the attribute and tag names,
+	// like MatchKeyExact, MatchKeySnakeCamel or a custom function. It is MatchKeyFuzzy in default.
+	// It applies to the struct and its embedded structs, but not the attributes of struct type.
+	KeyMatch KeyMatchFunc
+}
+
+// StructWithOption acts as Struct but with the converting option `option`,
+// which specifies the mapping rules, priority tags and key matching strategy.
+func StructWithOption(params interface{}, pointer interface{}, option StructOption) (err error) {
+	return doStructWithOption(params, pointer, option)
+}
+
 // doStructWithJsonCheck checks if given `params` is JSON, it then uses json.Unmarshal doing the converting.
 func doStructWithJsonCheck(params interface{}, pointer interface{}) (err error, ok bool) {
 	switch r := params.(type) {
@@ -86,6 +106,18 @@ func doStructWithJsonCheck(params interface{}, pointer interface{}) (err error,
 
 // doStruct is the core internal converting function for any data to struct.
 func doStruct(params interface{}, pointer interface{}, mapping map[string]string, priorityTag string) (err error) {
+	return doStructWithOption(params, pointer, StructOption{
+		Mapping:     mapping,
+		PriorityTag: priorityTag,
+	})
+}
+
+// doStructWithOption converts any data to struct with converting option `option`.
+func doStructWithOption(params interface{}, pointer interface{}, option StructOption) (err error) {
+	var (
+		mapping     = option.Mapping
+		priorityTag = option.PriorityTag
+	)
 	if params == nil {
 		// If `params` is nil, no conversion.
 		return nil
@@ -214,7 +246,7 @@ func doStruct(params interface{}, pointer interface{}, mapping map[string]string
 					continue
 				}
 			}
-			if err = doStruct(paramsMap, elemFieldValue, mapping, priorityTag); err != nil {
+			if err = doStructWithOption(paramsMap, elemFieldValue, option); err != nil {
 				return err
 			}
 		} else {
@@ -256,8 +288,8 @@ func doStruct(params interface{}, pointer interface{}, mapping map[string]string
 	}
 
 	var (
-		attrName  string
-		checkName string
+		attrName    string
+		conventions = getNamingConventions()
 	)
 	for paramName, paramValue := range paramsMap {
 		attrName = ""
@@ -269,37 +301,16 @@ func doStruct(params interface{}, pointer interface{}, mapping map[string]string
 		}
 		// It secondly checks the predefined tags and matching rules.
 		if attrName == "" {
-			// It firstly considers `paramName` as accurate tag name,
-			// and retrieve attribute name from `tagToAttrNameMap` .
-			attrName = tagToAttrNameMap[paramName]
-			if attrName == "" {
-				checkName = utils.RemoveSymbols(paramName)
-				// Loop to find the matched attribute name with or without
-				// string cases and chars like '-'/'_'/'.'/' '.
-
-				// Matching the parameters to struct tag names.
-				// The `attrKey` is the attribute name of the struct.
-				for attrKey, cmpKey := range attrToTagCheckNameMap {
-					if strings.EqualFold(checkName, cmpKey) {
-						attrName = attrKey
-						break
-					}
-				}
-			}
-
-			// Matching the parameters to struct attributes.
-			if attrName == "" {
-				for attrKey, cmpKey := range attrToCheckNameMap {
-					// Eg:
-					// UserName  eq user_name
-					// User-Name eq username
-					// username  eq userName
-					// etc.
-					if strings.EqualFold(checkName, cmpKey) {
-						attrName = attrKey
-						break
-					}
-				}
+			attrName = matchStructAttrName(
+				paramName, option.KeyMatch, tagToAttrNameMap, attrToTagCheckNameMap, attrToCheckNameMap,
+			)
+		}
+		// It finally checks the names converted by the global naming conventions.
+		for i := 0; attrName == "" && i < len(conventions); i++ {
+			if name := conventions[i](paramName); name != "" && name != paramName {
+				attrName = matchStructAttrName(
+					name, option.KeyMatch, tagToAttrNameMap, attrToTagCheckNameMap, attrToCheckNameMap,
+				)
 			}
 		}
 
@@ -320,6 +331,56 @@ func doStruct(params interface{}, pointer interface{}, mapping map[string]string
 	return nil
 }
 
+// matchStructAttrName returns the attribute name matching param key `paramName` using `keyMatch`,
+// or an empty string if no matching. It uses fuzzy matching if `keyMatch` is nil.
+func matchStructAttrName(
+	paramName string, keyMatch KeyMatchFunc,
+	tagToAttrNameMap, attrToTagCheckNameMap, attrToCheckNameMap map[string]string,
+) string {
+	// It firstly considers `paramName` as accurate tag name,
+	// and retrieve attribute name from `tagToAttrNameMap` .
+	if attrName := tagToAttrNameMap[paramName]; attrName != "" {
+		return attrName
+	}
+	if keyMatch != nil {
+		for tagName, attrName := range tagToAttrNameMap {
+			if keyMatch(paramName, strings.Split(tagName, ",")[0]) {
+				return attrName
+			}
+		}
+		for attrName := range attrToCheckNameMap {
+			if keyMatch(paramName, attrName) {
+				return attrName
+			}
+		}
+		return ""
+	}
+	// Loop to find the matched attribute name with or without
+	// string cases and chars like '-'/'_'/'.'/' '.
+	checkName := utils.RemoveSymbols(paramName)
+
+	// Matching the parameters to struct tag names.
+	// The `attrKey` is the attribute name of the struct.
+	for attrKey, cmpKey := range attrToTagCheckNameMap {
+		if strings.EqualFold(checkName, cmpKey) {
+			return attrKey
+		}
+	}
+
+	// Matching the parameters to struct attributes.
+	for attrKey, cmpKey := range attrToCheckNameMap {
+		// Eg:
+		// UserName  eq user_name
+		// User-Name eq username
+		// username  eq userName
+		// etc.
+		if strings.EqualFold(checkName, cmpKey) {
+			return attrKey
+		}
+	}
+	return ""
+}
+
 // bindVarToStructAttr sets value to struct object attribute by name.
 func bindVarToStructAttr(structReflectValue reflect.Value, attrName string, value interface{}, mapping map[string]string) (err error) {
 	structFieldValue := structReflectValue.FieldByName(attrName)
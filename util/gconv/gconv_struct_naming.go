@@ -0,0 +1,100 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gconv
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/internal/utils"
+)
+
+// KeyMatchFunc checks and returns whether the param key `key` matches the struct attribute name
+// or tag name `name` in struct converting.
+type KeyMatchFunc func(key, name string) bool
+
+// NamingConvention converts the param key `key` to the name for matching the struct attributes, which is
+// used for the keys that do not match any attribute, like removing the column prefix: f_user_name -> user_name.
+// It returns empty string if `key` is not of the convention.
+type NamingConvention func(key string) string
+
+var (
+	namingConventionsMu sync.RWMutex
+	namingConventions   []NamingConvention // Global naming conventions, see RegisterNamingConvention.
+)
+
+// RegisterNamingConvention registers global naming convention `convention` for struct converting,
+// which applies to all the struct converting. The conventions are tried in registering order
+// for the param key that does not match any attribute.
+//
+// Example:
+//
+//	RegisterNamingConvention(func(key string) string {
+//	    if strings.HasPrefix(key, "f_") {
+//	        return key[2:]
+//	    }
+//	    return ""
+//	})
+func RegisterNamingConvention(convention NamingConvention) {
+	namingConventionsMu.Lock()
+	defer namingConventionsMu.Unlock()
+	namingConventions = append(namingConventions, convention)
+}
+
+// getNamingConventions returns the registered global naming conventions.
+func getNamingConventions() []NamingConvention {
+	namingConventionsMu.RLock()
+	defer namingConventionsMu.RUnlock()
+	return namingConventions
+}
+
+// MatchKeyExact is the KeyMatchFunc matching `key` and `name` exactly.
+func MatchKeyExact(key, name string) bool {
+	return key == name
+}
+
+// MatchKeySnakeCamel is the KeyMatchFunc matching `key` and `name` in snake case and camel case,
+// like: user_name, userName, UserName and user-name, but not username.
+func MatchKeySnakeCamel(key, name string) bool {
+	return toSnakeCase(key) == toSnakeCase(name)
+}
+
+// MatchKeyFuzzy is the KeyMatchFunc matching `key` and `name` case-insensitively without chars like
+// '-'/'_'/'.'/' ', like: user_name, userName, UserName, user-name and username.
+// It is the default matching strategy of struct converting.
+func MatchKeyFuzzy(key, name string) bool {
+	return strings.EqualFold(utils.RemoveSymbols(key), utils.RemoveSymbols(name))
+}
+
+// toSnakeCase converts `s` to lower snake case, like: UserName -> user_name, userID -> user_id.
+func toSnakeCase(s string) string {
+	var (
+		runes = []rune(s)
+		b     = make([]rune, 0, len(runes)+4)
+	)
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == ' ' || r == '.' || r == '_':
+			if len(b) > 0 && b[len(b)-1] != '_' {
+				b = append(b, '_')
+			}
+		case r >= 'A' && r <= 'Z':
+			// Word boundary: aB -> a_b, ABc -> a_bc.
+			if i > 0 && len(b) > 0 && b[len(b)-1] != '_' {
+				prev := runes[i-1]
+				if (prev >= 'a' && prev <= 'z') || (prev >= '0' && prev <= '9') ||
+					(i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z') {
+					b = append(b, '_')
+				}
+			}
+			b = append(b, r+'a'-'A')
+		default:
+			b = append(b, r)
+		}
+	}
+	return strings.TrimRight(string(b), "_")
+}
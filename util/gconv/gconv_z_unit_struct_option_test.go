@@ -0,0 +1,147 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gconv_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+func Test_StructWithOption_KeyMatch(t *testing.T) {
+	type User struct {
+		Id       int
+		UserName string
+		NickName string `json:"nick_name"`
+	}
+	params := g.Map{
+		"id":        1,
+		"username":  "john",
+		"nick-name": "J",
+	}
+	// Default fuzzy matching.
+	gtest.C(t, func(t *gtest.T) {
+		user := new(User)
+		err := gconv.StructWithOption(params, user, gconv.StructOption{})
+		t.AssertNil(err)
+		t.Assert(user, &User{Id: 1, UserName: "john", NickName: "J"})
+	})
+	// Exact matching.
+	gtest.C(t, func(t *gtest.T) {
+		user := new(User)
+		err := gconv.StructWithOption(g.Map{
+			"Id":        1,
+			"username":  "john",
+			"nick_name": "J",
+		}, user, gconv.StructOption{
+			KeyMatch: gconv.MatchKeyExact,
+		})
+		t.AssertNil(err)
+		t.Assert(user, &User{Id: 1, NickName: "J"})
+	})
+	// Snake and camel matching.
+	gtest.C(t, func(t *gtest.T) {
+		user := new(User)
+		err := gconv.StructWithOption(g.Map{
+			"id":        1,
+			"username":  "john",
+			"user_name": "smith",
+			"nickName":  "J",
+		}, user, gconv.StructOption{
+			KeyMatch: gconv.MatchKeySnakeCamel,
+		})
+		t.AssertNil(err)
+		t.Assert(user, &User{Id: 1, UserName: "smith", NickName: "J"})
+	})
+	// Custom matching.
+	gtest.C(t, func(t *gtest.T) {
+		user := new(User)
+		err := gconv.StructWithOption(g.Map{
+			"col_id":        1,
+			"col_user_name": "john",
+		}, user, gconv.StructOption{
+			KeyMatch: func(key, name string) bool {
+				return gconv.MatchKeySnakeCamel(strings.TrimPrefix(key, "col_"), name)
+			},
+		})
+		t.AssertNil(err)
+		t.Assert(user, &User{Id: 1, UserName: "john"})
+	})
+	// Mapping has priority.
+	gtest.C(t, func(t *gtest.T) {
+		user := new(User)
+		err := gconv.StructWithOption(g.Map{
+			"id":   1,
+			"name": "john",
+		}, user, gconv.StructOption{
+			Mapping:  map[string]string{"name": "UserName"},
+			KeyMatch: gconv.MatchKeyExact,
+		})
+		t.AssertNil(err)
+		t.Assert(user, &User{UserName: "john"})
+	})
+}
+
+func Test_StructWithOption_Embedded(t *testing.T) {
+	type Base struct {
+		CreatedAt string
+	}
+	type User struct {
+		Base
+		UserName string
+	}
+	gtest.C(t, func(t *gtest.T) {
+		user := new(User)
+		err := gconv.StructWithOption(g.Map{
+			"created_at": "2022-01-01",
+			"username":   "john",
+		}, user, gconv.StructOption{
+			KeyMatch: gconv.MatchKeySnakeCamel,
+		})
+		t.AssertNil(err)
+		t.Assert(user.CreatedAt, "2022-01-01")
+		t.Assert(user.UserName, "")
+	})
+}
+
+func Test_RegisterNamingConvention(t *testing.T) {
+	gconv.RegisterNamingConvention(func(key string) string {
+		if strings.HasPrefix(key, "f_") {
+			return key[2:]
+		}
+		return ""
+	})
+	type User struct {
+		Id       int
+		UserName string
+		Score    int `json:"score_total"`
+	}
+	gtest.C(t, func(t *gtest.T) {
+		user := new(User)
+		err := gconv.Struct(g.Map{
+			"f_id":          1,
+			"f_user_name":   "john",
+			"f_score_total": 100,
+		}, user)
+		t.AssertNil(err)
+		t.Assert(user, &User{Id: 1, UserName: "john", Score: 100})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		user := new(User)
+		err := gconv.StructWithOption(g.Map{
+			"f_id":        1,
+			"f_user_name": "john",
+		}, user, gconv.StructOption{
+			KeyMatch: gconv.MatchKeySnakeCamel,
+		})
+		t.AssertNil(err)
+		t.Assert(user, &User{Id: 1, UserName: "john"})
+	})
+}
@@ -0,0 +1,200 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gpage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	ijson "github.com/gogf/gf/v2/internal/json"
+)
+
+// Cursor is the cursor for keyset pagination, which records the sorting fields and the values
+// of them of the last record in previous page. The next page is queried by the condition that
+// the records are after the cursor in the sorting order, which is stable and efficient for large
+// tables comparing to OFFSET pagination.
+//
+// The sorting fields should identify a record uniquely, usually ending with the primary key, like "score desc, id".
+type Cursor struct {
+	Fields []CursorField `json:"f"` // Sorting fields in order.
+}
+
+// CursorField is a sorting field of Cursor.
+type CursorField struct {
+	Name  string      `json:"n"`           // Field name.
+	Desc  bool        `json:"d,omitempty"` // Whether sorting in descending order.
+	Value interface{} `json:"v,omitempty"` // Value of the field of last record, nil for the first page.
+}
+
+// cursorFieldNameReg is the regular expression for the sorting field name of cursor,
+// which might be qualified by table like "u.id".
+var cursorFieldNameReg = regexp.MustCompile(`^[a-zA-Z0-9\-_]+(\.[a-zA-Z0-9\-_]+)?$`)
+
+const (
+	cursorSignSeparator = "." // Separator between cursor payload and its signature.
+	cursorSignSize      = 16  // Size of truncated HMAC signature in bytes.
+)
+
+// NewCursor creates and returns a cursor for the first page, which has no value.
+// The parameter `orderBy` specifies the sorting fields like the ORDER BY statement, eg: "score desc, id".
+func NewCursor(orderBy string) (*Cursor, error) {
+	var fields []CursorField
+	for _, item := range strings.Split(orderBy, ",") {
+		parts := strings.Fields(item)
+		if len(parts) == 0 {
+			continue
+		}
+		field := CursorField{Name: parts[0]}
+		if len(parts) > 2 || !cursorFieldNameReg.MatchString(field.Name) {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid cursor order by "%s"`, orderBy)
+		}
+		if len(parts) == 2 {
+			switch strings.ToLower(parts[1]) {
+			case "asc":
+			case "desc":
+				field.Desc = true
+			default:
+				return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid cursor order by "%s"`, orderBy)
+			}
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `cursor order by cannot be empty`)
+	}
+	return &Cursor{Fields: fields}, nil
+}
+
+// ParseCursor decodes and returns the cursor from `encoded`, which is produced by Cursor.Encode,
+// and checks that its sorting fields match `orderBy`, which avoids tampering of sorting from client.
+// It returns the cursor of first page if `encoded` is empty.
+// The optional parameter `secret` specifies the key for verifying the signature of `encoded`.
+func ParseCursor(encoded, orderBy string, secret ...[]byte) (*Cursor, error) {
+	cursor, err := NewCursor(orderBy)
+	if err != nil || encoded == "" {
+		return cursor, err
+	}
+	decoded, err := DecodeCursor(encoded, secret...)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded.Fields) != len(cursor.Fields) {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `cursor does not match order by "%s"`, orderBy)
+	}
+	for i, field := range decoded.Fields {
+		if field.Name != cursor.Fields[i].Name || field.Desc != cursor.Fields[i].Desc {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `cursor does not match order by "%s"`, orderBy)
+		}
+	}
+	return decoded, nil
+}
+
+// DecodeCursor decodes and returns the cursor from `encoded`, which is produced by Cursor.Encode.
+// The optional parameter `secret` specifies the key for verifying the signature of `encoded`.
+func DecodeCursor(encoded string, secret ...[]byte) (*Cursor, error) {
+	payload := encoded
+	if len(secret) > 0 && len(secret[0]) > 0 {
+		pos := strings.LastIndex(encoded, cursorSignSeparator)
+		if pos < 0 {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, `cursor signature is missing`)
+		}
+		payload = encoded[:pos]
+		sign, err := base64.RawURLEncoding.DecodeString(encoded[pos+1:])
+		if err != nil || !hmac.Equal(sign, signCursor(payload, secret[0])) {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid cursor signature`)
+		}
+	}
+	content, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid cursor encoding`)
+	}
+	cursor := &Cursor{}
+	if err = ijson.UnmarshalUseNumber(content, cursor); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid cursor content`)
+	}
+	if len(cursor.Fields) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid cursor without fields`)
+	}
+	for i, field := range cursor.Fields {
+		// The field names are used in sql statement, which should be validated for the cursor from client.
+		if !cursorFieldNameReg.MatchString(field.Name) {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid cursor field name "%s"`, field.Name)
+		}
+		// The numbers are decoded as json.Number to avoid precision losing of large integer.
+		if number, ok := field.Value.(json.Number); ok {
+			if v, err := number.Int64(); err == nil {
+				cursor.Fields[i].Value = v
+			} else if v, err := number.Float64(); err == nil {
+				cursor.Fields[i].Value = v
+			}
+		}
+	}
+	return cursor, nil
+}
+
+// Next creates and returns the cursor for next page, the values of which are from `record`,
+// which should be the last record of current page.
+func (c *Cursor) Next(record map[string]interface{}) *Cursor {
+	next := &Cursor{
+		Fields: make([]CursorField, len(c.Fields)),
+	}
+	for i, field := range c.Fields {
+		next.Fields[i] = CursorField{
+			Name:  field.Name,
+			Desc:  field.Desc,
+			Value: record[field.Name],
+		}
+	}
+	return next
+}
+
+// IsFirst checks and returns whether the cursor is for the first page, that is, it has no value.
+func (c *Cursor) IsFirst() bool {
+	for _, field := range c.Fields {
+		if field.Value != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// OrderBy returns the sorting fields of the cursor as ORDER BY statement, like "score DESC,id ASC".
+func (c *Cursor) OrderBy() string {
+	items := make([]string, len(c.Fields))
+	for i, field := range c.Fields {
+		if field.Desc {
+			items[i] = field.Name + " DESC"
+		} else {
+			items[i] = field.Name + " ASC"
+		}
+	}
+	return strings.Join(items, ",")
+}
+
+// Encode encodes the cursor as an opaque URL-safe string for client.
+// The optional parameter `secret` specifies the key for signing the cursor, which avoids tampering from client.
+func (c *Cursor) Encode(secret ...[]byte) string {
+	content, _ := ijson.Marshal(c)
+	payload := base64.RawURLEncoding.EncodeToString(content)
+	if len(secret) > 0 && len(secret[0]) > 0 {
+		return payload + cursorSignSeparator + base64.RawURLEncoding.EncodeToString(signCursor(payload, secret[0]))
+	}
+	return payload
+}
+
+// signCursor returns the truncated HMAC-SHA256 signature of `payload`.
+func signCursor(payload string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)[:cursorSignSize]
+}
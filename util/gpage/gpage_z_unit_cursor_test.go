@@ -0,0 +1,120 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gpage_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gpage"
+)
+
+func Test_Cursor_New(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cursor, err := gpage.NewCursor("score desc, id")
+		t.AssertNil(err)
+		t.Assert(len(cursor.Fields), 2)
+		t.Assert(cursor.Fields[0].Name, "score")
+		t.Assert(cursor.Fields[0].Desc, true)
+		t.Assert(cursor.Fields[1].Name, "id")
+		t.Assert(cursor.Fields[1].Desc, false)
+		t.Assert(cursor.IsFirst(), true)
+		t.Assert(cursor.OrderBy(), "score DESC,id ASC")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gpage.NewCursor("")
+		t.AssertNE(err, nil)
+		_, err = gpage.NewCursor("id random")
+		t.AssertNE(err, nil)
+		_, err = gpage.NewCursor("id desc nulls")
+		t.AssertNE(err, nil)
+		_, err = gpage.NewCursor("(SELECT 1)")
+		t.AssertNE(err, nil)
+		_, err = gpage.NewCursor("id;DROP")
+		t.AssertNE(err, nil)
+		cursor, err := gpage.NewCursor("u.score desc, u.id")
+		t.AssertNil(err)
+		t.Assert(cursor.OrderBy(), "u.score DESC,u.id ASC")
+	})
+}
+
+func Test_Cursor_Encode_Decode(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cursor, err := gpage.NewCursor("name, id DESC")
+		t.AssertNil(err)
+		next := cursor.Next(map[string]interface{}{
+			"id":   int64(9007199254740993),
+			"name": "john",
+			"age":  18,
+		})
+		t.Assert(next.IsFirst(), false)
+		t.Assert(len(next.Fields), 2)
+
+		decoded, err := gpage.DecodeCursor(next.Encode())
+		t.AssertNil(err)
+		t.Assert(decoded.OrderBy(), "name ASC,id DESC")
+		t.Assert(decoded.Fields[0].Value, "john")
+		// Large integer keeps its precision.
+		t.Assert(decoded.Fields[1].Value.(int64), int64(9007199254740993))
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gpage.DecodeCursor("!invalid!")
+		t.AssertNE(err, nil)
+		_, err = gpage.DecodeCursor("e30")
+		t.AssertNE(err, nil)
+		// Field name injected by client.
+		_, err = gpage.DecodeCursor(base64.RawURLEncoding.EncodeToString([]byte(`{"f":[{"n":"id=id OR 1","v":1}]}`)))
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Cursor_Secret(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			secret    = []byte("my-secret")
+			cursor, _ = gpage.NewCursor("id")
+			encoded   = cursor.Next(map[string]interface{}{"id": 10}).Encode(secret)
+		)
+		decoded, err := gpage.DecodeCursor(encoded, secret)
+		t.AssertNil(err)
+		t.Assert(decoded.Fields[0].Value, 10)
+
+		// Wrong secret.
+		_, err = gpage.DecodeCursor(encoded, []byte("other-secret"))
+		t.AssertNE(err, nil)
+		// Missing signature.
+		_, err = gpage.DecodeCursor(cursor.Next(map[string]interface{}{"id": 10}).Encode(), secret)
+		t.AssertNE(err, nil)
+		// Tampered payload.
+		tampered := cursor.Next(map[string]interface{}{"id": 1000}).Encode() + encoded[len(encoded)-23:]
+		_, err = gpage.DecodeCursor(tampered, secret)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Cursor_Parse(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cursor, err := gpage.ParseCursor("", "score desc, id")
+		t.AssertNil(err)
+		t.Assert(cursor.IsFirst(), true)
+
+		encoded := cursor.Next(map[string]interface{}{"score": 90, "id": 5}).Encode()
+		cursor, err = gpage.ParseCursor(encoded, "score desc, id")
+		t.AssertNil(err)
+		t.Assert(cursor.Fields[0].Value, 90)
+		t.Assert(cursor.Fields[1].Value, 5)
+
+		// Sorting fields do not match.
+		_, err = gpage.ParseCursor(encoded, "score, id")
+		t.AssertNE(err, nil)
+		_, err = gpage.ParseCursor(encoded, "score desc")
+		t.AssertNE(err, nil)
+		_, err = gpage.ParseCursor(encoded, "")
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grand
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// SecureRand provides cryptographically secure random generation, which is suitable for
+// security sensitive usages like tokens, keys, passwords and verification codes.
+//
+// Different from the package functions, it reads from crypto/rand directly without any buffer sharing,
+// and it produces uniformly distributed numbers without modulo bias, at the cost of performance.
+type SecureRand struct{}
+
+var (
+	// Secure is the cryptographically secure random generator.
+	// Eg: grand.Secure.Token(32)
+	Secure = SecureRand{}
+)
+
+// B retrieves and returns cryptographically secure random bytes of given length `n`.
+// It panics if the system random source is unavailable, which should never happen in normal system.
+func (SecureRand) B(n int) []byte {
+	if n <= 0 {
+		return nil
+	}
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(gerror.WrapCode(gcode.CodeInternalError, err, `error reading random bytes from system`))
+	}
+	return b
+}
+
+// Intn returns a uniformly distributed int number which is between 0 and max: [0, max).
+// It returns `max` directly if `max` is not greater than 0.
+func (r SecureRand) Intn(max int) int {
+	if max <= 0 {
+		return max
+	}
+	return int(r.uint64n(uint64(max)))
+}
+
+// N returns a uniformly distributed int number between min and max: [min, max].
+func (r SecureRand) N(min, max int) int {
+	if min >= max {
+		return min
+	}
+	return min + int(r.uint64n(uint64(max-min)+1))
+}
+
+// S returns a random string which contains digits and letters, and its length is `n`.
+// The optional parameter `symbols` specifies whether the result could contain symbols,
+// which is false in default.
+func (r SecureRand) S(n int, symbols ...bool) string {
+	if len(symbols) > 0 && symbols[0] {
+		return r.Str(characters, n)
+	}
+	return r.Str(characters[:62], n)
+}
+
+// Str randomly picks and returns `n` count of chars from given string `s`.
+// It also supports unicode string like Chinese/Russian/Japanese, etc.
+func (r SecureRand) Str(s string, n int) string {
+	runes := []rune(s)
+	if n <= 0 || len(runes) == 0 {
+		return ""
+	}
+	b := make([]rune, n)
+	for i := range b {
+		b[i] = runes[r.uint64n(uint64(len(runes)))]
+	}
+	return string(b)
+}
+
+// Digits returns a random string which contains only digits, and its length is `n`.
+// It is usually used for verification code.
+func (r SecureRand) Digits(n int) string {
+	return r.Str(digits, n)
+}
+
+// Token returns a URL-safe base64 encoded string without padding of `n` random bytes,
+// which is usually used for access token or session id.
+func (r SecureRand) Token(n int) string {
+	return base64.RawURLEncoding.EncodeToString(r.B(n))
+}
+
+// Hex returns a hex encoded string of `n` random bytes, and its length is 2*`n`.
+func (r SecureRand) Hex(n int) string {
+	return hex.EncodeToString(r.B(n))
+}
+
+// Perm returns, as a slice of n int numbers, a uniformly distributed permutation of the integers [0,n).
+func (r SecureRand) Perm(n int) []int {
+	m := make([]int, n)
+	for i := 0; i < n; i++ {
+		j := int(r.uint64n(uint64(i + 1)))
+		m[i] = m[j]
+		m[j] = i
+	}
+	return m
+}
+
+// uint64n returns a uniformly distributed uint64 number in [0, n) using rejection sampling,
+// which avoids the modulo bias. The `n` should be greater than 0.
+func (r SecureRand) uint64n(n uint64) uint64 {
+	if n&(n-1) == 0 {
+		// Power of two.
+		return binary.LittleEndian.Uint64(r.B(8)) & (n - 1)
+	}
+	// The max value that is a multiple of n, the numbers above which are rejected.
+	limit := ^uint64(0) - (^uint64(0)%n+1)%n
+	for {
+		v := binary.LittleEndian.Uint64(r.B(8))
+		if v <= limit {
+			return v % n
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grand
+
+import (
+	"encoding/binary"
+)
+
+// Weighted randomly picks and returns an index of `weights` with probability proportional to its weight.
+// The negative weight is considered as 0.
+// It returns -1 if `weights` is empty or all the weights are 0.
+//
+// Eg: Weighted([]int{1, 3}) returns 1 in probability of 75%.
+func Weighted(weights []int) int {
+	var total uint64
+	for _, w := range weights {
+		if w > 0 {
+			total += uint64(w)
+		}
+	}
+	if total == 0 {
+		return -1
+	}
+	n := uint64n(total)
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		if n < uint64(w) {
+			return i
+		}
+		n -= uint64(w)
+	}
+	return -1
+}
+
+// WeightedSample randomly picks and returns `k` distinct indexes of `weights` without replacement,
+// in which each pick is in probability proportional to the weight among the ones not picked yet.
+// The indexes of zero or negative weight are never picked, so the returned count might be less than `k`.
+func WeightedSample(weights []int, k int) []int {
+	if k <= 0 {
+		return []int{}
+	}
+	var (
+		remaining = make([]int, len(weights))
+		picked    = make([]int, 0, k)
+	)
+	copy(remaining, weights)
+	for len(picked) < k {
+		index := Weighted(remaining)
+		if index < 0 {
+			break
+		}
+		picked = append(picked, index)
+		remaining[index] = 0
+	}
+	return picked
+}
+
+// Sample randomly picks and returns `k` distinct int numbers from [0, n) without replacement.
+// It returns all the numbers in random order if `k` is greater than `n`.
+func Sample(n, k int) []int {
+	if k > n {
+		k = n
+	}
+	if k <= 0 {
+		return []int{}
+	}
+	// Partial Fisher-Yates shuffle, which uses map for sparse swapping
+	// to avoid allocating the whole [0, n) slice for large `n`.
+	var (
+		result  = make([]int, k)
+		swapped = make(map[int]int, k)
+	)
+	for i := 0; i < k; i++ {
+		j := i + Intn(n-i)
+		vj, ok := swapped[j]
+		if !ok {
+			vj = j
+		}
+		vi, ok := swapped[i]
+		if !ok {
+			vi = i
+		}
+		result[i] = vj
+		swapped[j] = vi
+	}
+	return result
+}
+
+// uint64n returns a uint64 number in [0, n), which supports n beyond the 32 bits limit of Intn.
+func uint64n(n uint64) uint64 {
+	if n <= 1<<31 {
+		return uint64(Intn(int(n)))
+	}
+	return binary.LittleEndian.Uint64(B(8)) % n
+}
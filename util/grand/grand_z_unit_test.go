@@ -241,3 +241,79 @@ func Test_Symbols(t *testing.T) {
 		t.Assert(grand.Symbols(0), "")
 	})
 }
+
+func Test_Secure(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(len(grand.Secure.B(0)), 0)
+		t.Assert(len(grand.Secure.B(33)), 33)
+		t.AssertNE(grand.Secure.B(16), grand.Secure.B(16))
+		for i := 0; i < 1000; i++ {
+			n := grand.Secure.Intn(3)
+			t.AssertIN(n, []int{0, 1, 2})
+			t.AssertIN(grand.Secure.N(-1, 1), []int{-1, 0, 1})
+		}
+		t.Assert(grand.Secure.Intn(0), 0)
+		t.Assert(grand.Secure.N(5, 5), 5)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(len(grand.Secure.S(10)), 10)
+		t.Assert(gstr.IsNumeric(grand.Secure.Digits(10)), true)
+		t.Assert(len(grand.Secure.Digits(6)), 6)
+		t.Assert(len(grand.Secure.Hex(16)), 32)
+		t.Assert(len(grand.Secure.Token(32)), 43)
+		t.Assert(strings.ContainsAny(grand.Secure.Token(64), "+/="), false)
+		t.Assert(grand.Secure.Str("", 10), "")
+		t.Assert(grand.Secure.Str("我", 3), "我我我")
+		t.Assert(len(grand.Secure.Perm(10)), 10)
+	})
+}
+
+func Test_Weighted(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(grand.Weighted(nil), -1)
+		t.Assert(grand.Weighted([]int{0, -1}), -1)
+		t.Assert(grand.Weighted([]int{0, 5, 0}), 1)
+		counts := make([]int, 2)
+		for i := 0; i < 10000; i++ {
+			counts[grand.Weighted([]int{1, 3})]++
+		}
+		// The probability of index 1 is 75%.
+		t.AssertGT(counts[1], 7000)
+		t.AssertLT(counts[1], 8000)
+	})
+}
+
+func Test_WeightedSample(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(grand.WeightedSample([]int{1, 2}, 0), []int{})
+		t.Assert(grand.WeightedSample([]int{0, 2, 0}, 3), []int{1})
+		for i := 0; i < 100; i++ {
+			picked := grand.WeightedSample([]int{1, 2, 3, 0}, 3)
+			t.Assert(len(picked), 3)
+			t.AssertNI(3, picked)
+			t.AssertNE(picked[0], picked[1])
+			t.AssertNE(picked[1], picked[2])
+			t.AssertNE(picked[0], picked[2])
+		}
+	})
+}
+
+func Test_Sample(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(grand.Sample(10, 0), []int{})
+		t.Assert(len(grand.Sample(3, 5)), 3)
+		for i := 0; i < 100; i++ {
+			var (
+				picked = grand.Sample(1000000, 100)
+				seen   = make(map[int]struct{})
+			)
+			t.Assert(len(picked), 100)
+			for _, v := range picked {
+				t.AssertGE(v, 0)
+				t.AssertLT(v, 1000000)
+				seen[v] = struct{}{}
+			}
+			t.Assert(len(seen), 100)
+		}
+	})
+}
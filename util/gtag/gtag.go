@@ -11,28 +11,45 @@
 package gtag
 
 import (
+	"fmt"
 	"regexp"
+	"runtime"
+	"sort"
+	"strings"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 )
 
+// Tag is a registered tag content, which is used for enumeration by tooling like OpenAPI and docs generation.
+type Tag struct {
+	Key       string // Full name for retrieving, which is "Namespace.Name" if Namespace is not empty.
+	Namespace string // Namespace that the tag is registered in, empty for global registration.
+	Name      string // Name in the namespace.
+	Value     string // Tag content.
+	Location  string // Source location "file:line" of the registration.
+}
+
 var (
-	data  = make(map[string]string)
+	data  = make(map[string]Tag)
 	regex = regexp.MustCompile(`\{(.+?)\}`)
+	// callerFilters are used to filter the registration calls of this package to retrieve the registration location.
+	callerFilters = []string{"/util/gtag/gtag.go", "/util/gtag/gtag_namespace.go"}
+)
+
+const (
+	// maxCallerDepth is the max depth of stack for retrieving the registration location.
+	maxCallerDepth = 10
 )
 
 // Set sets tag content for specified name.
 // Note that it panics if `name` already exists.
 func Set(name, value string) {
-	if _, ok := data[name]; ok {
-		panic(gerror.Newf(`value for tag name "%s" already exists`, name))
-	}
-	data[name] = value
+	doSet("", name, value, false)
 }
 
 // SetOver performs as Set, but it overwrites the old value if `name` already exists.
 func SetOver(name, value string) {
-	data[name] = value
+	doSet("", name, value, true)
 }
 
 // Sets sets multiple tag content by map.
@@ -51,7 +68,19 @@ func SetsOver(m map[string]string) {
 
 // Get retrieves and returns the stored tag content for specified name.
 func Get(name string) string {
-	return data[name]
+	return data[name].Value
+}
+
+// List returns all the registered tags in ascending order of their keys.
+func List() []Tag {
+	tags := make([]Tag, 0, len(data))
+	for _, tag := range data {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Key < tags[j].Key
+	})
+	return tags
 }
 
 // Parse parses and returns the content by replacing all tag name variable to
@@ -61,9 +90,53 @@ func Get(name string) string {
 // Parse(`This is {demo}`) -> `This is content`.
 func Parse(content string) string {
 	return regex.ReplaceAllStringFunc(content, func(s string) string {
-		if v, ok := data[s[1:len(s)-1]]; ok {
-			return v
+		if tag, ok := data[s[1:len(s)-1]]; ok {
+			return tag.Value
 		}
 		return s
 	})
 }
+
+// doSet registers tag content `value` for `name` in `namespace`.
+// It panics if the tag already exists and `overwrite` is false.
+func doSet(namespace, name, value string, overwrite bool) {
+	var (
+		key      = namespaceKey(namespace, name)
+		location = callerLocation()
+	)
+	if old, ok := data[key]; ok && !overwrite {
+		panic(gerror.Newf(
+			`value for tag name "%s" already exists: "%s" registered at %s, redefined with "%s" at %s`,
+			key, old.Value, old.Location, value, location,
+		))
+	}
+	data[key] = Tag{
+		Key:       key,
+		Namespace: namespace,
+		Name:      name,
+		Value:     value,
+		Location:  location,
+	}
+}
+
+// callerLocation returns the source location "file:line" of the caller out of this package.
+func callerLocation() string {
+	pcs := make([]uintptr, maxCallerDepth)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		filtered := false
+		for _, filter := range callerFilters {
+			if strings.HasSuffix(frame.File, filter) {
+				filtered = true
+				break
+			}
+		}
+		if !filtered {
+			return fmt.Sprintf(`%s:%d`, frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtag
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Namespace is a named set of tags, which is usually used by a package to register its tags
+// without name conflicts with other packages. The tag `name` in namespace `ns` is retrieved
+// by key "ns.name", eg: gtag.Get("user.nameDc") or `dc:"{user.nameDc}"`.
+type Namespace struct {
+	name string
+}
+
+const (
+	// NamespaceSeparator is the separator between namespace and tag name in the tag key.
+	NamespaceSeparator = "."
+)
+
+// NewNamespace creates and returns a tag namespace of given `name`.
+// It panics if `name` is empty or contains any of the characters "{}".
+func NewNamespace(name string) *Namespace {
+	if name == "" || strings.ContainsAny(name, "{}") {
+		panic(gerror.Newf(`invalid tag namespace "%s"`, name))
+	}
+	return &Namespace{name: name}
+}
+
+// Name returns the name of the namespace.
+func (n *Namespace) Name() string {
+	return n.name
+}
+
+// Key returns the full key of tag `name` in the namespace, which can be used by Get and Parse.
+func (n *Namespace) Key(name string) string {
+	return namespaceKey(n.name, name)
+}
+
+// Set sets tag content for specified name in the namespace.
+// Note that it panics if `name` already exists with different value, which is a conflicting redefinition.
+func (n *Namespace) Set(name, value string) {
+	doSet(n.name, name, value, false)
+}
+
+// SetOver performs as Set, but it overwrites the old value if `name` already exists.
+func (n *Namespace) SetOver(name, value string) {
+	doSet(n.name, name, value, true)
+}
+
+// Sets sets multiple tag content by map in the namespace.
+func (n *Namespace) Sets(m map[string]string) {
+	for k, v := range m {
+		n.Set(k, v)
+	}
+}
+
+// SetsOver performs as Sets, but it overwrites the old value if `name` already exists.
+func (n *Namespace) SetsOver(m map[string]string) {
+	for k, v := range m {
+		n.SetOver(k, v)
+	}
+}
+
+// Get retrieves and returns the stored tag content for specified name in the namespace.
+func (n *Namespace) Get(name string) string {
+	return Get(n.Key(name))
+}
+
+// List returns all the tags registered in the namespace in ascending order of their names.
+func (n *Namespace) List() []Tag {
+	tags := make([]Tag, 0)
+	for _, tag := range List() {
+		if tag.Namespace == n.name {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Namespaces returns the names of all namespaces that have registered tags in ascending order.
+func Namespaces() []string {
+	var (
+		names = make([]string, 0)
+		seen  = make(map[string]struct{})
+	)
+	for _, tag := range data {
+		if tag.Namespace == "" {
+			continue
+		}
+		if _, ok := seen[tag.Namespace]; !ok {
+			seen[tag.Namespace] = struct{}{}
+			names = append(names, tag.Namespace)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// namespaceKey returns the full key of tag `name` in `namespace`.
+func namespaceKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + NamespaceSeparator + name
+}
@@ -8,6 +8,7 @@ package gtag_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/gogf/gf/v2/frame/g"
@@ -134,3 +135,96 @@ func Test_Parse(t *testing.T) {
 		t.Assert(gtag.Parse(content), expect)
 	})
 }
+
+func Test_Set_Redefinition(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			k = guid.S()
+			v = guid.S()
+		)
+		// Identical redefinition also panics.
+		gtag.Set(k, v)
+		defer func() {
+			t.AssertNE(recover(), nil)
+			t.Assert(gtag.Get(k), v)
+		}()
+		gtag.Set(k, v)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		k := guid.S()
+		gtag.Set(k, "v1")
+		defer func() {
+			err := recover()
+			t.AssertNE(err, nil)
+			// The conflict message contains both registration locations.
+			t.Assert(strings.Count(fmt.Sprint(err), "gtag_z_unit_test.go"), 2)
+		}()
+		gtag.Set(k, "v2")
+	})
+}
+
+func Test_Namespace(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			name = guid.S()
+			ns   = gtag.NewNamespace(name)
+		)
+		t.Assert(ns.Name(), name)
+		ns.Sets(g.MapStrStr{
+			"b": "vb",
+			"a": "va",
+		})
+		t.Assert(ns.Get("a"), "va")
+		t.Assert(gtag.Get(name+".b"), "vb")
+		t.Assert(ns.Key("a"), name+".a")
+		t.Assert(gtag.Parse(fmt.Sprintf(`{%s.a} and {%s.b}`, name, name)), "va and vb")
+		t.AssertIN(name, gtag.Namespaces())
+
+		tags := ns.List()
+		t.Assert(len(tags), 2)
+		t.Assert(tags[0].Key, name+".a")
+		t.Assert(tags[0].Namespace, name)
+		t.Assert(tags[0].Name, "a")
+		t.Assert(tags[0].Value, "va")
+		t.Assert(strings.Contains(tags[0].Location, "gtag_z_unit_test.go"), true)
+
+		// Different namespaces do not conflict.
+		other := gtag.NewNamespace(guid.S())
+		other.Set("a", "other")
+		t.Assert(other.Get("a"), "other")
+		t.Assert(ns.Get("a"), "va")
+
+		ns.SetOver("a", "new")
+		t.Assert(ns.Get("a"), "new")
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		ns.Set("b", "conflict")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		gtag.NewNamespace("")
+	})
+}
+
+func Test_List(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		k := guid.S()
+		gtag.Set(k, "value")
+		tags := gtag.List()
+		for i := 1; i < len(tags); i++ {
+			t.Assert(tags[i-1].Key < tags[i].Key, true)
+		}
+		found := false
+		for _, tag := range tags {
+			if tag.Key == k {
+				found = true
+				t.Assert(tag.Namespace, "")
+				t.Assert(tag.Value, "value")
+			}
+		}
+		t.Assert(found, true)
+	})
+}
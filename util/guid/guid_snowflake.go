@@ -0,0 +1,198 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package guid
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/command"
+	"github.com/gogf/gf/v2/net/gipv4"
+)
+
+// Snowflake is a generator for Snowflake ids, which are 63 bits positive int64 composed with:
+// milliseconds since epoch(41 bits) + worker id(10 bits) + sequence in the same millisecond(12 bits).
+// It is concurrent-safe.
+type Snowflake struct {
+	mu         sync.Mutex
+	epoch      int64         // Epoch in unix milliseconds.
+	workerId   int64         // Worker id in range [0, 1023].
+	maxBackoff time.Duration // Max tolerated clock moving backward, which is waited for.
+	lastMilli  int64         // Milliseconds since epoch of last generated id.
+	sequence   int64         // Sequence of last generated id in the same millisecond.
+}
+
+// SnowflakeOption is the option for creating Snowflake generator.
+type SnowflakeOption struct {
+	// Epoch is the start time of timestamp part, default is 2020-01-01 00:00:00 UTC.
+	// The generator works for about 69 years since the epoch.
+	Epoch time.Time
+
+	// WorkerId is the unique worker id in range [0, 1023] among all the generating processes.
+	// If it is not specified, it is retrieved from command option or environment "gf.guid.worker.id",
+	// or else it is computed from the last 10 bits of the intranet IPv4 address.
+	WorkerId *int64
+
+	// MaxClockBackward is the max tolerated duration that the system clock moves backward,
+	// in which the generator waits for the clock catching up, default is 10ms.
+	// If the clock moves backward more than it, NextId returns error to avoid duplicated ids.
+	MaxClockBackward time.Duration
+}
+
+const (
+	snowflakeWorkerIdBits      = 10
+	snowflakeSequenceBits      = 12
+	snowflakeMaxWorkerId       = 1<<snowflakeWorkerIdBits - 1
+	snowflakeMaxSequence       = 1<<snowflakeSequenceBits - 1
+	snowflakeTimestampShift    = snowflakeWorkerIdBits + snowflakeSequenceBits
+	snowflakeWorkerIdShift     = snowflakeSequenceBits
+	defaultSnowflakeMaxBackoff = 10 * time.Millisecond
+
+	// commandEnvKeyForWorkerId is the command option or environment key for worker id of Snowflake.
+	commandEnvKeyForWorkerId = "gf.guid.worker.id"
+)
+
+var (
+	// defaultSnowflakeEpoch is 2020-01-01 00:00:00 UTC.
+	defaultSnowflakeEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// NewSnowflake creates and returns a Snowflake id generator.
+func NewSnowflake(option ...SnowflakeOption) (*Snowflake, error) {
+	var (
+		opt SnowflakeOption
+		err error
+	)
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Epoch.IsZero() {
+		opt.Epoch = defaultSnowflakeEpoch
+	}
+	if opt.Epoch.After(time.Now()) {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `epoch "%s" is in the future`, opt.Epoch)
+	}
+	if opt.MaxClockBackward <= 0 {
+		opt.MaxClockBackward = defaultSnowflakeMaxBackoff
+	}
+	var workerId int64
+	if opt.WorkerId != nil {
+		workerId = *opt.WorkerId
+	} else if workerId, err = getDefaultSnowflakeWorkerId(); err != nil {
+		return nil, err
+	}
+	if workerId < 0 || workerId > snowflakeMaxWorkerId {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`invalid worker id %d, it should be in range [0, %d]`,
+			workerId, snowflakeMaxWorkerId,
+		)
+	}
+	return &Snowflake{
+		epoch:      opt.Epoch.UnixNano() / int64(time.Millisecond),
+		workerId:   workerId,
+		maxBackoff: opt.MaxClockBackward,
+		lastMilli:  -1,
+	}, nil
+}
+
+// WorkerId returns the worker id of the generator.
+func (s *Snowflake) WorkerId() int64 {
+	return s.workerId
+}
+
+// NextId generates and returns the next unique id.
+// It returns error if the system clock moves backward more than the tolerated duration.
+func (s *Snowflake) NextId() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	milli := s.currentMilli()
+	if milli < s.lastMilli {
+		backward := time.Duration(s.lastMilli-milli) * time.Millisecond
+		if backward > s.maxBackoff {
+			return 0, gerror.NewCodef(
+				gcode.CodeOperationFailed,
+				`clock moved backward by %s, refusing to generate id`,
+				backward,
+			)
+		}
+		// Waits for the clock catching up the last millisecond.
+		milli = s.waitNextMilli(s.lastMilli - 1)
+	}
+	if milli == s.lastMilli {
+		s.sequence = (s.sequence + 1) & snowflakeMaxSequence
+		if s.sequence == 0 {
+			// Sequence exhausted in current millisecond, waits for the next millisecond.
+			milli = s.waitNextMilli(s.lastMilli)
+		}
+	} else {
+		s.sequence = 0
+	}
+	s.lastMilli = milli
+	return milli<<snowflakeTimestampShift | s.workerId<<snowflakeWorkerIdShift | s.sequence, nil
+}
+
+// MustNextId performs as NextId, but it panics if any error occurs.
+func (s *Snowflake) MustNextId() int64 {
+	id, err := s.NextId()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Parse parses the id generated by the generator and returns its creation time, worker id and sequence.
+func (s *Snowflake) Parse(id int64) (createdAt time.Time, workerId int64, sequence int64) {
+	milli := id>>snowflakeTimestampShift + s.epoch
+	createdAt = time.Unix(0, milli*int64(time.Millisecond))
+	workerId = (id >> snowflakeWorkerIdShift) & snowflakeMaxWorkerId
+	sequence = id & snowflakeMaxSequence
+	return
+}
+
+// currentMilli returns the milliseconds since epoch.
+func (s *Snowflake) currentMilli() int64 {
+	return time.Now().UnixNano()/int64(time.Millisecond) - s.epoch
+}
+
+// waitNextMilli blocks until the milliseconds since epoch is greater than `milli`.
+func (s *Snowflake) waitNextMilli(milli int64) int64 {
+	current := s.currentMilli()
+	for current <= milli {
+		time.Sleep(time.Duration(milli-current+1) * time.Millisecond / 2)
+		current = s.currentMilli()
+	}
+	return current
+}
+
+// getDefaultSnowflakeWorkerId retrieves worker id from command option or environment,
+// or computes it from intranet IPv4 address.
+func getDefaultSnowflakeWorkerId() (int64, error) {
+	if v := command.GetOptWithEnv(commandEnvKeyForWorkerId); v != "" {
+		workerId, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, gerror.WrapCodef(gcode.CodeInvalidConfiguration, err, `invalid worker id "%s"`, v)
+		}
+		return workerId, nil
+	}
+	ipStr, err := gipv4.GetIntranetIp()
+	if err != nil {
+		return 0, gerror.WrapCode(
+			gcode.CodeMissingConfiguration, err,
+			`worker id is not configured and cannot be computed from intranet IP`,
+		)
+	}
+	ip := net.ParseIP(ipStr).To4()
+	if ip == nil {
+		return 0, gerror.NewCodef(gcode.CodeInvalidConfiguration, `invalid intranet IP "%s"`, ipStr)
+	}
+	return (int64(ip[2])<<8 | int64(ip[3])) & snowflakeMaxWorkerId, nil
+}
@@ -0,0 +1,151 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package guid
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// UUID is a standard UUID defined in RFC 9562 (formerly RFC 4122).
+type UUID [16]byte
+
+const (
+	uuidStrLength = 36 // Length of canonical UUID string like "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx".
+)
+
+var (
+	// uuidV7Mu protects the monotonic state of UUID v7 generation.
+	uuidV7Mu sync.Mutex
+	// uuidV7LastMilli is the unix milliseconds of last generated UUID v7.
+	uuidV7LastMilli int64
+	// uuidV7Counter is the 12 bits counter for UUID v7 generated in the same millisecond,
+	// which keeps the UUID v7 monotonic in current process.
+	uuidV7Counter uint16
+)
+
+// UUIDv4 creates and returns a random UUID version 4 string in canonical format,
+// like "f47ac10b-58cc-4372-a567-0e02b2c3d479".
+func UUIDv4() string {
+	return NewUUIDv4().String()
+}
+
+// UUIDv7 creates and returns a time-ordered UUID version 7 string in canonical format,
+// like "018f3e2c-5b7a-7cc3-9a1e-2f4d6b8a0c1e".
+// The UUID v7 strings generated in current process are in ascending order.
+func UUIDv7() string {
+	return NewUUIDv7().String()
+}
+
+// NewUUIDv4 creates and returns a random UUID version 4.
+func NewUUIDv4() UUID {
+	var u UUID
+	copy(u[:], grand.B(16))
+	u.setVersionAndVariant(4)
+	return u
+}
+
+// NewUUIDv7 creates and returns a time-ordered UUID version 7, which is composed with
+// unix milliseconds(48 bits), a counter for the same millisecond(12 bits) and random bits(62 bits).
+func NewUUIDv7() UUID {
+	var (
+		u       UUID
+		milli   = time.Now().UnixNano() / int64(time.Millisecond)
+		counter uint16
+	)
+	uuidV7Mu.Lock()
+	if milli <= uuidV7LastMilli {
+		// The clock does not move forward, it increases the counter of last millisecond.
+		milli = uuidV7LastMilli
+		uuidV7Counter++
+		if uuidV7Counter > 0x0FFF {
+			// The counter overflows, it borrows the next millisecond.
+			milli++
+			uuidV7Counter = 0
+		}
+	} else {
+		// Random counter start for new millisecond, leaving enough space for increasing.
+		uuidV7Counter = binary.BigEndian.Uint16(grand.B(2)) & 0x07FF
+	}
+	uuidV7LastMilli = milli
+	counter = uuidV7Counter
+	uuidV7Mu.Unlock()
+
+	copy(u[8:], grand.B(8))
+	u[0] = byte(milli >> 40)
+	u[1] = byte(milli >> 32)
+	u[2] = byte(milli >> 24)
+	u[3] = byte(milli >> 16)
+	u[4] = byte(milli >> 8)
+	u[5] = byte(milli)
+	u[6] = byte(counter >> 8)
+	u[7] = byte(counter)
+	u.setVersionAndVariant(7)
+	return u
+}
+
+// ParseUUID parses `s` in canonical format, or 32 hex digits without hyphens, as UUID.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	switch len(s) {
+	case uuidStrLength:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return u, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid UUID format "%s"`, s)
+		}
+		s = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	case 32:
+	default:
+		return u, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid UUID length "%s"`, s)
+	}
+	if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+		return u, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid UUID "%s"`, s)
+	}
+	return u, nil
+}
+
+// String returns the UUID in canonical format "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx".
+func (u UUID) String() string {
+	b := make([]byte, uuidStrLength)
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b)
+}
+
+// Version returns the version of the UUID, eg: 4 or 7.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Time returns the creation time of UUID version 7 in millisecond precision.
+// It returns zero time if the UUID is not version 7.
+func (u UUID) Time() time.Time {
+	if u.Version() != 7 {
+		return time.Time{}
+	}
+	milli := int64(u[0])<<40 | int64(u[1])<<32 | int64(u[2])<<24 |
+		int64(u[3])<<16 | int64(u[4])<<8 | int64(u[5])
+	return time.Unix(0, milli*int64(time.Millisecond))
+}
+
+// setVersionAndVariant sets the version bits and the RFC 9562 variant bits of the UUID.
+func (u *UUID) setVersionAndVariant(version byte) {
+	u[6] = (u[6] & 0x0F) | (version << 4)
+	u[8] = (u[8] & 0x3F) | 0x80
+}
@@ -9,7 +9,9 @@
 package guid_test
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gogf/gf/v2/container/gset"
 	"github.com/gogf/gf/v2/test/gtest"
@@ -32,3 +34,108 @@ func Test_S_Data(t *testing.T) {
 		t.Assert(len(guid.S([]byte("123"))), 32)
 	})
 }
+
+func Test_UUIDv4(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		set := gset.NewStrSet()
+		for i := 0; i < 10000; i++ {
+			s := guid.UUIDv4()
+			t.Assert(set.AddIfNotExist(s), true)
+			t.Assert(len(s), 36)
+			t.Assert(s[14:15], "4")
+			t.AssertIN(s[19:20], []string{"8", "9", "a", "b"})
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		u := guid.NewUUIDv4()
+		t.Assert(u.Version(), 4)
+		t.Assert(u.Time().IsZero(), true)
+		parsed, err := guid.ParseUUID(u.String())
+		t.AssertNil(err)
+		t.Assert(parsed, u)
+	})
+}
+
+func Test_UUIDv7(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			last  string
+			start = time.Now().Add(-time.Millisecond)
+		)
+		for i := 0; i < 10000; i++ {
+			s := guid.UUIDv7()
+			t.Assert(len(s), 36)
+			t.Assert(s[14:15], "7")
+			t.Assert(s > last, true)
+			last = s
+		}
+		u, err := guid.ParseUUID(last)
+		t.AssertNil(err)
+		t.Assert(u.Version(), 7)
+		t.Assert(u.Time().Before(start), false)
+		t.Assert(u.Time().After(time.Now().Add(time.Second)), false)
+	})
+}
+
+func Test_ParseUUID(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		u, err := guid.ParseUUID("F47AC10B58CC4372A5670E02B2C3D479")
+		t.AssertNil(err)
+		t.Assert(u.String(), "f47ac10b-58cc-4372-a567-0e02b2c3d479")
+		_, err = guid.ParseUUID("f47ac10b-58cc-4372-a567-0e02b2c3d47")
+		t.AssertNE(err, nil)
+		_, err = guid.ParseUUID("f47ac10b_58cc_4372_a567_0e02b2c3d479")
+		t.AssertNE(err, nil)
+		_, err = guid.ParseUUID("x47ac10b-58cc-4372-a567-0e02b2c3d479")
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Snowflake(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		workerId := int64(1023)
+		sf, err := guid.NewSnowflake(guid.SnowflakeOption{WorkerId: &workerId})
+		t.AssertNil(err)
+		t.Assert(sf.WorkerId(), 1023)
+		var (
+			last  int64
+			start = time.Now().Add(-time.Millisecond)
+		)
+		for i := 0; i < 100000; i++ {
+			id := sf.MustNextId()
+			t.Assert(id > last, true)
+			last = id
+		}
+		createdAt, parsedWorkerId, _ := sf.Parse(last)
+		t.Assert(parsedWorkerId, 1023)
+		t.Assert(createdAt.Before(start), false)
+		t.Assert(createdAt.After(time.Now()), false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg       sync.WaitGroup
+			set      = gset.NewSet(true)
+			workerId = int64(1)
+		)
+		sf, err := guid.NewSnowflake(guid.SnowflakeOption{WorkerId: &workerId})
+		t.AssertNil(err)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 10000; j++ {
+					set.Add(sf.MustNextId())
+				}
+			}()
+		}
+		wg.Wait()
+		t.Assert(set.Size(), 100000)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		workerId := int64(1024)
+		_, err := guid.NewSnowflake(guid.SnowflakeOption{WorkerId: &workerId})
+		t.AssertNE(err, nil)
+		_, err = guid.NewSnowflake(guid.SnowflakeOption{Epoch: time.Now().Add(time.Hour)})
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,216 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gutil
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// MapKeys returns all keys of map `m` as slice in ascending order.
+// The numeric keys are sorted by their numeric values and the others by their string values.
+// It returns nil if `m` is not a map.
+// Eg:
+// MapKeys(map[string]int{"b": 2, "a": 1}) => ["a", "b"]
+func MapKeys(m interface{}) []interface{} {
+	reflectValue, ok := reflectValueOfKind(m, reflect.Map)
+	if !ok {
+		return nil
+	}
+	var (
+		mapKeys = sortedMapKeys(reflectValue)
+		keys    = make([]interface{}, len(mapKeys))
+	)
+	for i, key := range mapKeys {
+		keys[i] = key.Interface()
+	}
+	return keys
+}
+
+// MapValues returns all values of map `m` as slice in ascending order of their keys,
+// see MapKeys.
+// It returns nil if `m` is not a map.
+// Eg:
+// MapValues(map[string]int{"b": 2, "a": 1}) => [1, 2]
+func MapValues(m interface{}) []interface{} {
+	reflectValue, ok := reflectValueOfKind(m, reflect.Map)
+	if !ok {
+		return nil
+	}
+	var (
+		mapKeys = sortedMapKeys(reflectValue)
+		values  = make([]interface{}, len(mapKeys))
+	)
+	for i, key := range mapKeys {
+		values[i] = reflectValue.MapIndex(key).Interface()
+	}
+	return values
+}
+
+// Filter returns a new slice containing the elements of slice `slice` that `f` returns true for,
+// keeping their original order.
+// It returns nil if `slice` is not a slice or an array.
+// Eg:
+// Filter([]int{1, 2, 3, 4}, func(v interface{}) bool { return v.(int)%2 == 0 }) => [2, 4]
+func Filter(slice interface{}, f func(v interface{}) bool) []interface{} {
+	reflectValue, ok := reflectValueOfKind(slice, reflect.Slice, reflect.Array)
+	if !ok {
+		return nil
+	}
+	result := make([]interface{}, 0)
+	for i := 0; i < reflectValue.Len(); i++ {
+		if v := reflectValue.Index(i).Interface(); f(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// MapTo returns a new slice containing the results of applying `f` to each element of slice `slice`.
+// It returns nil if `slice` is not a slice or an array.
+// Eg:
+// MapTo([]int{1, 2}, func(v interface{}) interface{} { return v.(int) * 10 }) => [10, 20]
+func MapTo(slice interface{}, f func(v interface{}) interface{}) []interface{} {
+	reflectValue, ok := reflectValueOfKind(slice, reflect.Slice, reflect.Array)
+	if !ok {
+		return nil
+	}
+	result := make([]interface{}, reflectValue.Len())
+	for i := range result {
+		result[i] = f(reflectValue.Index(i).Interface())
+	}
+	return result
+}
+
+// GroupBy groups the elements of slice `slice` by the key returned by `key` function.
+// The elements in each group keep their original order in `slice`.
+// Use MapKeys on the result to iterate the groups in deterministic order.
+// It returns nil if `slice` is not a slice or an array.
+// Eg:
+// GroupBy([]int{1, 2, 3}, func(v interface{}) interface{} { return v.(int) % 2 }) => {0: [2], 1: [1, 3]}
+func GroupBy(slice interface{}, key func(v interface{}) interface{}) map[interface{}][]interface{} {
+	reflectValue, ok := reflectValueOfKind(slice, reflect.Slice, reflect.Array)
+	if !ok {
+		return nil
+	}
+	groups := make(map[interface{}][]interface{})
+	for i := 0; i < reflectValue.Len(); i++ {
+		var (
+			v = reflectValue.Index(i).Interface()
+			k = key(v)
+		)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Chunk splits slice `slice` into chunks of `size`, the last chunk might be smaller than `size`.
+// It returns nil if `slice` is not a slice or an array, or `size` is not greater than 0.
+// Eg:
+// Chunk([]int{1, 2, 3}, 2) => [[1, 2], [3]]
+func Chunk(slice interface{}, size int) [][]interface{} {
+	if size <= 0 {
+		return nil
+	}
+	reflectValue, ok := reflectValueOfKind(slice, reflect.Slice, reflect.Array)
+	if !ok {
+		return nil
+	}
+	var (
+		length = reflectValue.Len()
+		chunks = make([][]interface{}, 0, (length+size-1)/size)
+	)
+	for i := 0; i < length; i += size {
+		end := i + size
+		if end > length {
+			end = length
+		}
+		chunk := make([]interface{}, 0, end-i)
+		for j := i; j < end; j++ {
+			chunk = append(chunk, reflectValue.Index(j).Interface())
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// reflectValueOfKind returns the reflect value of `value` if its kind is one of `kinds`,
+// the pointers are dereferenced.
+func reflectValueOfKind(value interface{}, kinds ...reflect.Kind) (reflect.Value, bool) {
+	reflectValue := reflect.ValueOf(value)
+	for reflectValue.Kind() == reflect.Ptr {
+		reflectValue = reflectValue.Elem()
+	}
+	for _, kind := range kinds {
+		if reflectValue.Kind() == kind {
+			return reflectValue, true
+		}
+	}
+	return reflectValue, false
+}
+
+// sortedMapKeys returns the keys of map `reflectValue` in ascending order.
+func sortedMapKeys(reflectValue reflect.Value) []reflect.Value {
+	keys := reflectValue.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		return compareMapKey(keys[i], keys[j]) < 0
+	})
+	return keys
+}
+
+// compareMapKey compares map keys `a` and `b`, the numeric keys are compared by their numeric
+// values and go before the others, which are compared by their string values.
+func compareMapKey(a, b reflect.Value) int {
+	if a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+	var (
+		aNumeric = isNumericKind(a.Kind())
+		bNumeric = isNumericKind(b.Kind())
+	)
+	switch {
+	case aNumeric && bNumeric:
+		aFloat, bFloat := gconv.Float64(a.Interface()), gconv.Float64(b.Interface())
+		if aFloat < bFloat {
+			return -1
+		} else if aFloat > bFloat {
+			return 1
+		}
+		return 0
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	}
+	if !a.IsValid() || !b.IsValid() {
+		// The nil key goes first.
+		if a.IsValid() {
+			return 1
+		} else if b.IsValid() {
+			return -1
+		}
+		return 0
+	}
+	return strings.Compare(gconv.String(a.Interface()), gconv.String(b.Interface()))
+}
+
+// isNumericKind checks whether `kind` is numeric kind.
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,316 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gutil
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// DiffOp is the operation of a DiffItem.
+type DiffOp string
+
+const (
+	DiffOpAdd    DiffOp = "add"    // The path is added in new value.
+	DiffOpRemove DiffOp = "remove" // The path is removed from old value.
+	DiffOpChange DiffOp = "change" // The value of path is changed.
+)
+
+const (
+	diffPathSeparator = "."
+)
+
+// DiffItem is a change of the specified path.
+type DiffItem struct {
+	Op   DiffOp      `json:"op"`            // Operation of the change.
+	Path string      `json:"path"`          // Path of the change, like "name", "address.city" or "items.0.price".
+	Old  interface{} `json:"old,omitempty"` // Old value, which is nil for DiffOpAdd.
+	New  interface{} `json:"new,omitempty"` // New value, which is nil for DiffOpRemove.
+}
+
+// DiffResult is the changeset produced by Diff, which is ordered by path.
+type DiffResult []DiffItem
+
+// IsEmpty checks and returns whether there's no change.
+func (d DiffResult) IsEmpty() bool {
+	return len(d) == 0
+}
+
+// Added returns the items of added paths.
+func (d DiffResult) Added() DiffResult {
+	return d.filter(DiffOpAdd)
+}
+
+// Removed returns the items of removed paths.
+func (d DiffResult) Removed() DiffResult {
+	return d.filter(DiffOpRemove)
+}
+
+// Changed returns the items of changed paths.
+func (d DiffResult) Changed() DiffResult {
+	return d.filter(DiffOpChange)
+}
+
+// Paths returns the paths of all the changes.
+func (d DiffResult) Paths() []string {
+	paths := make([]string, len(d))
+	for i, item := range d {
+		paths[i] = item.Path
+	}
+	return paths
+}
+
+func (d DiffResult) filter(op DiffOp) DiffResult {
+	result := make(DiffResult, 0)
+	for _, item := range d {
+		if item.Op == op {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Diff compares `old` and `new` and returns the changeset of added, removed and changed paths,
+// which is usually used for the audit logs of entity changes.
+//
+// The `old` and `new` can be maps or structs, which are compared recursively as maps converted by
+// gconv.MapDeep, so the attribute names of struct are its tag names if any, like "json" tag.
+// The slices are compared by indexes. The paths are joined with char '.', like "items.0.price".
+func Diff(old, new interface{}) DiffResult {
+	result := make(DiffResult, 0)
+	doDiff("", diffNormalize(old), diffNormalize(new), &result)
+	return result
+}
+
+// ApplyDiff applies changeset `diff` to `data`, which is a map or struct, and returns the result map.
+// The `data` is not changed. The result map can be converted to struct using gconv.Struct.
+//
+// It returns error if any path of DiffOpChange or DiffOpRemove does not exist in `data`.
+func ApplyDiff(data interface{}, diff DiffResult) (map[string]interface{}, error) {
+	var (
+		err   error
+		value = diffNormalize(data) // It creates new maps and slices, so `data` is not changed.
+	)
+	if value == nil {
+		value = make(map[string]interface{})
+	}
+	for _, item := range diff {
+		var keys []string
+		if item.Path != "" {
+			keys = strings.Split(item.Path, diffPathSeparator)
+		}
+		if value, err = applyDiffItem(value, keys, item); err != nil {
+			return nil, err
+		}
+	}
+	if m, ok := value.(map[string]interface{}); ok {
+		return m, nil
+	}
+	return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid data type "%T" for applying diff`, value)
+}
+
+// doDiff compares `old` and `new` of `path` and appends the changes to `result`.
+func doDiff(path string, old, new interface{}, result *DiffResult) {
+	switch oldValue := old.(type) {
+	case map[string]interface{}:
+		newValue, ok := new.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(oldValue)+len(newValue))
+		for k := range oldValue {
+			keys = append(keys, k)
+		}
+		for k := range newValue {
+			if _, ok = oldValue[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			var (
+				subPath        = diffJoinPath(path, k)
+				oldItem, inOld = oldValue[k]
+				newItem, inNew = newValue[k]
+			)
+			switch {
+			case !inNew:
+				*result = append(*result, DiffItem{Op: DiffOpRemove, Path: subPath, Old: oldItem})
+			case !inOld:
+				*result = append(*result, DiffItem{Op: DiffOpAdd, Path: subPath, New: newItem})
+			default:
+				doDiff(subPath, oldItem, newItem, result)
+			}
+		}
+		return
+
+	case []interface{}:
+		newValue, ok := new.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(oldValue) && i < len(newValue); i++ {
+			doDiff(diffJoinPath(path, strconv.Itoa(i)), oldValue[i], newValue[i], result)
+		}
+		for i := len(oldValue); i < len(newValue); i++ {
+			*result = append(*result, DiffItem{
+				Op: DiffOpAdd, Path: diffJoinPath(path, strconv.Itoa(i)), New: newValue[i],
+			})
+		}
+		// The removed elements are in descending order, so they can be applied one by one.
+		for i := len(oldValue) - 1; i >= len(newValue); i-- {
+			*result = append(*result, DiffItem{
+				Op: DiffOpRemove, Path: diffJoinPath(path, strconv.Itoa(i)), Old: oldValue[i],
+			})
+		}
+		return
+	}
+	if !diffEqual(old, new) {
+		*result = append(*result, DiffItem{Op: DiffOpChange, Path: path, Old: old, New: new})
+	}
+}
+
+// applyDiffItem applies `item` to the path `keys` of `value` and returns the new value.
+func applyDiffItem(value interface{}, keys []string, item DiffItem) (interface{}, error) {
+	if len(keys) == 0 {
+		if item.Op == DiffOpRemove {
+			return nil, nil
+		}
+		return item.New, nil
+	}
+	var (
+		key  = keys[0]
+		last = len(keys) == 1
+	)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		subValue, ok := v[key]
+		if last {
+			switch item.Op {
+			case DiffOpAdd:
+				v[key] = item.New
+			case DiffOpRemove:
+				if !ok {
+					return nil, diffPathNotFoundError(item)
+				}
+				delete(v, key)
+			default:
+				if !ok {
+					return nil, diffPathNotFoundError(item)
+				}
+				v[key] = item.New
+			}
+			return v, nil
+		}
+		if !ok {
+			return nil, diffPathNotFoundError(item)
+		}
+		subValue, err := applyDiffItem(subValue, keys[1:], item)
+		if err != nil {
+			return nil, err
+		}
+		v[key] = subValue
+		return v, nil
+
+	case []interface{}:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index > len(v) || (index == len(v) && !(last && item.Op == DiffOpAdd)) {
+			return nil, diffPathNotFoundError(item)
+		}
+		if last {
+			switch item.Op {
+			case DiffOpAdd:
+				return append(v[:index], append([]interface{}{item.New}, v[index:]...)...), nil
+			case DiffOpRemove:
+				return append(v[:index], v[index+1:]...), nil
+			default:
+				v[index] = item.New
+				return v, nil
+			}
+		}
+		if v[index], err = applyDiffItem(v[index], keys[1:], item); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	return nil, diffPathNotFoundError(item)
+}
+
+// diffNormalize converts `value` to the comparable value, in which the maps and structs are converted to
+// map[string]interface{}, and the slices are converted to []interface{}.
+func diffNormalize(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	reflectValue := reflect.ValueOf(value)
+	for reflectValue.Kind() == reflect.Ptr {
+		if reflectValue.IsNil() {
+			return nil
+		}
+		reflectValue = reflectValue.Elem()
+	}
+	switch reflectValue.Kind() {
+	case reflect.Map:
+		m := gconv.MapDeep(reflectValue.Interface())
+		for k, v := range m {
+			m[k] = diffNormalize(v)
+		}
+		return m
+
+	case reflect.Struct:
+		if _, ok := reflectValue.Interface().(time.Time); ok {
+			return value
+		}
+		m := gconv.MapDeep(reflectValue.Interface())
+		if len(m) == 0 {
+			return value
+		}
+		for k, v := range m {
+			m[k] = diffNormalize(v)
+		}
+		return m
+
+	case reflect.Slice, reflect.Array:
+		if _, ok := value.([]byte); ok {
+			return value
+		}
+		array := make([]interface{}, reflectValue.Len())
+		for i := 0; i < reflectValue.Len(); i++ {
+			array[i] = diffNormalize(reflectValue.Index(i).Interface())
+		}
+		return array
+	}
+	return value
+}
+
+// diffEqual checks whether leaf values `a` and `b` are equal.
+func diffEqual(a, b interface{}) bool {
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Equal(tb)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func diffJoinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + diffPathSeparator + key
+}
+
+func diffPathNotFoundError(item DiffItem) error {
+	return gerror.NewCodef(gcode.CodeInvalidParameter, `path "%s" not found for diff operation "%s"`, item.Path, item.Op)
+}
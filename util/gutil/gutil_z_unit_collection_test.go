@@ -0,0 +1,107 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gutil_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+func Test_MapKeys(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := map[string]int{"c": 3, "a": 1, "b": 2}
+		t.Assert(gutil.MapKeys(m), g.Slice{"a", "b", "c"})
+		t.Assert(gutil.MapKeys(&m), g.Slice{"a", "b", "c"})
+		t.Assert(gutil.MapValues(m), g.Slice{1, 2, 3})
+	})
+	// Numeric keys are sorted by numeric values.
+	gtest.C(t, func(t *gtest.T) {
+		m := map[int]string{10: "ten", 2: "two", -1: "minus"}
+		t.Assert(gutil.MapKeys(m), g.Slice{-1, 2, 10})
+		t.Assert(gutil.MapValues(m), g.Slice{"minus", "two", "ten"})
+	})
+	// Mixed keys.
+	gtest.C(t, func(t *gtest.T) {
+		m := map[interface{}]interface{}{"b": 1, 3: 2, "a": 3, 1.5: 4}
+		t.Assert(gutil.MapKeys(m), g.Slice{1.5, 3, "a", "b"})
+		t.Assert(gutil.MapValues(m), g.Slice{4, 2, 3, 1})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(len(gutil.MapKeys(map[string]int{})), 0)
+		t.AssertNil(gutil.MapKeys(nil))
+		t.AssertNil(gutil.MapValues([]int{1}))
+	})
+}
+
+func Test_Filter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := []int{1, 2, 3, 4, 5}
+		t.Assert(gutil.Filter(s, func(v interface{}) bool {
+			return v.(int)%2 == 1
+		}), g.Slice{1, 3, 5})
+		t.Assert(len(gutil.Filter(s, func(v interface{}) bool {
+			return false
+		})), 0)
+		t.AssertNil(gutil.Filter(1, func(v interface{}) bool {
+			return true
+		}))
+	})
+}
+
+func Test_MapTo(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := [3]int{1, 2, 3}
+		t.Assert(gutil.MapTo(s, func(v interface{}) interface{} {
+			return v.(int) * 10
+		}), g.Slice{10, 20, 30})
+		t.Assert(gutil.MapTo(&s, func(v interface{}) interface{} {
+			return g.NewVar(v).String()
+		}), g.Slice{"1", "2", "3"})
+		t.AssertNil(gutil.MapTo("abc", func(v interface{}) interface{} {
+			return v
+		}))
+	})
+}
+
+func Test_GroupBy(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		type User struct {
+			Name string
+			Age  int
+		}
+		users := []User{
+			{Name: "john", Age: 20},
+			{Name: "smith", Age: 30},
+			{Name: "alice", Age: 20},
+		}
+		groups := gutil.GroupBy(users, func(v interface{}) interface{} {
+			return v.(User).Age
+		})
+		t.Assert(len(groups), 2)
+		t.Assert(gutil.MapKeys(groups), g.Slice{20, 30})
+		t.Assert(groups[20], g.Slice{users[0], users[2]})
+		t.Assert(groups[30], g.Slice{users[1]})
+		t.AssertNil(gutil.GroupBy(nil, func(v interface{}) interface{} {
+			return v
+		}))
+	})
+}
+
+func Test_Chunk(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := []int{1, 2, 3, 4, 5}
+		t.Assert(gutil.Chunk(s, 2), [][]interface{}{{1, 2}, {3, 4}, {5}})
+		t.Assert(gutil.Chunk(s, 5), [][]interface{}{{1, 2, 3, 4, 5}})
+		t.Assert(gutil.Chunk(s, 10), [][]interface{}{{1, 2, 3, 4, 5}})
+		t.Assert(len(gutil.Chunk([]int{}, 2)), 0)
+		t.AssertNil(gutil.Chunk(s, 0))
+		t.AssertNil(gutil.Chunk(map[int]int{}, 2))
+	})
+}
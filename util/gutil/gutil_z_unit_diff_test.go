@@ -0,0 +1,124 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gutil_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+func Test_Diff_Map(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		old := g.Map{
+			"name":  "john",
+			"age":   18,
+			"email": "john@example.com",
+			"address": g.Map{
+				"city": "Beijing",
+				"zip":  "100000",
+			},
+		}
+		new := g.Map{
+			"name":  "john",
+			"age":   19,
+			"phone": "123456",
+			"address": g.Map{
+				"city": "Shanghai",
+				"zip":  "100000",
+			},
+		}
+		diff := gutil.Diff(old, new)
+		t.Assert(diff.Paths(), g.Slice{"address.city", "age", "email", "phone"})
+		t.Assert(diff.Added(), gutil.DiffResult{
+			{Op: gutil.DiffOpAdd, Path: "phone", New: "123456"},
+		})
+		t.Assert(diff.Removed(), gutil.DiffResult{
+			{Op: gutil.DiffOpRemove, Path: "email", Old: "john@example.com"},
+		})
+		t.Assert(diff.Changed(), gutil.DiffResult{
+			{Op: gutil.DiffOpChange, Path: "address.city", Old: "Beijing", New: "Shanghai"},
+			{Op: gutil.DiffOpChange, Path: "age", Old: 18, New: 19},
+		})
+		t.Assert(gutil.Diff(old, old).IsEmpty(), true)
+
+		result, err := gutil.ApplyDiff(old, diff)
+		t.AssertNil(err)
+		t.Assert(result, new)
+		t.Assert(old["age"], 18)
+		t.Assert(old["address"].(g.Map)["city"], "Beijing")
+	})
+}
+
+func Test_Diff_Struct(t *testing.T) {
+	type Item struct {
+		Name  string  `json:"name"`
+		Price float64 `json:"price"`
+	}
+	type Order struct {
+		Id     int     `json:"id"`
+		Status string  `json:"status"`
+		Items  []*Item `json:"items"`
+	}
+	gtest.C(t, func(t *gtest.T) {
+		old := &Order{
+			Id:     1,
+			Status: "created",
+			Items: []*Item{
+				{Name: "apple", Price: 1},
+				{Name: "pear", Price: 2},
+				{Name: "peach", Price: 3},
+			},
+		}
+		new := &Order{
+			Id:     1,
+			Status: "paid",
+			Items: []*Item{
+				{Name: "apple", Price: 1.5},
+			},
+		}
+		diff := gutil.Diff(old, new)
+		t.Assert(diff.Paths(), g.Slice{"items.0.price", "items.2", "items.1", "status"})
+
+		result, err := gutil.ApplyDiff(old, diff)
+		t.AssertNil(err)
+		var order *Order
+		t.AssertNil(gconv.Struct(result, &order))
+		t.Assert(order, new)
+		t.Assert(len(old.Items), 3)
+
+		// Reverse.
+		diff = gutil.Diff(new, old)
+		t.Assert(diff.Paths(), g.Slice{"items.0.price", "items.1", "items.2", "status"})
+		result, err = gutil.ApplyDiff(new, diff)
+		t.AssertNil(err)
+		t.AssertNil(gconv.Struct(result, &order))
+		t.Assert(order, old)
+	})
+}
+
+func Test_ApplyDiff_Error(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gutil.ApplyDiff(g.Map{"a": 1}, gutil.DiffResult{
+			{Op: gutil.DiffOpChange, Path: "b", Old: 1, New: 2},
+		})
+		t.AssertNE(err, nil)
+		_, err = gutil.ApplyDiff(g.Map{"a": g.Slice{1}}, gutil.DiffResult{
+			{Op: gutil.DiffOpRemove, Path: "a.1", Old: 1},
+		})
+		t.AssertNE(err, nil)
+		result, err := gutil.ApplyDiff(g.Map{"a": g.Slice{1}}, gutil.DiffResult{
+			{Op: gutil.DiffOpAdd, Path: "a.1", New: 2},
+			{Op: gutil.DiffOpAdd, Path: "b.c", New: 3},
+		})
+		t.AssertNE(err, nil)
+		t.Assert(result, nil)
+	})
+}
@@ -75,6 +75,8 @@ var (
 		"password":             {}, // format: password                              brief: Universal password format rule1: Containing any visible chars, length between 6 and 18.
 		"password2":            {}, // format: password2                             brief: Universal password format rule2: Must meet password rule1, must contain lower and upper letters and numbers.
 		"password3":            {}, // format: password3                             brief: Universal password format rule3: Must meet password rule1, must contain lower and upper letters, numbers and special chars.
+		"password-strength":    {}, // format: password-strength:level               brief: Password strength is equal or greater than level, which is 1-4 or weak/medium/strong/very-strong, see PasswordStrength.
+		"not-common-password":  {}, // format: not-common-password                   brief: Password is not in common password list, see AddCommonPasswords.
 		"postcode":             {}, // format: postcode                              brief: Postcode number.
 		"resident-id":          {}, // format: resident-id                           brief: Resident id number.
 		"bank-card":            {}, // format: bank-card                             brief: Bank card number.
@@ -101,6 +103,10 @@ var (
 		"in":                   {}, // format: in:value1,value2,...                  brief: Value should be in: value1,value2,...
 		"not-in":               {}, // format: not-in:value1,value2,...              brief: Value should not be in: value1,value2,...
 		"regex":                {}, // format: regex:pattern                         brief: Value should match custom regular expression pattern.
+		"max-size":             {}, // format: max-size:size                         brief: Uploading file size is equal or lesser than size, like: 512kb, 2m.
+		"mime-in":              {}, // format: mime-in:type1,type2,...               brief: Uploading file content type sniffed from content is in: type1,type2,..., like: image/png, image/*.
+		"ext-in":               {}, // format: ext-in:ext1,ext2,...                  brief: Uploading file extension is in: ext1,ext2,..., and its content matches the extension if it can be sniffed.
+		"image-dimensions":     {}, // format: image-dimensions:name=value,...       brief: Uploading image dimensions, the name can be: width, height, min-width, max-width, min-height, max-height, ratio.
 	}
 
 	// defaultMessages is the default error messages.
@@ -123,6 +129,8 @@ var (
 		"password":              "The {attribute} value `{value}` is not a valid password format",
 		"password2":             "The {attribute} value `{value}` is not a valid password format",
 		"password3":             "The {attribute} value `{value}` is not a valid password format",
+		"password-strength":     "The {attribute} value is not strong enough",
+		"not-common-password":   "The {attribute} value is too common",
 		"postcode":              "The {attribute} value `{value}` is not a valid postcode format",
 		"resident-id":           "The {attribute} value `{value}` is not a valid resident id number",
 		"bank-card":             "The {attribute} value `{value}` is not a valid bank card number",
@@ -150,6 +158,10 @@ var (
 		"in":                    "The {attribute} value `{value}` is not in acceptable range: {pattern}",
 		"not-in":                "The {attribute} value `{value}` must not be in range: {pattern}",
 		"regex":                 "The {attribute} value `{value}` must be in regex of: {pattern}",
+		"max-size":              "The {attribute} file size must be equal or lesser than {pattern}",
+		"mime-in":               "The {attribute} file type must be in: {pattern}",
+		"ext-in":                "The {attribute} file extension must be in: {pattern}",
+		"image-dimensions":      "The {attribute} image dimensions do not match: {pattern}",
 		internalDefaultRuleName: "The {attribute} value `{value}` is invalid",
 	}
 
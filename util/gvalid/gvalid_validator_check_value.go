@@ -478,6 +478,31 @@ func (v *Validator) doCheckSingleBuildInRules(ctx context.Context, in doCheckBui
 			match = true
 		}
 
+	// Password strength, see PasswordStrength.
+	case "password-strength":
+		match, err = v.checkPasswordStrength(valueStr, in.RulePattern)
+		if err != nil {
+			return match, err
+		}
+
+	// Password not in common password list.
+	case "not-common-password":
+		match, err = v.checkNotCommonPassword(ctx, valueStr)
+		if err != nil {
+			return match, err
+		}
+
+	// Uploading file rules, the value is usually *ghttp.UploadFile.
+	case
+		"max-size",
+		"mime-in",
+		"ext-in",
+		"image-dimensions":
+		match, err = v.checkFile(in.Value, in.RuleKey, in.RulePattern)
+		if err != nil {
+			return match, err
+		}
+
 	// Json.
 	case "json":
 		if json.Valid([]byte(valueStr)) {
@@ -0,0 +1,303 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gvalid
+
+import (
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+const (
+	// fileSniffLength is the length of file content for sniffing its content type.
+	fileSniffLength = 512
+)
+
+var (
+	fileHeaderType = reflect.TypeOf((*multipart.FileHeader)(nil))
+
+	// sniffedExtensionTypes are the content types of extensions that can be sniffed by http.DetectContentType,
+	// the file of these extensions must have the matched content for rule "ext-in".
+	sniffedExtensionTypes = map[string]string{
+		"jpg":   "image/jpeg",
+		"jpeg":  "image/jpeg",
+		"png":   "image/png",
+		"gif":   "image/gif",
+		"webp":  "image/webp",
+		"bmp":   "image/bmp",
+		"ico":   "image/x-icon",
+		"pdf":   "application/pdf",
+		"zip":   "application/zip",
+		"gz":    "application/x-gzip",
+		"rar":   "application/x-rar-compressed",
+		"mp3":   "audio/mpeg",
+		"wav":   "audio/wave",
+		"ogg":   "application/ogg",
+		"mp4":   "video/mp4",
+		"webm":  "video/webm",
+		"avi":   "video/avi",
+		"wasm":  "application/wasm",
+		"woff":  "font/woff",
+		"woff2": "font/woff2",
+		"ttf":   "font/ttf",
+		"otf":   "font/otf",
+	}
+)
+
+// getFileHeaders returns the multipart file headers of `value`, which can be *multipart.FileHeader,
+// the struct embedding it like *ghttp.UploadFile, or the slice of them like ghttp.UploadFiles.
+// It returns false if `value` is not uploading file.
+func getFileHeaders(value interface{}) ([]*multipart.FileHeader, bool) {
+	if value == nil {
+		return nil, false
+	}
+	var (
+		reflectValue = reflect.ValueOf(value)
+		headers      []*multipart.FileHeader
+	)
+	if reflectValue.Kind() == reflect.Slice || reflectValue.Kind() == reflect.Array {
+		for i := 0; i < reflectValue.Len(); i++ {
+			header := getFileHeader(reflectValue.Index(i))
+			if header == nil {
+				return nil, false
+			}
+			headers = append(headers, header)
+		}
+		return headers, len(headers) > 0
+	}
+	if header := getFileHeader(reflectValue); header != nil {
+		return []*multipart.FileHeader{header}, true
+	}
+	return nil, false
+}
+
+// getFileHeader returns the multipart file header of `reflectValue`, or nil if it is not uploading file.
+func getFileHeader(reflectValue reflect.Value) *multipart.FileHeader {
+	for reflectValue.Kind() == reflect.Interface {
+		reflectValue = reflectValue.Elem()
+	}
+	if reflectValue.Type() == fileHeaderType {
+		header, _ := reflectValue.Interface().(*multipart.FileHeader)
+		return header
+	}
+	if reflectValue.Kind() == reflect.Ptr {
+		if reflectValue.IsNil() {
+			return nil
+		}
+		reflectValue = reflectValue.Elem()
+	}
+	if reflectValue.Kind() != reflect.Struct {
+		return nil
+	}
+	// The embedded *multipart.FileHeader, like *ghttp.UploadFile.
+	for i := 0; i < reflectValue.NumField(); i++ {
+		field := reflectValue.Type().Field(i)
+		if field.Anonymous && field.Type == fileHeaderType && !reflectValue.Field(i).IsNil() {
+			return reflectValue.Field(i).Interface().(*multipart.FileHeader)
+		}
+	}
+	return nil
+}
+
+// sniffFileContentType detects and returns the content type of file `header` by its content,
+// which has no parameters like "image/png".
+func sniffFileContentType(header *multipart.FileHeader) (string, error) {
+	file, err := header.Open()
+	if err != nil {
+		return "", gerror.Wrapf(err, `open uploading file "%s" failed`, header.Filename)
+	}
+	defer file.Close()
+	buffer := make([]byte, fileSniffLength)
+	n, err := io.ReadFull(file, buffer)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", gerror.Wrapf(err, `read uploading file "%s" failed`, header.Filename)
+	}
+	contentType := http.DetectContentType(buffer[:n])
+	if pos := strings.IndexByte(contentType, ';'); pos != -1 {
+		contentType = contentType[:pos]
+	}
+	return contentType, nil
+}
+
+// checkFile checks uploading file `value` using file rules.
+func (v *Validator) checkFile(value interface{}, ruleKey, ruleVal string) (bool, error) {
+	headers, ok := getFileHeaders(value)
+	if !ok {
+		return false, nil
+	}
+	for _, header := range headers {
+		var (
+			match bool
+			err   error
+		)
+		switch ruleKey {
+		case "max-size":
+			maxSize := gfile.StrToSize(ruleVal)
+			if maxSize < 0 {
+				return false, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid file size "%s"`, ruleVal)
+			}
+			match = header.Size <= maxSize
+
+		case "mime-in":
+			match, err = v.checkFileMime(header, ruleVal)
+
+		case "ext-in":
+			match, err = v.checkFileExtension(header, ruleVal)
+
+		case "image-dimensions":
+			match, err = v.checkImageDimensions(header, ruleVal)
+		}
+		if !match || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// checkFileMime checks whether the sniffed content type of file `header` is in `ruleVal`,
+// like "image/png,image/jpeg" or "image/*".
+func (v *Validator) checkFileMime(header *multipart.FileHeader, ruleVal string) (bool, error) {
+	contentType, err := sniffFileContentType(header)
+	if err != nil {
+		return false, err
+	}
+	for _, allowed := range gstr.SplitAndTrim(ruleVal, ",") {
+		allowed = strings.ToLower(allowed)
+		if allowed == contentType {
+			return true, nil
+		}
+		if strings.HasSuffix(allowed, "/*") && strings.HasPrefix(contentType, allowed[:len(allowed)-1]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkFileExtension checks whether the extension of file `header` is in `ruleVal`, like "jpg,png,pdf".
+// The content of file must match its extension if the content type of extension can be sniffed,
+// which prevents the file of other type from being uploaded by renaming.
+func (v *Validator) checkFileExtension(header *multipart.FileHeader, ruleVal string) (bool, error) {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(header.Filename), "."))
+	if ext == "" {
+		return false, nil
+	}
+	allowed := false
+	for _, item := range gstr.SplitAndTrim(ruleVal, ",") {
+		if strings.ToLower(strings.TrimPrefix(item, ".")) == ext {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return false, nil
+	}
+	expectedType, ok := sniffedExtensionTypes[ext]
+	if !ok {
+		return true, nil
+	}
+	contentType, err := sniffFileContentType(header)
+	if err != nil {
+		return false, err
+	}
+	return contentType == expectedType, nil
+}
+
+// checkImageDimensions checks the dimensions of image file `header` with `ruleVal`, like
+// "min-width=100,max-width=1920,min-height=100,max-height=1080", "width=200,height=200" or "ratio=16/9".
+// The supported image formats are jpeg, png and gif, and the other formats registered by image.RegisterFormat.
+func (v *Validator) checkImageDimensions(header *multipart.FileHeader, ruleVal string) (bool, error) {
+	file, err := header.Open()
+	if err != nil {
+		return false, gerror.Wrapf(err, `open uploading file "%s" failed`, header.Filename)
+	}
+	defer file.Close()
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		// It is not an image.
+		return false, nil
+	}
+	for _, item := range gstr.SplitAndTrim(ruleVal, ",") {
+		array := strings.SplitN(item, "=", 2)
+		if len(array) != 2 {
+			return false, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid image dimensions rule "%s"`, item)
+		}
+		var (
+			name  = strings.TrimSpace(array[0])
+			value = strings.TrimSpace(array[1])
+		)
+		if name == "ratio" {
+			ratio, err := parseImageRatio(value)
+			if err != nil {
+				return false, err
+			}
+			if config.Height == 0 {
+				return false, nil
+			}
+			// It allows a little deviation for the rounded pixels.
+			actual := float64(config.Width) / float64(config.Height)
+			if actual < ratio*0.99 || actual > ratio*1.01 {
+				return false, nil
+			}
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return false, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid image dimensions rule "%s"`, item)
+		}
+		var match bool
+		switch name {
+		case "width":
+			match = config.Width == n
+		case "height":
+			match = config.Height == n
+		case "min-width":
+			match = config.Width >= n
+		case "max-width":
+			match = config.Width <= n
+		case "min-height":
+			match = config.Height >= n
+		case "max-height":
+			match = config.Height <= n
+		default:
+			return false, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid image dimensions rule "%s"`, item)
+		}
+		if !match {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// parseImageRatio parses and returns the image ratio like "16/9" or "1.5".
+func parseImageRatio(value string) (float64, error) {
+	array := strings.SplitN(value, "/", 2)
+	numerator, err := strconv.ParseFloat(strings.TrimSpace(array[0]), 64)
+	if err != nil {
+		return 0, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid image ratio "%s"`, value)
+	}
+	if len(array) == 1 {
+		return numerator, nil
+	}
+	denominator, err := strconv.ParseFloat(strings.TrimSpace(array[1]), 64)
+	if err != nil || denominator == 0 {
+		return 0, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid image ratio "%s"`, value)
+	}
+	return numerator / denominator, nil
+}
@@ -0,0 +1,205 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gvalid
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gres"
+)
+
+// Password strength levels for rule "password-strength:level",
+// the level can also be specified using its name like "password-strength:strong".
+const (
+	PasswordStrengthWeak       = 1 // At least 6 chars and 28 bits entropy.
+	PasswordStrengthMedium     = 2 // At least 8 chars of 2 char classes and 36 bits entropy.
+	PasswordStrengthStrong     = 3 // At least 10 chars of 3 char classes and 60 bits entropy.
+	PasswordStrengthVeryStrong = 4 // At least 12 chars of 4 char classes and 80 bits entropy.
+)
+
+// CommonPasswordChecker checks whether `password` is a common password for rule "not-common-password",
+// like checking against the breached password service.
+type CommonPasswordChecker func(ctx context.Context, password string) (bool, error)
+
+// passwordStrengthRequirement is the requirement of a password strength level.
+type passwordStrengthRequirement struct {
+	Length  int     // Min length.
+	Classes int     // Min char classes, which are lower letters, upper letters, digits and symbols.
+	Entropy float64 // Min entropy bits.
+}
+
+var (
+	// passwordStrengthRequirements are the requirements of levels from PasswordStrengthWeak.
+	passwordStrengthRequirements = []passwordStrengthRequirement{
+		{Length: 6, Classes: 1, Entropy: 28},
+		{Length: 8, Classes: 2, Entropy: 36},
+		{Length: 10, Classes: 3, Entropy: 60},
+		{Length: 12, Classes: 4, Entropy: 80},
+	}
+
+	// passwordStrengthNames are the names of password strength levels.
+	passwordStrengthNames = map[string]int{
+		"weak":        PasswordStrengthWeak,
+		"medium":      PasswordStrengthMedium,
+		"strong":      PasswordStrengthStrong,
+		"very-strong": PasswordStrengthVeryStrong,
+	}
+
+	commonPasswordMu      sync.RWMutex
+	commonPasswordMap     map[string]struct{}   // Common passwords in lower case, lazily initialized with builtinCommonPasswords.
+	commonPasswordChecker CommonPasswordChecker // Custom checker, see SetCommonPasswordChecker.
+)
+
+// PasswordStrength estimates and returns the strength level of `password`, which is from 0 to
+// PasswordStrengthVeryStrong. The entropy is estimated by the char classes, in which the repeated
+// and sequential chars like "aaa" and "123" do not count.
+func PasswordStrength(password string) int {
+	var (
+		runes     = []rune(password)
+		length    = len(runes)
+		classes   = 0
+		poolSize  = 0
+		effective = 0
+		hasLower  bool
+		hasUpper  bool
+		hasDigit  bool
+		hasSymbol bool
+		hasOther  bool
+	)
+	for i, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			hasLower = true
+		case r >= 'A' && r <= 'Z':
+			hasUpper = true
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		case r < unicode.MaxASCII:
+			hasSymbol = true
+		default:
+			hasOther = true
+		}
+		if i > 0 && (r == runes[i-1] || r == runes[i-1]+1 || r == runes[i-1]-1) {
+			continue
+		}
+		effective++
+	}
+	for _, item := range []struct {
+		has  bool
+		size int
+	}{{hasLower, 26}, {hasUpper, 26}, {hasDigit, 10}, {hasSymbol, 33}, {hasOther, 100}} {
+		if item.has {
+			classes++
+			poolSize += item.size
+		}
+	}
+	if poolSize == 0 {
+		return 0
+	}
+	entropy := float64(effective) * math.Log2(float64(poolSize))
+	level := 0
+	for i, requirement := range passwordStrengthRequirements {
+		if length < requirement.Length || classes < requirement.Classes || entropy < requirement.Entropy {
+			break
+		}
+		level = i + 1
+	}
+	return level
+}
+
+// AddCommonPasswords adds `passwords` to the common password list of rule "not-common-password".
+// The passwords are compared case-insensitively.
+func AddCommonPasswords(passwords ...string) {
+	commonPasswordMu.Lock()
+	defer commonPasswordMu.Unlock()
+	initCommonPasswordMap()
+	for _, password := range passwords {
+		if password = strings.TrimSpace(password); password != "" {
+			commonPasswordMap[strings.ToLower(password)] = struct{}{}
+		}
+	}
+}
+
+// LoadCommonPasswords loads the common passwords from file `path`, one password each line,
+// and adds them to the common password list of rule "not-common-password".
+// The file can be a resource file, so a larger list like the top 10k passwords can be packed into binary.
+func LoadCommonPasswords(path string) error {
+	var content string
+	if gres.Contains(path) {
+		content = string(gres.GetContent(path))
+	} else {
+		if !gfile.Exists(path) {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, `common password file "%s" does not exist`, path)
+		}
+		content = gfile.GetContents(path)
+	}
+	AddCommonPasswords(strings.Split(content, "\n")...)
+	return nil
+}
+
+// SetCommonPasswordChecker sets the custom checker for rule "not-common-password", which is called
+// after the password is not found in the common password list.
+func SetCommonPasswordChecker(checker CommonPasswordChecker) {
+	commonPasswordMu.Lock()
+	defer commonPasswordMu.Unlock()
+	commonPasswordChecker = checker
+}
+
+// checkPasswordStrength checks whether `value` meets the password strength level `ruleVal`.
+func (v *Validator) checkPasswordStrength(value, ruleVal string) (bool, error) {
+	level := PasswordStrengthMedium
+	if ruleVal != "" {
+		if n, ok := passwordStrengthNames[ruleVal]; ok {
+			level = n
+		} else if n, err := strconv.Atoi(ruleVal); err == nil && n >= PasswordStrengthWeak && n <= PasswordStrengthVeryStrong {
+			level = n
+		} else {
+			return false, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid password strength level "%s"`, ruleVal)
+		}
+	}
+	return PasswordStrength(value) >= level, nil
+}
+
+// checkNotCommonPassword checks whether `value` is not a common password.
+func (v *Validator) checkNotCommonPassword(ctx context.Context, value string) (bool, error) {
+	commonPasswordMu.Lock()
+	initCommonPasswordMap()
+	_, found := commonPasswordMap[strings.ToLower(value)]
+	checker := commonPasswordChecker
+	commonPasswordMu.Unlock()
+	if found {
+		return false, nil
+	}
+	if checker != nil {
+		common, err := checker(ctx, value)
+		if err != nil {
+			return false, err
+		}
+		return !common, nil
+	}
+	return true, nil
+}
+
+// initCommonPasswordMap initializes the common password map with the builtin common passwords.
+// It should be called with commonPasswordMu locked.
+func initCommonPasswordMap() {
+	if commonPasswordMap != nil {
+		return
+	}
+	commonPasswordMap = make(map[string]struct{})
+	for _, password := range strings.Fields(builtinCommonPasswords) {
+		commonPasswordMap[password] = struct{}{}
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gvalid
+
+// builtinCommonPasswords are the most common passwords in the public breached password lists, in lower case.
+// A larger list like the top 10k passwords can be added by LoadCommonPasswords.
+const builtinCommonPasswords = `
+123456 password 12345678 qwerty 123456789 12345 1234 111111 1234567 dragon
+123123 baseball abc123 football monkey letmein 696969 shadow master 666666
+qwertyuiop 123321 mustang 1234567890 michael 654321 superman 1qaz2wsx 7777777 121212
+000000 qazwsx 123qwe killer trustno1 jordan jennifer zxcvbnm asdfgh hunter
+buster soccer harley batman andrew tigger sunshine iloveyou 2000 charlie
+robert thomas hockey ranger daniel starwars klaster 112233 george computer
+michelle jessica pepper 1111 zxcvbn 555555 11111111 131313 freedom 777777
+pass maggie 159753 aaaaaa ginger princess joshua cheese amanda summer
+love ashley nicole chelsea biteme matthew access yankees 987654321 dallas
+austin thunder taylor matrix mobilemail
+william corvette hello martin heather secret merlin diamond 1234qwer gfhjkm
+hammer silver 222222 88888888 anthony justin test bailey q1w2e3r4t5 patrick
+internet scooter orange 11111 golfer cookie richard samantha bigdog guitar
+jackson whatever mickey chicken sparky snoopy maverick phoenix camaro peanut
+morgan welcome falcon cowboy ferrari samsung andrea smokey steelers joseph mercedes
+dakota arsenal eagles melissa boomer booboo spider nascar monster tigers yellow
+xxxxxx 123123123 gateway marina diablo bulldog qwer1234 compaq purple hardcore
+banana junior hannah 123654 porsche lakers iceman money cowboys 987654
+london tennis 999999 ncc1701 coffee scooby 0000 miller boston q1w2e3r4
+brandon yamaha chester mother forever johnny edward 333333 oliver redsox
+player nikita knight fender barney midnight please brandy chicago badboy slayer
+rangers charles angel flower rabbit wizard jasper enter rachel
+chris steven winner adidas victoria natasha 1q2w3e4r jasmine winter prince
+marine ghbdtn fishing cocacola casper james 232323 raiders 888888
+marlboro gandalf asdfasdf crystal 87654321 12344321 golden 8675309 panther lauren
+angela spanky thx1138 angels madison winston shannon mike toyota
+blowjob jordan23 canada sophie apples tiger qweqwe bubbles dennis magic
+vikings 12341234 1qazxsw2 1q2w3e4r5t 1q2w3e admin administrator root toor
+passw0rd p@ssw0rd p@ssword password1 password12 password123 password1234 passwd qwerty123 qwerty1
+123abc abcd1234 a123456 aa123456 abc12345 1qaz2wsx3edc zaq12wsx zaq1zaq1 asdf1234
+letmein1 welcome1 welcome123 iloveyou1 iloveu lovely loveme princess1 sunshine1 football1
+baseball1 monkey1 dragon1 master1 shadow1 michael1 superman1 batman1 hello123 hello1
+changeme default guest user login test123 test1 testing demo temp
+qazwsxedc asdfghjkl zxcvbnm1 1234abcd abcdef abcdefg abcdefgh 12345a 123456a
+a12345 a1b2c3 a1b2c3d4 qwe123 qwerty12 qwertz azerty 147258369 147258 741852963
+159357 789456123 456789 4321 123654789 1111111 11223344 121314 7654321 1122334455
+football123 soccer1 hockey1 jesus jesus1 blessed god christ faith heaven
+samsung1 iphone apple google yahoo hotmail facebook twitter instagram linkedin
+minecraft pokemon naruto superstar rockstar starwars1 lol123 qwerty1234 mynoob flower1
+000000000 0987654321 9876543210 123456789a 1234567a 12345678a 123456789q q123456 qwerty123456
+zxcv1234 zxc123 asd123 asdasd asdfg qweasd qweasdzxc 1qaz2wsx! 123qweasd 123qweasdzxc
+`
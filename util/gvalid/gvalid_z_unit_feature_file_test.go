@@ -0,0 +1,107 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gvalid_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"testing"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// newFileHeader creates and returns a multipart file header of `filename` and `content`.
+func newFileHeader(t *gtest.T, filename string, content []byte) *multipart.FileHeader {
+	var (
+		buffer = bytes.NewBuffer(nil)
+		writer = multipart.NewWriter(buffer)
+	)
+	part, err := writer.CreateFormFile("file", filename)
+	t.AssertNil(err)
+	_, err = part.Write(content)
+	t.AssertNil(err)
+	t.AssertNil(writer.Close())
+	form, err := multipart.NewReader(buffer, writer.Boundary()).ReadForm(1024 * 1024)
+	t.AssertNil(err)
+	return form.File["file"][0]
+}
+
+// newPngContent creates and returns the content of png image of `width` and `height`.
+func newPngContent(t *gtest.T, width, height int) []byte {
+	buffer := bytes.NewBuffer(nil)
+	t.AssertNil(png.Encode(buffer, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buffer.Bytes()
+}
+
+func Test_File_MaxSize(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		file := newFileHeader(t, "a.txt", bytes.Repeat([]byte("a"), 2048))
+		t.AssertNil(g.Validator().Data(file).Rules("max-size:2k").Run(ctx))
+		t.Assert(
+			g.Validator().Data(file).Rules("max-size:1k").Run(ctx),
+			"The file size must be equal or lesser than 1k",
+		)
+		t.AssertNE(g.Validator().Data("a.txt").Rules("max-size:1k").Run(ctx), nil)
+	})
+}
+
+func Test_File_MimeIn(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			image = newFileHeader(t, "a.png", newPngContent(t, 10, 10))
+			text  = newFileHeader(t, "b.png", []byte("text content"))
+		)
+		t.AssertNil(g.Validator().Data(image).Rules("mime-in:image/jpeg,image/png").Run(ctx))
+		t.AssertNil(g.Validator().Data(image).Rules("mime-in:image/*").Run(ctx))
+		t.AssertNE(g.Validator().Data(text).Rules("mime-in:image/*").Run(ctx), nil)
+		t.AssertNil(g.Validator().Data(text).Rules("mime-in:text/plain").Run(ctx))
+		t.AssertNil(g.Validator().Data([]*multipart.FileHeader{image, image}).Rules("mime-in:image/png").Run(ctx))
+		t.AssertNE(g.Validator().Data([]*multipart.FileHeader{image, text}).Rules("mime-in:image/png").Run(ctx), nil)
+	})
+}
+
+func Test_File_ExtIn(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			image   = newFileHeader(t, "a.PNG", newPngContent(t, 10, 10))
+			renamed = newFileHeader(t, "b.jpg", []byte("MZ executable content"))
+			text    = newFileHeader(t, "c.txt", []byte("text content"))
+		)
+		t.AssertNil(g.Validator().Data(image).Rules("ext-in:jpg,png").Run(ctx))
+		t.Assert(
+			g.Validator().Data(image).Rules("ext-in:jpg,gif").Run(ctx),
+			"The file extension must be in: jpg,gif",
+		)
+		t.AssertNE(g.Validator().Data(renamed).Rules("ext-in:jpg,png").Run(ctx), nil)
+		t.AssertNil(g.Validator().Data(text).Rules("ext-in:txt,csv").Run(ctx))
+	})
+}
+
+func Test_File_ImageDimensions(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			image = newFileHeader(t, "a.png", newPngContent(t, 160, 90))
+			text  = newFileHeader(t, "b.png", []byte("text content"))
+		)
+		t.AssertNil(g.Validator().Data(image).Rules("image-dimensions:min-width=100,max-width=200,max-height=100").Run(ctx))
+		t.AssertNil(g.Validator().Data(image).Rules("image-dimensions:width=160,height=90").Run(ctx))
+		t.AssertNil(g.Validator().Data(image).Rules("image-dimensions:ratio=16/9").Run(ctx))
+		t.AssertNE(g.Validator().Data(image).Rules("image-dimensions:ratio=1").Run(ctx), nil)
+		t.Assert(
+			g.Validator().Data(image).Rules("image-dimensions:min-height=100").Run(ctx),
+			"The image dimensions do not match: min-height=100",
+		)
+		t.AssertNE(g.Validator().Data(text).Rules("image-dimensions:min-width=1").Run(ctx), nil)
+		t.Assert(
+			g.Validator().Data(image).Rules("image-dimensions:unknown=1").Run(ctx),
+			`invalid image dimensions rule "unknown=1"`,
+		)
+	})
+}
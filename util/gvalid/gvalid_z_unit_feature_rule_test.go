@@ -7,6 +7,7 @@
 package gvalid_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -14,8 +15,11 @@ import (
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+	"github.com/gogf/gf/v2/util/gvalid"
 )
 
 var (
@@ -1105,3 +1109,65 @@ func Test_Bail(t *testing.T) {
 		t.Assert(err.Error(), "min number is 1")
 	})
 }
+
+func Test_PasswordStrength(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gvalid.PasswordStrength(""), 0)
+		t.Assert(gvalid.PasswordStrength("aaaaaaaaaaaa"), 0)
+		t.Assert(gvalid.PasswordStrength("12345678"), 0)
+		t.Assert(gvalid.PasswordStrength("goframe"), gvalid.PasswordStrengthWeak)
+		t.Assert(gvalid.PasswordStrength("goframe2x"), gvalid.PasswordStrengthMedium)
+		t.Assert(gvalid.PasswordStrength("GoFrame2x7k"), gvalid.PasswordStrengthStrong)
+		t.Assert(gvalid.PasswordStrength("GoFrame#2x7k!Q"), gvalid.PasswordStrengthVeryStrong)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			rule = "password-strength:strong"
+			err1 = g.Validator().Data("goframe2x").Rules(rule).Run(ctx)
+			err2 = g.Validator().Data("GoFrame2x7k").Rules(rule).Run(ctx)
+			err3 = g.Validator().Data("goframe").Rules("password-strength").Run(ctx)
+			err4 = g.Validator().Data("goframe2x").Rules("password-strength").Run(ctx)
+			err5 = g.Validator().Data("GoFrame2x7k").Rules("password-strength:4").Run(ctx)
+			err6 = g.Validator().Data("GoFrame2x7k").Rules("password-strength:unknown").Run(ctx)
+		)
+		t.Assert(err1, "The value is not strong enough")
+		t.AssertNil(err2)
+		t.AssertNE(err3, nil)
+		t.AssertNil(err4)
+		t.AssertNE(err5, nil)
+		t.Assert(err6, `invalid password strength level "unknown"`)
+	})
+}
+
+func Test_NotCommonPassword(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		rule := "not-common-password"
+		t.Assert(g.Validator().Data("password").Rules(rule).Run(ctx), "The value is too common")
+		t.Assert(g.Validator().Data("P@ssw0rd").Rules(rule).Run(ctx), "The value is too common")
+		t.AssertNil(g.Validator().Data("GoFrame2x7k").Rules(rule).Run(ctx))
+
+		gvalid.AddCommonPasswords("GoFrame2x7k")
+		t.AssertNE(g.Validator().Data("goframe2x7k").Rules(rule).Run(ctx), nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			rule = "not-common-password"
+			path = gfile.Temp(guid.S())
+		)
+		defer gfile.Remove(path)
+		t.AssertNil(gfile.PutContents(path, "goframe@2022\r\ngoframe@2023\n"))
+		t.AssertNil(gvalid.LoadCommonPasswords(path))
+		t.AssertNE(g.Validator().Data("goframe@2022").Rules(rule).Run(ctx), nil)
+		t.AssertNE(g.Validator().Data("goframe@2023").Rules(rule).Run(ctx), nil)
+		t.AssertNE(gvalid.LoadCommonPasswords(path+".none"), nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		rule := "not-common-password"
+		gvalid.SetCommonPasswordChecker(func(ctx context.Context, password string) (bool, error) {
+			return password == "breached-password", nil
+		})
+		defer gvalid.SetCommonPasswordChecker(nil)
+		t.AssertNE(g.Validator().Data("breached-password").Rules(rule).Run(ctx), nil)
+		t.AssertNil(g.Validator().Data("GoFrame#2x7k!Q").Rules(rule).Run(ctx))
+	})
+}